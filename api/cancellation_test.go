@@ -0,0 +1,107 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+)
+
+// runawayProgram contient une règle qui s'alimente elle-même sans fin
+const runawayProgram = `type Tick(n: number)
+type Reading(#id: string, value: number)
+
+rule loop : {t: Tick} / t.n >= 0 ==> Insert(Tick(n: t.n + 1))
+`
+
+func TestPipeline_IngestStringContextDeadline(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	config.MaxCascadeDepth = 1 << 30 // Seule l'échéance interrompt la cascade
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(runawayProgram + `Reading(id: "r1", value: 1)`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := pipeline.IngestStringContext(ctx, `Tick(n: 0)`)
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeCanceled {
+		t.Fatalf("IngestStringContext() error = %v, want ErrorTypeCanceled", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error %v should wrap context.DeadlineExceeded", err)
+	}
+
+	// Les Tick insérés par la cascade ont été annulés, le pipeline reste utilisable
+	facts := pipeline.MemorySnapshot().FactsByType
+	if facts["Tick"] != 0 || facts["Reading"] != 1 {
+		t.Errorf("facts = %v, want only Reading~r1", facts)
+	}
+	if _, err := pipeline.IngestString(`Reading(id: "r2", value: 2)`); err != nil {
+		t.Errorf("IngestString() after cancellation error = %v", err)
+	}
+}
+
+func TestPipeline_IngestFileContextCanceled(t *testing.T) {
+	pipeline := NewPipeline()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pipeline.IngestStringContext(ctx, runawayProgram)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("IngestStringContext() error = %v, want canceled", err)
+	}
+}
+
+func TestPipeline_SubmitFactContext(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	config.MaxCascadeDepth = 1 << 30 // Seule l'échéance interrompt la cascade
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(runawayProgram); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+	reading := &rete.Fact{ID: "r1", Type: "Reading", Fields: map[string]interface{}{"id": "r1", "value": 1.0}}
+	if err := pipeline.SubmitFactContext(context.Background(), reading); err != nil {
+		t.Fatalf("SubmitFactContext() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := pipeline.SubmitFactContext(ctx, &rete.Fact{ID: "t0", Type: "Tick", Fields: map[string]interface{}{"n": 0.0}})
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeCanceled {
+		t.Fatalf("SubmitFactContext() error = %v, want ErrorTypeCanceled", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error %v should wrap context.DeadlineExceeded", err)
+	}
+
+	// Le fait soumis et les Tick insérés par la cascade ont été annulés
+	facts := pipeline.MemorySnapshot().FactsByType
+	if facts["Tick"] != 0 || facts["Reading"] != 1 {
+		t.Errorf("facts = %v, want only Reading~r1", facts)
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	reading2 := &rete.Fact{ID: "r2", Type: "Reading", Fields: map[string]interface{}{"id": "r2", "value": 2.0}}
+	if err := pipeline.SubmitFactContext(canceled, reading2); !errors.Is(err, context.Canceled) {
+		t.Errorf("SubmitFactContext() error = %v, want canceled", err)
+	}
+	if err := pipeline.SubmitFact(reading2); err != nil {
+		t.Errorf("SubmitFact() after cancellation error = %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const restockProgram = `type Product(#id: string, stock: number)
+
+rule restock : {p: Product} / p.stock < 10 ==> Update(p, {stock: p.stock - 1})
+`
+
+func TestPipeline_CascadeLimit(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	config.MaxCascadeDepth = 10
+	pipeline := NewPipelineWithConfig(config)
+
+	_, err := pipeline.IngestString(runawayProgram + `Tick(n: 0)`)
+	var limitErr *rete.CascadeLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, rete.ErrCascadeLimitExceeded) {
+		t.Fatalf("IngestString() error = %v, want cascade limit error", err)
+	}
+	if limitErr.Limit != rete.CascadeLimitDepth || limitErr.Rule != "loop" || !limitErr.Loop {
+		t.Errorf("error = %+v", limitErr)
+	}
+
+	// Ingestion annulée, limites conservées après Reset
+	if facts := pipeline.MemorySnapshot().FactsByType; facts["Tick"] != 0 {
+		t.Errorf("facts = %v, want no Tick", facts)
+	}
+	pipeline.Reset()
+	if limits := pipeline.network.CascadeLimits(); limits.MaxDepth != 10 {
+		t.Errorf("CascadeLimits() after Reset = %+v, want MaxDepth 10", limits)
+	}
+}
+
+func TestPipeline_SelfTriggeringRules(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	if _, err := pipeline.IngestString(restockProgram); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	warnings := pipeline.SelfTriggeringRules()
+	if len(warnings) != 1 || warnings[0].Rule != "restock" || strings.Join(warnings[0].Fields, ",") != "stock" {
+		t.Fatalf("SelfTriggeringRules() = %+v, want restock on stock", warnings)
+	}
+	if msg := warnings[0].String(); !strings.Contains(msg, "Update(p) modifie stock") {
+		t.Errorf("String() = %q", msg)
+	}
+}
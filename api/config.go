@@ -4,7 +4,12 @@
 
 package api
 
-import "time"
+import (
+	"io"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+)
 
 // LogLevel représente le niveau de logging
 type LogLevel int
@@ -42,6 +47,19 @@ const (
 	RetentionDuration  RetentionPolicy = "duration"
 )
 
+// FactLimitPolicy définit le comportement lorsque MaxFactsInMemory (ou un quota
+// de MaxFactsPerType) est atteint
+type FactLimitPolicy string
+
+const (
+	// FactLimitReject refuse les nouveaux faits (erreur rete.ErrFactLimitExceeded)
+	FactLimitReject FactLimitPolicy = "reject"
+	// FactLimitEvictOldest rétracte les plus anciens faits du même type
+	FactLimitEvictOldest FactLimitPolicy = "evict-oldest"
+	// FactLimitBlock bloque la soumission jusqu'à ce que de la place se libère
+	FactLimitBlock FactLimitPolicy = "block"
+)
+
 // XupleSpaceDefaults contient les valeurs par défaut pour les xuple-spaces
 type XupleSpaceDefaults struct {
 	Selection         SelectionPolicy
@@ -55,10 +73,17 @@ type XupleSpaceDefaults struct {
 type Config struct {
 	LogLevel           LogLevel
 	EnableMetrics      bool
-	MaxFactsInMemory   int
+	MaxFactsInMemory   int             // Nombre maximal de faits en mémoire de travail (0 : illimité)
+	MaxFactsPerType    map[string]int  // Quotas par type de fait (absent ou 0 : pas de quota)
+	FactLimitPolicy    FactLimitPolicy // Politique appliquée aux limites (défaut : reject)
+	FactLimitTimeout   time.Duration   // Attente maximale avec FactLimitBlock (0 : 30s)
+	MaxCascadeDepth    int             // Actions de règles imbriquées au plus (0 : rete.DefaultMaxCascadeDepth)
+	MaxActivations     int             // Activations de règles par ingestion (0 : illimité)
+	MaxRuleActivations int             // Activations d'une même règle par ingestion (0 : illimité)
 	XupleSpaceDefaults *XupleSpaceDefaults
 	EnableTransactions bool
 	TransactionTimeout time.Duration
+	Output             io.Writer // Sortie des actions Print et Log et des traces du moteur (nil : os.Stdout)
 }
 
 // DefaultConfig retourne la configuration par défaut
@@ -95,6 +120,52 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for factType, quota := range c.MaxFactsPerType {
+		if quota < 0 {
+			return &ConfigError{
+				Field:   "MaxFactsPerType." + factType,
+				Message: "ne peut pas être négatif",
+			}
+		}
+	}
+
+	switch c.FactLimitPolicy {
+	case "", FactLimitReject, FactLimitEvictOldest, FactLimitBlock:
+	default:
+		return &ConfigError{
+			Field:   "FactLimitPolicy",
+			Message: "valeur invalide: " + string(c.FactLimitPolicy),
+		}
+	}
+
+	if c.FactLimitTimeout < 0 {
+		return &ConfigError{
+			Field:   "FactLimitTimeout",
+			Message: "ne peut pas être négatif",
+		}
+	}
+
+	if c.MaxCascadeDepth < 0 {
+		return &ConfigError{
+			Field:   "MaxCascadeDepth",
+			Message: "ne peut pas être négatif",
+		}
+	}
+
+	if c.MaxActivations < 0 {
+		return &ConfigError{
+			Field:   "MaxActivations",
+			Message: "ne peut pas être négatif",
+		}
+	}
+
+	if c.MaxRuleActivations < 0 {
+		return &ConfigError{
+			Field:   "MaxRuleActivations",
+			Message: "ne peut pas être négatif",
+		}
+	}
+
 	if c.XupleSpaceDefaults != nil {
 		if err := c.validateXupleSpaceDefaults(); err != nil {
 			return err
@@ -104,6 +175,25 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// factLimits convertit les limites de faits de la configuration pour le réseau RETE
+func (c *Config) factLimits() rete.FactLimits {
+	return rete.FactLimits{
+		MaxFacts:        c.MaxFactsInMemory,
+		MaxFactsPerType: c.MaxFactsPerType,
+		Policy:          rete.FactLimitPolicy(c.FactLimitPolicy),
+		BlockTimeout:    c.FactLimitTimeout,
+	}
+}
+
+// cascadeLimits convertit les garde-fous de cascade de la configuration pour le réseau RETE
+func (c *Config) cascadeLimits() rete.CascadeLimits {
+	return rete.CascadeLimits{
+		MaxDepth:           c.MaxCascadeDepth,
+		MaxActivations:     c.MaxActivations,
+		MaxRuleActivations: c.MaxRuleActivations,
+	}
+}
+
 func (c *Config) validateXupleSpaceDefaults() error {
 	defaults := c.XupleSpaceDefaults
 
@@ -263,3 +263,30 @@ func TestLogLevelConstants(t *testing.T) {
 
 	t.Log("✅ Constantes niveau log correctes")
 }
+
+func TestConfigValidate_InvalidFactLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  string
+		mutate func(*Config)
+	}{
+		{"negative type quota", "MaxFactsPerType.Reading", func(c *Config) { c.MaxFactsPerType = map[string]int{"Reading": -1} }},
+		{"unknown policy", "FactLimitPolicy", func(c *Config) { c.FactLimitPolicy = "drop" }},
+		{"negative timeout", "FactLimitTimeout", func(c *Config) { c.FactLimitTimeout = -1 }},
+		{"negative cascade depth", "MaxCascadeDepth", func(c *Config) { c.MaxCascadeDepth = -1 }},
+		{"negative activations", "MaxActivations", func(c *Config) { c.MaxActivations = -1 }},
+		{"negative rule activations", "MaxRuleActivations", func(c *Config) { c.MaxRuleActivations = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.mutate(config)
+			err := config.Validate()
+			configErr, ok := err.(*ConfigError)
+			if !ok || configErr.Field != tt.field {
+				t.Errorf("Validate() error = %v, want *ConfigError on %s", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+
+	"github.com/treivax/tsd/tsdio"
+	"github.com/treivax/tsd/tsdtable"
+)
+
+// IngestDecisionTable compile une table de décision CSV (voir le package
+// tsdtable) et ingère les règles générées, une par ligne. Les types et actions
+// qu'elle utilise doivent être déjà ingérés. Une table en erreur (cellule
+// invalide, lignes qui se chevauchent en politique unique...) n'est pas
+// ingérée ; les avertissements, comme les combinaisons de valeurs qu'aucune
+// ligne ne couvre, sont retournés avec le résultat.
+func (p *Pipeline) IngestDecisionTable(filename string) (*Result, []tsdio.Diagnostic, error) {
+	compilation, err := tsdtable.CompileFile(filename)
+	if err != nil {
+		var diagnostic *tsdio.Diagnostic
+		if compilation == nil && !errors.As(err, &diagnostic) {
+			return nil, nil, &Error{
+				Type:    ErrorTypeIO,
+				Message: "fichier inaccessible",
+				Cause:   err,
+			}
+		}
+		var diagnostics []tsdio.Diagnostic
+		if compilation != nil {
+			diagnostics = compilation.Diagnostics
+		} else {
+			diagnostics = []tsdio.Diagnostic{*diagnostic}
+		}
+		return nil, diagnostics, &Error{
+			Type:    ErrorTypeValidation,
+			Message: "table de décision invalide",
+			Cause:   err,
+		}
+	}
+
+	result, err := p.IngestString(compilation.Source())
+	if err != nil {
+		return nil, compilation.Diagnostics, err
+	}
+	return result, compilation.Diagnostics, nil
+}
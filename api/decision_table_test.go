@@ -0,0 +1,81 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPipeline_IngestDecisionTable(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(`type Customer(#id: string, tier: string)
+type Order(#id: string, customer: Customer, total: number)
+type Discount(#order: string, rate: number)
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pricing.csv")
+	table := `# hit: first
+# when: {o: Order, c: Customer} / o.customer == c
+o.total >=,c.tier,"Insert(Discount(order: o.id, rate: ?))"
+1000,gold,20
+1000,,10
+,,0
+`
+	if err := os.WriteFile(path, []byte(table), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, diagnostics, err := pipeline.IngestDecisionTable(path); err != nil || len(diagnostics) != 0 {
+		t.Fatalf("IngestDecisionTable() = %v, %v", diagnostics, err)
+	}
+
+	if _, err := pipeline.IngestString(`gold = Customer(id: "gold", tier: "gold")
+silver = Customer(id: "silver", tier: "silver")
+Order(id: "o1", customer: gold, total: 1500)
+Order(id: "o2", customer: silver, total: 1500)
+Order(id: "o3", customer: gold, total: 50)
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	// Politique first : une seule remise par commande, celle de la première ligne applicable
+	for id, rate := range map[string]float64{"o1": 20, "o2": 10, "o3": 0} {
+		fact := pipeline.network.Storage.GetFact("Discount~" + id)
+		if fact == nil || fact.Fields["rate"] != rate {
+			t.Errorf("Discount %s = %v, want rate %v", id, fact, rate)
+		}
+	}
+}
+
+func TestPipeline_IngestDecisionTable_Invalid(t *testing.T) {
+	pipeline := NewPipeline()
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "overlap.csv")
+	table := "# when: {o: Order}\no.total >,\"notify(o.id, ?)\"\n100,5\n1000,10\n"
+	if err := os.WriteFile(path, []byte(table), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	_, diagnostics, err := pipeline.IngestDecisionTable(path)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+		t.Fatalf("IngestDecisionTable() error = %v, attendu une erreur de validation", err)
+	}
+	if len(diagnostics) == 0 || diagnostics[len(diagnostics)-1].Span.Line != 4 {
+		t.Errorf("diagnostics = %v", diagnostics)
+	}
+
+	_, _, err = pipeline.IngestDecisionTable(filepath.Join(dir, "missing.csv"))
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeIO {
+		t.Errorf("IngestDecisionTable() error = %v, attendu une erreur d'accès au fichier", err)
+	}
+}
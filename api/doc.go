@@ -58,6 +58,9 @@ config := &api.Config{
 LogLevel:          api.LogLevelDebug,
 EnableMetrics:     true,
 MaxFactsInMemory:  100000,
+MaxFactsPerType:   map[string]int{"Reading": 10000},
+FactLimitPolicy:   api.FactLimitEvictOldest,
+MaxCascadeDepth:   50,
 XupleSpaceDefaults: &api.XupleSpaceDefaults{
 Selection:   api.SelectionFIFO,
 Consumption: api.ConsumptionOnce,
@@ -4,7 +4,12 @@
 
 package api
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
 
 // Error représente une erreur de l'API
 type Error struct {
@@ -23,6 +28,8 @@ const (
 	ErrorTypeConfig     ErrorType = "config"
 	ErrorTypeIO         ErrorType = "io"
 	ErrorTypeInternal   ErrorType = "internal"
+	// ErrorTypeCanceled : ingestion interrompue par l'annulation ou l'échéance de son contexte
+	ErrorTypeCanceled ErrorType = "canceled"
 )
 
 func (e *Error) Error() string {
@@ -36,6 +43,16 @@ func (e *Error) Unwrap() error {
 	return e.Cause
 }
 
+// Diagnostics retourne les diagnostics localisés de l'erreur (erreurs de
+// syntaxe, de validation). Une erreur sans position donne un diagnostic
+// sans Span.
+func (e *Error) Diagnostics() []constraint.Diagnostic {
+	if e.Cause == nil {
+		return []constraint.Diagnostic{{Severity: tsdio.SeverityError, Code: string(e.Type), Message: e.Message}}
+	}
+	return constraint.Diagnostics(e.Cause)
+}
+
 // ParseError représente une erreur de parsing avec position
 type ParseError struct {
 	Filename string
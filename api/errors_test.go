@@ -5,8 +5,13 @@
 package api
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/treivax/tsd/constraint"
 )
 
 func TestErrorTypes(t *testing.T) {
@@ -196,3 +201,37 @@ func TestXupleSpaceError_WithCause(t *testing.T) {
 
 	t.Log("✅ XupleSpaceError avec cause fonctionne")
 }
+
+func TestError_Diagnostics(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules.tsd")
+	program := "type Order(#id: string, total: number)\naction notify(id: string)\nrule big : {o: Order} / o.totl > 100 ==> notify(o.id)\n"
+	if err := os.WriteFile(file, []byte(program), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	_, err := NewPipelineWithConfig(config).IngestFile(file)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("IngestFile() error = %v, want *Error", err)
+	}
+
+	diagnostics := apiErr.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("Diagnostics() = %+v, want 1", diagnostics)
+	}
+	d := diagnostics[0]
+	if d.Code != constraint.DiagnosticCodeUnknownField || d.Span == nil || d.Span.File != file || d.Span.Line != 3 || d.Span.Column != 25 {
+		t.Errorf("diagnostic = %+v (span %v), want unknown-field at %s:3:25", d, d.Span, file)
+	}
+	if d.SuggestedFix != "remplacer 'totl' par 'total'" {
+		t.Errorf("SuggestedFix = %q", d.SuggestedFix)
+	}
+
+	noCause := &Error{Type: ErrorTypeConfig, Message: "invalide"}
+	if got := noCause.Diagnostics(); len(got) != 1 || got[0].Code != "config" || got[0].Span != nil {
+		t.Errorf("Diagnostics() without cause = %+v", got)
+	}
+}
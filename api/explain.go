@@ -0,0 +1,59 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/treivax/tsd/rete"
+)
+
+// Explain explique pourquoi une règle s'est déclenchée, ou non, pour les faits
+// donnés (identifiants internes Type~id, ou variable=Type~id).
+//
+// L'explication détaille chaque condition alpha et de jointure de la règle avec
+// les valeurs évaluées, les résultats NOT/EXISTS/agrégats face à leur seuil et,
+// si la règle s'est déclenchée, la provenance de ses activations.
+func (p *Pipeline) Explain(ruleID string, factIDs ...string) (*rete.Explanation, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	explanation, err := p.network.Explain(ruleID, factIDs...)
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: fmt.Sprintf("explication de la règle '%s' impossible", ruleID),
+			Cause:   err,
+		}
+	}
+	return explanation, nil
+}
+
+// SelfTriggeringRules retourne les règles du programme chargé dont l'action
+// modifie des faits filtrés par leurs propres conditions (Update d'un champ
+// qu'elles lisent, Insert d'un type qu'elles filtrent), et qui risquent donc
+// de se redéclencher en boucle.
+func (p *Pipeline) SelfTriggeringRules() []rete.SelfTriggerWarning {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.network.SelfTriggeringRules()
+}
+
+// NetworkGraph retourne la topologie du réseau compilé (nœuds, arcs, partage
+// et taille des mémoires), restreinte aux règles données s'il y en a.
+func (p *Pipeline) NetworkGraph(rules ...string) (*rete.NetworkGraph, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	graph, err := p.network.Graph(rules...)
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: "graphe du réseau impossible",
+			Cause:   err,
+		}
+	}
+	return graph, nil
+}
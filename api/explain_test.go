@@ -0,0 +1,65 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+const explainPipelineProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: string, total: number)
+type Alert(#id: string)
+
+rule bigOrder : {c: Customer, o: Order} / o.customer == c.id AND o.total > 100 ==> Insert(Alert(id: o.id))
+rule alerted : {a: Alert, o: Order} / a.id == o.id ==> Print(a.id)
+
+Customer(id: "c1", tier: "gold")
+Order(id: "o1", customer: "c1", total: 250)
+Order(id: "42", customer: "c1", total: 50)
+`
+
+func TestPipeline_Explain(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(explainPipelineProgram); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	explanation, err := pipeline.Explain("bigOrder", "Order~42", "Customer~c1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if explanation.Matched || explanation.Fired {
+		t.Errorf("bigOrder on Order~42: Matched = %v, Fired = %v, want false/false", explanation.Matched, explanation.Fired)
+	}
+
+	explanation, err = pipeline.Explain("alerted", "Alert~o1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !explanation.Fired || len(explanation.Activations) != 1 {
+		t.Fatalf("alerted: Fired = %v, activations = %d", explanation.Fired, len(explanation.Activations))
+	}
+	for _, fact := range explanation.Activations[0].Facts {
+		if fact.FactID == "Alert~o1" && (fact.ProducedBy == nil || fact.ProducedBy.Rule != "bigOrder") {
+			t.Errorf("Alert~o1 provenance = %+v, want produced by bigOrder", fact.ProducedBy)
+		}
+	}
+}
+
+func TestPipeline_ExplainErrors(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(explainPipelineProgram); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	for _, args := range [][]string{{"missing"}, {"bigOrder", "Order~nope"}} {
+		_, err := pipeline.Explain(args[0], args[1:]...)
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+			t.Errorf("Explain(%v) error = %v, want validation error", args, err)
+		}
+	}
+}
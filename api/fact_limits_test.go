@@ -0,0 +1,174 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const limitsTypes = `type Reading(#id: string, value: number)
+type Sensor(#id: string)
+`
+
+func TestPipeline_MaxFactsInMemoryReject(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxFactsInMemory = 2
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(limitsTypes + `Reading(id: "r1", value: 1)
+Reading(id: "r2", value: 2)
+`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	_, err := pipeline.IngestString(`Reading(id: "r3", value: 3)`)
+	var limitErr *rete.FactLimitError
+	if !errors.Is(err, rete.ErrFactLimitExceeded) || !errors.As(err, &limitErr) {
+		t.Fatalf("IngestString() error = %v, want fact limit error", err)
+	}
+	if limitErr.FactID != "Reading~r3" || limitErr.Limit != 2 {
+		t.Errorf("limit error = %+v", limitErr)
+	}
+
+	stats := pipeline.FactLimitStats()
+	if stats.Facts != 2 || stats.Rejections != 1 {
+		t.Errorf("FactLimitStats() = %+v, want 2 facts and 1 rejection", stats)
+	}
+}
+
+func TestPipeline_MaxFactsPerTypeEvictOldest(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxFactsPerType = map[string]int{"Reading": 2}
+	config.FactLimitPolicy = FactLimitEvictOldest
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(limitsTypes + `Sensor(id: "s1")
+Reading(id: "r1", value: 1)
+Reading(id: "r2", value: 2)
+Reading(id: "r3", value: 3)
+`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	snapshot := pipeline.MemorySnapshot()
+	if snapshot.FactsByType["Reading"] != 2 || snapshot.FactsByType["Sensor"] != 1 {
+		t.Errorf("FactsByType = %v, want 2 Reading and 1 Sensor", snapshot.FactsByType)
+	}
+	stats := pipeline.FactLimitStats()
+	if stats.EvictionsByType["Reading"] != 1 || stats.Rejections != 0 {
+		t.Errorf("FactLimitStats() = %+v, want 1 Reading eviction", stats)
+	}
+}
+
+func TestPipeline_FactLimitsSurviveReset(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxFactsInMemory = 1
+	pipeline := NewPipelineWithConfig(config)
+	pipeline.Reset()
+
+	_, err := pipeline.IngestString(limitsTypes + `Sensor(id: "s1")
+Sensor(id: "s2")
+`)
+	if !errors.Is(err, rete.ErrFactLimitExceeded) {
+		t.Errorf("IngestString() after Reset error = %v, want fact limit error", err)
+	}
+}
+
+func TestPipeline_FactLimitBlockReleasedByRetraction(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	config.MaxFactsInMemory = 1
+	config.FactLimitPolicy = FactLimitBlock
+	config.FactLimitTimeout = 10 * time.Second
+	pipeline := NewPipelineWithConfig(config)
+	if _, err := pipeline.IngestString(limitsTypes + `Reading(id: "r1", value: 1)`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- pipeline.SubmitFact(&rete.Fact{ID: "Reading~r2", Type: "Reading", Fields: map[string]interface{}{"id": "r2", "value": 2.0}})
+	}()
+
+	// La soumission attend la place sans tenir le verrou du pipeline
+	deadline := time.Now().Add(5 * time.Second)
+	for pipeline.FactLimitStats().Blocked == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("submission never blocked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := pipeline.ApplyFactEvent(rete.FactEvent{Op: rete.FactOperationRetract, ID: "Reading~r1"}); err != nil {
+		t.Fatalf("ApplyFactEvent(retract) error = %v", err)
+	}
+
+	select {
+	case err := <-submitted:
+		if err != nil {
+			t.Fatalf("blocked SubmitFact() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("submission still blocked after the retraction")
+	}
+	facts := pipeline.network.Storage.GetAllFacts()
+	if len(facts) != 1 || facts[0].GetInternalID() != "Reading~r2" {
+		t.Errorf("facts = %v, want only Reading~r2", facts)
+	}
+	if stats := pipeline.FactLimitStats(); stats.Blocked != 1 || stats.Rejections != 0 {
+		t.Errorf("FactLimitStats() = %+v, want 1 blocked submission and no rejection", stats)
+	}
+}
+
+func TestPipeline_FactLimitBlockedIngestionRollsBack(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	config.MaxFactsInMemory = 1
+	config.FactLimitPolicy = FactLimitBlock
+	config.FactLimitTimeout = 300 * time.Millisecond
+	pipeline := NewPipelineWithConfig(config)
+	if _, err := pipeline.IngestString(limitsTypes + `Reading(id: "r1", value: 1)`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	ingested := make(chan error, 1)
+	go func() {
+		_, err := pipeline.IngestString(`Reading(id: "r2", value: 2)
+Reading(id: "r3", value: 3)
+`)
+		ingested <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for pipeline.FactLimitStats().Blocked == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("ingestion never blocked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// L'ingestion attend verrou tenu : la rétractation concurrente ne passe
+	// qu'après son échec, et ne se mêle pas à sa transaction
+	retracted := make(chan error, 1)
+	go func() {
+		_, err := pipeline.ApplyFactEvent(rete.FactEvent{Op: rete.FactOperationRetract, ID: "Reading~r1"})
+		retracted <- err
+	}()
+
+	if err := <-ingested; !errors.Is(err, rete.ErrFactLimitExceeded) {
+		t.Fatalf("blocked IngestString() error = %v, want fact limit error", err)
+	}
+	if err := <-retracted; err != nil {
+		t.Fatalf("ApplyFactEvent(retract) error = %v", err)
+	}
+	if facts := pipeline.network.Storage.GetAllFacts(); len(facts) != 0 {
+		t.Errorf("facts = %v, want none: nothing submitted by the failed ingestion survives", facts)
+	}
+	if stats := pipeline.FactLimitStats(); stats.Facts != 0 || stats.Rejections != 1 {
+		t.Errorf("FactLimitStats() = %+v, want no tracked fact and 1 rejection", stats)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/treivax/tsd/rete"
+)
+
+// LoadFacts charge en masse des faits depuis un flux JSON (tableau), NDJSON
+// ou CSV, dans les types déclarés par les programmes déjà ingérés.
+//
+// Le mapping donne le type des faits (ou la colonne qui le porte) et la
+// correspondance entre colonnes et champs. Les valeurs sont converties selon
+// le type des champs (number, bool) et l'identifiant est généré à partir de la
+// clé primaire, comme pour un fait écrit en TSD. Les enregistrements invalides
+// sont listés dans le rapport sans bloquer les autres, qui sont insérés dans
+// une seule transaction : si l'une des insertions échoue, aucun n'est conservé
+// et une erreur est retournée.
+func (p *Pipeline) LoadFacts(r io.Reader, format rete.FactFormat, mapping rete.FactMapping) (*rete.FactLoadReport, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report, err := p.network.LoadFacts(r, format, mapping)
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: "chargement des faits impossible",
+			Cause:   err,
+		}
+	}
+	return report, nil
+}
+
+// ExportFacts écrit les faits de la mémoire de travail, faits dérivés par les
+// règles compris, au format TSD (rechargeable, références exprimées par des
+// variables), JSON, NDJSON ou CSV (un seul type). Le filtre restreint les
+// types exportés et peut poser une condition sur le fait, désigné par la
+// variable f (voir rete.FactFilter). Retourne le nombre de faits écrits.
+func (p *Pipeline) ExportFacts(w io.Writer, format rete.FactFormat, filter rete.FactFilter) (int, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	count, err := p.network.ExportFacts(w, format, filter)
+	if err != nil {
+		return count, &Error{
+			Type:    ErrorTypeValidation,
+			Message: "export des faits impossible",
+			Cause:   err,
+		}
+	}
+	return count, nil
+}
+
+// ApplyFactEvent applique une insertion, une mise à jour ou une rétractation
+// reçue d'un flux (voir rete.FactEvent) et la propage dans le réseau : les
+// actions des règles déclenchées sont exécutées avant le retour. Retourne le
+// fait inséré, mis à jour ou retiré. Avec la politique FactLimitBlock, une
+// insertion attend la place avant de prendre le verrou du pipeline.
+func (p *Pipeline) ApplyFactEvent(event rete.FactEvent) (*rete.Fact, error) {
+	var err error
+	if event.Op == rete.FactOperationInsert {
+		err = p.awaitFactCapacity(context.Background(), &rete.Fact{Type: event.TypeName()})
+	}
+	var fact *rete.Fact
+	if err == nil {
+		p.mu.Lock()
+		fact, err = p.network.ApplyFactEvent(event)
+		p.mu.Unlock()
+	}
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: "événement refusé",
+			Cause:   err,
+		}
+	}
+	return fact, nil
+}
+
+// SubmitFact insère un fait dans la mémoire de travail et le propage : les
+// actions des règles déclenchées sont exécutées avant le retour
+func (p *Pipeline) SubmitFact(fact *rete.Fact) error {
+	return p.SubmitFactContext(context.Background(), fact)
+}
+
+// SubmitFactContext insère un fait comme SubmitFact, en interrompant la
+// propagation dès que ctx est terminé. L'insertion et les modifications faites
+// par les actions sont alors annulées et l'erreur retournée, de type
+// ErrorTypeCanceled, enveloppe ctx.Err(). Avec la politique FactLimitBlock, la
+// soumission attend la place avant de prendre le verrou du pipeline.
+func (p *Pipeline) SubmitFactContext(ctx context.Context, fact *rete.Fact) error {
+	err := p.awaitFactCapacity(ctx, fact)
+	if err == nil {
+		p.mu.Lock()
+		err = p.network.SubmitFactContext(ctx, fact)
+		p.mu.Unlock()
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return &Error{
+				Type:    ErrorTypeCanceled,
+				Message: "soumission du fait interrompue",
+				Cause:   err,
+			}
+		}
+		return &Error{
+			Type:    ErrorTypeExecution,
+			Message: "soumission du fait refusée",
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+// awaitFactCapacity attend, verrou du pipeline relâché, que la politique
+// FactLimitBlock laisse entrer le fait : une rétractation concurrente peut
+// alors libérer la place. L'attente précède toute transaction ; une fois le
+// verrou pris, une soumission qui manque encore de place attend verrou tenu.
+func (p *Pipeline) awaitFactCapacity(ctx context.Context, fact *rete.Fact) error {
+	p.mu.RLock()
+	network := p.network
+	p.mu.RUnlock()
+	return network.AwaitFactCapacity(ctx, fact)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+func TestPipeline_LoadFacts(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(`type Account(#id: string, balance: number)
+type Alert(#id: string)
+rule overdrawn : {a: Account} / a.balance < 0 ==> Insert(Alert(id: a.id))
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	csv := "account,balance\na1,10\na2,-5\na3,n/a\n"
+	report, err := pipeline.LoadFacts(strings.NewReader(csv), rete.FactFormatCSV, rete.FactMapping{
+		Type:   "Account",
+		Fields: map[string]string{"account": "id"},
+	})
+	if err != nil {
+		t.Fatalf("LoadFacts() error = %v", err)
+	}
+	if report.Loaded != 2 || len(report.Errors) != 1 || report.Errors[0].Line != 4 {
+		t.Errorf("rapport = %+v", report)
+	}
+	if pipeline.network.Storage.GetFact("Alert~a2") == nil {
+		t.Errorf("la règle doit se déclencher pour les faits chargés: %v", pipeline.network.Storage.GetAllFacts())
+	}
+
+	_, err = pipeline.LoadFacts(strings.NewReader("[]"), rete.FactFormatJSON, rete.FactMapping{Type: "Ghost"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+		t.Errorf("LoadFacts() error = %v, attendu une erreur de validation", err)
+	}
+}
+
+func TestPipeline_ApplyFactEvent(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+
+	if _, err := pipeline.IngestString(`type Account(#id: string, owner: string, balance: number)
+type Alert(#id: string)
+rule overdrawn : {a: Account} / a.balance < 0 ==> Insert(Alert(id: a.id))
+Account(id: "a1", owner: "alice", balance: 10)
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	// La mise à jour déclenche la règle que le fait satisfait désormais
+	fact, err := pipeline.ApplyFactEvent(rete.FactEvent{Op: rete.FactOperationUpdate, Type: "Account", ID: "a1", Fields: map[string]interface{}{"balance": -5.0}})
+	if err != nil || fact.Fields["owner"] != "alice" {
+		t.Fatalf("ApplyFactEvent() = %v, %v", fact, err)
+	}
+	if pipeline.network.Storage.GetFact("Alert~a1") == nil {
+		t.Errorf("la règle doit se déclencher après la mise à jour: %v", pipeline.network.Storage.GetAllFacts())
+	}
+
+	_, err = pipeline.ApplyFactEvent(rete.FactEvent{Op: rete.FactOperationRetract, Type: "Account", ID: "a9"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+		t.Errorf("ApplyFactEvent() error = %v, attendu une erreur de validation", err)
+	}
+}
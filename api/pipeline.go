@@ -5,7 +5,10 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"sync"
@@ -23,6 +26,8 @@ type Pipeline struct {
 	storage      rete.Storage
 	xupleManager xuples.XupleManager
 	retePipeline *rete.ConstraintPipeline
+	observer     rete.ActionObserver
+	rules        map[string]ruleDefinition // Règles issues du dernier ReloadRules
 	mu           sync.RWMutex
 }
 
@@ -41,38 +46,74 @@ func NewPipelineWithConfig(config *Config) *Pipeline {
 		panic(fmt.Sprintf("configuration invalide: %v", err))
 	}
 
-	storage := rete.NewMemoryStorage()
+	output := config.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	// Les traces du moteur suivent le niveau et la sortie configurés : le
+	// réseau ne garde pas son logger par défaut (Info, os.Stdout)
+	logger := createLogger(config.LogLevel, output)
+
+	retePipeline := rete.NewConstraintPipeline()
+	retePipeline.SetLogger(logger)
+
+	// Créer le pipeline
+	p := &Pipeline{
+		config:       config,
+		storage:      rete.NewMemoryStorage(),
+		xupleManager: xuples.NewXupleManager(),
+		retePipeline: retePipeline,
+	}
+	network, err := p.newNetwork(p.storage)
+	if err != nil {
+		panic(err.Error())
+	}
+	p.network = network
+
+	// Configurer le callback pour créer les xuple-spaces dès qu'ils sont détectés
+	retePipeline.SetOnXupleSpacesDetected(p.createXupleSpacesFromDefinitionsCallback)
+
+	return p
+}
+
+// newNetwork crée un réseau vide configuré pour le pipeline : limites, traces,
+// actions natives liées au gestionnaire de xuples du pipeline
+func (p *Pipeline) newNetwork(storage rete.Storage) (*rete.ReteNetwork, error) {
 	network := rete.NewReteNetwork(storage)
-	xupleManager := xuples.NewXupleManager()
+	if err := network.SetFactLimits(p.config.factLimits()); err != nil {
+		return nil, fmt.Errorf("configuration invalide: %w", err)
+	}
+	if err := network.SetCascadeLimits(p.config.cascadeLimits()); err != nil {
+		return nil, fmt.Errorf("configuration invalide: %w", err)
+	}
 
 	// Créer le BuiltinActionExecutor pour les actions natives
-	logger := createLogger(config.LogLevel)
+	output := p.config.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	network.SetLogger(createLogger(p.config.LogLevel, output))
+	xupleManager := p.xupleManager
 	builtinExecutor := actions.NewBuiltinActionExecutor(
 		network,
 		xupleManager,
-		os.Stdout,
-		log.New(os.Stdout, "[TSD] ", log.LstdFlags),
+		output,
+		log.New(output, "[TSD] ", log.LstdFlags),
 	)
 
 	// Enregistrer toutes les actions builtin dans l'ActionExecutor du réseau
 	actionRegistry := network.ActionExecutor.GetRegistry()
-	if err := actionRegistry.Register(actions.NewUpdateActionHandler(builtinExecutor)); err != nil {
-		panic(fmt.Sprintf("erreur enregistrement action Update: %v", err))
-	}
-	if err := actionRegistry.Register(actions.NewInsertActionHandler(builtinExecutor)); err != nil {
-		panic(fmt.Sprintf("erreur enregistrement action Insert: %v", err))
-	}
-	if err := actionRegistry.Register(actions.NewRetractActionHandler(builtinExecutor)); err != nil {
-		panic(fmt.Sprintf("erreur enregistrement action Retract: %v", err))
-	}
-	if err := actionRegistry.Register(actions.NewPrintActionHandler(builtinExecutor)); err != nil {
-		panic(fmt.Sprintf("erreur enregistrement action Print: %v", err))
-	}
-	if err := actionRegistry.Register(actions.NewLogActionHandler(builtinExecutor)); err != nil {
-		panic(fmt.Sprintf("erreur enregistrement action Log: %v", err))
-	}
-	if err := actionRegistry.Register(actions.NewXupleActionHandler(builtinExecutor)); err != nil {
-		panic(fmt.Sprintf("erreur enregistrement action Xuple: %v", err))
+	for name, handler := range map[string]rete.ActionHandler{
+		"Update":  actions.NewUpdateActionHandler(builtinExecutor),
+		"Insert":  actions.NewInsertActionHandler(builtinExecutor),
+		"Retract": actions.NewRetractActionHandler(builtinExecutor),
+		"Print":   actions.NewPrintActionHandler(builtinExecutor),
+		"Log":     actions.NewLogActionHandler(builtinExecutor),
+		"Xuple":   actions.NewXupleActionHandler(builtinExecutor),
+	} {
+		if err := actionRegistry.Register(handler); err != nil {
+			return nil, fmt.Errorf("erreur enregistrement action %s: %w", name, err)
+		}
 	}
 
 	// Configurer le handler pour l'action Xuple (rétrocompatibilité)
@@ -80,30 +121,29 @@ func NewPipelineWithConfig(config *Config) *Pipeline {
 	network.SetXupleHandler(func(xuplespace string, fact *rete.Fact, triggeringFacts []*rete.Fact) error {
 		return xupleManager.CreateXuple(xuplespace, fact, triggeringFacts)
 	})
-
-	retePipeline := rete.NewConstraintPipeline()
-	retePipeline.SetLogger(logger)
-
-	// Créer le pipeline
-	p := &Pipeline{
-		config:       config,
-		network:      network,
-		storage:      storage,
-		xupleManager: xupleManager,
-		retePipeline: retePipeline,
+	if p.observer != nil {
+		network.SetActionObserver(p.observer)
 	}
-
-	// Configurer le callback pour créer les xuple-spaces dès qu'ils sont détectés
-	retePipeline.SetOnXupleSpacesDetected(p.createXupleSpacesFromDefinitionsCallback)
-
-	return p
+	return network, nil
 }
 
 // IngestFile ingère un fichier TSD et retourne le résultat
 func (p *Pipeline) IngestFile(filename string) (*Result, error) {
+	return p.IngestFileContext(context.Background(), filename)
+}
+
+// IngestFileContext ingère un fichier TSD en interrompant l'ingestion dès que
+// ctx est terminé (annulation ou échéance). La propagation en cours s'arrête
+// entre deux faits ou actions, la transaction d'ingestion est annulée et
+// l'erreur retournée, de type ErrorTypeCanceled, enveloppe ctx.Err().
+func (p *Pipeline) IngestFileContext(ctx context.Context, filename string) (*Result, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.ingestFile(ctx, filename)
+}
 
+// ingestFile ingère un fichier TSD, verrou du pipeline tenu
+func (p *Pipeline) ingestFile(ctx context.Context, filename string) (*Result, error) {
 	startTime := time.Now()
 
 	if _, err := os.Stat(filename); err != nil {
@@ -114,7 +154,7 @@ func (p *Pipeline) IngestFile(filename string) (*Result, error) {
 		}
 	}
 
-	network, reteMetrics, err := p.retePipeline.IngestFile(filename, p.network, p.storage)
+	network, reteMetrics, err := p.retePipeline.IngestFileContext(ctx, filename, p.network, p.storage)
 	if err != nil {
 		return nil, p.wrapError(err, filename)
 	}
@@ -144,6 +184,12 @@ func (p *Pipeline) IngestFile(filename string) (*Result, error) {
 
 // IngestString ingère un programme TSD depuis une chaîne
 func (p *Pipeline) IngestString(program string) (*Result, error) {
+	return p.IngestStringContext(context.Background(), program)
+}
+
+// IngestStringContext ingère un programme TSD depuis une chaîne, interrompu
+// dès que ctx est terminé (voir IngestFileContext)
+func (p *Pipeline) IngestStringContext(ctx context.Context, program string) (*Result, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -170,7 +216,7 @@ func (p *Pipeline) IngestString(program string) (*Result, error) {
 	tmpFile.Close()
 
 	p.mu.Unlock()
-	result, err := p.IngestFile(tmpFile.Name())
+	result, err := p.IngestFileContext(ctx, tmpFile.Name())
 	p.mu.Lock()
 
 	if err != nil {
@@ -188,16 +234,84 @@ func (p *Pipeline) Reset() {
 
 	p.storage = rete.NewMemoryStorage()
 	p.network = rete.NewReteNetwork(p.storage)
+	_ = p.network.SetFactLimits(p.config.factLimits()) // configuration validée à la création
+	_ = p.network.SetCascadeLimits(p.config.cascadeLimits())
 	p.xupleManager = xuples.NewXupleManager()
+	p.rules = nil
 
 	// Configurer le handler pour l'action Xuple
 	p.network.SetXupleManager(p.xupleManager)
 	p.network.SetXupleHandler(func(xuplespace string, fact *rete.Fact, triggeringFacts []*rete.Fact) error {
 		return p.xupleManager.CreateXuple(xuplespace, fact, triggeringFacts)
 	})
+	if p.observer != nil {
+		p.network.SetActionObserver(p.observer)
+	}
+}
+
+// SetUndoable rend annulable la dernière ingestion réussie (voir Undo) : sa
+// transaction reste ouverte jusqu'à la prochaine ingestion réussie, qui la
+// valide ; une ingestion en échec est annulée sans la valider.
+func (p *Pipeline) SetUndoable(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retePipeline.SetUndoable(enabled)
+}
+
+// SetUndoDepth rend annulables les depth dernières ingestions réussies :
+// des appels successifs à Undo les annulent de la plus récente à la plus
+// ancienne. SetUndoDepth(1) équivaut à SetUndoable(true), 0 à SetUndoable(false).
+func (p *Pipeline) SetUndoDepth(depth int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retePipeline.SetUndoDepth(depth)
 }
 
-func createLogger(level LogLevel) *rete.Logger {
+// Undo annule la dernière ingestion réussie encore annulable (voir
+// SetUndoable et SetUndoDepth) : faits insérés, modifiés ou retirés (y compris
+// par les actions), règles ajoutées et activations journalisées, qui
+// disparaissent d'Explain. Les types, actions et xuple-spaces déclarés, ainsi
+// que les xuples créés, sont conservés. Retourne rete.ErrNoUndoableTransaction
+// s'il n'y a plus rien à annuler.
+func (p *Pipeline) Undo() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.network.UndoTransaction()
+}
+
+// SetActionObserver enregistre un observateur des exécutions d'actions.
+// L'observateur est conservé après Reset.
+func (p *Pipeline) SetActionObserver(observer rete.ActionObserver) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.observer = observer
+	p.network.SetActionObserver(observer)
+}
+
+// MemorySnapshot retourne l'occupation mémoire courante du réseau
+func (p *Pipeline) MemorySnapshot() rete.MemorySnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.network.MemorySnapshot()
+}
+
+// FactLimitStats retourne l'occupation suivie et les évictions et refus dus à
+// MaxFactsInMemory et MaxFactsPerType (valeur nulle sans limite configurée)
+func (p *Pipeline) FactLimitStats() rete.FactLimitStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.network.FactLimitStats()
+}
+
+// XupleManager retourne le gestionnaire des xuple-spaces du pipeline
+func (p *Pipeline) XupleManager() xuples.XupleManager {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.xupleManager
+}
+
+func createLogger(level LogLevel, output io.Writer) *rete.Logger {
 	var reteLevel rete.LogLevel
 	switch level {
 	case LogLevelSilent:
@@ -213,7 +327,7 @@ func createLogger(level LogLevel) *rete.Logger {
 	default:
 		reteLevel = rete.LogLevelInfo
 	}
-	return rete.NewLogger(reteLevel, os.Stdout)
+	return rete.NewLogger(reteLevel, output)
 }
 
 // createXupleSpacesFromDefinitionsCallback est le callback appelé par le pipeline RETE
@@ -331,6 +445,13 @@ func (p *Pipeline) parseRetentionPolicy(xsMap map[string]interface{}) xuples.Ret
 }
 
 func (p *Pipeline) wrapError(err error, filename string) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &Error{
+			Type:    ErrorTypeCanceled,
+			Message: "ingestion interrompue",
+			Cause:   err,
+		}
+	}
 	return &Error{
 		Type:    ErrorTypeExecution,
 		Message: "erreur d'ingestion",
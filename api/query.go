@@ -0,0 +1,238 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/treivax/tsd/rete"
+)
+
+// QueryResult contient les lignes retournées par une requête nommée
+type QueryResult struct {
+	Query     string
+	Variables []string
+	Rows      []QueryRow
+}
+
+// Count retourne le nombre de lignes
+func (r *QueryResult) Count() int {
+	return len(r.Rows)
+}
+
+// QueryRow est une ligne de résultat : chaque variable de la requête est liée à un fait.
+// Les faits sont des copies, indépendantes de la mémoire de travail.
+type QueryRow struct {
+	facts map[string]*rete.Fact
+}
+
+// Fact retourne le fait lié à une variable (nil si absente)
+func (r QueryRow) Fact(variable string) *rete.Fact {
+	return r.facts[variable]
+}
+
+// Variables retourne les variables liées dans la ligne, triées
+func (r QueryRow) Variables() []string {
+	variables := make([]string, 0, len(r.facts))
+	for variable := range r.facts {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// Value retourne la valeur brute d'un champ du fait lié à une variable
+func (r QueryRow) Value(variable, field string) (interface{}, bool) {
+	fact := r.facts[variable]
+	if fact == nil {
+		return nil, false
+	}
+	return fact.GetField(field)
+}
+
+// String retourne un champ de type string
+func (r QueryRow) String(variable, field string) (string, bool) {
+	value, ok := r.Value(variable, field)
+	if !ok {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// Number retourne un champ numérique
+func (r QueryRow) Number(variable, field string) (float64, bool) {
+	value, ok := r.Value(variable, field)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Bool retourne un champ booléen
+func (r QueryRow) Bool(variable, field string) (bool, bool) {
+	value, ok := r.Value(variable, field)
+	if !ok {
+		return false, false
+	}
+	b, ok := value.(bool)
+	return b, ok
+}
+
+// ToMap convertit la ligne en structure sérialisable : variable → {id, type, fields}
+func (r QueryRow) ToMap() map[string]interface{} {
+	result := make(map[string]interface{}, len(r.facts))
+	for variable, fact := range r.facts {
+		result[variable] = map[string]interface{}{
+			"id":     fact.ID,
+			"type":   fact.Type,
+			"fields": fact.Fields,
+		}
+	}
+	return result
+}
+
+// newQueryRow copie une ligne RETE en ligne API
+func newQueryRow(row rete.QueryRow) QueryRow {
+	facts := make(map[string]*rete.Fact, len(row.Bindings))
+	for variable, fact := range row.Bindings {
+		facts[variable] = fact.Clone()
+	}
+	return QueryRow{facts: facts}
+}
+
+// LiveQuery est une requête vivante : ses callbacks sont appelés lorsque des lignes
+// entrent ou sortent du résultat au fil des ingestions.
+type LiveQuery struct {
+	name    string
+	initial []QueryRow
+	watch   *rete.QueryWatch
+}
+
+// Name retourne le nom de la requête
+func (lq *LiveQuery) Name() string {
+	return lq.name
+}
+
+// InitialRows retourne les lignes présentes au moment de l'abonnement
+func (lq *LiveQuery) InitialRows() []QueryRow {
+	return lq.initial
+}
+
+// Close termine l'abonnement. Appels multiples sans effet.
+func (lq *LiveQuery) Close() {
+	lq.watch.Close()
+}
+
+// Queries retourne les noms des requêtes déclarées, triés
+func (p *Pipeline) Queries() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.network.QueryNames()
+}
+
+// Query exécute une requête nommée avec ses arguments positionnels
+func (p *Pipeline) Query(name string, args ...interface{}) (*QueryResult, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	query, err := p.lookupQuery(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.Execute(args...)
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: fmt.Sprintf("exécution de la requête '%s' impossible", name),
+			Cause:   err,
+		}
+	}
+
+	result := &QueryResult{
+		Query:     name,
+		Variables: append([]string(nil), query.Variables...),
+		Rows:      make([]QueryRow, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result.Rows = append(result.Rows, newQueryRow(row))
+	}
+	return result, nil
+}
+
+// LiveQuery abonne des callbacks aux changements du résultat d'une requête.
+// Les lignes déjà présentes sont disponibles via InitialRows ; les callbacks
+// sont appelés de manière synchrone pendant les ingestions suivantes et ne
+// doivent pas appeler le pipeline.
+func (p *Pipeline) LiveQuery(name string, args []interface{}, onAdded, onRemoved func(QueryRow)) (*LiveQuery, error) {
+	// Verrou exclusif : aucune ingestion entre l'état initial et l'abonnement
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	query, err := p.lookupQuery(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.Execute(args...)
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: fmt.Sprintf("exécution de la requête '%s' impossible", name),
+			Cause:   err,
+		}
+	}
+
+	watch, err := query.Watch(args, wrapRowHandler(onAdded), wrapRowHandler(onRemoved))
+	if err != nil {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: fmt.Sprintf("abonnement à la requête '%s' impossible", name),
+			Cause:   err,
+		}
+	}
+
+	live := &LiveQuery{
+		name:    name,
+		initial: make([]QueryRow, 0, len(rows)),
+		watch:   watch,
+	}
+	for _, row := range rows {
+		live.initial = append(live.initial, newQueryRow(row))
+	}
+	return live, nil
+}
+
+// lookupQuery retrouve une requête compilée (appelant détenant p.mu)
+func (p *Pipeline) lookupQuery(name string) (*rete.Query, error) {
+	query, exists := p.network.GetQuery(name)
+	if !exists {
+		return nil, &Error{
+			Type:    ErrorTypeValidation,
+			Message: fmt.Sprintf("requête '%s' inconnue", name),
+		}
+	}
+	return query, nil
+}
+
+// wrapRowHandler adapte un callback API en callback RETE
+func wrapRowHandler(handler func(QueryRow)) rete.QueryRowHandler {
+	if handler == nil {
+		return nil
+	}
+	return func(row rete.QueryRow) {
+		handler(newQueryRow(row))
+	}
+}
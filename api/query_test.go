@@ -0,0 +1,183 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const queryPipelineProgram = `type Person(#id: string, age: number, active: bool)
+query adults(min: number) : {p: Person} / p.age >= min
+query active() : {p: Person} / p.active == true
+
+Person(id: "alice", age: 30, active: true)
+Person(id: "bob", age: 12, active: false)
+`
+
+func TestPipeline_Query(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(queryPipelineProgram); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	if names := pipeline.Queries(); len(names) != 2 || names[0] != "active" || names[1] != "adults" {
+		t.Errorf("Queries() = %v", names)
+	}
+
+	result, err := pipeline.Query("adults", 18)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.Count() != 1 {
+		t.Fatalf("Count() = %d, want 1", result.Count())
+	}
+	if len(result.Variables) != 1 || result.Variables[0] != "p" {
+		t.Errorf("Variables = %v, want [p]", result.Variables)
+	}
+
+	row := result.Rows[0]
+	if id, ok := row.String("p", "id"); !ok || id != "alice" {
+		t.Errorf("p.id = %q, want alice", id)
+	}
+	if age, ok := row.Number("p", "age"); !ok || age != 30 {
+		t.Errorf("p.age = %v, want 30", age)
+	}
+	if active, ok := row.Bool("p", "active"); !ok || !active {
+		t.Errorf("p.active = %v, want true", active)
+	}
+	if _, ok := row.String("p", "age"); ok {
+		t.Error("String() on a number field should fail")
+	}
+	if row.Fact("missing") != nil {
+		t.Error("Fact() on an unbound variable should be nil")
+	}
+	if m := row.ToMap(); m["p"] == nil {
+		t.Errorf("ToMap() = %v", m)
+	}
+
+	// Les faits retournés sont des copies
+	row.Fact("p").Fields["age"] = 99.0
+	again, _ := pipeline.Query("adults", 18)
+	if age, _ := again.Rows[0].Number("p", "age"); age != 30 {
+		t.Error("modifying a returned fact must not affect the working memory")
+	}
+}
+
+func TestPipeline_QueryErrors(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(queryPipelineProgram); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		{"unknown query", "missing", nil},
+		{"missing argument", "adults", nil},
+		{"wrong argument type", "adults", []interface{}{"x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pipeline.Query(tt.query, tt.args...)
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+				t.Errorf("Query() error = %v, want validation error", err)
+			}
+		})
+	}
+}
+
+func TestPipeline_LiveQuery(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(queryPipelineProgram); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+
+	var added []string
+	live, err := pipeline.LiveQuery("adults", []interface{}{18}, func(row QueryRow) {
+		id, _ := row.String("p", "id")
+		added = append(added, id)
+	}, nil)
+	if err != nil {
+		t.Fatalf("LiveQuery() error = %v", err)
+	}
+	if live.Name() != "adults" || len(live.InitialRows()) != 1 {
+		t.Errorf("unexpected live query: %s, %d initial rows", live.Name(), len(live.InitialRows()))
+	}
+
+	if _, err := pipeline.IngestString(`Person(id: "carol", age: 45, active: true)
+Person(id: "dave", age: 5, active: true)`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+	if len(added) != 1 || added[0] != "carol" {
+		t.Errorf("added = %v, want [carol]", added)
+	}
+
+	live.Close()
+	if _, err := pipeline.IngestString(`Person(id: "erin", age: 50, active: true)`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+	if len(added) != 1 {
+		t.Errorf("closed live query should not be notified, added = %v", added)
+	}
+
+	if _, err := pipeline.LiveQuery("missing", nil, nil, nil); err == nil {
+		t.Error("LiveQuery() on an unknown query should fail")
+	}
+}
+
+// collectRecorder retient le dernier nombre de lignes notifié par commande
+type collectRecorder map[string]interface{}
+
+func (r collectRecorder) OnActionExecuted(result rete.ExecutionResult) {
+	if len(result.Arguments) == 2 {
+		r[fmt.Sprint(result.Arguments[0])] = result.Arguments[1]
+	}
+}
+
+func TestPipeline_CollectAcrossIngestions(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	if _, err := pipeline.IngestString(`type Order(#id: string)
+type OrderLine(#id: string, order: string)
+action notify(id: string, lines: number)
+query lines() : {o: Order, lines: COLLECT(l: OrderLine / l.order == o.id)} / LENGTH(lines) > 0
+rule notifyLines : {o: Order, lines: COLLECT(l: OrderLine / l.order == o.id)} / ==> notify(o.id, LENGTH(lines))
+`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion des règles: %v", err)
+	}
+	notified := collectRecorder{}
+	pipeline.SetActionObserver(notified)
+
+	if _, err := pipeline.IngestString(`Order(id: "o1")
+Order(id: "o2")
+OrderLine(id: "l1", order: "o1")
+OrderLine(id: "l2", order: "o1")
+`); err != nil {
+		t.Fatalf("❌ Erreur d'ingestion des faits: %v", err)
+	}
+
+	result, err := pipeline.Query("lines")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.Count() != 1 {
+		t.Fatalf("Count() = %d, want 1", result.Count())
+	}
+	if id, _ := result.Rows[0].String("o", "id"); id != "o1" {
+		t.Errorf("o.id = %q, want o1", id)
+	}
+	if fmt.Sprint(notified["o1"]) != "2" || fmt.Sprint(notified["o2"]) != "0" {
+		t.Errorf("notifications = %v, want o1:2 o2:0", notified)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/rete"
+)
+
+// ruleFileForbiddenKeys liste les instructions refusées dans les fichiers de
+// règles rechargés : elles modifieraient les faits ou l'état du réseau
+var ruleFileForbiddenKeys = map[string]string{
+	"facts":           "faits",
+	"factAssignments": "affectations de faits",
+	"retractions":     "rétractations",
+	"ruleRemovals":    "suppressions de règles",
+	"resets":          "reset",
+	"xupleSpaces":     "xuple-spaces",
+}
+
+// RuleReload décrit les changements appliqués par ReloadRules
+type RuleReload struct {
+	Added   []string // Règles ajoutées
+	Removed []string // Règles supprimées
+	Changed []string // Règles dont la définition a changé, recompilées
+	Rules   int      // Règles issues des fichiers après le rechargement
+}
+
+// Empty indique qu'aucune règle n'a été ajoutée, supprimée ou modifiée
+func (r *RuleReload) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// ruleDefinition est une règle chargée depuis un fichier de règles
+type ruleDefinition struct {
+	expression  interface{} // Expression de l'AST
+	fingerprint string      // Forme canonique servant à détecter les modifications
+}
+
+// ReloadRules aligne les règles du pipeline sur le contenu des fichiers donnés.
+//
+// Les fichiers (types, actions, règles et requêtes, imports compris) sont
+// chargés et validés contre le réseau courant. Les règles sont comparées par
+// identifiant à celles du chargement précédent : les règles supprimées ou
+// modifiées sont retirées du réseau (RemoveRule), puis les règles nouvelles ou
+// modifiées sont ajoutées et propagées aux faits existants, sans redéclencher
+// les autres règles. La mémoire de travail n'est pas recopiée.
+//
+// Le remplacement est atomique (rete.ConstraintPipeline.ReplaceRulesContext) :
+// en cas d'erreur ou d'annulation de ctx, les faits modifiés par les actions
+// sont restaurés, les règles ajoutées supprimées et les règles retirées
+// rétablies sans exécuter d'action. Les requêtes vivantes voient alors
+// apparaître puis disparaître les lignes produites entre-temps. Les
+// ingestions encore annulables (SetUndoDepth) sont validées.
+//
+// Le verrou du pipeline est tenu pendant tout le rechargement : les
+// ingestions concurrentes voient l'ancienne base de règles ou la nouvelle,
+// jamais un état intermédiaire.
+func (p *Pipeline) ReloadRules(ctx context.Context, files ...string) (*RuleReload, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	program, err := loadRuleFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	if err := rete.NewIncrementalValidator(p.network).ValidateWithContext(program); err != nil {
+		return nil, &Error{Type: ErrorTypeValidation, Message: "règles invalides", Cause: err}
+	}
+	rules, err := ruleDefinitions(program)
+	if err != nil {
+		return nil, err
+	}
+
+	reload := p.diffRules(rules)
+	removed := append(append([]string(nil), reload.Removed...), reload.Changed...)
+	addition := p.ruleAddition(program, rules, append(append([]string(nil), reload.Added...), reload.Changed...))
+	if _, err := p.retePipeline.ReplaceRulesContext(ctx, removed, addition, p.network, p.storage); err != nil {
+		return nil, p.wrapError(err, "règles")
+	}
+	p.rules = rules
+
+	reload.Rules = len(rules)
+	return reload, nil
+}
+
+// loadRuleFiles charge les fichiers de règles et fusionne leurs AST
+func loadRuleFiles(files []string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	loader := constraint.NewModuleLoader()
+	for _, file := range files {
+		ast, err := loader.Load(file)
+		if err != nil {
+			return nil, &Error{Type: ErrorTypeParse, Message: "chargement de " + file, Cause: err}
+		}
+		for key, value := range ast {
+			items, _ := value.([]interface{})
+			if len(items) == 0 {
+				continue
+			}
+			if label, forbidden := ruleFileForbiddenKeys[key]; forbidden {
+				return nil, &Error{
+					Type:    ErrorTypeValidation,
+					Message: fmt.Sprintf("%s: %s interdit(e)s dans un fichier de règles", file, label),
+				}
+			}
+			existing, _ := merged[key].([]interface{})
+			merged[key] = append(existing, items...)
+		}
+	}
+	return merged, nil
+}
+
+// ruleDefinitions indexe les règles d'un programme par identifiant
+func ruleDefinitions(program map[string]interface{}) (map[string]ruleDefinition, error) {
+	expressions, _ := program["expressions"].([]interface{})
+	rules := make(map[string]ruleDefinition, len(expressions))
+	for _, expression := range expressions {
+		exprMap, _ := expression.(map[string]interface{})
+		id, _ := exprMap["ruleId"].(string)
+		if id == "" {
+			return nil, &Error{Type: ErrorTypeValidation, Message: "règle sans identifiant"}
+		}
+		if _, exists := rules[id]; exists {
+			return nil, &Error{Type: ErrorTypeValidation, Message: fmt.Sprintf("règle '%s' définie plusieurs fois", id)}
+		}
+		// Déplacer une règle dans le fichier ne la modifie pas
+		fingerprint, err := json.Marshal(constraint.StripPositions(expression))
+		if err != nil {
+			return nil, &Error{Type: ErrorTypeInternal, Message: "règle " + id, Cause: err}
+		}
+		rules[id] = ruleDefinition{expression: expression, fingerprint: string(fingerprint)}
+	}
+	return rules, nil
+}
+
+// diffRules compare les règles chargées à celles du rechargement précédent et
+// au réseau : une règle absente du réseau (après un reset, par exemple) est
+// ajoutée à nouveau, une règle de même identifiant ingérée par ailleurs est
+// remplacée
+func (p *Pipeline) diffRules(rules map[string]ruleDefinition) *RuleReload {
+	reload := &RuleReload{}
+	for id, rule := range rules {
+		old, known := p.rules[id]
+		switch {
+		case !p.hasRule(id):
+			reload.Added = append(reload.Added, id)
+		case !known || old.fingerprint != rule.fingerprint:
+			reload.Changed = append(reload.Changed, id)
+		}
+	}
+	for id := range p.rules {
+		if _, kept := rules[id]; !kept && p.hasRule(id) {
+			reload.Removed = append(reload.Removed, id)
+		}
+	}
+	sort.Strings(reload.Added)
+	sort.Strings(reload.Removed)
+	sort.Strings(reload.Changed)
+	return reload
+}
+
+// hasRule indique si le réseau contient des nœuds de la règle
+func (p *Pipeline) hasRule(id string) bool {
+	return p.network.LifecycleManager != nil && len(p.network.LifecycleManager.GetNodesForRule(id)) > 0
+}
+
+// ruleAddition construit le programme à ingérer : types, actions, requêtes
+// inconnues du réseau et règles à ajouter
+func (p *Pipeline) ruleAddition(program map[string]interface{}, rules map[string]ruleDefinition, ids []string) map[string]interface{} {
+	addition := map[string]interface{}{
+		"types":   program["types"],
+		"actions": program["actions"],
+	}
+
+	var queries []interface{}
+	items, _ := program["queries"].([]interface{})
+	for _, query := range items {
+		queryMap, _ := query.(map[string]interface{})
+		name, _ := queryMap["name"].(string)
+		if _, exists := p.network.GetQuery(name); !exists {
+			queries = append(queries, query)
+		}
+	}
+	addition["queries"] = queries
+
+	sort.Strings(ids)
+	expressions := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		expressions = append(expressions, rules[id].expression)
+	}
+	addition["expressions"] = expressions
+
+	for key, value := range addition {
+		if value == nil {
+			addition[key] = []interface{}{}
+		}
+	}
+	return addition
+}
+
+// SetRuleState change l'état d'une règle : active, disabled (mise en sourdine,
+// les correspondances sont conservées et rejouées à la réactivation) ou
+// dry-run (les activations sont enregistrées sans exécuter les actions).
+// L'état est conservé lors du rechargement des règles.
+func (p *Pipeline) SetRuleState(ruleID string, state rete.RuleState) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	parsed, err := rete.ParseRuleState(string(state))
+	if err != nil {
+		return &Error{Type: ErrorTypeValidation, Message: "état de règle invalide", Cause: err}
+	}
+	if _, err := p.network.RuleState(ruleID); err != nil {
+		return &Error{Type: ErrorTypeValidation, Message: fmt.Sprintf("état de la règle '%s'", ruleID), Cause: err}
+	}
+	if err := p.network.SetRuleState(ruleID, parsed); err != nil {
+		return &Error{Type: ErrorTypeExecution, Message: fmt.Sprintf("rejeu des correspondances de la règle '%s'", ruleID), Cause: err}
+	}
+	return nil
+}
+
+// RuleStatus retourne l'état, les annotations et les correspondances en attente d'une règle
+func (p *Pipeline) RuleStatus(ruleID string) (rete.RuleStatus, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status, err := p.network.RuleStatus(ruleID)
+	if err != nil {
+		return rete.RuleStatus{}, &Error{Type: ErrorTypeValidation, Message: fmt.Sprintf("état de la règle '%s'", ruleID), Cause: err}
+	}
+	return status, nil
+}
+
+// RuleStatuses retourne l'état de toutes les règles, triées par identifiant
+func (p *Pipeline) RuleStatuses() []rete.RuleStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.network.RuleStatuses()
+}
+
+// DryRunActivations retourne les activations enregistrées d'une règle en
+// dry-run (de toutes les règles si ruleID est vide)
+func (p *Pipeline) DryRunActivations(ruleID string) []rete.DryRunRecord {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.network.DryRunActivations(ruleID)
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const reloadTypes = `type Order(#id: string, total: number)
+action notify(id: string)
+`
+
+// writeRuleFile écrit un fichier de règles dans dir
+func writeRuleFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+// ruleFired indique si la règle s'est déclenchée pour le fait
+func ruleFired(t *testing.T, pipeline *Pipeline, rule, factID string) bool {
+	t.Helper()
+	explanation, err := pipeline.Explain(rule, factID)
+	if err != nil {
+		t.Fatalf("Explain(%s) error = %v", rule, err)
+	}
+	return explanation.Fired
+}
+
+func TestPipeline_ReloadRules(t *testing.T) {
+	dir := t.TempDir()
+	types := writeRuleFile(t, dir, "types.tsd", reloadTypes)
+	rules := writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+rule small : {o: Order} / o.total < 10 ==> notify(o.id)
+`)
+	pipeline := NewPipeline()
+	ctx := context.Background()
+
+	reload, err := pipeline.ReloadRules(ctx, types, rules)
+	if err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	if want := []string{"big", "small"}; !reflect.DeepEqual(reload.Added, want) || reload.Rules != 2 {
+		t.Errorf("reload = %+v, want added %v", reload, want)
+	}
+	if _, err := pipeline.IngestString(`Order(id: "o1", total: 50)`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if ruleFired(t, pipeline, "big", "Order~o1") {
+		t.Errorf("big fired for a total of 50")
+	}
+
+	// Sans changement, rien n'est recompilé
+	reload, err = pipeline.ReloadRules(ctx, types, rules)
+	if err != nil || !reload.Empty() {
+		t.Fatalf("ReloadRules() = %+v, %v, want no change", reload, err)
+	}
+
+	// big modifiée, small supprimée, medium ajoutée : les faits restent en place
+	writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 20 ==> notify(o.id)
+rule medium : {o: Order} / o.total > 40 ==> notify(o.id)
+`)
+	reload, err = pipeline.ReloadRules(ctx, types, rules)
+	if err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	want := &RuleReload{Added: []string{"medium"}, Removed: []string{"small"}, Changed: []string{"big"}, Rules: 2}
+	if !reflect.DeepEqual(reload, want) {
+		t.Errorf("reload = %+v, want %+v", reload, want)
+	}
+	if facts := pipeline.MemorySnapshot().FactsByType["Order"]; facts != 1 {
+		t.Errorf("%d Order facts after reload, want 1", facts)
+	}
+	for _, rule := range []string{"big", "medium"} {
+		if !ruleFired(t, pipeline, rule, "Order~o1") {
+			t.Errorf("%s did not fire for the existing fact", rule)
+		}
+	}
+	if _, err := pipeline.Explain("small", "Order~o1"); err == nil {
+		t.Errorf("small should have been removed")
+	}
+}
+
+func TestPipeline_ReloadRulesInvalidKeepsRules(t *testing.T) {
+	dir := t.TempDir()
+	types := writeRuleFile(t, dir, "types.tsd", reloadTypes)
+	rules := writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+`)
+	pipeline := NewPipeline()
+	ctx := context.Background()
+	if _, err := pipeline.ReloadRules(ctx, types, rules); err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		errType ErrorType
+	}{
+		{"parse", `rule big : {o: Order / o.total > 1 ==> notify(o.id)`, ErrorTypeParse},
+		{"unknown field", `rule big : {o: Order} / o.missing > 1 ==> notify(o.id)`, ErrorTypeValidation},
+		{"facts", `Order(id: "o2", total: 1)`, ErrorTypeValidation},
+		{"duplicate", "rule a : {o: Order} / o.total > 1 ==> notify(o.id)\nrule a : {o: Order} / o.total > 2 ==> notify(o.id)\n", ErrorTypeValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeRuleFile(t, dir, "rules.tsd", tt.content)
+			_, err := pipeline.ReloadRules(ctx, types, rules)
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.Type != tt.errType {
+				t.Fatalf("ReloadRules() error = %v, want %s error", err, tt.errType)
+			}
+			if !pipeline.hasRule("big") {
+				t.Errorf("big removed by a failed reload")
+			}
+		})
+	}
+}
+
+func TestPipeline_RuleStates(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(reloadTypes + `@owner("pricing")
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	if err := pipeline.SetRuleState("big", rete.RuleStateDisabled); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if _, err := pipeline.IngestString(`Order(id: "o1", total: 150)`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if ruleFired(t, pipeline, "big", "Order~o1") {
+		t.Errorf("disabled rule fired")
+	}
+	status, err := pipeline.RuleStatus("big")
+	if err != nil || status.Pending != 1 || status.Metadata.Owner != "pricing" {
+		t.Errorf("RuleStatus() = %+v, %v", status, err)
+	}
+
+	if err := pipeline.SetRuleState("big", rete.RuleStateActive); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if !ruleFired(t, pipeline, "big", "Order~o1") {
+		t.Errorf("held match not replayed after re-enabling")
+	}
+
+	// L'état est conservé par le rechargement des règles
+	_ = pipeline.SetRuleState("big", rete.RuleStateDryRun)
+	dir := t.TempDir()
+	types := writeRuleFile(t, dir, "types.tsd", reloadTypes)
+	rules := writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 10 ==> notify(o.id)
+`)
+	if _, err := pipeline.ReloadRules(context.Background(), types, rules); err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	if statuses := pipeline.RuleStatuses(); len(statuses) != 1 || statuses[0].State != rete.RuleStateDryRun {
+		t.Errorf("RuleStatuses() = %+v, want big in dry-run", statuses)
+	}
+	if records := pipeline.DryRunActivations("big"); len(records) != 1 {
+		t.Errorf("DryRunActivations() = %+v, want the existing order recorded", records)
+	}
+
+	for _, tt := range []struct{ rule, state string }{{"unknown", "disabled"}, {"big", "paused"}} {
+		var apiErr *Error
+		err := pipeline.SetRuleState(tt.rule, rete.RuleState(tt.state))
+		if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+			t.Errorf("SetRuleState(%s, %s) error = %v, want validation error", tt.rule, tt.state, err)
+		}
+	}
+}
+
+// cancelObserver annule un contexte dès qu'une règle exécute son action
+type cancelObserver struct {
+	rule   string
+	cancel context.CancelFunc
+}
+
+func (o cancelObserver) OnActionExecuted(result rete.ExecutionResult) {
+	if result.Context.RuleName == o.rule {
+		o.cancel()
+	}
+}
+
+func TestPipeline_ReloadRulesFailureLeavesPipelineUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	types := writeRuleFile(t, dir, "types.tsd", `type Order(#id: string, total: number)
+type Flag(#order: string)
+query flags() : {f: Flag}
+`)
+	rules := writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 100 ==> Insert(Flag(order: o.id))
+`)
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	ctx := context.Background()
+	if _, err := pipeline.ReloadRules(ctx, types, rules); err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	for _, order := range []string{`Order(id: "o1", total: 200)`, `Order(id: "o2", total: 50)`} {
+		if _, err := pipeline.IngestString(order); err != nil {
+			t.Fatalf("IngestString() error = %v", err)
+		}
+	}
+
+	var events []string
+	live, err := pipeline.LiveQuery("flags", nil,
+		func(row QueryRow) { events = append(events, "+"+row.Fact("f").GetInternalID()) },
+		func(row QueryRow) { events = append(events, "-"+row.Fact("f").GetInternalID()) })
+	if err != nil {
+		t.Fatalf("LiveQuery() error = %v", err)
+	}
+	defer live.Close()
+
+	// big est retirée, puis le rechargement est interrompu pendant la
+	// propagation de any aux faits existants
+	writeRuleFile(t, dir, "rules.tsd", `rule any : {o: Order} / o.total > 0 ==> Insert(Flag(order: o.id))
+`)
+	canceled, cancel := context.WithCancel(ctx)
+	pipeline.SetActionObserver(cancelObserver{rule: "any", cancel: cancel})
+	_, err = pipeline.ReloadRules(canceled, types, rules)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeCanceled {
+		t.Fatalf("ReloadRules() error = %v, want canceled", err)
+	}
+	pipeline.SetActionObserver(nil)
+	if statuses := pipeline.RuleStatuses(); len(statuses) != 1 || statuses[0].Rule != "big" {
+		t.Errorf("RuleStatuses() = %+v, want big only", statuses)
+	}
+	if result, err := pipeline.Query("flags"); err != nil || result.Count() != 1 {
+		t.Errorf("Query(flags) = %+v, %v, want the flag of o1", result, err)
+	}
+	// Une ligne produite avant l'interruption est retirée par l'annulation
+	pending := make(map[string]int)
+	for _, event := range events {
+		if event[0] == '+' {
+			pending[event[1:]]++
+		} else {
+			pending[event[1:]]--
+		}
+	}
+	for id, count := range pending {
+		if count != 0 {
+			t.Errorf("live query events = %v, %s left by a failed reload", events, id)
+		}
+	}
+	events = nil
+
+	// Un rechargement réussi notifie la requête vivante des lignes produites
+	// par les règles ajoutées
+	writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 100 ==> Insert(Flag(order: o.id))
+rule low : {o: Order} / o.total < 100 ==> Insert(Flag(order: o.id))
+`)
+	reload, err := pipeline.ReloadRules(ctx, types, rules)
+	if err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	if !reflect.DeepEqual(reload.Added, []string{"low"}) {
+		t.Errorf("reload = %+v, want low added", reload)
+	}
+	if !reflect.DeepEqual(events, []string{"+Flag~o2"}) {
+		t.Errorf("live query events = %v, want +Flag~o2", events)
+	}
+	if _, err := pipeline.IngestString(`Order(id: "o3", total: 500)`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if !reflect.DeepEqual(events, []string{"+Flag~o2", "+Flag~o3"}) {
+		t.Errorf("live query events = %v, want +Flag~o2 then +Flag~o3", events)
+	}
+	if !ruleFired(t, pipeline, "big", "Order~o1") {
+		t.Errorf("activation of big before the reload forgotten by Explain")
+	}
+}
+
+func TestPipeline_ReloadRulesCommitsUndoableIngestions(t *testing.T) {
+	dir := t.TempDir()
+	types := writeRuleFile(t, dir, "types.tsd", reloadTypes)
+	rules := writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+`)
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	pipeline.SetUndoable(true)
+	ctx := context.Background()
+	if _, err := pipeline.ReloadRules(ctx, types, rules); err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	if _, err := pipeline.IngestString(`Order(id: "o1", total: 200)`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	writeRuleFile(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 10 ==> notify(o.id)
+`)
+	if _, err := pipeline.ReloadRules(ctx, types, rules); err != nil {
+		t.Fatalf("ReloadRules() error = %v", err)
+	}
+	// L'ingestion précédant le rechargement n'est plus annulable
+	if err := pipeline.Undo(); !errors.Is(err, rete.ErrNoUndoableTransaction) {
+		t.Errorf("Undo() error = %v, want ErrNoUndoableTransaction", err)
+	}
+	if !ruleFired(t, pipeline, "big", "Order~o1") {
+		t.Error("fact ingested before the reload lost")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/rete"
+)
+
+// simulationForbiddenKeys liste les instructions refusées en simulation :
+// elles modifieraient le pipeline au-delà de la mémoire de travail
+var simulationForbiddenKeys = map[string]string{
+	"resets":       "reset",
+	"ruleRemovals": "suppressions de règles",
+	"xupleSpaces":  "xuple-spaces",
+}
+
+// Simulate ingère un programme TSD en simulation (voir SimulateFile)
+func (p *Pipeline) Simulate(ctx context.Context, program string) (*rete.SimulationReport, error) {
+	tmpFile, err := os.CreateTemp("", "tsd-simulation-*.tsd")
+	if err != nil {
+		return nil, &Error{Type: ErrorTypeIO, Message: "impossible de créer fichier temporaire", Cause: err}
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(program)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, &Error{Type: ErrorTypeIO, Message: "impossible d'écrire dans fichier temporaire", Cause: err}
+	}
+	return p.SimulateFile(ctx, tmpFile.Name())
+}
+
+// SimulateFile ingère un fichier TSD (faits et règles) sans effet de bord et
+// retourne ce qui se serait produit : activations de règles avec les actions
+// appelées, faits insérés, modifiés ou rétractés, xuples créés.
+//
+// La simulation s'exécute sur un réseau jetable, reconstruit à partir des
+// déclarations du pipeline (types, actions, xuple-spaces, requêtes, règles et
+// leur état) et d'une copie de sa mémoire de travail. Insert, Update et
+// Retract s'appliquent à cette copie pour que la cascade se poursuive ; Xuple,
+// Print, Log et les actions personnalisées sont seulement enregistrés. Le
+// pipeline n'est jamais modifié : ses requêtes, requêtes suivies et
+// xuple-spaces ne voient pas les faits simulés, et les types, actions et
+// règles déclarés par le fichier sont abandonnés avec la copie. reset, remove
+// rule et les xuple-spaces sont refusés.
+func (p *Pipeline) SimulateFile(ctx context.Context, filename string) (*rete.SimulationReport, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ast, err := constraint.NewModuleLoader().Load(filename)
+	if err != nil {
+		return nil, &Error{Type: ErrorTypeParse, Message: "chargement de " + filename, Cause: err}
+	}
+	for key, label := range simulationForbiddenKeys {
+		if items, _ := ast[key].([]interface{}); len(items) > 0 {
+			return nil, &Error{Type: ErrorTypeValidation, Message: fmt.Sprintf("%s interdit(e)s en simulation", label)}
+		}
+	}
+
+	simulated, err := p.copyPipeline(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := simulated.network.BeginSimulation(); err != nil {
+		return nil, &Error{Type: ErrorTypeValidation, Message: "simulation impossible", Cause: err}
+	}
+	_, ingestErr := simulated.ingestFile(ctx, filename)
+	report, endErr := simulated.network.EndSimulation()
+	if ingestErr != nil {
+		return nil, ingestErr
+	}
+	if endErr != nil {
+		return nil, &Error{Type: ErrorTypeExecution, Message: "fin de la simulation", Cause: endErr}
+	}
+	return report, nil
+}
+
+// copyPipeline construit un pipeline de même configuration, de mêmes
+// déclarations et de même mémoire de travail, sans ré-exécuter les actions
+// (appelant détenant p.mu)
+func (p *Pipeline) copyPipeline(ctx context.Context) (*Pipeline, error) {
+	// Rien ne peut libérer de place dans la copie : block y refuse le fait
+	config := *p.config
+	if config.FactLimitPolicy == FactLimitBlock {
+		config.FactLimitPolicy = FactLimitReject
+	}
+	copied := NewPipelineWithConfig(&config)
+
+	network, _, err := copied.retePipeline.IngestProgramContext(ctx, "copie du pipeline", p.network.Definitions(), copied.network, copied.storage)
+	if err != nil {
+		return nil, &Error{Type: ErrorTypeInternal, Message: "copie des déclarations du pipeline", Cause: err}
+	}
+	copied.network = network
+	if p.network.Modules != nil {
+		copied.network.Modules = p.network.Modules.Clone()
+	}
+	if err := copied.network.SeedFacts(p.network); err != nil {
+		return nil, &Error{Type: ErrorTypeInternal, Message: "copie de la mémoire de travail", Cause: err}
+	}
+	// La propagation dans la copie est tracée par le traceur du pipeline : les
+	// nœuds reconstruits portent les mêmes identifiants
+	if tracer := p.network.GetTracer(); tracer != nil {
+		copied.network.SetTracer(tracer)
+	}
+	return copied, nil
+}
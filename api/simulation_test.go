@@ -0,0 +1,192 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const simulationBase = `xuple-space alerts {
+    selection: fifo
+    consumption: once
+}
+
+type Order(#id: string, total: number, status: string)
+type Alert(#order: string, level: string)
+
+rule flag : {o: Order} / o.total > 100 AND o.status == "new" ==>
+    Update(o, {status: "review"}), Insert(Alert(order: o.id, level: "high"))
+rule publish : {a: Alert} / ==> Xuple("alerts", a)
+
+Order(id: "o1", total: 50, status: "new")
+`
+
+func TestPipeline_Simulate(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(simulationBase); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	report, err := pipeline.Simulate(context.Background(), `rule audit : {o: Order} / o.total < 100 ==> Print("petite commande")
+Order(id: "o2", total: 500, status: "new")
+`)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	fired := map[string]int{}
+	for _, activation := range report.Activations {
+		fired[activation.Rule]++
+	}
+	if fired["audit"] != 1 || fired["flag"] != 1 || fired["publish"] != 1 {
+		t.Errorf("activations = %v, want audit, flag and publish once", fired)
+	}
+
+	changes := map[string]string{}
+	for _, change := range report.Facts {
+		changes[change.FactID] = change.Change
+	}
+	want := map[string]string{"Order~o2": rete.FactChangeInserted, "Alert~o2": rete.FactChangeInserted}
+	if len(changes) != len(want) || changes["Order~o2"] != want["Order~o2"] || changes["Alert~o2"] != want["Alert~o2"] {
+		t.Errorf("fact changes = %v, want %v", changes, want)
+	}
+	for _, change := range report.Facts {
+		if change.FactID == "Order~o2" && change.After["status"] != "review" {
+			t.Errorf("Order~o2 after = %v, want status review", change.After)
+		}
+	}
+	if len(report.Xuples) != 1 || report.Xuples[0].Space != "alerts" || report.Xuples[0].Fields["level"] != "high" {
+		t.Errorf("xuples = %+v", report.Xuples)
+	}
+
+	// Le pipeline retrouve son état d'avant la simulation
+	space, err := pipeline.XupleManager().GetXupleSpace("alerts")
+	if err != nil {
+		t.Fatalf("GetXupleSpace() error = %v", err)
+	}
+	if count := space.Count(); count != 0 {
+		t.Errorf("alerts contains %d xuples after the simulation", count)
+	}
+	if statuses := pipeline.RuleStatuses(); len(statuses) != 2 {
+		t.Errorf("RuleStatuses() = %+v, want audit removed", statuses)
+	}
+	if facts := pipeline.network.Storage.GetAllFacts(); len(facts) != 1 || facts[0].Fields["status"] != "new" {
+		t.Errorf("facts after the simulation = %v", facts)
+	}
+
+	// Une nouvelle commande est traitée pour de vrai
+	if _, err := pipeline.IngestString(`Order(id: "o3", total: 500, status: "new")`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if count := space.Count(); count != 1 {
+		t.Errorf("alerts contains %d xuples, want 1", count)
+	}
+}
+
+func TestPipeline_SimulateForbidden(t *testing.T) {
+	pipeline := NewPipeline()
+	if _, err := pipeline.IngestString(simulationBase); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	for _, program := range []string{"reset\n", "remove rule flag\n"} {
+		var apiErr *Error
+		if _, err := pipeline.Simulate(context.Background(), program); !errors.As(err, &apiErr) || apiErr.Type != ErrorTypeValidation {
+			t.Errorf("Simulate(%q) error = %v, want validation error", program, err)
+		}
+	}
+}
+
+func TestPipeline_SimulateLeavesQueriesUnchanged(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	if _, err := pipeline.IngestString(simulationBase + `
+rule held : {o: Order} / o.total > 0 ==> Print(o.id)
+query orders() : {o: Order}
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if err := pipeline.SetRuleState("held", rete.RuleStateDisabled); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if _, err := pipeline.IngestString(`Order(id: "o0", total: 5, status: "old")`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	var events []string
+	var mutex sync.Mutex
+	record := func(kind string) func(QueryRow) {
+		return func(QueryRow) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			events = append(events, kind)
+		}
+	}
+	live, err := pipeline.LiveQuery("orders", nil, record("added"), record("removed"))
+	if err != nil {
+		t.Fatalf("LiveQuery() error = %v", err)
+	}
+	defer live.Close()
+
+	// Les requêtes restent lisibles pendant la simulation et ne voient pas ses faits
+	done := make(chan struct{})
+	counts := make(chan int, 1)
+	go func() {
+		defer close(counts)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if result, err := pipeline.Query("orders"); err != nil || result.Count() != 2 {
+				counts <- result.Count()
+				return
+			}
+		}
+	}()
+
+	report, err := pipeline.Simulate(context.Background(), `Order(id: "o2", total: 500, status: "new")
+remove fact Order o1
+`)
+	close(done)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+	if count, seen := <-counts; seen {
+		t.Errorf("orders returned %d rows during the simulation, want 2", count)
+	}
+	if len(report.Facts) != 3 {
+		t.Errorf("fact changes = %+v, want Order~o2 and Alert~o2 inserted, Order~o1 retracted", report.Facts)
+	}
+
+	result, err := pipeline.Query("orders")
+	if err != nil || result.Count() != 2 {
+		t.Errorf("Query(orders) after the simulation = %+v, %v", result, err)
+	}
+	mutex.Lock()
+	if len(events) != 0 {
+		t.Errorf("live query notified %v during the simulation", events)
+	}
+	mutex.Unlock()
+	if status, _ := pipeline.RuleStatus("held"); status.Pending != 1 {
+		t.Errorf("held rule has %d pending matches, want 1", status.Pending)
+	}
+
+	// La requête suivie voit toujours les ingestions réelles
+	if _, err := pipeline.IngestString(`Order(id: "o3", total: 5, status: "new")`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(events) != 1 || events[0] != "added" {
+		t.Errorf("live query events = %v, want one addition", events)
+	}
+}
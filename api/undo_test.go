@@ -0,0 +1,120 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+func TestPipeline_Undo(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	pipeline.SetUndoable(true)
+
+	if _, err := pipeline.IngestString(`type Account(#id: string, balance: number, status: string)
+type Alert(#id: string)
+rule overdrawn : {a: Account} / a.balance < 0 ==> Insert(Alert(id: a.id)), Update(a, {status: "blocked"})
+Account(id: "a1", balance: 10, status: "open")
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+
+	// L'action modifie le fait inséré et en crée un autre : tout est annulé
+	if _, err := pipeline.IngestString(`Account(id: "a2", balance: -5, status: "open")
+remove fact Account a1
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	storage := pipeline.network.Storage
+	if storage.GetFact("Alert~a2") == nil || storage.GetFact("Account~a1") != nil {
+		t.Fatalf("faits après ingestion = %v", storage.GetAllFacts())
+	}
+
+	if err := pipeline.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	facts := storage.GetAllFacts()
+	if len(facts) != 1 || facts[0].ID != "Account~a1" || facts[0].Fields["status"] != "open" {
+		t.Errorf("faits après Undo = %v", facts)
+	}
+	if err := pipeline.Undo(); !errors.Is(err, rete.ErrNoUndoableTransaction) {
+		t.Errorf("second Undo() error = %v", err)
+	}
+
+	// La règle fonctionne toujours après l'annulation
+	if _, err := pipeline.IngestString(`Account(id: "a3", balance: -1, status: "open")`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if fact := storage.GetFact("Account~a3"); fact == nil || fact.Fields["status"] != "blocked" || storage.GetFact("Alert~a3") == nil {
+		t.Errorf("faits = %v", storage.GetAllFacts())
+	}
+}
+
+func TestPipeline_UndoDepth(t *testing.T) {
+	config := DefaultConfig()
+	config.LogLevel = LogLevelSilent
+	pipeline := NewPipelineWithConfig(config)
+	pipeline.SetUndoDepth(2)
+
+	if _, err := pipeline.IngestString(`type Account(#id: string, balance: number)
+type Alert(#id: string)
+rule overdrawn : {a: Account} / a.balance < 0 ==> Insert(Alert(id: a.id))
+Account(id: "a1", balance: -1)
+`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	for _, program := range []string{`Account(id: "a2", balance: -2)`, `Account(id: "a3", balance: -3)`} {
+		if _, err := pipeline.IngestString(program); err != nil {
+			t.Fatalf("IngestString() error = %v", err)
+		}
+	}
+
+	fired := func(account string) bool {
+		explanation, err := pipeline.Explain("overdrawn", "Account~"+account)
+		if err != nil {
+			t.Fatalf("Explain() error = %v", err)
+		}
+		return explanation.Fired
+	}
+	for _, account := range []string{"a1", "a2", "a3"} {
+		if !fired(account) {
+			t.Fatalf("Explain(%s).Fired = false before Undo", account)
+		}
+	}
+
+	// Deux annulations remontent deux ingestions, activations comprises
+	for i := 0; i < 2; i++ {
+		if err := pipeline.Undo(); err != nil {
+			t.Fatalf("Undo() #%d error = %v", i+1, err)
+		}
+	}
+	storage := pipeline.network.Storage
+	if storage.GetFact("Account~a2") != nil || storage.GetFact("Alert~a3") != nil || storage.GetFact("Alert~a1") == nil {
+		t.Errorf("faits après Undo = %v", storage.GetAllFacts())
+	}
+	if !fired("a1") {
+		t.Error("the activation of the kept ingestion was forgotten")
+	}
+	if records := pipeline.network.Activations("overdrawn"); len(records) != 1 {
+		t.Errorf("activations after Undo = %d, want 1", len(records))
+	}
+
+	// Au-delà de la profondeur, la première ingestion a été validée
+	if err := pipeline.Undo(); !errors.Is(err, rete.ErrNoUndoableTransaction) {
+		t.Errorf("third Undo() error = %v", err)
+	}
+
+	// Une nouvelle activation du même fait n'hérite pas d'une activation annulée
+	if _, err := pipeline.IngestString(`Account(id: "a2", balance: -2)`); err != nil {
+		t.Fatalf("IngestString() error = %v", err)
+	}
+	if records := pipeline.network.Activations("overdrawn", "Account~a2"); len(records) != 1 {
+		t.Errorf("activations of a2 = %d, want 1", len(records))
+	}
+}
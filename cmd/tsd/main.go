@@ -11,7 +11,17 @@ import (
 	"github.com/treivax/tsd/internal/authcmd"
 	"github.com/treivax/tsd/internal/clientcmd"
 	"github.com/treivax/tsd/internal/compilercmd"
+	"github.com/treivax/tsd/internal/explaincmd"
+	"github.com/treivax/tsd/internal/exportcmd"
+	"github.com/treivax/tsd/internal/fmtcmd"
+	"github.com/treivax/tsd/internal/lintcmd"
+	"github.com/treivax/tsd/internal/lspcmd"
+	"github.com/treivax/tsd/internal/networkcmd"
+	"github.com/treivax/tsd/internal/replcmd"
+	"github.com/treivax/tsd/internal/runcmd"
 	"github.com/treivax/tsd/internal/servercmd"
+	"github.com/treivax/tsd/internal/tablecmd"
+	"github.com/treivax/tsd/internal/testcmd"
 )
 
 const (
@@ -22,6 +32,16 @@ const (
 	RoleAuth     = "auth"
 	RoleClient   = "client"
 	RoleServer   = "server"
+	RoleExplain  = "explain"
+	RoleTest     = "test"
+	RoleLSP      = "lsp"
+	RoleFmt      = "fmt"
+	RoleLint     = "lint"
+	RoleRepl     = "repl"
+	RoleNetwork  = "network"
+	RoleExport   = "export"
+	RoleRun      = "run"
+	RoleTable    = "table"
 	RoleCompiler = "" // Rôle par défaut (compilateur)
 
 	// Exit codes standards
@@ -67,7 +87,7 @@ func determineRole() string {
 
 	// Vérifier si le premier argument est un rôle connu
 	switch firstArg {
-	case RoleAuth, RoleClient, RoleServer:
+	case RoleAuth, RoleClient, RoleServer, RoleExplain, RoleTest, RoleLSP, RoleFmt, RoleLint, RoleRepl, RoleNetwork, RoleExport, RoleRun, RoleTable:
 		return firstArg
 	default:
 		// Pas un rôle connu: comportement par défaut (compilateur)
@@ -90,6 +110,46 @@ func dispatch(role string) int {
 		// Exécuter la commande server
 		return servercmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
 
+	case RoleExplain:
+		// Expliquer le déclenchement (ou non) d'une règle
+		return explaincmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleTest:
+		// Exécuter les tests de règles (.tsdtest)
+		return testcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleLSP:
+		// Serveur de langage pour les éditeurs (stdin/stdout)
+		return lspcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleFmt:
+		// Formater les fichiers .tsd
+		return fmtcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleLint:
+		// Analyser statiquement les fichiers .tsd
+		return lintcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleRepl:
+		// Session interactive sur un réseau de règles
+		return replcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleNetwork:
+		// Exporter le réseau RETE compilé (DOT, Mermaid, JSON)
+		return networkcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleExport:
+		// Exporter les faits de la mémoire de travail (TSD, JSON, NDJSON, CSV)
+		return exportcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleRun:
+		// Traiter un flux continu d'événements NDJSON (stdin, tube nommé, socket Unix)
+		return runcmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
+	case RoleTable:
+		// Compiler des tables de décision CSV en règles TSD
+		return tablecmd.Run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+
 	case RoleCompiler:
 		// Exécuter le compilateur/runner avec tous les arguments
 		return compilercmd.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
@@ -114,6 +174,16 @@ func printGlobalHelp() {
 	fmt.Println("  auth            Gestion de l'authentification (clés API, JWT)")
 	fmt.Println("  client          Client HTTP pour communiquer avec tsd-server")
 	fmt.Println("  server          Serveur HTTP TSD")
+	fmt.Println("  explain         Expliquer pourquoi une règle s'est déclenchée (ou non)")
+	fmt.Println("  test            Exécuter les tests de règles (.tsdtest)")
+	fmt.Println("  lsp             Serveur de langage (LSP) pour les éditeurs")
+	fmt.Println("  fmt             Formater les fichiers .tsd (forme canonique)")
+	fmt.Println("  lint            Analyse statique des règles (erreurs probables)")
+	fmt.Println("  repl            Session interactive (faits, règles, annulation)")
+	fmt.Println("  network         Exporter le réseau RETE (Graphviz DOT, Mermaid, JSON)")
+	fmt.Println("  export          Exporter les faits après exécution (TSD, JSON, NDJSON, CSV)")
+	fmt.Println("  run             Traiter un flux continu d'événements NDJSON (stdin, socket)")
+	fmt.Println("  table           Compiler des tables de décision CSV en règles TSD")
 	fmt.Println("")
 	fmt.Println("OPTIONS GLOBALES:")
 	fmt.Println("  --help, -h      Afficher cette aide")
@@ -143,10 +213,54 @@ func printGlobalHelp() {
 	fmt.Println("  tsd server -port 8443 -auth jwt -jwt-secret \"mon-secret\"")
 	fmt.Println("  tsd server -insecure  # HTTP non sécurisé (déconseillé)")
 	fmt.Println("")
+	fmt.Println("  # Pourquoi customerOrders ne s'est-elle pas déclenchée pour la commande 42 ?")
+	fmt.Println("  tsd explain -rule customerOrders program.tsd Order~42")
+	fmt.Println("")
+	fmt.Println("  # Tests de règles avec couverture et rapport JUnit")
+	fmt.Println("  tsd test -coverage -junit report.xml rules/")
+	fmt.Println("")
+	fmt.Println("  # Serveur de langage, lancé par l'éditeur")
+	fmt.Println("  tsd lsp")
+	fmt.Println("")
+	fmt.Println("  # Formater les règles, ou vérifier le formatage en CI")
+	fmt.Println("  tsd fmt -w rules/")
+	fmt.Println("  tsd fmt -check rules/")
+	fmt.Println("")
+	fmt.Println("  # Détecter les règles contradictoires, dupliquées ou mal jointes")
+	fmt.Println("  tsd lint rules/")
+	fmt.Println("  tsd lint -format sarif rules/ > lint.sarif")
+	fmt.Println("")
+	fmt.Println("  # Explorer une session de règles de façon interactive")
+	fmt.Println("  tsd repl rules.tsd")
+	fmt.Println("")
+	fmt.Println("  # Visualiser le réseau RETE compilé")
+	fmt.Println("  tsd network rules.tsd | dot -Tsvg > network.svg")
+	fmt.Println("  tsd network -format mermaid -rule customerOrders rules.tsd")
+	fmt.Println("")
+	fmt.Println("  # Exporter les faits dérivés pour un autre système")
+	fmt.Println("  tsd export rules.tsd facts.tsd > snapshot.tsd")
+	fmt.Println("  tsd export -format csv -o export/ rules.tsd facts.tsd")
+	fmt.Println("")
+	fmt.Println("  # Insérer TSD dans un pipeline shell")
+	fmt.Println("  producer | tsd run -rules rules.tsd | consumer")
+	fmt.Println("")
+	fmt.Println("  # Compiler une table de décision tenue dans un tableur")
+	fmt.Println("  tsd table compile pricing.csv > pricing.tsd")
+	fmt.Println("")
 	fmt.Println("AIDE SPÉCIFIQUE À UN RÔLE:")
 	fmt.Println("  tsd auth --help")
 	fmt.Println("  tsd client --help")
 	fmt.Println("  tsd server --help")
+	fmt.Println("  tsd explain --help")
+	fmt.Println("  tsd test --help")
+	fmt.Println("  tsd lsp --help")
+	fmt.Println("  tsd fmt --help")
+	fmt.Println("  tsd lint --help")
+	fmt.Println("  tsd repl --help")
+	fmt.Println("  tsd network --help")
+	fmt.Println("  tsd export --help")
+	fmt.Println("  tsd run --help")
+	fmt.Println("  tsd table --help")
 	fmt.Println("  tsd --help          (aide du compilateur)")
 	fmt.Println("")
 	fmt.Println("TLS/HTTPS:")
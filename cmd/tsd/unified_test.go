@@ -37,6 +37,56 @@ func TestDetermineRole(t *testing.T) {
 			args:     []string{"tsd", "server", "-port", "8080"},
 			expected: RoleServer,
 		},
+		{
+			name:     "explain role",
+			args:     []string{"tsd", "explain", "-rule", "r", "program.tsd"},
+			expected: RoleExplain,
+		},
+		{
+			name:     "test role",
+			args:     []string{"tsd", "test", "rules/"},
+			expected: RoleTest,
+		},
+		{
+			name:     "lsp role",
+			args:     []string{"tsd", "lsp"},
+			expected: RoleLSP,
+		},
+		{
+			name:     "fmt role",
+			args:     []string{"tsd", "fmt", "-check", "rules/"},
+			expected: RoleFmt,
+		},
+		{
+			name:     "lint role",
+			args:     []string{"tsd", "lint", "-format", "sarif", "rules/"},
+			expected: RoleLint,
+		},
+		{
+			name:     "repl role",
+			args:     []string{"tsd", "repl", "rules.tsd"},
+			expected: RoleRepl,
+		},
+		{
+			name:     "network role",
+			args:     []string{"tsd", "network", "--format", "mermaid", "rules.tsd"},
+			expected: RoleNetwork,
+		},
+		{
+			name:     "export role",
+			args:     []string{"tsd", "export", "-format", "ndjson", "rules.tsd"},
+			expected: RoleExport,
+		},
+		{
+			name:     "run role",
+			args:     []string{"tsd", "run", "-rules", "rules.tsd", "-input", "-"},
+			expected: RoleRun,
+		},
+		{
+			name:     "table role",
+			args:     []string{"tsd", "table", "compile", "pricing.csv"},
+			expected: RoleTable,
+		},
 		{
 			name:     "file argument - default compiler",
 			args:     []string{"tsd", "program.tsd"},
@@ -147,6 +197,16 @@ func TestRoleConstants(t *testing.T) {
 		{"auth role", RoleAuth, "auth"},
 		{"client role", RoleClient, "client"},
 		{"server role", RoleServer, "server"},
+		{"explain role", RoleExplain, "explain"},
+		{"test role", RoleTest, "test"},
+		{"lsp role", RoleLSP, "lsp"},
+		{"fmt role", RoleFmt, "fmt"},
+		{"lint role", RoleLint, "lint"},
+		{"repl role", RoleRepl, "repl"},
+		{"network role", RoleNetwork, "network"},
+		{"export role", RoleExport, "export"},
+		{"run role", RoleRun, "run"},
+		{"table role", RoleTable, "table"},
 		{"compiler role", RoleCompiler, ""},
 	}
 
@@ -214,6 +274,16 @@ func TestDispatch_ValidRoles(t *testing.T) {
 		{"auth role", RoleAuth},
 		{"client role", RoleClient},
 		{"server role", RoleServer},
+		{"explain role", RoleExplain},
+		{"test role", RoleTest},
+		{"lsp role", RoleLSP},
+		{"fmt role", RoleFmt},
+		{"lint role", RoleLint},
+		{"repl role", RoleRepl},
+		{"network role", RoleNetwork},
+		{"export role", RoleExport},
+		{"run role", RoleRun},
+		{"table role", RoleTable},
 		{"compiler role", RoleCompiler},
 	}
 
@@ -224,6 +294,16 @@ func TestDispatch_ValidRoles(t *testing.T) {
 				RoleAuth:     true,
 				RoleClient:   true,
 				RoleServer:   true,
+				RoleExplain:  true,
+				RoleTest:     true,
+				RoleLSP:      true,
+				RoleFmt:      true,
+				RoleLint:     true,
+				RoleRepl:     true,
+				RoleNetwork:  true,
+				RoleExport:   true,
+				RoleRun:      true,
+				RoleTable:    true,
 				RoleCompiler: true,
 			}
 
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"strings"
+	"testing"
+)
+
+const controlFlowTypes = `type Customer(#id: string, tier: string, spent: number)
+action discount(customer: string, rate: number)
+action flag(customer: string, label: string)
+`
+
+func TestParseActionControlFlow(t *testing.T) {
+	program := parseQueryProgram(t, controlFlowTypes+`
+rule discounts : {c: Customer} / c.spent > 0 ==>
+    let base = c.spent * 0.01,
+    if c.tier == "gold" then { discount(c.id, base * 2), flag(c.id, "gold") }
+    else if c.tier == "silver" then discount(c.id, base)
+    else flag(c.id, (c.spent > 1000 ? "watch" : "standard"))
+`)
+
+	if err := ValidateConstraintProgram(program); err != nil {
+		t.Fatalf("ValidateConstraintProgram() error = %v", err)
+	}
+
+	jobs := program.Expressions[0].Action.GetJobs()
+	if len(jobs) != 2 || jobs[0].Type != JobTypeLet || jobs[1].Type != JobTypeIf {
+		t.Fatalf("jobs = %+v", jobs)
+	}
+	if jobs[0].Name != "base" || jobs[0].Value == nil {
+		t.Errorf("let = %+v", jobs[0])
+	}
+
+	ifBlock := jobs[1]
+	if len(ifBlock.Then) != 2 || len(ifBlock.Else) != 1 || ifBlock.Else[0].Type != JobTypeIf {
+		t.Fatalf("if = %+v", ifBlock)
+	}
+	elseIf := ifBlock.Else[0]
+	if len(elseIf.Else) != 1 || elseIf.Else[0].Name != "flag" {
+		t.Fatalf("else = %+v", elseIf.Else)
+	}
+	ternary, _ := elseIf.Else[0].Args[1].(map[string]interface{})
+	if ternary["type"] != ExprTypeTernary {
+		t.Errorf("flag label = %v, want ternary", elseIf.Else[0].Args[1])
+	}
+}
+
+func TestValidateActionControlFlowErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		wantErr string
+	}{
+		{
+			name:    "let redefines rule variable",
+			action:  `let c = 1, flag("x", "y")`,
+			wantErr: "already bound",
+		},
+		{
+			name:    "let used with wrong type",
+			action:  `let label = 1, flag(c.id, label)`,
+			wantErr: "type mismatch",
+		},
+		{
+			name:    "unknown variable in condition",
+			action:  `if x.tier == "gold" then flag(c.id, "gold")`,
+			wantErr: "'x'",
+		},
+		{
+			name:    "ternary branches differ",
+			action:  `flag(c.id, (c.spent > 10 ? "big" : 1))`,
+			wantErr: "different types",
+		},
+		{
+			name:    "let is local to its branch",
+			action:  `if c.spent > 10 then { let label = "big", flag(c.id, label) }, flag(c.id, label)`,
+			wantErr: "label",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parseQueryProgram(t, controlFlowTypes+"rule r : {c: Customer} / c.spent > 0 ==> "+tt.action+"\n")
+			err := ValidateConstraintProgram(program)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateConstraintProgram() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
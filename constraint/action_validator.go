@@ -116,6 +116,105 @@ func (av *ActionValidator) ValidateActionCall(jobCall *JobCall, ruleVariables ma
 	return nil
 }
 
+// ValidateActionStatements validates the statements of an action in order.
+// A let binding adds its name and inferred type to the scope of the following
+// statements; the branches of an if block are validated in their own scope.
+func (av *ActionValidator) ValidateActionStatements(jobs []JobCall, ruleVariables map[string]string) error {
+	scope := make(map[string]string, len(ruleVariables))
+	for name, varType := range ruleVariables {
+		scope[name] = varType
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		switch job.Type {
+		case JobTypeLet:
+			if _, exists := scope[job.Name]; exists {
+				return fmt.Errorf("let '%s': name is already bound", sanitizeForLog(job.Name, 50))
+			}
+			valueType, err := av.inferArgumentType(job.Value, scope, 0)
+			if err != nil {
+				return fmt.Errorf("let '%s': %v", sanitizeForLog(job.Name, 50), err)
+			}
+			scope[job.Name] = valueType
+		case JobTypeIf:
+			if err := av.validateCondition(job.Condition, scope, 0); err != nil {
+				return fmt.Errorf("if condition: %v", err)
+			}
+			if err := av.ValidateActionStatements(job.Then, scope); err != nil {
+				return err
+			}
+			if err := av.ValidateActionStatements(job.Else, scope); err != nil {
+				return err
+			}
+		default:
+			if err := av.ValidateActionCall(job, scope); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateCondition checks that a condition of an if block or a ternary only
+// references bound variables, existing fields and compares compatible types.
+func (av *ActionValidator) validateCondition(condition interface{}, scope map[string]string, depth int) error {
+	if depth > MaxValidationDepth {
+		return fmt.Errorf("maximum validation depth exceeded (%d)", MaxValidationDepth)
+	}
+
+	condMap, ok := condition.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected condition structure")
+	}
+
+	switch condMap["type"] {
+	case ConstraintTypeLogicalExpr:
+		if err := av.validateCondition(condMap["left"], scope, depth+1); err != nil {
+			return err
+		}
+		operations, _ := condMap["operations"].([]interface{})
+		for _, op := range operations {
+			opMap, _ := op.(map[string]interface{})
+			if err := av.validateCondition(opMap["right"], scope, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "notConstraint":
+		return av.validateCondition(condMap["expression"], scope, depth+1)
+	case ConstraintTypeComparison:
+		return av.validateComparison(condMap, scope)
+	default:
+		return fmt.Errorf("unsupported condition type: %v", condMap["type"])
+	}
+}
+
+// validateComparison checks the operands of a comparison used as a condition
+func (av *ActionValidator) validateComparison(condMap map[string]interface{}, scope map[string]string) error {
+	for _, operand := range []interface{}{condMap["left"], condMap["right"]} {
+		for _, varName := range extractVariablesFromArg(operand) {
+			if _, exists := scope[varName]; !exists {
+				return fmt.Errorf("variable '%s' not found in rule", sanitizeForLog(varName, 50))
+			}
+		}
+	}
+
+	// Operands whose type cannot be inferred (array literals, ...) are checked at runtime
+	leftType, leftErr := av.inferArgumentType(condMap["left"], scope, 0)
+	rightType, rightErr := av.inferArgumentType(condMap["right"], scope, 0)
+	if leftErr != nil || rightErr != nil {
+		return nil
+	}
+
+	operator, _ := condMap["operator"].(string)
+	if isComparisonOperator(operator) && leftType != rightType {
+		return fmt.Errorf("type incompatibility in comparison: %s vs %s",
+			sanitizeForLog(leftType, 50), sanitizeForLog(rightType, 50))
+	}
+	return nil
+}
+
 // inferArgumentType infers the type of an argument expression with recursion depth tracking.
 func (av *ActionValidator) inferArgumentType(arg interface{}, ruleVariables map[string]string, depth int) (string, error) {
 	if depth > MaxValidationDepth {
@@ -154,6 +253,8 @@ func (av *ActionValidator) inferComplexType(argType string, argMap map[string]in
 		return av.inferFunctionCallType(argMap)
 	case "inlineFact":
 		return av.inferInlineFactType(argMap)
+	case ExprTypeTernary:
+		return av.inferTernaryType(argMap, ruleVariables)
 	case "updateWithModifications":
 		// Type spécial pour Update(variable, {...}) transformé par le parser
 		// Retourne "any" car c'est une structure complexe avec variable + modifications
@@ -235,6 +336,28 @@ func (av *ActionValidator) inferBinaryOpType(argMap map[string]interface{}) (str
 	return "", fmt.Errorf("unknown operator '%s'", sanitizeForLog(op, 20))
 }
 
+// inferTernaryType infers the type of a conditional expression.
+// Both branches must have the same type.
+func (av *ActionValidator) inferTernaryType(argMap map[string]interface{}, ruleVariables map[string]string) (string, error) {
+	if err := av.validateCondition(argMap["condition"], ruleVariables, 0); err != nil {
+		return "", fmt.Errorf("ternary condition: %v", err)
+	}
+
+	thenType, err := av.inferArgumentType(argMap["then"], ruleVariables, 0)
+	if err != nil {
+		return "", err
+	}
+	elseType, err := av.inferArgumentType(argMap["else"], ruleVariables, 0)
+	if err != nil {
+		return "", err
+	}
+	if thenType != elseType {
+		return "", fmt.Errorf("ternary branches have different types: %s vs %s",
+			sanitizeForLog(thenType, 50), sanitizeForLog(elseType, 50))
+	}
+	return thenType, nil
+}
+
 // inferFunctionCallType infers the type of a function call
 func (av *ActionValidator) inferFunctionCallType(argMap map[string]interface{}) (string, error) {
 	funcName, ok := argMap["name"].(string)
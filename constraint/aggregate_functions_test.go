@@ -0,0 +1,76 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExtendedAggregateFunctionsSyntax(t *testing.T) {
+	program := parseQueryProgram(t, `type Product(#id: string)
+type Review(#id: string, product: string, score: number, day: number, author: string)
+action report(product: string, median: number, author: string, names: string)
+
+rule stats : {p: Product, med: median(r.score), p95: PERCENTILE(r.score, 95), sd: STDDEV(r.score), n: COUNT_DISTINCT(r.author), first: FIRST(r.author, r.day), names: STRING_AGG(r.author, " / "), tags: COLLECT_SET(r.author)} / {r: Review} / r.product == p.id ==> report(p.id, med, first, names)
+`)
+	if len(program.Expressions) != 1 {
+		t.Fatalf("expressions = %d, want 1", len(program.Expressions))
+	}
+
+	want := map[string]string{
+		"med":   "MEDIAN []",
+		"p95":   "PERCENTILE [map[type:number value:95]]",
+		"sd":    "STDDEV []",
+		"n":     "COUNT_DISTINCT []",
+		"first": "FIRST [map[field:day object:r type:fieldAccess]]",
+		"names": "STRING_AGG [map[type:string value: / ]]",
+		"tags":  "COLLECT_SET []",
+	}
+	for _, v := range program.Expressions[0].Patterns[0].Variables {
+		if v.Type != VariableTypeAggregation {
+			continue
+		}
+		if got := fmt.Sprintf("%s %v", v.Function, StripPositions(v.Args)); got != want[v.Name] {
+			t.Errorf("%s = %q, want %q", v.Name, got, want[v.Name])
+		}
+		delete(want, v.Name)
+	}
+	if len(want) != 0 {
+		t.Errorf("missing aggregation variables: %v", want)
+	}
+
+	if err := ValidateActionCalls(program); err != nil {
+		t.Errorf("ValidateActionCalls() error = %v", err)
+	}
+}
+
+func TestAggregationResultTypeInActions(t *testing.T) {
+	program := parseQueryProgram(t, `type Product(#id: string)
+type Review(#id: string, product: string, score: number, author: string)
+action report(product: string, count: string)
+
+rule stats : {p: Product, n: COUNT_DISTINCT(r.author)} / {r: Review} / r.product == p.id ==> report(p.id, n)
+`)
+	if err := ValidateActionCalls(program); err == nil {
+		t.Error("expected type mismatch for numeric aggregate passed as string")
+	}
+}
+
+func TestAccumulateConstraintArguments(t *testing.T) {
+	result, err := ParseConstraint("accumulate.tsd", []byte(`type Review(#id: string, score: number)
+rule high : {r: Review} / PERCENTILE(x: Review / x.score > 0; x.score, 90) > 4 ==> print("high")
+`))
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	got := fmt.Sprint(StripPositions(result))
+	for _, want := range []string{"accumulateConstraint", "function:PERCENTILE", "args:[map[type:number value:90]]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("parse result missing %q: %s", want, got)
+		}
+	}
+}
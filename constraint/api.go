@@ -30,7 +30,7 @@ func ReadFileContent(filename string) (string, error) {
 //	content, _ := os.ReadFile("rules.constraint")
 //	ast, err := ParseConstraint("rules.constraint", content)
 func ParseConstraint(filename string, input []byte) (interface{}, error) {
-	return Parse(filename, input)
+	return Parse(filename, input, GlobalStore(filenameStoreKey, filename))
 }
 
 // ValidateConstraintProgram validates a parsed constraint program AST.
@@ -65,7 +65,22 @@ func ValidateConstraintProgram(result interface{}) error {
 //
 //	ast, err := ParseConstraintFile("rules.constraint")
 func ParseConstraintFile(filename string) (interface{}, error) {
-	return ParseFile(filename)
+	return ParseFile(filename, GlobalStore(filenameStoreKey, filename))
+}
+
+// ParseConstraintFileWithImports parses a constraint file together with the files
+// it imports, and qualifies the names declared in packages.
+// The returned AST merges all loaded files, imported files first.
+//
+// Example:
+//
+//	ast, err := ParseConstraintFileWithImports("billing/rules.tsd")
+func ParseConstraintFileWithImports(filename string) (interface{}, error) {
+	ast, err := NewModuleLoader().Load(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ast, nil
 }
 
 // ParseFactsFile parses a .facts file using the constraint grammar.
@@ -76,7 +91,7 @@ func ParseConstraintFile(filename string) (interface{}, error) {
 //
 //	facts, err := ParseFactsFile("data.facts")
 func ParseFactsFile(filename string) (interface{}, error) {
-	return ParseFile(filename)
+	return ParseFile(filename, GlobalStore(filenameStoreKey, filename))
 }
 
 // ExtractFactsFromProgram extracts facts from a parsed program and converts them to RETE format
@@ -136,14 +151,12 @@ func ValidateActionCalls(program *Program) error {
 	// Validate action calls in each rule
 	for _, expr := range program.Expressions {
 		// Build map of rule variables to their types
-		ruleVariables := extractRuleVariablesFromExpression(&expr)
+		ruleVariables := extractRuleVariablesFromExpression(&expr, program.Types)
 
-		// Validate each action call
+		// Validate each action statement
 		if expr.Action != nil {
-			for _, job := range expr.Action.GetJobs() {
-				if err := validator.ValidateActionCall(&job, ruleVariables); err != nil {
-					return fmt.Errorf("rule '%s': %v", expr.RuleId, err)
-				}
+			if err := validator.ValidateActionStatements(expr.Action.GetJobs(), ruleVariables); err != nil {
+				return fmt.Errorf("rule '%s': %v", expr.RuleId, err)
 			}
 		}
 	}
@@ -174,8 +187,9 @@ func loadDefaultActionsInternal() ([]ActionDefinition, error) {
 	return program.Actions, nil
 }
 
-// extractRuleVariablesFromExpression extracts all variables and their types from an expression
-func extractRuleVariablesFromExpression(expr *Expression) map[string]string {
+// extractRuleVariablesFromExpression extracts all variables and their types from an expression.
+// Aggregation variables are typed by the value their function produces.
+func extractRuleVariablesFromExpression(expr *Expression, types []TypeDefinition) map[string]string {
 	ruleVariables := make(map[string]string)
 
 	// Extract variables from Set (single pattern, backward compatibility)
@@ -192,11 +206,46 @@ func extractRuleVariablesFromExpression(expr *Expression) map[string]string {
 				ruleVariables[v.Name] = v.DataType
 			}
 		}
+		for _, pattern := range expr.Patterns {
+			for _, v := range pattern.Variables {
+				if v.Type == VariableTypeAggregation {
+					ruleVariables[v.Name] = aggregationResultType(v, ruleVariables, types)
+				}
+			}
+		}
 	}
 
 	return ruleVariables
 }
 
+// aggregationResultType returns the type of the value produced by an aggregation variable
+func aggregationResultType(v TypedVariable, ruleVariables map[string]string, types []TypeDefinition) string {
+	switch v.Function {
+	case "STRING_AGG":
+		return "string"
+	case "COLLECT_SET":
+		return "any"
+	case "FIRST", "LAST":
+		// Type of the aggregated field
+		field, _ := v.Field.(map[string]interface{})
+		object, _ := field["object"].(string)
+		fieldName, _ := field["field"].(string)
+		for _, typeDef := range types {
+			if typeDef.Name != ruleVariables[object] {
+				continue
+			}
+			for _, f := range typeDef.Fields {
+				if f.Name == fieldName {
+					return f.Type
+				}
+			}
+		}
+		return "any"
+	default:
+		return "number"
+	}
+}
+
 // ConvertResultToProgram converts parser result to Program structure
 func ConvertResultToProgram(result interface{}) (*Program, error) {
 	// Convert result to Program structure
@@ -232,6 +281,19 @@ func ConvertToReteProgram(program *Program) (interface{}, error) {
 		return nil, fmt.Errorf("error converting expressions: %w", err)
 	}
 
+	// Les positions ne sont pas transmises au réseau : deux conditions
+	// identiques partagent leurs nœuds quel que soit leur emplacement dans le
+	// source. Seule la position de chaque règle est conservée.
+	typesInterface = StripPositions(typesInterface).([]interface{})
+	actionsInterface = StripPositions(actionsInterface).([]interface{})
+	for i, expression := range expressionsInterface {
+		exprMap := StripPositions(expression).(map[string]interface{})
+		if pos, ok := expression.(map[string]interface{})[JSONKeyPos]; ok {
+			exprMap[JSONKeyPos] = pos
+		}
+		expressionsInterface[i] = exprMap
+	}
+
 	// Create program structure
 	reteProgram := map[string]interface{}{
 		JSONKeyTypes:       typesInterface,
@@ -32,21 +32,52 @@ func ValidateAction(program Program, action Action, expressionIndex int) error {
 	}
 
 	// Obtenir tous les jobs (supporte ancien et nouveau format)
-	jobs := action.GetJobs()
+	return validateJobVariables(action.GetJobs(), availableVars)
+}
+
+// validateJobVariables vérifie que les instructions d'une action ne référencent que des variables liées.
+// Un let lie son nom pour les instructions suivantes ; les branches d'un if ont leur propre portée.
+func validateJobVariables(jobs []JobCall, availableVars map[string]bool) error {
+	scope := make(map[string]bool, len(availableVars))
+	for name := range availableVars {
+		scope[name] = true
+	}
 
-	// Vérifier que tous les arguments de chaque job référencent des variables valides
 	for _, job := range jobs {
-		for _, arg := range job.Args {
-			// Extraire les variables utilisées dans l'argument
-			vars := extractVariablesFromArg(arg)
-			for _, varName := range vars {
-				if !availableVars[varName] {
-					return fmt.Errorf("action %s: argument contient la variable '%s' qui ne correspond à aucune variable de l'expression", job.Name, varName)
+		switch job.Type {
+		case JobTypeLet:
+			if err := checkVariablesInScope(job.Value, scope); err != nil {
+				return fmt.Errorf("let %s: %v", job.Name, err)
+			}
+			scope[job.Name] = true
+		case JobTypeIf:
+			if err := checkVariablesInScope(job.Condition, scope); err != nil {
+				return fmt.Errorf("if: %v", err)
+			}
+			if err := validateJobVariables(job.Then, scope); err != nil {
+				return err
+			}
+			if err := validateJobVariables(job.Else, scope); err != nil {
+				return err
+			}
+		default:
+			for _, arg := range job.Args {
+				if err := checkVariablesInScope(arg, scope); err != nil {
+					return fmt.Errorf("action %s: %v", job.Name, err)
 				}
 			}
 		}
 	}
+	return nil
+}
 
+// checkVariablesInScope vérifie que toutes les variables d'une expression sont liées
+func checkVariablesInScope(expr interface{}, scope map[string]bool) error {
+	for _, varName := range extractVariablesFromArg(expr) {
+		if !scope[varName] {
+			return fmt.Errorf("argument contient la variable '%s' qui ne correspond à aucune variable de l'expression", varName)
+		}
+	}
 	return nil
 }
 
@@ -78,6 +109,14 @@ func extractVariablesByType(argType string, argMap map[string]interface{}) []str
 		return []string{} // Literals ne contiennent pas de variables
 	case ArgTypeFunctionCall:
 		return extractFromFunctionCall(argMap)
+	case ExprTypeTernary:
+		return extractFromTernary(argMap)
+	case ConstraintTypeComparison:
+		return extractFromBinaryOp(argMap)
+	case ConstraintTypeLogicalExpr:
+		return extractFromLogicalExpr(argMap)
+	case "notConstraint":
+		return extractVariablesFromArg(argMap["expression"])
 	default:
 		if isBinaryOperationType(argType) {
 			return extractFromBinaryOp(argMap)
@@ -124,3 +163,22 @@ func extractFromBinaryOp(argMap map[string]interface{}) []string {
 	}
 	return vars
 }
+
+// extractFromTernary extrait les variables de la condition et des deux branches d'un ternaire
+func extractFromTernary(argMap map[string]interface{}) []string {
+	vars := extractVariablesFromArg(argMap["condition"])
+	vars = append(vars, extractVariablesFromArg(argMap["then"])...)
+	return append(vars, extractVariablesFromArg(argMap["else"])...)
+}
+
+// extractFromLogicalExpr extrait les variables de chaque opérande d'une expression logique
+func extractFromLogicalExpr(argMap map[string]interface{}) []string {
+	vars := extractVariablesFromArg(argMap["left"])
+	operations, _ := argMap["operations"].([]interface{})
+	for _, op := range operations {
+		if opMap, ok := op.(map[string]interface{}); ok {
+			vars = append(vars, extractVariablesFromArg(opMap["right"])...)
+		}
+	}
+	return vars
+}
@@ -13,8 +13,26 @@ const (
 	ConstraintTypeComparison  = "comparison"
 	ConstraintTypeLogicalExpr = "logicalExpr"
 	ConstraintTypeBinaryOp    = "binaryOp"
+	ConstraintTypeForall      = "forallConstraint"
 )
 
+// Variable kind constants identify the entries of a pattern set.
+const (
+	VariableTypeTyped       = "typedVariable"
+	VariableTypeCollect     = "collectVariable"
+	VariableTypeAggregation = "aggregationVariable"
+)
+
+// Action statement type constants identify the entries of an action list.
+const (
+	JobTypeCall = "jobCall"
+	JobTypeLet  = "letBinding"
+	JobTypeIf   = "ifBlock"
+)
+
+// ExprTypeTernary identifies a conditional expression (cond ? a : b).
+const ExprTypeTernary = "ternary"
+
 // Value type constants define the different types of values
 // that can be used in constraints and facts.
 const (
@@ -11,25 +11,28 @@ import (
 // ValidateFacts vérifie que tous les faits parsés sont cohérents avec les définitions de types
 func ValidateFacts(program Program) error {
 	definedTypes := make(map[string]TypeDefinition)
+	typeNames := make([]string, 0, len(program.Types))
 	for _, typeDef := range program.Types {
 		definedTypes[typeDef.Name] = typeDef
+		typeNames = append(typeNames, typeDef.Name)
 	}
 
 	for i, fact := range program.Facts {
 		// Vérifier que le type du fait existe
 		typeDef, exists := definedTypes[fact.TypeName]
 		if !exists {
-			return fmt.Errorf("fait %d: type non défini: %s", i+1, fact.TypeName)
+			return diagnosticf(fact.Pos, DiagnosticCodeUnknownType, didYouMean(fact.TypeName, typeNames),
+				"fait %d: type non défini: %s", i+1, fact.TypeName)
 		}
 
 		// Valider la clé primaire du fait
 		if err := ValidateFactPrimaryKey(fact, typeDef); err != nil {
-			return fmt.Errorf("fait %d: %v", i+1, err)
+			return atSpan(fact.Pos, DiagnosticCodeInvalidFact, fmt.Errorf("fait %d: %w", i+1, err))
 		}
 
 		// Valider les valeurs des champs PK
 		if err := ValidateFactPrimaryKeyValues(fact, typeDef); err != nil {
-			return fmt.Errorf("fait %d: %v", i+1, err)
+			return atSpan(fact.Pos, DiagnosticCodeInvalidFact, fmt.Errorf("fait %d: %w", i+1, err))
 		}
 
 		// Créer une map des champs définis pour ce type
@@ -43,13 +46,18 @@ func ValidateFacts(program Program) error {
 			// Vérifier que le champ existe dans le type
 			expectedType, exists := definedFields[factField.Name]
 			if !exists {
-				return fmt.Errorf("fait %d, champ %d: champ '%s' non défini dans le type %s", i+1, j+1, factField.Name, fact.TypeName)
+				fieldNames := make([]string, 0, len(typeDef.Fields))
+				for _, field := range typeDef.Fields {
+					fieldNames = append(fieldNames, field.Name)
+				}
+				return diagnosticf(firstSpan(factField.Pos, fact.Pos), DiagnosticCodeUnknownField, didYouMean(factField.Name, fieldNames),
+					"fait %d, champ %d: champ '%s' non défini dans le type %s", i+1, j+1, factField.Name, fact.TypeName)
 			}
 
 			// Vérifier la compatibilité du type de la valeur
 			err := ValidateFactFieldType(factField.Value, expectedType, fact.TypeName, factField.Name)
 			if err != nil {
-				return fmt.Errorf("fait %d, champ %d: %v", i+1, j+1, err)
+				return atSpan(firstSpan(factField.Value.Pos, factField.Pos, fact.Pos), DiagnosticCodeTypeMismatch, fmt.Errorf("fait %d, champ %d: %w", i+1, j+1, err))
 			}
 		}
 	}
@@ -26,12 +26,12 @@ func ValidateFieldAccess(program Program, fieldAccess FieldAccess, expressionInd
 	// Use helper to find variable type
 	objectType, err := findVariableType(expr, fieldAccess.Object)
 	if err != nil {
-		return fmt.Errorf("in expression %d: %v", expressionIndex+1, err)
+		return atSpan(fieldAccess.Pos, DiagnosticCodeUnknownVariable, fmt.Errorf("in expression %d: %v", expressionIndex+1, err))
 	}
 
 	// Le champ '_id_' est INTERDIT dans les expressions TSD
 	if fieldAccess.Field == FieldNameInternalID {
-		return fmt.Errorf(
+		return diagnosticf(fieldAccess.Pos, DiagnosticCodeUnknownField, "",
 			"le champ '%s' est interne et ne peut pas être accédé dans les expressions",
 			FieldNameInternalID,
 		)
@@ -40,7 +40,7 @@ func ValidateFieldAccess(program Program, fieldAccess FieldAccess, expressionInd
 	// Vérifier que le champ existe dans le type
 	fields, err := GetTypeFields(program, objectType)
 	if err != nil {
-		return err
+		return atSpan(fieldAccess.Pos, DiagnosticCodeUnknownType, err)
 	}
 
 	for _, field := range fields {
@@ -49,8 +49,18 @@ func ValidateFieldAccess(program Program, fieldAccess FieldAccess, expressionInd
 		}
 	}
 
-	return fmt.Errorf("field %s not found in type %s",
-		sanitizeForLog(fieldAccess.Field, 50), sanitizeForLog(objectType, 50))
+	return unknownFieldError(fieldAccess.Pos, fieldAccess.Field, objectType, fields)
+}
+
+// unknownFieldError signale un champ absent d'un type, en proposant le champ
+// au nom le plus proche
+func unknownFieldError(span *Span, field, typeName string, fields []Field) error {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return diagnosticf(span, DiagnosticCodeUnknownField, didYouMean(field, names),
+		"field %s not found in type %s", sanitizeForLog(field, 50), sanitizeForLog(typeName, 50))
 }
 
 // ValidateConstraintFieldAccess parcourt récursivement les contraintes pour valider les accès aux champs
@@ -79,6 +89,7 @@ func validateConstraintFieldAccessWithDepth(program Program, constraint interfac
 			if objOk && fieldOk {
 				fieldAccess := FieldAccess{
 					Type:   ConstraintTypeFieldAccess,
+					Pos:    SpanOf(c),
 					Object: object,
 					Field:  field,
 				}
@@ -163,6 +174,5 @@ func GetFieldType(program Program, object string, field string, expressionIndex
 		}
 	}
 
-	return "", fmt.Errorf("field %s not found in type %s",
-		sanitizeForLog(field, 50), sanitizeForLog(objectType, 50))
+	return "", unknownFieldError(nil, field, objectType, fields)
 }
@@ -24,32 +24,32 @@ func ValidateProgram(result interface{}) error {
 
 	// Validation des types
 	if err := ValidateTypes(program); err != nil {
-		return fmt.Errorf("erreur validation types: %v", err)
+		return fmt.Errorf("erreur validation types: %w", err)
 	}
 
 	// Validation des références de types utilisateur dans les champs
 	if err := validateTypeReferences(program); err != nil {
-		return fmt.Errorf("erreur validation références types: %v", err)
+		return fmt.Errorf("erreur validation références types: %w", err)
 	}
 
 	// Validation des références circulaires entre types
 	if err := validateNoCircularReferences(program); err != nil {
-		return fmt.Errorf("erreur validation références circulaires: %v", err)
+		return fmt.Errorf("erreur validation références circulaires: %w", err)
 	}
 
 	// Validation des variables dans les faits
 	if err := validateVariableReferences(program); err != nil {
-		return fmt.Errorf("erreur validation références variables: %v", err)
+		return fmt.Errorf("erreur validation références variables: %w", err)
 	}
 
 	// Validation des faits
 	if err := ValidateFacts(program); err != nil {
-		return fmt.Errorf("erreur validation faits: %v", err)
+		return fmt.Errorf("erreur validation faits: %w", err)
 	}
 
 	// Validation des xuple-spaces
 	if err := validateXupleSpaces(program); err != nil {
-		return fmt.Errorf("erreur validation xuple-spaces: %v", err)
+		return fmt.Errorf("erreur validation xuple-spaces: %w", err)
 	}
 
 	// Validation des contraintes dans les expressions
@@ -62,6 +62,16 @@ func ValidateProgram(result interface{}) error {
 		return err
 	}
 
+	// Validation des annotations de règles
+	if err := validateRuleAnnotations(program); err != nil {
+		return fmt.Errorf("erreur validation annotations: %w", err)
+	}
+
+	// Validation des requêtes
+	if err := validateQueries(program); err != nil {
+		return fmt.Errorf("erreur validation requêtes: %w", err)
+	}
+
 	tsdio.Printf("✓ Programme valide avec %d type(s), %d expression(s), %d fait(s), %d affectation(s) et %d xuple-space(s)\n",
 		len(program.Types), len(program.Expressions), len(program.Facts), len(program.FactAssignments), len(program.XupleSpaces))
 	return nil
@@ -90,12 +100,14 @@ func validateExpressionConstraints(program Program) error {
 		if expression.Constraints != nil {
 			// Validation des accès aux champs
 			if err := ValidateConstraintFieldAccess(program, expression.Constraints, i); err != nil {
-				return fmt.Errorf("erreur validation champs dans l'expression %d: %v", i+1, err)
+				return atSpan(expression.Pos, DiagnosticCodeInvalidRule,
+					fmt.Errorf("erreur validation champs dans l'expression %d: %w", i+1, err))
 			}
 
 			// Validation des types dans les comparaisons
 			if err := ValidateTypeCompatibility(program, expression.Constraints, i); err != nil {
-				return fmt.Errorf("erreur validation types dans l'expression %d: %v", i+1, err)
+				return atSpan(expression.Pos, DiagnosticCodeTypeMismatch,
+					fmt.Errorf("erreur validation types dans l'expression %d: %w", i+1, err))
 			}
 		}
 	}
@@ -107,11 +119,13 @@ func validateExpressionActions(program Program) error {
 	for i, expression := range program.Expressions {
 		if expression.Action != nil {
 			if err := ValidateAction(program, *expression.Action, i); err != nil {
-				return fmt.Errorf("erreur validation action dans l'expression %d: %v", i+1, err)
+				return atSpan(expression.Pos, DiagnosticCodeInvalidAction,
+					fmt.Errorf("erreur validation action dans l'expression %d: %w", i+1, err))
 			}
 		} else {
 			// Avec la nouvelle grammaire, cette condition ne devrait plus arriver
-			return fmt.Errorf("action manquante dans l'expression %d: chaque règle doit avoir une action définie", i+1)
+			return diagnosticf(expression.Pos, DiagnosticCodeInvalidAction, "",
+				"action manquante dans l'expression %d: chaque règle doit avoir une action définie", i+1)
 		}
 	}
 	return nil
@@ -121,7 +135,7 @@ func validateExpressionActions(program Program) error {
 func validateXupleSpaces(program Program) error {
 	for _, xs := range program.XupleSpaces {
 		if err := ValidateXupleSpaceDeclaration(&xs); err != nil {
-			return err
+			return atSpan(xs.Pos, DiagnosticCodeInvalidXuple, err)
 		}
 	}
 	return nil
@@ -130,16 +144,21 @@ func validateXupleSpaces(program Program) error {
 // validateTypeReferences validates that all user-defined types referenced in fields exist.
 func validateTypeReferences(program Program) error {
 	typeMap := make(map[string]bool)
+	typeNames := make([]string, 0, len(program.Types))
 	for _, typeDef := range program.Types {
 		typeMap[typeDef.Name] = true
+		typeNames = append(typeNames, typeDef.Name)
 	}
 
 	primitiveTypes := GetPrimitiveTypesSet()
+	for primitive := range primitiveTypes {
+		typeNames = append(typeNames, primitive)
+	}
 
 	for _, typeDef := range program.Types {
 		for _, field := range typeDef.Fields {
 			if !primitiveTypes[field.Type] && !typeMap[field.Type] {
-				return fmt.Errorf(
+				return diagnosticf(firstSpan(field.Pos, typeDef.Pos), DiagnosticCodeUnknownType, didYouMean(field.Type, typeNames),
 					"type '%s': champ '%s' référence un type inconnu '%s'",
 					typeDef.Name,
 					field.Name,
@@ -250,11 +269,12 @@ func validateFieldVariableReference(
 	// OK, this is legitimately a variable reference for a custom type field
 	varName, ok := field.Value.Value.(string)
 	if !ok || varName == "" {
-		return fmt.Errorf("fait %d, champ %d: référence de variable invalide", factIndex+1, fieldIndex+1)
+		return diagnosticf(fact.Pos, DiagnosticCodeInvalidFact, "",
+			"fait %d, champ %d: référence de variable invalide", factIndex+1, fieldIndex+1)
 	}
 
 	if _, exists := varMap[varName]; !exists {
-		return fmt.Errorf(
+		return diagnosticf(fact.Pos, DiagnosticCodeUnknownVariable, "",
 			"fait %d (%s), champ %d (%s): variable '%s' non définie",
 			factIndex+1,
 			fact.TypeName,
@@ -270,9 +290,11 @@ func validateFieldVariableReference(
 // validateNoCircularReferences detects circular type dependencies.
 func validateNoCircularReferences(program Program) error {
 	typeGraph := make(map[string][]string)
+	typeSpans := make(map[string]*Span)
 	primitiveTypes := GetPrimitiveTypesSet()
 
 	for _, typeDef := range program.Types {
+		typeSpans[typeDef.Name] = typeDef.Pos
 		for _, field := range typeDef.Fields {
 			if !primitiveTypes[field.Type] {
 				typeGraph[typeDef.Name] = append(typeGraph[typeDef.Name], field.Type)
@@ -284,7 +306,8 @@ func validateNoCircularReferences(program Program) error {
 		visited := make(map[string]bool)
 		recStack := make(map[string]bool)
 		if hasCycle(typeName, typeGraph, visited, recStack) {
-			return fmt.Errorf("référence circulaire détectée impliquant le type '%s'", typeName)
+			return diagnosticf(typeSpans[typeName], DiagnosticCodeInvalidType, "",
+				"référence circulaire détectée impliquant le type '%s'", typeName)
 		}
 	}
 
@@ -83,7 +83,7 @@ func validateConstraintWithOperands(program Program, c map[string]interface{}, e
 	// Validate type compatibility between operands (only for comparisons)
 	if checkCompatibility {
 		if err := validateOperandTypeCompatibility(program, left, right, expressionIndex); err != nil {
-			return err
+			return atSpan(SpanOf(c), DiagnosticCodeTypeMismatch, err)
 		}
 	}
 
@@ -138,7 +138,8 @@ func getOperandType(program Program, operand interface{}, expressionIndex int) (
 	if operandMap["type"] == ConstraintTypeFieldAccess {
 		object := operandMap["object"].(string)
 		field := operandMap["field"].(string)
-		return GetFieldType(program, object, field, expressionIndex)
+		fieldType, err := GetFieldType(program, object, field, expressionIndex)
+		return fieldType, atSpan(SpanOf(operandMap), DiagnosticCodeUnknownField, err)
 	}
 
 	return GetValueType(operand), nil
@@ -12,13 +12,15 @@ import (
 // et valide également la cohérence des clés primaires.
 func ValidateTypes(program Program) error {
 	definedTypes := make(map[string]bool)
+	typeNames := make([]string, 0, len(program.Types))
 	for _, typeDef := range program.Types {
 		definedTypes[typeDef.Name] = true
+		typeNames = append(typeNames, typeDef.Name)
 
 		// Valider que _id_ n'est pas utilisé comme nom de champ
 		for _, field := range typeDef.Fields {
 			if field.Name == FieldNameInternalID {
-				return fmt.Errorf(
+				return diagnosticf(firstSpan(field.Pos, typeDef.Pos), DiagnosticCodeInvalidType, "",
 					"type '%s': le champ '%s' est réservé au système et ne peut pas être utilisé",
 					typeDef.Name,
 					FieldNameInternalID,
@@ -28,16 +30,17 @@ func ValidateTypes(program Program) error {
 
 		// Valider la clé primaire du type
 		if err := ValidateTypePrimaryKey(typeDef); err != nil {
-			return err
+			return atSpan(typeDef.Pos, DiagnosticCodeInvalidType, err)
 		}
 	}
 
 	// Vérifier les variables typées dans toutes les expressions
 	for i, expression := range program.Expressions {
 		for _, variable := range expression.Set.Variables {
-			if !definedTypes[variable.DataType] {
-				return fmt.Errorf("expression %d: undefined type: %s for variable %s",
-					i+1, sanitizeForLog(variable.DataType, 50), sanitizeForLog(variable.Name, 50))
+			if dataType := declaredVariableType(variable); !definedTypes[dataType] {
+				return diagnosticf(variable.Pos, DiagnosticCodeUnknownType, didYouMean(dataType, typeNames),
+					"expression %d: undefined type: %s for variable %s",
+					i+1, sanitizeForLog(dataType, 50), sanitizeForLog(variable.Name, 50))
 			}
 		}
 	}
@@ -45,6 +48,15 @@ func ValidateTypes(program Program) error {
 	return nil
 }
 
+// declaredVariableType retourne le type à vérifier pour une variable de motif.
+// Une variable COLLECT est typée par l'élément collecté.
+func declaredVariableType(variable TypedVariable) string {
+	if variable.Type == VariableTypeCollect && variable.Variable != nil {
+		return variable.Variable.DataType
+	}
+	return variable.DataType
+}
+
 // GetTypeFields retourne les champs d'un type donné
 func GetTypeFields(program Program, typeName string) ([]Field, error) {
 	for _, typeDef := range program.Types {
@@ -7,27 +7,41 @@ package constraint
 // Program represents the complete AST of a constraint program including types, actions, expressions, facts and resets.
 // It serves as the root structure for parsed constraint files.
 type Program struct {
-	Types           []TypeDefinition        `json:"types"`           // Type definitions declared in the program
-	Actions         []ActionDefinition      `json:"actions"`         // Action definitions with their signatures
-	XupleSpaces     []XupleSpaceDeclaration `json:"xupleSpaces"`     // Xuple-space declarations with their policies
-	Expressions     []Expression            `json:"expressions"`     // Constraint expressions/rules
-	Facts           []Fact                  `json:"facts"`           // Facts parsed from the program
-	FactAssignments []FactAssignment        `json:"factAssignments"` // Fact assignments (variable = Fact(...))
-	Resets          []Reset                 `json:"resets"`          // Reset instructions to clear the system
-	RuleRemovals    []RuleRemoval           `json:"ruleRemovals"`    // Rule removal commands
+	Package         string                  `json:"package,omitempty"` // Package (namespace) declared by the file, if any
+	Imports         []ImportDeclaration     `json:"imports,omitempty"` // Files imported by the program
+	Types           []TypeDefinition        `json:"types"`             // Type definitions declared in the program
+	Actions         []ActionDefinition      `json:"actions"`           // Action definitions with their signatures
+	XupleSpaces     []XupleSpaceDeclaration `json:"xupleSpaces"`       // Xuple-space declarations with their policies
+	Expressions     []Expression            `json:"expressions"`       // Constraint expressions/rules
+	Queries         []QueryDefinition       `json:"queries"`           // Named parameterized queries
+	Facts           []Fact                  `json:"facts"`             // Facts parsed from the program
+	FactAssignments []FactAssignment        `json:"factAssignments"`   // Fact assignments (variable = Fact(...))
+	Retractions     []FactRetraction        `json:"retractions"`       // Fact removal commands
+	Resets          []Reset                 `json:"resets"`            // Reset instructions to clear the system
+	RuleRemovals    []RuleRemoval           `json:"ruleRemovals"`      // Rule removal commands
+}
+
+// ImportDeclaration represents an import statement.
+// Example: import "common/types.tsd"
+type ImportDeclaration struct {
+	Type string `json:"type"`          // Always "import"
+	Pos  *Span  `json:"pos,omitempty"` // Source position
+	Path string `json:"path"`          // Imported file path, relative to the importing file
 }
 
 // TypeDefinition represents a user-defined type with its fields.
 // Example: type Person(#id: string, name: string, age: number)
 type TypeDefinition struct {
-	Type   string  `json:"type"`   // Always "typeDefinition"
-	Name   string  `json:"name"`   // The type name (e.g., "Person")
-	Fields []Field `json:"fields"` // List of fields in the type
+	Type   string  `json:"type"`          // Always "typeDefinition"
+	Pos    *Span   `json:"pos,omitempty"` // Source position
+	Name   string  `json:"name"`          // The type name (e.g., "Person")
+	Fields []Field `json:"fields"`        // List of fields in the type
 }
 
 // Field represents a single field within a type definition.
 // It contains the field name, its type, and whether it's part of the primary key.
 type Field struct {
+	Pos          *Span  `json:"pos,omitempty"`          // Source position
 	Name         string `json:"name"`                   // Field name (e.g., "id", "name")
 	Type         string `json:"type"`                   // Field type (e.g., "string", "number", "bool")
 	IsPrimaryKey bool   `json:"isPrimaryKey,omitempty"` // True if field is part of primary key (marked with #)
@@ -37,6 +51,7 @@ type Field struct {
 // Example: action notify(recipient: string, message: string, priority: number = 1)
 type ActionDefinition struct {
 	Type       string      `json:"type"`                // Always "actionDefinition"
+	Pos        *Span       `json:"pos,omitempty"`       // Source position
 	Name       string      `json:"name"`                // The action name (e.g., "notify")
 	Parameters []Parameter `json:"parameters"`          // List of parameters for the action
 	IsDefault  bool        `json:"isDefault,omitempty"` // true if action is a system default action
@@ -45,6 +60,7 @@ type ActionDefinition struct {
 // Parameter represents a single parameter within an action definition.
 // It contains the parameter name, type, whether it's optional, and an optional default value.
 type Parameter struct {
+	Pos          *Span       `json:"pos,omitempty"`          // Source position
 	Name         string      `json:"name"`                   // Parameter name (e.g., "recipient", "priority")
 	Type         string      `json:"type"`                   // Parameter type (e.g., "string", "number", "bool", or a user-defined type like "Person")
 	Optional     bool        `json:"optional"`               // Whether the parameter is optional (marked with ?)
@@ -55,12 +71,35 @@ type Parameter struct {
 // It defines variables, constraints on those variables, and actions to execute when matched.
 // Each expression must have a unique identifier for management purposes (e.g., deletion).
 type Expression struct {
-	Type        string      `json:"type"`               // Always "expression"
-	RuleId      string      `json:"ruleId"`             // Unique identifier for the rule
-	Set         Set         `json:"set,omitempty"`      // Set of variables (single pattern, backward compatibility)
-	Patterns    []Set       `json:"patterns,omitempty"` // Multiple pattern blocks (aggregation with joins)
-	Constraints interface{} `json:"constraints"`        // Constraints to evaluate
-	Action      *Action     `json:"action,omitempty"`   // Action to execute when constraints match
+	Type        string       `json:"type"`                  // Always "expression"
+	Pos         *Span        `json:"pos,omitempty"`         // Source position
+	RuleId      string       `json:"ruleId"`                // Unique identifier for the rule
+	Set         Set          `json:"set,omitempty"`         // Set of variables (single pattern, backward compatibility)
+	Patterns    []Set        `json:"patterns,omitempty"`    // Multiple pattern blocks (aggregation with joins)
+	Constraints interface{}  `json:"constraints"`           // Constraints to evaluate
+	Action      *Action      `json:"action,omitempty"`      // Action to execute when constraints match
+	Annotations []Annotation `json:"annotations,omitempty"` // Rule metadata (@version, @owner, @tags)
+}
+
+// Annotation represents a metadata annotation placed before a rule.
+// Example: @version("3") @owner("pricing") @tags("prix", "promo")
+type Annotation struct {
+	Type string   `json:"type"` // Always "annotation"
+	Name string   `json:"name"` // Annotation name (version, owner, tags)
+	Args []string `json:"args"` // Literal arguments, numbers kept as written
+}
+
+// QueryDefinition represents a named, parameterized query over working memory.
+// Parameters are referenced as plain variables inside the constraints.
+// Example: query adults(min: number) : {p: Person} / p.age >= min
+type QueryDefinition struct {
+	Type        string      `json:"type"`               // Always "queryDefinition"
+	Pos         *Span       `json:"pos,omitempty"`      // Source position
+	Name        string      `json:"name"`               // Unique query name
+	Parameters  []Parameter `json:"parameters"`         // Query parameters (primitive types only)
+	Set         Set         `json:"set,omitempty"`      // Set of variables (single pattern)
+	Patterns    []Set       `json:"patterns,omitempty"` // Multiple pattern blocks
+	Constraints interface{} `json:"constraints"`        // Constraints to evaluate (may reference parameters)
 }
 
 // Set represents a collection of typed variables used in an expression.
@@ -72,20 +111,26 @@ type Set struct {
 
 // TypedVariable represents a variable with its associated type.
 // Example: p: Person where 'p' is the name and 'Person' is the dataType.
-// For aggregation variables (type="aggregationVariable"), it also includes Function and Field.
+// For aggregation variables (type="aggregationVariable"), it also includes Function, Field and Args.
+// For collect variables (type="collectVariable"), it includes the collected Variable and its Condition.
 type TypedVariable struct {
-	Type     string      `json:"type"`               // "typedVariable" or "aggregationVariable"
-	Name     string      `json:"name"`               // Variable name (e.g., "p", "order", "avg_sal")
-	DataType string      `json:"dataType"`           // Associated type (e.g., "Person", "Order")
-	Function string      `json:"function,omitempty"` // Aggregation function (e.g., "AVG", "COUNT", "SUM") for aggregation variables
-	Field    interface{} `json:"field,omitempty"`    // Field being aggregated (map with object/field/type) for aggregation variables
-	Value    interface{} `json:"value,omitempty"`    // Optional value field for complex variable definitions
+	Type      string         `json:"type"`                // "typedVariable", "aggregationVariable" or "collectVariable"
+	Pos       *Span          `json:"pos,omitempty"`       // Source position
+	Name      string         `json:"name"`                // Variable name (e.g., "p", "order", "avg_sal")
+	DataType  string         `json:"dataType"`            // Associated type (e.g., "Person", "Order")
+	Function  string         `json:"function,omitempty"`  // Aggregation function (e.g., "AVG", "COUNT", "SUM") for aggregation variables
+	Field     interface{}    `json:"field,omitempty"`     // Field being aggregated (map with object/field/type) for aggregation variables
+	Args      []interface{}  `json:"args,omitempty"`      // Extra aggregation arguments (percentile rank, ordering field, separator)
+	Value     interface{}    `json:"value,omitempty"`     // Optional value field for complex variable definitions
+	Variable  *TypedVariable `json:"variable,omitempty"`  // Collected element variable for collect variables
+	Condition interface{}    `json:"condition,omitempty"` // Optional filter on collected elements for collect variables
 }
 
 // Constraint represents a basic constraint in the system.
 // It can be a comparison, field access, or other constraint type.
 type Constraint struct {
 	Type     string      `json:"type"`               // Type of constraint
+	Pos      *Span       `json:"pos,omitempty"`      // Source position
 	Left     interface{} `json:"left,omitempty"`     // Left operand
 	Operator string      `json:"operator,omitempty"` // Comparison operator (==, !=, <, >, etc.)
 	Right    interface{} `json:"right,omitempty"`    // Right operand
@@ -94,9 +139,10 @@ type Constraint struct {
 // LogicalExpression represents a complex logical expression with AND/OR operations.
 // It consists of a left operand and a series of logical operations.
 type LogicalExpression struct {
-	Type       string             `json:"type"`       // Always "logicalExpr"
-	Left       interface{}        `json:"left"`       // Left operand of the expression
-	Operations []LogicalOperation `json:"operations"` // Chain of AND/OR operations
+	Type       string             `json:"type"`          // Always "logicalExpr"
+	Pos        *Span              `json:"pos,omitempty"` // Source position
+	Left       interface{}        `json:"left"`          // Left operand of the expression
+	Operations []LogicalOperation `json:"operations"`    // Chain of AND/OR operations
 }
 
 // LogicalOperation represents a single logical operation (AND/OR) in a chain.
@@ -109,86 +155,107 @@ type LogicalOperation struct {
 // BinaryOperation represents a binary operation between two operands.
 // Common operations include arithmetic (+, -, *, /) and comparisons (==, !=, <, >).
 type BinaryOperation struct {
-	Type     string      `json:"type"`     // Always "binaryOperation" or "comparison"
-	Left     interface{} `json:"left"`     // Left operand
-	Operator string      `json:"operator"` // Operation symbol
-	Right    interface{} `json:"right"`    // Right operand
+	Type     string      `json:"type"`          // Always "binaryOperation" or "comparison"
+	Pos      *Span       `json:"pos,omitempty"` // Source position
+	Left     interface{} `json:"left"`          // Left operand
+	Operator string      `json:"operator"`      // Operation symbol
+	Right    interface{} `json:"right"`         // Right operand
 }
 
 // FieldAccess represents accessing a field of an object/variable.
 // Example: p.age where 'p' is the object and 'age' is the field.
 type FieldAccess struct {
-	Type   string `json:"type"`   // Always "fieldAccess"
-	Object string `json:"object"` // Variable name (e.g., "p")
-	Field  string `json:"field"`  // Field name (e.g., "age", "name")
+	Type   string `json:"type"`          // Always "fieldAccess"
+	Pos    *Span  `json:"pos,omitempty"` // Source position
+	Object string `json:"object"`        // Variable name (e.g., "p")
+	Field  string `json:"field"`         // Field name (e.g., "age", "name")
 }
 
 // Variable represents a simple variable reference in expressions.
 // It's used when referencing a variable by name.
 type Variable struct {
-	Type string `json:"type"` // Always "variable"
-	Name string `json:"name"` // Variable name
+	Type string `json:"type"`          // Always "variable"
+	Pos  *Span  `json:"pos,omitempty"` // Source position
+	Name string `json:"name"`          // Variable name
 }
 
 // NumberLiteral represents a numeric literal value in expressions.
 // It supports both integer and floating-point numbers.
 type NumberLiteral struct {
-	Type  string  `json:"type"`  // Always "numberLiteral" or "number"
-	Value float64 `json:"value"` // Numeric value
+	Type  string  `json:"type"`          // Always "numberLiteral" or "number"
+	Pos   *Span   `json:"pos,omitempty"` // Source position
+	Value float64 `json:"value"`         // Numeric value
 }
 
 // StringLiteral represents a string literal value in expressions.
 // It contains text values enclosed in quotes.
 type StringLiteral struct {
-	Type  string `json:"type"`  // Always "stringLiteral" or "string"
-	Value string `json:"value"` // String content
+	Type  string `json:"type"`          // Always "stringLiteral" or "string"
+	Pos   *Span  `json:"pos,omitempty"` // Source position
+	Value string `json:"value"`         // String content
 }
 
 // BooleanLiteral represents a boolean literal value (true/false).
 // It's used for boolean constants in expressions.
 type BooleanLiteral struct {
-	Type  string `json:"type"`  // Always "booleanLiteral" or "bool"
-	Value bool   `json:"value"` // Boolean value
+	Type  string `json:"type"`          // Always "booleanLiteral" or "bool"
+	Pos   *Span  `json:"pos,omitempty"` // Source position
+	Value bool   `json:"value"`         // Boolean value
 }
 
 // NotConstraint represents a negation constraint (NOT operator).
 // It negates the result of the contained expression.
 type NotConstraint struct {
-	Type       string      `json:"type"`       // Always "notConstraint"
-	Expression interface{} `json:"expression"` // Expression to negate
+	Type       string      `json:"type"`          // Always "notConstraint"
+	Pos        *Span       `json:"pos,omitempty"` // Source position
+	Expression interface{} `json:"expression"`    // Expression to negate
 }
 
 // ExistsConstraint represents an existential quantifier (EXISTS).
 // It checks if there exists at least one instance satisfying the condition.
 type ExistsConstraint struct {
-	Type      string        `json:"type"`      // Always "existsConstraint"
-	Variable  TypedVariable `json:"variable"`  // Variable to quantify over
-	Condition interface{}   `json:"condition"` // Condition that must be satisfied
+	Type      string        `json:"type"`          // Always "existsConstraint"
+	Pos       *Span         `json:"pos,omitempty"` // Source position
+	Variable  TypedVariable `json:"variable"`      // Variable to quantify over
+	Condition interface{}   `json:"condition"`     // Condition that must be satisfied
+}
+
+// ForallConstraint represents a universal quantifier (FORALL).
+// Every instance satisfying Condition must also satisfy Then.
+type ForallConstraint struct {
+	Type      string        `json:"type"`          // Always "forallConstraint"
+	Pos       *Span         `json:"pos,omitempty"` // Source position
+	Variable  TypedVariable `json:"variable"`      // Variable to quantify over
+	Condition interface{}   `json:"condition"`     // Selects the instances concerned
+	Then      interface{}   `json:"then"`          // Condition every selected instance must satisfy
 }
 
 // AggregateConstraint represents aggregate operations (SUM, COUNT, AVG, MIN, MAX).
 // It performs calculations over sets of data and compares the result.
 type AggregateConstraint struct {
-	Type       string      `json:"type"`       // Always "aggregateConstraint"
-	Function   string      `json:"function"`   // Aggregate function (SUM, COUNT, AVG, MIN, MAX)
-	Expression interface{} `json:"expression"` // Expression to aggregate
-	Operator   string      `json:"operator"`   // Comparison operator
-	Value      interface{} `json:"value"`      // Value to compare against
+	Type       string      `json:"type"`          // Always "aggregateConstraint"
+	Pos        *Span       `json:"pos,omitempty"` // Source position
+	Function   string      `json:"function"`      // Aggregate function (SUM, COUNT, AVG, MIN, MAX)
+	Expression interface{} `json:"expression"`    // Expression to aggregate
+	Operator   string      `json:"operator"`      // Comparison operator
+	Value      interface{} `json:"value"`         // Value to compare against
 }
 
 // FunctionCall represents a function call in expressions.
 // It includes the function name and its arguments.
 type FunctionCall struct {
-	Type string        `json:"type"` // Always "functionCall"
-	Name string        `json:"name"` // Function name (e.g., "LENGTH", "UPPER")
-	Args []interface{} `json:"args"` // Function arguments
+	Type string        `json:"type"`          // Always "functionCall"
+	Pos  *Span         `json:"pos,omitempty"` // Source position
+	Name string        `json:"name"`          // Function name (e.g., "LENGTH", "UPPER")
+	Args []interface{} `json:"args"`          // Function arguments
 }
 
 // ArrayLiteral represents an array/list literal in expressions.
 // It contains a collection of elements of potentially different types.
 type ArrayLiteral struct {
-	Type     string        `json:"type"`     // Always "arrayLiteral"
-	Elements []interface{} `json:"elements"` // Array elements
+	Type     string        `json:"type"`          // Always "arrayLiteral"
+	Pos      *Span         `json:"pos,omitempty"` // Source position
+	Elements []interface{} `json:"elements"`      // Array elements
 }
 
 // Action represents an action to execute when constraints are satisfied.
@@ -213,29 +280,38 @@ func (a *Action) GetJobs() []JobCall {
 	return []JobCall{}
 }
 
-// JobCall represents a specific job/function call within an action.
-// It specifies the job name and arguments to pass.
+// JobCall represents a statement within an action.
+// A "jobCall" specifies the job name and arguments to pass. A "letBinding"
+// binds Name to Value for the following statements, and an "ifBlock" executes
+// Then or Else depending on Condition.
 type JobCall struct {
-	Type string        `json:"type"` // Always "jobCall"
-	Name string        `json:"name"` // Job/function name
-	Args []interface{} `json:"args"` // Arguments to pass to the job
+	Type      string        `json:"type"`                // "jobCall", "letBinding" or "ifBlock"
+	Pos       *Span         `json:"pos,omitempty"`       // Source position
+	Name      string        `json:"name"`                // Job/function name, or bound name for letBinding
+	Args      []interface{} `json:"args"`                // Arguments to pass to the job
+	Value     interface{}   `json:"value,omitempty"`     // Bound expression (letBinding)
+	Condition interface{}   `json:"condition,omitempty"` // Branch condition (ifBlock)
+	Then      []JobCall     `json:"then,omitempty"`      // Statements executed when Condition holds (ifBlock)
+	Else      []JobCall     `json:"else,omitempty"`      // Statements executed otherwise (ifBlock)
 }
 
 // Fact represents a fact parsed from constraint files.
 // Facts are data instances that can be inserted into the RETE network.
 // Example: Person(id: "P001", name: "Alice", age: 25)
 type Fact struct {
-	Type     string      `json:"type"`     // Always "fact"
-	TypeName string      `json:"typeName"` // Type name (e.g., "Person", "Order")
-	Fields   []FactField `json:"fields"`   // List of field assignments
+	Type     string      `json:"type"`          // Always "fact"
+	Pos      *Span       `json:"pos,omitempty"` // Source position
+	TypeName string      `json:"typeName"`      // Type name (e.g., "Person", "Order")
+	Fields   []FactField `json:"fields"`        // List of field assignments
 }
 
 // FactAssignment represents an assignment of a fact to a variable.
 // Example: alice = User("Alice", 30)
 type FactAssignment struct {
-	Type     string `json:"type"`     // Always "factAssignment"
-	Variable string `json:"variable"` // Variable name (e.g., "alice", "bob")
-	Fact     Fact   `json:"fact"`     // The fact being assigned
+	Type     string `json:"type"`          // Always "factAssignment"
+	Pos      *Span  `json:"pos,omitempty"` // Source position
+	Variable string `json:"variable"`      // Variable name (e.g., "alice", "bob")
+	Fact     Fact   `json:"fact"`          // The fact being assigned
 }
 
 // BuildFieldMap creates a map of field values indexed by field name.
@@ -251,16 +327,18 @@ func (f Fact) BuildFieldMap() map[string]FactValue {
 // FactField represents a field assignment within a fact.
 // It pairs a field name with its assigned value.
 type FactField struct {
-	Name  string    `json:"name"`  // Field name (e.g., "id", "name")
-	Value FactValue `json:"value"` // Assigned value
+	Pos   *Span     `json:"pos,omitempty"` // Source position
+	Name  string    `json:"name"`          // Field name (e.g., "id", "name")
+	Value FactValue `json:"value"`         // Assigned value
 }
 
 // FactValue represents a value assigned to a fact field.
 // It wraps the actual value with type information.
 // Type can be: "string", "number", "bool", "identifier", "variableReference"
 type FactValue struct {
-	Type  string      `json:"type"`  // Value type
-	Value interface{} `json:"value"` // Actual value (for variableReference, this is the variable name)
+	Type  string      `json:"type"`          // Value type
+	Pos   *Span       `json:"pos,omitempty"` // Source position (literal values)
+	Value interface{} `json:"value"`         // Actual value (for variableReference, this is the variable name)
 }
 
 // Unwrap extracts the underlying value from a FactValue.
@@ -280,14 +358,25 @@ func (fv FactValue) Unwrap() interface{} {
 // When executed, it removes all facts, rules, types, and the RETE network.
 // Example: reset
 type Reset struct {
-	Type string `json:"type"` // Always "reset"
+	Type string `json:"type"`          // Always "reset"
+	Pos  *Span  `json:"pos,omitempty"` // Source position
+}
+
+// FactRetraction represents a command to remove a fact from working memory.
+// Example: remove fact Person P3
+type FactRetraction struct {
+	Type     string `json:"type"`          // Always "retraction"
+	Pos      *Span  `json:"pos,omitempty"` // Source position
+	TypeName string `json:"typeName"`      // Type of the fact to remove
+	FactID   string `json:"factID"`        // ID of the fact to remove
 }
 
 // RuleRemoval represents a command to remove a rule from the system.
 // Example: remove rule my_rule
 type RuleRemoval struct {
-	Type   string `json:"type"`   // Always "ruleRemoval"
-	RuleID string `json:"ruleID"` // ID of the rule to remove
+	Type   string `json:"type"`          // Always "ruleRemoval"
+	Pos    *Span  `json:"pos,omitempty"` // Source position
+	RuleID string `json:"ruleID"`        // ID of the rule to remove
 }
 
 // GetPrimaryKeyFields returns the list of fields that are part of the primary key.
@@ -330,6 +419,7 @@ func (td TypeDefinition) Clone() TypeDefinition {
 		Type:   td.Type,
 		Name:   td.Name,
 		Fields: make([]Field, len(td.Fields)),
+		Pos:    td.Pos,
 	}
 
 	// Copy all fields (copy() copies all struct fields including IsPrimaryKey)
@@ -342,6 +432,7 @@ func (td TypeDefinition) Clone() TypeDefinition {
 // Example: xuple-space agents-commands { selection: fifo, consumption: once, retention: unlimited, max-size: 1000 }
 type XupleSpaceDeclaration struct {
 	Type              string                     `json:"type"`              // Always "xupleSpaceDeclaration"
+	Pos               *Span                      `json:"pos,omitempty"`     // Source position
 	Name              string                     `json:"name"`              // Xuple-space name (e.g., "agents-commands")
 	SelectionPolicy   string                     `json:"selectionPolicy"`   // Selection policy: "random", "fifo", "lifo"
 	ConsumptionPolicy XupleConsumptionPolicyConf `json:"consumptionPolicy"` // Consumption policy configuration
@@ -0,0 +1,318 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Span désigne une portion du source ; chaque nœud de l'AST porte la sienne
+// (clé JSONKeyPos dans l'AST brut, champ Pos dans les structures).
+type Span = tsdio.Span
+
+// Diagnostic est une erreur ou un avertissement localisé, partagé par la
+// ligne de commande, le serveur et le package api.
+type Diagnostic = tsdio.Diagnostic
+
+// JSONKeyPos est la clé de la position d'un nœud dans l'AST brut
+const JSONKeyPos = "pos"
+
+// filenameStoreKey est la clé du nom de fichier dans le store global du parser
+const filenameStoreKey = "filename"
+
+// Codes des diagnostics
+const (
+	DiagnosticCodeSyntax          = "syntax"
+	DiagnosticCodeUnknownType     = "unknown-type"
+	DiagnosticCodeUnknownField    = "unknown-field"
+	DiagnosticCodeUnknownVariable = "unknown-variable"
+	DiagnosticCodeTypeMismatch    = "type-mismatch"
+	DiagnosticCodeDuplicate       = "duplicate"
+	DiagnosticCodeInvalidType     = "invalid-type"
+	DiagnosticCodeInvalidFact     = "invalid-fact"
+	DiagnosticCodeInvalidRule     = "invalid-rule"
+	DiagnosticCodeInvalidAction   = "invalid-action"
+	DiagnosticCodeInvalidQuery    = "invalid-query"
+	DiagnosticCodeInvalidXuple    = "invalid-xuple-space"
+	DiagnosticCodeSelfTrigger     = "self-trigger"
+	DiagnosticCodeError           = "error"
+)
+
+// nodeSpan retourne la position du texte reconnu par la règle courante du
+// parser : début, fin (exclusive) et fichier, transmis par l'option GlobalStore
+func nodeSpan(c *current) map[string]interface{} {
+	endLine, endColumn := c.pos.line, c.pos.col
+	for _, r := range string(c.text) {
+		if r == '\n' {
+			endLine++
+			endColumn = 1
+		} else {
+			endColumn++
+		}
+	}
+	span := map[string]interface{}{
+		"line":      c.pos.line,
+		"column":    c.pos.col,
+		"endLine":   endLine,
+		"endColumn": endColumn,
+	}
+	if file, ok := c.globalStore[filenameStoreKey].(string); ok && file != "" {
+		span["file"] = file
+	}
+	return span
+}
+
+// spanBetween retourne la position allant du début de first à la fin de last
+// (opération binaire construite de gauche à droite), ou celle de la règle
+// courante si l'un des deux nœuds n'a pas de position
+func spanBetween(c *current, first, last interface{}) map[string]interface{} {
+	start, _ := first.(map[string]interface{})
+	end, _ := last.(map[string]interface{})
+	startPos, _ := start[JSONKeyPos].(map[string]interface{})
+	endPos, _ := end[JSONKeyPos].(map[string]interface{})
+	if startPos == nil || endPos == nil {
+		return nodeSpan(c)
+	}
+	span := map[string]interface{}{
+		"line":      startPos["line"],
+		"column":    startPos["column"],
+		"endLine":   endPos["endLine"],
+		"endColumn": endPos["endColumn"],
+	}
+	if file, ok := startPos["file"]; ok {
+		span["file"] = file
+	}
+	return span
+}
+
+// firstSpan retourne la première position connue, de la plus précise à la plus large
+func firstSpan(spans ...*Span) *Span {
+	for _, span := range spans {
+		if span != nil {
+			return span
+		}
+	}
+	return nil
+}
+
+// SpanOf retourne la position d'un nœud de l'AST brut (nil si absente)
+func SpanOf(node interface{}) *Span {
+	nodeMap, ok := node.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	switch pos := nodeMap[JSONKeyPos].(type) {
+	case map[string]interface{}:
+		span := &Span{}
+		span.File, _ = pos["file"].(string)
+		span.Line = intValue(pos["line"])
+		span.Column = intValue(pos["column"])
+		span.EndLine = intValue(pos["endLine"])
+		span.EndColumn = intValue(pos["endColumn"])
+		return span
+	case *Span:
+		return pos
+	}
+	return nil
+}
+
+// intValue convertit un entier de l'AST (int, ou float64 après un aller-retour JSON)
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// StripPositions retourne une copie de l'AST brut sans les positions : deux
+// nœuds identiques à des endroits différents du source sont alors égaux
+// (partage des nœuds du réseau, comparaison de règles)
+func StripPositions(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(n))
+		for key, value := range n {
+			if key != JSONKeyPos {
+				stripped[key] = StripPositions(value)
+			}
+		}
+		return stripped
+	case []interface{}:
+		stripped := make([]interface{}, len(n))
+		for i, value := range n {
+			stripped[i] = StripPositions(value)
+		}
+		return stripped
+	case []map[string]interface{}:
+		stripped := make([]map[string]interface{}, len(n))
+		for i, value := range n {
+			stripped[i] = StripPositions(value).(map[string]interface{})
+		}
+		return stripped
+	}
+	return node
+}
+
+// atSpan localise une erreur : elle devient un diagnostic portant span. Un
+// diagnostic déjà présent dans l'erreur garde son code et, s'il en a une,
+// sa position (plus précise).
+func atSpan(span *Span, code string, err error) error {
+	if err == nil || span == nil {
+		return err
+	}
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) {
+		if diagnostic.Span == nil {
+			diagnostic.Span = span
+		}
+		return err
+	}
+	return tsdio.NewDiagnostic(code, err.Error(), span).WithCause(err)
+}
+
+// diagnosticf crée un diagnostic localisé avec une correction proposée optionnelle
+func diagnosticf(span *Span, code, fix, format string, args ...interface{}) error {
+	diagnostic := tsdio.NewDiagnostic(code, fmt.Sprintf(format, args...), span)
+	diagnostic.SuggestedFix = fix
+	return diagnostic
+}
+
+// locate retourne la position et le message (sans position) d'une erreur :
+// celle du diagnostic qu'elle contient, à défaut fallback
+func locate(err error, fallback *Span) (*Span, string) {
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) && diagnostic.Span != nil {
+		return diagnostic.Span, strings.Replace(err.Error(), diagnostic.Error(), diagnostic.Message, 1)
+	}
+	return fallback, err.Error()
+}
+
+// Diagnostics convertit une erreur de parsing ou de validation en
+// diagnostics : erreurs de syntaxe du parser, ValidationError(s) et erreurs
+// contenant un Diagnostic. Les autres erreurs donnent un diagnostic sans
+// position. Le message d'un diagnostic conserve le contexte des erreurs
+// englobantes, sans la position.
+func Diagnostics(err error) []Diagnostic {
+	if err == nil {
+		return nil
+	}
+
+	var parseErrors errList
+	if errors.As(err, &parseErrors) {
+		diagnostics := make([]Diagnostic, 0, len(parseErrors))
+		for _, parseErr := range parseErrors {
+			diagnostics = append(diagnostics, syntaxDiagnostic(parseErr))
+		}
+		return diagnostics
+	}
+
+	var validationErrors ValidationErrors
+	if errors.As(err, &validationErrors) {
+		diagnostics := make([]Diagnostic, 0, len(validationErrors))
+		for _, validationErr := range validationErrors {
+			diagnostics = append(diagnostics, validationErr.Diagnostic())
+		}
+		return diagnostics
+	}
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return []Diagnostic{validationErr.Diagnostic()}
+	}
+
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) {
+		result := *diagnostic
+		result.Message = strings.Replace(err.Error(), diagnostic.Error(), diagnostic.Message, 1)
+		return []Diagnostic{result}
+	}
+
+	return []Diagnostic{{Severity: tsdio.SeverityError, Code: DiagnosticCodeError, Message: err.Error()}}
+}
+
+// syntaxDiagnostic convertit une erreur du parser généré
+func syntaxDiagnostic(err error) Diagnostic {
+	var parseErr *parserError
+	if !errors.As(err, &parseErr) {
+		return Diagnostic{Severity: tsdio.SeverityError, Code: DiagnosticCodeSyntax, Message: err.Error()}
+	}
+
+	// Le préfixe est « fichier:ligne:colonne (offset): rule X »
+	location := fmt.Sprintf("%d:%d (%d)", parseErr.pos.line, parseErr.pos.col, parseErr.pos.offset)
+	file := ""
+	if index := strings.Index(parseErr.prefix, location); index > 0 {
+		file = strings.TrimSuffix(parseErr.prefix[:index], ":")
+	}
+
+	diagnostic := Diagnostic{
+		Severity: tsdio.SeverityError,
+		Code:     DiagnosticCodeSyntax,
+		Message:  parseErr.Inner.Error(),
+		Span:     &Span{File: file, Line: parseErr.pos.line, Column: parseErr.pos.col},
+	}
+	// Les symboles attendus passent dans la correction proposée lorsqu'ils
+	// sont peu nombreux ; une longue liste (toute expression possible)
+	// n'apporte rien
+	if message, _, found := strings.Cut(diagnostic.Message, ", expected: "); found {
+		diagnostic.Message = message
+	}
+	if len(parseErr.expected) > 0 && len(parseErr.expected) <= 5 {
+		expected := append([]string(nil), parseErr.expected...)
+		sort.Strings(expected)
+		diagnostic.SuggestedFix = "attendu : " + strings.Join(expected, ", ")
+	}
+	return diagnostic
+}
+
+// suggestName propose le nom connu le plus proche d'un nom inconnu (faute de
+// frappe) ; vide si aucun n'est assez proche
+func suggestName(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/2+1
+	for _, candidate := range candidates {
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if distance < bestDistance || (distance == bestDistance && best != "" && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// didYouMean formate la correction proposée pour un nom inconnu
+func didYouMean(name string, candidates []string) string {
+	if suggestion := suggestName(name, candidates); suggestion != "" {
+		return fmt.Sprintf("remplacer '%s' par '%s'", name, suggestion)
+	}
+	return ""
+}
+
+// levenshtein retourne la distance d'édition entre deux chaînes
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const positionsProgram = `type Person(#id: string, age: number)
+action greet(id: string)
+
+rule adult : {p: Person} / p.age >= 18 ==> greet(p.id)
+Person(id: "p1", age: 30)
+`
+
+func TestParse_Positions(t *testing.T) {
+	result, err := ParseConstraint("people.tsd", []byte(positionsProgram))
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	program, err := ConvertResultToProgram(result)
+	if err != nil {
+		t.Fatalf("ConvertResultToProgram() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		span *Span
+		want Span
+	}{
+		{"type", program.Types[0].Pos, Span{File: "people.tsd", Line: 1, Column: 1, EndLine: 1, EndColumn: 38}},
+		{"action", program.Actions[0].Pos, Span{File: "people.tsd", Line: 2, Column: 1, EndLine: 2, EndColumn: 25}},
+		{"rule", program.Expressions[0].Pos, Span{File: "people.tsd", Line: 4, Column: 1, EndLine: 4, EndColumn: 55}},
+		{"variable", program.Expressions[0].Set.Variables[0].Pos, Span{File: "people.tsd", Line: 4, Column: 15, EndLine: 4, EndColumn: 24}},
+		{"comparison", SpanOf(program.Expressions[0].Constraints), Span{File: "people.tsd", Line: 4, Column: 28, EndLine: 4, EndColumn: 39}},
+		{"job", program.Expressions[0].Action.GetJobs()[0].Pos, Span{File: "people.tsd", Line: 4, Column: 44, EndLine: 4, EndColumn: 55}},
+		{"fact", program.Facts[0].Pos, Span{File: "people.tsd", Line: 5, Column: 1, EndLine: 5, EndColumn: 26}},
+	}
+	for _, tt := range tests {
+		if tt.span == nil || *tt.span != tt.want {
+			t.Errorf("%s: Pos = %+v, want %+v", tt.name, tt.span, tt.want)
+		}
+	}
+}
+
+const nestedPositionsProgram = `type Item(#id: string, price: number, tags: string)
+action log(msg: string, level: number = 1)
+rule cheap : {i: Item} / (i.price * 2 + 1 < 10 AND i.tags != "x") OR i.id == "a" ==> log("ok")
+Item(id: "i1", price: 3, tags: "t")
+`
+
+func TestParse_NestedPositions(t *testing.T) {
+	result, err := ParseConstraint("items.tsd", []byte(nestedPositionsProgram))
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	program, err := ConvertResultToProgram(result)
+	if err != nil {
+		t.Fatalf("ConvertResultToProgram() error = %v", err)
+	}
+
+	// child suit un chemin de clés et d'indices dans l'AST brut
+	child := func(node interface{}, path ...interface{}) interface{} {
+		for _, step := range path {
+			switch key := step.(type) {
+			case string:
+				node = node.(map[string]interface{})[key]
+			case int:
+				switch items := node.(type) {
+				case []interface{}:
+					node = items[key]
+				case []map[string]interface{}:
+					node = items[key]
+				}
+			}
+		}
+		return node
+	}
+	outer := program.Expressions[0].Constraints
+	inner := child(outer, "left")
+	sum := child(inner, "left", "left")
+
+	tests := []struct {
+		name string
+		span *Span
+		want Span
+	}{
+		{"field", program.Types[0].Fields[1].Pos, Span{Line: 1, Column: 24, EndLine: 1, EndColumn: 37}},
+		{"parameter", program.Actions[0].Parameters[1].Pos, Span{Line: 2, Column: 25, EndLine: 2, EndColumn: 42}},
+		{"logical expression", SpanOf(outer), Span{Line: 3, Column: 26, EndLine: 3, EndColumn: 81}},
+		{"nested logical expression", SpanOf(inner), Span{Line: 3, Column: 27, EndLine: 3, EndColumn: 65}},
+		{"binary operation", SpanOf(sum), Span{Line: 3, Column: 27, EndLine: 3, EndColumn: 42}},
+		{"nested binary operation", SpanOf(child(sum, "left")), Span{Line: 3, Column: 27, EndLine: 3, EndColumn: 38}},
+		{"number literal", SpanOf(child(sum, "left", "right")), Span{Line: 3, Column: 37, EndLine: 3, EndColumn: 38}},
+		{"string literal", SpanOf(child(inner, "operations", 0, "right", "right")), Span{Line: 3, Column: 62, EndLine: 3, EndColumn: 65}},
+		{"fact field", program.Facts[0].Fields[1].Pos, Span{Line: 4, Column: 16, EndLine: 4, EndColumn: 24}},
+		{"fact value", program.Facts[0].Fields[1].Value.Pos, Span{Line: 4, Column: 23, EndLine: 4, EndColumn: 24}},
+	}
+	for _, tt := range tests {
+		tt.want.File = "items.tsd"
+		if tt.span == nil || *tt.span != tt.want {
+			t.Errorf("%s: Pos = %+v, want %+v", tt.name, tt.span, tt.want)
+		}
+	}
+}
+
+func TestStripPositions(t *testing.T) {
+	first, _ := ParseConstraint("a.tsd", []byte("type P(#id: string)\nrule r : {p: P} / p.id == \"x\" ==> print(p.id)\n"))
+	second, _ := ParseConstraint("b.tsd", []byte("type P(#id: string)\n\n\nrule r : {p: P} /   p.id == \"x\" ==> print(p.id)\n"))
+	if reflect.DeepEqual(first, second) {
+		t.Fatalf("positions should differ before stripping")
+	}
+	if !reflect.DeepEqual(StripPositions(first), StripPositions(second)) {
+		t.Errorf("StripPositions() should make both programs equal")
+	}
+	data, err := json.Marshal(StripPositions(first))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), `"`+JSONKeyPos+`"`) {
+		t.Errorf("StripPositions() left a %q key", JSONKeyPos)
+	}
+}
+
+func TestStripPositions_LogicalOperations(t *testing.T) {
+	first, _ := ParseConstraint("a.tsd", []byte("rule r : {p: P} / p.a > 1 AND p.b > 2 ==> print(p.a)\n"))
+	second, _ := ParseConstraint("b.tsd", []byte("rule r : {p: P} /\n    p.a > 1 AND\n    p.b > 2\n    ==> print(p.a)\n"))
+	if !reflect.DeepEqual(StripPositions(first), StripPositions(second)) {
+		t.Errorf("StripPositions() should strip the operands of AND/OR chains")
+	}
+}
+
+func TestDiagnostics_Syntax(t *testing.T) {
+	_, err := ParseConstraint("broken.tsd", []byte("type P(#id: string)\nrule r  {p: P} / p.id == \"x\" ==> print(p.id)\n"))
+	diagnostics := Diagnostics(err)
+	if len(diagnostics) != 1 {
+		t.Fatalf("Diagnostics() = %+v, want 1", diagnostics)
+	}
+	d := diagnostics[0]
+	if d.Code != DiagnosticCodeSyntax || d.Message != "no match found" {
+		t.Errorf("diagnostic = %+v", d)
+	}
+	if d.Span == nil || *d.Span != (Span{File: "broken.tsd", Line: 2, Column: 9}) {
+		t.Errorf("Span = %+v, want broken.tsd:2:9", d.Span)
+	}
+	if !strings.HasPrefix(d.SuggestedFix, "attendu : ") || !strings.Contains(d.SuggestedFix, `":"`) {
+		t.Errorf("SuggestedFix = %q", d.SuggestedFix)
+	}
+}
+
+func TestDiagnostics_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  string
+		wantCode string
+		wantLine int
+		wantCol  int
+		wantFix  string
+	}{
+		{
+			name:     "unknown field",
+			program:  "type Person(#id: string, age: number)\nrule r : {p: Person} / p.agee > 1 ==> print(p.id)\n",
+			wantCode: DiagnosticCodeUnknownField,
+			wantLine: 2, wantCol: 24,
+			wantFix: "remplacer 'agee' par 'age'",
+		},
+		{
+			name:     "unknown type",
+			program:  "type Person(#id: string)\nrule r : {p: Persn} / p.id == \"x\" ==> print(p.id)\n",
+			wantCode: DiagnosticCodeUnknownType,
+			wantLine: 2, wantCol: 11,
+			wantFix: "remplacer 'Persn' par 'Person'",
+		},
+		{
+			name:     "type mismatch",
+			program:  "type Person(#id: string, age: number)\nrule r : {p: Person} / p.age == \"old\" ==> print(p.id)\n",
+			wantCode: DiagnosticCodeTypeMismatch,
+			wantLine: 2, wantCol: 24,
+		},
+		{
+			name:     "fact field",
+			program:  "type Person(#id: string, age: number)\n\nPerson(id: \"p1\", ag: 3)\n",
+			wantCode: DiagnosticCodeUnknownField,
+			wantLine: 3, wantCol: 18,
+			wantFix: "remplacer 'ag' par 'age'",
+		},
+		{
+			name:     "field type reference",
+			program:  "type Address(#id: string)\ntype Person(#id: string, home: Adress)\n",
+			wantCode: DiagnosticCodeUnknownType,
+			wantLine: 2, wantCol: 26,
+			wantFix: "remplacer 'Adress' par 'Address'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseConstraint("rules.tsd", []byte(tt.program))
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			err = ValidateProgram(result)
+			if err == nil {
+				t.Fatal("ValidateProgram() should fail")
+			}
+			diagnostics := Diagnostics(err)
+			if len(diagnostics) != 1 {
+				t.Fatalf("Diagnostics() = %+v, want 1", diagnostics)
+			}
+			d := diagnostics[0]
+			if d.Code != tt.wantCode || d.SuggestedFix != tt.wantFix {
+				t.Errorf("diagnostic = %+v, want code %s, fix %q", d, tt.wantCode, tt.wantFix)
+			}
+			if d.Span == nil || d.Span.File != "rules.tsd" || d.Span.Line != tt.wantLine || d.Span.Column != tt.wantCol {
+				t.Errorf("Span = %+v, want rules.tsd:%d:%d", d.Span, tt.wantLine, tt.wantCol)
+			}
+			if strings.Contains(d.Message, "rules.tsd:") {
+				t.Errorf("Message should not repeat the position: %q", d.Message)
+			}
+			if !strings.Contains(err.Error(), d.Span.String()) {
+				t.Errorf("Error() = %q, want position %s", err.Error(), d.Span)
+			}
+		})
+	}
+}
+
+func TestDiagnostics_Fallback(t *testing.T) {
+	diagnostics := Diagnostics(errors.New("boom"))
+	if len(diagnostics) != 1 || diagnostics[0].Code != DiagnosticCodeError || diagnostics[0].Span != nil || diagnostics[0].Message != "boom" {
+		t.Errorf("Diagnostics() = %+v", diagnostics)
+	}
+	if Diagnostics(nil) != nil {
+		t.Errorf("Diagnostics(nil) should be nil")
+	}
+}
+
+func TestProgramState_ErrorPositions(t *testing.T) {
+	ps := NewProgramState()
+	program := "type Person(#id: string, age: number)\naction log(id: string)\n\nrule r : {p: Person} / p.agee > 1 ==> log(p.id)\nPerson(id: \"p1\", salary: 3)\n"
+	if err := ps.ParseAndMergeContent(program, "people.tsd"); err != nil {
+		t.Fatalf("ParseAndMergeContent() error = %v", err)
+	}
+
+	errs := ps.GetErrors()
+	if len(errs) != 2 {
+		t.Fatalf("GetErrors() = %+v, want 2", errs)
+	}
+	if errs[0].Line != 4 || errs[0].Column != 24 || errs[0].Code != DiagnosticCodeUnknownField {
+		t.Errorf("rule error = %+v, want line 4 column 24", errs[0])
+	}
+	if errs[1].Line != 5 || errs[1].Column != 18 || errs[1].Code != DiagnosticCodeUnknownField {
+		t.Errorf("fact error = %+v, want line 5 column 18", errs[1])
+	}
+	if !strings.HasPrefix(errs[0].Error(), "people.tsd:4:24: ") {
+		t.Errorf("Error() = %q", errs[0].Error())
+	}
+
+	d := errs[0].Diagnostic()
+	if d.Span == nil || d.Span.Line != 4 || d.SuggestedFix != "remplacer 'agee' par 'age'" {
+		t.Errorf("Diagnostic() = %+v", d)
+	}
+}
+
+func TestSuggestName(t *testing.T) {
+	candidates := []string{"Person", "Product", "Order"}
+	tests := map[string]string{
+		"Persn":   "Person",
+		"order":   "Order",
+		"Prodcut": "Product",
+		"Invoice": "",
+	}
+	for name, want := range tests {
+		if got := suggestName(name, candidates); got != want {
+			t.Errorf("suggestName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
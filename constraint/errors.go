@@ -7,26 +7,55 @@ package constraint
 import (
 	"fmt"
 	"strings"
+
+	"github.com/treivax/tsd/tsdio"
 )
 
 // ValidationError represents a non-blocking validation error that occurs during parsing.
 // It captures the context of the error including the file, type of element (fact/rule),
-// the error message, and optionally the line number where the error occurred.
+// the error message, and optionally the line and column where the error occurred.
 type ValidationError struct {
 	File    string // Source file where the error occurred
 	Type    string // Type of element: "fact", "rule", or "type"
 	Message string // Descriptive error message
 	Line    int    // Line number in the source file (0 if unknown)
+	Column  int    // Column number in the source file (0 if unknown)
+
+	Code         string // Diagnostic code (derived from Type if empty)
+	SuggestedFix string // Suggested fix, if any
 }
 
 // Error implements the error interface for ValidationError.
 func (ve ValidationError) Error() string {
-	if ve.Line > 0 {
+	switch {
+	case ve.Line > 0 && ve.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %s in %s", ve.File, ve.Line, ve.Column, ve.Message, ve.Type)
+	case ve.Line > 0:
 		return fmt.Sprintf("%s:%d: %s in %s", ve.File, ve.Line, ve.Message, ve.Type)
 	}
 	return fmt.Sprintf("%s: %s in %s", ve.File, ve.Message, ve.Type)
 }
 
+// Diagnostic converts the validation error to a diagnostic.
+func (ve ValidationError) Diagnostic() Diagnostic {
+	diagnostic := Diagnostic{
+		Severity:     tsdio.SeverityError,
+		Code:         ve.Code,
+		Message:      fmt.Sprintf("%s in %s", ve.Message, ve.Type),
+		SuggestedFix: ve.SuggestedFix,
+	}
+	if diagnostic.Code == "" {
+		diagnostic.Code = validationErrorCodes[ve.Type]
+	}
+	if diagnostic.Code == "" {
+		diagnostic.Code = DiagnosticCodeError
+	}
+	if ve.Line > 0 {
+		diagnostic.Span = &Span{File: ve.File, Line: ve.Line, Column: ve.Column}
+	}
+	return diagnostic
+}
+
 // ValidationErrors is a collection of validation errors.
 type ValidationErrors []ValidationError
 
@@ -62,3 +91,10 @@ const (
 	ErrorTypeRule = "rule"
 	ErrorTypeType = "type"
 )
+
+// validationErrorCodes maps validation error types to diagnostic codes
+var validationErrorCodes = map[string]string{
+	ErrorTypeFact: DiagnosticCodeInvalidFact,
+	ErrorTypeRule: DiagnosticCodeInvalidRule,
+	ErrorTypeType: DiagnosticCodeInvalidType,
+}
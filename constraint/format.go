@@ -0,0 +1,185 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sourceStoreKey est la clé du collecteur de source dans le store global du
+// parser : présent uniquement lorsque le formateur parse un fichier
+const sourceStoreKey = "source"
+
+// sourceComment est un commentaire du source (délimiteurs compris)
+type sourceComment struct {
+	text   string
+	offset int
+	start  sourcePos
+	end    sourcePos // exclusive
+}
+
+// sourcePos est une position ligne/colonne (en runes, à partir de 1)
+type sourcePos struct {
+	line, column int
+}
+
+// before indique si p précède strictement q
+func (p sourcePos) before(q sourcePos) bool {
+	return p.line < q.line || (p.line == q.line && p.column < q.column)
+}
+
+// sourceCollector recueille pendant le parsing ce que l'AST ne conserve pas :
+// les commentaires et l'ordre des déclarations
+type sourceCollector struct {
+	comments   map[int]sourceComment // par offset : le backtracking peut reconnaître un commentaire plusieurs fois
+	statements []interface{}
+}
+
+// recordComment enregistre le commentaire reconnu par la règle courante
+func recordComment(c *current) {
+	collector, ok := c.globalStore[sourceStoreKey].(*sourceCollector)
+	if !ok {
+		return
+	}
+	if _, seen := collector.comments[c.pos.offset]; seen {
+		return
+	}
+	span := SpanOf(map[string]interface{}{JSONKeyPos: nodeSpan(c)})
+	collector.comments[c.pos.offset] = sourceComment{
+		text:   string(c.text),
+		offset: c.pos.offset,
+		start:  sourcePos{span.Line, span.Column},
+		end:    sourcePos{span.EndLine, span.EndColumn},
+	}
+}
+
+// recordStatements enregistre les déclarations du fichier dans l'ordre du source
+func recordStatements(c *current, statements []interface{}) {
+	if collector, ok := c.globalStore[sourceStoreKey].(*sourceCollector); ok {
+		collector.statements = statements
+	}
+}
+
+// parsedSource est un fichier parsé avec ses commentaires et ses déclarations ordonnées
+type parsedSource struct {
+	ast        interface{}
+	statements []interface{}
+	comments   []sourceComment
+}
+
+// parseSource parse un fichier en conservant commentaires et ordre des déclarations
+func parseSource(filename string, src []byte) (*parsedSource, error) {
+	collector := &sourceCollector{comments: make(map[int]sourceComment)}
+	ast, err := Parse(filename, src, GlobalStore(filenameStoreKey, filename), GlobalStore(sourceStoreKey, collector))
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]sourceComment, 0, len(collector.comments))
+	for _, comment := range collector.comments {
+		comments = append(comments, comment)
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].offset < comments[j].offset })
+
+	// Un commentaire reconnu à l'intérieur d'un autre n'en est pas un
+	kept := comments[:0]
+	for _, comment := range comments {
+		if len(kept) > 0 && comment.offset < kept[len(kept)-1].offset+len(kept[len(kept)-1].text) {
+			continue
+		}
+		kept = append(kept, comment)
+	}
+	return &parsedSource{ast: ast, statements: collector.statements, comments: kept}, nil
+}
+
+// Comment est un commentaire du source, délimiteurs compris
+type Comment struct {
+	Text string
+	Span Span
+}
+
+// Comments parse un fichier et retourne ses commentaires dans l'ordre du source
+func Comments(filename string, src []byte) ([]Comment, error) {
+	source, err := parseSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]Comment, len(source.comments))
+	for i, comment := range source.comments {
+		comments[i] = Comment{
+			Text: comment.text,
+			Span: Span{
+				File:      filename,
+				Line:      comment.start.line,
+				Column:    comment.start.column,
+				EndLine:   comment.end.line,
+				EndColumn: comment.end.column,
+			},
+		}
+	}
+	return comments, nil
+}
+
+// Format retourne le source TSD mis en forme canonique : déclarations dans
+// l'ordre du source, commentaires conservés, mots-clés en majuscules (AND,
+// NOT, EXISTS...), champs des types longs alignés sur une ligne chacun et
+// conditions des longues chaînes AND/OR une par ligne. Une erreur de syntaxe
+// est retournée telle quelle (voir Diagnostics). Le résultat est vérifié : il
+// doit se parser en un AST identique, commentaires compris.
+func Format(filename string, src []byte) ([]byte, error) {
+	source, err := parseSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &sourcePrinter{comments: source.comments}
+	for _, statement := range source.statements {
+		p.emit(p.statementUnits(statement))
+	}
+	p.flushComments()
+	formatted := []byte(p.String())
+
+	// Le formateur ne doit jamais changer le sens d'un programme
+	reparsed, err := parseSource(filename, formatted)
+	if err != nil {
+		return nil, fmt.Errorf("formatage invalide de %s: %w", filename, err)
+	}
+	if !reflect.DeepEqual(StripPositions(reparsed.ast), StripPositions(source.ast)) {
+		return nil, fmt.Errorf("formatage de %s: le programme formaté diffère de l'original", filename)
+	}
+	if !sameComments(reparsed.comments, source.comments) {
+		return nil, fmt.Errorf("formatage de %s: des commentaires seraient perdus", filename)
+	}
+	return formatted, nil
+}
+
+// FormatLiteral écrit une valeur de fait (string, number, bool) sous la forme
+// d'un littéral TSD, comme le formateur
+func FormatLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return quoteString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return formatNumberLiteral(value)
+}
+
+// sameComments indique si deux listes de commentaires ont les mêmes textes
+func sameComments(a, b []sourceComment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimSpace(a[i].text) != strings.TrimSpace(b[i].text) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,740 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatMaxWidth est la largeur au-delà de laquelle une déclaration est
+// répartie sur plusieurs lignes
+const FormatMaxWidth = 100
+
+// formatMaxConditions est le nombre de conditions d'une chaîne AND/OR au-delà
+// duquel elles sont écrites une par ligne
+const formatMaxConditions = 3
+
+// formatIndent est l'indentation d'un niveau
+const formatIndent = "    "
+
+// endOfSource est une position après tout le source
+var endOfSource = sourcePos{math.MaxInt, math.MaxInt}
+
+// formatUnit est une ligne du résultat rattachée à une portion du source : les
+// commentaires qui la précèdent dans le source sont écrits avant elle, celui
+// qui suit sa fin sur la même ligne est gardé en fin de ligne
+type formatUnit struct {
+	depth     int
+	text      string
+	start     sourcePos
+	end       sourcePos
+	keepBlank bool // conserver une ligne vide qui la précède dans le source
+}
+
+// sourcePrinter écrit les lignes du résultat et y place les commentaires
+type sourcePrinter struct {
+	comments []sourceComment
+	next     int
+	lines    []string
+	lastLine int // dernière ligne du source écrite, pour les lignes vides
+}
+
+// emit écrit les lignes d'une déclaration avec les commentaires qui s'y rattachent
+func (p *sourcePrinter) emit(units []formatUnit) {
+	for i, unit := range units {
+		// Commentaires précédant la fin de la ligne : au-dessus d'elle
+		for p.next < len(p.comments) && p.comments[p.next].start.before(unit.end) {
+			p.writeComment(unit.depth, p.comments[p.next])
+		}
+
+		if unit.keepBlank {
+			p.blankBefore(unit.start.line)
+		}
+		nextStart := endOfSource
+		if i+1 < len(units) {
+			nextStart = units[i+1].start
+		}
+		text := unit.text
+		for p.next < len(p.comments) {
+			comment := p.comments[p.next]
+			if comment.start.line != unit.end.line || comment.start.before(unit.end) || !comment.start.before(nextStart) {
+				break
+			}
+			text += " " + comment.text
+			p.lastLine = max(p.lastLine, comment.end.line)
+			p.next++
+		}
+		p.lines = append(p.lines, strings.Repeat(formatIndent, unit.depth)+text)
+		p.lastLine = max(p.lastLine, unit.end.line)
+	}
+}
+
+// flushComments écrit les commentaires qui suivent la dernière déclaration
+func (p *sourcePrinter) flushComments() {
+	for p.next < len(p.comments) {
+		p.writeComment(0, p.comments[p.next])
+	}
+}
+
+// writeComment écrit le prochain commentaire sur sa propre ligne
+func (p *sourcePrinter) writeComment(depth int, comment sourceComment) {
+	p.blankBefore(comment.start.line)
+	p.lines = append(p.lines, strings.Repeat(formatIndent, depth)+comment.text)
+	p.lastLine = max(p.lastLine, comment.end.line)
+	p.next++
+}
+
+// blankBefore ajoute une ligne vide si le source en avait au moins une avant line
+func (p *sourcePrinter) blankBefore(line int) {
+	if p.lastLine > 0 && line-p.lastLine >= 2 && len(p.lines) > 0 && p.lines[len(p.lines)-1] != "" {
+		p.lines = append(p.lines, "")
+	}
+}
+
+// String retourne le résultat, terminé par un saut de ligne
+func (p *sourcePrinter) String() string {
+	if len(p.lines) == 0 {
+		return ""
+	}
+	return strings.Join(p.lines, "\n") + "\n"
+}
+
+// hasCommentsWithin indique si un commentaire commence entre start et end
+func (p *sourcePrinter) hasCommentsWithin(start, end sourcePos) bool {
+	for _, comment := range p.comments[p.next:] {
+		if start.before(comment.start) && comment.start.before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// statementUnits met en forme une déclaration
+func (p *sourcePrinter) statementUnits(node interface{}) []formatUnit {
+	statement, _ := node.(map[string]interface{})
+	start, end := nodeBounds(statement)
+	single := func(text string) []formatUnit {
+		return []formatUnit{{text: text, start: start, end: end, keepBlank: true}}
+	}
+
+	prefix := ""
+	if statement["visibility"] == "private" {
+		prefix = "private "
+	}
+
+	switch statement["type"] {
+	case "import":
+		return single("import " + quoteString(stringValue(statement["path"])))
+	case "packageDeclaration":
+		return single("package " + stringValue(statement["name"]))
+	case "reset":
+		return single("reset")
+	case "ruleRemoval":
+		return single("remove rule " + stringValue(statement["ruleID"]))
+	case "retraction":
+		return single(fmt.Sprintf("remove fact %s %s", stringValue(statement["typeName"]), stringValue(statement["factID"])))
+	case "fact":
+		return single(formatFact(statement))
+	case "factAssignment":
+		return single(stringValue(statement["variable"]) + " = " + formatFact(statement["fact"]))
+	case "actionDefinition":
+		return single(fmt.Sprintf("%saction %s(%s)", prefix, stringValue(statement["name"]), formatParameters(statement["parameters"])))
+	case "xupleSpaceDeclaration":
+		return xupleSpaceUnits(statement, start, end)
+	case "typeDefinition":
+		return p.typeUnits(statement, prefix, start, end)
+	case "expression", "queryDefinition":
+		return p.ruleUnits(statement, prefix, start, end)
+	}
+	return single(formatExpr(statement))
+}
+
+// typeUnits met en forme un type : sur une ligne s'il est court, sinon un
+// champ par ligne, types alignés
+func (p *sourcePrinter) typeUnits(statement map[string]interface{}, prefix string, start, end sourcePos) []formatUnit {
+	fields := asSlice(statement["fields"])
+	header := fmt.Sprintf("%stype %s(", prefix, stringValue(statement["name"]))
+
+	declarations := make([]string, len(fields))
+	width := 0
+	for i, field := range fields {
+		fieldMap, _ := field.(map[string]interface{})
+		declarations[i] = stringValue(fieldMap["name"]) + ":"
+		if fieldMap["isPrimaryKey"] == true {
+			declarations[i] = "#" + declarations[i]
+		}
+		width = max(width, utf8.RuneCountInString(declarations[i]))
+	}
+
+	oneLine := make([]string, len(fields))
+	for i, field := range fields {
+		oneLine[i] = declarations[i] + " " + stringValue(field.(map[string]interface{})["type"])
+	}
+	text := header + strings.Join(oneLine, ", ") + ")"
+	if utf8.RuneCountInString(text) <= FormatMaxWidth && !p.hasCommentsWithin(start, end) {
+		return []formatUnit{{text: text, start: start, end: end, keepBlank: true}}
+	}
+
+	units := []formatUnit{{text: header, start: start, end: start, keepBlank: true}}
+	for i, field := range fields {
+		fieldStart, fieldEnd := nodeBounds(field)
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(declarations[i])+1)
+		line := declarations[i] + padding + stringValue(field.(map[string]interface{})["type"])
+		if i < len(fields)-1 {
+			line += ","
+		}
+		units = append(units, formatUnit{depth: 1, text: line, start: fieldStart, end: fieldEnd, keepBlank: true})
+	}
+	return append(units, formatUnit{text: ")", start: end, end: end})
+}
+
+// xupleSpaceUnits met en forme un xuple-space, une politique par ligne ; les
+// commentaires internes sont placés avant la déclaration
+func xupleSpaceUnits(statement map[string]interface{}, start, end sourcePos) []formatUnit {
+	units := []formatUnit{{text: fmt.Sprintf("xuple-space %s {", stringValue(statement["name"])), start: start, end: end, keepBlank: true}}
+	property := func(text string) {
+		units = append(units, formatUnit{depth: 1, text: text, start: start, end: start})
+	}
+
+	property("selection: " + stringValue(statement["selectionPolicy"]))
+
+	consumption, _ := statement["consumptionPolicy"].(map[string]interface{})
+	if consumption["type"] == "limited" {
+		property(fmt.Sprintf("consumption: limited(%d)", intValue(consumption["limit"])))
+	} else {
+		property("consumption: " + stringValue(consumption["type"]))
+	}
+
+	retention, _ := statement["retentionPolicy"].(map[string]interface{})
+	if retention["type"] == "duration" {
+		property("retention: duration(" + formatDuration(intValue(retention["duration"])) + ")")
+	} else {
+		property("retention: " + stringValue(retention["type"]))
+	}
+
+	if maxSize := intValue(statement["maxSize"]); maxSize > 0 {
+		property(fmt.Sprintf("max-size: %d", maxSize))
+	}
+	return append(units, formatUnit{text: "}", start: end, end: end})
+}
+
+// formatDuration écrit une durée en secondes dans la plus grande unité exacte
+func formatDuration(seconds int) string {
+	for _, unit := range []struct {
+		suffix  string
+		seconds int
+	}{{"d", 86400}, {"h", 3600}, {"m", 60}} {
+		if seconds%unit.seconds == 0 {
+			return fmt.Sprintf("%d%s", seconds/unit.seconds, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// ruleUnits met en forme une règle ou une requête. Trop longue ou commentée,
+// elle est répartie : motifs, conditions (une par ligne si la chaîne AND/OR
+// est trop longue) puis actions (une par ligne si elles sont trop longues).
+func (p *sourcePrinter) ruleUnits(statement map[string]interface{}, prefix string, start, end sourcePos) []formatUnit {
+	var units []formatUnit
+
+	var annotations []string
+	for _, annotation := range asSlice(statement["annotations"]) {
+		annotations = append(annotations, formatAnnotation(annotation))
+	}
+	if len(annotations) > 0 {
+		annotationsStart, _ := nodeBounds(asSlice(statement["annotations"])[0])
+		_, annotationsEnd := nodeBounds(statement["annotations"])
+		units = append(units, formatUnit{text: strings.Join(annotations, " "), start: annotationsStart, end: annotationsEnd, keepBlank: true})
+	}
+
+	var patterns []interface{}
+	if set, ok := statement["set"].(map[string]interface{}); ok {
+		patterns = []interface{}{set}
+	} else {
+		patterns = asSlice(statement["patterns"])
+	}
+	sets := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		sets[i] = formatSet(pattern)
+	}
+
+	isRule := statement["type"] == "expression"
+	var header string
+	if isRule {
+		header = fmt.Sprintf("%srule %s : %s", prefix, stringValue(statement["ruleId"]), strings.Join(sets, " / "))
+	} else {
+		header = fmt.Sprintf("%squery %s(%s) : %s", prefix, stringValue(statement["name"]), formatParameters(statement["parameters"]), strings.Join(sets, " / "))
+	}
+	_, headerEnd := nodeBounds(patterns)
+
+	constraints := statement["constraints"]
+	conditions := ""
+	if constraints != nil {
+		conditions = formatConstraints(constraints)
+	}
+	var jobs []interface{}
+	if action, ok := statement["action"].(map[string]interface{}); ok {
+		jobs = asSlice(action["jobs"])
+	}
+	actions := make([]string, len(jobs))
+	for i, job := range jobs {
+		actions[i] = formatJob(job)
+	}
+
+	oneLine := header
+	if constraints != nil || isRule {
+		oneLine += " /"
+	}
+	if constraints != nil {
+		oneLine += " " + conditions
+	}
+	if isRule {
+		oneLine += " ==> " + strings.Join(actions, ", ")
+	}
+	longChain := chainLength(constraints) > formatMaxConditions
+	if utf8.RuneCountInString(oneLine) <= FormatMaxWidth && !longChain && !p.hasCommentsWithin(start, end) {
+		return append(units, formatUnit{text: oneLine, start: start, end: end, keepBlank: len(units) == 0})
+	}
+
+	if constraints == nil && !isRule {
+		return append(units, formatUnit{text: header, start: start, end: end, keepBlank: len(units) == 0})
+	}
+	units = append(units, formatUnit{text: header + " /", start: start, end: headerEnd, keepBlank: len(units) == 0})
+
+	if constraints != nil {
+		chainStart, chainEnd := nodeBounds(constraints)
+		logical, isChain := constraints.(map[string]interface{})
+		split := isChain && logical["type"] == "logicalExpr" && (longChain ||
+			utf8.RuneCountInString(formatIndent+conditions) > FormatMaxWidth || p.hasCommentsWithin(chainStart, chainEnd))
+		if split {
+			operands := []interface{}{logical["left"]}
+			var operators []string
+			for _, operation := range asSlice(logical["operations"]) {
+				operationMap, _ := operation.(map[string]interface{})
+				operators = append(operators, stringValue(operationMap["op"]))
+				operands = append(operands, operationMap["right"])
+			}
+			for i, operand := range operands {
+				line := formatOperand(operand)
+				if i < len(operators) {
+					line += " " + operators[i]
+				}
+				operandStart, operandEnd := nodeBounds(operand)
+				units = append(units, formatUnit{depth: 1, text: line, start: operandStart, end: operandEnd, keepBlank: true})
+			}
+		} else {
+			units = append(units, formatUnit{depth: 1, text: conditions, start: chainStart, end: chainEnd, keepBlank: true})
+		}
+	}
+
+	if isRule {
+		actionsStart, actionsEnd := nodeBounds(jobs)
+		inline := "==> " + strings.Join(actions, ", ")
+		if utf8.RuneCountInString(formatIndent+inline) <= FormatMaxWidth && !p.hasCommentsWithin(actionsStart, actionsEnd) {
+			units = append(units, formatUnit{depth: 1, text: inline, start: actionsStart, end: actionsEnd, keepBlank: true})
+		} else {
+			for i, job := range jobs {
+				line := actions[i]
+				if i < len(jobs)-1 {
+					line += ","
+				}
+				jobStart, jobEnd := nodeBounds(job)
+				unit := formatUnit{depth: 2, text: line, start: jobStart, end: jobEnd, keepBlank: true}
+				if i == 0 {
+					unit.depth, unit.text = 1, "==> "+line
+				}
+				units = append(units, unit)
+			}
+		}
+	}
+	return units
+}
+
+// chainLength retourne le nombre de conditions d'une chaîne AND/OR (1 pour
+// une condition seule, 0 sans condition)
+func chainLength(node interface{}) int {
+	constraint, ok := node.(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	if constraint["type"] != "logicalExpr" {
+		return 1
+	}
+	return 1 + len(asSlice(constraint["operations"]))
+}
+
+// nodeBounds retourne le début et la fin dans le source d'un nœud : sa
+// position, à défaut celles de ses descendants
+func nodeBounds(node interface{}) (sourcePos, sourcePos) {
+	start, end := endOfSource, sourcePos{}
+	var walk func(interface{})
+	walk = func(node interface{}) {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			if span := SpanOf(n); span != nil && span.Line > 0 {
+				if s := (sourcePos{span.Line, span.Column}); s.before(start) {
+					start = s
+				}
+				if e := (sourcePos{span.EndLine, span.EndColumn}); end.before(e) {
+					end = e
+				}
+				return
+			}
+			for key, value := range n {
+				if key != JSONKeyPos {
+					walk(value)
+				}
+			}
+		case []interface{}, []map[string]interface{}:
+			for _, value := range asSlice(n) {
+				walk(value)
+			}
+		}
+	}
+	walk(node)
+	if start == endOfSource {
+		return sourcePos{}, sourcePos{}
+	}
+	return start, end
+}
+
+// formatAnnotation écrit une annotation ; les arguments sont des chaînes
+func formatAnnotation(node interface{}) string {
+	annotation, _ := node.(map[string]interface{})
+	args := asSlice(annotation["args"])
+	if len(args) == 0 {
+		return "@" + stringValue(annotation["name"])
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quoteString(stringValue(arg))
+	}
+	return fmt.Sprintf("@%s(%s)", stringValue(annotation["name"]), strings.Join(quoted, ", "))
+}
+
+// formatParameters écrit les paramètres d'une action ou d'une requête
+func formatParameters(node interface{}) string {
+	var parameters []string
+	for _, parameter := range asSlice(node) {
+		parameterMap, _ := parameter.(map[string]interface{})
+		text := stringValue(parameterMap["name"]) + ": " + stringValue(parameterMap["type"])
+		if parameterMap["optional"] == true {
+			text += "?"
+		}
+		if defaultValue, ok := parameterMap["defaultValue"]; ok {
+			text += " = " + formatExpr(defaultValue)
+		}
+		parameters = append(parameters, text)
+	}
+	return strings.Join(parameters, ", ")
+}
+
+// formatSet écrit un bloc de variables typées
+func formatSet(node interface{}) string {
+	set, _ := node.(map[string]interface{})
+	variables := asSlice(set["variables"])
+	texts := make([]string, len(variables))
+	for i, variable := range variables {
+		texts[i] = formatTypedVariable(variable)
+	}
+	return "{" + strings.Join(texts, ", ") + "}"
+}
+
+// formatTypedVariable écrit une variable simple, d'agrégation ou de collecte
+func formatTypedVariable(node interface{}) string {
+	variable, _ := node.(map[string]interface{})
+	name := stringValue(variable["name"])
+	switch variable["type"] {
+	case "aggregationVariable":
+		args := []string{formatExpr(variable["field"])}
+		for _, arg := range asSlice(variable["args"]) {
+			args = append(args, formatExpr(arg))
+		}
+		return fmt.Sprintf("%s: %s(%s)", name, stringValue(variable["function"]), strings.Join(args, ", "))
+	case "collectVariable":
+		inner := formatTypedVariable(variable["variable"])
+		if condition, ok := variable["condition"]; ok && condition != nil {
+			inner += " / " + formatConstraints(condition)
+		}
+		return fmt.Sprintf("%s: COLLECT(%s)", name, inner)
+	}
+	return name + ": " + stringValue(variable["dataType"])
+}
+
+// formatFact écrit un fait
+func formatFact(node interface{}) string {
+	fact, _ := node.(map[string]interface{})
+	fields := asSlice(fact["fields"])
+	texts := make([]string, len(fields))
+	for i, field := range fields {
+		fieldMap, _ := field.(map[string]interface{})
+		texts[i] = stringValue(fieldMap["name"]) + ": " + formatExpr(fieldMap["value"])
+	}
+	return fmt.Sprintf("%s(%s)", stringValue(fact["typeName"]), strings.Join(texts, ", "))
+}
+
+// formatJob écrit une instruction d'action
+func formatJob(node interface{}) string {
+	job, _ := node.(map[string]interface{})
+	switch job["type"] {
+	case "letBinding":
+		return fmt.Sprintf("let %s = %s", stringValue(job["name"]), formatExpr(job["value"]))
+	case "ifBlock":
+		text := fmt.Sprintf("if %s then %s", formatConstraints(job["condition"]), formatBlock(job["then"], false))
+		if elseJobs, ok := job["else"]; ok && elseJobs != nil {
+			text += " else " + formatBlock(elseJobs, true)
+		}
+		return text
+	}
+
+	args := asSlice(job["args"])
+	texts := make([]string, 0, len(args))
+	for _, arg := range args {
+		if argMap, ok := arg.(map[string]interface{}); ok && argMap["type"] == "updateWithModifications" {
+			texts = append(texts, formatExpr(argMap["variable"]), formatModifications(argMap["modifications"]))
+			continue
+		}
+		texts = append(texts, formatExpr(arg))
+	}
+	return fmt.Sprintf("%s(%s)", stringValue(job["name"]), strings.Join(texts, ", "))
+}
+
+// formatBlock écrit une branche de if : une instruction seule sans accolades,
+// sauf un if imbriqué dans then (le else serait ambigu) ; else if s'enchaîne
+func formatBlock(node interface{}, isElse bool) string {
+	jobs := asSlice(node)
+	if len(jobs) == 1 {
+		job, _ := jobs[0].(map[string]interface{})
+		if job["type"] != "ifBlock" || isElse {
+			return formatJob(job)
+		}
+	}
+	texts := make([]string, len(jobs))
+	for i, job := range jobs {
+		texts[i] = formatJob(job)
+	}
+	return "{ " + strings.Join(texts, ", ") + " }"
+}
+
+// formatModifications écrit les modifications d'un Update, champs triés
+func formatModifications(node interface{}) string {
+	modifications, _ := node.(map[string]interface{})
+	names := make([]string, 0, len(modifications))
+	for name := range modifications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	texts := make([]string, len(names))
+	for i, name := range names {
+		texts[i] = name + ": " + formatExpr(modifications[name])
+	}
+	return "{" + strings.Join(texts, ", ") + "}"
+}
+
+// formatConstraints écrit une condition
+func formatConstraints(node interface{}) string {
+	constraint, _ := node.(map[string]interface{})
+	if constraint["type"] != "logicalExpr" {
+		return formatExpr(node)
+	}
+	var sb strings.Builder
+	sb.WriteString(formatOperand(constraint["left"]))
+	for _, operation := range asSlice(constraint["operations"]) {
+		operationMap, _ := operation.(map[string]interface{})
+		sb.WriteString(" " + stringValue(operationMap["op"]) + " ")
+		sb.WriteString(formatOperand(operationMap["right"]))
+	}
+	return sb.String()
+}
+
+// formatOperand écrit un opérande d'une chaîne AND/OR ; une chaîne imbriquée
+// est entre parenthèses
+func formatOperand(node interface{}) string {
+	if constraint, ok := node.(map[string]interface{}); ok && constraint["type"] == "logicalExpr" {
+		return "(" + formatConstraints(node) + ")"
+	}
+	return formatConstraints(node)
+}
+
+// arithmeticPrecedence retourne la priorité d'un opérateur arithmétique
+func arithmeticPrecedence(operator string) int {
+	switch operator {
+	case "*", "/", "%":
+		return 2
+	}
+	return 1
+}
+
+// formatExpr écrit une expression
+func formatExpr(node interface{}) string {
+	expr, ok := node.(map[string]interface{})
+	if !ok {
+		switch v := node.(type) {
+		case string:
+			return v
+		case nil:
+			return ""
+		}
+		return fmt.Sprint(node)
+	}
+
+	switch expr["type"] {
+	case "comparison":
+		return fmt.Sprintf("%s %s %s", formatExpr(expr["left"]), stringValue(expr["operator"]), formatExpr(expr["right"]))
+	case "logicalExpr":
+		return formatConstraints(expr)
+	case "notConstraint":
+		return "NOT (" + formatConstraints(expr["expression"]) + ")"
+	case "existsConstraint":
+		return fmt.Sprintf("EXISTS (%s / %s)", formatTypedVariable(expr["variable"]), formatConstraints(expr["condition"]))
+	case "forallConstraint":
+		return fmt.Sprintf("FORALL (%s / %s ==> %s)", formatTypedVariable(expr["variable"]), formatConstraints(expr["condition"]), formatConstraints(expr["then"]))
+	case "accumulateConstraint":
+		variable := expr["variable"].(map[string]interface{})
+		inner := formatTypedVariable(variable) + " / " + formatConstraints(expr["condition"])
+		if field := stringValue(expr["field"]); field != "" {
+			inner += " ; " + stringValue(variable["name"]) + "." + field
+			for _, arg := range asSlice(expr["args"]) {
+				inner += ", " + formatExpr(arg)
+			}
+		}
+		return fmt.Sprintf("%s(%s) %s %s", stringValue(expr["function"]), inner, stringValue(expr["operator"]), formatExpr(expr["threshold"]))
+	case "binaryOp":
+		operator := stringValue(expr["operator"])
+		left, right := formatExpr(expr["left"]), formatExpr(expr["right"])
+		if child, ok := expr["left"].(map[string]interface{}); ok && child["type"] == "binaryOp" &&
+			arithmeticPrecedence(stringValue(child["operator"])) < arithmeticPrecedence(operator) {
+			left = "(" + left + ")"
+		}
+		if child, ok := expr["right"].(map[string]interface{}); ok && child["type"] == "binaryOp" &&
+			arithmeticPrecedence(stringValue(child["operator"])) <= arithmeticPrecedence(operator) {
+			right = "(" + right + ")"
+		}
+		return left + " " + operator + " " + right
+	case "ternary":
+		return fmt.Sprintf("(%s ? %s : %s)", formatConstraints(expr["condition"]), formatExpr(expr["then"]), formatExpr(expr["else"]))
+	case "cast":
+		operand := formatExpr(expr["expression"])
+		if child, ok := expr["expression"].(map[string]interface{}); ok && child["type"] == "binaryOp" {
+			operand = "(" + operand + ")"
+		}
+		return fmt.Sprintf("(%s) %s", stringValue(expr["castType"]), operand)
+	case "fieldAccess":
+		return stringValue(expr["object"]) + "." + stringValue(expr["field"])
+	case "variable":
+		return stringValue(expr["name"])
+	case "number":
+		return formatNumberLiteral(expr["value"])
+	case "string":
+		return quoteString(stringValue(expr["value"]))
+	case "boolean":
+		return fmt.Sprint(expr["value"])
+	case "identifier", "variableReference":
+		return stringValue(expr["value"])
+	case "arrayLiteral":
+		elements := asSlice(expr["elements"])
+		texts := make([]string, len(elements))
+		for i, element := range elements {
+			texts[i] = formatExpr(element)
+		}
+		return "[" + strings.Join(texts, ", ") + "]"
+	case "objectLiteral":
+		fields := asSlice(expr["fields"])
+		texts := make([]string, len(fields))
+		for i, field := range fields {
+			fieldMap, _ := field.(map[string]interface{})
+			texts[i] = stringValue(fieldMap["name"]) + ": " + formatExpr(fieldMap["value"])
+		}
+		return "{" + strings.Join(texts, ", ") + "}"
+	case "functionCall":
+		args := asSlice(expr["args"])
+		texts := make([]string, len(args))
+		for i, arg := range args {
+			texts[i] = formatExpr(arg)
+		}
+		return fmt.Sprintf("%s(%s)", stringValue(expr["name"]), strings.Join(texts, ", "))
+	case "inlineFact":
+		return formatFact(expr)
+	case "fact":
+		return formatFact(expr)
+	}
+	return fmt.Sprint(node)
+}
+
+// formatNumberLiteral écrit un nombre sans zéros superflus (25.0 devient 25)
+func formatNumberLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// quoteString écrit une chaîne entre guillemets doubles. Une barre oblique
+// inverse n'est doublée que si elle serait lue comme un échappement (\n, \",
+// ...) : les expressions régulières restent lisibles.
+func quoteString(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	runes := []rune(value)
+	for i, r := range runes {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\\':
+			if i+1 == len(runes) || strings.ContainsRune(`ntr\"'`, runes[i+1]) {
+				sb.WriteString(`\\`)
+			} else {
+				sb.WriteRune(r)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// stringValue retourne une valeur de l'AST sous forme de chaîne
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// asSlice retourne une liste de l'AST (nil si la valeur n'en est pas une)
+func asSlice(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case []map[string]interface{}:
+		slice := make([]interface{}, len(v))
+		for i, item := range v {
+			slice[i] = item
+		}
+		return slice
+	}
+	return nil
+}
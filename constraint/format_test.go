@@ -0,0 +1,143 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "espaces et mots-clés",
+			input: "type  P(#id:string,age:number)\nrule r:{p:P}/p.age>1 and not(p.id=='x') ==> Print(p.id)\n",
+			want:  "type P(#id: string, age: number)\nrule r : {p: P} / p.age > 1 AND NOT (p.id == \"x\") ==> Print(p.id)\n",
+		},
+		{
+			name:  "champs alignés",
+			input: "type Order(#orderId: string, customerId: string, totalAmount: number, status: string, itemCount: number)\n",
+			want: "type Order(\n" +
+				"    #orderId:    string,\n" +
+				"    customerId:  string,\n" +
+				"    totalAmount: number,\n" +
+				"    status:      string,\n" +
+				"    itemCount:   number\n" +
+				")\n",
+		},
+		{
+			name:  "une condition par ligne",
+			input: "rule r : {p: Person} / p.age >= 18 AND p.age < 65 AND p.status == \"active\" AND p.country IN [\"FR\", \"BE\"] ==> Print(p.id)\n",
+			want: "rule r : {p: Person} /\n" +
+				"    p.age >= 18 AND\n" +
+				"    p.age < 65 AND\n" +
+				"    p.status == \"active\" AND\n" +
+				"    p.country IN [\"FR\", \"BE\"]\n" +
+				"    ==> Print(p.id)\n",
+		},
+		{
+			name:  "commentaires conservés",
+			input: "// Types\ntype P(#id: string) // clé seule\n\n\n/* règle */\nrule r : {p: P} / p.id == \"a\" AND // premier\n p.id != \"b\" ==> Print(p.id)\n// fin\n",
+			want: "// Types\ntype P(#id: string) // clé seule\n\n/* règle */\n" +
+				"rule r : {p: P} /\n" +
+				"    p.id == \"a\" AND // premier\n" +
+				"    p.id != \"b\"\n" +
+				"    ==> Print(p.id)\n" +
+				"// fin\n",
+		},
+		{
+			name:  "parenthèses et priorités",
+			input: "rule r : {p: P} / (p.a - 1) * 2 > p.b - (p.c - 1) or (p.a > 1 AND p.b > 2) ==> Print(p.a)\n",
+			want:  "rule r : {p: P} / (p.a - 1) * 2 > p.b - (p.c - 1) OR (p.a > 1 AND p.b > 2) ==> Print(p.a)\n",
+		},
+		{
+			name:  "xuple-space",
+			input: "xuple-space q { selection: lifo consumption: limited(3) retention: duration(7200s) }\n",
+			want:  "xuple-space q {\n    selection: lifo\n    consumption: limited(3)\n    retention: duration(2h)\n}\n",
+		},
+		{
+			name:  "vide",
+			input: "\n\n",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format("rules.tsd", []byte(tt.input))
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Format() =\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			again, err := Format("rules.tsd", got)
+			if err != nil || string(again) != string(got) {
+				t.Errorf("Format() n'est pas idempotent:\n%s", again)
+			}
+		})
+	}
+}
+
+func TestFormat_SyntaxError(t *testing.T) {
+	_, err := Format("broken.tsd", []byte("rule r  {p: P} / p.id == \"x\" ==> Print(p.id)\n"))
+	if diagnostics := Diagnostics(err); len(diagnostics) != 1 || diagnostics[0].Code != DiagnosticCodeSyntax {
+		t.Errorf("Diagnostics() = %+v, want one syntax error", diagnostics)
+	}
+}
+
+// TestFormat_Examples vérifie que les exemples se formatent sans changer de
+// sens (Format le contrôle en reparsant) et de façon stable
+func TestFormat_Examples(t *testing.T) {
+	var files []string
+	err := filepath.Walk("../examples", func(path string, info os.FileInfo, err error) error {
+		if err == nil && strings.HasSuffix(path, ".tsd") {
+			files = append(files, path)
+		}
+		return err
+	})
+	if err != nil || len(files) == 0 {
+		t.Fatalf("aucun exemple trouvé: %v", err)
+	}
+
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseConstraint(file, src); err != nil {
+			continue // exemples volontairement invalides
+		}
+		formatted, err := Format(file, src)
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+			continue
+		}
+		if again, err := Format(file, formatted); err != nil || string(again) != string(formatted) {
+			t.Errorf("%s: formatage instable", file)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	comments, err := Comments("rules.tsd", []byte("// en-tête\ntype P(#id: string) /* fin */\nrule r : {p: P} / p.id == \"// pas un commentaire\" ==> Print(p.id)\n"))
+	if err != nil {
+		t.Fatalf("Comments() error = %v", err)
+	}
+	want := []Comment{
+		{Text: "// en-tête", Span: Span{File: "rules.tsd", Line: 1, Column: 1, EndLine: 1, EndColumn: 11}},
+		{Text: "/* fin */", Span: Span{File: "rules.tsd", Line: 2, Column: 21, EndLine: 2, EndColumn: 30}},
+	}
+	if len(comments) != len(want) || comments[0] != want[0] || comments[1] != want[1] {
+		t.Errorf("Comments() = %+v, want %+v", comments, want)
+	}
+}
@@ -5,6 +5,7 @@
 package constraint
 
 import (
+	"sort"
 	"strings"
 	"sync"
 )
@@ -81,6 +82,19 @@ func (fr *FunctionRegistry) GetSignature(funcName string) (*FunctionSignature, b
 	return sig, exists
 }
 
+// Functions returns the registered function signatures sorted by name
+func (fr *FunctionRegistry) Functions() []FunctionSignature {
+	fr.mu.RLock()
+	defer fr.mu.RUnlock()
+
+	functions := make([]FunctionSignature, 0, len(fr.functions))
+	for _, sig := range fr.functions {
+		functions = append(functions, *sig)
+	}
+	sort.Slice(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
+	return functions
+}
+
 // HasFunction checks if a function is registered
 func (fr *FunctionRegistry) HasFunction(funcName string) bool {
 	fr.mu.RLock()
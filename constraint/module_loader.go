@@ -0,0 +1,375 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Visibilités des déclarations d'un package
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// Catégories de symboles déclarés par un package
+const (
+	symbolKindType   = "type"
+	symbolKindAction = "action"
+	symbolKindRule   = "rule"
+	symbolKindQuery  = "query"
+)
+
+// astListKeys liste les sections de l'AST concaténées lors de la fusion des modules
+var astListKeys = []string{
+	"types", "actions", "xupleSpaces", "expressions", "queries",
+	"facts", "factAssignments", "retractions", "ruleRemovals", "resets",
+}
+
+// moduleSymbol décrit une déclaration qualifiée connue du chargeur
+type moduleSymbol struct {
+	pkg        string
+	visibility string
+	file       string
+}
+
+// ModuleLoader charge un fichier TSD avec ses imports et applique les espaces de noms.
+//
+// Les imports sont résolus relativement au fichier qui les déclare et chargés une
+// seule fois : un fichier déjà chargé par ce chargeur (y compris lors d'un appel
+// précédent) est ignoré. Les cycles d'import sont détectés et signalés.
+//
+// Un fichier qui déclare "package billing" voit ses types, actions, règles et
+// requêtes qualifiés (billing.Invoice). Dans ce fichier, les noms non qualifiés
+// désignent d'abord les déclarations du package, puis l'espace global. Les
+// déclarations marquées "private" ne sont accessibles que depuis leur package.
+//
+// Thread-Safety: ModuleLoader n'est PAS thread-safe.
+type ModuleLoader struct {
+	loaded  map[string]bool
+	symbols map[string]moduleSymbol
+}
+
+// NewModuleLoader crée un chargeur de modules vierge
+func NewModuleLoader() *ModuleLoader {
+	return &ModuleLoader{
+		loaded:  make(map[string]bool),
+		symbols: make(map[string]moduleSymbol),
+	}
+}
+
+// Clone retourne une copie indépendante du chargeur.
+// Permet de charger de manière provisoire et de ne conserver l'état qu'en cas de succès.
+func (ml *ModuleLoader) Clone() *ModuleLoader {
+	clone := NewModuleLoader()
+	for path := range ml.loaded {
+		clone.loaded[path] = true
+	}
+	for key, symbol := range ml.symbols {
+		clone.symbols[key] = symbol
+	}
+	return clone
+}
+
+// Reset oublie les fichiers chargés et les symboles déclarés
+func (ml *ModuleLoader) Reset() {
+	ml.loaded = make(map[string]bool)
+	ml.symbols = make(map[string]moduleSymbol)
+}
+
+// LoadedFiles retourne le nombre de fichiers chargés
+func (ml *ModuleLoader) LoadedFiles() int {
+	return len(ml.loaded)
+}
+
+// Load parse un fichier, charge ses imports et retourne l'AST fusionné.
+// Le fichier d'entrée est toujours chargé, même s'il l'a déjà été.
+func (ml *ModuleLoader) Load(filename string) (map[string]interface{}, error) {
+	return ml.load(filename, nil)
+}
+
+// LoadContent fonctionne comme Load pour un contenu en mémoire.
+// Les imports relatifs sont résolus depuis le répertoire de filename.
+func (ml *ModuleLoader) LoadContent(content, filename string) (map[string]interface{}, error) {
+	return ml.load(filename, []byte(content))
+}
+
+// load est l'implémentation commune de Load et LoadContent
+func (ml *ModuleLoader) load(filename string, content []byte) (map[string]interface{}, error) {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("chemin invalide %s: %w", filename, err)
+	}
+
+	merged := make(map[string]interface{}, len(astListKeys))
+	for _, key := range astListKeys {
+		merged[key] = []interface{}{}
+	}
+
+	if err := ml.loadModule(path, filename, content, nil, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// loadModule charge un fichier après ses imports et ajoute ses déclarations à l'AST fusionné
+func (ml *ModuleLoader) loadModule(path, displayName string, content []byte, stack []string, merged map[string]interface{}) error {
+	for i, inProgress := range stack {
+		if inProgress == path {
+			cycle := append(append([]string(nil), stack[i:]...), path)
+			return fmt.Errorf("cycle d'import détecté: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	entry := len(stack) == 0
+	if !entry && ml.loaded[path] {
+		return nil
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	if content == nil {
+		// Les positions et les erreurs de syntaxe désignent le fichier par son nom affiché
+		if content, err = os.ReadFile(path); err != nil {
+			return err
+		}
+	}
+	result, err = ParseConstraint(displayName, content)
+	if err != nil {
+		return err
+	}
+
+	ast, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("format AST non reconnu pour %s: %T", displayName, result)
+	}
+
+	// Un reset dans le fichier d'entrée invalide tout ce qui a été chargé avant
+	if entry && hasASTEntries(ast, "resets") {
+		ml.Reset()
+	}
+	ml.loaded[path] = true
+
+	stack = append(stack, path)
+	for _, importPath := range DeclaredImports(ast) {
+		resolved := importPath
+		if !filepath.IsAbs(resolved) {
+			resolved = filepath.Join(filepath.Dir(path), resolved)
+		}
+		resolved = filepath.Clean(resolved)
+
+		if _, err := os.Stat(resolved); err != nil {
+			return fmt.Errorf("import '%s' dans %s: %w", importPath, displayName, err)
+		}
+		if err := ml.loadModule(resolved, resolved, nil, stack, merged); err != nil {
+			return err
+		}
+	}
+
+	pkg, _ := ast["package"].(string)
+	if err := ml.applyNamespace(ast, pkg, displayName); err != nil {
+		return err
+	}
+
+	for _, key := range astListKeys {
+		if items, ok := ast[key].([]interface{}); ok {
+			merged[key] = append(merged[key].([]interface{}), items...)
+		}
+	}
+	return nil
+}
+
+// DeclaredImports retourne les chemins importés par un AST brut, dans l'ordre de déclaration
+func DeclaredImports(ast interface{}) []string {
+	astMap, ok := ast.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	imports, _ := astMap["imports"].([]interface{})
+	paths := make([]string, 0, len(imports))
+	for _, item := range imports {
+		if importMap, ok := item.(map[string]interface{}); ok {
+			if path, ok := importMap["path"].(string); ok {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// applyNamespace enregistre les déclarations d'un fichier puis qualifie et vérifie ses références
+func (ml *ModuleLoader) applyNamespace(ast map[string]interface{}, pkg, file string) error {
+	declarations := []struct {
+		key     string
+		kind    string
+		nameKey string
+	}{
+		{"types", symbolKindType, "name"},
+		{"actions", symbolKindAction, "name"},
+		{"expressions", symbolKindRule, "ruleId"},
+		{"queries", symbolKindQuery, "name"},
+	}
+
+	// Première passe : qualifier et enregistrer les déclarations du fichier
+	for _, decl := range declarations {
+		items, _ := ast[decl.key].([]interface{})
+		for _, item := range items {
+			node, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if err := ml.declare(node, decl.kind, decl.nameKey, pkg, file); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Seconde passe : résoudre les références
+	r := &namespaceResolver{loader: ml, pkg: pkg, file: file}
+	for _, key := range astListKeys {
+		if key == "resets" {
+			continue
+		}
+		if err := r.walk(ast[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// declare qualifie le nom d'une déclaration et l'enregistre dans la table des symboles
+func (ml *ModuleLoader) declare(node map[string]interface{}, kind, nameKey, pkg, file string) error {
+	name, _ := node[nameKey].(string)
+	visibility, _ := node["visibility"].(string)
+	delete(node, "visibility")
+
+	if visibility == VisibilityPrivate && pkg == "" {
+		return fmt.Errorf("%s '%s' dans %s: 'private' nécessite une déclaration package", kind, name, file)
+	}
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+	if name == "" {
+		return nil
+	}
+
+	qualified := name
+	if pkg != "" {
+		qualified = pkg + "." + name
+		node[nameKey] = qualified
+	}
+	ml.symbols[kind+":"+qualified] = moduleSymbol{pkg: pkg, visibility: visibility, file: file}
+	return nil
+}
+
+// namespaceResolver résout les références d'un fichier dans le contexte de son package
+type namespaceResolver struct {
+	loader *ModuleLoader
+	pkg    string
+	file   string
+}
+
+// walk parcourt l'AST et résout les noms de types, d'actions et de règles référencés
+func (r *namespaceResolver) walk(node interface{}) error {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, item := range n {
+			if err := r.walk(item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		if err := r.resolveNode(n); err != nil {
+			return err
+		}
+		for _, value := range n {
+			if err := r.walk(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveNode résout les références portées directement par un nœud
+func (r *namespaceResolver) resolveNode(node map[string]interface{}) error {
+	switch node["type"] {
+	case "typedVariable":
+		return r.resolveField(node, "dataType", symbolKindType)
+	case "fact", "inlineFact", "retraction":
+		return r.resolveField(node, "typeName", symbolKindType)
+	case "jobCall":
+		return r.resolveField(node, "name", symbolKindAction)
+	case "ruleRemoval":
+		return r.resolveField(node, "ruleID", symbolKindRule)
+	}
+
+	// Champs et paramètres de types utilisateur
+	if _, isField := node["isPrimaryKey"]; isField {
+		return r.resolveField(node, "type", symbolKindType)
+	}
+	if _, isParam := node["optional"]; isParam {
+		return r.resolveField(node, "type", symbolKindType)
+	}
+	return nil
+}
+
+// resolveField remplace un nom par sa forme qualifiée résolue
+func (r *namespaceResolver) resolveField(node map[string]interface{}, key, kind string) error {
+	name, ok := node[key].(string)
+	if !ok || name == "" {
+		return nil
+	}
+	resolved, err := r.resolve(kind, name)
+	if err != nil {
+		return err
+	}
+	node[key] = resolved
+	return nil
+}
+
+// resolve retourne le nom qualifié d'une référence et vérifie sa visibilité
+func (r *namespaceResolver) resolve(kind, name string) (string, error) {
+	if kind == symbolKindType && isPrimitiveTypeName(name) {
+		return name, nil
+	}
+
+	if pkg, _, qualified := strings.Cut(name, "."); qualified {
+		symbol, exists := r.loader.symbols[kind+":"+name]
+		if exists && symbol.visibility == VisibilityPrivate && pkg != r.pkg {
+			return "", fmt.Errorf("%s '%s' privé au package '%s', inaccessible depuis %s", kind, name, pkg, r.file)
+		}
+		// Un symbole inconnu est laissé tel quel : la validation signalera l'erreur
+		return name, nil
+	}
+
+	if r.pkg != "" {
+		local := r.pkg + "." + name
+		if _, exists := r.loader.symbols[kind+":"+local]; exists {
+			return local, nil
+		}
+	}
+	return name, nil
+}
+
+// isPrimitiveTypeName indique si un nom de type est un type primitif
+func isPrimitiveTypeName(name string) bool {
+	switch name {
+	case ValueTypeString, ValueTypeNumber, ValueTypeBool:
+		return true
+	}
+	return false
+}
+
+// hasASTEntries indique si une section de l'AST contient au moins un élément
+func hasASTEntries(ast map[string]interface{}, key string) bool {
+	items, ok := ast[key].([]interface{})
+	return ok && len(items) > 0
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeModuleFiles crée une arborescence de fichiers TSD dans un répertoire temporaire
+func writeModuleFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// astNames extrait un champ de chaque élément d'une section de l'AST
+func astNames(ast map[string]interface{}, key, field string) []string {
+	var names []string
+	items, _ := ast[key].([]interface{})
+	for _, item := range items {
+		if node, ok := item.(map[string]interface{}); ok {
+			if name, ok := node[field].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+var billingModules = map[string]string{
+	"common/types.tsd": `type Customer(#id: string, name: string)`,
+	"billing/invoice.tsd": `package billing
+import "../common/types.tsd"
+
+type Invoice(#id: string, customer: string, amount: number)
+private type Ledger(#id: string, total: number)
+action notify(id: string)
+rule big : {i: Invoice, c: Customer} / i.customer == c.id AND i.amount > 100 ==> notify(i.id)
+query large(min: number) : {i: Invoice} / i.amount > min
+`,
+	"main.tsd": `import "billing/invoice.tsd"
+import "common/types.tsd"
+
+Customer(id: "c1", name: "Ann")
+billing.Invoice(id: "i1", customer: "c1", amount: 500)
+rule audit : {i: billing.Invoice} / i.amount > 0 ==> billing.notify(i.id)
+`,
+}
+
+func TestModuleLoader_ImportsAndPackages(t *testing.T) {
+	dir := writeModuleFiles(t, billingModules)
+
+	ast, err := NewModuleLoader().Load(filepath.Join(dir, "main.tsd"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Les fichiers importés sont chargés avant l'importeur, une seule fois
+	types := astNames(ast, "types", "name")
+	if strings.Join(types, ",") != "Customer,billing.Invoice,billing.Ledger" {
+		t.Errorf("types = %v", types)
+	}
+	if rules := astNames(ast, "expressions", "ruleId"); strings.Join(rules, ",") != "billing.big,audit" {
+		t.Errorf("rules = %v", rules)
+	}
+	if actions := astNames(ast, "actions", "name"); len(actions) != 1 || actions[0] != "billing.notify" {
+		t.Errorf("actions = %v", actions)
+	}
+	if queries := astNames(ast, "queries", "name"); len(queries) != 1 || queries[0] != "billing.large" {
+		t.Errorf("queries = %v", queries)
+	}
+	if facts := astNames(ast, "facts", "typeName"); strings.Join(facts, ",") != "Customer,billing.Invoice" {
+		t.Errorf("facts = %v", facts)
+	}
+
+	// Les références non qualifiées du package sont résolues dans le package
+	program, err := ConvertResultToProgram(ast)
+	if err != nil {
+		t.Fatalf("ConvertResultToProgram() error = %v", err)
+	}
+	big := program.Expressions[0]
+	if big.Set.Variables[0].DataType != "billing.Invoice" || big.Set.Variables[1].DataType != "Customer" {
+		t.Errorf("variables = %+v", big.Set.Variables)
+	}
+	if jobs := big.Action.GetJobs(); jobs[0].Name != "billing.notify" {
+		t.Errorf("job = %s, want billing.notify", jobs[0].Name)
+	}
+
+	if err := ValidateConstraintProgram(ast); err != nil {
+		t.Errorf("ValidateConstraintProgram() error = %v", err)
+	}
+}
+
+func TestModuleLoader_LoadsImportsOnce(t *testing.T) {
+	dir := writeModuleFiles(t, map[string]string{
+		"types.tsd":  `type Item(#id: string)`,
+		"first.tsd":  "import \"types.tsd\"\nItem(id: \"a\")",
+		"second.tsd": "import \"types.tsd\"\nItem(id: \"b\")",
+	})
+
+	loader := NewModuleLoader()
+	first, err := loader.Load(filepath.Join(dir, "first.tsd"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(astNames(first, "types", "name")) != 1 {
+		t.Errorf("first load should include the imported type")
+	}
+
+	second, err := loader.Load(filepath.Join(dir, "second.tsd"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(astNames(second, "types", "name")) != 0 {
+		t.Errorf("already loaded import should be skipped")
+	}
+	if loader.LoadedFiles() != 3 {
+		t.Errorf("LoadedFiles() = %d, want 3", loader.LoadedFiles())
+	}
+
+	// Un clone est indépendant, un reset oublie les fichiers chargés
+	clone := loader.Clone()
+	loader.Reset()
+	if clone.LoadedFiles() != 3 || loader.LoadedFiles() != 0 {
+		t.Errorf("Clone()/Reset() not independent: %d, %d", clone.LoadedFiles(), loader.LoadedFiles())
+	}
+}
+
+func TestModuleLoader_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		entry   string
+		wantErr string
+	}{
+		{
+			name: "import cycle",
+			files: map[string]string{
+				"a.tsd": "import \"b.tsd\"\ntype A(#id: string)",
+				"b.tsd": "import \"a.tsd\"\ntype B(#id: string)",
+			},
+			entry:   "a.tsd",
+			wantErr: "cycle d'import",
+		},
+		{
+			name:    "missing import",
+			files:   map[string]string{"a.tsd": `import "missing.tsd"`},
+			entry:   "a.tsd",
+			wantErr: "missing.tsd",
+		},
+		{
+			name: "private type from another package",
+			files: map[string]string{
+				"billing.tsd": "package billing\nprivate type Ledger(#id: string)",
+				"main.tsd":    "import \"billing.tsd\"\nbilling.Ledger(id: \"x\")",
+			},
+			entry:   "main.tsd",
+			wantErr: "privé au package 'billing'",
+		},
+		{
+			name: "private action from another package",
+			files: map[string]string{
+				"billing.tsd": "package billing\ntype Invoice(#id: string)\nprivate action audit(id: string)",
+				"main.tsd":    "import \"billing.tsd\"\nrule r : {i: billing.Invoice} / ==> billing.audit(i.id)",
+			},
+			entry:   "main.tsd",
+			wantErr: "privé au package 'billing'",
+		},
+		{
+			name:    "private without package",
+			files:   map[string]string{"a.tsd": `private type A(#id: string)`},
+			entry:   "a.tsd",
+			wantErr: "nécessite une déclaration package",
+		},
+		{
+			name:    "multiple package declarations",
+			files:   map[string]string{"a.tsd": "package one\npackage two"},
+			entry:   "a.tsd",
+			wantErr: "déclaration package multiple",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeModuleFiles(t, tt.files)
+			_, err := NewModuleLoader().Load(filepath.Join(dir, tt.entry))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModuleLoader_PrivateAccessibleInsidePackage(t *testing.T) {
+	dir := writeModuleFiles(t, map[string]string{
+		"ledger.tsd": "package billing\nprivate type Ledger(#id: string, total: number)",
+		"rules.tsd": `package billing
+import "ledger.tsd"
+rule r : {l: Ledger} / l.total > 0 ==> Print(l.id)
+billing.Ledger(id: "x", total: 1)
+`,
+	})
+
+	ast, err := NewModuleLoader().Load(filepath.Join(dir, "rules.tsd"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := ValidateConstraintProgram(ast); err != nil {
+		t.Errorf("ValidateConstraintProgram() error = %v", err)
+	}
+}
+
+func TestProgramState_ParseAndMergeWithImports(t *testing.T) {
+	dir := writeModuleFiles(t, billingModules)
+
+	ps := NewProgramState()
+	if err := ps.ParseAndMerge(filepath.Join(dir, "main.tsd")); err != nil {
+		t.Fatalf("ParseAndMerge() error = %v", err)
+	}
+	if ps.HasErrors() {
+		t.Fatalf("unexpected errors: %v", ps.GetErrors())
+	}
+	if ps.GetTypesCount() != 3 || ps.GetRulesCount() != 2 || ps.GetFactsCount() != 2 {
+		t.Errorf("types=%d rules=%d facts=%d", ps.GetTypesCount(), ps.GetRulesCount(), ps.GetFactsCount())
+	}
+
+	// Un import déjà chargé n'est pas rechargé par un appel suivant
+	if err := ps.ParseAndMergeContent("import \"common/types.tsd\"\nCustomer(id: \"c2\", name: \"Bob\")",
+		filepath.Join(dir, "more.tsd")); err != nil {
+		t.Fatalf("ParseAndMergeContent() error = %v", err)
+	}
+	if ps.GetFactsCount() != 3 || ps.HasErrors() {
+		t.Errorf("facts=%d errors=%v", ps.GetFactsCount(), ps.GetErrors())
+	}
+}
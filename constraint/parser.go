@@ -54,24 +54,24 @@ var g = &grammar{
 		},
 		{
 			name: "StatementList",
-			pos:  position{line: 67, col: 1, offset: 2471},
+			pos:  position{line: 83, col: 1, offset: 3278},
 			expr: &actionExpr{
-				pos: position{line: 67, col: 18, offset: 2488},
+				pos: position{line: 83, col: 18, offset: 3295},
 				run: (*parser).callonStatementList1,
 				expr: &labeledExpr{
-					pos:   position{line: 67, col: 18, offset: 2488},
+					pos:   position{line: 83, col: 18, offset: 3295},
 					label: "statements",
 					expr: &zeroOrMoreExpr{
-						pos: position{line: 67, col: 29, offset: 2499},
+						pos: position{line: 83, col: 29, offset: 3306},
 						expr: &seqExpr{
-							pos: position{line: 67, col: 30, offset: 2500},
+							pos: position{line: 83, col: 30, offset: 3307},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 67, col: 30, offset: 2500},
+									pos:  position{line: 83, col: 30, offset: 3307},
 									name: "Statement",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 67, col: 40, offset: 2510},
+									pos:  position{line: 83, col: 40, offset: 3317},
 									name: "_",
 								},
 							},
@@ -82,57 +82,408 @@ var g = &grammar{
 		},
 		{
 			name: "Statement",
-			pos:  position{line: 77, col: 1, offset: 2734},
+			pos:  position{line: 94, col: 1, offset: 3573},
 			expr: &choiceExpr{
-				pos: position{line: 77, col: 14, offset: 2747},
+				pos: position{line: 94, col: 14, offset: 3586},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 14, offset: 2747},
+						pos:  position{line: 94, col: 14, offset: 3586},
+						name: "ImportStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 94, col: 32, offset: 3604},
+						name: "PackageDeclaration",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 94, col: 53, offset: 3625},
+						name: "AnnotatedRule",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 94, col: 69, offset: 3641},
+						name: "PrivateDeclaration",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 94, col: 90, offset: 3662},
 						name: "TypeDefinition",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 31, offset: 2764},
+						pos:  position{line: 94, col: 107, offset: 3679},
 						name: "ActionDefinition",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 50, offset: 2783},
+						pos:  position{line: 94, col: 126, offset: 3698},
 						name: "XupleSpaceDeclaration",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 74, offset: 2807},
+						pos:  position{line: 94, col: 150, offset: 3722},
+						name: "QueryDefinition",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 94, col: 168, offset: 3740},
 						name: "Expression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 87, offset: 2820},
+						pos:  position{line: 94, col: 181, offset: 3753},
 						name: "RemoveRule",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 100, offset: 2833},
+						pos:  position{line: 94, col: 194, offset: 3766},
 						name: "RemoveFact",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 113, offset: 2846},
+						pos:  position{line: 94, col: 207, offset: 3779},
 						name: "FactAssignment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 130, offset: 2863},
+						pos:  position{line: 94, col: 224, offset: 3796},
 						name: "Fact",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 137, offset: 2870},
+						pos:  position{line: 94, col: 231, offset: 3803},
 						name: "Reset",
 					},
 				},
 			},
 		},
+		{
+			name: "ImportStatement",
+			pos:  position{line: 97, col: 1, offset: 3898},
+			expr: &actionExpr{
+				pos: position{line: 97, col: 20, offset: 3917},
+				run: (*parser).callonImportStatement1,
+				expr: &seqExpr{
+					pos: position{line: 97, col: 20, offset: 3917},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 97, col: 20, offset: 3917},
+							val:        "import",
+							ignoreCase: false,
+							want:       "\"import\"",
+						},
+						&notExpr{
+							pos: position{line: 97, col: 29, offset: 3926},
+							expr: &ruleRefExpr{
+								pos:  position{line: 97, col: 30, offset: 3927},
+								name: "IdentContinue",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 97, col: 44, offset: 3941},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 97, col: 46, offset: 3943},
+							label: "path",
+							expr: &ruleRefExpr{
+								pos:  position{line: 97, col: 51, offset: 3948},
+								name: "StringLiteral",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PackageDeclaration",
+			pos:  position{line: 106, col: 1, offset: 4221},
+			expr: &actionExpr{
+				pos: position{line: 106, col: 23, offset: 4243},
+				run: (*parser).callonPackageDeclaration1,
+				expr: &seqExpr{
+					pos: position{line: 106, col: 23, offset: 4243},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 106, col: 23, offset: 4243},
+							val:        "package",
+							ignoreCase: false,
+							want:       "\"package\"",
+						},
+						&notExpr{
+							pos: position{line: 106, col: 33, offset: 4253},
+							expr: &ruleRefExpr{
+								pos:  position{line: 106, col: 34, offset: 4254},
+								name: "IdentContinue",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 106, col: 48, offset: 4268},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 106, col: 50, offset: 4270},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 106, col: 55, offset: 4275},
+								name: "IdentName",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AnnotatedRule",
+			pos:  position{line: 115, col: 1, offset: 4538},
+			expr: &actionExpr{
+				pos: position{line: 115, col: 18, offset: 4555},
+				run: (*parser).callonAnnotatedRule1,
+				expr: &seqExpr{
+					pos: position{line: 115, col: 18, offset: 4555},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 115, col: 18, offset: 4555},
+							label: "annotations",
+							expr: &oneOrMoreExpr{
+								pos: position{line: 115, col: 30, offset: 4567},
+								expr: &seqExpr{
+									pos: position{line: 115, col: 31, offset: 4568},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 31, offset: 4568},
+											name: "Annotation",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 42, offset: 4579},
+											name: "_",
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 115, col: 46, offset: 4583},
+							label: "rule",
+							expr: &choiceExpr{
+								pos: position{line: 115, col: 52, offset: 4589},
+								alternatives: []any{
+									&ruleRefExpr{
+										pos:  position{line: 115, col: 52, offset: 4589},
+										name: "PrivateDeclaration",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 115, col: 73, offset: 4610},
+										name: "Expression",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Annotation",
+			pos:  position{line: 128, col: 1, offset: 5012},
+			expr: &actionExpr{
+				pos: position{line: 128, col: 15, offset: 5026},
+				run: (*parser).callonAnnotation1,
+				expr: &seqExpr{
+					pos: position{line: 128, col: 15, offset: 5026},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 128, col: 15, offset: 5026},
+							val:        "@",
+							ignoreCase: false,
+							want:       "\"@\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 128, col: 19, offset: 5030},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 128, col: 24, offset: 5035},
+								name: "IdentName",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 128, col: 34, offset: 5045},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 128, col: 39, offset: 5050},
+								expr: &seqExpr{
+									pos: position{line: 128, col: 40, offset: 5051},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 128, col: 40, offset: 5051},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 128, col: 42, offset: 5053},
+											val:        "(",
+											ignoreCase: false,
+											want:       "\"(\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 128, col: 46, offset: 5057},
+											name: "_",
+										},
+										&zeroOrOneExpr{
+											pos: position{line: 128, col: 48, offset: 5059},
+											expr: &ruleRefExpr{
+												pos:  position{line: 128, col: 48, offset: 5059},
+												name: "AnnotationArgs",
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 128, col: 64, offset: 5075},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 128, col: 66, offset: 5077},
+											val:        ")",
+											ignoreCase: false,
+											want:       "\")\"",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AnnotationArgs",
+			pos:  position{line: 143, col: 1, offset: 5405},
+			expr: &actionExpr{
+				pos: position{line: 143, col: 19, offset: 5423},
+				run: (*parser).callonAnnotationArgs1,
+				expr: &seqExpr{
+					pos: position{line: 143, col: 19, offset: 5423},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 143, col: 19, offset: 5423},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 143, col: 25, offset: 5429},
+								name: "AnnotationArg",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 143, col: 39, offset: 5443},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 143, col: 44, offset: 5448},
+								expr: &seqExpr{
+									pos: position{line: 143, col: 45, offset: 5449},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 143, col: 45, offset: 5449},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 143, col: 47, offset: 5451},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 143, col: 51, offset: 5455},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 143, col: 53, offset: 5457},
+											name: "AnnotationArg",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AnnotationArg",
+			pos:  position{line: 153, col: 1, offset: 5686},
+			expr: &choiceExpr{
+				pos: position{line: 153, col: 18, offset: 5703},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 153, col: 18, offset: 5703},
+						run: (*parser).callonAnnotationArg2,
+						expr: &labeledExpr{
+							pos:   position{line: 153, col: 18, offset: 5703},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 153, col: 24, offset: 5709},
+								name: "StringLiteral",
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 155, col: 5, offset: 5785},
+						run: (*parser).callonAnnotationArg5,
+						expr: &ruleRefExpr{
+							pos:  position{line: 155, col: 5, offset: 5785},
+							name: "Number",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PrivateDeclaration",
+			pos:  position{line: 160, col: 1, offset: 5904},
+			expr: &actionExpr{
+				pos: position{line: 160, col: 23, offset: 5926},
+				run: (*parser).callonPrivateDeclaration1,
+				expr: &seqExpr{
+					pos: position{line: 160, col: 23, offset: 5926},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 160, col: 23, offset: 5926},
+							val:        "private",
+							ignoreCase: false,
+							want:       "\"private\"",
+						},
+						&notExpr{
+							pos: position{line: 160, col: 33, offset: 5936},
+							expr: &ruleRefExpr{
+								pos:  position{line: 160, col: 34, offset: 5937},
+								name: "IdentContinue",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 160, col: 48, offset: 5951},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 160, col: 50, offset: 5953},
+							label: "decl",
+							expr: &choiceExpr{
+								pos: position{line: 160, col: 56, offset: 5959},
+								alternatives: []any{
+									&ruleRefExpr{
+										pos:  position{line: 160, col: 56, offset: 5959},
+										name: "TypeDefinition",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 160, col: 73, offset: 5976},
+										name: "ActionDefinition",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 160, col: 92, offset: 5995},
+										name: "QueryDefinition",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 160, col: 110, offset: 6013},
+										name: "Expression",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Reset",
-			pos:  position{line: 79, col: 1, offset: 2877},
+			pos:  position{line: 166, col: 1, offset: 6137},
 			expr: &actionExpr{
-				pos: position{line: 79, col: 10, offset: 2886},
+				pos: position{line: 166, col: 10, offset: 6146},
 				run: (*parser).callonReset1,
 				expr: &litMatcher{
-					pos:        position{line: 79, col: 10, offset: 2886},
+					pos:        position{line: 166, col: 10, offset: 6146},
 					val:        "reset",
 					ignoreCase: false,
 					want:       "\"reset\"",
@@ -141,59 +492,59 @@ var g = &grammar{
 		},
 		{
 			name: "TypeDefinition",
-			pos:  position{line: 85, col: 1, offset: 2970},
+			pos:  position{line: 173, col: 1, offset: 6258},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 19, offset: 2988},
+				pos: position{line: 173, col: 19, offset: 6276},
 				run: (*parser).callonTypeDefinition1,
 				expr: &seqExpr{
-					pos: position{line: 85, col: 19, offset: 2988},
+					pos: position{line: 173, col: 19, offset: 6276},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 85, col: 19, offset: 2988},
+							pos:        position{line: 173, col: 19, offset: 6276},
 							val:        "type",
 							ignoreCase: false,
 							want:       "\"type\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 26, offset: 2995},
+							pos:  position{line: 173, col: 26, offset: 6283},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 85, col: 28, offset: 2997},
+							pos:   position{line: 173, col: 28, offset: 6285},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 85, col: 33, offset: 3002},
+								pos:  position{line: 173, col: 33, offset: 6290},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 43, offset: 3012},
+							pos:  position{line: 173, col: 43, offset: 6300},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 85, col: 45, offset: 3014},
+							pos:        position{line: 173, col: 45, offset: 6302},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 49, offset: 3018},
+							pos:  position{line: 173, col: 49, offset: 6306},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 85, col: 51, offset: 3020},
+							pos:   position{line: 173, col: 51, offset: 6308},
 							label: "fields",
 							expr: &ruleRefExpr{
-								pos:  position{line: 85, col: 58, offset: 3027},
+								pos:  position{line: 173, col: 58, offset: 6315},
 								name: "FieldList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 68, offset: 3037},
+							pos:  position{line: 173, col: 68, offset: 6325},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 85, col: 70, offset: 3039},
+							pos:        position{line: 173, col: 70, offset: 6327},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -204,45 +555,45 @@ var g = &grammar{
 		},
 		{
 			name: "FieldList",
-			pos:  position{line: 93, col: 1, offset: 3176},
+			pos:  position{line: 182, col: 1, offset: 6492},
 			expr: &actionExpr{
-				pos: position{line: 93, col: 14, offset: 3189},
+				pos: position{line: 182, col: 14, offset: 6505},
 				run: (*parser).callonFieldList1,
 				expr: &seqExpr{
-					pos: position{line: 93, col: 14, offset: 3189},
+					pos: position{line: 182, col: 14, offset: 6505},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 93, col: 14, offset: 3189},
+							pos:   position{line: 182, col: 14, offset: 6505},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 93, col: 20, offset: 3195},
+								pos:  position{line: 182, col: 20, offset: 6511},
 								name: "Field",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 26, offset: 3201},
+							pos:   position{line: 182, col: 26, offset: 6517},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 93, col: 31, offset: 3206},
+								pos: position{line: 182, col: 31, offset: 6522},
 								expr: &seqExpr{
-									pos: position{line: 93, col: 32, offset: 3207},
+									pos: position{line: 182, col: 32, offset: 6523},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 32, offset: 3207},
+											pos:  position{line: 182, col: 32, offset: 6523},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 93, col: 34, offset: 3209},
+											pos:        position{line: 182, col: 34, offset: 6525},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 38, offset: 3213},
+											pos:  position{line: 182, col: 38, offset: 6529},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 40, offset: 3215},
+											pos:  position{line: 182, col: 40, offset: 6531},
 											name: "Field",
 										},
 									},
@@ -255,20 +606,20 @@ var g = &grammar{
 		},
 		{
 			name: "Field",
-			pos:  position{line: 103, col: 1, offset: 3436},
+			pos:  position{line: 192, col: 1, offset: 6752},
 			expr: &actionExpr{
-				pos: position{line: 103, col: 10, offset: 3445},
+				pos: position{line: 192, col: 10, offset: 6761},
 				run: (*parser).callonField1,
 				expr: &seqExpr{
-					pos: position{line: 103, col: 10, offset: 3445},
+					pos: position{line: 192, col: 10, offset: 6761},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 103, col: 10, offset: 3445},
+							pos:   position{line: 192, col: 10, offset: 6761},
 							label: "primaryKey",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 103, col: 21, offset: 3456},
+								pos: position{line: 192, col: 21, offset: 6772},
 								expr: &litMatcher{
-									pos:        position{line: 103, col: 21, offset: 3456},
+									pos:        position{line: 192, col: 21, offset: 6772},
 									val:        "#",
 									ignoreCase: false,
 									want:       "\"#\"",
@@ -276,32 +627,32 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 103, col: 26, offset: 3461},
+							pos:   position{line: 192, col: 26, offset: 6777},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 103, col: 31, offset: 3466},
+								pos:  position{line: 192, col: 31, offset: 6782},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 103, col: 41, offset: 3476},
+							pos:  position{line: 192, col: 41, offset: 6792},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 103, col: 43, offset: 3478},
+							pos:        position{line: 192, col: 43, offset: 6794},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 103, col: 47, offset: 3482},
+							pos:  position{line: 192, col: 47, offset: 6798},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 103, col: 49, offset: 3484},
+							pos:   position{line: 192, col: 49, offset: 6800},
 							label: "fieldType",
 							expr: &ruleRefExpr{
-								pos:  position{line: 103, col: 59, offset: 3494},
+								pos:  position{line: 192, col: 59, offset: 6810},
 								name: "FieldType",
 							},
 						},
@@ -311,16 +662,16 @@ var g = &grammar{
 		},
 		{
 			name: "FieldType",
-			pos:  position{line: 123, col: 1, offset: 3969},
+			pos:  position{line: 213, col: 1, offset: 7313},
 			expr: &choiceExpr{
-				pos: position{line: 123, col: 14, offset: 3982},
+				pos: position{line: 213, col: 14, offset: 7326},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 123, col: 14, offset: 3982},
+						pos:  position{line: 213, col: 14, offset: 7326},
 						name: "PrimitiveType",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 123, col: 30, offset: 3998},
+						pos:  position{line: 213, col: 30, offset: 7342},
 						name: "UserDefinedType",
 					},
 				},
@@ -328,35 +679,35 @@ var g = &grammar{
 		},
 		{
 			name: "PrimitiveType",
-			pos:  position{line: 125, col: 1, offset: 4015},
+			pos:  position{line: 215, col: 1, offset: 7359},
 			expr: &choiceExpr{
-				pos: position{line: 125, col: 18, offset: 4032},
+				pos: position{line: 215, col: 18, offset: 7376},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 125, col: 18, offset: 4032},
+						pos: position{line: 215, col: 18, offset: 7376},
 						run: (*parser).callonPrimitiveType2,
 						expr: &litMatcher{
-							pos:        position{line: 125, col: 18, offset: 4032},
+							pos:        position{line: 215, col: 18, offset: 7376},
 							val:        "string",
 							ignoreCase: false,
 							want:       "\"string\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 126, col: 17, offset: 4084},
+						pos: position{line: 216, col: 17, offset: 7428},
 						run: (*parser).callonPrimitiveType4,
 						expr: &litMatcher{
-							pos:        position{line: 126, col: 17, offset: 4084},
+							pos:        position{line: 216, col: 17, offset: 7428},
 							val:        "number",
 							ignoreCase: false,
 							want:       "\"number\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 127, col: 17, offset: 4136},
+						pos: position{line: 217, col: 17, offset: 7480},
 						run: (*parser).callonPrimitiveType6,
 						expr: &litMatcher{
-							pos:        position{line: 127, col: 17, offset: 4136},
+							pos:        position{line: 217, col: 17, offset: 7480},
 							val:        "bool",
 							ignoreCase: false,
 							want:       "\"bool\"",
@@ -367,26 +718,26 @@ var g = &grammar{
 		},
 		{
 			name: "UserDefinedType",
-			pos:  position{line: 129, col: 1, offset: 4169},
+			pos:  position{line: 219, col: 1, offset: 7513},
 			expr: &actionExpr{
-				pos: position{line: 129, col: 20, offset: 4188},
+				pos: position{line: 219, col: 20, offset: 7532},
 				run: (*parser).callonUserDefinedType1,
 				expr: &seqExpr{
-					pos: position{line: 129, col: 20, offset: 4188},
+					pos: position{line: 219, col: 20, offset: 7532},
 					exprs: []any{
 						&notExpr{
-							pos: position{line: 129, col: 20, offset: 4188},
+							pos: position{line: 219, col: 20, offset: 7532},
 							expr: &ruleRefExpr{
-								pos:  position{line: 129, col: 21, offset: 4189},
+								pos:  position{line: 219, col: 21, offset: 7533},
 								name: "ReservedWord",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 129, col: 34, offset: 4202},
+							pos:   position{line: 219, col: 34, offset: 7546},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 129, col: 39, offset: 4207},
-								name: "IdentName",
+								pos:  position{line: 219, col: 39, offset: 7551},
+								name: "QualifiedName",
 							},
 						},
 					},
@@ -395,62 +746,62 @@ var g = &grammar{
 		},
 		{
 			name: "ActionDefinition",
-			pos:  position{line: 133, col: 1, offset: 4243},
+			pos:  position{line: 223, col: 1, offset: 7591},
 			expr: &actionExpr{
-				pos: position{line: 133, col: 21, offset: 4263},
+				pos: position{line: 223, col: 21, offset: 7611},
 				run: (*parser).callonActionDefinition1,
 				expr: &seqExpr{
-					pos: position{line: 133, col: 21, offset: 4263},
+					pos: position{line: 223, col: 21, offset: 7611},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 133, col: 21, offset: 4263},
+							pos:        position{line: 223, col: 21, offset: 7611},
 							val:        "action",
 							ignoreCase: false,
 							want:       "\"action\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 133, col: 30, offset: 4272},
+							pos:  position{line: 223, col: 30, offset: 7620},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 133, col: 32, offset: 4274},
+							pos:   position{line: 223, col: 32, offset: 7622},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 133, col: 37, offset: 4279},
+								pos:  position{line: 223, col: 37, offset: 7627},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 133, col: 47, offset: 4289},
+							pos:  position{line: 223, col: 47, offset: 7637},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 133, col: 49, offset: 4291},
+							pos:        position{line: 223, col: 49, offset: 7639},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 133, col: 53, offset: 4295},
+							pos:  position{line: 223, col: 53, offset: 7643},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 133, col: 55, offset: 4297},
+							pos:   position{line: 223, col: 55, offset: 7645},
 							label: "params",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 133, col: 62, offset: 4304},
+								pos: position{line: 223, col: 62, offset: 7652},
 								expr: &ruleRefExpr{
-									pos:  position{line: 133, col: 62, offset: 4304},
+									pos:  position{line: 223, col: 62, offset: 7652},
 									name: "ParameterList",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 133, col: 77, offset: 4319},
+							pos:  position{line: 223, col: 77, offset: 7667},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 133, col: 79, offset: 4321},
+							pos:        position{line: 223, col: 79, offset: 7669},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -461,62 +812,62 @@ var g = &grammar{
 		},
 		{
 			name: "XupleSpaceDeclaration",
-			pos:  position{line: 144, col: 1, offset: 4526},
+			pos:  position{line: 235, col: 1, offset: 7902},
 			expr: &actionExpr{
-				pos: position{line: 144, col: 26, offset: 4551},
+				pos: position{line: 235, col: 26, offset: 7927},
 				run: (*parser).callonXupleSpaceDeclaration1,
 				expr: &seqExpr{
-					pos: position{line: 144, col: 26, offset: 4551},
+					pos: position{line: 235, col: 26, offset: 7927},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 144, col: 26, offset: 4551},
+							pos:        position{line: 235, col: 26, offset: 7927},
 							val:        "xuple-space",
 							ignoreCase: false,
 							want:       "\"xuple-space\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 144, col: 40, offset: 4565},
+							pos:  position{line: 235, col: 40, offset: 7941},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 144, col: 42, offset: 4567},
+							pos:   position{line: 235, col: 42, offset: 7943},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 144, col: 47, offset: 4572},
+								pos:  position{line: 235, col: 47, offset: 7948},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 144, col: 57, offset: 4582},
+							pos:  position{line: 235, col: 57, offset: 7958},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 144, col: 59, offset: 4584},
+							pos:        position{line: 235, col: 59, offset: 7960},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 144, col: 63, offset: 4588},
+							pos:  position{line: 235, col: 63, offset: 7964},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 144, col: 65, offset: 4590},
+							pos:   position{line: 235, col: 65, offset: 7966},
 							label: "props",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 144, col: 71, offset: 4596},
+								pos: position{line: 235, col: 71, offset: 7972},
 								expr: &ruleRefExpr{
-									pos:  position{line: 144, col: 71, offset: 4596},
+									pos:  position{line: 235, col: 71, offset: 7972},
 									name: "XupleSpaceProperties",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 144, col: 93, offset: 4618},
+							pos:  position{line: 235, col: 93, offset: 7994},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 144, col: 95, offset: 4620},
+							pos:        position{line: 235, col: 95, offset: 7996},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -527,35 +878,35 @@ var g = &grammar{
 		},
 		{
 			name: "XupleSpaceProperties",
-			pos:  position{line: 187, col: 1, offset: 5852},
+			pos:  position{line: 279, col: 1, offset: 9256},
 			expr: &actionExpr{
-				pos: position{line: 187, col: 25, offset: 5876},
+				pos: position{line: 279, col: 25, offset: 9280},
 				run: (*parser).callonXupleSpaceProperties1,
 				expr: &seqExpr{
-					pos: position{line: 187, col: 25, offset: 5876},
+					pos: position{line: 279, col: 25, offset: 9280},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 187, col: 25, offset: 5876},
+							pos:   position{line: 279, col: 25, offset: 9280},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 187, col: 31, offset: 5882},
+								pos:  position{line: 279, col: 31, offset: 9286},
 								name: "XupleSpaceProperty",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 187, col: 50, offset: 5901},
+							pos:   position{line: 279, col: 50, offset: 9305},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 187, col: 55, offset: 5906},
+								pos: position{line: 279, col: 55, offset: 9310},
 								expr: &seqExpr{
-									pos: position{line: 187, col: 56, offset: 5907},
+									pos: position{line: 279, col: 56, offset: 9311},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 187, col: 56, offset: 5907},
+											pos:  position{line: 279, col: 56, offset: 9311},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 187, col: 58, offset: 5909},
+											pos:  position{line: 279, col: 58, offset: 9313},
 											name: "XupleSpaceProperty",
 										},
 									},
@@ -568,24 +919,24 @@ var g = &grammar{
 		},
 		{
 			name: "XupleSpaceProperty",
-			pos:  position{line: 210, col: 1, offset: 6463},
+			pos:  position{line: 302, col: 1, offset: 9867},
 			expr: &choiceExpr{
-				pos: position{line: 210, col: 23, offset: 6485},
+				pos: position{line: 302, col: 23, offset: 9889},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 210, col: 23, offset: 6485},
+						pos:  position{line: 302, col: 23, offset: 9889},
 						name: "SelectionProperty",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 210, col: 43, offset: 6505},
+						pos:  position{line: 302, col: 43, offset: 9909},
 						name: "ConsumptionProperty",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 210, col: 65, offset: 6527},
+						pos:  position{line: 302, col: 65, offset: 9931},
 						name: "RetentionProperty",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 210, col: 85, offset: 6547},
+						pos:  position{line: 302, col: 85, offset: 9951},
 						name: "MaxSizeProperty",
 					},
 				},
@@ -593,38 +944,38 @@ var g = &grammar{
 		},
 		{
 			name: "SelectionProperty",
-			pos:  position{line: 212, col: 1, offset: 6564},
+			pos:  position{line: 304, col: 1, offset: 9968},
 			expr: &actionExpr{
-				pos: position{line: 212, col: 22, offset: 6585},
+				pos: position{line: 304, col: 22, offset: 9989},
 				run: (*parser).callonSelectionProperty1,
 				expr: &seqExpr{
-					pos: position{line: 212, col: 22, offset: 6585},
+					pos: position{line: 304, col: 22, offset: 9989},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 212, col: 22, offset: 6585},
+							pos:        position{line: 304, col: 22, offset: 9989},
 							val:        "selection",
 							ignoreCase: false,
 							want:       "\"selection\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 212, col: 34, offset: 6597},
+							pos:  position{line: 304, col: 34, offset: 10001},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 212, col: 36, offset: 6599},
+							pos:        position{line: 304, col: 36, offset: 10003},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 212, col: 40, offset: 6603},
+							pos:  position{line: 304, col: 40, offset: 10007},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 212, col: 42, offset: 6605},
+							pos:   position{line: 304, col: 42, offset: 10009},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 212, col: 48, offset: 6611},
+								pos:  position{line: 304, col: 48, offset: 10015},
 								name: "SelectionValue",
 							},
 						},
@@ -634,35 +985,35 @@ var g = &grammar{
 		},
 		{
 			name: "SelectionValue",
-			pos:  position{line: 218, col: 1, offset: 6705},
+			pos:  position{line: 310, col: 1, offset: 10109},
 			expr: &choiceExpr{
-				pos: position{line: 218, col: 19, offset: 6723},
+				pos: position{line: 310, col: 19, offset: 10127},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 218, col: 19, offset: 6723},
+						pos: position{line: 310, col: 19, offset: 10127},
 						run: (*parser).callonSelectionValue2,
 						expr: &litMatcher{
-							pos:        position{line: 218, col: 19, offset: 6723},
+							pos:        position{line: 310, col: 19, offset: 10127},
 							val:        "random",
 							ignoreCase: false,
 							want:       "\"random\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 219, col: 19, offset: 6777},
+						pos: position{line: 311, col: 19, offset: 10181},
 						run: (*parser).callonSelectionValue4,
 						expr: &litMatcher{
-							pos:        position{line: 219, col: 19, offset: 6777},
+							pos:        position{line: 311, col: 19, offset: 10181},
 							val:        "fifo",
 							ignoreCase: false,
 							want:       "\"fifo\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 220, col: 19, offset: 6829},
+						pos: position{line: 312, col: 19, offset: 10233},
 						run: (*parser).callonSelectionValue6,
 						expr: &litMatcher{
-							pos:        position{line: 220, col: 19, offset: 6829},
+							pos:        position{line: 312, col: 19, offset: 10233},
 							val:        "lifo",
 							ignoreCase: false,
 							want:       "\"lifo\"",
@@ -673,38 +1024,38 @@ var g = &grammar{
 		},
 		{
 			name: "ConsumptionProperty",
-			pos:  position{line: 222, col: 1, offset: 6862},
+			pos:  position{line: 314, col: 1, offset: 10266},
 			expr: &actionExpr{
-				pos: position{line: 222, col: 24, offset: 6885},
+				pos: position{line: 314, col: 24, offset: 10289},
 				run: (*parser).callonConsumptionProperty1,
 				expr: &seqExpr{
-					pos: position{line: 222, col: 24, offset: 6885},
+					pos: position{line: 314, col: 24, offset: 10289},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 222, col: 24, offset: 6885},
+							pos:        position{line: 314, col: 24, offset: 10289},
 							val:        "consumption",
 							ignoreCase: false,
 							want:       "\"consumption\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 38, offset: 6899},
+							pos:  position{line: 314, col: 38, offset: 10303},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 222, col: 40, offset: 6901},
+							pos:        position{line: 314, col: 40, offset: 10305},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 44, offset: 6905},
+							pos:  position{line: 314, col: 44, offset: 10309},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 46, offset: 6907},
+							pos:   position{line: 314, col: 46, offset: 10311},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 52, offset: 6913},
+								pos:  position{line: 314, col: 52, offset: 10317},
 								name: "ConsumptionValue",
 							},
 						},
@@ -714,70 +1065,70 @@ var g = &grammar{
 		},
 		{
 			name: "ConsumptionValue",
-			pos:  position{line: 228, col: 1, offset: 7011},
+			pos:  position{line: 320, col: 1, offset: 10415},
 			expr: &choiceExpr{
-				pos: position{line: 228, col: 21, offset: 7031},
+				pos: position{line: 320, col: 21, offset: 10435},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 228, col: 21, offset: 7031},
+						pos: position{line: 320, col: 21, offset: 10435},
 						run: (*parser).callonConsumptionValue2,
 						expr: &litMatcher{
-							pos:        position{line: 228, col: 21, offset: 7031},
+							pos:        position{line: 320, col: 21, offset: 10435},
 							val:        "once",
 							ignoreCase: false,
 							want:       "\"once\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 233, col: 5, offset: 7134},
+						pos: position{line: 325, col: 5, offset: 10538},
 						run: (*parser).callonConsumptionValue4,
 						expr: &litMatcher{
-							pos:        position{line: 233, col: 5, offset: 7134},
+							pos:        position{line: 325, col: 5, offset: 10538},
 							val:        "per-agent",
 							ignoreCase: false,
 							want:       "\"per-agent\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 238, col: 5, offset: 7247},
+						pos: position{line: 330, col: 5, offset: 10651},
 						run: (*parser).callonConsumptionValue6,
 						expr: &seqExpr{
-							pos: position{line: 238, col: 5, offset: 7247},
+							pos: position{line: 330, col: 5, offset: 10651},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 238, col: 5, offset: 7247},
+									pos:        position{line: 330, col: 5, offset: 10651},
 									val:        "limited",
 									ignoreCase: false,
 									want:       "\"limited\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 238, col: 15, offset: 7257},
+									pos:  position{line: 330, col: 15, offset: 10661},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 238, col: 17, offset: 7259},
+									pos:        position{line: 330, col: 17, offset: 10663},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 238, col: 21, offset: 7263},
+									pos:  position{line: 330, col: 21, offset: 10667},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 238, col: 23, offset: 7265},
+									pos:   position{line: 330, col: 23, offset: 10669},
 									label: "limit",
 									expr: &ruleRefExpr{
-										pos:  position{line: 238, col: 29, offset: 7271},
+										pos:  position{line: 330, col: 29, offset: 10675},
 										name: "Integer",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 238, col: 37, offset: 7279},
+									pos:  position{line: 330, col: 37, offset: 10683},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 238, col: 39, offset: 7281},
+									pos:        position{line: 330, col: 39, offset: 10685},
 									val:        ")",
 									ignoreCase: false,
 									want:       "\")\"",
@@ -790,38 +1141,38 @@ var g = &grammar{
 		},
 		{
 			name: "RetentionProperty",
-			pos:  position{line: 249, col: 1, offset: 7543},
+			pos:  position{line: 341, col: 1, offset: 10947},
 			expr: &actionExpr{
-				pos: position{line: 249, col: 22, offset: 7564},
+				pos: position{line: 341, col: 22, offset: 10968},
 				run: (*parser).callonRetentionProperty1,
 				expr: &seqExpr{
-					pos: position{line: 249, col: 22, offset: 7564},
+					pos: position{line: 341, col: 22, offset: 10968},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 249, col: 22, offset: 7564},
+							pos:        position{line: 341, col: 22, offset: 10968},
 							val:        "retention",
 							ignoreCase: false,
 							want:       "\"retention\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 249, col: 34, offset: 7576},
+							pos:  position{line: 341, col: 34, offset: 10980},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 249, col: 36, offset: 7578},
+							pos:        position{line: 341, col: 36, offset: 10982},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 249, col: 40, offset: 7582},
+							pos:  position{line: 341, col: 40, offset: 10986},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 249, col: 42, offset: 7584},
+							pos:   position{line: 341, col: 42, offset: 10988},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 249, col: 48, offset: 7590},
+								pos:  position{line: 341, col: 48, offset: 10994},
 								name: "RetentionValue",
 							},
 						},
@@ -831,60 +1182,60 @@ var g = &grammar{
 		},
 		{
 			name: "RetentionValue",
-			pos:  position{line: 255, col: 1, offset: 7684},
+			pos:  position{line: 347, col: 1, offset: 11088},
 			expr: &choiceExpr{
-				pos: position{line: 255, col: 19, offset: 7702},
+				pos: position{line: 347, col: 19, offset: 11106},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 255, col: 19, offset: 7702},
+						pos: position{line: 347, col: 19, offset: 11106},
 						run: (*parser).callonRetentionValue2,
 						expr: &litMatcher{
-							pos:        position{line: 255, col: 19, offset: 7702},
+							pos:        position{line: 347, col: 19, offset: 11106},
 							val:        "unlimited",
 							ignoreCase: false,
 							want:       "\"unlimited\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 260, col: 5, offset: 7818},
+						pos: position{line: 352, col: 5, offset: 11222},
 						run: (*parser).callonRetentionValue4,
 						expr: &seqExpr{
-							pos: position{line: 260, col: 5, offset: 7818},
+							pos: position{line: 352, col: 5, offset: 11222},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 260, col: 5, offset: 7818},
+									pos:        position{line: 352, col: 5, offset: 11222},
 									val:        "duration",
 									ignoreCase: false,
 									want:       "\"duration\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 260, col: 16, offset: 7829},
+									pos:  position{line: 352, col: 16, offset: 11233},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 260, col: 18, offset: 7831},
+									pos:        position{line: 352, col: 18, offset: 11235},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 260, col: 22, offset: 7835},
+									pos:  position{line: 352, col: 22, offset: 11239},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 260, col: 24, offset: 7837},
+									pos:   position{line: 352, col: 24, offset: 11241},
 									label: "dur",
 									expr: &ruleRefExpr{
-										pos:  position{line: 260, col: 28, offset: 7841},
+										pos:  position{line: 352, col: 28, offset: 11245},
 										name: "Duration",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 260, col: 37, offset: 7850},
+									pos:  position{line: 352, col: 37, offset: 11254},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 260, col: 39, offset: 7852},
+									pos:        position{line: 352, col: 39, offset: 11256},
 									val:        ")",
 									ignoreCase: false,
 									want:       "\")\"",
@@ -897,26 +1248,26 @@ var g = &grammar{
 		},
 		{
 			name: "Duration",
-			pos:  position{line: 267, col: 1, offset: 7960},
+			pos:  position{line: 359, col: 1, offset: 11364},
 			expr: &actionExpr{
-				pos: position{line: 267, col: 13, offset: 7972},
+				pos: position{line: 359, col: 13, offset: 11376},
 				run: (*parser).callonDuration1,
 				expr: &seqExpr{
-					pos: position{line: 267, col: 13, offset: 7972},
+					pos: position{line: 359, col: 13, offset: 11376},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 267, col: 13, offset: 7972},
+							pos:   position{line: 359, col: 13, offset: 11376},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 267, col: 19, offset: 7978},
+								pos:  position{line: 359, col: 19, offset: 11382},
 								name: "Integer",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 267, col: 27, offset: 7986},
+							pos:   position{line: 359, col: 27, offset: 11390},
 							label: "unit",
 							expr: &ruleRefExpr{
-								pos:  position{line: 267, col: 32, offset: 7991},
+								pos:  position{line: 359, col: 32, offset: 11395},
 								name: "TimeUnit",
 							},
 						},
@@ -926,45 +1277,45 @@ var g = &grammar{
 		},
 		{
 			name: "TimeUnit",
-			pos:  position{line: 298, col: 1, offset: 8640},
+			pos:  position{line: 390, col: 1, offset: 12044},
 			expr: &choiceExpr{
-				pos: position{line: 298, col: 13, offset: 8652},
+				pos: position{line: 390, col: 13, offset: 12056},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 298, col: 13, offset: 8652},
+						pos: position{line: 390, col: 13, offset: 12056},
 						run: (*parser).callonTimeUnit2,
 						expr: &litMatcher{
-							pos:        position{line: 298, col: 13, offset: 8652},
+							pos:        position{line: 390, col: 13, offset: 12056},
 							val:        "s",
 							ignoreCase: false,
 							want:       "\"s\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 299, col: 13, offset: 8690},
+						pos: position{line: 391, col: 13, offset: 12094},
 						run: (*parser).callonTimeUnit4,
 						expr: &litMatcher{
-							pos:        position{line: 299, col: 13, offset: 8690},
+							pos:        position{line: 391, col: 13, offset: 12094},
 							val:        "m",
 							ignoreCase: false,
 							want:       "\"m\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 300, col: 13, offset: 8728},
+						pos: position{line: 392, col: 13, offset: 12132},
 						run: (*parser).callonTimeUnit6,
 						expr: &litMatcher{
-							pos:        position{line: 300, col: 13, offset: 8728},
+							pos:        position{line: 392, col: 13, offset: 12132},
 							val:        "h",
 							ignoreCase: false,
 							want:       "\"h\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 301, col: 13, offset: 8766},
+						pos: position{line: 393, col: 13, offset: 12170},
 						run: (*parser).callonTimeUnit8,
 						expr: &litMatcher{
-							pos:        position{line: 301, col: 13, offset: 8766},
+							pos:        position{line: 393, col: 13, offset: 12170},
 							val:        "d",
 							ignoreCase: false,
 							want:       "\"d\"",
@@ -975,38 +1326,38 @@ var g = &grammar{
 		},
 		{
 			name: "MaxSizeProperty",
-			pos:  position{line: 303, col: 1, offset: 8791},
+			pos:  position{line: 395, col: 1, offset: 12195},
 			expr: &actionExpr{
-				pos: position{line: 303, col: 20, offset: 8810},
+				pos: position{line: 395, col: 20, offset: 12214},
 				run: (*parser).callonMaxSizeProperty1,
 				expr: &seqExpr{
-					pos: position{line: 303, col: 20, offset: 8810},
+					pos: position{line: 395, col: 20, offset: 12214},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 303, col: 20, offset: 8810},
+							pos:        position{line: 395, col: 20, offset: 12214},
 							val:        "max-size",
 							ignoreCase: false,
 							want:       "\"max-size\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 303, col: 31, offset: 8821},
+							pos:  position{line: 395, col: 31, offset: 12225},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 303, col: 33, offset: 8823},
+							pos:        position{line: 395, col: 33, offset: 12227},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 303, col: 37, offset: 8827},
+							pos:  position{line: 395, col: 37, offset: 12231},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 303, col: 39, offset: 8829},
+							pos:   position{line: 395, col: 39, offset: 12233},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 303, col: 45, offset: 8835},
+								pos:  position{line: 395, col: 45, offset: 12239},
 								name: "Integer",
 							},
 						},
@@ -1016,45 +1367,45 @@ var g = &grammar{
 		},
 		{
 			name: "ParameterList",
-			pos:  position{line: 314, col: 1, offset: 9034},
+			pos:  position{line: 406, col: 1, offset: 12438},
 			expr: &actionExpr{
-				pos: position{line: 314, col: 18, offset: 9051},
+				pos: position{line: 406, col: 18, offset: 12455},
 				run: (*parser).callonParameterList1,
 				expr: &seqExpr{
-					pos: position{line: 314, col: 18, offset: 9051},
+					pos: position{line: 406, col: 18, offset: 12455},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 314, col: 18, offset: 9051},
+							pos:   position{line: 406, col: 18, offset: 12455},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 314, col: 24, offset: 9057},
+								pos:  position{line: 406, col: 24, offset: 12461},
 								name: "Parameter",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 314, col: 34, offset: 9067},
+							pos:   position{line: 406, col: 34, offset: 12471},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 314, col: 39, offset: 9072},
+								pos: position{line: 406, col: 39, offset: 12476},
 								expr: &seqExpr{
-									pos: position{line: 314, col: 40, offset: 9073},
+									pos: position{line: 406, col: 40, offset: 12477},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 314, col: 40, offset: 9073},
+											pos:  position{line: 406, col: 40, offset: 12477},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 314, col: 42, offset: 9075},
+											pos:        position{line: 406, col: 42, offset: 12479},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 314, col: 46, offset: 9079},
+											pos:  position{line: 406, col: 46, offset: 12483},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 314, col: 48, offset: 9081},
+											pos:  position{line: 406, col: 48, offset: 12485},
 											name: "Parameter",
 										},
 									},
@@ -1067,50 +1418,50 @@ var g = &grammar{
 		},
 		{
 			name: "Parameter",
-			pos:  position{line: 324, col: 1, offset: 9322},
+			pos:  position{line: 416, col: 1, offset: 12726},
 			expr: &actionExpr{
-				pos: position{line: 324, col: 14, offset: 9335},
+				pos: position{line: 416, col: 14, offset: 12739},
 				run: (*parser).callonParameter1,
 				expr: &seqExpr{
-					pos: position{line: 324, col: 14, offset: 9335},
+					pos: position{line: 416, col: 14, offset: 12739},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 324, col: 14, offset: 9335},
+							pos:   position{line: 416, col: 14, offset: 12739},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 324, col: 19, offset: 9340},
+								pos:  position{line: 416, col: 19, offset: 12744},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 324, col: 29, offset: 9350},
+							pos:  position{line: 416, col: 29, offset: 12754},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 324, col: 31, offset: 9352},
+							pos:        position{line: 416, col: 31, offset: 12756},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 324, col: 35, offset: 9356},
+							pos:  position{line: 416, col: 35, offset: 12760},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 324, col: 37, offset: 9358},
+							pos:   position{line: 416, col: 37, offset: 12762},
 							label: "paramType",
 							expr: &ruleRefExpr{
-								pos:  position{line: 324, col: 47, offset: 9368},
+								pos:  position{line: 416, col: 47, offset: 12772},
 								name: "ParameterType",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 324, col: 61, offset: 9382},
+							pos:   position{line: 416, col: 61, offset: 12786},
 							label: "optional",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 324, col: 70, offset: 9391},
+								pos: position{line: 416, col: 70, offset: 12795},
 								expr: &litMatcher{
-									pos:        position{line: 324, col: 70, offset: 9391},
+									pos:        position{line: 416, col: 70, offset: 12795},
 									val:        "?",
 									ignoreCase: false,
 									want:       "\"?\"",
@@ -1118,33 +1469,33 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 324, col: 75, offset: 9396},
+							pos:  position{line: 416, col: 75, offset: 12800},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 324, col: 77, offset: 9398},
+							pos:   position{line: 416, col: 77, offset: 12802},
 							label: "defaultValue",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 324, col: 90, offset: 9411},
+								pos: position{line: 416, col: 90, offset: 12815},
 								expr: &seqExpr{
-									pos: position{line: 324, col: 91, offset: 9412},
+									pos: position{line: 416, col: 91, offset: 12816},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 324, col: 91, offset: 9412},
+											pos:  position{line: 416, col: 91, offset: 12816},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 324, col: 93, offset: 9414},
+											pos:        position{line: 416, col: 93, offset: 12818},
 											val:        "=",
 											ignoreCase: false,
 											want:       "\"=\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 324, col: 97, offset: 9418},
+											pos:  position{line: 416, col: 97, offset: 12822},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 324, col: 99, offset: 9420},
+											pos:  position{line: 416, col: 99, offset: 12824},
 											name: "ParameterDefaultValue",
 										},
 									},
@@ -1157,32 +1508,32 @@ var g = &grammar{
 		},
 		{
 			name: "ParameterType",
-			pos:  position{line: 336, col: 1, offset: 9702},
+			pos:  position{line: 429, col: 1, offset: 13134},
 			expr: &actionExpr{
-				pos: position{line: 336, col: 18, offset: 9719},
+				pos: position{line: 429, col: 18, offset: 13151},
 				run: (*parser).callonParameterType1,
 				expr: &ruleRefExpr{
-					pos:  position{line: 336, col: 18, offset: 9719},
-					name: "IdentName",
+					pos:  position{line: 429, col: 18, offset: 13151},
+					name: "QualifiedName",
 				},
 			},
 		},
 		{
 			name: "ParameterDefaultValue",
-			pos:  position{line: 338, col: 1, offset: 9761},
+			pos:  position{line: 431, col: 1, offset: 13197},
 			expr: &choiceExpr{
-				pos: position{line: 338, col: 26, offset: 9786},
+				pos: position{line: 431, col: 26, offset: 13222},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 338, col: 26, offset: 9786},
+						pos:  position{line: 431, col: 26, offset: 13222},
 						name: "Number",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 338, col: 35, offset: 9795},
+						pos:  position{line: 431, col: 35, offset: 13231},
 						name: "StringLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 338, col: 51, offset: 9811},
+						pos:  position{line: 431, col: 51, offset: 13247},
 						name: "BooleanLiteral",
 					},
 				},
@@ -1190,97 +1541,97 @@ var g = &grammar{
 		},
 		{
 			name: "Expression",
-			pos:  position{line: 340, col: 1, offset: 9827},
+			pos:  position{line: 433, col: 1, offset: 13263},
 			expr: &choiceExpr{
-				pos: position{line: 340, col: 15, offset: 9841},
+				pos: position{line: 433, col: 15, offset: 13277},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 340, col: 15, offset: 9841},
+						pos: position{line: 433, col: 15, offset: 13277},
 						run: (*parser).callonExpression2,
 						expr: &seqExpr{
-							pos: position{line: 340, col: 15, offset: 9841},
+							pos: position{line: 433, col: 15, offset: 13277},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 340, col: 15, offset: 9841},
+									pos:        position{line: 433, col: 15, offset: 13277},
 									val:        "rule",
 									ignoreCase: false,
 									want:       "\"rule\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 22, offset: 9848},
+									pos:  position{line: 433, col: 22, offset: 13284},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 340, col: 24, offset: 9850},
+									pos:   position{line: 433, col: 24, offset: 13286},
 									label: "ruleId",
 									expr: &ruleRefExpr{
-										pos:  position{line: 340, col: 31, offset: 9857},
+										pos:  position{line: 433, col: 31, offset: 13293},
 										name: "IdentName",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 41, offset: 9867},
+									pos:  position{line: 433, col: 41, offset: 13303},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 340, col: 43, offset: 9869},
+									pos:        position{line: 433, col: 43, offset: 13305},
 									val:        ":",
 									ignoreCase: false,
 									want:       "\":\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 47, offset: 9873},
+									pos:  position{line: 433, col: 47, offset: 13309},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 340, col: 49, offset: 9875},
+									pos:   position{line: 433, col: 49, offset: 13311},
 									label: "patterns",
 									expr: &ruleRefExpr{
-										pos:  position{line: 340, col: 58, offset: 9884},
+										pos:  position{line: 433, col: 58, offset: 13320},
 										name: "PatternBlocks",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 72, offset: 9898},
+									pos:  position{line: 433, col: 72, offset: 13334},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 340, col: 74, offset: 9900},
+									pos:        position{line: 433, col: 74, offset: 13336},
 									val:        "/",
 									ignoreCase: false,
 									want:       "\"/\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 78, offset: 9904},
+									pos:  position{line: 433, col: 78, offset: 13340},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 340, col: 80, offset: 9906},
+									pos:   position{line: 433, col: 80, offset: 13342},
 									label: "constraints",
 									expr: &ruleRefExpr{
-										pos:  position{line: 340, col: 92, offset: 9918},
+										pos:  position{line: 433, col: 92, offset: 13354},
 										name: "Constraints",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 104, offset: 9930},
+									pos:  position{line: 433, col: 104, offset: 13366},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 340, col: 106, offset: 9932},
+									pos:        position{line: 433, col: 106, offset: 13368},
 									val:        "==>",
 									ignoreCase: false,
 									want:       "\"==>\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 340, col: 112, offset: 9938},
+									pos:  position{line: 433, col: 112, offset: 13374},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 340, col: 114, offset: 9940},
+									pos:   position{line: 433, col: 114, offset: 13376},
 									label: "action",
 									expr: &ruleRefExpr{
-										pos:  position{line: 340, col: 121, offset: 9947},
+										pos:  position{line: 433, col: 121, offset: 13383},
 										name: "Action",
 									},
 								},
@@ -1288,80 +1639,80 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 361, col: 5, offset: 10594},
+						pos: position{line: 456, col: 5, offset: 14090},
 						run: (*parser).callonExpression23,
 						expr: &seqExpr{
-							pos: position{line: 361, col: 5, offset: 10594},
+							pos: position{line: 456, col: 5, offset: 14090},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 361, col: 5, offset: 10594},
+									pos:        position{line: 456, col: 5, offset: 14090},
 									val:        "rule",
 									ignoreCase: false,
 									want:       "\"rule\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 361, col: 12, offset: 10601},
+									pos:  position{line: 456, col: 12, offset: 14097},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 361, col: 14, offset: 10603},
+									pos:   position{line: 456, col: 14, offset: 14099},
 									label: "ruleId",
 									expr: &ruleRefExpr{
-										pos:  position{line: 361, col: 21, offset: 10610},
+										pos:  position{line: 456, col: 21, offset: 14106},
 										name: "IdentName",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 361, col: 31, offset: 10620},
+									pos:  position{line: 456, col: 31, offset: 14116},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 361, col: 33, offset: 10622},
+									pos:        position{line: 456, col: 33, offset: 14118},
 									val:        ":",
 									ignoreCase: false,
 									want:       "\":\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 361, col: 37, offset: 10626},
+									pos:  position{line: 456, col: 37, offset: 14122},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 361, col: 39, offset: 10628},
+									pos:   position{line: 456, col: 39, offset: 14124},
 									label: "patterns",
 									expr: &ruleRefExpr{
-										pos:  position{line: 361, col: 48, offset: 10637},
+										pos:  position{line: 456, col: 48, offset: 14133},
 										name: "PatternBlocks",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 361, col: 62, offset: 10651},
+									pos:  position{line: 456, col: 62, offset: 14147},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 361, col: 64, offset: 10653},
+									pos:        position{line: 456, col: 64, offset: 14149},
 									val:        "/",
 									ignoreCase: false,
 									want:       "\"/\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 361, col: 68, offset: 10657},
+									pos:  position{line: 456, col: 68, offset: 14153},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 361, col: 70, offset: 10659},
+									pos:        position{line: 456, col: 70, offset: 14155},
 									val:        "==>",
 									ignoreCase: false,
 									want:       "\"==>\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 361, col: 76, offset: 10665},
+									pos:  position{line: 456, col: 76, offset: 14161},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 361, col: 78, offset: 10667},
+									pos:   position{line: 456, col: 78, offset: 14163},
 									label: "action",
 									expr: &ruleRefExpr{
-										pos:  position{line: 361, col: 85, offset: 10674},
+										pos:  position{line: 456, col: 85, offset: 14170},
 										name: "Action",
 									},
 								},
@@ -1372,89 +1723,211 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "PatternBlocks",
-			pos:  position{line: 385, col: 1, offset: 11355},
-			expr: &actionExpr{
-				pos: position{line: 385, col: 18, offset: 11372},
-				run: (*parser).callonPatternBlocks1,
-				expr: &seqExpr{
-					pos: position{line: 385, col: 18, offset: 11372},
-					exprs: []any{
-						&labeledExpr{
-							pos:   position{line: 385, col: 18, offset: 11372},
-							label: "first",
-							expr: &ruleRefExpr{
-								pos:  position{line: 385, col: 24, offset: 11378},
-								name: "Set",
-							},
-						},
-						&labeledExpr{
-							pos:   position{line: 385, col: 28, offset: 11382},
-							label: "rest",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 385, col: 33, offset: 11387},
-								expr: &seqExpr{
-									pos: position{line: 385, col: 34, offset: 11388},
-									exprs: []any{
-										&ruleRefExpr{
-											pos:  position{line: 385, col: 34, offset: 11388},
-											name: "_",
-										},
-										&litMatcher{
-											pos:        position{line: 385, col: 36, offset: 11390},
-											val:        "/",
-											ignoreCase: false,
-											want:       "\"/\"",
-										},
-										&ruleRefExpr{
-											pos:  position{line: 385, col: 40, offset: 11394},
-											name: "_",
-										},
-										&ruleRefExpr{
-											pos:  position{line: 385, col: 42, offset: 11396},
-											name: "Set",
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "Set",
-			pos:  position{line: 395, col: 1, offset: 11615},
+			name: "QueryDefinition",
+			pos:  position{line: 484, col: 1, offset: 15108},
 			expr: &actionExpr{
-				pos: position{line: 395, col: 8, offset: 11622},
-				run: (*parser).callonSet1,
+				pos: position{line: 484, col: 20, offset: 15127},
+				run: (*parser).callonQueryDefinition1,
 				expr: &seqExpr{
-					pos: position{line: 395, col: 8, offset: 11622},
+					pos: position{line: 484, col: 20, offset: 15127},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 395, col: 8, offset: 11622},
-							val:        "{",
+							pos:        position{line: 484, col: 20, offset: 15127},
+							val:        "query",
 							ignoreCase: false,
-							want:       "\"{\"",
+							want:       "\"query\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 395, col: 12, offset: 11626},
+							pos:  position{line: 484, col: 28, offset: 15135},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 395, col: 14, offset: 11628},
+							pos:   position{line: 484, col: 30, offset: 15137},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 484, col: 35, offset: 15142},
+								name: "IdentName",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 484, col: 45, offset: 15152},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 484, col: 47, offset: 15154},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 484, col: 51, offset: 15158},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 484, col: 53, offset: 15160},
+							label: "params",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 484, col: 60, offset: 15167},
+								expr: &ruleRefExpr{
+									pos:  position{line: 484, col: 60, offset: 15167},
+									name: "ParameterList",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 484, col: 75, offset: 15182},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 484, col: 77, offset: 15184},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 484, col: 81, offset: 15188},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 484, col: 83, offset: 15190},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 484, col: 87, offset: 15194},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 484, col: 89, offset: 15196},
+							label: "patterns",
+							expr: &ruleRefExpr{
+								pos:  position{line: 484, col: 98, offset: 15205},
+								name: "PatternBlocks",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 484, col: 112, offset: 15219},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 484, col: 114, offset: 15221},
+							label: "constraints",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 484, col: 126, offset: 15233},
+								expr: &seqExpr{
+									pos: position{line: 484, col: 127, offset: 15234},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 484, col: 127, offset: 15234},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 484, col: 129, offset: 15236},
+											val:        "/",
+											ignoreCase: false,
+											want:       "\"/\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 484, col: 133, offset: 15240},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 484, col: 135, offset: 15242},
+											name: "Constraints",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PatternBlocks",
+			pos:  position{line: 510, col: 1, offset: 15875},
+			expr: &actionExpr{
+				pos: position{line: 510, col: 18, offset: 15892},
+				run: (*parser).callonPatternBlocks1,
+				expr: &seqExpr{
+					pos: position{line: 510, col: 18, offset: 15892},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 510, col: 18, offset: 15892},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 510, col: 24, offset: 15898},
+								name: "Set",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 510, col: 28, offset: 15902},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 510, col: 33, offset: 15907},
+								expr: &seqExpr{
+									pos: position{line: 510, col: 34, offset: 15908},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 510, col: 34, offset: 15908},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 510, col: 36, offset: 15910},
+											val:        "/",
+											ignoreCase: false,
+											want:       "\"/\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 510, col: 40, offset: 15914},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 510, col: 42, offset: 15916},
+											name: "Set",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Set",
+			pos:  position{line: 520, col: 1, offset: 16135},
+			expr: &actionExpr{
+				pos: position{line: 520, col: 8, offset: 16142},
+				run: (*parser).callonSet1,
+				expr: &seqExpr{
+					pos: position{line: 520, col: 8, offset: 16142},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 520, col: 8, offset: 16142},
+							val:        "{",
+							ignoreCase: false,
+							want:       "\"{\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 520, col: 12, offset: 16146},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 520, col: 14, offset: 16148},
 							label: "variables",
 							expr: &ruleRefExpr{
-								pos:  position{line: 395, col: 24, offset: 11638},
+								pos:  position{line: 520, col: 24, offset: 16158},
 								name: "TypedVariableList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 395, col: 42, offset: 11656},
+							pos:  position{line: 520, col: 42, offset: 16176},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 395, col: 44, offset: 11658},
+							pos:        position{line: 520, col: 44, offset: 16178},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1465,45 +1938,45 @@ var g = &grammar{
 		},
 		{
 			name: "TypedVariableList",
-			pos:  position{line: 402, col: 1, offset: 11768},
+			pos:  position{line: 527, col: 1, offset: 16288},
 			expr: &actionExpr{
-				pos: position{line: 402, col: 22, offset: 11789},
+				pos: position{line: 527, col: 22, offset: 16309},
 				run: (*parser).callonTypedVariableList1,
 				expr: &seqExpr{
-					pos: position{line: 402, col: 22, offset: 11789},
+					pos: position{line: 527, col: 22, offset: 16309},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 402, col: 22, offset: 11789},
+							pos:   position{line: 527, col: 22, offset: 16309},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 402, col: 28, offset: 11795},
+								pos:  position{line: 527, col: 28, offset: 16315},
 								name: "TypedVariable",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 402, col: 42, offset: 11809},
+							pos:   position{line: 527, col: 42, offset: 16329},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 402, col: 47, offset: 11814},
+								pos: position{line: 527, col: 47, offset: 16334},
 								expr: &seqExpr{
-									pos: position{line: 402, col: 48, offset: 11815},
+									pos: position{line: 527, col: 48, offset: 16335},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 402, col: 48, offset: 11815},
+											pos:  position{line: 527, col: 48, offset: 16335},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 402, col: 50, offset: 11817},
+											pos:        position{line: 527, col: 50, offset: 16337},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 402, col: 54, offset: 11821},
+											pos:  position{line: 527, col: 54, offset: 16341},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 402, col: 56, offset: 11823},
+											pos:  position{line: 527, col: 56, offset: 16343},
 											name: "TypedVariable",
 										},
 									},
@@ -1516,16 +1989,20 @@ var g = &grammar{
 		},
 		{
 			name: "TypedVariable",
-			pos:  position{line: 412, col: 1, offset: 12064},
+			pos:  position{line: 537, col: 1, offset: 16584},
 			expr: &choiceExpr{
-				pos: position{line: 412, col: 18, offset: 12081},
+				pos: position{line: 537, col: 18, offset: 16601},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 412, col: 18, offset: 12081},
+						pos:  position{line: 537, col: 18, offset: 16601},
 						name: "AggregationVariable",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 412, col: 40, offset: 12103},
+						pos:  position{line: 537, col: 40, offset: 16623},
+						name: "CollectVariable",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 537, col: 58, offset: 16641},
 						name: "SimpleTypedVariable",
 					},
 				},
@@ -1533,41 +2010,41 @@ var g = &grammar{
 		},
 		{
 			name: "SimpleTypedVariable",
-			pos:  position{line: 414, col: 1, offset: 12124},
+			pos:  position{line: 539, col: 1, offset: 16662},
 			expr: &actionExpr{
-				pos: position{line: 414, col: 24, offset: 12147},
+				pos: position{line: 539, col: 24, offset: 16685},
 				run: (*parser).callonSimpleTypedVariable1,
 				expr: &seqExpr{
-					pos: position{line: 414, col: 24, offset: 12147},
+					pos: position{line: 539, col: 24, offset: 16685},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 414, col: 24, offset: 12147},
+							pos:   position{line: 539, col: 24, offset: 16685},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 414, col: 29, offset: 12152},
+								pos:  position{line: 539, col: 29, offset: 16690},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 414, col: 39, offset: 12162},
+							pos:  position{line: 539, col: 39, offset: 16700},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 414, col: 41, offset: 12164},
+							pos:        position{line: 539, col: 41, offset: 16702},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 414, col: 45, offset: 12168},
+							pos:  position{line: 539, col: 45, offset: 16706},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 414, col: 47, offset: 12170},
+							pos:   position{line: 539, col: 47, offset: 16708},
 							label: "dataType",
 							expr: &ruleRefExpr{
-								pos:  position{line: 414, col: 56, offset: 12179},
-								name: "IdentName",
+								pos:  position{line: 539, col: 56, offset: 16717},
+								name: "QualifiedName",
 							},
 						},
 					},
@@ -1576,71 +2053,258 @@ var g = &grammar{
 		},
 		{
 			name: "AggregationVariable",
-			pos:  position{line: 422, col: 1, offset: 12325},
+			pos:  position{line: 548, col: 1, offset: 16895},
 			expr: &actionExpr{
-				pos: position{line: 422, col: 24, offset: 12348},
+				pos: position{line: 548, col: 24, offset: 16918},
 				run: (*parser).callonAggregationVariable1,
 				expr: &seqExpr{
-					pos: position{line: 422, col: 24, offset: 12348},
+					pos: position{line: 548, col: 24, offset: 16918},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 422, col: 24, offset: 12348},
+							pos:   position{line: 548, col: 24, offset: 16918},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 422, col: 29, offset: 12353},
+								pos:  position{line: 548, col: 29, offset: 16923},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 422, col: 39, offset: 12363},
+							pos:  position{line: 548, col: 39, offset: 16933},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 422, col: 41, offset: 12365},
+							pos:        position{line: 548, col: 41, offset: 16935},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 422, col: 45, offset: 12369},
+							pos:  position{line: 548, col: 45, offset: 16939},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 422, col: 47, offset: 12371},
+							pos:   position{line: 548, col: 47, offset: 16941},
 							label: "aggFunc",
 							expr: &ruleRefExpr{
-								pos:  position{line: 422, col: 55, offset: 12379},
+								pos:  position{line: 548, col: 55, offset: 16949},
 								name: "AccumulateFunction",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 422, col: 74, offset: 12398},
+							pos:  position{line: 548, col: 74, offset: 16968},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 422, col: 76, offset: 12400},
+							pos:        position{line: 548, col: 76, offset: 16970},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 422, col: 80, offset: 12404},
+							pos:  position{line: 548, col: 80, offset: 16974},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 422, col: 82, offset: 12406},
+							pos:   position{line: 548, col: 82, offset: 16976},
 							label: "fieldAccess",
 							expr: &ruleRefExpr{
-								pos:  position{line: 422, col: 94, offset: 12418},
+								pos:  position{line: 548, col: 94, offset: 16988},
 								name: "FieldAccess",
 							},
 						},
+						&labeledExpr{
+							pos:   position{line: 548, col: 106, offset: 17000},
+							label: "args",
+							expr: &ruleRefExpr{
+								pos:  position{line: 548, col: 111, offset: 17005},
+								name: "AggregateArguments",
+							},
+						},
 						&ruleRefExpr{
-							pos:  position{line: 422, col: 106, offset: 12430},
+							pos:  position{line: 548, col: 130, offset: 17024},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 422, col: 108, offset: 12432},
+							pos:        position{line: 548, col: 132, offset: 17026},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateArguments",
+			pos:  position{line: 564, col: 1, offset: 17452},
+			expr: &actionExpr{
+				pos: position{line: 564, col: 23, offset: 17474},
+				run: (*parser).callonAggregateArguments1,
+				expr: &labeledExpr{
+					pos:   position{line: 564, col: 23, offset: 17474},
+					label: "args",
+					expr: &zeroOrMoreExpr{
+						pos: position{line: 564, col: 28, offset: 17479},
+						expr: &seqExpr{
+							pos: position{line: 564, col: 29, offset: 17480},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 564, col: 29, offset: 17480},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 564, col: 31, offset: 17482},
+									val:        ",",
+									ignoreCase: false,
+									want:       "\",\"",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 564, col: 35, offset: 17486},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 564, col: 37, offset: 17488},
+									name: "AggregateArgument",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AggregateArgument",
+			pos:  position{line: 572, col: 1, offset: 17677},
+			expr: &choiceExpr{
+				pos: position{line: 572, col: 22, offset: 17698},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 572, col: 22, offset: 17698},
+						name: "FieldAccess",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 572, col: 36, offset: 17712},
+						name: "Number",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 572, col: 45, offset: 17721},
+						name: "StringLiteral",
+					},
+				},
+			},
+		},
+		{
+			name: "CollectVariable",
+			pos:  position{line: 574, col: 1, offset: 17736},
+			expr: &actionExpr{
+				pos: position{line: 574, col: 20, offset: 17755},
+				run: (*parser).callonCollectVariable1,
+				expr: &seqExpr{
+					pos: position{line: 574, col: 20, offset: 17755},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 574, col: 20, offset: 17755},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 574, col: 25, offset: 17760},
+								name: "IdentName",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 574, col: 35, offset: 17770},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 574, col: 37, offset: 17772},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 574, col: 41, offset: 17776},
+							name: "_",
+						},
+						&choiceExpr{
+							pos: position{line: 574, col: 44, offset: 17779},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 574, col: 44, offset: 17779},
+									val:        "COLLECT",
+									ignoreCase: false,
+									want:       "\"COLLECT\"",
+								},
+								&litMatcher{
+									pos:        position{line: 574, col: 56, offset: 17791},
+									val:        "collect",
+									ignoreCase: false,
+									want:       "\"collect\"",
+								},
+								&litMatcher{
+									pos:        position{line: 574, col: 68, offset: 17803},
+									val:        "Collect",
+									ignoreCase: false,
+									want:       "\"Collect\"",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 574, col: 79, offset: 17814},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 574, col: 81, offset: 17816},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 574, col: 85, offset: 17820},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 574, col: 87, offset: 17822},
+							label: "variable",
+							expr: &ruleRefExpr{
+								pos:  position{line: 574, col: 96, offset: 17831},
+								name: "SimpleTypedVariable",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 574, col: 116, offset: 17851},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 574, col: 118, offset: 17853},
+							label: "condition",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 574, col: 128, offset: 17863},
+								expr: &seqExpr{
+									pos: position{line: 574, col: 129, offset: 17864},
+									exprs: []any{
+										&litMatcher{
+											pos:        position{line: 574, col: 129, offset: 17864},
+											val:        "/",
+											ignoreCase: false,
+											want:       "\"/\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 574, col: 133, offset: 17868},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 574, col: 135, offset: 17870},
+											name: "Constraints",
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 574, col: 149, offset: 17884},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 574, col: 151, offset: 17886},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1651,43 +2315,43 @@ var g = &grammar{
 		},
 		{
 			name: "Constraints",
-			pos:  position{line: 431, col: 1, offset: 12607},
+			pos:  position{line: 587, col: 1, offset: 18168},
 			expr: &actionExpr{
-				pos: position{line: 431, col: 16, offset: 12622},
+				pos: position{line: 587, col: 16, offset: 18183},
 				run: (*parser).callonConstraints1,
 				expr: &seqExpr{
-					pos: position{line: 431, col: 16, offset: 12622},
+					pos: position{line: 587, col: 16, offset: 18183},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 431, col: 16, offset: 12622},
+							pos:   position{line: 587, col: 16, offset: 18183},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 431, col: 22, offset: 12628},
+								pos:  position{line: 587, col: 22, offset: 18189},
 								name: "Constraint",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 431, col: 33, offset: 12639},
+							pos:   position{line: 587, col: 33, offset: 18200},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 431, col: 38, offset: 12644},
+								pos: position{line: 587, col: 38, offset: 18205},
 								expr: &seqExpr{
-									pos: position{line: 431, col: 39, offset: 12645},
+									pos: position{line: 587, col: 39, offset: 18206},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 431, col: 39, offset: 12645},
+											pos:  position{line: 587, col: 39, offset: 18206},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 431, col: 41, offset: 12647},
+											pos:  position{line: 587, col: 41, offset: 18208},
 											name: "LogicalOp",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 431, col: 51, offset: 12657},
+											pos:  position{line: 587, col: 51, offset: 18218},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 431, col: 53, offset: 12659},
+											pos:  position{line: 587, col: 53, offset: 18220},
 											name: "Constraint",
 										},
 									},
@@ -1700,40 +2364,40 @@ var g = &grammar{
 		},
 		{
 			name: "Constraint",
-			pos:  position{line: 453, col: 1, offset: 13203},
+			pos:  position{line: 610, col: 1, offset: 18792},
 			expr: &choiceExpr{
-				pos: position{line: 453, col: 15, offset: 13217},
+				pos: position{line: 610, col: 15, offset: 18806},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 453, col: 15, offset: 13217},
+						pos: position{line: 610, col: 15, offset: 18806},
 						run: (*parser).callonConstraint2,
 						expr: &seqExpr{
-							pos: position{line: 453, col: 15, offset: 13217},
+							pos: position{line: 610, col: 15, offset: 18806},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 453, col: 15, offset: 13217},
+									pos:        position{line: 610, col: 15, offset: 18806},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 453, col: 19, offset: 13221},
+									pos:  position{line: 610, col: 19, offset: 18810},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 453, col: 21, offset: 13223},
+									pos:   position{line: 610, col: 21, offset: 18812},
 									label: "expr",
 									expr: &ruleRefExpr{
-										pos:  position{line: 453, col: 26, offset: 13228},
+										pos:  position{line: 610, col: 26, offset: 18817},
 										name: "Constraints",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 453, col: 38, offset: 13240},
+									pos:  position{line: 610, col: 38, offset: 18829},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 453, col: 40, offset: 13242},
+									pos:        position{line: 610, col: 40, offset: 18831},
 									val:        ")",
 									ignoreCase: false,
 									want:       "\")\"",
@@ -1742,52 +2406,56 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 454, col: 15, offset: 13283},
+						pos:  position{line: 611, col: 15, offset: 18872},
 						name: "NotConstraint",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 455, col: 15, offset: 13313},
+						pos:  position{line: 612, col: 15, offset: 18902},
 						name: "ExistsConstraint",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 456, col: 15, offset: 13346},
+						pos:  position{line: 613, col: 15, offset: 18935},
+						name: "ForallConstraint",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 614, col: 15, offset: 18968},
 						name: "AccumulateConstraint",
 					},
 					&actionExpr{
-						pos: position{line: 457, col: 15, offset: 13383},
-						run: (*parser).callonConstraint13,
+						pos: position{line: 615, col: 15, offset: 19005},
+						run: (*parser).callonConstraint14,
 						expr: &seqExpr{
-							pos: position{line: 457, col: 15, offset: 13383},
+							pos: position{line: 615, col: 15, offset: 19005},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 457, col: 15, offset: 13383},
+									pos:   position{line: 615, col: 15, offset: 19005},
 									label: "left",
 									expr: &ruleRefExpr{
-										pos:  position{line: 457, col: 20, offset: 13388},
+										pos:  position{line: 615, col: 20, offset: 19010},
 										name: "ArithmeticExpr",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 457, col: 35, offset: 13403},
+									pos:  position{line: 615, col: 35, offset: 19025},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 457, col: 37, offset: 13405},
+									pos:   position{line: 615, col: 37, offset: 19027},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 457, col: 40, offset: 13408},
+										pos:  position{line: 615, col: 40, offset: 19030},
 										name: "ComparisonOp",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 457, col: 53, offset: 13421},
+									pos:  position{line: 615, col: 53, offset: 19043},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 457, col: 55, offset: 13423},
+									pos:   position{line: 615, col: 55, offset: 19045},
 									label: "right",
 									expr: &ruleRefExpr{
-										pos:  position{line: 457, col: 61, offset: 13429},
+										pos:  position{line: 615, col: 61, offset: 19051},
 										name: "ArithmeticExpr",
 									},
 								},
@@ -1799,30 +2467,30 @@ var g = &grammar{
 		},
 		{
 			name: "NotConstraint",
-			pos:  position{line: 466, col: 1, offset: 13595},
+			pos:  position{line: 625, col: 1, offset: 19245},
 			expr: &actionExpr{
-				pos: position{line: 466, col: 18, offset: 13612},
+				pos: position{line: 625, col: 18, offset: 19262},
 				run: (*parser).callonNotConstraint1,
 				expr: &seqExpr{
-					pos: position{line: 466, col: 18, offset: 13612},
+					pos: position{line: 625, col: 18, offset: 19262},
 					exprs: []any{
 						&choiceExpr{
-							pos: position{line: 466, col: 19, offset: 13613},
+							pos: position{line: 625, col: 19, offset: 19263},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 466, col: 19, offset: 13613},
+									pos:        position{line: 625, col: 19, offset: 19263},
 									val:        "NOT",
 									ignoreCase: false,
 									want:       "\"NOT\"",
 								},
 								&litMatcher{
-									pos:        position{line: 466, col: 27, offset: 13621},
+									pos:        position{line: 625, col: 27, offset: 19271},
 									val:        "not",
 									ignoreCase: false,
 									want:       "\"not\"",
 								},
 								&litMatcher{
-									pos:        position{line: 466, col: 35, offset: 13629},
+									pos:        position{line: 625, col: 35, offset: 19279},
 									val:        "Not",
 									ignoreCase: false,
 									want:       "\"Not\"",
@@ -1830,33 +2498,33 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 466, col: 42, offset: 13636},
+							pos:  position{line: 625, col: 42, offset: 19286},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 466, col: 44, offset: 13638},
+							pos:        position{line: 625, col: 44, offset: 19288},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 466, col: 48, offset: 13642},
+							pos:  position{line: 625, col: 48, offset: 19292},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 466, col: 50, offset: 13644},
+							pos:   position{line: 625, col: 50, offset: 19294},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 466, col: 55, offset: 13649},
+								pos:  position{line: 625, col: 55, offset: 19299},
 								name: "Constraints",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 466, col: 67, offset: 13661},
+							pos:  position{line: 625, col: 67, offset: 19311},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 466, col: 69, offset: 13663},
+							pos:        position{line: 625, col: 69, offset: 19313},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1867,30 +2535,30 @@ var g = &grammar{
 		},
 		{
 			name: "ExistsConstraint",
-			pos:  position{line: 473, col: 1, offset: 13779},
+			pos:  position{line: 633, col: 1, offset: 19457},
 			expr: &actionExpr{
-				pos: position{line: 473, col: 21, offset: 13799},
+				pos: position{line: 633, col: 21, offset: 19477},
 				run: (*parser).callonExistsConstraint1,
 				expr: &seqExpr{
-					pos: position{line: 473, col: 21, offset: 13799},
+					pos: position{line: 633, col: 21, offset: 19477},
 					exprs: []any{
 						&choiceExpr{
-							pos: position{line: 473, col: 22, offset: 13800},
+							pos: position{line: 633, col: 22, offset: 19478},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 473, col: 22, offset: 13800},
+									pos:        position{line: 633, col: 22, offset: 19478},
 									val:        "EXISTS",
 									ignoreCase: false,
 									want:       "\"EXISTS\"",
 								},
 								&litMatcher{
-									pos:        position{line: 473, col: 33, offset: 13811},
+									pos:        position{line: 633, col: 33, offset: 19489},
 									val:        "exists",
 									ignoreCase: false,
 									want:       "\"exists\"",
 								},
 								&litMatcher{
-									pos:        position{line: 473, col: 44, offset: 13822},
+									pos:        position{line: 633, col: 44, offset: 19500},
 									val:        "Exists",
 									ignoreCase: false,
 									want:       "\"Exists\"",
@@ -1898,55 +2566,167 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 54, offset: 13832},
+							pos:  position{line: 633, col: 54, offset: 19510},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 473, col: 56, offset: 13834},
+							pos:        position{line: 633, col: 56, offset: 19512},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 60, offset: 13838},
+							pos:  position{line: 633, col: 60, offset: 19516},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 473, col: 62, offset: 13840},
+							pos:   position{line: 633, col: 62, offset: 19518},
 							label: "variable",
 							expr: &ruleRefExpr{
-								pos:  position{line: 473, col: 71, offset: 13849},
+								pos:  position{line: 633, col: 71, offset: 19527},
 								name: "TypedVariable",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 85, offset: 13863},
+							pos:  position{line: 633, col: 85, offset: 19541},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 473, col: 87, offset: 13865},
+							pos:        position{line: 633, col: 87, offset: 19543},
 							val:        "/",
 							ignoreCase: false,
 							want:       "\"/\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 91, offset: 13869},
+							pos:  position{line: 633, col: 91, offset: 19547},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 473, col: 93, offset: 13871},
+							pos:   position{line: 633, col: 93, offset: 19549},
 							label: "condition",
 							expr: &ruleRefExpr{
-								pos:  position{line: 473, col: 103, offset: 13881},
+								pos:  position{line: 633, col: 103, offset: 19559},
 								name: "Constraints",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 115, offset: 13893},
+							pos:  position{line: 633, col: 115, offset: 19571},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 473, col: 117, offset: 13895},
+							pos:        position{line: 633, col: 117, offset: 19573},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ForallConstraint",
+			pos:  position{line: 642, col: 1, offset: 19754},
+			expr: &actionExpr{
+				pos: position{line: 642, col: 21, offset: 19774},
+				run: (*parser).callonForallConstraint1,
+				expr: &seqExpr{
+					pos: position{line: 642, col: 21, offset: 19774},
+					exprs: []any{
+						&choiceExpr{
+							pos: position{line: 642, col: 22, offset: 19775},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 642, col: 22, offset: 19775},
+									val:        "FORALL",
+									ignoreCase: false,
+									want:       "\"FORALL\"",
+								},
+								&litMatcher{
+									pos:        position{line: 642, col: 33, offset: 19786},
+									val:        "forall",
+									ignoreCase: false,
+									want:       "\"forall\"",
+								},
+								&litMatcher{
+									pos:        position{line: 642, col: 44, offset: 19797},
+									val:        "Forall",
+									ignoreCase: false,
+									want:       "\"Forall\"",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 54, offset: 19807},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 642, col: 56, offset: 19809},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 60, offset: 19813},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 642, col: 62, offset: 19815},
+							label: "variable",
+							expr: &ruleRefExpr{
+								pos:  position{line: 642, col: 71, offset: 19824},
+								name: "SimpleTypedVariable",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 91, offset: 19844},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 642, col: 93, offset: 19846},
+							val:        "/",
+							ignoreCase: false,
+							want:       "\"/\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 97, offset: 19850},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 642, col: 99, offset: 19852},
+							label: "condition",
+							expr: &ruleRefExpr{
+								pos:  position{line: 642, col: 109, offset: 19862},
+								name: "Constraints",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 121, offset: 19874},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 642, col: 123, offset: 19876},
+							val:        "==>",
+							ignoreCase: false,
+							want:       "\"==>\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 129, offset: 19882},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 642, col: 131, offset: 19884},
+							label: "then",
+							expr: &ruleRefExpr{
+								pos:  position{line: 642, col: 136, offset: 19889},
+								name: "Constraints",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 642, col: 148, offset: 19901},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 642, col: 150, offset: 19903},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1957,130 +2737,134 @@ var g = &grammar{
 		},
 		{
 			name: "AccumulateConstraint",
-			pos:  position{line: 481, col: 1, offset: 14048},
+			pos:  position{line: 652, col: 1, offset: 20106},
 			expr: &actionExpr{
-				pos: position{line: 481, col: 25, offset: 14072},
+				pos: position{line: 652, col: 25, offset: 20130},
 				run: (*parser).callonAccumulateConstraint1,
 				expr: &seqExpr{
-					pos: position{line: 481, col: 25, offset: 14072},
+					pos: position{line: 652, col: 25, offset: 20130},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 481, col: 25, offset: 14072},
+							pos:   position{line: 652, col: 25, offset: 20130},
 							label: "accumFunc",
 							expr: &ruleRefExpr{
-								pos:  position{line: 481, col: 35, offset: 14082},
+								pos:  position{line: 652, col: 35, offset: 20140},
 								name: "AccumulateFunction",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 54, offset: 14101},
+							pos:  position{line: 652, col: 54, offset: 20159},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 481, col: 56, offset: 14103},
+							pos:        position{line: 652, col: 56, offset: 20161},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 60, offset: 14107},
+							pos:  position{line: 652, col: 60, offset: 20165},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 481, col: 62, offset: 14109},
+							pos:   position{line: 652, col: 62, offset: 20167},
 							label: "accumVar",
 							expr: &ruleRefExpr{
-								pos:  position{line: 481, col: 71, offset: 14118},
+								pos:  position{line: 652, col: 71, offset: 20176},
 								name: "TypedVariable",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 85, offset: 14132},
+							pos:  position{line: 652, col: 85, offset: 20190},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 481, col: 87, offset: 14134},
+							pos:        position{line: 652, col: 87, offset: 20192},
 							val:        "/",
 							ignoreCase: false,
 							want:       "\"/\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 91, offset: 14138},
+							pos:  position{line: 652, col: 91, offset: 20196},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 481, col: 93, offset: 14140},
+							pos:   position{line: 652, col: 93, offset: 20198},
 							label: "accumCond",
 							expr: &ruleRefExpr{
-								pos:  position{line: 481, col: 103, offset: 14150},
+								pos:  position{line: 652, col: 103, offset: 20208},
 								name: "Constraints",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 115, offset: 14162},
+							pos:  position{line: 652, col: 115, offset: 20220},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 481, col: 117, offset: 14164},
+							pos:   position{line: 652, col: 117, offset: 20222},
 							label: "accumField",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 481, col: 128, offset: 14175},
+								pos: position{line: 652, col: 128, offset: 20233},
 								expr: &seqExpr{
-									pos: position{line: 481, col: 129, offset: 14176},
+									pos: position{line: 652, col: 129, offset: 20234},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 481, col: 129, offset: 14176},
+											pos:  position{line: 652, col: 129, offset: 20234},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 481, col: 131, offset: 14178},
+											pos:        position{line: 652, col: 131, offset: 20236},
 											val:        ";",
 											ignoreCase: false,
 											want:       "\";\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 481, col: 135, offset: 14182},
+											pos:  position{line: 652, col: 135, offset: 20240},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 481, col: 137, offset: 14184},
+											pos:  position{line: 652, col: 137, offset: 20242},
 											name: "FieldAccess",
 										},
+										&ruleRefExpr{
+											pos:  position{line: 652, col: 149, offset: 20254},
+											name: "AggregateArguments",
+										},
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 151, offset: 14198},
+							pos:  position{line: 652, col: 170, offset: 20275},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 481, col: 153, offset: 14200},
+							pos:        position{line: 652, col: 172, offset: 20277},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 157, offset: 14204},
+							pos:  position{line: 652, col: 176, offset: 20281},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 481, col: 159, offset: 14206},
+							pos:   position{line: 652, col: 178, offset: 20283},
 							label: "accumOp",
 							expr: &ruleRefExpr{
-								pos:  position{line: 481, col: 167, offset: 14214},
+								pos:  position{line: 652, col: 186, offset: 20291},
 								name: "ComparisonOp",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 481, col: 180, offset: 14227},
+							pos:  position{line: 652, col: 199, offset: 20304},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 481, col: 182, offset: 14229},
+							pos:   position{line: 652, col: 201, offset: 20306},
 							label: "accumThreshold",
 							expr: &ruleRefExpr{
-								pos:  position{line: 481, col: 197, offset: 14244},
+								pos:  position{line: 652, col: 216, offset: 20321},
 								name: "ArithmeticExpr",
 							},
 						},
@@ -2090,30 +2874,30 @@ var g = &grammar{
 		},
 		{
 			name: "AccumulateFunction",
-			pos:  position{line: 499, col: 1, offset: 14722},
+			pos:  position{line: 679, col: 1, offset: 21049},
 			expr: &choiceExpr{
-				pos: position{line: 499, col: 23, offset: 14744},
+				pos: position{line: 679, col: 23, offset: 21071},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 499, col: 23, offset: 14744},
+						pos: position{line: 679, col: 23, offset: 21071},
 						run: (*parser).callonAccumulateFunction2,
 						expr: &choiceExpr{
-							pos: position{line: 499, col: 24, offset: 14745},
+							pos: position{line: 679, col: 24, offset: 21072},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 499, col: 24, offset: 14745},
+									pos:        position{line: 679, col: 24, offset: 21072},
 									val:        "AVG",
 									ignoreCase: false,
 									want:       "\"AVG\"",
 								},
 								&litMatcher{
-									pos:        position{line: 499, col: 32, offset: 14753},
+									pos:        position{line: 679, col: 32, offset: 21080},
 									val:        "avg",
 									ignoreCase: false,
 									want:       "\"avg\"",
 								},
 								&litMatcher{
-									pos:        position{line: 499, col: 40, offset: 14761},
+									pos:        position{line: 679, col: 40, offset: 21088},
 									val:        "Avg",
 									ignoreCase: false,
 									want:       "\"Avg\"",
@@ -2122,25 +2906,52 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 500, col: 22, offset: 14813},
+						pos: position{line: 680, col: 22, offset: 21140},
 						run: (*parser).callonAccumulateFunction7,
 						expr: &choiceExpr{
-							pos: position{line: 500, col: 23, offset: 14814},
+							pos: position{line: 680, col: 23, offset: 21141},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 680, col: 23, offset: 21141},
+									val:        "COUNT_DISTINCT",
+									ignoreCase: false,
+									want:       "\"COUNT_DISTINCT\"",
+								},
+								&litMatcher{
+									pos:        position{line: 680, col: 42, offset: 21160},
+									val:        "count_distinct",
+									ignoreCase: false,
+									want:       "\"count_distinct\"",
+								},
+								&litMatcher{
+									pos:        position{line: 680, col: 61, offset: 21179},
+									val:        "Count_Distinct",
+									ignoreCase: false,
+									want:       "\"Count_Distinct\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 681, col: 22, offset: 21253},
+						run: (*parser).callonAccumulateFunction12,
+						expr: &choiceExpr{
+							pos: position{line: 681, col: 23, offset: 21254},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 500, col: 23, offset: 14814},
+									pos:        position{line: 681, col: 23, offset: 21254},
 									val:        "COUNT",
 									ignoreCase: false,
 									want:       "\"COUNT\"",
 								},
 								&litMatcher{
-									pos:        position{line: 500, col: 33, offset: 14824},
+									pos:        position{line: 681, col: 33, offset: 21264},
 									val:        "count",
 									ignoreCase: false,
 									want:       "\"count\"",
 								},
 								&litMatcher{
-									pos:        position{line: 500, col: 43, offset: 14834},
+									pos:        position{line: 681, col: 43, offset: 21274},
 									val:        "Count",
 									ignoreCase: false,
 									want:       "\"Count\"",
@@ -2149,25 +2960,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 501, col: 22, offset: 14890},
-						run: (*parser).callonAccumulateFunction12,
+						pos: position{line: 682, col: 22, offset: 21330},
+						run: (*parser).callonAccumulateFunction17,
 						expr: &choiceExpr{
-							pos: position{line: 501, col: 23, offset: 14891},
+							pos: position{line: 682, col: 23, offset: 21331},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 501, col: 23, offset: 14891},
+									pos:        position{line: 682, col: 23, offset: 21331},
 									val:        "SUM",
 									ignoreCase: false,
 									want:       "\"SUM\"",
 								},
 								&litMatcher{
-									pos:        position{line: 501, col: 31, offset: 14899},
+									pos:        position{line: 682, col: 31, offset: 21339},
 									val:        "sum",
 									ignoreCase: false,
 									want:       "\"sum\"",
 								},
 								&litMatcher{
-									pos:        position{line: 501, col: 39, offset: 14907},
+									pos:        position{line: 682, col: 39, offset: 21347},
 									val:        "Sum",
 									ignoreCase: false,
 									want:       "\"Sum\"",
@@ -2176,25 +2987,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 502, col: 22, offset: 14959},
-						run: (*parser).callonAccumulateFunction17,
+						pos: position{line: 683, col: 22, offset: 21399},
+						run: (*parser).callonAccumulateFunction22,
 						expr: &choiceExpr{
-							pos: position{line: 502, col: 23, offset: 14960},
+							pos: position{line: 683, col: 23, offset: 21400},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 502, col: 23, offset: 14960},
+									pos:        position{line: 683, col: 23, offset: 21400},
 									val:        "MIN",
 									ignoreCase: false,
 									want:       "\"MIN\"",
 								},
 								&litMatcher{
-									pos:        position{line: 502, col: 31, offset: 14968},
+									pos:        position{line: 683, col: 31, offset: 21408},
 									val:        "min",
 									ignoreCase: false,
 									want:       "\"min\"",
 								},
 								&litMatcher{
-									pos:        position{line: 502, col: 39, offset: 14976},
+									pos:        position{line: 683, col: 39, offset: 21416},
 									val:        "Min",
 									ignoreCase: false,
 									want:       "\"Min\"",
@@ -2203,25 +3014,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 503, col: 22, offset: 15028},
-						run: (*parser).callonAccumulateFunction22,
+						pos: position{line: 684, col: 22, offset: 21468},
+						run: (*parser).callonAccumulateFunction27,
 						expr: &choiceExpr{
-							pos: position{line: 503, col: 23, offset: 15029},
+							pos: position{line: 684, col: 23, offset: 21469},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 503, col: 23, offset: 15029},
+									pos:        position{line: 684, col: 23, offset: 21469},
 									val:        "MAX",
 									ignoreCase: false,
 									want:       "\"MAX\"",
 								},
 								&litMatcher{
-									pos:        position{line: 503, col: 31, offset: 15037},
+									pos:        position{line: 684, col: 31, offset: 21477},
 									val:        "max",
 									ignoreCase: false,
 									want:       "\"max\"",
 								},
 								&litMatcher{
-									pos:        position{line: 503, col: 39, offset: 15045},
+									pos:        position{line: 684, col: 39, offset: 21485},
 									val:        "Max",
 									ignoreCase: false,
 									want:       "\"Max\"",
@@ -2229,49 +3040,238 @@ var g = &grammar{
 							},
 						},
 					},
+					&actionExpr{
+						pos: position{line: 685, col: 22, offset: 21537},
+						run: (*parser).callonAccumulateFunction32,
+						expr: &choiceExpr{
+							pos: position{line: 685, col: 23, offset: 21538},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 685, col: 23, offset: 21538},
+									val:        "MEDIAN",
+									ignoreCase: false,
+									want:       "\"MEDIAN\"",
+								},
+								&litMatcher{
+									pos:        position{line: 685, col: 34, offset: 21549},
+									val:        "median",
+									ignoreCase: false,
+									want:       "\"median\"",
+								},
+								&litMatcher{
+									pos:        position{line: 685, col: 45, offset: 21560},
+									val:        "Median",
+									ignoreCase: false,
+									want:       "\"Median\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 686, col: 22, offset: 21618},
+						run: (*parser).callonAccumulateFunction37,
+						expr: &choiceExpr{
+							pos: position{line: 686, col: 23, offset: 21619},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 686, col: 23, offset: 21619},
+									val:        "PERCENTILE",
+									ignoreCase: false,
+									want:       "\"PERCENTILE\"",
+								},
+								&litMatcher{
+									pos:        position{line: 686, col: 38, offset: 21634},
+									val:        "percentile",
+									ignoreCase: false,
+									want:       "\"percentile\"",
+								},
+								&litMatcher{
+									pos:        position{line: 686, col: 53, offset: 21649},
+									val:        "Percentile",
+									ignoreCase: false,
+									want:       "\"Percentile\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 687, col: 22, offset: 21715},
+						run: (*parser).callonAccumulateFunction42,
+						expr: &choiceExpr{
+							pos: position{line: 687, col: 23, offset: 21716},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 687, col: 23, offset: 21716},
+									val:        "STDDEV",
+									ignoreCase: false,
+									want:       "\"STDDEV\"",
+								},
+								&litMatcher{
+									pos:        position{line: 687, col: 34, offset: 21727},
+									val:        "stddev",
+									ignoreCase: false,
+									want:       "\"stddev\"",
+								},
+								&litMatcher{
+									pos:        position{line: 687, col: 45, offset: 21738},
+									val:        "Stddev",
+									ignoreCase: false,
+									want:       "\"Stddev\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 688, col: 22, offset: 21796},
+						run: (*parser).callonAccumulateFunction47,
+						expr: &choiceExpr{
+							pos: position{line: 688, col: 23, offset: 21797},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 688, col: 23, offset: 21797},
+									val:        "FIRST",
+									ignoreCase: false,
+									want:       "\"FIRST\"",
+								},
+								&litMatcher{
+									pos:        position{line: 688, col: 33, offset: 21807},
+									val:        "first",
+									ignoreCase: false,
+									want:       "\"first\"",
+								},
+								&litMatcher{
+									pos:        position{line: 688, col: 43, offset: 21817},
+									val:        "First",
+									ignoreCase: false,
+									want:       "\"First\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 689, col: 22, offset: 21873},
+						run: (*parser).callonAccumulateFunction52,
+						expr: &choiceExpr{
+							pos: position{line: 689, col: 23, offset: 21874},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 689, col: 23, offset: 21874},
+									val:        "LAST",
+									ignoreCase: false,
+									want:       "\"LAST\"",
+								},
+								&litMatcher{
+									pos:        position{line: 689, col: 32, offset: 21883},
+									val:        "last",
+									ignoreCase: false,
+									want:       "\"last\"",
+								},
+								&litMatcher{
+									pos:        position{line: 689, col: 41, offset: 21892},
+									val:        "Last",
+									ignoreCase: false,
+									want:       "\"Last\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 690, col: 22, offset: 21946},
+						run: (*parser).callonAccumulateFunction57,
+						expr: &choiceExpr{
+							pos: position{line: 690, col: 23, offset: 21947},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 690, col: 23, offset: 21947},
+									val:        "STRING_AGG",
+									ignoreCase: false,
+									want:       "\"STRING_AGG\"",
+								},
+								&litMatcher{
+									pos:        position{line: 690, col: 38, offset: 21962},
+									val:        "string_agg",
+									ignoreCase: false,
+									want:       "\"string_agg\"",
+								},
+								&litMatcher{
+									pos:        position{line: 690, col: 53, offset: 21977},
+									val:        "String_Agg",
+									ignoreCase: false,
+									want:       "\"String_Agg\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 691, col: 22, offset: 22043},
+						run: (*parser).callonAccumulateFunction62,
+						expr: &choiceExpr{
+							pos: position{line: 691, col: 23, offset: 22044},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 691, col: 23, offset: 22044},
+									val:        "COLLECT_SET",
+									ignoreCase: false,
+									want:       "\"COLLECT_SET\"",
+								},
+								&litMatcher{
+									pos:        position{line: 691, col: 39, offset: 22060},
+									val:        "collect_set",
+									ignoreCase: false,
+									want:       "\"collect_set\"",
+								},
+								&litMatcher{
+									pos:        position{line: 691, col: 55, offset: 22076},
+									val:        "Collect_Set",
+									ignoreCase: false,
+									want:       "\"Collect_Set\"",
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		{
 			name: "ArithmeticExpr",
-			pos:  position{line: 506, col: 1, offset: 15076},
+			pos:  position{line: 694, col: 1, offset: 22123},
 			expr: &actionExpr{
-				pos: position{line: 506, col: 19, offset: 15094},
+				pos: position{line: 694, col: 19, offset: 22141},
 				run: (*parser).callonArithmeticExpr1,
 				expr: &seqExpr{
-					pos: position{line: 506, col: 19, offset: 15094},
+					pos: position{line: 694, col: 19, offset: 22141},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 506, col: 19, offset: 15094},
+							pos:   position{line: 694, col: 19, offset: 22141},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 506, col: 25, offset: 15100},
+								pos:  position{line: 694, col: 25, offset: 22147},
 								name: "Term",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 506, col: 30, offset: 15105},
+							pos:   position{line: 694, col: 30, offset: 22152},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 506, col: 35, offset: 15110},
+								pos: position{line: 694, col: 35, offset: 22157},
 								expr: &seqExpr{
-									pos: position{line: 506, col: 36, offset: 15111},
+									pos: position{line: 694, col: 36, offset: 22158},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 506, col: 36, offset: 15111},
+											pos:  position{line: 694, col: 36, offset: 22158},
 											name: "_",
 										},
 										&choiceExpr{
-											pos: position{line: 506, col: 39, offset: 15114},
+											pos: position{line: 694, col: 39, offset: 22161},
 											alternatives: []any{
 												&litMatcher{
-													pos:        position{line: 506, col: 39, offset: 15114},
+													pos:        position{line: 694, col: 39, offset: 22161},
 													val:        "+",
 													ignoreCase: false,
 													want:       "\"+\"",
 												},
 												&litMatcher{
-													pos:        position{line: 506, col: 45, offset: 15120},
+													pos:        position{line: 694, col: 45, offset: 22167},
 													val:        "-",
 													ignoreCase: false,
 													want:       "\"-\"",
@@ -2279,11 +3279,11 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 506, col: 50, offset: 15125},
+											pos:  position{line: 694, col: 50, offset: 22172},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 506, col: 52, offset: 15127},
+											pos:  position{line: 694, col: 52, offset: 22174},
 											name: "Term",
 										},
 									},
@@ -2296,50 +3296,50 @@ var g = &grammar{
 		},
 		{
 			name: "Term",
-			pos:  position{line: 525, col: 1, offset: 15570},
+			pos:  position{line: 714, col: 1, offset: 22667},
 			expr: &actionExpr{
-				pos: position{line: 525, col: 9, offset: 15578},
+				pos: position{line: 714, col: 9, offset: 22675},
 				run: (*parser).callonTerm1,
 				expr: &seqExpr{
-					pos: position{line: 525, col: 9, offset: 15578},
+					pos: position{line: 714, col: 9, offset: 22675},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 525, col: 9, offset: 15578},
+							pos:   position{line: 714, col: 9, offset: 22675},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 525, col: 15, offset: 15584},
+								pos:  position{line: 714, col: 15, offset: 22681},
 								name: "Factor",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 525, col: 22, offset: 15591},
+							pos:   position{line: 714, col: 22, offset: 22688},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 525, col: 27, offset: 15596},
+								pos: position{line: 714, col: 27, offset: 22693},
 								expr: &seqExpr{
-									pos: position{line: 525, col: 28, offset: 15597},
+									pos: position{line: 714, col: 28, offset: 22694},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 525, col: 28, offset: 15597},
+											pos:  position{line: 714, col: 28, offset: 22694},
 											name: "_",
 										},
 										&choiceExpr{
-											pos: position{line: 525, col: 31, offset: 15600},
+											pos: position{line: 714, col: 31, offset: 22697},
 											alternatives: []any{
 												&litMatcher{
-													pos:        position{line: 525, col: 31, offset: 15600},
+													pos:        position{line: 714, col: 31, offset: 22697},
 													val:        "*",
 													ignoreCase: false,
 													want:       "\"*\"",
 												},
 												&litMatcher{
-													pos:        position{line: 525, col: 37, offset: 15606},
+													pos:        position{line: 714, col: 37, offset: 22703},
 													val:        "/",
 													ignoreCase: false,
 													want:       "\"/\"",
 												},
 												&litMatcher{
-													pos:        position{line: 525, col: 43, offset: 15612},
+													pos:        position{line: 714, col: 43, offset: 22709},
 													val:        "%",
 													ignoreCase: false,
 													want:       "\"%\"",
@@ -2347,11 +3347,11 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 525, col: 48, offset: 15617},
+											pos:  position{line: 714, col: 48, offset: 22714},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 525, col: 50, offset: 15619},
+											pos:  position{line: 714, col: 50, offset: 22716},
 											name: "Factor",
 										},
 									},
@@ -2364,44 +3364,48 @@ var g = &grammar{
 		},
 		{
 			name: "Factor",
-			pos:  position{line: 544, col: 1, offset: 16064},
+			pos:  position{line: 734, col: 1, offset: 23211},
 			expr: &choiceExpr{
-				pos: position{line: 544, col: 11, offset: 16074},
+				pos: position{line: 734, col: 11, offset: 23221},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 544, col: 11, offset: 16074},
+						pos:  position{line: 734, col: 11, offset: 23221},
 						name: "ObjectLiteral",
 					},
+					&ruleRefExpr{
+						pos:  position{line: 735, col: 11, offset: 23247},
+						name: "TernaryExpression",
+					},
 					&actionExpr{
-						pos: position{line: 545, col: 11, offset: 16100},
-						run: (*parser).callonFactor3,
+						pos: position{line: 736, col: 11, offset: 23277},
+						run: (*parser).callonFactor4,
 						expr: &seqExpr{
-							pos: position{line: 545, col: 11, offset: 16100},
+							pos: position{line: 736, col: 11, offset: 23277},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 545, col: 11, offset: 16100},
+									pos:        position{line: 736, col: 11, offset: 23277},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 545, col: 15, offset: 16104},
+									pos:  position{line: 736, col: 15, offset: 23281},
 									name: "_",
 								},
 								&labeledExpr{
-									pos:   position{line: 545, col: 17, offset: 16106},
+									pos:   position{line: 736, col: 17, offset: 23283},
 									label: "expr",
 									expr: &ruleRefExpr{
-										pos:  position{line: 545, col: 22, offset: 16111},
+										pos:  position{line: 736, col: 22, offset: 23288},
 										name: "ArithmeticExpr",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 545, col: 37, offset: 16126},
+									pos:  position{line: 736, col: 37, offset: 23303},
 									name: "_",
 								},
 								&litMatcher{
-									pos:        position{line: 545, col: 39, offset: 16128},
+									pos:        position{line: 736, col: 39, offset: 23305},
 									val:        ")",
 									ignoreCase: false,
 									want:       "\")\"",
@@ -2410,90 +3414,175 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 546, col: 11, offset: 16165},
+						pos:  position{line: 737, col: 11, offset: 23342},
 						name: "CastExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 547, col: 11, offset: 16192},
+						pos:  position{line: 738, col: 11, offset: 23369},
 						name: "InlineFact",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 548, col: 11, offset: 16215},
+						pos:  position{line: 739, col: 11, offset: 23392},
 						name: "FunctionCall",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 549, col: 11, offset: 16240},
+						pos:  position{line: 740, col: 11, offset: 23417},
 						name: "FieldAccess",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 550, col: 11, offset: 16264},
+						pos:  position{line: 741, col: 11, offset: 23441},
 						name: "Number",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 551, col: 11, offset: 16283},
+						pos:  position{line: 742, col: 11, offset: 23460},
 						name: "StringLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 552, col: 11, offset: 16309},
+						pos:  position{line: 743, col: 11, offset: 23486},
 						name: "BooleanLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 553, col: 11, offset: 16336},
+						pos:  position{line: 744, col: 11, offset: 23513},
 						name: "ArrayLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 554, col: 11, offset: 16361},
+						pos:  position{line: 745, col: 11, offset: 23538},
 						name: "Variable",
 					},
 				},
 			},
 		},
+		{
+			name: "TernaryExpression",
+			pos:  position{line: 747, col: 1, offset: 23548},
+			expr: &actionExpr{
+				pos: position{line: 747, col: 22, offset: 23569},
+				run: (*parser).callonTernaryExpression1,
+				expr: &seqExpr{
+					pos: position{line: 747, col: 22, offset: 23569},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 747, col: 22, offset: 23569},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 747, col: 26, offset: 23573},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 747, col: 28, offset: 23575},
+							label: "condition",
+							expr: &ruleRefExpr{
+								pos:  position{line: 747, col: 38, offset: 23585},
+								name: "Constraints",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 747, col: 50, offset: 23597},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 747, col: 52, offset: 23599},
+							val:        "?",
+							ignoreCase: false,
+							want:       "\"?\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 747, col: 56, offset: 23603},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 747, col: 58, offset: 23605},
+							label: "then",
+							expr: &ruleRefExpr{
+								pos:  position{line: 747, col: 63, offset: 23610},
+								name: "ArithmeticExpr",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 747, col: 78, offset: 23625},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 747, col: 80, offset: 23627},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 747, col: 84, offset: 23631},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 747, col: 86, offset: 23633},
+							label: "elseExpr",
+							expr: &ruleRefExpr{
+								pos:  position{line: 747, col: 95, offset: 23642},
+								name: "ArithmeticExpr",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 747, col: 110, offset: 23657},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 747, col: 112, offset: 23659},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "CastExpression",
-			pos:  position{line: 556, col: 1, offset: 16371},
+			pos:  position{line: 757, col: 1, offset: 23849},
 			expr: &actionExpr{
-				pos: position{line: 556, col: 19, offset: 16389},
+				pos: position{line: 757, col: 19, offset: 23867},
 				run: (*parser).callonCastExpression1,
 				expr: &seqExpr{
-					pos: position{line: 556, col: 19, offset: 16389},
+					pos: position{line: 757, col: 19, offset: 23867},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 556, col: 19, offset: 16389},
+							pos:        position{line: 757, col: 19, offset: 23867},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 556, col: 23, offset: 16393},
+							pos:  position{line: 757, col: 23, offset: 23871},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 556, col: 25, offset: 16395},
+							pos:   position{line: 757, col: 25, offset: 23873},
 							label: "castType",
 							expr: &ruleRefExpr{
-								pos:  position{line: 556, col: 34, offset: 16404},
+								pos:  position{line: 757, col: 34, offset: 23882},
 								name: "CastType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 556, col: 43, offset: 16413},
+							pos:  position{line: 757, col: 43, offset: 23891},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 556, col: 45, offset: 16415},
+							pos:        position{line: 757, col: 45, offset: 23893},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 556, col: 49, offset: 16419},
+							pos:  position{line: 757, col: 49, offset: 23897},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 556, col: 51, offset: 16421},
+							pos:   position{line: 757, col: 51, offset: 23899},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 556, col: 56, offset: 16426},
+								pos:  position{line: 757, col: 56, offset: 23904},
 								name: "Factor",
 							},
 						},
@@ -2503,35 +3592,35 @@ var g = &grammar{
 		},
 		{
 			name: "CastType",
-			pos:  position{line: 564, col: 1, offset: 16566},
+			pos:  position{line: 766, col: 1, offset: 24072},
 			expr: &choiceExpr{
-				pos: position{line: 564, col: 13, offset: 16578},
+				pos: position{line: 766, col: 13, offset: 24084},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 564, col: 13, offset: 16578},
+						pos: position{line: 766, col: 13, offset: 24084},
 						run: (*parser).callonCastType2,
 						expr: &litMatcher{
-							pos:        position{line: 564, col: 13, offset: 16578},
+							pos:        position{line: 766, col: 13, offset: 24084},
 							val:        "number",
 							ignoreCase: false,
 							want:       "\"number\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 565, col: 13, offset: 16626},
+						pos: position{line: 767, col: 13, offset: 24132},
 						run: (*parser).callonCastType4,
 						expr: &litMatcher{
-							pos:        position{line: 565, col: 13, offset: 16626},
+							pos:        position{line: 767, col: 13, offset: 24132},
 							val:        "string",
 							ignoreCase: false,
 							want:       "\"string\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 566, col: 13, offset: 16674},
+						pos: position{line: 768, col: 13, offset: 24180},
 						run: (*parser).callonCastType6,
 						expr: &litMatcher{
-							pos:        position{line: 566, col: 13, offset: 16674},
+							pos:        position{line: 768, col: 13, offset: 24180},
 							val:        "bool",
 							ignoreCase: false,
 							want:       "\"bool\"",
@@ -2542,32 +3631,32 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAccess",
-			pos:  position{line: 568, col: 1, offset: 16707},
+			pos:  position{line: 770, col: 1, offset: 24213},
 			expr: &actionExpr{
-				pos: position{line: 568, col: 16, offset: 16722},
+				pos: position{line: 770, col: 16, offset: 24228},
 				run: (*parser).callonFieldAccess1,
 				expr: &seqExpr{
-					pos: position{line: 568, col: 16, offset: 16722},
+					pos: position{line: 770, col: 16, offset: 24228},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 568, col: 16, offset: 16722},
+							pos:   position{line: 770, col: 16, offset: 24228},
 							label: "object",
 							expr: &ruleRefExpr{
-								pos:  position{line: 568, col: 23, offset: 16729},
+								pos:  position{line: 770, col: 23, offset: 24235},
 								name: "IdentName",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 568, col: 33, offset: 16739},
+							pos:        position{line: 770, col: 33, offset: 24245},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 568, col: 37, offset: 16743},
+							pos:   position{line: 770, col: 37, offset: 24249},
 							label: "field",
 							expr: &ruleRefExpr{
-								pos:  position{line: 568, col: 43, offset: 16749},
+								pos:  position{line: 770, col: 43, offset: 24255},
 								name: "IdentName",
 							},
 						},
@@ -2577,45 +3666,45 @@ var g = &grammar{
 		},
 		{
 			name: "InlineFact",
-			pos:  position{line: 576, col: 1, offset: 16891},
+			pos:  position{line: 779, col: 1, offset: 24425},
 			expr: &actionExpr{
-				pos: position{line: 576, col: 15, offset: 16905},
+				pos: position{line: 779, col: 15, offset: 24439},
 				run: (*parser).callonInlineFact1,
 				expr: &seqExpr{
-					pos: position{line: 576, col: 15, offset: 16905},
+					pos: position{line: 779, col: 15, offset: 24439},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 576, col: 15, offset: 16905},
+							pos:   position{line: 779, col: 15, offset: 24439},
 							label: "typeName",
 							expr: &ruleRefExpr{
-								pos:  position{line: 576, col: 24, offset: 16914},
-								name: "IdentName",
+								pos:  position{line: 779, col: 24, offset: 24448},
+								name: "QualifiedName",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 576, col: 34, offset: 16924},
+							pos:        position{line: 779, col: 38, offset: 24462},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 576, col: 38, offset: 16928},
+							pos:  position{line: 779, col: 42, offset: 24466},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 576, col: 40, offset: 16930},
+							pos:   position{line: 779, col: 44, offset: 24468},
 							label: "fields",
 							expr: &ruleRefExpr{
-								pos:  position{line: 576, col: 47, offset: 16937},
+								pos:  position{line: 779, col: 51, offset: 24475},
 								name: "InlineFactFieldList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 576, col: 67, offset: 16957},
+							pos:  position{line: 779, col: 71, offset: 24495},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 576, col: 69, offset: 16959},
+							pos:        position{line: 779, col: 73, offset: 24497},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -2626,45 +3715,45 @@ var g = &grammar{
 		},
 		{
 			name: "InlineFactFieldList",
-			pos:  position{line: 584, col: 1, offset: 17100},
+			pos:  position{line: 788, col: 1, offset: 24666},
 			expr: &actionExpr{
-				pos: position{line: 584, col: 24, offset: 17123},
+				pos: position{line: 788, col: 24, offset: 24689},
 				run: (*parser).callonInlineFactFieldList1,
 				expr: &seqExpr{
-					pos: position{line: 584, col: 24, offset: 17123},
+					pos: position{line: 788, col: 24, offset: 24689},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 584, col: 24, offset: 17123},
+							pos:   position{line: 788, col: 24, offset: 24689},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 584, col: 30, offset: 17129},
+								pos:  position{line: 788, col: 30, offset: 24695},
 								name: "InlineFactField",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 584, col: 46, offset: 17145},
+							pos:   position{line: 788, col: 46, offset: 24711},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 584, col: 51, offset: 17150},
+								pos: position{line: 788, col: 51, offset: 24716},
 								expr: &seqExpr{
-									pos: position{line: 584, col: 52, offset: 17151},
+									pos: position{line: 788, col: 52, offset: 24717},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 584, col: 52, offset: 17151},
+											pos:  position{line: 788, col: 52, offset: 24717},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 584, col: 54, offset: 17153},
+											pos:        position{line: 788, col: 54, offset: 24719},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 584, col: 58, offset: 17157},
+											pos:  position{line: 788, col: 58, offset: 24723},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 584, col: 60, offset: 17159},
+											pos:  position{line: 788, col: 60, offset: 24725},
 											name: "InlineFactField",
 										},
 									},
@@ -2677,40 +3766,40 @@ var g = &grammar{
 		},
 		{
 			name: "InlineFactField",
-			pos:  position{line: 594, col: 1, offset: 17390},
+			pos:  position{line: 798, col: 1, offset: 24956},
 			expr: &actionExpr{
-				pos: position{line: 594, col: 20, offset: 17409},
+				pos: position{line: 798, col: 20, offset: 24975},
 				run: (*parser).callonInlineFactField1,
 				expr: &seqExpr{
-					pos: position{line: 594, col: 20, offset: 17409},
+					pos: position{line: 798, col: 20, offset: 24975},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 594, col: 20, offset: 17409},
+							pos:   position{line: 798, col: 20, offset: 24975},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 594, col: 25, offset: 17414},
+								pos:  position{line: 798, col: 25, offset: 24980},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 594, col: 35, offset: 17424},
+							pos:  position{line: 798, col: 35, offset: 24990},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 594, col: 37, offset: 17426},
+							pos:        position{line: 798, col: 37, offset: 24992},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 594, col: 41, offset: 17430},
+							pos:  position{line: 798, col: 41, offset: 24996},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 594, col: 43, offset: 17432},
+							pos:   position{line: 798, col: 43, offset: 24998},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 594, col: 49, offset: 17438},
+								pos:  position{line: 798, col: 49, offset: 25004},
 								name: "ArithmeticExpr",
 							},
 						},
@@ -2720,15 +3809,15 @@ var g = &grammar{
 		},
 		{
 			name: "Variable",
-			pos:  position{line: 601, col: 1, offset: 17550},
+			pos:  position{line: 805, col: 1, offset: 25116},
 			expr: &actionExpr{
-				pos: position{line: 601, col: 13, offset: 17562},
+				pos: position{line: 805, col: 13, offset: 25128},
 				run: (*parser).callonVariable1,
 				expr: &labeledExpr{
-					pos:   position{line: 601, col: 13, offset: 17562},
+					pos:   position{line: 805, col: 13, offset: 25128},
 					label: "name",
 					expr: &ruleRefExpr{
-						pos:  position{line: 601, col: 18, offset: 17567},
+						pos:  position{line: 805, col: 18, offset: 25133},
 						name: "IdentName",
 					},
 				},
@@ -2736,40 +3825,40 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayLiteral",
-			pos:  position{line: 608, col: 1, offset: 17678},
+			pos:  position{line: 813, col: 1, offset: 25272},
 			expr: &actionExpr{
-				pos: position{line: 608, col: 17, offset: 17694},
+				pos: position{line: 813, col: 17, offset: 25288},
 				run: (*parser).callonArrayLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 608, col: 17, offset: 17694},
+					pos: position{line: 813, col: 17, offset: 25288},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 608, col: 17, offset: 17694},
+							pos:        position{line: 813, col: 17, offset: 25288},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 608, col: 21, offset: 17698},
+							pos:  position{line: 813, col: 21, offset: 25292},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 608, col: 23, offset: 17700},
+							pos:   position{line: 813, col: 23, offset: 25294},
 							label: "elements",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 608, col: 32, offset: 17709},
+								pos: position{line: 813, col: 32, offset: 25303},
 								expr: &ruleRefExpr{
-									pos:  position{line: 608, col: 32, offset: 17709},
+									pos:  position{line: 813, col: 32, offset: 25303},
 									name: "ArrayElementList",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 608, col: 50, offset: 17727},
+							pos:  position{line: 813, col: 50, offset: 25321},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 608, col: 52, offset: 17729},
+							pos:        position{line: 813, col: 52, offset: 25323},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -2780,45 +3869,45 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayElementList",
-			pos:  position{line: 618, col: 1, offset: 17912},
+			pos:  position{line: 824, col: 1, offset: 25534},
 			expr: &actionExpr{
-				pos: position{line: 618, col: 21, offset: 17932},
+				pos: position{line: 824, col: 21, offset: 25554},
 				run: (*parser).callonArrayElementList1,
 				expr: &seqExpr{
-					pos: position{line: 618, col: 21, offset: 17932},
+					pos: position{line: 824, col: 21, offset: 25554},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 618, col: 21, offset: 17932},
+							pos:   position{line: 824, col: 21, offset: 25554},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 618, col: 27, offset: 17938},
+								pos:  position{line: 824, col: 27, offset: 25560},
 								name: "ArithmeticExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 618, col: 42, offset: 17953},
+							pos:   position{line: 824, col: 42, offset: 25575},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 618, col: 47, offset: 17958},
+								pos: position{line: 824, col: 47, offset: 25580},
 								expr: &seqExpr{
-									pos: position{line: 618, col: 48, offset: 17959},
+									pos: position{line: 824, col: 48, offset: 25581},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 618, col: 48, offset: 17959},
+											pos:  position{line: 824, col: 48, offset: 25581},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 618, col: 50, offset: 17961},
+											pos:        position{line: 824, col: 50, offset: 25583},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 618, col: 54, offset: 17965},
+											pos:  position{line: 824, col: 54, offset: 25587},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 618, col: 56, offset: 17967},
+											pos:  position{line: 824, col: 56, offset: 25589},
 											name: "ArithmeticExpr",
 										},
 									},
@@ -2831,40 +3920,40 @@ var g = &grammar{
 		},
 		{
 			name: "ObjectLiteral",
-			pos:  position{line: 628, col: 1, offset: 18205},
+			pos:  position{line: 834, col: 1, offset: 25827},
 			expr: &actionExpr{
-				pos: position{line: 628, col: 18, offset: 18222},
+				pos: position{line: 834, col: 18, offset: 25844},
 				run: (*parser).callonObjectLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 628, col: 18, offset: 18222},
+					pos: position{line: 834, col: 18, offset: 25844},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 628, col: 18, offset: 18222},
+							pos:        position{line: 834, col: 18, offset: 25844},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 628, col: 22, offset: 18226},
+							pos:  position{line: 834, col: 22, offset: 25848},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 628, col: 24, offset: 18228},
+							pos:   position{line: 834, col: 24, offset: 25850},
 							label: "fields",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 628, col: 31, offset: 18235},
+								pos: position{line: 834, col: 31, offset: 25857},
 								expr: &ruleRefExpr{
-									pos:  position{line: 628, col: 31, offset: 18235},
+									pos:  position{line: 834, col: 31, offset: 25857},
 									name: "ObjectFieldList",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 628, col: 48, offset: 18252},
+							pos:  position{line: 834, col: 48, offset: 25874},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 628, col: 50, offset: 18254},
+							pos:        position{line: 834, col: 50, offset: 25876},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -2875,45 +3964,45 @@ var g = &grammar{
 		},
 		{
 			name: "ObjectFieldList",
-			pos:  position{line: 638, col: 1, offset: 18430},
+			pos:  position{line: 845, col: 1, offset: 26080},
 			expr: &actionExpr{
-				pos: position{line: 638, col: 20, offset: 18449},
+				pos: position{line: 845, col: 20, offset: 26099},
 				run: (*parser).callonObjectFieldList1,
 				expr: &seqExpr{
-					pos: position{line: 638, col: 20, offset: 18449},
+					pos: position{line: 845, col: 20, offset: 26099},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 638, col: 20, offset: 18449},
+							pos:   position{line: 845, col: 20, offset: 26099},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 638, col: 26, offset: 18455},
+								pos:  position{line: 845, col: 26, offset: 26105},
 								name: "ObjectField",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 638, col: 38, offset: 18467},
+							pos:   position{line: 845, col: 38, offset: 26117},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 638, col: 43, offset: 18472},
+								pos: position{line: 845, col: 43, offset: 26122},
 								expr: &seqExpr{
-									pos: position{line: 638, col: 44, offset: 18473},
+									pos: position{line: 845, col: 44, offset: 26123},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 638, col: 44, offset: 18473},
+											pos:  position{line: 845, col: 44, offset: 26123},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 638, col: 46, offset: 18475},
+											pos:        position{line: 845, col: 46, offset: 26125},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 638, col: 50, offset: 18479},
+											pos:  position{line: 845, col: 50, offset: 26129},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 638, col: 52, offset: 18481},
+											pos:  position{line: 845, col: 52, offset: 26131},
 											name: "ObjectField",
 										},
 									},
@@ -2926,40 +4015,40 @@ var g = &grammar{
 		},
 		{
 			name: "ObjectField",
-			pos:  position{line: 648, col: 1, offset: 18708},
+			pos:  position{line: 855, col: 1, offset: 26358},
 			expr: &actionExpr{
-				pos: position{line: 648, col: 16, offset: 18723},
+				pos: position{line: 855, col: 16, offset: 26373},
 				run: (*parser).callonObjectField1,
 				expr: &seqExpr{
-					pos: position{line: 648, col: 16, offset: 18723},
+					pos: position{line: 855, col: 16, offset: 26373},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 648, col: 16, offset: 18723},
+							pos:   position{line: 855, col: 16, offset: 26373},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 648, col: 21, offset: 18728},
+								pos:  position{line: 855, col: 21, offset: 26378},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 648, col: 31, offset: 18738},
+							pos:  position{line: 855, col: 31, offset: 26388},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 648, col: 33, offset: 18740},
+							pos:        position{line: 855, col: 33, offset: 26390},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 648, col: 37, offset: 18744},
+							pos:  position{line: 855, col: 37, offset: 26394},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 648, col: 39, offset: 18746},
+							pos:   position{line: 855, col: 39, offset: 26396},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 648, col: 45, offset: 18752},
+								pos:  position{line: 855, col: 45, offset: 26402},
 								name: "ArithmeticExpr",
 							},
 						},
@@ -2969,52 +4058,52 @@ var g = &grammar{
 		},
 		{
 			name: "FunctionCall",
-			pos:  position{line: 655, col: 1, offset: 18864},
+			pos:  position{line: 862, col: 1, offset: 26514},
 			expr: &actionExpr{
-				pos: position{line: 655, col: 17, offset: 18880},
+				pos: position{line: 862, col: 17, offset: 26530},
 				run: (*parser).callonFunctionCall1,
 				expr: &seqExpr{
-					pos: position{line: 655, col: 17, offset: 18880},
+					pos: position{line: 862, col: 17, offset: 26530},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 655, col: 17, offset: 18880},
+							pos:   position{line: 862, col: 17, offset: 26530},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 655, col: 22, offset: 18885},
+								pos:  position{line: 862, col: 22, offset: 26535},
 								name: "FunctionName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 655, col: 35, offset: 18898},
+							pos:  position{line: 862, col: 35, offset: 26548},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 655, col: 37, offset: 18900},
+							pos:        position{line: 862, col: 37, offset: 26550},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 655, col: 41, offset: 18904},
+							pos:  position{line: 862, col: 41, offset: 26554},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 655, col: 43, offset: 18906},
+							pos:   position{line: 862, col: 43, offset: 26556},
 							label: "args",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 655, col: 48, offset: 18911},
+								pos: position{line: 862, col: 48, offset: 26561},
 								expr: &ruleRefExpr{
-									pos:  position{line: 655, col: 48, offset: 18911},
+									pos:  position{line: 862, col: 48, offset: 26561},
 									name: "FunctionArgList",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 655, col: 65, offset: 18928},
+							pos:  position{line: 862, col: 65, offset: 26578},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 655, col: 67, offset: 18930},
+							pos:        position{line: 862, col: 67, offset: 26580},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -3025,30 +4114,30 @@ var g = &grammar{
 		},
 		{
 			name: "FunctionName",
-			pos:  position{line: 666, col: 1, offset: 19119},
+			pos:  position{line: 874, col: 1, offset: 26797},
 			expr: &choiceExpr{
-				pos: position{line: 666, col: 17, offset: 19135},
+				pos: position{line: 874, col: 17, offset: 26813},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 666, col: 17, offset: 19135},
+						pos: position{line: 874, col: 17, offset: 26813},
 						run: (*parser).callonFunctionName2,
 						expr: &choiceExpr{
-							pos: position{line: 666, col: 18, offset: 19136},
+							pos: position{line: 874, col: 18, offset: 26814},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 666, col: 18, offset: 19136},
+									pos:        position{line: 874, col: 18, offset: 26814},
 									val:        "LENGTH",
 									ignoreCase: false,
 									want:       "\"LENGTH\"",
 								},
 								&litMatcher{
-									pos:        position{line: 666, col: 29, offset: 19147},
+									pos:        position{line: 874, col: 29, offset: 26825},
 									val:        "length",
 									ignoreCase: false,
 									want:       "\"length\"",
 								},
 								&litMatcher{
-									pos:        position{line: 666, col: 40, offset: 19158},
+									pos:        position{line: 874, col: 40, offset: 26836},
 									val:        "Length",
 									ignoreCase: false,
 									want:       "\"Length\"",
@@ -3057,25 +4146,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 667, col: 17, offset: 19211},
+						pos: position{line: 875, col: 17, offset: 26889},
 						run: (*parser).callonFunctionName7,
 						expr: &choiceExpr{
-							pos: position{line: 667, col: 18, offset: 19212},
+							pos: position{line: 875, col: 18, offset: 26890},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 667, col: 18, offset: 19212},
+									pos:        position{line: 875, col: 18, offset: 26890},
 									val:        "SUBSTRING",
 									ignoreCase: false,
 									want:       "\"SUBSTRING\"",
 								},
 								&litMatcher{
-									pos:        position{line: 667, col: 32, offset: 19226},
+									pos:        position{line: 875, col: 32, offset: 26904},
 									val:        "substring",
 									ignoreCase: false,
 									want:       "\"substring\"",
 								},
 								&litMatcher{
-									pos:        position{line: 667, col: 46, offset: 19240},
+									pos:        position{line: 875, col: 46, offset: 26918},
 									val:        "Substring",
 									ignoreCase: false,
 									want:       "\"Substring\"",
@@ -3084,25 +4173,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 668, col: 17, offset: 19299},
+						pos: position{line: 876, col: 17, offset: 26977},
 						run: (*parser).callonFunctionName12,
 						expr: &choiceExpr{
-							pos: position{line: 668, col: 18, offset: 19300},
+							pos: position{line: 876, col: 18, offset: 26978},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 668, col: 18, offset: 19300},
+									pos:        position{line: 876, col: 18, offset: 26978},
 									val:        "UPPER",
 									ignoreCase: false,
 									want:       "\"UPPER\"",
 								},
 								&litMatcher{
-									pos:        position{line: 668, col: 28, offset: 19310},
+									pos:        position{line: 876, col: 28, offset: 26988},
 									val:        "upper",
 									ignoreCase: false,
 									want:       "\"upper\"",
 								},
 								&litMatcher{
-									pos:        position{line: 668, col: 38, offset: 19320},
+									pos:        position{line: 876, col: 38, offset: 26998},
 									val:        "Upper",
 									ignoreCase: false,
 									want:       "\"Upper\"",
@@ -3111,25 +4200,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 669, col: 17, offset: 19371},
+						pos: position{line: 877, col: 17, offset: 27049},
 						run: (*parser).callonFunctionName17,
 						expr: &choiceExpr{
-							pos: position{line: 669, col: 18, offset: 19372},
+							pos: position{line: 877, col: 18, offset: 27050},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 669, col: 18, offset: 19372},
+									pos:        position{line: 877, col: 18, offset: 27050},
 									val:        "LOWER",
 									ignoreCase: false,
 									want:       "\"LOWER\"",
 								},
 								&litMatcher{
-									pos:        position{line: 669, col: 28, offset: 19382},
+									pos:        position{line: 877, col: 28, offset: 27060},
 									val:        "lower",
 									ignoreCase: false,
 									want:       "\"lower\"",
 								},
 								&litMatcher{
-									pos:        position{line: 669, col: 38, offset: 19392},
+									pos:        position{line: 877, col: 38, offset: 27070},
 									val:        "Lower",
 									ignoreCase: false,
 									want:       "\"Lower\"",
@@ -3138,25 +4227,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 670, col: 17, offset: 19443},
+						pos: position{line: 878, col: 17, offset: 27121},
 						run: (*parser).callonFunctionName22,
 						expr: &choiceExpr{
-							pos: position{line: 670, col: 18, offset: 19444},
+							pos: position{line: 878, col: 18, offset: 27122},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 670, col: 18, offset: 19444},
+									pos:        position{line: 878, col: 18, offset: 27122},
 									val:        "TRIM",
 									ignoreCase: false,
 									want:       "\"TRIM\"",
 								},
 								&litMatcher{
-									pos:        position{line: 670, col: 27, offset: 19453},
+									pos:        position{line: 878, col: 27, offset: 27131},
 									val:        "trim",
 									ignoreCase: false,
 									want:       "\"trim\"",
 								},
 								&litMatcher{
-									pos:        position{line: 670, col: 36, offset: 19462},
+									pos:        position{line: 878, col: 36, offset: 27140},
 									val:        "Trim",
 									ignoreCase: false,
 									want:       "\"Trim\"",
@@ -3165,25 +4254,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 671, col: 17, offset: 19511},
+						pos: position{line: 879, col: 17, offset: 27189},
 						run: (*parser).callonFunctionName27,
 						expr: &choiceExpr{
-							pos: position{line: 671, col: 18, offset: 19512},
+							pos: position{line: 879, col: 18, offset: 27190},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 671, col: 18, offset: 19512},
+									pos:        position{line: 879, col: 18, offset: 27190},
 									val:        "ABS",
 									ignoreCase: false,
 									want:       "\"ABS\"",
 								},
 								&litMatcher{
-									pos:        position{line: 671, col: 26, offset: 19520},
+									pos:        position{line: 879, col: 26, offset: 27198},
 									val:        "abs",
 									ignoreCase: false,
 									want:       "\"abs\"",
 								},
 								&litMatcher{
-									pos:        position{line: 671, col: 34, offset: 19528},
+									pos:        position{line: 879, col: 34, offset: 27206},
 									val:        "Abs",
 									ignoreCase: false,
 									want:       "\"Abs\"",
@@ -3192,25 +4281,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 672, col: 17, offset: 19575},
+						pos: position{line: 880, col: 17, offset: 27253},
 						run: (*parser).callonFunctionName32,
 						expr: &choiceExpr{
-							pos: position{line: 672, col: 18, offset: 19576},
+							pos: position{line: 880, col: 18, offset: 27254},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 672, col: 18, offset: 19576},
+									pos:        position{line: 880, col: 18, offset: 27254},
 									val:        "ROUND",
 									ignoreCase: false,
 									want:       "\"ROUND\"",
 								},
 								&litMatcher{
-									pos:        position{line: 672, col: 28, offset: 19586},
+									pos:        position{line: 880, col: 28, offset: 27264},
 									val:        "round",
 									ignoreCase: false,
 									want:       "\"round\"",
 								},
 								&litMatcher{
-									pos:        position{line: 672, col: 38, offset: 19596},
+									pos:        position{line: 880, col: 38, offset: 27274},
 									val:        "Round",
 									ignoreCase: false,
 									want:       "\"Round\"",
@@ -3219,25 +4308,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 673, col: 17, offset: 19647},
+						pos: position{line: 881, col: 17, offset: 27325},
 						run: (*parser).callonFunctionName37,
 						expr: &choiceExpr{
-							pos: position{line: 673, col: 18, offset: 19648},
+							pos: position{line: 881, col: 18, offset: 27326},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 673, col: 18, offset: 19648},
+									pos:        position{line: 881, col: 18, offset: 27326},
 									val:        "FLOOR",
 									ignoreCase: false,
 									want:       "\"FLOOR\"",
 								},
 								&litMatcher{
-									pos:        position{line: 673, col: 28, offset: 19658},
+									pos:        position{line: 881, col: 28, offset: 27336},
 									val:        "floor",
 									ignoreCase: false,
 									want:       "\"floor\"",
 								},
 								&litMatcher{
-									pos:        position{line: 673, col: 38, offset: 19668},
+									pos:        position{line: 881, col: 38, offset: 27346},
 									val:        "Floor",
 									ignoreCase: false,
 									want:       "\"Floor\"",
@@ -3246,25 +4335,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 674, col: 17, offset: 19719},
+						pos: position{line: 882, col: 17, offset: 27397},
 						run: (*parser).callonFunctionName42,
 						expr: &choiceExpr{
-							pos: position{line: 674, col: 18, offset: 19720},
+							pos: position{line: 882, col: 18, offset: 27398},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 674, col: 18, offset: 19720},
+									pos:        position{line: 882, col: 18, offset: 27398},
 									val:        "CEIL",
 									ignoreCase: false,
 									want:       "\"CEIL\"",
 								},
 								&litMatcher{
-									pos:        position{line: 674, col: 27, offset: 19729},
+									pos:        position{line: 882, col: 27, offset: 27407},
 									val:        "ceil",
 									ignoreCase: false,
 									want:       "\"ceil\"",
 								},
 								&litMatcher{
-									pos:        position{line: 674, col: 36, offset: 19738},
+									pos:        position{line: 882, col: 36, offset: 27416},
 									val:        "Ceil",
 									ignoreCase: false,
 									want:       "\"Ceil\"",
@@ -3277,45 +4366,45 @@ var g = &grammar{
 		},
 		{
 			name: "FunctionArgList",
-			pos:  position{line: 676, col: 1, offset: 19770},
+			pos:  position{line: 884, col: 1, offset: 27448},
 			expr: &actionExpr{
-				pos: position{line: 676, col: 20, offset: 19789},
+				pos: position{line: 884, col: 20, offset: 27467},
 				run: (*parser).callonFunctionArgList1,
 				expr: &seqExpr{
-					pos: position{line: 676, col: 20, offset: 19789},
+					pos: position{line: 884, col: 20, offset: 27467},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 676, col: 20, offset: 19789},
+							pos:   position{line: 884, col: 20, offset: 27467},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 676, col: 26, offset: 19795},
+								pos:  position{line: 884, col: 26, offset: 27473},
 								name: "ArithmeticExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 676, col: 41, offset: 19810},
+							pos:   position{line: 884, col: 41, offset: 27488},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 676, col: 46, offset: 19815},
+								pos: position{line: 884, col: 46, offset: 27493},
 								expr: &seqExpr{
-									pos: position{line: 676, col: 47, offset: 19816},
+									pos: position{line: 884, col: 47, offset: 27494},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 676, col: 47, offset: 19816},
+											pos:  position{line: 884, col: 47, offset: 27494},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 676, col: 49, offset: 19818},
+											pos:        position{line: 884, col: 49, offset: 27496},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 676, col: 53, offset: 19822},
+											pos:  position{line: 884, col: 53, offset: 27500},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 676, col: 55, offset: 19824},
+											pos:  position{line: 884, col: 55, offset: 27502},
 											name: "ArithmeticExpr",
 										},
 									},
@@ -3328,46 +4417,256 @@ var g = &grammar{
 		},
 		{
 			name: "Action",
-			pos:  position{line: 686, col: 1, offset: 20046},
+			pos:  position{line: 894, col: 1, offset: 27724},
 			expr: &actionExpr{
-				pos: position{line: 686, col: 11, offset: 20056},
+				pos: position{line: 894, col: 11, offset: 27734},
 				run: (*parser).callonAction1,
+				expr: &labeledExpr{
+					pos:   position{line: 894, col: 11, offset: 27734},
+					label: "jobs",
+					expr: &ruleRefExpr{
+						pos:  position{line: 894, col: 16, offset: 27739},
+						name: "ActionStatements",
+					},
+				},
+			},
+		},
+		{
+			name: "ActionStatements",
+			pos:  position{line: 901, col: 1, offset: 27855},
+			expr: &actionExpr{
+				pos: position{line: 901, col: 21, offset: 27875},
+				run: (*parser).callonActionStatements1,
 				expr: &seqExpr{
-					pos: position{line: 686, col: 11, offset: 20056},
+					pos: position{line: 901, col: 21, offset: 27875},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 686, col: 11, offset: 20056},
+							pos:   position{line: 901, col: 21, offset: 27875},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 686, col: 17, offset: 20062},
-								name: "JobCall",
+								pos:  position{line: 901, col: 27, offset: 27881},
+								name: "ActionStatement",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 686, col: 25, offset: 20070},
+							pos:   position{line: 901, col: 43, offset: 27897},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 686, col: 30, offset: 20075},
+								pos: position{line: 901, col: 48, offset: 27902},
 								expr: &seqExpr{
-									pos: position{line: 686, col: 31, offset: 20076},
+									pos: position{line: 901, col: 49, offset: 27903},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 686, col: 31, offset: 20076},
+											pos:  position{line: 901, col: 49, offset: 27903},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 686, col: 33, offset: 20078},
+											pos:        position{line: 901, col: 51, offset: 27905},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 686, col: 37, offset: 20082},
+											pos:  position{line: 901, col: 55, offset: 27909},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 901, col: 57, offset: 27911},
+											name: "ActionStatement",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ActionStatement",
+			pos:  position{line: 911, col: 1, offset: 28134},
+			expr: &choiceExpr{
+				pos: position{line: 911, col: 20, offset: 28153},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 911, col: 20, offset: 28153},
+						name: "LetBinding",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 911, col: 33, offset: 28166},
+						name: "IfBlock",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 911, col: 43, offset: 28176},
+						name: "JobCall",
+					},
+				},
+			},
+		},
+		{
+			name: "LetBinding",
+			pos:  position{line: 913, col: 1, offset: 28185},
+			expr: &actionExpr{
+				pos: position{line: 913, col: 15, offset: 28199},
+				run: (*parser).callonLetBinding1,
+				expr: &seqExpr{
+					pos: position{line: 913, col: 15, offset: 28199},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 913, col: 15, offset: 28199},
+							val:        "let",
+							ignoreCase: false,
+							want:       "\"let\"",
+						},
+						&notExpr{
+							pos: position{line: 913, col: 21, offset: 28205},
+							expr: &ruleRefExpr{
+								pos:  position{line: 913, col: 22, offset: 28206},
+								name: "IdentContinue",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 913, col: 36, offset: 28220},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 913, col: 38, offset: 28222},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 913, col: 43, offset: 28227},
+								name: "IdentName",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 913, col: 53, offset: 28237},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 913, col: 55, offset: 28239},
+							val:        "=",
+							ignoreCase: false,
+							want:       "\"=\"",
+						},
+						&notExpr{
+							pos: position{line: 913, col: 59, offset: 28243},
+							expr: &litMatcher{
+								pos:        position{line: 913, col: 60, offset: 28244},
+								val:        "=",
+								ignoreCase: false,
+								want:       "\"=\"",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 913, col: 64, offset: 28248},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 913, col: 66, offset: 28250},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 913, col: 72, offset: 28256},
+								name: "ArithmeticExpr",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IfBlock",
+			pos:  position{line: 922, col: 1, offset: 28426},
+			expr: &actionExpr{
+				pos: position{line: 922, col: 12, offset: 28437},
+				run: (*parser).callonIfBlock1,
+				expr: &seqExpr{
+					pos: position{line: 922, col: 12, offset: 28437},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 922, col: 12, offset: 28437},
+							val:        "if",
+							ignoreCase: false,
+							want:       "\"if\"",
+						},
+						&notExpr{
+							pos: position{line: 922, col: 17, offset: 28442},
+							expr: &ruleRefExpr{
+								pos:  position{line: 922, col: 18, offset: 28443},
+								name: "IdentContinue",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 922, col: 32, offset: 28457},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 922, col: 34, offset: 28459},
+							label: "condition",
+							expr: &ruleRefExpr{
+								pos:  position{line: 922, col: 44, offset: 28469},
+								name: "Constraints",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 922, col: 56, offset: 28481},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 922, col: 58, offset: 28483},
+							val:        "then",
+							ignoreCase: false,
+							want:       "\"then\"",
+						},
+						&notExpr{
+							pos: position{line: 922, col: 65, offset: 28490},
+							expr: &ruleRefExpr{
+								pos:  position{line: 922, col: 66, offset: 28491},
+								name: "IdentContinue",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 922, col: 80, offset: 28505},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 922, col: 82, offset: 28507},
+							label: "then",
+							expr: &ruleRefExpr{
+								pos:  position{line: 922, col: 87, offset: 28512},
+								name: "ActionBlock",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 922, col: 99, offset: 28524},
+							label: "elseBlock",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 922, col: 109, offset: 28534},
+								expr: &seqExpr{
+									pos: position{line: 922, col: 110, offset: 28535},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 922, col: 110, offset: 28535},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 922, col: 112, offset: 28537},
+											val:        "else",
+											ignoreCase: false,
+											want:       "\"else\"",
+										},
+										&notExpr{
+											pos: position{line: 922, col: 119, offset: 28544},
+											expr: &ruleRefExpr{
+												pos:  position{line: 922, col: 120, offset: 28545},
+												name: "IdentContinue",
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 922, col: 134, offset: 28559},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 686, col: 39, offset: 20084},
-											name: "JobCall",
+											pos:  position{line: 922, col: 136, offset: 28561},
+											name: "ActionBlock",
 										},
 									},
 								},
@@ -3377,54 +4676,112 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "ActionBlock",
+			pos:  position{line: 935, col: 1, offset: 28842},
+			expr: &choiceExpr{
+				pos: position{line: 935, col: 16, offset: 28857},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 935, col: 16, offset: 28857},
+						run: (*parser).callonActionBlock2,
+						expr: &seqExpr{
+							pos: position{line: 935, col: 16, offset: 28857},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 935, col: 16, offset: 28857},
+									val:        "{",
+									ignoreCase: false,
+									want:       "\"{\"",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 935, col: 20, offset: 28861},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 935, col: 22, offset: 28863},
+									label: "jobs",
+									expr: &ruleRefExpr{
+										pos:  position{line: 935, col: 27, offset: 28868},
+										name: "ActionStatements",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 935, col: 44, offset: 28885},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 935, col: 46, offset: 28887},
+									val:        "}",
+									ignoreCase: false,
+									want:       "\"}\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 936, col: 16, offset: 28929},
+						run: (*parser).callonActionBlock10,
+						expr: &labeledExpr{
+							pos:   position{line: 936, col: 16, offset: 28929},
+							label: "job",
+							expr: &ruleRefExpr{
+								pos:  position{line: 936, col: 20, offset: 28933},
+								name: "ActionStatement",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "JobCall",
-			pos:  position{line: 699, col: 1, offset: 20372},
+			pos:  position{line: 938, col: 1, offset: 28985},
 			expr: &actionExpr{
-				pos: position{line: 699, col: 12, offset: 20383},
+				pos: position{line: 938, col: 12, offset: 28996},
 				run: (*parser).callonJobCall1,
 				expr: &seqExpr{
-					pos: position{line: 699, col: 12, offset: 20383},
+					pos: position{line: 938, col: 12, offset: 28996},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 699, col: 12, offset: 20383},
+							pos:   position{line: 938, col: 12, offset: 28996},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 699, col: 17, offset: 20388},
-								name: "IdentName",
+								pos:  position{line: 938, col: 17, offset: 29001},
+								name: "QualifiedName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 699, col: 27, offset: 20398},
+							pos:  position{line: 938, col: 31, offset: 29015},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 699, col: 29, offset: 20400},
+							pos:        position{line: 938, col: 33, offset: 29017},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 699, col: 33, offset: 20404},
+							pos:  position{line: 938, col: 37, offset: 29021},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 699, col: 35, offset: 20406},
+							pos:   position{line: 938, col: 39, offset: 29023},
 							label: "args",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 699, col: 40, offset: 20411},
+								pos: position{line: 938, col: 44, offset: 29028},
 								expr: &ruleRefExpr{
-									pos:  position{line: 699, col: 40, offset: 20411},
+									pos:  position{line: 938, col: 44, offset: 29028},
 									name: "ArgumentList",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 699, col: 54, offset: 20425},
+							pos:  position{line: 938, col: 58, offset: 29042},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 699, col: 56, offset: 20427},
+							pos:        position{line: 938, col: 60, offset: 29044},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -3435,45 +4792,45 @@ var g = &grammar{
 		},
 		{
 			name: "ArgumentList",
-			pos:  position{line: 751, col: 1, offset: 22384},
+			pos:  position{line: 992, col: 1, offset: 31073},
 			expr: &actionExpr{
-				pos: position{line: 751, col: 17, offset: 22400},
+				pos: position{line: 992, col: 17, offset: 31089},
 				run: (*parser).callonArgumentList1,
 				expr: &seqExpr{
-					pos: position{line: 751, col: 17, offset: 22400},
+					pos: position{line: 992, col: 17, offset: 31089},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 751, col: 17, offset: 22400},
+							pos:   position{line: 992, col: 17, offset: 31089},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 751, col: 23, offset: 22406},
+								pos:  position{line: 992, col: 23, offset: 31095},
 								name: "ArithmeticExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 751, col: 38, offset: 22421},
+							pos:   position{line: 992, col: 38, offset: 31110},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 751, col: 43, offset: 22426},
+								pos: position{line: 992, col: 43, offset: 31115},
 								expr: &seqExpr{
-									pos: position{line: 751, col: 44, offset: 22427},
+									pos: position{line: 992, col: 44, offset: 31116},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 751, col: 44, offset: 22427},
+											pos:  position{line: 992, col: 44, offset: 31116},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 751, col: 46, offset: 22429},
+											pos:        position{line: 992, col: 46, offset: 31118},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 751, col: 50, offset: 22433},
+											pos:  position{line: 992, col: 50, offset: 31122},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 751, col: 52, offset: 22435},
+											pos:  position{line: 992, col: 52, offset: 31124},
 											name: "ArithmeticExpr",
 										},
 									},
@@ -3486,90 +4843,90 @@ var g = &grammar{
 		},
 		{
 			name: "ComparisonOp",
-			pos:  position{line: 761, col: 1, offset: 22677},
+			pos:  position{line: 1002, col: 1, offset: 31366},
 			expr: &choiceExpr{
-				pos: position{line: 761, col: 17, offset: 22693},
+				pos: position{line: 1002, col: 17, offset: 31382},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 761, col: 17, offset: 22693},
+						pos: position{line: 1002, col: 17, offset: 31382},
 						run: (*parser).callonComparisonOp2,
 						expr: &litMatcher{
-							pos:        position{line: 761, col: 17, offset: 22693},
+							pos:        position{line: 1002, col: 17, offset: 31382},
 							val:        "==",
 							ignoreCase: false,
 							want:       "\"==\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 762, col: 17, offset: 22737},
+						pos: position{line: 1003, col: 17, offset: 31426},
 						run: (*parser).callonComparisonOp4,
 						expr: &litMatcher{
-							pos:        position{line: 762, col: 17, offset: 22737},
+							pos:        position{line: 1003, col: 17, offset: 31426},
 							val:        "!=",
 							ignoreCase: false,
 							want:       "\"!=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 763, col: 17, offset: 22781},
+						pos: position{line: 1004, col: 17, offset: 31470},
 						run: (*parser).callonComparisonOp6,
 						expr: &litMatcher{
-							pos:        position{line: 763, col: 17, offset: 22781},
+							pos:        position{line: 1004, col: 17, offset: 31470},
 							val:        "<=",
 							ignoreCase: false,
 							want:       "\"<=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 764, col: 17, offset: 22825},
+						pos: position{line: 1005, col: 17, offset: 31514},
 						run: (*parser).callonComparisonOp8,
 						expr: &litMatcher{
-							pos:        position{line: 764, col: 17, offset: 22825},
+							pos:        position{line: 1005, col: 17, offset: 31514},
 							val:        ">=",
 							ignoreCase: false,
 							want:       "\">=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 765, col: 17, offset: 22869},
+						pos: position{line: 1006, col: 17, offset: 31558},
 						run: (*parser).callonComparisonOp10,
 						expr: &litMatcher{
-							pos:        position{line: 765, col: 17, offset: 22869},
+							pos:        position{line: 1006, col: 17, offset: 31558},
 							val:        "<",
 							ignoreCase: false,
 							want:       "\"<\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 766, col: 17, offset: 22912},
+						pos: position{line: 1007, col: 17, offset: 31601},
 						run: (*parser).callonComparisonOp12,
 						expr: &litMatcher{
-							pos:        position{line: 766, col: 17, offset: 22912},
+							pos:        position{line: 1007, col: 17, offset: 31601},
 							val:        ">",
 							ignoreCase: false,
 							want:       "\">\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 767, col: 17, offset: 22955},
+						pos: position{line: 1008, col: 17, offset: 31644},
 						run: (*parser).callonComparisonOp14,
 						expr: &choiceExpr{
-							pos: position{line: 767, col: 18, offset: 22956},
+							pos: position{line: 1008, col: 18, offset: 31645},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 767, col: 18, offset: 22956},
+									pos:        position{line: 1008, col: 18, offset: 31645},
 									val:        "IN",
 									ignoreCase: false,
 									want:       "\"IN\"",
 								},
 								&litMatcher{
-									pos:        position{line: 767, col: 25, offset: 22963},
+									pos:        position{line: 1008, col: 25, offset: 31652},
 									val:        "in",
 									ignoreCase: false,
 									want:       "\"in\"",
 								},
 								&litMatcher{
-									pos:        position{line: 767, col: 32, offset: 22970},
+									pos:        position{line: 1008, col: 32, offset: 31659},
 									val:        "In",
 									ignoreCase: false,
 									want:       "\"In\"",
@@ -3578,25 +4935,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 768, col: 17, offset: 23015},
+						pos: position{line: 1009, col: 17, offset: 31704},
 						run: (*parser).callonComparisonOp19,
 						expr: &choiceExpr{
-							pos: position{line: 768, col: 18, offset: 23016},
+							pos: position{line: 1009, col: 18, offset: 31705},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 768, col: 18, offset: 23016},
+									pos:        position{line: 1009, col: 18, offset: 31705},
 									val:        "LIKE",
 									ignoreCase: false,
 									want:       "\"LIKE\"",
 								},
 								&litMatcher{
-									pos:        position{line: 768, col: 27, offset: 23025},
+									pos:        position{line: 1009, col: 27, offset: 31714},
 									val:        "like",
 									ignoreCase: false,
 									want:       "\"like\"",
 								},
 								&litMatcher{
-									pos:        position{line: 768, col: 36, offset: 23034},
+									pos:        position{line: 1009, col: 36, offset: 31723},
 									val:        "Like",
 									ignoreCase: false,
 									want:       "\"Like\"",
@@ -3605,25 +4962,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 769, col: 17, offset: 23083},
+						pos: position{line: 1010, col: 17, offset: 31772},
 						run: (*parser).callonComparisonOp24,
 						expr: &choiceExpr{
-							pos: position{line: 769, col: 18, offset: 23084},
+							pos: position{line: 1010, col: 18, offset: 31773},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 769, col: 18, offset: 23084},
+									pos:        position{line: 1010, col: 18, offset: 31773},
 									val:        "MATCHES",
 									ignoreCase: false,
 									want:       "\"MATCHES\"",
 								},
 								&litMatcher{
-									pos:        position{line: 769, col: 30, offset: 23096},
+									pos:        position{line: 1010, col: 30, offset: 31785},
 									val:        "matches",
 									ignoreCase: false,
 									want:       "\"matches\"",
 								},
 								&litMatcher{
-									pos:        position{line: 769, col: 42, offset: 23108},
+									pos:        position{line: 1010, col: 42, offset: 31797},
 									val:        "Matches",
 									ignoreCase: false,
 									want:       "\"Matches\"",
@@ -3632,25 +4989,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 770, col: 17, offset: 23163},
+						pos: position{line: 1011, col: 17, offset: 31852},
 						run: (*parser).callonComparisonOp29,
 						expr: &choiceExpr{
-							pos: position{line: 770, col: 18, offset: 23164},
+							pos: position{line: 1011, col: 18, offset: 31853},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 770, col: 18, offset: 23164},
+									pos:        position{line: 1011, col: 18, offset: 31853},
 									val:        "CONTAINS",
 									ignoreCase: false,
 									want:       "\"CONTAINS\"",
 								},
 								&litMatcher{
-									pos:        position{line: 770, col: 31, offset: 23177},
+									pos:        position{line: 1011, col: 31, offset: 31866},
 									val:        "contains",
 									ignoreCase: false,
 									want:       "\"contains\"",
 								},
 								&litMatcher{
-									pos:        position{line: 770, col: 44, offset: 23190},
+									pos:        position{line: 1011, col: 44, offset: 31879},
 									val:        "Contains",
 									ignoreCase: false,
 									want:       "\"Contains\"",
@@ -3663,30 +5020,30 @@ var g = &grammar{
 		},
 		{
 			name: "LogicalOp",
-			pos:  position{line: 772, col: 1, offset: 23230},
+			pos:  position{line: 1013, col: 1, offset: 31919},
 			expr: &choiceExpr{
-				pos: position{line: 772, col: 14, offset: 23243},
+				pos: position{line: 1013, col: 14, offset: 31932},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 772, col: 14, offset: 23243},
+						pos: position{line: 1013, col: 14, offset: 31932},
 						run: (*parser).callonLogicalOp2,
 						expr: &choiceExpr{
-							pos: position{line: 772, col: 15, offset: 23244},
+							pos: position{line: 1013, col: 15, offset: 31933},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 772, col: 15, offset: 23244},
+									pos:        position{line: 1013, col: 15, offset: 31933},
 									val:        "AND",
 									ignoreCase: false,
 									want:       "\"AND\"",
 								},
 								&litMatcher{
-									pos:        position{line: 772, col: 23, offset: 23252},
+									pos:        position{line: 1013, col: 23, offset: 31941},
 									val:        "and",
 									ignoreCase: false,
 									want:       "\"and\"",
 								},
 								&litMatcher{
-									pos:        position{line: 772, col: 31, offset: 23260},
+									pos:        position{line: 1013, col: 31, offset: 31949},
 									val:        "And",
 									ignoreCase: false,
 									want:       "\"And\"",
@@ -3695,25 +5052,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 773, col: 14, offset: 23304},
+						pos: position{line: 1014, col: 14, offset: 31993},
 						run: (*parser).callonLogicalOp7,
 						expr: &choiceExpr{
-							pos: position{line: 773, col: 15, offset: 23305},
+							pos: position{line: 1014, col: 15, offset: 31994},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 773, col: 15, offset: 23305},
+									pos:        position{line: 1014, col: 15, offset: 31994},
 									val:        "OR",
 									ignoreCase: false,
 									want:       "\"OR\"",
 								},
 								&litMatcher{
-									pos:        position{line: 773, col: 22, offset: 23312},
+									pos:        position{line: 1014, col: 22, offset: 32001},
 									val:        "or",
 									ignoreCase: false,
 									want:       "\"or\"",
 								},
 								&litMatcher{
-									pos:        position{line: 773, col: 29, offset: 23319},
+									pos:        position{line: 1014, col: 29, offset: 32008},
 									val:        "Or",
 									ignoreCase: false,
 									want:       "\"Or\"",
@@ -3726,25 +5083,25 @@ var g = &grammar{
 		},
 		{
 			name: "BooleanLiteral",
-			pos:  position{line: 775, col: 1, offset: 23348},
+			pos:  position{line: 1016, col: 1, offset: 32037},
 			expr: &choiceExpr{
-				pos: position{line: 775, col: 19, offset: 23366},
+				pos: position{line: 1016, col: 19, offset: 32055},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 775, col: 19, offset: 23366},
+						pos: position{line: 1016, col: 19, offset: 32055},
 						run: (*parser).callonBooleanLiteral2,
 						expr: &litMatcher{
-							pos:        position{line: 775, col: 19, offset: 23366},
+							pos:        position{line: 1016, col: 19, offset: 32055},
 							val:        "true",
 							ignoreCase: false,
 							want:       "\"true\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 781, col: 5, offset: 23499},
+						pos: position{line: 1023, col: 5, offset: 32220},
 						run: (*parser).callonBooleanLiteral4,
 						expr: &litMatcher{
-							pos:        position{line: 781, col: 5, offset: 23499},
+							pos:        position{line: 1023, col: 5, offset: 32220},
 							val:        "false",
 							ignoreCase: false,
 							want:       "\"false\"",
@@ -3755,17 +5112,17 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 788, col: 1, offset: 23629},
+			pos:  position{line: 1031, col: 1, offset: 32382},
 			expr: &actionExpr{
-				pos: position{line: 788, col: 12, offset: 23640},
+				pos: position{line: 1031, col: 12, offset: 32393},
 				run: (*parser).callonInteger1,
 				expr: &labeledExpr{
-					pos:   position{line: 788, col: 12, offset: 23640},
+					pos:   position{line: 1031, col: 12, offset: 32393},
 					label: "digits",
 					expr: &oneOrMoreExpr{
-						pos: position{line: 788, col: 19, offset: 23647},
+						pos: position{line: 1031, col: 19, offset: 32400},
 						expr: &charClassMatcher{
-							pos:        position{line: 788, col: 19, offset: 23647},
+							pos:        position{line: 1031, col: 19, offset: 32400},
 							val:        "[0-9]",
 							ranges:     []rune{'0', '9'},
 							ignoreCase: false,
@@ -3777,20 +5134,20 @@ var g = &grammar{
 		},
 		{
 			name: "Number",
-			pos:  position{line: 796, col: 1, offset: 23774},
+			pos:  position{line: 1039, col: 1, offset: 32527},
 			expr: &actionExpr{
-				pos: position{line: 796, col: 11, offset: 23784},
+				pos: position{line: 1039, col: 11, offset: 32537},
 				run: (*parser).callonNumber1,
 				expr: &seqExpr{
-					pos: position{line: 796, col: 11, offset: 23784},
+					pos: position{line: 1039, col: 11, offset: 32537},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 796, col: 11, offset: 23784},
+							pos:   position{line: 1039, col: 11, offset: 32537},
 							label: "sign",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 796, col: 16, offset: 23789},
+								pos: position{line: 1039, col: 16, offset: 32542},
 								expr: &litMatcher{
-									pos:        position{line: 796, col: 16, offset: 23789},
+									pos:        position{line: 1039, col: 16, offset: 32542},
 									val:        "-",
 									ignoreCase: false,
 									want:       "\"-\"",
@@ -3798,12 +5155,12 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 796, col: 21, offset: 23794},
+							pos:   position{line: 1039, col: 21, offset: 32547},
 							label: "digits",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 796, col: 28, offset: 23801},
+								pos: position{line: 1039, col: 28, offset: 32554},
 								expr: &charClassMatcher{
-									pos:        position{line: 796, col: 28, offset: 23801},
+									pos:        position{line: 1039, col: 28, offset: 32554},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
@@ -3812,23 +5169,23 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 796, col: 35, offset: 23808},
+							pos:   position{line: 1039, col: 35, offset: 32561},
 							label: "decimal",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 796, col: 43, offset: 23816},
+								pos: position{line: 1039, col: 43, offset: 32569},
 								expr: &seqExpr{
-									pos: position{line: 796, col: 44, offset: 23817},
+									pos: position{line: 1039, col: 44, offset: 32570},
 									exprs: []any{
 										&litMatcher{
-											pos:        position{line: 796, col: 44, offset: 23817},
+											pos:        position{line: 1039, col: 44, offset: 32570},
 											val:        ".",
 											ignoreCase: false,
 											want:       "\".\"",
 										},
 										&oneOrMoreExpr{
-											pos: position{line: 796, col: 48, offset: 23821},
+											pos: position{line: 1039, col: 48, offset: 32574},
 											expr: &charClassMatcher{
-												pos:        position{line: 796, col: 48, offset: 23821},
+												pos:        position{line: 1039, col: 48, offset: 32574},
 												val:        "[0-9]",
 												ranges:     []rune{'0', '9'},
 												ignoreCase: false,
@@ -3845,35 +5202,35 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 807, col: 1, offset: 24034},
+			pos:  position{line: 1051, col: 1, offset: 32815},
 			expr: &choiceExpr{
-				pos: position{line: 807, col: 18, offset: 24051},
+				pos: position{line: 1051, col: 18, offset: 32832},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 807, col: 18, offset: 24051},
+						pos: position{line: 1051, col: 18, offset: 32832},
 						run: (*parser).callonStringLiteral2,
 						expr: &seqExpr{
-							pos: position{line: 807, col: 18, offset: 24051},
+							pos: position{line: 1051, col: 18, offset: 32832},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 807, col: 18, offset: 24051},
+									pos:        position{line: 1051, col: 18, offset: 32832},
 									val:        "\"",
 									ignoreCase: false,
 									want:       "\"\\\"\"",
 								},
 								&labeledExpr{
-									pos:   position{line: 807, col: 23, offset: 24056},
+									pos:   position{line: 1051, col: 23, offset: 32837},
 									label: "chars",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 807, col: 29, offset: 24062},
+										pos: position{line: 1051, col: 29, offset: 32843},
 										expr: &ruleRefExpr{
-											pos:  position{line: 807, col: 29, offset: 24062},
+											pos:  position{line: 1051, col: 29, offset: 32843},
 											name: "DoubleStringChar",
 										},
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 807, col: 47, offset: 24080},
+									pos:        position{line: 1051, col: 47, offset: 32861},
 									val:        "\"",
 									ignoreCase: false,
 									want:       "\"\\\"\"",
@@ -3882,30 +5239,30 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 819, col: 5, offset: 24416},
+						pos: position{line: 1064, col: 5, offset: 33229},
 						run: (*parser).callonStringLiteral9,
 						expr: &seqExpr{
-							pos: position{line: 819, col: 5, offset: 24416},
+							pos: position{line: 1064, col: 5, offset: 33229},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 819, col: 5, offset: 24416},
+									pos:        position{line: 1064, col: 5, offset: 33229},
 									val:        "'",
 									ignoreCase: false,
 									want:       "\"'\"",
 								},
 								&labeledExpr{
-									pos:   position{line: 819, col: 9, offset: 24420},
+									pos:   position{line: 1064, col: 9, offset: 33233},
 									label: "chars",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 819, col: 15, offset: 24426},
+										pos: position{line: 1064, col: 15, offset: 33239},
 										expr: &ruleRefExpr{
-											pos:  position{line: 819, col: 15, offset: 24426},
+											pos:  position{line: 1064, col: 15, offset: 33239},
 											name: "SingleStringChar",
 										},
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 819, col: 33, offset: 24444},
+									pos:        position{line: 1064, col: 33, offset: 33257},
 									val:        "'",
 									ignoreCase: false,
 									want:       "\"'\"",
@@ -3918,31 +5275,31 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringChar",
-			pos:  position{line: 832, col: 1, offset: 24774},
+			pos:  position{line: 1078, col: 1, offset: 33619},
 			expr: &choiceExpr{
-				pos: position{line: 832, col: 21, offset: 24794},
+				pos: position{line: 1078, col: 21, offset: 33639},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 832, col: 21, offset: 24794},
+						pos:  position{line: 1078, col: 21, offset: 33639},
 						name: "EscapeSequence",
 					},
 					&actionExpr{
-						pos: position{line: 832, col: 38, offset: 24811},
+						pos: position{line: 1078, col: 38, offset: 33656},
 						run: (*parser).callonDoubleStringChar3,
 						expr: &seqExpr{
-							pos: position{line: 832, col: 39, offset: 24812},
+							pos: position{line: 1078, col: 39, offset: 33657},
 							exprs: []any{
 								&notExpr{
-									pos: position{line: 832, col: 39, offset: 24812},
+									pos: position{line: 1078, col: 39, offset: 33657},
 									expr: &litMatcher{
-										pos:        position{line: 832, col: 40, offset: 24813},
+										pos:        position{line: 1078, col: 40, offset: 33658},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 832, col: 44, offset: 24817},
+									pos:  position{line: 1078, col: 44, offset: 33662},
 									name: "UnicodeChar",
 								},
 							},
@@ -3953,31 +5310,31 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringChar",
-			pos:  position{line: 836, col: 1, offset: 24866},
+			pos:  position{line: 1082, col: 1, offset: 33711},
 			expr: &choiceExpr{
-				pos: position{line: 836, col: 21, offset: 24886},
+				pos: position{line: 1082, col: 21, offset: 33731},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 836, col: 21, offset: 24886},
+						pos:  position{line: 1082, col: 21, offset: 33731},
 						name: "EscapeSequence",
 					},
 					&actionExpr{
-						pos: position{line: 836, col: 38, offset: 24903},
+						pos: position{line: 1082, col: 38, offset: 33748},
 						run: (*parser).callonSingleStringChar3,
 						expr: &seqExpr{
-							pos: position{line: 836, col: 39, offset: 24904},
+							pos: position{line: 1082, col: 39, offset: 33749},
 							exprs: []any{
 								&notExpr{
-									pos: position{line: 836, col: 39, offset: 24904},
+									pos: position{line: 1082, col: 39, offset: 33749},
 									expr: &litMatcher{
-										pos:        position{line: 836, col: 40, offset: 24905},
+										pos:        position{line: 1082, col: 40, offset: 33750},
 										val:        "'",
 										ignoreCase: false,
 										want:       "\"'\"",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 836, col: 45, offset: 24910},
+									pos:  position{line: 1082, col: 45, offset: 33755},
 									name: "UnicodeChar",
 								},
 							},
@@ -3988,24 +5345,24 @@ var g = &grammar{
 		},
 		{
 			name: "EscapeSequence",
-			pos:  position{line: 840, col: 1, offset: 24959},
+			pos:  position{line: 1086, col: 1, offset: 33804},
 			expr: &actionExpr{
-				pos: position{line: 840, col: 19, offset: 24977},
+				pos: position{line: 1086, col: 19, offset: 33822},
 				run: (*parser).callonEscapeSequence1,
 				expr: &seqExpr{
-					pos: position{line: 840, col: 19, offset: 24977},
+					pos: position{line: 1086, col: 19, offset: 33822},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 840, col: 19, offset: 24977},
+							pos:        position{line: 1086, col: 19, offset: 33822},
 							val:        "\\",
 							ignoreCase: false,
 							want:       "\"\\\\\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 840, col: 24, offset: 24982},
+							pos:   position{line: 1086, col: 24, offset: 33827},
 							label: "char",
 							expr: &ruleRefExpr{
-								pos:  position{line: 840, col: 29, offset: 24987},
+								pos:  position{line: 1086, col: 29, offset: 33832},
 								name: "EscapeChar",
 							},
 						},
@@ -4015,75 +5372,75 @@ var g = &grammar{
 		},
 		{
 			name: "EscapeChar",
-			pos:  position{line: 869, col: 1, offset: 25511},
+			pos:  position{line: 1115, col: 1, offset: 34356},
 			expr: &choiceExpr{
-				pos: position{line: 869, col: 15, offset: 25525},
+				pos: position{line: 1115, col: 15, offset: 34370},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 869, col: 15, offset: 25525},
+						pos: position{line: 1115, col: 15, offset: 34370},
 						run: (*parser).callonEscapeChar2,
 						expr: &litMatcher{
-							pos:        position{line: 869, col: 15, offset: 25525},
+							pos:        position{line: 1115, col: 15, offset: 34370},
 							val:        "n",
 							ignoreCase: false,
 							want:       "\"n\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 870, col: 15, offset: 25565},
+						pos: position{line: 1116, col: 15, offset: 34410},
 						run: (*parser).callonEscapeChar4,
 						expr: &litMatcher{
-							pos:        position{line: 870, col: 15, offset: 25565},
+							pos:        position{line: 1116, col: 15, offset: 34410},
 							val:        "t",
 							ignoreCase: false,
 							want:       "\"t\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 871, col: 15, offset: 25605},
+						pos: position{line: 1117, col: 15, offset: 34450},
 						run: (*parser).callonEscapeChar6,
 						expr: &litMatcher{
-							pos:        position{line: 871, col: 15, offset: 25605},
+							pos:        position{line: 1117, col: 15, offset: 34450},
 							val:        "r",
 							ignoreCase: false,
 							want:       "\"r\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 872, col: 15, offset: 25645},
+						pos: position{line: 1118, col: 15, offset: 34490},
 						run: (*parser).callonEscapeChar8,
 						expr: &litMatcher{
-							pos:        position{line: 872, col: 15, offset: 25645},
+							pos:        position{line: 1118, col: 15, offset: 34490},
 							val:        "\\",
 							ignoreCase: false,
 							want:       "\"\\\\\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 873, col: 15, offset: 25687},
+						pos: position{line: 1119, col: 15, offset: 34532},
 						run: (*parser).callonEscapeChar10,
 						expr: &litMatcher{
-							pos:        position{line: 873, col: 15, offset: 25687},
+							pos:        position{line: 1119, col: 15, offset: 34532},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 874, col: 15, offset: 25729},
+						pos: position{line: 1120, col: 15, offset: 34574},
 						run: (*parser).callonEscapeChar12,
 						expr: &litMatcher{
-							pos:        position{line: 874, col: 15, offset: 25729},
+							pos:        position{line: 1120, col: 15, offset: 34574},
 							val:        "'",
 							ignoreCase: false,
 							want:       "\"'\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 875, col: 15, offset: 25769},
+						pos: position{line: 1121, col: 15, offset: 34614},
 						run: (*parser).callonEscapeChar14,
 						expr: &anyMatcher{
-							line: 875, col: 15, offset: 25769,
+							line: 1121, col: 15, offset: 34614,
 						},
 					},
 				},
@@ -4091,46 +5448,46 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeChar",
-			pos:  position{line: 877, col: 1, offset: 25803},
+			pos:  position{line: 1123, col: 1, offset: 34648},
 			expr: &anyMatcher{
-				line: 877, col: 16, offset: 25818,
+				line: 1123, col: 16, offset: 34663,
 			},
 		},
 		{
 			name: "RemoveRule",
-			pos:  position{line: 880, col: 1, offset: 25920},
+			pos:  position{line: 1126, col: 1, offset: 34765},
 			expr: &actionExpr{
-				pos: position{line: 880, col: 15, offset: 25934},
+				pos: position{line: 1126, col: 15, offset: 34779},
 				run: (*parser).callonRemoveRule1,
 				expr: &seqExpr{
-					pos: position{line: 880, col: 15, offset: 25934},
+					pos: position{line: 1126, col: 15, offset: 34779},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 880, col: 15, offset: 25934},
+							pos:        position{line: 1126, col: 15, offset: 34779},
 							val:        "remove",
 							ignoreCase: false,
 							want:       "\"remove\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 880, col: 24, offset: 25943},
+							pos:  position{line: 1126, col: 24, offset: 34788},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 880, col: 26, offset: 25945},
+							pos:        position{line: 1126, col: 26, offset: 34790},
 							val:        "rule",
 							ignoreCase: false,
 							want:       "\"rule\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 880, col: 33, offset: 25952},
+							pos:  position{line: 1126, col: 33, offset: 34797},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 880, col: 35, offset: 25954},
+							pos:   position{line: 1126, col: 35, offset: 34799},
 							label: "ruleID",
 							expr: &ruleRefExpr{
-								pos:  position{line: 880, col: 42, offset: 25961},
-								name: "IdentName",
+								pos:  position{line: 1126, col: 42, offset: 34806},
+								name: "QualifiedName",
 							},
 						},
 					},
@@ -4139,50 +5496,50 @@ var g = &grammar{
 		},
 		{
 			name: "RemoveFact",
-			pos:  position{line: 888, col: 1, offset: 26181},
+			pos:  position{line: 1135, col: 1, offset: 35058},
 			expr: &actionExpr{
-				pos: position{line: 888, col: 15, offset: 26195},
+				pos: position{line: 1135, col: 15, offset: 35072},
 				run: (*parser).callonRemoveFact1,
 				expr: &seqExpr{
-					pos: position{line: 888, col: 15, offset: 26195},
+					pos: position{line: 1135, col: 15, offset: 35072},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 888, col: 15, offset: 26195},
+							pos:        position{line: 1135, col: 15, offset: 35072},
 							val:        "remove",
 							ignoreCase: false,
 							want:       "\"remove\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 888, col: 24, offset: 26204},
+							pos:  position{line: 1135, col: 24, offset: 35081},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 888, col: 26, offset: 26206},
+							pos:        position{line: 1135, col: 26, offset: 35083},
 							val:        "fact",
 							ignoreCase: false,
 							want:       "\"fact\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 888, col: 33, offset: 26213},
+							pos:  position{line: 1135, col: 33, offset: 35090},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 888, col: 35, offset: 26215},
+							pos:   position{line: 1135, col: 35, offset: 35092},
 							label: "typeName",
 							expr: &ruleRefExpr{
-								pos:  position{line: 888, col: 44, offset: 26224},
-								name: "IdentName",
+								pos:  position{line: 1135, col: 44, offset: 35101},
+								name: "QualifiedName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 888, col: 54, offset: 26234},
+							pos:  position{line: 1135, col: 58, offset: 35115},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 888, col: 56, offset: 26236},
+							pos:   position{line: 1135, col: 60, offset: 35117},
 							label: "factID",
 							expr: &ruleRefExpr{
-								pos:  position{line: 888, col: 63, offset: 26243},
+								pos:  position{line: 1135, col: 67, offset: 35124},
 								name: "FactID",
 							},
 						},
@@ -4192,20 +5549,20 @@ var g = &grammar{
 		},
 		{
 			name: "FactID",
-			pos:  position{line: 897, col: 1, offset: 26471},
+			pos:  position{line: 1145, col: 1, offset: 35380},
 			expr: &actionExpr{
-				pos: position{line: 897, col: 11, offset: 26481},
+				pos: position{line: 1145, col: 11, offset: 35390},
 				run: (*parser).callonFactID1,
 				expr: &labeledExpr{
-					pos:   position{line: 897, col: 11, offset: 26481},
+					pos:   position{line: 1145, col: 11, offset: 35390},
 					label: "chars",
 					expr: &oneOrMoreExpr{
-						pos: position{line: 897, col: 17, offset: 26487},
+						pos: position{line: 1145, col: 17, offset: 35396},
 						expr: &choiceExpr{
-							pos: position{line: 897, col: 18, offset: 26488},
+							pos: position{line: 1145, col: 18, offset: 35397},
 							alternatives: []any{
 								&charClassMatcher{
-									pos:        position{line: 897, col: 18, offset: 26488},
+									pos:        position{line: 1145, col: 18, offset: 35397},
 									val:        "[a-zA-Z0-9_-]",
 									chars:      []rune{'_', '-'},
 									ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4213,7 +5570,7 @@ var g = &grammar{
 									inverted:   false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 897, col: 34, offset: 26504},
+									pos:  position{line: 1145, col: 34, offset: 35413},
 									name: "SpecialFactChar",
 								},
 							},
@@ -4224,40 +5581,40 @@ var g = &grammar{
 		},
 		{
 			name: "FactAssignment",
-			pos:  position{line: 902, col: 1, offset: 26641},
+			pos:  position{line: 1150, col: 1, offset: 35550},
 			expr: &actionExpr{
-				pos: position{line: 902, col: 19, offset: 26659},
+				pos: position{line: 1150, col: 19, offset: 35568},
 				run: (*parser).callonFactAssignment1,
 				expr: &seqExpr{
-					pos: position{line: 902, col: 19, offset: 26659},
+					pos: position{line: 1150, col: 19, offset: 35568},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 902, col: 19, offset: 26659},
+							pos:   position{line: 1150, col: 19, offset: 35568},
 							label: "variable",
 							expr: &ruleRefExpr{
-								pos:  position{line: 902, col: 28, offset: 26668},
+								pos:  position{line: 1150, col: 28, offset: 35577},
 								name: "IdentName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 902, col: 38, offset: 26678},
+							pos:  position{line: 1150, col: 38, offset: 35587},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 902, col: 40, offset: 26680},
+							pos:        position{line: 1150, col: 40, offset: 35589},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 902, col: 44, offset: 26684},
+							pos:  position{line: 1150, col: 44, offset: 35593},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 902, col: 46, offset: 26686},
+							pos:   position{line: 1150, col: 46, offset: 35595},
 							label: "fact",
 							expr: &ruleRefExpr{
-								pos:  position{line: 902, col: 51, offset: 26691},
+								pos:  position{line: 1150, col: 51, offset: 35600},
 								name: "Fact",
 							},
 						},
@@ -4267,67 +5624,67 @@ var g = &grammar{
 		},
 		{
 			name: "Fact",
-			pos:  position{line: 912, col: 1, offset: 26993},
+			pos:  position{line: 1161, col: 1, offset: 35930},
 			expr: &actionExpr{
-				pos: position{line: 912, col: 9, offset: 27001},
+				pos: position{line: 1161, col: 9, offset: 35938},
 				run: (*parser).callonFact1,
 				expr: &seqExpr{
-					pos: position{line: 912, col: 9, offset: 27001},
+					pos: position{line: 1161, col: 9, offset: 35938},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 912, col: 9, offset: 27001},
+							pos:   position{line: 1161, col: 9, offset: 35938},
 							label: "typeName",
 							expr: &ruleRefExpr{
-								pos:  position{line: 912, col: 18, offset: 27010},
-								name: "IdentName",
+								pos:  position{line: 1161, col: 18, offset: 35947},
+								name: "QualifiedName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 912, col: 28, offset: 27020},
+							pos:  position{line: 1161, col: 32, offset: 35961},
 							name: "_",
 						},
 						&notExpr{
-							pos: position{line: 912, col: 30, offset: 27022},
+							pos: position{line: 1161, col: 34, offset: 35963},
 							expr: &seqExpr{
-								pos: position{line: 912, col: 32, offset: 27024},
+								pos: position{line: 1161, col: 36, offset: 35965},
 								exprs: []any{
 									&litMatcher{
-										pos:        position{line: 912, col: 32, offset: 27024},
+										pos:        position{line: 1161, col: 36, offset: 35965},
 										val:        "=",
 										ignoreCase: false,
 										want:       "\"=\"",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 912, col: 36, offset: 27028},
+										pos:  position{line: 1161, col: 40, offset: 35969},
 										name: "_",
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 912, col: 39, offset: 27031},
+							pos:        position{line: 1161, col: 43, offset: 35972},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 912, col: 43, offset: 27035},
+							pos:  position{line: 1161, col: 47, offset: 35976},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 912, col: 45, offset: 27037},
+							pos:   position{line: 1161, col: 49, offset: 35978},
 							label: "fields",
 							expr: &ruleRefExpr{
-								pos:  position{line: 912, col: 52, offset: 27044},
+								pos:  position{line: 1161, col: 56, offset: 35985},
 								name: "FactFieldList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 912, col: 66, offset: 27058},
+							pos:  position{line: 1161, col: 70, offset: 35999},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 912, col: 68, offset: 27060},
+							pos:        position{line: 1161, col: 72, offset: 36001},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -4338,45 +5695,45 @@ var g = &grammar{
 		},
 		{
 			name: "FactFieldList",
-			pos:  position{line: 920, col: 1, offset: 27195},
+			pos:  position{line: 1170, col: 1, offset: 36164},
 			expr: &actionExpr{
-				pos: position{line: 920, col: 18, offset: 27212},
+				pos: position{line: 1170, col: 18, offset: 36181},
 				run: (*parser).callonFactFieldList1,
 				expr: &seqExpr{
-					pos: position{line: 920, col: 18, offset: 27212},
+					pos: position{line: 1170, col: 18, offset: 36181},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 920, col: 18, offset: 27212},
+							pos:   position{line: 1170, col: 18, offset: 36181},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 920, col: 24, offset: 27218},
+								pos:  position{line: 1170, col: 24, offset: 36187},
 								name: "FactField",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 920, col: 34, offset: 27228},
+							pos:   position{line: 1170, col: 34, offset: 36197},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 920, col: 39, offset: 27233},
+								pos: position{line: 1170, col: 39, offset: 36202},
 								expr: &seqExpr{
-									pos: position{line: 920, col: 40, offset: 27234},
+									pos: position{line: 1170, col: 40, offset: 36203},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 920, col: 40, offset: 27234},
+											pos:  position{line: 1170, col: 40, offset: 36203},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 920, col: 42, offset: 27236},
+											pos:        position{line: 1170, col: 42, offset: 36205},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 920, col: 46, offset: 27240},
+											pos:  position{line: 1170, col: 46, offset: 36209},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 920, col: 48, offset: 27242},
+											pos:  position{line: 1170, col: 48, offset: 36211},
 											name: "FactField",
 										},
 									},
@@ -4389,36 +5746,36 @@ var g = &grammar{
 		},
 		{
 			name: "FactField",
-			pos:  position{line: 930, col: 1, offset: 27467},
+			pos:  position{line: 1180, col: 1, offset: 36436},
 			expr: &actionExpr{
-				pos: position{line: 930, col: 14, offset: 27480},
+				pos: position{line: 1180, col: 14, offset: 36449},
 				run: (*parser).callonFactField1,
 				expr: &seqExpr{
-					pos: position{line: 930, col: 14, offset: 27480},
+					pos: position{line: 1180, col: 14, offset: 36449},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 930, col: 14, offset: 27480},
+							pos:   position{line: 1180, col: 14, offset: 36449},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 930, col: 19, offset: 27485},
+								pos:  position{line: 1180, col: 19, offset: 36454},
 								name: "IdentName",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 930, col: 29, offset: 27495},
+							pos:        position{line: 1180, col: 29, offset: 36464},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 930, col: 33, offset: 27499},
+							pos:  position{line: 1180, col: 33, offset: 36468},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 930, col: 35, offset: 27501},
+							pos:   position{line: 1180, col: 35, offset: 36470},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 930, col: 41, offset: 27507},
+								pos:  position{line: 1180, col: 41, offset: 36476},
 								name: "FactValue",
 							},
 						},
@@ -4428,39 +5785,39 @@ var g = &grammar{
 		},
 		{
 			name: "FactValue",
-			pos:  position{line: 943, col: 1, offset: 27854},
+			pos:  position{line: 1194, col: 1, offset: 36851},
 			expr: &choiceExpr{
-				pos: position{line: 943, col: 14, offset: 27867},
+				pos: position{line: 1194, col: 14, offset: 36864},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 943, col: 14, offset: 27867},
+						pos:  position{line: 1194, col: 14, offset: 36864},
 						name: "StringLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 943, col: 30, offset: 27883},
+						pos:  position{line: 1194, col: 30, offset: 36880},
 						name: "Number",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 943, col: 39, offset: 27892},
+						pos:  position{line: 1194, col: 39, offset: 36889},
 						name: "BooleanLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 943, col: 56, offset: 27909},
+						pos:  position{line: 1194, col: 56, offset: 36906},
 						name: "ObjectLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 943, col: 72, offset: 27925},
+						pos:  position{line: 1194, col: 72, offset: 36922},
 						name: "ArrayLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 943, col: 87, offset: 27940},
+						pos:  position{line: 1194, col: 87, offset: 36937},
 						name: "VariableReference",
 					},
 					&actionExpr{
-						pos: position{line: 943, col: 107, offset: 27960},
+						pos: position{line: 1194, col: 107, offset: 36957},
 						run: (*parser).callonFactValue8,
 						expr: &ruleRefExpr{
-							pos:  position{line: 943, col: 107, offset: 27960},
+							pos:  position{line: 1194, col: 107, offset: 36957},
 							name: "ComplexIdentifier",
 						},
 					},
@@ -4469,48 +5826,48 @@ var g = &grammar{
 		},
 		{
 			name: "VariableReference",
-			pos:  position{line: 951, col: 1, offset: 28171},
+			pos:  position{line: 1202, col: 1, offset: 37168},
 			expr: &actionExpr{
-				pos: position{line: 951, col: 22, offset: 28192},
+				pos: position{line: 1202, col: 22, offset: 37189},
 				run: (*parser).callonVariableReference1,
 				expr: &seqExpr{
-					pos: position{line: 951, col: 22, offset: 28192},
+					pos: position{line: 1202, col: 22, offset: 37189},
 					exprs: []any{
 						&notExpr{
-							pos: position{line: 951, col: 22, offset: 28192},
+							pos: position{line: 1202, col: 22, offset: 37189},
 							expr: &ruleRefExpr{
-								pos:  position{line: 951, col: 23, offset: 28193},
+								pos:  position{line: 1202, col: 23, offset: 37190},
 								name: "ReservedWord",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 951, col: 36, offset: 28206},
+							pos:   position{line: 1202, col: 36, offset: 37203},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 951, col: 41, offset: 28211},
+								pos:  position{line: 1202, col: 41, offset: 37208},
 								name: "IdentName",
 							},
 						},
 						&andExpr{
-							pos: position{line: 951, col: 51, offset: 28221},
+							pos: position{line: 1202, col: 51, offset: 37218},
 							expr: &seqExpr{
-								pos: position{line: 951, col: 53, offset: 28223},
+								pos: position{line: 1202, col: 53, offset: 37220},
 								exprs: []any{
 									&ruleRefExpr{
-										pos:  position{line: 951, col: 53, offset: 28223},
+										pos:  position{line: 1202, col: 53, offset: 37220},
 										name: "_",
 									},
 									&choiceExpr{
-										pos: position{line: 951, col: 56, offset: 28226},
+										pos: position{line: 1202, col: 56, offset: 37223},
 										alternatives: []any{
 											&litMatcher{
-												pos:        position{line: 951, col: 56, offset: 28226},
+												pos:        position{line: 1202, col: 56, offset: 37223},
 												val:        ",",
 												ignoreCase: false,
 												want:       "\",\"",
 											},
 											&litMatcher{
-												pos:        position{line: 951, col: 62, offset: 28232},
+												pos:        position{line: 1202, col: 62, offset: 37229},
 												val:        ")",
 												ignoreCase: false,
 												want:       "\")\"",
@@ -4526,21 +5883,21 @@ var g = &grammar{
 		},
 		{
 			name: "ComplexIdentifier",
-			pos:  position{line: 959, col: 1, offset: 28528},
+			pos:  position{line: 1210, col: 1, offset: 37525},
 			expr: &actionExpr{
-				pos: position{line: 959, col: 22, offset: 28549},
+				pos: position{line: 1210, col: 22, offset: 37546},
 				run: (*parser).callonComplexIdentifier1,
 				expr: &seqExpr{
-					pos: position{line: 959, col: 22, offset: 28549},
+					pos: position{line: 1210, col: 22, offset: 37546},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 959, col: 22, offset: 28549},
+							pos:  position{line: 1210, col: 22, offset: 37546},
 							name: "ComplexIdentStart",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 959, col: 40, offset: 28567},
+							pos: position{line: 1210, col: 40, offset: 37564},
 							expr: &ruleRefExpr{
-								pos:  position{line: 959, col: 40, offset: 28567},
+								pos:  position{line: 1210, col: 40, offset: 37564},
 								name: "ComplexIdentContinue",
 							},
 						},
@@ -4550,12 +5907,12 @@ var g = &grammar{
 		},
 		{
 			name: "ComplexIdentStart",
-			pos:  position{line: 963, col: 1, offset: 28625},
+			pos:  position{line: 1214, col: 1, offset: 37622},
 			expr: &choiceExpr{
-				pos: position{line: 963, col: 22, offset: 28646},
+				pos: position{line: 1214, col: 22, offset: 37643},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 963, col: 22, offset: 28646},
+						pos:        position{line: 1214, col: 22, offset: 37643},
 						val:        "[a-zA-Z0-9_]",
 						chars:      []rune{'_'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4563,7 +5920,7 @@ var g = &grammar{
 						inverted:   false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 963, col: 37, offset: 28661},
+						pos:  position{line: 1214, col: 37, offset: 37658},
 						name: "UnicodeLetterStart",
 					},
 				},
@@ -4571,12 +5928,12 @@ var g = &grammar{
 		},
 		{
 			name: "ComplexIdentContinue",
-			pos:  position{line: 965, col: 1, offset: 28681},
+			pos:  position{line: 1216, col: 1, offset: 37678},
 			expr: &choiceExpr{
-				pos: position{line: 965, col: 25, offset: 28705},
+				pos: position{line: 1216, col: 25, offset: 37702},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 965, col: 25, offset: 28705},
+						pos:        position{line: 1216, col: 25, offset: 37702},
 						val:        "[a-zA-Z0-9_-]",
 						chars:      []rune{'_', '-'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4584,11 +5941,11 @@ var g = &grammar{
 						inverted:   false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 965, col: 41, offset: 28721},
+						pos:  position{line: 1216, col: 41, offset: 37718},
 						name: "UnicodeLetterContinue",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 965, col: 65, offset: 28745},
+						pos:  position{line: 1216, col: 65, offset: 37742},
 						name: "SpecialFactChar",
 					},
 				},
@@ -4596,9 +5953,9 @@ var g = &grammar{
 		},
 		{
 			name: "SpecialFactChar",
-			pos:  position{line: 968, col: 1, offset: 28824},
+			pos:  position{line: 1219, col: 1, offset: 37821},
 			expr: &charClassMatcher{
-				pos:        position{line: 968, col: 20, offset: 28843},
+				pos:        position{line: 1219, col: 20, offset: 37840},
 				val:        "[-_:.]",
 				chars:      []rune{'-', '_', ':', '.'},
 				ignoreCase: false,
@@ -4607,21 +5964,21 @@ var g = &grammar{
 		},
 		{
 			name: "IdentName",
-			pos:  position{line: 970, col: 1, offset: 28851},
+			pos:  position{line: 1221, col: 1, offset: 37848},
 			expr: &actionExpr{
-				pos: position{line: 970, col: 14, offset: 28864},
+				pos: position{line: 1221, col: 14, offset: 37861},
 				run: (*parser).callonIdentName1,
 				expr: &seqExpr{
-					pos: position{line: 970, col: 14, offset: 28864},
+					pos: position{line: 1221, col: 14, offset: 37861},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 970, col: 14, offset: 28864},
+							pos:  position{line: 1221, col: 14, offset: 37861},
 							name: "IdentStart",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 970, col: 25, offset: 28875},
+							pos: position{line: 1221, col: 25, offset: 37872},
 							expr: &ruleRefExpr{
-								pos:  position{line: 970, col: 25, offset: 28875},
+								pos:  position{line: 1221, col: 25, offset: 37872},
 								name: "IdentContinue",
 							},
 						},
@@ -4629,14 +5986,49 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "QualifiedName",
+			pos:  position{line: 1226, col: 1, offset: 38010},
+			expr: &actionExpr{
+				pos: position{line: 1226, col: 18, offset: 38027},
+				run: (*parser).callonQualifiedName1,
+				expr: &seqExpr{
+					pos: position{line: 1226, col: 18, offset: 38027},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 1226, col: 18, offset: 38027},
+							name: "IdentName",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 1226, col: 28, offset: 38037},
+							expr: &seqExpr{
+								pos: position{line: 1226, col: 29, offset: 38038},
+								exprs: []any{
+									&litMatcher{
+										pos:        position{line: 1226, col: 29, offset: 38038},
+										val:        ".",
+										ignoreCase: false,
+										want:       "\".\"",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 1226, col: 33, offset: 38042},
+										name: "IdentName",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "IdentStart",
-			pos:  position{line: 974, col: 1, offset: 28926},
+			pos:  position{line: 1230, col: 1, offset: 38090},
 			expr: &choiceExpr{
-				pos: position{line: 974, col: 15, offset: 28940},
+				pos: position{line: 1230, col: 15, offset: 38104},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 974, col: 15, offset: 28940},
+						pos:        position{line: 1230, col: 15, offset: 38104},
 						val:        "[a-zA-Z_]",
 						chars:      []rune{'_'},
 						ranges:     []rune{'a', 'z', 'A', 'Z'},
@@ -4644,7 +6036,7 @@ var g = &grammar{
 						inverted:   false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 974, col: 27, offset: 28952},
+						pos:  position{line: 1230, col: 27, offset: 38116},
 						name: "UnicodeLetterStart",
 					},
 				},
@@ -4652,12 +6044,12 @@ var g = &grammar{
 		},
 		{
 			name: "IdentContinue",
-			pos:  position{line: 976, col: 1, offset: 28972},
+			pos:  position{line: 1232, col: 1, offset: 38136},
 			expr: &choiceExpr{
-				pos: position{line: 976, col: 18, offset: 28989},
+				pos: position{line: 1232, col: 18, offset: 38153},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 976, col: 18, offset: 28989},
+						pos:        position{line: 1232, col: 18, offset: 38153},
 						val:        "[a-zA-Z0-9_-]",
 						chars:      []rune{'_', '-'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4665,11 +6057,11 @@ var g = &grammar{
 						inverted:   false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 976, col: 34, offset: 29005},
+						pos:  position{line: 1232, col: 34, offset: 38169},
 						name: "UnicodeLetterContinue",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 976, col: 58, offset: 29029},
+						pos:  position{line: 1232, col: 58, offset: 38193},
 						name: "PunctuationChar",
 					},
 				},
@@ -4677,110 +6069,110 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeLetterStart",
-			pos:  position{line: 980, col: 1, offset: 29213},
+			pos:  position{line: 1236, col: 1, offset: 38377},
 			expr: &choiceExpr{
-				pos: position{line: 980, col: 23, offset: 29235},
+				pos: position{line: 1236, col: 23, offset: 38399},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 980, col: 23, offset: 29235},
+						pos:        position{line: 1236, col: 23, offset: 38399},
 						val:        "[\\u00C0-\\u00D6]",
 						ranges:     []rune{'À', 'Ö'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 980, col: 41, offset: 29253},
+						pos:        position{line: 1236, col: 41, offset: 38417},
 						val:        "[\\u00D8-\\u00F6]",
 						ranges:     []rune{'Ø', 'ö'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 980, col: 59, offset: 29271},
+						pos:        position{line: 1236, col: 59, offset: 38435},
 						val:        "[\\u00F8-\\u017F]",
 						ranges:     []rune{'ø', 'ſ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 981, col: 23, offset: 29311},
+						pos:        position{line: 1237, col: 23, offset: 38475},
 						val:        "[\\u0100-\\u024F]",
 						ranges:     []rune{'Ā', 'ɏ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 981, col: 41, offset: 29329},
+						pos:        position{line: 1237, col: 41, offset: 38493},
 						val:        "[\\u1E00-\\u1EFF]",
 						ranges:     []rune{'Ḁ', 'ỿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 981, col: 59, offset: 29347},
+						pos:        position{line: 1237, col: 59, offset: 38511},
 						val:        "[\\u0370-\\u03FF]",
 						ranges:     []rune{'Ͱ', 'Ͽ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 982, col: 23, offset: 29387},
+						pos:        position{line: 1238, col: 23, offset: 38551},
 						val:        "[\\u0400-\\u04FF]",
 						ranges:     []rune{'Ѐ', 'ӿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 982, col: 41, offset: 29405},
+						pos:        position{line: 1238, col: 41, offset: 38569},
 						val:        "[\\u0590-\\u05FF]",
 						ranges:     []rune{'\u0590', '\u05ff'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 982, col: 59, offset: 29423},
+						pos:        position{line: 1238, col: 59, offset: 38587},
 						val:        "[\\u0600-\\u06FF]",
 						ranges:     []rune{'\u0600', 'ۿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 983, col: 23, offset: 29463},
+						pos:        position{line: 1239, col: 23, offset: 38627},
 						val:        "[\\u3040-\\u309F]",
 						ranges:     []rune{'\u3040', 'ゟ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 983, col: 41, offset: 29481},
+						pos:        position{line: 1239, col: 41, offset: 38645},
 						val:        "[\\u30A0-\\u30FF]",
 						ranges:     []rune{'゠', 'ヿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 983, col: 59, offset: 29499},
+						pos:        position{line: 1239, col: 59, offset: 38663},
 						val:        "[\\u3400-\\u4DBF]",
 						ranges:     []rune{'㐀', '䶿'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 984, col: 23, offset: 29539},
+						pos:        position{line: 1240, col: 23, offset: 38703},
 						val:        "[\\u4E00-\\u9FFF]",
 						ranges:     []rune{'一', '鿿'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 984, col: 41, offset: 29557},
+						pos:        position{line: 1240, col: 41, offset: 38721},
 						val:        "[\\uAC00-\\uD7AF]",
 						ranges:     []rune{'가', '\ud7af'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 984, col: 59, offset: 29575},
+						pos:        position{line: 1240, col: 59, offset: 38739},
 						val:        "[\\uF900-\\uFAFF]",
 						ranges:     []rune{'豈', '\ufaff'},
 						ignoreCase: false,
@@ -4791,131 +6183,131 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeLetterContinue",
-			pos:  position{line: 986, col: 1, offset: 29592},
+			pos:  position{line: 1242, col: 1, offset: 38756},
 			expr: &choiceExpr{
-				pos: position{line: 986, col: 26, offset: 29617},
+				pos: position{line: 1242, col: 26, offset: 38781},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 986, col: 26, offset: 29617},
+						pos:        position{line: 1242, col: 26, offset: 38781},
 						val:        "[\\u00C0-\\u00D6]",
 						ranges:     []rune{'À', 'Ö'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 986, col: 44, offset: 29635},
+						pos:        position{line: 1242, col: 44, offset: 38799},
 						val:        "[\\u00D8-\\u00F6]",
 						ranges:     []rune{'Ø', 'ö'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 986, col: 62, offset: 29653},
+						pos:        position{line: 1242, col: 62, offset: 38817},
 						val:        "[\\u00F8-\\u017F]",
 						ranges:     []rune{'ø', 'ſ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 987, col: 26, offset: 29696},
+						pos:        position{line: 1243, col: 26, offset: 38860},
 						val:        "[\\u0100-\\u024F]",
 						ranges:     []rune{'Ā', 'ɏ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 987, col: 44, offset: 29714},
+						pos:        position{line: 1243, col: 44, offset: 38878},
 						val:        "[\\u1E00-\\u1EFF]",
 						ranges:     []rune{'Ḁ', 'ỿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 987, col: 62, offset: 29732},
+						pos:        position{line: 1243, col: 62, offset: 38896},
 						val:        "[\\u0370-\\u03FF]",
 						ranges:     []rune{'Ͱ', 'Ͽ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 988, col: 26, offset: 29775},
+						pos:        position{line: 1244, col: 26, offset: 38939},
 						val:        "[\\u0400-\\u04FF]",
 						ranges:     []rune{'Ѐ', 'ӿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 988, col: 44, offset: 29793},
+						pos:        position{line: 1244, col: 44, offset: 38957},
 						val:        "[\\u0590-\\u05FF]",
 						ranges:     []rune{'\u0590', '\u05ff'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 988, col: 62, offset: 29811},
+						pos:        position{line: 1244, col: 62, offset: 38975},
 						val:        "[\\u0600-\\u06FF]",
 						ranges:     []rune{'\u0600', 'ۿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 989, col: 26, offset: 29854},
+						pos:        position{line: 1245, col: 26, offset: 39018},
 						val:        "[\\u0300-\\u036F]",
 						ranges:     []rune{'̀', 'ͯ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 989, col: 44, offset: 29872},
+						pos:        position{line: 1245, col: 44, offset: 39036},
 						val:        "[\\u1AB0-\\u1AFF]",
 						ranges:     []rune{'᪰', '\u1aff'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 989, col: 62, offset: 29890},
+						pos:        position{line: 1245, col: 62, offset: 39054},
 						val:        "[\\u1DC0-\\u1DFF]",
 						ranges:     []rune{'᷀', '᷿'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 990, col: 26, offset: 29933},
+						pos:        position{line: 1246, col: 26, offset: 39097},
 						val:        "[\\u3040-\\u309F]",
 						ranges:     []rune{'\u3040', 'ゟ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 990, col: 44, offset: 29951},
+						pos:        position{line: 1246, col: 44, offset: 39115},
 						val:        "[\\u30A0-\\u30FF]",
 						ranges:     []rune{'゠', 'ヿ'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 990, col: 62, offset: 29969},
+						pos:        position{line: 1246, col: 62, offset: 39133},
 						val:        "[\\u3400-\\u4DBF]",
 						ranges:     []rune{'㐀', '䶿'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 991, col: 26, offset: 30012},
+						pos:        position{line: 1247, col: 26, offset: 39176},
 						val:        "[\\u4E00-\\u9FFF]",
 						ranges:     []rune{'一', '鿿'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 991, col: 44, offset: 30030},
+						pos:        position{line: 1247, col: 44, offset: 39194},
 						val:        "[\\uAC00-\\uD7AF]",
 						ranges:     []rune{'가', '\ud7af'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 991, col: 62, offset: 30048},
+						pos:        position{line: 1247, col: 62, offset: 39212},
 						val:        "[\\uF900-\\uFAFF]",
 						ranges:     []rune{'豈', '\ufaff'},
 						ignoreCase: false,
@@ -4926,33 +6318,33 @@ var g = &grammar{
 		},
 		{
 			name: "PunctuationChar",
-			pos:  position{line: 993, col: 1, offset: 30065},
+			pos:  position{line: 1249, col: 1, offset: 39229},
 			expr: &choiceExpr{
-				pos: position{line: 993, col: 20, offset: 30084},
+				pos: position{line: 1249, col: 20, offset: 39248},
 				alternatives: []any{
 					&charClassMatcher{
-						pos:        position{line: 993, col: 20, offset: 30084},
+						pos:        position{line: 1249, col: 20, offset: 39248},
 						val:        "[-_]",
 						chars:      []rune{'-', '_'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 993, col: 27, offset: 30091},
+						pos:        position{line: 1249, col: 27, offset: 39255},
 						val:        "[']",
 						chars:      []rune{'\''},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 993, col: 33, offset: 30097},
+						pos:        position{line: 1249, col: 33, offset: 39261},
 						val:        "[\\u2010-\\u2015]",
 						ranges:     []rune{'‐', '―'},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 993, col: 51, offset: 30115},
+						pos:        position{line: 1249, col: 51, offset: 39279},
 						val:        "[\\u2032-\\u2037]",
 						ranges:     []rune{'′', '‷'},
 						ignoreCase: false,
@@ -4963,261 +6355,327 @@ var g = &grammar{
 		},
 		{
 			name: "ReservedWord",
-			pos:  position{line: 996, col: 1, offset: 30231},
+			pos:  position{line: 1252, col: 1, offset: 39395},
 			expr: &seqExpr{
-				pos: position{line: 996, col: 17, offset: 30247},
+				pos: position{line: 1252, col: 17, offset: 39411},
 				exprs: []any{
 					&choiceExpr{
-						pos: position{line: 996, col: 18, offset: 30248},
+						pos: position{line: 1252, col: 18, offset: 39412},
 						alternatives: []any{
 							&litMatcher{
-								pos:        position{line: 996, col: 18, offset: 30248},
+								pos:        position{line: 1252, col: 18, offset: 39412},
 								val:        "type",
 								ignoreCase: false,
 								want:       "\"type\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 27, offset: 30257},
+								pos:        position{line: 1252, col: 27, offset: 39421},
 								val:        "action",
 								ignoreCase: false,
 								want:       "\"action\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 38, offset: 30268},
+								pos:        position{line: 1252, col: 38, offset: 39432},
 								val:        "rule",
 								ignoreCase: false,
 								want:       "\"rule\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 47, offset: 30277},
+								pos:        position{line: 1252, col: 47, offset: 39441},
+								val:        "query",
+								ignoreCase: false,
+								want:       "\"query\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 57, offset: 39451},
+								val:        "import",
+								ignoreCase: false,
+								want:       "\"import\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 68, offset: 39462},
+								val:        "package",
+								ignoreCase: false,
+								want:       "\"package\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 80, offset: 39474},
+								val:        "private",
+								ignoreCase: false,
+								want:       "\"private\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 92, offset: 39486},
 								val:        "when",
 								ignoreCase: false,
 								want:       "\"when\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 56, offset: 30286},
+								pos:        position{line: 1252, col: 101, offset: 39495},
 								val:        "then",
 								ignoreCase: false,
 								want:       "\"then\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 65, offset: 30295},
+								pos:        position{line: 1252, col: 110, offset: 39504},
+								val:        "let",
+								ignoreCase: false,
+								want:       "\"let\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 118, offset: 39512},
+								val:        "if",
+								ignoreCase: false,
+								want:       "\"if\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 125, offset: 39519},
+								val:        "else",
+								ignoreCase: false,
+								want:       "\"else\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1252, col: 134, offset: 39528},
 								val:        "remove",
 								ignoreCase: false,
 								want:       "\"remove\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 76, offset: 30306},
+								pos:        position{line: 1252, col: 145, offset: 39539},
 								val:        "fact",
 								ignoreCase: false,
 								want:       "\"fact\"",
 							},
 							&litMatcher{
-								pos:        position{line: 996, col: 85, offset: 30315},
+								pos:        position{line: 1252, col: 154, offset: 39548},
 								val:        "reset",
 								ignoreCase: false,
 								want:       "\"reset\"",
 							},
 							&litMatcher{
-								pos:        position{line: 997, col: 17, offset: 30341},
+								pos:        position{line: 1253, col: 17, offset: 39574},
 								val:        "xuple-space",
 								ignoreCase: false,
 								want:       "\"xuple-space\"",
 							},
 							&litMatcher{
-								pos:        position{line: 997, col: 33, offset: 30357},
+								pos:        position{line: 1253, col: 33, offset: 39590},
 								val:        "selection",
 								ignoreCase: false,
 								want:       "\"selection\"",
 							},
 							&litMatcher{
-								pos:        position{line: 997, col: 47, offset: 30371},
+								pos:        position{line: 1253, col: 47, offset: 39604},
 								val:        "consumption",
 								ignoreCase: false,
 								want:       "\"consumption\"",
 							},
 							&litMatcher{
-								pos:        position{line: 997, col: 63, offset: 30387},
+								pos:        position{line: 1253, col: 63, offset: 39620},
 								val:        "retention",
 								ignoreCase: false,
 								want:       "\"retention\"",
 							},
 							&litMatcher{
-								pos:        position{line: 997, col: 77, offset: 30401},
+								pos:        position{line: 1253, col: 77, offset: 39634},
 								val:        "max-size",
 								ignoreCase: false,
 								want:       "\"max-size\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 17, offset: 30430},
+								pos:        position{line: 1254, col: 17, offset: 39663},
 								val:        "AND",
 								ignoreCase: false,
 								want:       "\"AND\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 25, offset: 30438},
+								pos:        position{line: 1254, col: 25, offset: 39671},
 								val:        "and",
 								ignoreCase: false,
 								want:       "\"and\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 33, offset: 30446},
+								pos:        position{line: 1254, col: 33, offset: 39679},
 								val:        "OR",
 								ignoreCase: false,
 								want:       "\"OR\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 40, offset: 30453},
+								pos:        position{line: 1254, col: 40, offset: 39686},
 								val:        "or",
 								ignoreCase: false,
 								want:       "\"or\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 47, offset: 30460},
-								val:        "NOT",
+								pos:        position{line: 1254, col: 47, offset: 39693},
+								val:        "NOT",
+								ignoreCase: false,
+								want:       "\"NOT\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1254, col: 55, offset: 39701},
+								val:        "not",
+								ignoreCase: false,
+								want:       "\"not\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1254, col: 63, offset: 39709},
+								val:        "EXISTS",
+								ignoreCase: false,
+								want:       "\"EXISTS\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1254, col: 74, offset: 39720},
+								val:        "exists",
+								ignoreCase: false,
+								want:       "\"exists\"",
+							},
+							&litMatcher{
+								pos:        position{line: 1254, col: 85, offset: 39731},
+								val:        "FORALL",
 								ignoreCase: false,
-								want:       "\"NOT\"",
+								want:       "\"FORALL\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 55, offset: 30468},
-								val:        "not",
+								pos:        position{line: 1254, col: 96, offset: 39742},
+								val:        "forall",
 								ignoreCase: false,
-								want:       "\"not\"",
+								want:       "\"forall\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 63, offset: 30476},
-								val:        "EXISTS",
+								pos:        position{line: 1254, col: 107, offset: 39753},
+								val:        "COLLECT",
 								ignoreCase: false,
-								want:       "\"EXISTS\"",
+								want:       "\"COLLECT\"",
 							},
 							&litMatcher{
-								pos:        position{line: 998, col: 74, offset: 30487},
-								val:        "exists",
+								pos:        position{line: 1254, col: 119, offset: 39765},
+								val:        "collect",
 								ignoreCase: false,
-								want:       "\"exists\"",
+								want:       "\"collect\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 17, offset: 30514},
+								pos:        position{line: 1255, col: 17, offset: 39793},
 								val:        "true",
 								ignoreCase: false,
 								want:       "\"true\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 26, offset: 30523},
+								pos:        position{line: 1255, col: 26, offset: 39802},
 								val:        "false",
 								ignoreCase: false,
 								want:       "\"false\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 36, offset: 30533},
+								pos:        position{line: 1255, col: 36, offset: 39812},
 								val:        "IN",
 								ignoreCase: false,
 								want:       "\"IN\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 43, offset: 30540},
+								pos:        position{line: 1255, col: 43, offset: 39819},
 								val:        "in",
 								ignoreCase: false,
 								want:       "\"in\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 50, offset: 30547},
+								pos:        position{line: 1255, col: 50, offset: 39826},
 								val:        "LIKE",
 								ignoreCase: false,
 								want:       "\"LIKE\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 59, offset: 30556},
+								pos:        position{line: 1255, col: 59, offset: 39835},
 								val:        "like",
 								ignoreCase: false,
 								want:       "\"like\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 68, offset: 30565},
+								pos:        position{line: 1255, col: 68, offset: 39844},
 								val:        "CONTAINS",
 								ignoreCase: false,
 								want:       "\"CONTAINS\"",
 							},
 							&litMatcher{
-								pos:        position{line: 999, col: 81, offset: 30578},
+								pos:        position{line: 1255, col: 81, offset: 39857},
 								val:        "contains",
 								ignoreCase: false,
 								want:       "\"contains\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 17, offset: 30607},
+								pos:        position{line: 1256, col: 17, offset: 39886},
 								val:        "MATCHES",
 								ignoreCase: false,
 								want:       "\"MATCHES\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 29, offset: 30619},
+								pos:        position{line: 1256, col: 29, offset: 39898},
 								val:        "matches",
 								ignoreCase: false,
 								want:       "\"matches\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 41, offset: 30631},
+								pos:        position{line: 1256, col: 41, offset: 39910},
 								val:        "AVG",
 								ignoreCase: false,
 								want:       "\"AVG\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 49, offset: 30639},
+								pos:        position{line: 1256, col: 49, offset: 39918},
 								val:        "avg",
 								ignoreCase: false,
 								want:       "\"avg\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 57, offset: 30647},
+								pos:        position{line: 1256, col: 57, offset: 39926},
 								val:        "COUNT",
 								ignoreCase: false,
 								want:       "\"COUNT\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 67, offset: 30657},
+								pos:        position{line: 1256, col: 67, offset: 39936},
 								val:        "count",
 								ignoreCase: false,
 								want:       "\"count\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 77, offset: 30667},
+								pos:        position{line: 1256, col: 77, offset: 39946},
 								val:        "SUM",
 								ignoreCase: false,
 								want:       "\"SUM\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1000, col: 85, offset: 30675},
+								pos:        position{line: 1256, col: 85, offset: 39954},
 								val:        "sum",
 								ignoreCase: false,
 								want:       "\"sum\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1001, col: 17, offset: 30699},
+								pos:        position{line: 1257, col: 17, offset: 39978},
 								val:        "MIN",
 								ignoreCase: false,
 								want:       "\"MIN\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1001, col: 25, offset: 30707},
+								pos:        position{line: 1257, col: 25, offset: 39986},
 								val:        "min",
 								ignoreCase: false,
 								want:       "\"min\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1001, col: 33, offset: 30715},
+								pos:        position{line: 1257, col: 33, offset: 39994},
 								val:        "MAX",
 								ignoreCase: false,
 								want:       "\"MAX\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1001, col: 41, offset: 30723},
+								pos:        position{line: 1257, col: 41, offset: 40002},
 								val:        "max",
 								ignoreCase: false,
 								want:       "\"max\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1001, col: 49, offset: 30731},
+								pos:        position{line: 1257, col: 49, offset: 40010},
 								val:        "_id_",
 								ignoreCase: false,
 								want:       "\"_id_\"",
@@ -5225,9 +6683,9 @@ var g = &grammar{
 						},
 					},
 					&notExpr{
-						pos: position{line: 1001, col: 57, offset: 30739},
+						pos: position{line: 1257, col: 57, offset: 40018},
 						expr: &ruleRefExpr{
-							pos:  position{line: 1001, col: 58, offset: 30740},
+							pos:  position{line: 1257, col: 58, offset: 40019},
 							name: "IdentContinue",
 						},
 					},
@@ -5236,18 +6694,18 @@ var g = &grammar{
 		},
 		{
 			name: "_",
-			pos:  position{line: 1003, col: 1, offset: 30755},
+			pos:  position{line: 1259, col: 1, offset: 40034},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 1003, col: 6, offset: 30760},
+				pos: position{line: 1259, col: 6, offset: 40039},
 				expr: &choiceExpr{
-					pos: position{line: 1003, col: 7, offset: 30761},
+					pos: position{line: 1259, col: 7, offset: 40040},
 					alternatives: []any{
 						&ruleRefExpr{
-							pos:  position{line: 1003, col: 7, offset: 30761},
+							pos:  position{line: 1259, col: 7, offset: 40040},
 							name: "Whitespace",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 1003, col: 20, offset: 30774},
+							pos:  position{line: 1259, col: 20, offset: 40053},
 							name: "Comment",
 						},
 					},
@@ -5256,9 +6714,9 @@ var g = &grammar{
 		},
 		{
 			name: "Whitespace",
-			pos:  position{line: 1005, col: 1, offset: 30785},
+			pos:  position{line: 1261, col: 1, offset: 40064},
 			expr: &charClassMatcher{
-				pos:        position{line: 1005, col: 15, offset: 30799},
+				pos:        position{line: 1261, col: 15, offset: 40078},
 				val:        "[ \\t\\r\\n]",
 				chars:      []rune{' ', '\t', '\r', '\n'},
 				ignoreCase: false,
@@ -5267,16 +6725,16 @@ var g = &grammar{
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 1007, col: 1, offset: 30810},
+			pos:  position{line: 1263, col: 1, offset: 40089},
 			expr: &choiceExpr{
-				pos: position{line: 1007, col: 12, offset: 30821},
+				pos: position{line: 1263, col: 12, offset: 40100},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 1007, col: 12, offset: 30821},
+						pos:  position{line: 1263, col: 12, offset: 40100},
 						name: "LineComment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1007, col: 26, offset: 30835},
+						pos:  position{line: 1263, col: 26, offset: 40114},
 						name: "BlockComment",
 					},
 				},
@@ -5284,31 +6742,31 @@ var g = &grammar{
 		},
 		{
 			name: "LineComment",
-			pos:  position{line: 1009, col: 1, offset: 30849},
+			pos:  position{line: 1266, col: 1, offset: 40207},
 			expr: &actionExpr{
-				pos: position{line: 1009, col: 16, offset: 30864},
+				pos: position{line: 1266, col: 16, offset: 40222},
 				run: (*parser).callonLineComment1,
 				expr: &seqExpr{
-					pos: position{line: 1009, col: 16, offset: 30864},
+					pos: position{line: 1266, col: 16, offset: 40222},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 1009, col: 16, offset: 30864},
+							pos:        position{line: 1266, col: 16, offset: 40222},
 							val:        "//",
 							ignoreCase: false,
 							want:       "\"//\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 1009, col: 21, offset: 30869},
+							pos:   position{line: 1266, col: 21, offset: 40227},
 							label: "CommentText",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 1009, col: 33, offset: 30881},
+								pos: position{line: 1266, col: 33, offset: 40239},
 								expr: &seqExpr{
-									pos: position{line: 1009, col: 34, offset: 30882},
+									pos: position{line: 1266, col: 34, offset: 40240},
 									exprs: []any{
 										&notExpr{
-											pos: position{line: 1009, col: 34, offset: 30882},
+											pos: position{line: 1266, col: 34, offset: 40240},
 											expr: &charClassMatcher{
-												pos:        position{line: 1009, col: 35, offset: 30883},
+												pos:        position{line: 1266, col: 35, offset: 40241},
 												val:        "[\\r\\n]",
 												chars:      []rune{'\r', '\n'},
 												ignoreCase: false,
@@ -5316,7 +6774,7 @@ var g = &grammar{
 											},
 										},
 										&anyMatcher{
-											line: 1009, col: 42, offset: 30890,
+											line: 1266, col: 42, offset: 40248,
 										},
 									},
 								},
@@ -5328,45 +6786,45 @@ var g = &grammar{
 		},
 		{
 			name: "BlockComment",
-			pos:  position{line: 1013, col: 1, offset: 30959},
+			pos:  position{line: 1271, col: 1, offset: 40298},
 			expr: &actionExpr{
-				pos: position{line: 1013, col: 17, offset: 30975},
+				pos: position{line: 1271, col: 17, offset: 40314},
 				run: (*parser).callonBlockComment1,
 				expr: &seqExpr{
-					pos: position{line: 1013, col: 17, offset: 30975},
+					pos: position{line: 1271, col: 17, offset: 40314},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 1013, col: 17, offset: 30975},
+							pos:        position{line: 1271, col: 17, offset: 40314},
 							val:        "/*",
 							ignoreCase: false,
 							want:       "\"/*\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 1013, col: 22, offset: 30980},
+							pos:   position{line: 1271, col: 22, offset: 40319},
 							label: "CommentText",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 1013, col: 34, offset: 30992},
+								pos: position{line: 1271, col: 34, offset: 40331},
 								expr: &seqExpr{
-									pos: position{line: 1013, col: 35, offset: 30993},
+									pos: position{line: 1271, col: 35, offset: 40332},
 									exprs: []any{
 										&notExpr{
-											pos: position{line: 1013, col: 35, offset: 30993},
+											pos: position{line: 1271, col: 35, offset: 40332},
 											expr: &litMatcher{
-												pos:        position{line: 1013, col: 36, offset: 30994},
+												pos:        position{line: 1271, col: 36, offset: 40333},
 												val:        "*/",
 												ignoreCase: false,
 												want:       "\"*/\"",
 											},
 										},
 										&anyMatcher{
-											line: 1013, col: 41, offset: 30999,
+											line: 1271, col: 41, offset: 40338,
 										},
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 1013, col: 45, offset: 31003},
+							pos:        position{line: 1271, col: 45, offset: 40342},
 							val:        "*/",
 							ignoreCase: false,
 							want:       "\"*/\"",
@@ -5377,11 +6835,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 1017, col: 1, offset: 31073},
+			pos:  position{line: 1276, col: 1, offset: 40393},
 			expr: &notExpr{
-				pos: position{line: 1017, col: 8, offset: 31080},
+				pos: position{line: 1276, col: 8, offset: 40400},
 				expr: &anyMatcher{
-					line: 1017, col: 9, offset: 31081,
+					line: 1276, col: 9, offset: 40401,
 				},
 			},
 		},
@@ -5389,11 +6847,15 @@ var g = &grammar{
 }
 
 func (c *current) onStart1(statements any) (any, error) {
-	// Séparer types, actions, xupleSpaces, expressions, faits, factAssignments, retractions, ruleRemovals et reset
+	// Séparer types, actions, xupleSpaces, expressions, requêtes, faits, factAssignments, retractions, ruleRemovals et reset
+	// Les imports et la déclaration de package sont résolus par le ModuleLoader
+	imports := []interface{}{}
+	packageName := ""
 	types := []interface{}{}
 	actions := []interface{}{}
 	xupleSpaces := []interface{}{}
 	expressions := []interface{}{}
+	queries := []interface{}{}
 	facts := []interface{}{}
 	factAssignments := []interface{}{}
 	retractions := []interface{}{}
@@ -5403,7 +6865,14 @@ func (c *current) onStart1(statements any) (any, error) {
 	if statements != nil {
 		for _, stmt := range statements.([]interface{}) {
 			if stmtMap, ok := stmt.(map[string]interface{}); ok {
-				if stmtMap["type"] == "typeDefinition" {
+				if stmtMap["type"] == "import" {
+					imports = append(imports, stmt)
+				} else if stmtMap["type"] == "packageDeclaration" {
+					if packageName != "" {
+						return nil, fmt.Errorf("déclaration package multiple: '%s' et '%s'", packageName, stmtMap["name"])
+					}
+					packageName = stmtMap["name"].(string)
+				} else if stmtMap["type"] == "typeDefinition" {
 					types = append(types, stmt)
 				} else if stmtMap["type"] == "actionDefinition" {
 					actions = append(actions, stmt)
@@ -5411,6 +6880,8 @@ func (c *current) onStart1(statements any) (any, error) {
 					xupleSpaces = append(xupleSpaces, stmt)
 				} else if stmtMap["type"] == "expression" {
 					expressions = append(expressions, stmt)
+				} else if stmtMap["type"] == "queryDefinition" {
+					queries = append(queries, stmt)
 				} else if stmtMap["type"] == "fact" {
 					facts = append(facts, stmt)
 				} else if stmtMap["type"] == "factAssignment" {
@@ -5427,10 +6898,13 @@ func (c *current) onStart1(statements any) (any, error) {
 	}
 
 	return map[string]interface{}{
+		"package":         packageName,
+		"imports":         imports,
 		"types":           types,
 		"actions":         actions,
 		"xupleSpaces":     xupleSpaces,
 		"expressions":     expressions,
+		"queries":         queries,
 		"facts":           facts,
 		"factAssignments": factAssignments,
 		"retractions":     retractions,
@@ -5452,6 +6926,7 @@ func (c *current) onStatementList1(statements any) (any, error) {
 			result = append(result, stmt.([]interface{})[0])
 		}
 	}
+	recordStatements(c, result)
 	return result, nil
 }
 
@@ -5461,9 +6936,126 @@ func (p *parser) callonStatementList1() (any, error) {
 	return p.cur.onStatementList1(stack["statements"])
 }
 
+func (c *current) onImportStatement1(path any) (any, error) {
+	return map[string]interface{}{
+		"type": "import",
+		"pos":  nodeSpan(c),
+		"path": path.(map[string]interface{})["value"],
+	}, nil
+}
+
+func (p *parser) callonImportStatement1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportStatement1(stack["path"])
+}
+
+func (c *current) onPackageDeclaration1(name any) (any, error) {
+	return map[string]interface{}{
+		"type": "packageDeclaration",
+		"pos":  nodeSpan(c),
+		"name": name,
+	}, nil
+}
+
+func (p *parser) callonPackageDeclaration1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPackageDeclaration1(stack["name"])
+}
+
+func (c *current) onAnnotatedRule1(annotations, rule any) (any, error) {
+	ruleMap := rule.(map[string]interface{})
+	if ruleMap["type"] != "expression" {
+		return nil, fmt.Errorf("les annotations ne s'appliquent qu'aux règles")
+	}
+	list := []interface{}{}
+	for _, annotation := range annotations.([]interface{}) {
+		list = append(list, annotation.([]interface{})[0])
+	}
+	ruleMap["annotations"] = list
+	return ruleMap, nil
+}
+
+func (p *parser) callonAnnotatedRule1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAnnotatedRule1(stack["annotations"], stack["rule"])
+}
+
+func (c *current) onAnnotation1(name, args any) (any, error) {
+	values := []interface{}{}
+	if args != nil {
+		if list := args.([]interface{})[3]; list != nil {
+			values = list.([]interface{})
+		}
+	}
+	return map[string]interface{}{
+		"type": "annotation",
+		"pos":  nodeSpan(c),
+		"name": name,
+		"args": values,
+	}, nil
+}
+
+func (p *parser) callonAnnotation1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAnnotation1(stack["name"], stack["args"])
+}
+
+func (c *current) onAnnotationArgs1(first, rest any) (any, error) {
+	values := []interface{}{first}
+	if rest != nil {
+		for _, item := range rest.([]interface{}) {
+			values = append(values, item.([]interface{})[3])
+		}
+	}
+	return values, nil
+}
+
+func (p *parser) callonAnnotationArgs1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAnnotationArgs1(stack["first"], stack["rest"])
+}
+
+func (c *current) onAnnotationArg2(value any) (any, error) {
+	return value.(map[string]interface{})["value"], nil
+}
+
+func (p *parser) callonAnnotationArg2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAnnotationArg2(stack["value"])
+}
+
+func (c *current) onAnnotationArg5() (any, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonAnnotationArg5() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAnnotationArg5()
+}
+
+func (c *current) onPrivateDeclaration1(decl any) (any, error) {
+	declMap := decl.(map[string]interface{})
+	declMap["visibility"] = "private"
+	return declMap, nil
+}
+
+func (p *parser) callonPrivateDeclaration1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPrivateDeclaration1(stack["decl"])
+}
+
 func (c *current) onReset1() (any, error) {
 	return map[string]interface{}{
 		"type": "reset",
+		"pos":  nodeSpan(c),
 	}, nil
 }
 
@@ -5476,6 +7068,7 @@ func (p *parser) callonReset1() (any, error) {
 func (c *current) onTypeDefinition1(name, fields any) (any, error) {
 	return map[string]interface{}{
 		"type":   "typeDefinition",
+		"pos":    nodeSpan(c),
 		"name":   name,
 		"fields": fields,
 	}, nil
@@ -5511,6 +7104,7 @@ func (c *current) onField1(primaryKey, name, fieldType any) (any, error) {
 	}
 
 	result := map[string]interface{}{
+		"pos":          nodeSpan(c),
 		"name":         name,
 		"type":         fieldType,
 		"isPrimaryKey": false,
@@ -5575,6 +7169,7 @@ func (c *current) onActionDefinition1(name, params any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type":       "actionDefinition",
+		"pos":        nodeSpan(c),
 		"name":       name,
 		"parameters": params,
 	}, nil
@@ -5618,6 +7213,7 @@ func (c *current) onXupleSpaceDeclaration1(name, props any) (any, error) {
 
 	result := map[string]interface{}{
 		"type":              "xupleSpaceDeclaration",
+		"pos":               nodeSpan(c),
 		"name":              name,
 		"selectionPolicy":   selection,
 		"consumptionPolicy": consumption,
@@ -5910,6 +7506,7 @@ func (p *parser) callonParameterList1() (any, error) {
 
 func (c *current) onParameter1(name, paramType, optional, defaultValue any) (any, error) {
 	result := map[string]interface{}{
+		"pos":      nodeSpan(c),
 		"name":     name,
 		"type":     paramType,
 		"optional": optional != nil,
@@ -5942,6 +7539,7 @@ func (c *current) onExpression2(ruleId, patterns, constraints, action any) (any,
 	if len(patternList) == 1 {
 		return map[string]interface{}{
 			"type":        "expression",
+			"pos":         nodeSpan(c),
 			"ruleId":      ruleId,
 			"set":         patternList[0],
 			"constraints": constraints,
@@ -5952,6 +7550,7 @@ func (c *current) onExpression2(ruleId, patterns, constraints, action any) (any,
 	// Multiple pattern blocks (aggregation with joins)
 	return map[string]interface{}{
 		"type":        "expression",
+		"pos":         nodeSpan(c),
 		"ruleId":      ruleId,
 		"patterns":    patterns,
 		"constraints": constraints,
@@ -5972,6 +7571,7 @@ func (c *current) onExpression23(ruleId, patterns, action any) (any, error) {
 	if len(patternList) == 1 {
 		return map[string]interface{}{
 			"type":        "expression",
+			"pos":         nodeSpan(c),
 			"ruleId":      ruleId,
 			"set":         patternList[0],
 			"constraints": nil,
@@ -5982,6 +7582,7 @@ func (c *current) onExpression23(ruleId, patterns, action any) (any, error) {
 	// Multiple pattern blocks without constraints
 	return map[string]interface{}{
 		"type":        "expression",
+		"pos":         nodeSpan(c),
 		"ruleId":      ruleId,
 		"patterns":    patterns,
 		"constraints": nil,
@@ -5995,6 +7596,38 @@ func (p *parser) callonExpression23() (any, error) {
 	return p.cur.onExpression23(stack["ruleId"], stack["patterns"], stack["action"])
 }
 
+func (c *current) onQueryDefinition1(name, params, patterns, constraints any) (any, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	result := map[string]interface{}{
+		"type":        "queryDefinition",
+		"pos":         nodeSpan(c),
+		"name":        name,
+		"parameters":  params,
+		"constraints": nil,
+	}
+	if constraints != nil {
+		result["constraints"] = constraints.([]interface{})[3]
+	}
+
+	// Un seul bloc de motifs : même structure que les règles simples
+	patternList := patterns.([]interface{})
+	if len(patternList) == 1 {
+		result["set"] = patternList[0]
+	} else {
+		result["patterns"] = patterns
+	}
+	return result, nil
+}
+
+func (p *parser) callonQueryDefinition1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQueryDefinition1(stack["name"], stack["params"], stack["patterns"], stack["constraints"])
+}
+
 func (c *current) onPatternBlocks1(first, rest any) (any, error) {
 	blocks := []interface{}{first}
 	if rest != nil {
@@ -6043,6 +7676,7 @@ func (p *parser) callonTypedVariableList1() (any, error) {
 func (c *current) onSimpleTypedVariable1(name, dataType any) (any, error) {
 	return map[string]interface{}{
 		"type":     "typedVariable",
+		"pos":      nodeSpan(c),
 		"name":     name,
 		"dataType": dataType,
 	}, nil
@@ -6054,19 +7688,57 @@ func (p *parser) callonSimpleTypedVariable1() (any, error) {
 	return p.cur.onSimpleTypedVariable1(stack["name"], stack["dataType"])
 }
 
-func (c *current) onAggregationVariable1(name, aggFunc, fieldAccess any) (any, error) {
-	return map[string]interface{}{
+func (c *current) onAggregationVariable1(name, aggFunc, fieldAccess, args any) (any, error) {
+	result := map[string]interface{}{
 		"type":     "aggregationVariable",
+		"pos":      nodeSpan(c),
 		"name":     name,
 		"function": aggFunc,
 		"field":    fieldAccess,
-	}, nil
+	}
+	if len(args.([]interface{})) > 0 {
+		result["args"] = args
+	}
+	return result, nil
 }
 
 func (p *parser) callonAggregationVariable1() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAggregationVariable1(stack["name"], stack["aggFunc"], stack["fieldAccess"])
+	return p.cur.onAggregationVariable1(stack["name"], stack["aggFunc"], stack["fieldAccess"], stack["args"])
+}
+
+func (c *current) onAggregateArguments1(args any) (any, error) {
+	result := []interface{}{}
+	for _, item := range args.([]interface{}) {
+		result = append(result, item.([]interface{})[3])
+	}
+	return result, nil
+}
+
+func (p *parser) callonAggregateArguments1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAggregateArguments1(stack["args"])
+}
+
+func (c *current) onCollectVariable1(name, variable, condition any) (any, error) {
+	result := map[string]interface{}{
+		"type":     "collectVariable",
+		"pos":      nodeSpan(c),
+		"name":     name,
+		"variable": variable,
+	}
+	if condition != nil {
+		result["condition"] = condition.([]interface{})[2]
+	}
+	return result, nil
+}
+
+func (p *parser) callonCollectVariable1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCollectVariable1(stack["name"], stack["variable"], stack["condition"])
 }
 
 func (c *current) onConstraints1(first, rest any) (any, error) {
@@ -6086,6 +7758,7 @@ func (c *current) onConstraints1(first, rest any) (any, error) {
 
 	return map[string]interface{}{
 		"type":       "logicalExpr",
+		"pos":        nodeSpan(c),
 		"left":       first,
 		"operations": operations,
 	}, nil
@@ -6107,24 +7780,26 @@ func (p *parser) callonConstraint2() (any, error) {
 	return p.cur.onConstraint2(stack["expr"])
 }
 
-func (c *current) onConstraint13(left, op, right any) (any, error) {
+func (c *current) onConstraint14(left, op, right any) (any, error) {
 	return map[string]interface{}{
 		"type":     "comparison",
+		"pos":      nodeSpan(c),
 		"left":     left,
 		"operator": op,
 		"right":    right,
 	}, nil
 }
 
-func (p *parser) callonConstraint13() (any, error) {
+func (p *parser) callonConstraint14() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onConstraint13(stack["left"], stack["op"], stack["right"])
+	return p.cur.onConstraint14(stack["left"], stack["op"], stack["right"])
 }
 
 func (c *current) onNotConstraint1(expr any) (any, error) {
 	return map[string]interface{}{
 		"type":       "notConstraint",
+		"pos":        nodeSpan(c),
 		"expression": expr,
 	}, nil
 }
@@ -6138,6 +7813,7 @@ func (p *parser) callonNotConstraint1() (any, error) {
 func (c *current) onExistsConstraint1(variable, condition any) (any, error) {
 	return map[string]interface{}{
 		"type":      "existsConstraint",
+		"pos":       nodeSpan(c),
 		"variable":  variable,
 		"condition": condition,
 	}, nil
@@ -6149,22 +7825,47 @@ func (p *parser) callonExistsConstraint1() (any, error) {
 	return p.cur.onExistsConstraint1(stack["variable"], stack["condition"])
 }
 
+func (c *current) onForallConstraint1(variable, condition, then any) (any, error) {
+	return map[string]interface{}{
+		"type":      "forallConstraint",
+		"pos":       nodeSpan(c),
+		"variable":  variable,
+		"condition": condition,
+		"then":      then,
+	}, nil
+}
+
+func (p *parser) callonForallConstraint1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForallConstraint1(stack["variable"], stack["condition"], stack["then"])
+}
+
 func (c *current) onAccumulateConstraint1(accumFunc, accumVar, accumCond, accumField, accumOp, accumThreshold any) (any, error) {
 	fieldValue := ""
+	var args interface{}
 	if accumField != nil {
 		fieldAccess := accumField.([]interface{})[3].(map[string]interface{})
 		fieldValue = fieldAccess["field"].(string)
+		if extra := accumField.([]interface{})[4].([]interface{}); len(extra) > 0 {
+			args = extra
+		}
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"type":      "accumulateConstraint",
+		"pos":       nodeSpan(c),
 		"function":  accumFunc,
 		"variable":  accumVar,
 		"condition": accumCond,
 		"field":     fieldValue,
 		"operator":  accumOp,
 		"threshold": accumThreshold,
-	}, nil
+	}
+	if args != nil {
+		result["args"] = args
+	}
+	return result, nil
 }
 
 func (p *parser) callonAccumulateConstraint1() (any, error) {
@@ -6184,7 +7885,7 @@ func (p *parser) callonAccumulateFunction2() (any, error) {
 }
 
 func (c *current) onAccumulateFunction7() (any, error) {
-	return "COUNT", nil
+	return "COUNT_DISTINCT", nil
 }
 
 func (p *parser) callonAccumulateFunction7() (any, error) {
@@ -6194,7 +7895,7 @@ func (p *parser) callonAccumulateFunction7() (any, error) {
 }
 
 func (c *current) onAccumulateFunction12() (any, error) {
-	return "SUM", nil
+	return "COUNT", nil
 }
 
 func (p *parser) callonAccumulateFunction12() (any, error) {
@@ -6204,7 +7905,7 @@ func (p *parser) callonAccumulateFunction12() (any, error) {
 }
 
 func (c *current) onAccumulateFunction17() (any, error) {
-	return "MIN", nil
+	return "SUM", nil
 }
 
 func (p *parser) callonAccumulateFunction17() (any, error) {
@@ -6214,7 +7915,7 @@ func (p *parser) callonAccumulateFunction17() (any, error) {
 }
 
 func (c *current) onAccumulateFunction22() (any, error) {
-	return "MAX", nil
+	return "MIN", nil
 }
 
 func (p *parser) callonAccumulateFunction22() (any, error) {
@@ -6223,6 +7924,86 @@ func (p *parser) callonAccumulateFunction22() (any, error) {
 	return p.cur.onAccumulateFunction22()
 }
 
+func (c *current) onAccumulateFunction27() (any, error) {
+	return "MAX", nil
+}
+
+func (p *parser) callonAccumulateFunction27() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction27()
+}
+
+func (c *current) onAccumulateFunction32() (any, error) {
+	return "MEDIAN", nil
+}
+
+func (p *parser) callonAccumulateFunction32() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction32()
+}
+
+func (c *current) onAccumulateFunction37() (any, error) {
+	return "PERCENTILE", nil
+}
+
+func (p *parser) callonAccumulateFunction37() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction37()
+}
+
+func (c *current) onAccumulateFunction42() (any, error) {
+	return "STDDEV", nil
+}
+
+func (p *parser) callonAccumulateFunction42() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction42()
+}
+
+func (c *current) onAccumulateFunction47() (any, error) {
+	return "FIRST", nil
+}
+
+func (p *parser) callonAccumulateFunction47() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction47()
+}
+
+func (c *current) onAccumulateFunction52() (any, error) {
+	return "LAST", nil
+}
+
+func (p *parser) callonAccumulateFunction52() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction52()
+}
+
+func (c *current) onAccumulateFunction57() (any, error) {
+	return "STRING_AGG", nil
+}
+
+func (p *parser) callonAccumulateFunction57() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction57()
+}
+
+func (c *current) onAccumulateFunction62() (any, error) {
+	return "COLLECT_SET", nil
+}
+
+func (p *parser) callonAccumulateFunction62() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccumulateFunction62()
+}
+
 func (c *current) onArithmeticExpr1(first, rest any) (any, error) {
 	if rest == nil || len(rest.([]interface{})) == 0 {
 		return first, nil
@@ -6234,6 +8015,7 @@ func (c *current) onArithmeticExpr1(first, rest any) (any, error) {
 		right := item.([]interface{})[3]
 		result = map[string]interface{}{
 			"type":     "binaryOp",
+			"pos":      spanBetween(c, result, right),
 			"left":     result,
 			"operator": op,
 			"right":    right,
@@ -6259,6 +8041,7 @@ func (c *current) onTerm1(first, rest any) (any, error) {
 		right := item.([]interface{})[3]
 		result = map[string]interface{}{
 			"type":     "binaryOp",
+			"pos":      spanBetween(c, result, right),
 			"left":     result,
 			"operator": op,
 			"right":    right,
@@ -6273,19 +8056,36 @@ func (p *parser) callonTerm1() (any, error) {
 	return p.cur.onTerm1(stack["first"], stack["rest"])
 }
 
-func (c *current) onFactor3(expr any) (any, error) {
+func (c *current) onFactor4(expr any) (any, error) {
 	return expr, nil
 }
 
-func (p *parser) callonFactor3() (any, error) {
+func (p *parser) callonFactor4() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFactor4(stack["expr"])
+}
+
+func (c *current) onTernaryExpression1(condition, then, elseExpr any) (any, error) {
+	return map[string]interface{}{
+		"type":      "ternary",
+		"pos":       nodeSpan(c),
+		"condition": condition,
+		"then":      then,
+		"else":      elseExpr,
+	}, nil
+}
+
+func (p *parser) callonTernaryExpression1() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFactor3(stack["expr"])
+	return p.cur.onTernaryExpression1(stack["condition"], stack["then"], stack["elseExpr"])
 }
 
 func (c *current) onCastExpression1(castType, expr any) (any, error) {
 	return map[string]interface{}{
 		"type":       "cast",
+		"pos":        nodeSpan(c),
 		"castType":   castType,
 		"expression": expr,
 	}, nil
@@ -6330,6 +8130,7 @@ func (p *parser) callonCastType6() (any, error) {
 func (c *current) onFieldAccess1(object, field any) (any, error) {
 	return map[string]interface{}{
 		"type":   "fieldAccess",
+		"pos":    nodeSpan(c),
 		"object": object,
 		"field":  field,
 	}, nil
@@ -6344,6 +8145,7 @@ func (p *parser) callonFieldAccess1() (any, error) {
 func (c *current) onInlineFact1(typeName, fields any) (any, error) {
 	return map[string]interface{}{
 		"type":     "inlineFact",
+		"pos":      nodeSpan(c),
 		"typeName": typeName,
 		"fields":   fields,
 	}, nil
@@ -6387,6 +8189,7 @@ func (p *parser) callonInlineFactField1() (any, error) {
 func (c *current) onVariable1(name any) (any, error) {
 	return map[string]interface{}{
 		"type": "variable",
+		"pos":  nodeSpan(c),
 		"name": name,
 	}, nil
 }
@@ -6403,6 +8206,7 @@ func (c *current) onArrayLiteral1(elements any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type":     "arrayLiteral",
+		"pos":      nodeSpan(c),
 		"elements": elements,
 	}, nil
 }
@@ -6435,6 +8239,7 @@ func (c *current) onObjectLiteral1(fields any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type":   "objectLiteral",
+		"pos":    nodeSpan(c),
 		"fields": fields,
 	}, nil
 }
@@ -6480,6 +8285,7 @@ func (c *current) onFunctionCall1(name, args any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type": "functionCall",
+		"pos":  nodeSpan(c),
 		"name": name,
 		"args": args,
 	}, nil
@@ -6597,23 +8403,87 @@ func (p *parser) callonFunctionArgList1() (any, error) {
 	return p.cur.onFunctionArgList1(stack["first"], stack["rest"])
 }
 
-func (c *current) onAction1(first, rest any) (any, error) {
+func (c *current) onAction1(jobs any) (any, error) {
+	return map[string]interface{}{
+		"type": "action",
+		"jobs": jobs,
+	}, nil
+}
+
+func (p *parser) callonAction1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAction1(stack["jobs"])
+}
+
+func (c *current) onActionStatements1(first, rest any) (any, error) {
 	jobs := []interface{}{first}
 	if rest != nil {
 		for _, item := range rest.([]interface{}) {
 			jobs = append(jobs, item.([]interface{})[3])
 		}
 	}
+	return jobs, nil
+}
+
+func (p *parser) callonActionStatements1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onActionStatements1(stack["first"], stack["rest"])
+}
+
+func (c *current) onLetBinding1(name, value any) (any, error) {
 	return map[string]interface{}{
-		"type": "action",
-		"jobs": jobs,
+		"type":  "letBinding",
+		"pos":   nodeSpan(c),
+		"name":  name,
+		"value": value,
 	}, nil
 }
 
-func (p *parser) callonAction1() (any, error) {
+func (p *parser) callonLetBinding1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLetBinding1(stack["name"], stack["value"])
+}
+
+func (c *current) onIfBlock1(condition, then, elseBlock any) (any, error) {
+	result := map[string]interface{}{
+		"type":      "ifBlock",
+		"pos":       nodeSpan(c),
+		"condition": condition,
+		"then":      then,
+	}
+	if elseBlock != nil {
+		result["else"] = elseBlock.([]interface{})[4]
+	}
+	return result, nil
+}
+
+func (p *parser) callonIfBlock1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIfBlock1(stack["condition"], stack["then"], stack["elseBlock"])
+}
+
+func (c *current) onActionBlock2(jobs any) (any, error) {
+	return jobs, nil
+}
+
+func (p *parser) callonActionBlock2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onActionBlock2(stack["jobs"])
+}
+
+func (c *current) onActionBlock10(job any) (any, error) {
+	return []interface{}{job}, nil
+}
+
+func (p *parser) callonActionBlock10() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAction1(stack["first"], stack["rest"])
+	return p.cur.onActionBlock10(stack["job"])
 }
 
 func (c *current) onJobCall1(name, args any) (any, error) {
@@ -6647,6 +8517,7 @@ func (c *current) onJobCall1(name, args any) (any, error) {
 
 					return map[string]interface{}{
 						"type": "jobCall",
+						"pos":  nodeSpan(c),
 						"name": name,
 						"args": []interface{}{
 							map[string]interface{}{
@@ -6663,6 +8534,7 @@ func (c *current) onJobCall1(name, args any) (any, error) {
 
 	return map[string]interface{}{
 		"type": "jobCall",
+		"pos":  nodeSpan(c),
 		"name": name,
 		"args": args,
 	}, nil
@@ -6813,6 +8685,7 @@ func (p *parser) callonLogicalOp7() (any, error) {
 func (c *current) onBooleanLiteral2() (any, error) {
 	return map[string]interface{}{
 		"type":  "boolean",
+		"pos":   nodeSpan(c),
 		"value": true,
 	}, nil
 
@@ -6827,6 +8700,7 @@ func (p *parser) callonBooleanLiteral2() (any, error) {
 func (c *current) onBooleanLiteral4() (any, error) {
 	return map[string]interface{}{
 		"type":  "boolean",
+		"pos":   nodeSpan(c),
 		"value": false,
 	}, nil
 
@@ -6859,6 +8733,7 @@ func (c *current) onNumber1(sign, digits, decimal any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type":  "number",
+		"pos":   nodeSpan(c),
 		"value": val,
 	}, nil
 }
@@ -6878,6 +8753,7 @@ func (c *current) onStringLiteral2(chars any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type":  "string",
+		"pos":   nodeSpan(c),
 		"value": builder.String(),
 	}, nil
 
@@ -6898,6 +8774,7 @@ func (c *current) onStringLiteral9(chars any) (any, error) {
 	}
 	return map[string]interface{}{
 		"type":  "string",
+		"pos":   nodeSpan(c),
 		"value": builder.String(),
 	}, nil
 
@@ -7037,6 +8914,7 @@ func (p *parser) callonEscapeChar14() (any, error) {
 func (c *current) onRemoveRule1(ruleID any) (any, error) {
 	return map[string]interface{}{
 		"type":   "ruleRemoval",
+		"pos":    nodeSpan(c),
 		"ruleID": ruleID,
 	}, nil
 }
@@ -7050,6 +8928,7 @@ func (p *parser) callonRemoveRule1() (any, error) {
 func (c *current) onRemoveFact1(typeName, factID any) (any, error) {
 	return map[string]interface{}{
 		"type":     "retraction",
+		"pos":      nodeSpan(c),
 		"typeName": typeName,
 		"factID":   factID,
 	}, nil
@@ -7074,6 +8953,7 @@ func (p *parser) callonFactID1() (any, error) {
 func (c *current) onFactAssignment1(variable, fact any) (any, error) {
 	return map[string]interface{}{
 		"type":     "factAssignment",
+		"pos":      nodeSpan(c),
 		"variable": variable,
 		"fact":     fact,
 	}, nil
@@ -7088,6 +8968,7 @@ func (p *parser) callonFactAssignment1() (any, error) {
 func (c *current) onFact1(typeName, fields any) (any, error) {
 	return map[string]interface{}{
 		"type":     "fact",
+		"pos":      nodeSpan(c),
 		"typeName": typeName,
 		"fields":   fields,
 	}, nil
@@ -7123,6 +9004,7 @@ func (c *current) onFactField1(name, value any) (any, error) {
 	}
 
 	return map[string]interface{}{
+		"pos":   nodeSpan(c),
 		"name":  name,
 		"value": value,
 	}, nil
@@ -7182,8 +9064,19 @@ func (p *parser) callonIdentName1() (any, error) {
 	return p.cur.onIdentName1()
 }
 
+func (c *current) onQualifiedName1() (any, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonQualifiedName1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQualifiedName1()
+}
+
 func (c *current) onLineComment1(CommentText any) (any, error) {
-	return nil, nil // Les commentaires ne retournent rien
+	recordComment(c)
+	return nil, nil
 }
 
 func (p *parser) callonLineComment1() (any, error) {
@@ -7193,7 +9086,8 @@ func (p *parser) callonLineComment1() (any, error) {
 }
 
 func (c *current) onBlockComment1(CommentText any) (any, error) {
-	return nil, nil // Les commentaires ne retournent rien
+	recordComment(c)
+	return nil, nil
 }
 
 func (p *parser) callonBlockComment1() (any, error) {
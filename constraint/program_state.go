@@ -6,6 +6,7 @@ package constraint
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 
@@ -26,6 +27,7 @@ type ProgramState struct {
 	filesParsed []string
 	errors      []ValidationError
 	ruleIDs     map[string]bool // Track used rule IDs (reset clears this)
+	modules     *ModuleLoader   // Imported files and package symbols (reset clears this)
 }
 
 // NewProgramState creates a new empty program state
@@ -37,14 +39,16 @@ func NewProgramState() *ProgramState {
 		filesParsed: make([]string, 0),
 		errors:      make([]ValidationError, 0),
 		ruleIDs:     make(map[string]bool),
+		modules:     NewModuleLoader(),
 	}
 }
 
 // ParseAndMerge parses a file and merges the results into the program state
-// It validates that new rules and facts are compatible with existing type definitions
+// It validates that new rules and facts are compatible with existing type definitions.
+// Imported files are loaded first, and only once across calls.
 func (ps *ProgramState) ParseAndMerge(filename string) error {
-	// Parse the file
-	result, err := ParseConstraintFile(filename)
+	// Parse the file and its imports
+	result, err := ps.moduleLoader().Load(filename)
 	if err != nil {
 		return fmt.Errorf("error parsing file %s: %w", filename, err)
 	}
@@ -65,8 +69,8 @@ func (ps *ProgramState) ParseAndMergeContent(content, filename string) error {
 		return fmt.Errorf("content cannot be empty")
 	}
 
-	// Parse the content
-	result, err := ParseConstraint(filename, []byte(content))
+	// Parse the content and its imports
+	result, err := ps.moduleLoader().LoadContent(content, filename)
 	if err != nil {
 		return fmt.Errorf("error parsing content %s: %w", filename, err)
 	}
@@ -84,8 +88,11 @@ func (ps *ProgramState) mergeParseResult(result interface{}, filename string) er
 
 	// Check for reset instructions first
 	if len(program.Resets) > 0 {
-		// Apply reset: clear all existing state including rule IDs
+		// Apply reset: clear all existing state including rule IDs.
+		// The module loader has already handled the reset while loading this file.
+		modules := ps.modules
 		ps.Reset()
+		ps.modules = modules
 		// Note: After reset, we continue to merge the new content from this file
 	}
 
@@ -113,6 +120,14 @@ func (ps *ProgramState) mergeParseResult(result interface{}, filename string) er
 	return nil
 }
 
+// moduleLoader returns the module loader, creating it if needed
+func (ps *ProgramState) moduleLoader() *ModuleLoader {
+	if ps.modules == nil {
+		ps.modules = NewModuleLoader()
+	}
+	return ps.modules
+}
+
 // mergeTypes merges new type definitions into the program state
 func (ps *ProgramState) mergeTypes(newTypes []TypeDefinition, filename string) error {
 	if ps == nil {
@@ -129,6 +144,7 @@ func (ps *ProgramState) mergeTypes(newTypes []TypeDefinition, filename string) e
 		// Create a copy with filename information
 		newType := &TypeDefinition{
 			Type:   typeDef.Type,
+			Pos:    typeDef.Pos,
 			Name:   typeDef.Name,
 			Fields: typeDef.Fields,
 		}
@@ -168,8 +184,9 @@ func (ps *ProgramState) mergeRules(newRules []Expression, filename string) error
 		if rule.RuleId != "" {
 			if ps.ruleIDs[rule.RuleId] {
 				// Non-blocking error: record and skip this rule
-				errMsg := fmt.Sprintf("rule ID '%s' already used, ignoring duplicate rule", rule.RuleId)
-				ps.recordAndSkipError(filename, ErrorTypeRule, errMsg)
+				err := diagnosticf(rule.Pos, DiagnosticCodeDuplicate, "",
+					"rule ID '%s' already used, ignoring duplicate rule", rule.RuleId)
+				ps.recordAndSkipError(filename, ErrorTypeRule, err, rule.Pos)
 				continue
 			}
 		}
@@ -177,15 +194,17 @@ func (ps *ProgramState) mergeRules(newRules []Expression, filename string) error
 		// Create a copy of the rule
 		newRule := &Expression{
 			Type:        rule.Type,
+			Pos:         rule.Pos,
 			RuleId:      rule.RuleId,
 			Set:         rule.Set,
 			Constraints: rule.Constraints,
 			Action:      rule.Action,
+			Annotations: rule.Annotations,
 		}
 
 		// Validate rule against existing types
 		if err := ps.validateRule(newRule, filename); err != nil {
-			ps.recordAndSkipError(filename, ErrorTypeRule, err.Error())
+			ps.recordAndSkipError(filename, ErrorTypeRule, err, rule.Pos)
 			continue
 		}
 
@@ -210,13 +229,14 @@ func (ps *ProgramState) mergeFacts(newFacts []Fact, filename string) error {
 		// Create a copy of the fact
 		newFact := &Fact{
 			Type:     fact.Type,
+			Pos:      fact.Pos,
 			TypeName: fact.TypeName,
 			Fields:   fact.Fields,
 		}
 
 		// Validate fact against existing types
 		if err := ps.validateFact(newFact, filename); err != nil {
-			ps.recordAndSkipError(filename, ErrorTypeFact, err.Error())
+			ps.recordAndSkipError(filename, ErrorTypeFact, err, fact.Pos)
 			continue
 		}
 
@@ -226,15 +246,28 @@ func (ps *ProgramState) mergeFacts(newFacts []Fact, filename string) error {
 	return nil
 }
 
-// recordAndSkipError records a validation error and logs it
-func (ps *ProgramState) recordAndSkipError(filename, errorType, message string) {
-	ps.errors = append(ps.errors, ValidationError{
+// recordAndSkipError records a validation error and logs it. The error is
+// located at the diagnostic it contains, or else at the skipped element (span).
+func (ps *ProgramState) recordAndSkipError(filename, errorType string, err error, span *Span) {
+	span, message := locate(err, span)
+	validationErr := ValidationError{
 		File:    filename,
 		Type:    errorType,
 		Message: message,
-		Line:    0,
-	})
-	tsdio.Printf("⚠️  Skipping invalid %s in %s: %s\n", errorType, filename, message)
+	}
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) {
+		validationErr.Code = diagnostic.Code
+		validationErr.SuggestedFix = diagnostic.SuggestedFix
+	}
+	location := filename
+	if span != nil {
+		validationErr.Line = span.Line
+		validationErr.Column = span.Column
+		location = fmt.Sprintf("%s:%d:%d", filename, span.Line, span.Column)
+	}
+	ps.errors = append(ps.errors, validationErr)
+	tsdio.Printf("⚠️  Skipping invalid %s in %s: %s\n", errorType, location, message)
 }
 
 // validateRule validates a rule against existing type definitions
@@ -279,6 +312,13 @@ func (ps *ProgramState) extractRuleVariables(rule *Expression) map[string]string
 	} else {
 		// Old single-pattern syntax (backward compatibility)
 		for _, variable := range rule.Set.Variables {
+			// Une variable COLLECT lie une liste : seul l'élément collecté est typé
+			if variable.Type == VariableTypeCollect {
+				if variable.Variable != nil {
+					variables[variable.Variable.Name] = variable.Variable.DataType
+				}
+				continue
+			}
 			variables[variable.Name] = variable.DataType
 		}
 	}
@@ -325,7 +365,12 @@ func (ps *ProgramState) validateFact(fact *Fact, filename string) error {
 	// Check if type exists
 	typeDef, exists := ps.types[fact.TypeName]
 	if !exists {
-		return fmt.Errorf("fact references undefined type %s in %s", fact.TypeName, filename)
+		typeNames := make([]string, 0, len(ps.types))
+		for name := range ps.types {
+			typeNames = append(typeNames, name)
+		}
+		return diagnosticf(fact.Pos, DiagnosticCodeUnknownType, didYouMean(fact.TypeName, typeNames),
+			"fact references undefined type %s in %s", fact.TypeName, filename)
 	}
 
 	// Create a map of field definitions for easy lookup
@@ -339,14 +384,19 @@ func (ps *ProgramState) validateFact(fact *Fact, filename string) error {
 		// Find field definition
 		fieldDef, found := fieldDefs[factField.Name]
 		if !found {
-			return fmt.Errorf("fact contains undefined field %s for type %s in %s",
-				factField.Name, fact.TypeName, filename)
+			names := make([]string, len(typeDef.Fields))
+			for i, field := range typeDef.Fields {
+				names[i] = field.Name
+			}
+			return diagnosticf(firstSpan(factField.Pos, fact.Pos), DiagnosticCodeUnknownField, didYouMean(factField.Name, names),
+				"fact contains undefined field %s for type %s in %s", factField.Name, fact.TypeName, filename)
 		}
 
 		// Validate field value type
 		err := ps.validateFactValue(factField.Value, fieldDef.Type)
 		if err != nil {
-			return fmt.Errorf("field %s validation failed in %s: %w", factField.Name, filename, err)
+			return atSpan(firstSpan(factField.Value.Pos, factField.Pos, fact.Pos), DiagnosticCodeTypeMismatch,
+				fmt.Errorf("field %s validation failed in %s: %w", factField.Name, filename, err))
 		}
 	}
 
@@ -438,7 +488,12 @@ func (ps *ProgramState) scanForFieldAccess(data interface{}, variables map[strin
 						}
 					}
 					if !fieldFound {
-						return fmt.Errorf("field %s.%s not found in type %s", object, field, varType)
+						names := make([]string, len(typeDef.Fields))
+						for i, fieldDef := range typeDef.Fields {
+							names[i] = fieldDef.Name
+						}
+						return diagnosticf(SpanOf(v), DiagnosticCodeUnknownField, didYouMean(field, names),
+							"field %s.%s not found in type %s", object, field, varType)
 					}
 				}
 			}
@@ -101,4 +101,5 @@ func (ps *ProgramState) Reset() {
 	ps.filesParsed = make([]string, 0)
 	ps.errors = make([]ValidationError, 0)
 	ps.ruleIDs = make(map[string]bool) // Clear rule IDs on reset
+	ps.modules = NewModuleLoader()
 }
@@ -22,26 +22,26 @@ func NewProgramValidator() *ProgramValidator {
 // Validate valide un programme complet.
 func (pv *ProgramValidator) Validate(program Program) error {
 	if err := pv.validateTypeDefinitions(program.Types); err != nil {
-		return fmt.Errorf("validation des types: %v", err)
+		return fmt.Errorf("validation des types: %w", err)
 	}
 
 	pv.typeSystem = NewTypeSystem(program.Types)
 	pv.factValidator = NewFactValidator(pv.typeSystem)
 
 	if err := pv.typeSystem.ValidateCircularReferences(); err != nil {
-		return fmt.Errorf("validation des types: %v", err)
+		return fmt.Errorf("validation des types: %w", err)
 	}
 
 	if err := pv.validateFactAssignments(program.FactAssignments); err != nil {
-		return fmt.Errorf("validation des affectations: %v", err)
+		return fmt.Errorf("validation des affectations: %w", err)
 	}
 
 	if err := pv.validateFacts(program.Facts); err != nil {
-		return fmt.Errorf("validation des faits: %v", err)
+		return fmt.Errorf("validation des faits: %w", err)
 	}
 
 	if err := pv.validateExpressions(program.Expressions); err != nil {
-		return fmt.Errorf("validation des expressions: %v", err)
+		return fmt.Errorf("validation des expressions: %w", err)
 	}
 
 	return nil
@@ -51,7 +51,7 @@ func (pv *ProgramValidator) Validate(program Program) error {
 func (pv *ProgramValidator) validateTypeDefinitions(types []TypeDefinition) error {
 	for i, typeDef := range types {
 		if err := ValidateTypeDefinition(typeDef); err != nil {
-			return fmt.Errorf("type %d ('%s'): %v", i+1, typeDef.Name, err)
+			return atSpan(typeDef.Pos, DiagnosticCodeInvalidType, fmt.Errorf("type %d ('%s'): %w", i+1, typeDef.Name, err))
 		}
 	}
 
@@ -59,7 +59,8 @@ func (pv *ProgramValidator) validateTypeDefinitions(types []TypeDefinition) erro
 	for i, typeDef := range types {
 		for _, field := range typeDef.Fields {
 			if !ts.IsPrimitiveType(field.Type) && !ts.IsUserDefinedType(field.Type) {
-				return fmt.Errorf("type %d ('%s'): champ '%s' utilise un type non défini '%s'", i+1, typeDef.Name, field.Name, field.Type)
+				return diagnosticf(firstSpan(field.Pos, typeDef.Pos), DiagnosticCodeUnknownType, "",
+					"type %d ('%s'): champ '%s' utilise un type non défini '%s'", i+1, typeDef.Name, field.Name, field.Type)
 			}
 		}
 	}
@@ -71,11 +72,12 @@ func (pv *ProgramValidator) validateTypeDefinitions(types []TypeDefinition) erro
 func (pv *ProgramValidator) validateFactAssignments(assignments []FactAssignment) error {
 	for i, assignment := range assignments {
 		if err := pv.factValidator.ValidateFact(assignment.Fact); err != nil {
-			return fmt.Errorf("affectation %d (variable '%s'): %v", i+1, assignment.Variable, err)
+			return atSpan(assignment.Pos, DiagnosticCodeInvalidFact,
+				fmt.Errorf("affectation %d (variable '%s'): %w", i+1, assignment.Variable, err))
 		}
 
 		if err := pv.typeSystem.RegisterVariable(assignment.Variable, assignment.Fact.TypeName); err != nil {
-			return fmt.Errorf("affectation %d: %v", i+1, err)
+			return atSpan(assignment.Pos, DiagnosticCodeDuplicate, fmt.Errorf("affectation %d: %w", i+1, err))
 		}
 	}
 
@@ -86,7 +88,7 @@ func (pv *ProgramValidator) validateFactAssignments(assignments []FactAssignment
 func (pv *ProgramValidator) validateFacts(facts []Fact) error {
 	for i, fact := range facts {
 		if err := pv.factValidator.ValidateFact(fact); err != nil {
-			return fmt.Errorf("fait %d: %v", i+1, err)
+			return atSpan(fact.Pos, DiagnosticCodeInvalidFact, fmt.Errorf("fait %d: %w", i+1, err))
 		}
 	}
 
@@ -97,7 +99,7 @@ func (pv *ProgramValidator) validateFacts(facts []Fact) error {
 func (pv *ProgramValidator) validateExpressions(expressions []Expression) error {
 	for i, expr := range expressions {
 		if err := pv.validateExpression(expr); err != nil {
-			return fmt.Errorf("expression %d (règle '%s'): %v", i+1, expr.RuleId, err)
+			return atSpan(expr.Pos, DiagnosticCodeInvalidRule, fmt.Errorf("expression %d (règle '%s'): %w", i+1, expr.RuleId, err))
 		}
 	}
 
@@ -200,7 +202,7 @@ func (pv *ProgramValidator) validateConstraintMap(c map[string]interface{}, varT
 func (pv *ProgramValidator) validateComparisonFromMap(c map[string]interface{}, operator string, varTypes map[string]string) error {
 	if left := c["left"]; left != nil {
 		if right := c["right"]; right != nil {
-			return pv.validateComparison(left, right, operator, varTypes)
+			return atSpan(SpanOf(c), DiagnosticCodeTypeMismatch, pv.validateComparison(left, right, operator, varTypes))
 		}
 	}
 	return nil
@@ -243,12 +245,12 @@ func (pv *ProgramValidator) validateLogicalExpression(expr LogicalExpression, va
 func (pv *ProgramValidator) validateComparison(left, right interface{}, operator string, varTypes map[string]string) error {
 	leftType, err := pv.inferExpressionType(left, varTypes)
 	if err != nil {
-		return fmt.Errorf("expression gauche: %v", err)
+		return fmt.Errorf("expression gauche: %w", err)
 	}
 
 	rightType, err := pv.inferExpressionType(right, varTypes)
 	if err != nil {
-		return fmt.Errorf("expression droite: %v", err)
+		return fmt.Errorf("expression droite: %w", err)
 	}
 
 	if !pv.typeSystem.AreTypesCompatible(leftType, rightType, operator) {
@@ -300,7 +302,7 @@ func (pv *ProgramValidator) inferMapExpressionType(e map[string]interface{}, var
 	case ConstraintTypeFieldAccess:
 		object, _ := e["object"].(string)
 		field, _ := e["field"].(string)
-		return pv.inferFieldAccessType(FieldAccess{Object: object, Field: field}, varTypes)
+		return pv.inferFieldAccessType(FieldAccess{Pos: SpanOf(e), Object: object, Field: field}, varTypes)
 
 	case ValueTypeVariable:
 		name, _ := e["name"].(string)
@@ -324,12 +326,13 @@ func (pv *ProgramValidator) inferMapExpressionType(e map[string]interface{}, var
 func (pv *ProgramValidator) inferFieldAccessType(fa FieldAccess, varTypes map[string]string) (string, error) {
 	varType, exists := varTypes[fa.Object]
 	if !exists {
-		return "", fmt.Errorf("variable '%s' non définie dans cette règle", fa.Object)
+		return "", diagnosticf(fa.Pos, DiagnosticCodeUnknownVariable, "",
+			"variable '%s' non définie dans cette règle", fa.Object)
 	}
 
 	fieldType, err := pv.typeSystem.GetFieldType(varType, fa.Field)
 	if err != nil {
-		return "", err
+		return "", atSpan(fa.Pos, DiagnosticCodeUnknownField, err)
 	}
 
 	return fieldType, nil
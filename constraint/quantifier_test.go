@@ -0,0 +1,77 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"strings"
+	"testing"
+)
+
+const quantifierTypes = `type Order(#id: string, status: string)
+type OrderLine(#id: string, order: string, shipped: bool)
+action notify(order: string, count: number)
+`
+
+func TestParseForallConstraint(t *testing.T) {
+	program := parseQueryProgram(t, quantifierTypes+`
+rule allShipped : {o: Order} / o.status == "open" AND FORALL(l: OrderLine / l.order == o.id ==> l.shipped == true) ==> notify(o.id, 0)
+`)
+
+	if err := ValidateConstraintProgram(program); err != nil {
+		t.Fatalf("ValidateConstraintProgram() error = %v", err)
+	}
+
+	constraints, ok := program.Expressions[0].Constraints.(map[string]interface{})
+	if !ok {
+		t.Fatalf("constraints = %T", program.Expressions[0].Constraints)
+	}
+	operations, _ := constraints["operations"].([]interface{})
+	if len(operations) != 1 {
+		t.Fatalf("operations = %v", constraints["operations"])
+	}
+	forall, _ := operations[0].(map[string]interface{})["right"].(map[string]interface{})
+	if forall["type"] != ConstraintTypeForall {
+		t.Errorf("type = %v, want %s", forall["type"], ConstraintTypeForall)
+	}
+	if forall["condition"] == nil || forall["then"] == nil {
+		t.Errorf("forall = %v, condition and then expected", forall)
+	}
+}
+
+func TestParseCollectVariable(t *testing.T) {
+	program := parseQueryProgram(t, quantifierTypes+`
+rule summary : {o: Order, lines: COLLECT(l: OrderLine / l.order == o.id)} / LENGTH(lines) > 0 ==> notify(o.id, LENGTH(lines))
+`)
+
+	if err := ValidateConstraintProgram(program); err != nil {
+		t.Fatalf("ValidateConstraintProgram() error = %v", err)
+	}
+
+	variables := program.Expressions[0].Set.Variables
+	if len(variables) != 2 {
+		t.Fatalf("variables = %v", variables)
+	}
+	lines := variables[1]
+	if lines.Type != VariableTypeCollect || lines.Name != "lines" {
+		t.Errorf("lines = %+v", lines)
+	}
+	if lines.Variable == nil || lines.Variable.Name != "l" || lines.Variable.DataType != "OrderLine" {
+		t.Errorf("element = %+v", lines.Variable)
+	}
+	if lines.Condition == nil {
+		t.Error("condition expected")
+	}
+}
+
+func TestValidateCollectUnknownType(t *testing.T) {
+	program := parseQueryProgram(t, quantifierTypes+`
+rule summary : {o: Order, lines: COLLECT(l: Missing)} / ==> notify(o.id, LENGTH(lines))
+`)
+
+	err := ValidateConstraintProgram(program)
+	if err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("ValidateConstraintProgram() error = %v, want undefined type Missing", err)
+	}
+}
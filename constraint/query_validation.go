@@ -0,0 +1,228 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"fmt"
+)
+
+// queryParameterTypes liste les types autorisés pour les paramètres de requête
+var queryParameterTypes = map[string]bool{
+	ValueTypeString: true,
+	ValueTypeNumber: true,
+	ValueTypeBool:   true,
+}
+
+// QueryExpression construit l'expression équivalente à une requête (sans action).
+// Elle permet de réutiliser les validateurs et constructeurs de règles existants.
+func (q QueryDefinition) QueryExpression(constraints interface{}) Expression {
+	return Expression{
+		Type:        "expression",
+		RuleId:      q.Name,
+		Set:         q.Set,
+		Patterns:    q.Patterns,
+		Constraints: constraints,
+	}
+}
+
+// Variables retourne les variables des motifs de la requête, dans l'ordre de déclaration
+func (q QueryDefinition) Variables() []TypedVariable {
+	if len(q.Patterns) > 0 {
+		variables := make([]TypedVariable, 0)
+		for _, pattern := range q.Patterns {
+			variables = append(variables, pattern.Variables...)
+		}
+		return variables
+	}
+	return q.Set.Variables
+}
+
+// validateQueries valide les déclarations de requêtes d'un programme
+func validateQueries(program Program) error {
+	definedTypes := make(map[string]bool, len(program.Types))
+	for _, typeDef := range program.Types {
+		definedTypes[typeDef.Name] = true
+	}
+
+	seen := make(map[string]bool, len(program.Queries))
+	for _, query := range program.Queries {
+		if seen[query.Name] {
+			return fmt.Errorf("requête '%s' déclarée plusieurs fois", sanitizeForLog(query.Name, 50))
+		}
+		seen[query.Name] = true
+
+		if err := validateQuery(program, query, definedTypes); err != nil {
+			return fmt.Errorf("requête '%s': %v", sanitizeForLog(query.Name, 50), err)
+		}
+	}
+	return nil
+}
+
+// validateQuery valide une requête: paramètres, types des motifs et contraintes
+func validateQuery(program Program, query QueryDefinition, definedTypes map[string]bool) error {
+	variables := make(map[string]bool)
+	for _, variable := range query.Variables() {
+		dataType := declaredVariableType(variable)
+		if variable.Type != "aggregationVariable" && !definedTypes[dataType] {
+			return fmt.Errorf("undefined type: %s for variable %s",
+				sanitizeForLog(dataType, 50), sanitizeForLog(variable.Name, 50))
+		}
+		variables[variable.Name] = true
+	}
+	if len(variables) == 0 {
+		return fmt.Errorf("aucune variable déclarée")
+	}
+
+	placeholders := make(map[string]interface{}, len(query.Parameters))
+	for _, param := range query.Parameters {
+		if !queryParameterTypes[param.Type] {
+			return fmt.Errorf("paramètre '%s': type '%s' non supporté (string, number ou bool attendu)",
+				sanitizeForLog(param.Name, 50), sanitizeForLog(param.Type, 50))
+		}
+		if _, exists := placeholders[param.Name]; exists {
+			return fmt.Errorf("paramètre '%s' déclaré plusieurs fois", sanitizeForLog(param.Name, 50))
+		}
+		if variables[param.Name] {
+			return fmt.Errorf("paramètre '%s' masque une variable de motif", sanitizeForLog(param.Name, 50))
+		}
+		placeholders[param.Name] = zeroValueForParameterType(param.Type)
+	}
+
+	if query.Constraints == nil {
+		return nil
+	}
+
+	// Valider les contraintes avec des valeurs factices typées à la place des paramètres
+	constraints := SubstituteQueryParameters(query.Constraints, placeholders)
+	shadow := Program{
+		Types:       program.Types,
+		Expressions: []Expression{query.QueryExpression(constraints)},
+	}
+	if err := ValidateConstraintFieldAccess(shadow, constraints, 0); err != nil {
+		return fmt.Errorf("erreur validation champs: %v", err)
+	}
+	if err := ValidateTypeCompatibility(shadow, constraints, 0); err != nil {
+		return fmt.Errorf("erreur validation types: %v", err)
+	}
+	return nil
+}
+
+// zeroValueForParameterType retourne une valeur représentative d'un type de paramètre
+func zeroValueForParameterType(paramType string) interface{} {
+	switch paramType {
+	case ValueTypeNumber:
+		return float64(0)
+	case ValueTypeBool:
+		return false
+	default:
+		return ""
+	}
+}
+
+// CoerceQueryArgument vérifie et convertit un argument de requête selon le type du paramètre
+func CoerceQueryArgument(param Parameter, value interface{}) (interface{}, error) {
+	switch param.Type {
+	case ValueTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		case int32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+	case ValueTypeString:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case ValueTypeBool:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("paramètre '%s': valeur de type %T incompatible avec %s",
+		sanitizeForLog(param.Name, 50), value, param.Type)
+}
+
+// SubstituteQueryParameters retourne une copie des contraintes où chaque référence
+// à un paramètre est remplacée par un littéral portant sa valeur.
+// Les contraintes d'origine ne sont pas modifiées.
+func SubstituteQueryParameters(constraints interface{}, values map[string]interface{}) interface{} {
+	switch node := constraints.(type) {
+	case map[string]interface{}:
+		if node["type"] == ValueTypeVariable {
+			if name, ok := node["name"].(string); ok {
+				if value, isParam := values[name]; isParam {
+					return queryParameterLiteral(value)
+				}
+			}
+		}
+		result := make(map[string]interface{}, len(node))
+		for key, child := range node {
+			result[key] = SubstituteQueryParameters(child, values)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(node))
+		for i, child := range node {
+			result[i] = SubstituteQueryParameters(child, values)
+		}
+		return result
+	default:
+		return constraints
+	}
+}
+
+// ReferencesQueryParameters indique si les contraintes référencent au moins un paramètre
+func ReferencesQueryParameters(constraints interface{}, params []Parameter) bool {
+	if len(params) == 0 {
+		return false
+	}
+	names := make(map[string]bool, len(params))
+	for _, param := range params {
+		names[param.Name] = true
+	}
+	return referencesVariables(constraints, names)
+}
+
+// referencesVariables parcourt récursivement l'AST à la recherche de variables nommées
+func referencesVariables(constraints interface{}, names map[string]bool) bool {
+	switch node := constraints.(type) {
+	case map[string]interface{}:
+		if node["type"] == ValueTypeVariable {
+			if name, ok := node["name"].(string); ok && names[name] {
+				return true
+			}
+		}
+		for _, child := range node {
+			if referencesVariables(child, names) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range node {
+			if referencesVariables(child, names) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// queryParameterLiteral construit le littéral AST correspondant à une valeur de paramètre
+func queryParameterLiteral(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case bool:
+		return map[string]interface{}{"type": ValueTypeBoolean, "value": v}
+	case string:
+		return map[string]interface{}{"type": ValueTypeString, "value": v}
+	default:
+		return map[string]interface{}{"type": ValueTypeNumber, "value": v}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"testing"
+)
+
+// parseQueryProgram parse et convertit un programme contenant des requêtes
+func parseQueryProgram(t *testing.T, input string) *Program {
+	t.Helper()
+	result, err := ParseConstraint("query.tsd", []byte(input))
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	program, err := ConvertResultToProgram(result)
+	if err != nil {
+		t.Fatalf("ConvertResultToProgram() error = %v", err)
+	}
+	return program
+}
+
+func TestParseQueryDefinition(t *testing.T) {
+	program := parseQueryProgram(t, `type Person(#id: string, age: number)
+type Order(#ref: string, owner: string)
+query adults(min: number) : {p: Person} / p.age >= min
+query all() : {p: Person}
+query owned(owner: string) : {p: Person, o: Order} / o.owner == p.id AND p.id == owner
+`)
+
+	if len(program.Queries) != 3 {
+		t.Fatalf("len(Queries) = %d, want 3", len(program.Queries))
+	}
+
+	adults := program.Queries[0]
+	if adults.Name != "adults" || len(adults.Parameters) != 1 || adults.Parameters[0].Type != "number" {
+		t.Errorf("unexpected query: %+v", adults)
+	}
+	if adults.Constraints == nil {
+		t.Error("adults should have constraints")
+	}
+
+	all := program.Queries[1]
+	if len(all.Parameters) != 0 || all.Constraints != nil {
+		t.Errorf("unexpected query: %+v", all)
+	}
+	if vars := all.Variables(); len(vars) != 1 || vars[0].Name != "p" {
+		t.Errorf("Variables() = %v", vars)
+	}
+
+	if vars := program.Queries[2].Variables(); len(vars) != 2 {
+		t.Errorf("Variables() = %v, want 2 variables", vars)
+	}
+
+	if err := ValidateConstraintProgram(program); err != nil {
+		t.Errorf("ValidateConstraintProgram() error = %v", err)
+	}
+}
+
+func TestValidateQueries_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"duplicate name", `type Person(#id: string)
+query q() : {p: Person}
+query q() : {p: Person}`},
+		{"unknown type", `type Person(#id: string)
+query q() : {x: Unknown}`},
+		{"unknown field", `type Person(#id: string)
+query q() : {p: Person} / p.missing == 1`},
+		{"duplicate parameter", `type Person(#id: string, age: number)
+query q(a: number, a: number) : {p: Person} / p.age > a`},
+		{"parameter shadows variable", `type Person(#id: string)
+query q(p: string) : {p: Person}`},
+		{"unsupported parameter type", `type Person(#id: string)
+query q(x: Person) : {p: Person}`},
+		{"parameter type mismatch", `type Person(#id: string, age: number)
+query q(name: string) : {p: Person} / p.age > name`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parseQueryProgram(t, tt.input)
+			if err := ValidateConstraintProgram(program); err == nil {
+				t.Error("ValidateConstraintProgram() should fail")
+			}
+		})
+	}
+}
+
+func TestCoerceQueryArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   Parameter
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"int to number", Parameter{Name: "n", Type: "number"}, 18, 18.0, false},
+		{"int64 to number", Parameter{Name: "n", Type: "number"}, int64(3), 3.0, false},
+		{"float number", Parameter{Name: "n", Type: "number"}, 1.5, 1.5, false},
+		{"string", Parameter{Name: "s", Type: "string"}, "x", "x", false},
+		{"bool", Parameter{Name: "b", Type: "bool"}, true, true, false},
+		{"string for number", Parameter{Name: "n", Type: "number"}, "18", nil, true},
+		{"number for bool", Parameter{Name: "b", Type: "bool"}, 1, nil, true},
+		{"nil", Parameter{Name: "s", Type: "string"}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CoerceQueryArgument(tt.param, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CoerceQueryArgument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("CoerceQueryArgument() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteQueryParameters(t *testing.T) {
+	constraints := map[string]interface{}{
+		"type":     "comparison",
+		"operator": ">=",
+		"left":     map[string]interface{}{"type": "fieldAccess", "object": "p", "field": "age"},
+		"right":    map[string]interface{}{"type": "variable", "name": "min"},
+	}
+	params := []Parameter{{Name: "min", Type: "number"}}
+
+	if !ReferencesQueryParameters(constraints, params) {
+		t.Fatal("ReferencesQueryParameters() should be true")
+	}
+
+	substituted := SubstituteQueryParameters(constraints, map[string]interface{}{"min": 18.0})
+	right := substituted.(map[string]interface{})["right"].(map[string]interface{})
+	if right["type"] != ValueTypeNumber || right["value"] != 18.0 {
+		t.Errorf("right = %v, want number literal 18", right)
+	}
+
+	// L'original n'est pas modifié
+	if constraints["right"].(map[string]interface{})["type"] != ValueTypeVariable {
+		t.Error("SubstituteQueryParameters() must not modify its input")
+	}
+	if ReferencesQueryParameters(substituted, params) {
+		t.Error("substituted constraints should no longer reference parameters")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import "fmt"
+
+// Annotations de règles reconnues
+const (
+	AnnotationVersion = "version" // @version("3") : version de la règle
+	AnnotationOwner   = "owner"   // @owner("pricing") : équipe responsable
+	AnnotationTags    = "tags"    // @tags("prix", "promo") : étiquettes libres
+)
+
+// validateRuleAnnotations vérifie les annotations de chaque règle : nom
+// connu, nombre d'arguments, pas de doublon
+func validateRuleAnnotations(program Program) error {
+	for _, expr := range program.Expressions {
+		seen := make(map[string]bool, len(expr.Annotations))
+		for _, annotation := range expr.Annotations {
+			if seen[annotation.Name] {
+				return fmt.Errorf("règle '%s': annotation @%s répétée", expr.RuleId, annotation.Name)
+			}
+			seen[annotation.Name] = true
+
+			switch annotation.Name {
+			case AnnotationVersion, AnnotationOwner:
+				if len(annotation.Args) != 1 || annotation.Args[0] == "" {
+					return fmt.Errorf("règle '%s': @%s attend une valeur non vide", expr.RuleId, annotation.Name)
+				}
+			case AnnotationTags:
+				if len(annotation.Args) == 0 {
+					return fmt.Errorf("règle '%s': @%s attend au moins une étiquette", expr.RuleId, annotation.Name)
+				}
+			default:
+				return fmt.Errorf("règle '%s': annotation inconnue @%s (attendu: @%s, @%s, @%s)",
+					expr.RuleId, annotation.Name, AnnotationVersion, AnnotationOwner, AnnotationTags)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package constraint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const annotationsTestHeader = `type Product(#id: string, price: number)
+action notify(id: string)
+`
+
+func TestParseRuleAnnotations(t *testing.T) {
+	input := annotationsTestHeader + `@version("3") @owner("pricing")
+@tags("prix", "promo")
+rule discount : {p: Product} / p.price > 100 ==> notify(p.id)
+
+@version(2) private rule hidden : {p: Product} / ==> notify(p.id)
+
+rule plain : {p: Product} / ==> notify(p.id)
+`
+	ast, err := ParseConstraint("annotations.tsd", []byte(input))
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	if err := ValidateConstraintProgram(ast); err != nil {
+		t.Fatalf("ValidateConstraintProgram() error = %v", err)
+	}
+	program, err := ConvertResultToProgram(ast)
+	if err != nil {
+		t.Fatalf("ConvertResultToProgram() error = %v", err)
+	}
+
+	want := map[string][]Annotation{
+		"discount": {
+			{Type: "annotation", Name: AnnotationVersion, Args: []string{"3"}},
+			{Type: "annotation", Name: AnnotationOwner, Args: []string{"pricing"}},
+			{Type: "annotation", Name: AnnotationTags, Args: []string{"prix", "promo"}},
+		},
+		"hidden": {{Type: "annotation", Name: AnnotationVersion, Args: []string{"2"}}},
+		"plain":  nil,
+	}
+	for _, expr := range program.Expressions {
+		if !reflect.DeepEqual(expr.Annotations, want[expr.RuleId]) {
+			t.Errorf("%s annotations = %+v, want %+v", expr.RuleId, expr.Annotations, want[expr.RuleId])
+		}
+	}
+}
+
+func TestParseRuleAnnotations_OnlyRules(t *testing.T) {
+	if _, err := ParseConstraint("annotations.tsd", []byte(`@owner("x") type Product(#id: string)`)); err == nil {
+		t.Errorf("annotation on a type should not parse")
+	}
+}
+
+func TestValidateRuleAnnotations(t *testing.T) {
+	tests := []struct {
+		annotations string
+		wantErr     string
+	}{
+		{`@color("red")`, "annotation inconnue @color"},
+		{`@version("1") @version("2")`, "@version répétée"},
+		{`@owner("a", "b")`, "@owner attend une valeur non vide"},
+		{`@version`, "@version attend une valeur non vide"},
+		{`@tags()`, "@tags attend au moins une étiquette"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.annotations, func(t *testing.T) {
+			input := annotationsTestHeader + tt.annotations + "\nrule r : {p: Product} / ==> notify(p.id)\n"
+			ast, err := ParseConstraint("annotations.tsd", []byte(input))
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			err = ValidateConstraintProgram(ast)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateConstraintProgram() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
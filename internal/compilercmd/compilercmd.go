@@ -46,12 +46,13 @@ const (
 
 // Error messages
 var (
-	ErrNoSource        = errors.New("aucune source spécifiée (-file, -text ou -stdin)")
-	ErrMultipleSources = errors.New("une seule source autorisée (-file, -text ou -stdin)")
-	ErrFileNotFound    = errors.New("fichier non trouvé")
-	ErrInputTooLarge   = errors.New("entrée trop volumineuse")
-	ErrInvalidPath     = errors.New("chemin de fichier non valide")
-	ErrPathTraversal   = errors.New("tentative de traversée de répertoire interdite")
+	ErrNoSource          = errors.New("aucune source spécifiée (-file, -text ou -stdin)")
+	ErrMultipleSources   = errors.New("une seule source autorisée (-file, -text ou -stdin)")
+	ErrFileNotFound      = errors.New("fichier non trouvé")
+	ErrInputTooLarge     = errors.New("entrée trop volumineuse")
+	ErrInvalidPath       = errors.New("chemin de fichier non valide")
+	ErrPathTraversal     = errors.New("tentative de traversée de répertoire interdite")
+	ErrFactFilesSimulate = errors.New("-facts-csv et -facts-ndjson ne sont pas disponibles avec -simulate")
 )
 
 // Config holds the CLI configuration
@@ -62,8 +63,14 @@ type Config struct {
 	UseStdin       bool
 	FactsFile      string // Deprecated: use File instead
 	Verbose        bool
+	Simulate       bool         // Exécuter en simulation et afficher le rapport
+	JSON           bool         // Rapport de simulation et diagnostics au format JSON
+	FactSources    []factSource // Fichiers CSV et NDJSON chargés après le programme
+	FactsMap       string       // Correspondance colonne=champ des fichiers chargés
 	ShowVersion    bool
 	ShowHelp       bool
+
+	stdinContent string // Programme lu depuis stdin (rejoué par la simulation)
 }
 
 // Result holds the execution result
@@ -102,6 +109,7 @@ func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 	result, sourceName, err := parseConstraintSource(config, stdin)
 	if err != nil {
 		fmt.Fprintf(stderr, "Erreur de parsing: %v\n", err)
+		reportDiagnostics(config, err, stdout, stderr)
 		return ExitErrorParsing
 	}
 
@@ -112,6 +120,7 @@ func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 
 	if err := constraint.ValidateConstraintProgram(result); err != nil {
 		fmt.Fprintf(stderr, "Erreur de validation: %v\n", err)
+		reportDiagnostics(config, err, stdout, stderr)
 		return ExitErrorValidation
 	}
 
@@ -119,6 +128,14 @@ func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 		fmt.Fprintf(stdout, "✅ Contraintes validées avec succès\n")
 	}
 
+	if config.Simulate {
+		return runSimulation(config, stdout, stderr)
+	}
+
+	if len(config.FactSources) > 0 {
+		return runWithFactFiles(config, stdout, stderr)
+	}
+
 	if config.FactsFile != "" {
 		return runWithFacts(config, sourceName, stdout, stderr)
 	}
@@ -137,6 +154,11 @@ func ParseFlags(args []string) (*Config, error) {
 	flagSet.BoolVar(&config.UseStdin, "stdin", false, "Lire depuis stdin")
 	flagSet.StringVar(&config.FactsFile, "facts", "", "Deprecated: use -file instead (fichier .facts)")
 	flagSet.BoolVar(&config.Verbose, "v", false, "Mode verbeux")
+	flagSet.BoolVar(&config.Simulate, "simulate", false, "Simuler l'exécution sans effet de bord et afficher le rapport")
+	flagSet.BoolVar(&config.JSON, "json", false, "Rapport de simulation et diagnostics au format JSON")
+	flagSet.Var(factSources{rete.FactFormatCSV, &config.FactSources}, "facts-csv", "Charger les faits d'un fichier CSV (Type=fichier.csv, répétable)")
+	flagSet.Var(factSources{rete.FactFormatNDJSON, &config.FactSources}, "facts-ndjson", "Charger les faits d'un fichier NDJSON ([Type=]fichier.ndjson, répétable)")
+	flagSet.StringVar(&config.FactsMap, "facts-map", "", "Correspondance colonne=champ des fichiers chargés (colonne= pour l'ignorer)")
 	flagSet.BoolVar(&config.ShowVersion, "version", false, "Afficher la version")
 	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
 
@@ -179,6 +201,10 @@ func validateConfig(config *Config) error {
 		return ErrMultipleSources
 	}
 
+	if len(config.FactSources) > 0 && config.Simulate {
+		return ErrFactFilesSimulate
+	}
+
 	return nil
 }
 
@@ -210,9 +236,13 @@ func parseFromStdin(config *Config, stdin io.Reader) (interface{}, string, error
 	if len(stdinContent) > MaxStdinRead {
 		return nil, "", fmt.Errorf("%w: maximum %d bytes", ErrInputTooLarge, MaxStdinRead)
 	}
+	config.stdinContent = string(stdinContent)
 
-	result, err := constraint.ParseConstraint(sourceName, stdinContent)
-	return result, sourceName, err
+	result, err := constraint.NewModuleLoader().LoadContent(string(stdinContent), sourceName)
+	if err != nil {
+		return nil, sourceName, err
+	}
+	return result, sourceName, nil
 }
 
 // parseFromText parses constraints from a text string
@@ -224,8 +254,11 @@ func parseFromText(config *Config) (interface{}, string, error) {
 		return nil, "", fmt.Errorf("%w: maximum %d bytes", ErrInputTooLarge, MaxInputSize)
 	}
 
-	result, err := constraint.ParseConstraint(sourceName, []byte(config.ConstraintText))
-	return result, sourceName, err
+	result, err := constraint.NewModuleLoader().LoadContent(config.ConstraintText, sourceName)
+	if err != nil {
+		return nil, sourceName, err
+	}
+	return result, sourceName, nil
 }
 
 // parseFromFile parses constraints from a file
@@ -251,7 +284,7 @@ func parseFromFile(config *Config) (interface{}, string, error) {
 		return nil, "", fmt.Errorf("%w: fichier %s dépasse %d bytes", ErrInputTooLarge, config.File, MaxInputSize)
 	}
 
-	result, err := constraint.ParseConstraintFile(config.File)
+	result, err := constraint.ParseConstraintFileWithImports(config.File)
 	return result, sourceName, err
 }
 
@@ -466,6 +499,12 @@ func printHelp(w io.Writer) {
 	fmt.Fprintln(w, "  -facts <file>       [DEPRECATED] Use -file instead")
 	fmt.Fprintln(w, "  -constraint <file>  [DEPRECATED] Use -file instead")
 	fmt.Fprintln(w, "  -v                  Mode verbeux (affiche plus de détails)")
+	fmt.Fprintln(w, "  -simulate           Simuler : actions enregistrées, aucun effet de bord")
+	fmt.Fprintln(w, "  -facts-csv <T=f>    Charger les faits de type T du fichier CSV f (répétable)")
+	fmt.Fprintln(w, "  -facts-ndjson <f>   Charger les faits d'un fichier NDJSON, type dans la colonne")
+	fmt.Fprintln(w, "                      \"type\" ou imposé par T=f (répétable)")
+	fmt.Fprintln(w, "  -facts-map <c=f,..> Renommer les colonnes en champs (c= pour ignorer)")
+	fmt.Fprintln(w, "  -json               Rapport de simulation et diagnostics au format JSON")
 	fmt.Fprintln(w, "  -version            Afficher la version")
 	fmt.Fprintln(w, "  -h                  Afficher cette aide")
 	fmt.Fprintln(w, "")
@@ -475,6 +514,8 @@ func printHelp(w io.Writer) {
 	fmt.Fprintln(w, "  tsd -text 'type Person : <id: string, name: string>'")
 	fmt.Fprintln(w, "  echo 'type Person : <id: string>' | tsd -stdin")
 	fmt.Fprintln(w, "  cat program.tsd | tsd -stdin -v")
+	fmt.Fprintln(w, "  tsd -simulate rules.tsd")
+	fmt.Fprintln(w, "  tsd -facts-csv Person=people.csv -facts-ndjson events.ndjson rules.tsd")
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "FORMAT DE FICHIER:")
 	fmt.Fprintln(w, "  .tsd : Fichiers TSD (types, facts, rules)")
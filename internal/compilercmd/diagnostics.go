@@ -0,0 +1,110 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package compilercmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// reportDiagnostics affiche les diagnostics d'une erreur de parsing ou de
+// validation : sur stderr avec un extrait du source, ou sur stdout en JSON
+// avec -json. Rien n'est affiché si aucun diagnostic n'est localisé.
+func reportDiagnostics(config *Config, err error, stdout, stderr io.Writer) {
+	diagnostics := constraint.Diagnostics(err)
+	located := false
+	for _, diagnostic := range diagnostics {
+		located = located || diagnostic.Span != nil
+	}
+	if !located {
+		return
+	}
+
+	if config.JSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(struct {
+			Diagnostics []constraint.Diagnostic `json:"diagnostics"`
+		}{diagnostics})
+		return
+	}
+
+	sources := make(map[string][]string)
+	for _, diagnostic := range diagnostics {
+		printDiagnostic(stderr, diagnostic, sourceLines(config, diagnostic.Span, sources))
+	}
+}
+
+// printDiagnostic affiche un diagnostic suivi de la ligne concernée, d'un
+// repère sous la portion signalée et de la correction proposée
+func printDiagnostic(w io.Writer, diagnostic constraint.Diagnostic, lines []string) {
+	// La correction proposée est affichée sous l'extrait
+	header := diagnostic
+	header.SuggestedFix = ""
+	fmt.Fprintln(w, header.String())
+
+	span := diagnostic.Span
+	if span != nil && span.Line >= 1 && span.Line <= len(lines) {
+		line := lines[span.Line-1]
+		runes := []rune(line)
+		start := min(max(span.Column, 1), len(runes)+1)
+		width := 1
+		if span.EndLine == span.Line && span.EndColumn > start {
+			width = span.EndColumn - start
+		}
+
+		// Les tabulations sont conservées pour que le repère reste aligné
+		var padding strings.Builder
+		for _, r := range runes[:start-1] {
+			if r == '\t' {
+				padding.WriteRune('\t')
+			} else {
+				padding.WriteRune(' ')
+			}
+		}
+		gutter := fmt.Sprintf("%4d", span.Line)
+		fmt.Fprintf(w, "%s | %s\n", gutter, line)
+		fmt.Fprintf(w, "%s | %s^%s\n", strings.Repeat(" ", len(gutter)), padding.String(), strings.Repeat("~", width-1))
+	}
+
+	if diagnostic.SuggestedFix != "" {
+		fmt.Fprintf(w, "     = correction : %s\n", diagnostic.SuggestedFix)
+	}
+}
+
+// sourceLines retourne les lignes du source désigné par span : stdin, texte
+// de -text ou fichier (lu une seule fois)
+func sourceLines(config *Config, span *constraint.Span, cache map[string][]string) []string {
+	if span == nil {
+		return nil
+	}
+	if lines, ok := cache[span.File]; ok {
+		return lines
+	}
+
+	var content string
+	switch span.File {
+	case SourceNameStdin:
+		content = config.stdinContent
+	case SourceNameText:
+		content = config.ConstraintText
+	default:
+		data, err := os.ReadFile(span.File)
+		if err != nil {
+			cache[span.File] = nil
+			return nil
+		}
+		content = string(data)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	cache[span.File] = lines
+	return lines
+}
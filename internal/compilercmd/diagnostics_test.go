@@ -0,0 +1,85 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package compilercmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+const diagnosticProgram = `type Person(#id: string, age: number)
+action log(id: string)
+rule adult : {p: Person} / p.agee >= 18 ==> log(p.id)
+`
+
+func TestRun_DiagnosticExcerpt(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "people.tsd")
+	if err := os.WriteFile(file, []byte(diagnosticProgram), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := Run([]string{file}, nil, stdout, stderr); code != ExitErrorValidation {
+		t.Fatalf("Run() exitCode = %d, want %d", code, ExitErrorValidation)
+	}
+
+	output := stderr.String()
+	for _, want := range []string{
+		"Erreur de validation",
+		file + ":3:28: error[unknown-field]:",
+		"   3 | rule adult : {p: Person} / p.agee >= 18 ==> log(p.id)",
+		"     |                            ^~~~~~\n",
+		"= correction : remplacer 'agee' par 'age'",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("stderr missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRun_DiagnosticSyntaxFromText(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := Run([]string{"-text", "type P(#id: string)\nrule r  {p: P} / p.id == \"a\""}, nil, stdout, stderr); code != ExitErrorParsing {
+		t.Fatalf("Run() exitCode = %d, want %d", code, ExitErrorParsing)
+	}
+
+	output := stderr.String()
+	for _, want := range []string{
+		"<text>:2:9: error[syntax]: no match found\n",
+		"   2 | rule r  {p: P} / p.id == \"a\"\n     |         ^\n",
+		`= correction : attendu : "/*", "//", ":"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("stderr missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRun_DiagnosticJSON(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := Run([]string{"-json", "-text", diagnosticProgram}, nil, stdout, stderr); code != ExitErrorValidation {
+		t.Fatalf("Run() exitCode = %d, want %d", code, ExitErrorValidation)
+	}
+
+	var output struct {
+		Diagnostics []constraint.Diagnostic `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(output.Diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v, want 1", output.Diagnostics)
+	}
+	d := output.Diagnostics[0]
+	if d.Code != constraint.DiagnosticCodeUnknownField || d.Span == nil || *d.Span != (constraint.Span{File: SourceNameText, Line: 3, Column: 28, EndLine: 3, EndColumn: 34}) {
+		t.Errorf("diagnostic = %+v (span %+v)", d, d.Span)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package compilercmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// DefaultFactsTypeField est la colonne portant le type des enregistrements
+// NDJSON chargés sans type explicite (-facts-ndjson fichier.ndjson)
+const DefaultFactsTypeField = "type"
+
+// factSource est un fichier de faits à charger en masse
+type factSource struct {
+	Format rete.FactFormat
+	Type   string // Vide : type lu dans DefaultFactsTypeField
+	Path   string
+}
+
+// factSources collecte les valeurs [Type=]fichier d'une option répétable
+type factSources struct {
+	format  rete.FactFormat
+	sources *[]factSource
+}
+
+func (s factSources) String() string {
+	if s.sources == nil {
+		return ""
+	}
+	values := make([]string, 0, len(*s.sources))
+	for _, source := range *s.sources {
+		if source.Format == s.format {
+			values = append(values, source.Path)
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+func (s factSources) Set(value string) error {
+	source := factSource{Format: s.format, Path: value}
+	if i := strings.Index(value, "="); i > 0 && isTypeName(value[:i]) {
+		source.Type, source.Path = value[:i], value[i+1:]
+	}
+	if source.Path == "" {
+		return fmt.Errorf("fichier manquant dans '%s'", value)
+	}
+	if source.Type == "" && s.format == rete.FactFormatCSV {
+		return fmt.Errorf("type requis pour un fichier CSV: Type=%s", value)
+	}
+	*s.sources = append(*s.sources, source)
+	return nil
+}
+
+// isTypeName indique si la chaîne peut être un nom de type TSD
+func isTypeName(name string) bool {
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// parseFactsMap lit la correspondance colonne=champ,colonne= de -facts-map
+func parseFactsMap(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	fields := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		column, field, ok := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.TrimSpace(field)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("correspondance '%s' invalide (colonne=champ attendu)", pair)
+		}
+		fields[column] = field
+	}
+	return fields, nil
+}
+
+// runWithFactFiles exécute le programme puis charge les fichiers CSV et NDJSON
+// dans ses types. Les enregistrements rejetés sont listés sur stderr et
+// donnent un code de sortie en erreur, les autres restent chargés.
+func runWithFactFiles(config *Config, stdout, stderr io.Writer) int {
+	fields, err := parseFactsMap(config.FactsMap)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitErrorGeneric
+	}
+
+	pipelineConfig := api.DefaultConfig()
+	if !config.Verbose {
+		pipelineConfig.LogLevel = api.LogLevelSilent
+	}
+	pipeline := api.NewPipelineWithConfig(pipelineConfig)
+
+	var result *api.Result
+	switch {
+	case config.File != "":
+		result, err = pipeline.IngestFile(config.File)
+	case config.ConstraintText != "":
+		result, err = pipeline.IngestString(config.ConstraintText)
+	default:
+		result, err = pipeline.IngestString(config.stdinContent)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur pipeline RETE: %v\n", err)
+		return ExitErrorExecution
+	}
+
+	rejected := 0
+	for _, source := range config.FactSources {
+		report, err := loadFactSource(pipeline, source, fields)
+		if err != nil {
+			fmt.Fprintf(stderr, "Erreur chargement %s: %v\n", source.Path, err)
+			return ExitErrorExecution
+		}
+		for _, rowErr := range report.Errors {
+			fmt.Fprintf(stderr, "%s: %v\n", source.Path, rowErr)
+		}
+		rejected += len(report.Errors)
+		fmt.Fprintf(stdout, "📥 %s: %d/%d fait(s) chargé(s)\n", source.Path, report.Loaded, report.Records)
+	}
+
+	network := result.Network()
+	printResults(config, &Result{
+		Network:     network,
+		Facts:       network.Storage.GetAllFacts(),
+		Activations: countActivations(network),
+	}, stdout)
+
+	if rejected > 0 {
+		fmt.Fprintf(stderr, "❌ %d enregistrement(s) rejeté(s)\n", rejected)
+		return ExitErrorValidation
+	}
+	return ExitSuccess
+}
+
+// loadFactSource charge un fichier de faits dans le pipeline
+func loadFactSource(pipeline *api.Pipeline, source factSource, fields map[string]string) (*rete.FactLoadReport, error) {
+	if err := validateFilePath(source.Path); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(source.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	mapping := rete.FactMapping{Type: source.Type, Fields: fields}
+	if source.Type == "" {
+		mapping.TypeField = DefaultFactsTypeField
+	}
+	return pipeline.LoadFacts(file, source.Format, mapping)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package compilercmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const testFactsProgram = `type Person(#id: string, age: number)
+type Visit(#id: string, person: Person, paid: bool)
+rule adults : {p: Person} / p.age >= 18 ==> Log(p.id)`
+
+// writeFactsFile écrit un fichier de faits dans un répertoire temporaire
+func writeFactsFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestRun_FactFiles(t *testing.T) {
+	people := writeFactsFile(t, "people.csv", "name,age,note\np1,42,x\np2,abc,y\n")
+	visits := writeFactsFile(t, "visits.ndjson", `{"type": "Visit", "id": "v1", "person": "p1", "paid": "true"}
+{"type": "Visit", "id": "v2", "person": "p1"}
+`)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	exitCode := Run([]string{
+		"-facts-csv", "Person=" + people,
+		"-facts-ndjson", visits,
+		"-facts-map", "name=id,note=",
+		"-text", testFactsProgram,
+	}, nil, stdout, stderr)
+	if exitCode != ExitErrorValidation {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", exitCode, ExitErrorValidation, stderr.String())
+	}
+
+	for _, want := range []string{people + ": 1/2 fait(s) chargé(s)", visits + ": 1/2 fait(s) chargé(s)"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout missing %q:\n%s", want, stdout.String())
+		}
+	}
+	for _, want := range []string{people + ": ligne 3: colonne 'age'", visits + ": ligne 2: champ 'paid' manquant", "2 enregistrement(s) rejeté(s)"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr.String())
+		}
+	}
+}
+
+func TestParseFlags_FactFiles(t *testing.T) {
+	config, err := ParseFlags([]string{"-facts-csv", "Person=a.csv", "-facts-ndjson", "b.ndjson", "-facts-ndjson", "Visit=c=1.ndjson", "rules.tsd"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	want := []factSource{
+		{Format: rete.FactFormatCSV, Type: "Person", Path: "a.csv"},
+		{Format: rete.FactFormatNDJSON, Path: "b.ndjson"},
+		{Format: rete.FactFormatNDJSON, Type: "Visit", Path: "c=1.ndjson"},
+	}
+	if len(config.FactSources) != len(want) {
+		t.Fatalf("FactSources = %+v", config.FactSources)
+	}
+	for i := range want {
+		if config.FactSources[i] != want[i] {
+			t.Errorf("FactSources[%d] = %+v, want %+v", i, config.FactSources[i], want[i])
+		}
+	}
+
+	if _, err := ParseFlags([]string{"-facts-csv", "people.csv"}); err == nil {
+		t.Error("ParseFlags() should require a type for CSV files")
+	}
+	if _, err := parseFactsMap("a=b,c"); err == nil {
+		t.Error("parseFactsMap() should reject a pair without '='")
+	}
+}
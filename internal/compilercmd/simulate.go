@@ -0,0 +1,166 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package compilercmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// runSimulation exécute le programme en simulation et affiche le rapport :
+// activations, faits insérés, modifiés ou rétractés et xuples qui auraient
+// été créés. Aucune action externe n'est exécutée.
+func runSimulation(config *Config, stdout, stderr io.Writer) int {
+	pipelineConfig := api.DefaultConfig()
+	pipelineConfig.LogLevel = api.LogLevelSilent
+	pipeline := api.NewPipelineWithConfig(pipelineConfig)
+
+	// Les traces du moteur vont sur stderr : stdout ne contient que le rapport
+	realStdout := os.Stdout
+	os.Stdout = os.Stderr
+	var report *rete.SimulationReport
+	var err error
+	switch {
+	case config.File != "":
+		report, err = pipeline.SimulateFile(context.Background(), config.File)
+	case config.ConstraintText != "":
+		report, err = pipeline.Simulate(context.Background(), config.ConstraintText)
+	default:
+		report, err = pipeline.Simulate(context.Background(), config.stdinContent)
+	}
+	os.Stdout = realStdout
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur de simulation: %v\n", err)
+		return ExitErrorExecution
+	}
+
+	if config.JSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitErrorGeneric
+		}
+		return ExitSuccess
+	}
+	PrintSimulationReport(stdout, report)
+	return ExitSuccess
+}
+
+// PrintSimulationReport affiche un rapport de simulation sous forme de texte
+func PrintSimulationReport(w io.Writer, report *rete.SimulationReport) {
+	fmt.Fprintf(w, "🧪 Simulation: %d activation(s), %d fait(s) modifié(s), %d xuple(s)\n",
+		len(report.Activations), len(report.Facts), len(report.Xuples))
+
+	if len(report.Activations) > 0 {
+		fmt.Fprintf(w, "\nActivations:\n")
+	}
+	for _, activation := range report.Activations {
+		fmt.Fprintf(w, "  %d. %s [%s]\n", activation.Sequence, activation.Rule, formatBindings(activation.Bindings))
+		for _, action := range activation.Actions {
+			mark := "enregistrée"
+			if action.Applied {
+				mark = "appliquée"
+			}
+			fmt.Fprintf(w, "       %s(%s) (%s)\n", action.Name, formatValues(action.Args), mark)
+		}
+		if activation.Error != "" {
+			fmt.Fprintf(w, "       ❌ %s\n", activation.Error)
+		}
+	}
+
+	if len(report.Facts) > 0 {
+		fmt.Fprintf(w, "\nFaits:\n")
+	}
+	for _, change := range report.Facts {
+		switch change.Change {
+		case rete.FactChangeInserted:
+			fmt.Fprintf(w, "  + %s %s\n", change.FactID, formatFields(change.After))
+		case rete.FactChangeRetracted:
+			fmt.Fprintf(w, "  - %s %s\n", change.FactID, formatFields(change.Before))
+		default:
+			fmt.Fprintf(w, "  ~ %s %s\n", change.FactID, formatFieldChanges(change.Before, change.After))
+		}
+	}
+
+	if len(report.Xuples) > 0 {
+		fmt.Fprintf(w, "\nXuples:\n")
+	}
+	for _, xuple := range report.Xuples {
+		fmt.Fprintf(w, "  %s: %s %s ← %s\n", xuple.Space, xuple.Type, formatFields(xuple.Fields), strings.Join(xuple.Triggers, ", "))
+	}
+}
+
+// formatBindings formate les variables liées, triées par nom
+func formatBindings(bindings map[string]string) string {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + bindings[name]
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatValues formate des arguments d'action
+func formatValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		if text, ok := value.(string); ok {
+			parts[i] = fmt.Sprintf("%q", text)
+		} else {
+			parts[i] = fmt.Sprint(value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedFieldNames retourne les noms de champs triés
+func sortedFieldNames(fields ...map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, values := range fields {
+		for name := range values {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatFields formate les champs d'un fait, triés par nom
+func formatFields(fields map[string]interface{}) string {
+	names := sortedFieldNames(fields)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s: %v", name, fields[name])
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// formatFieldChanges formate les champs modifiés d'un fait
+func formatFieldChanges(before, after map[string]interface{}) string {
+	var parts []string
+	for _, name := range sortedFieldNames(before, after) {
+		if fmt.Sprint(before[name]) != fmt.Sprint(after[name]) {
+			parts = append(parts, fmt.Sprintf("%s: %v → %v", name, before[name], after[name]))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package compilercmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const testSimulationProgram = `type Order(#id: string, status: string)
+action notify(id: string)
+Order(id: "o1", status: "new")
+rule review : {o: Order} / o.status == "new" ==> Update(o, {status: "review"}), notify(o.id)`
+
+func TestRun_Simulate(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	exitCode := Run([]string{"-simulate", "-text", testSimulationProgram}, nil, stdout, stderr)
+	if exitCode != ExitSuccess {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", exitCode, ExitSuccess, stderr.String())
+	}
+
+	output := stdout.String()
+	for _, want := range []string{
+		"1 activation(s), 1 fait(s) modifié(s), 0 xuple(s)",
+		"1. review [o=Order~o1]",
+		`Update("Order~o1") (appliquée)`,
+		`notify("o1") (enregistrée)`,
+		"+ Order~o1 {id: o1, status: review}",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRun_SimulateJSON(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	exitCode := Run([]string{"-simulate", "-json", "-stdin"}, strings.NewReader(testSimulationProgram), stdout, stderr)
+	if exitCode != ExitSuccess {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", exitCode, ExitSuccess, stderr.String())
+	}
+
+	var report rete.SimulationReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, stdout.String())
+	}
+	if len(report.Activations) != 1 || len(report.Activations[0].Actions) != 2 {
+		t.Errorf("activations = %+v, want review with 2 actions", report.Activations)
+	}
+}
+
+func TestPrintSimulationReport_Updated(t *testing.T) {
+	report := &rete.SimulationReport{
+		Facts: []rete.FactChange{{
+			FactID: "Order~o1",
+			Type:   "Order",
+			Change: rete.FactChangeUpdated,
+			Before: map[string]interface{}{"id": "o1", "status": "new"},
+			After:  map[string]interface{}{"id": "o1", "status": "review"},
+		}},
+		Xuples: []rete.SimulatedXuple{{Space: "alerts", Type: "Alert", Fields: map[string]interface{}{"level": "high"}, Triggers: []string{"Order~o1"}}},
+	}
+	buf := &bytes.Buffer{}
+	PrintSimulationReport(buf, report)
+
+	for _, want := range []string{"~ Order~o1 {status: new → review}", "alerts: Alert {level: high} ← Order~o1"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package explaincmd
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+	// ExitNotFired est retourné lorsque la règle ne s'est pas déclenchée pour les faits
+	ExitNotFired = 2
+)
+
+// Error messages
+var (
+	ErrNoFile = errors.New("aucun fichier TSD spécifié (-file ou argument positionnel)")
+	ErrNoRule = errors.New("aucune règle spécifiée (-rule)")
+)
+
+// Config holds the explain command configuration
+type Config struct {
+	File     string   // Programme TSD (types, règles et faits)
+	Rule     string   // Règle à expliquer
+	Facts    []string // Faits examinés : Type~id ou variable=Type~id
+	JSON     bool     // Sortie JSON plutôt que texte
+	ShowHelp bool
+}
+
+// Run executes the explain command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	if err := validateConfig(config); err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n\n", err)
+		printHelp(stderr)
+		return ExitError
+	}
+
+	explanation, err := explain(config, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.JSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(explanation); err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitError
+		}
+	} else {
+		PrintExplanation(stdout, explanation)
+	}
+
+	if !explanation.Fired {
+		return ExitNotFired
+	}
+	return ExitSuccess
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Le premier argument positionnel est le fichier si -file est absent,
+// les suivants sont les faits à examiner.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	flagSet := flag.NewFlagSet("explain", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.StringVar(&config.File, "file", "", "Fichier TSD (.tsd)")
+	flagSet.StringVar(&config.Rule, "rule", "", "Règle à expliquer")
+	flagSet.BoolVar(&config.JSON, "json", false, "Sortie JSON")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	positional := flagSet.Args()
+	if config.File == "" && len(positional) > 0 {
+		config.File = positional[0]
+		positional = positional[1:]
+	}
+	config.Facts = positional
+
+	return config, nil
+}
+
+// validateConfig checks that a program and a rule are specified
+func validateConfig(config *Config) error {
+	if config.File == "" {
+		return ErrNoFile
+	}
+	if config.Rule == "" {
+		return ErrNoRule
+	}
+	return nil
+}
+
+// explain ingère le programme puis explique la règle pour les faits donnés
+func explain(config *Config, stderr io.Writer) (*rete.Explanation, error) {
+	pipelineConfig := api.DefaultConfig()
+	pipelineConfig.LogLevel = api.LogLevelSilent
+	pipelineConfig.Output = stderr
+	pipeline := api.NewPipelineWithConfig(pipelineConfig)
+
+	// Les loggers du moteur et les actions Print/Log écrivent sur stderr ;
+	// les traces fmt.Printf des constructeurs de nœuds suivent os.Stdout,
+	// redirigé le temps de l'ingestion : stdout ne contient que l'explication
+	// (exploitable avec -json)
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	_, err := pipeline.IngestFile(config.File)
+	os.Stdout = stdout
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline.Explain(config.Rule, config.Facts...)
+}
+
+// PrintExplanation affiche une explication sous forme de texte
+func PrintExplanation(w io.Writer, explanation *rete.Explanation) {
+	fmt.Fprintf(w, "Règle %s", explanation.Rule)
+	if len(explanation.Bindings) > 0 {
+		fmt.Fprintf(w, " pour %s", formatBindings(explanation.Bindings))
+	}
+	fmt.Fprintln(w)
+
+	for _, step := range explanation.Steps {
+		fmt.Fprintf(w, "  %s %s %s [%s]\n", outcomeMark(step.Outcome), step.Kind, step.NodeID, strings.Join(step.Variables, ", "))
+		if step.Reason != "" {
+			fmt.Fprintf(w, "      %s\n", step.Reason)
+		}
+		for _, check := range step.Checks {
+			fmt.Fprintf(w, "      %s %s%s\n", passMark(check.Passed), check.Expression, formatCheckValues(check))
+		}
+		for _, aggregate := range step.Aggregates {
+			fmt.Fprintf(w, "      %s %s\n", passMark(aggregate.Passed), formatAggregate(aggregate))
+		}
+		if len(step.Matches) > 0 {
+			fmt.Fprintf(w, "      correspondances : %s\n", strings.Join(step.Matches, ", "))
+		}
+	}
+
+	switch {
+	case explanation.Fired:
+		fmt.Fprintf(w, "Résultat : règle déclenchée (%d activation(s))\n", len(explanation.Activations))
+	case explanation.Matched:
+		fmt.Fprintln(w, "Résultat : conditions satisfaites, aucune activation conservée")
+	default:
+		fmt.Fprintln(w, "Résultat : règle non déclenchée")
+	}
+
+	for _, activation := range explanation.Activations {
+		printActivation(w, activation, "  ")
+	}
+}
+
+// printActivation affiche une activation et, récursivement, l'origine de ses faits
+func printActivation(w io.Writer, activation rete.ActivationProvenance, indent string) {
+	fmt.Fprintf(w, "%s#%d %s → %s (%s)\n", indent, activation.Sequence, activation.Rule, activation.Action,
+		activation.Time.Format("15:04:05.000"))
+	if activation.Error != "" {
+		fmt.Fprintf(w, "%s   erreur : %s\n", indent, activation.Error)
+	}
+	if len(activation.Produced) > 0 {
+		fmt.Fprintf(w, "%s   a produit : %s\n", indent, strings.Join(activation.Produced, ", "))
+	}
+	for _, fact := range activation.Facts {
+		if fact.ProducedBy == nil {
+			fmt.Fprintf(w, "%s   %s = %s (asserté)\n", indent, fact.Variable, fact.FactID)
+			continue
+		}
+		fmt.Fprintf(w, "%s   %s = %s produit par :\n", indent, fact.Variable, fact.FactID)
+		printActivation(w, *fact.ProducedBy, indent+"      ")
+	}
+}
+
+// formatBindings rend les liaisons variable=fait triées par variable
+func formatBindings(bindings map[string]string) string {
+	variables := make([]string, 0, len(bindings))
+	for variable := range bindings {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+
+	parts := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		parts = append(parts, variable+"="+bindings[variable])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatCheckValues rend les valeurs évaluées d'une comparaison
+func formatCheckValues(check rete.ConditionCheck) string {
+	if check.Error != "" {
+		return "  (erreur : " + check.Error + ")"
+	}
+	if check.Operator == "" {
+		return ""
+	}
+	return fmt.Sprintf("  (%s %s %s)", formatValue(check.Left), check.Operator, formatValue(check.Right))
+}
+
+// formatAggregate rend une valeur agrégée face à son seuil
+func formatAggregate(aggregate rete.AggregateCheck) string {
+	name := aggregate.Function
+	if aggregate.Field != "" {
+		name += "(" + aggregate.Field + ")"
+	}
+	if aggregate.Variable != "" {
+		name = aggregate.Variable + " = " + name
+	}
+	text := fmt.Sprintf("%s = %s sur %d fait(s)", name, formatValue(aggregate.Value), aggregate.Count)
+	if aggregate.Operator != "" {
+		text += fmt.Sprintf(", seuil %s %s", aggregate.Operator, formatValue(aggregate.Threshold))
+	}
+	return text
+}
+
+// formatValue rend une valeur évaluée (chaînes entre guillemets)
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	if value == nil {
+		return "nil"
+	}
+	return fmt.Sprint(value)
+}
+
+func outcomeMark(outcome rete.ExplainOutcome) string {
+	switch outcome {
+	case rete.ExplainPassed:
+		return "✓"
+	case rete.ExplainFailed:
+		return "✗"
+	default:
+		return "–"
+	}
+}
+
+func passMark(passed bool) string {
+	if passed {
+		return "✓"
+	}
+	return "✗"
+}
+
+// printHelp displays the explain command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Explain - Pourquoi une règle s'est-elle déclenchée (ou non) ?")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd explain -rule <règle> [options] <fichier.tsd> [fait...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Le programme est ingéré (faits compris), puis la chaîne de nœuds de la règle")
+	fmt.Fprintln(w, "est évaluée sur les faits donnés : chaque condition alpha et de jointure avec")
+	fmt.Fprintln(w, "ses valeurs, les NOT/EXISTS/agrégats face à leur seuil et, si la règle s'est")
+	fmt.Fprintln(w, "déclenchée, la provenance de ses activations.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "FAITS:")
+	fmt.Fprintln(w, "  Type~id           Lié à la première variable libre de ce type")
+	fmt.Fprintln(w, "  variable=Type~id  Lié à la variable donnée")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -file <fichier>   Fichier TSD (alternative à l'argument positionnel)")
+	fmt.Fprintln(w, "  -rule <règle>     Règle à expliquer (obligatoire)")
+	fmt.Fprintln(w, "  -json             Sortie JSON")
+	fmt.Fprintln(w, "  -h, --help        Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "CODES DE SORTIE:")
+	fmt.Fprintln(w, "  0  La règle s'est déclenchée pour les faits donnés")
+	fmt.Fprintln(w, "  1  Erreur (programme, règle ou fait inconnu)")
+	fmt.Fprintln(w, "  2  La règle ne s'est pas déclenchée")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd explain -rule customerOrders orders.tsd Order~42")
+	fmt.Fprintln(w, "  tsd explain -rule customerOrders -json orders.tsd o=Order~42 c=Customer~c1")
+}
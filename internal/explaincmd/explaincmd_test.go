@@ -0,0 +1,209 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package explaincmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const explainProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: string, total: number)
+type Alert(#id: string)
+
+rule bigOrder : {c: Customer, o: Order} / o.customer == c.id AND o.total > 100 ==> Insert(Alert(id: o.id))
+rule alerted : {a: Alert, o: Order} / a.id == o.id ==> Print(a.id)
+
+Customer(id: "c1", tier: "gold")
+Order(id: "o1", customer: "c1", total: 250)
+Order(id: "42", customer: "c1", total: 50)
+`
+
+func writeProgram(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "orders.tsd")
+	if err := os.WriteFile(path, []byte(explainProgram), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := ParseFlags([]string{"-rule", "bigOrder", "-json", "orders.tsd", "Order~42", "c=Customer~c1"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if config.File != "orders.tsd" || config.Rule != "bigOrder" || !config.JSON {
+		t.Errorf("config = %+v", config)
+	}
+	if len(config.Facts) != 2 || config.Facts[0] != "Order~42" || config.Facts[1] != "c=Customer~c1" {
+		t.Errorf("Facts = %v", config.Facts)
+	}
+
+	config, err = ParseFlags([]string{"-file", "orders.tsd", "-rule", "bigOrder", "Order~42"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if config.File != "orders.tsd" || len(config.Facts) != 1 {
+		t.Errorf("config = %+v, want file from -file and one fact", config)
+	}
+}
+
+func TestRun_TextNotFired(t *testing.T) {
+	path := writeProgram(t)
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"-rule", "bigOrder", path, "Order~42", "Customer~c1"}, nil, &stdout, &stderr)
+	if code != ExitNotFired {
+		t.Fatalf("Run() = %d, want %d (stderr: %s)", code, ExitNotFired, stderr.String())
+	}
+	output := stdout.String()
+	for _, want := range []string{"Règle bigOrder pour c=Customer~c1, o=Order~42", "✗ o.total > 100  (50 > 100)", "non déclenchée"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRun_TextFactReference(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owned.tsd")
+	program := `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: Customer, total: number)
+action notify(id: string)
+
+rule owned : {o: Order, c: Customer} / o.customer == c ==> notify(o.id)
+
+c1 = Customer(id: "c1", tier: "gold")
+Order(id: "o1", customer: c1, total: 10)
+`
+	if err := os.WriteFile(path, []byte(program), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"-rule", "owned", path, "Order~o1", "Customer~c1"}, nil, &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Run() = %d, want %d (stderr: %s)", code, ExitSuccess, stderr.String())
+	}
+	output := stdout.String()
+	// Le fait lié à c est désigné par son identifiant, comme dans les filtres alpha
+	if want := `✓ o.customer == c  ("Customer~c1" == "Customer~c1")`; !strings.Contains(output, want) {
+		t.Errorf("output missing %q:\n%s", want, output)
+	}
+	if strings.Contains(output, "Fact{") {
+		t.Errorf("output renders a raw fact:\n%s", output)
+	}
+}
+
+func TestRun_TextProvenance(t *testing.T) {
+	path := writeProgram(t)
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"-rule", "alerted", path, "Alert~o1"}, nil, &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Run() = %d, want %d (stderr: %s)", code, ExitSuccess, stderr.String())
+	}
+	output := stdout.String()
+	for _, want := range []string{"règle déclenchée", "a = Alert~o1 produit par :", "bigOrder → Insert", "o = Order~o1 (asserté)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	path := writeProgram(t)
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"-rule", "bigOrder", "-json", path, "o=Order~o1"}, nil, &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Run() = %d, want %d (stderr: %s)", code, ExitSuccess, stderr.String())
+	}
+	var explanation rete.Explanation
+	if err := json.Unmarshal(stdout.Bytes(), &explanation); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+	if explanation.Rule != "bigOrder" || !explanation.Fired || explanation.Bindings["o"] != "Order~o1" {
+		t.Errorf("explanation = %+v", explanation)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	path := writeProgram(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no file", []string{"-rule", "bigOrder"}, "aucun fichier"},
+		{"no rule", []string{path}, "aucune règle"},
+		{"unknown rule", []string{"-rule", "missing", path}, "missing"},
+		{"unknown fact", []string{"-rule", "bigOrder", path, "Order~nope"}, "Order~nope"},
+		{"missing file", []string{"-rule", "bigOrder", filepath.Join(t.TempDir(), "none.tsd")}, "Erreur"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, nil, &stdout, &stderr); code != ExitError {
+				t.Errorf("Run() = %d, want %d", code, ExitError)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want mention of %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-h"}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Run(-h) = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "tsd explain") {
+		t.Errorf("help output = %q", stdout.String())
+	}
+}
+
+func TestRun_JSONProcessStdout(t *testing.T) {
+	path := writeProgram(t)
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	output := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- data
+	}()
+
+	// La règle alerted exécute Print pendant l'ingestion : sa sortie et les
+	// traces du moteur ne doivent pas précéder le JSON sur le vrai stdout
+	stdout := os.Stdout
+	os.Stdout = writer
+	var stderr bytes.Buffer
+	code := Run([]string{"-rule", "alerted", "-json", path}, nil, writer, &stderr)
+	os.Stdout = stdout
+	writer.Close()
+	data := <-output
+
+	if code != ExitSuccess {
+		t.Fatalf("Run() = %d, want %d (stderr: %s)", code, ExitSuccess, stderr.String())
+	}
+	var explanation rete.Explanation
+	if err := json.Unmarshal(data, &explanation); err != nil {
+		t.Fatalf("stdout n'est pas du JSON: %v\n%s", err, data)
+	}
+	if !strings.Contains(stderr.String(), "o1") {
+		t.Errorf("sortie de Print absente de stderr: %s", stderr.String())
+	}
+}
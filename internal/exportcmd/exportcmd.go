@@ -0,0 +1,213 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package exportcmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+)
+
+// ErrNoFile est retourné lorsqu'aucun fichier n'est donné
+var ErrNoFile = errors.New("aucun fichier TSD spécifié")
+
+// Config holds the export command configuration
+type Config struct {
+	Files     []string        // Programmes TSD ingérés dans l'ordre (types, règles, faits)
+	Format    rete.FactFormat // tsd, json, ndjson ou csv
+	Types     []string        // Types exportés (vide : tous)
+	Condition string          // Condition sur le fait f
+	Output    string          // Fichier de sortie ; répertoire d'un fichier par type en csv
+	ShowHelp  bool
+}
+
+// Run executes the export command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	if len(config.Files) == 0 {
+		fmt.Fprintf(stderr, "Erreur: %v\n\n", ErrNoFile)
+		printHelp(stderr)
+		return ExitError
+	}
+
+	pipeline, types, err := runProgram(config, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	filter := rete.FactFilter{Types: config.Types, Condition: config.Condition}
+	var count int
+	switch {
+	case config.Format == rete.FactFormatCSV && config.Output != "":
+		count, err = exportCSVFiles(pipeline, config, types, stderr)
+	case config.Output != "":
+		count, err = exportFile(pipeline, config.Output, config.Format, filter)
+	default:
+		count, err = pipeline.ExportFacts(stdout, config.Format, filter)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(stderr, "✅ %d fait(s) exporté(s)\n", count)
+	return ExitSuccess
+}
+
+// runProgram ingère les fichiers et retourne le pipeline et les types déclarés
+func runProgram(config *Config, stderr io.Writer) (*api.Pipeline, []string, error) {
+	pipelineConfig := api.DefaultConfig()
+	pipelineConfig.LogLevel = api.LogLevelSilent
+	pipelineConfig.Output = stderr
+	pipeline := api.NewPipelineWithConfig(pipelineConfig)
+
+	// Les loggers du moteur et les actions Print/Log écrivent sur Output ;
+	// les traces fmt.Printf des constructeurs de nœuds suivent os.Stdout,
+	// redirigé le temps de l'ingestion : stdout ne contient que les faits
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+	var result *api.Result
+	for _, file := range config.Files {
+		var err error
+		if result, err = pipeline.IngestFile(file); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	types := make([]string, 0, len(result.Network().Types))
+	for _, typeDef := range result.Network().Types {
+		types = append(types, typeDef.Name)
+	}
+	return pipeline, types, nil
+}
+
+// exportFile écrit l'export dans un fichier
+func exportFile(pipeline *api.Pipeline, path string, format rete.FactFormat, filter rete.FactFilter) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	count, err := pipeline.ExportFacts(file, format, filter)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return count, err
+}
+
+// exportCSVFiles écrit un fichier Type.csv par type dans le répertoire de
+// sortie : les types demandés, ou à défaut ceux qui ont des faits retenus
+func exportCSVFiles(pipeline *api.Pipeline, config *Config, types []string, stderr io.Writer) (int, error) {
+	if err := os.MkdirAll(config.Output, 0755); err != nil {
+		return 0, err
+	}
+	if len(config.Types) > 0 {
+		types = config.Types
+	}
+
+	total := 0
+	for _, typeName := range types {
+		var buf bytes.Buffer
+		count, err := pipeline.ExportFacts(&buf, rete.FactFormatCSV, rete.FactFilter{Types: []string{typeName}, Condition: config.Condition})
+		if err != nil {
+			return total, err
+		}
+		if count == 0 && len(config.Types) == 0 {
+			continue
+		}
+		path := filepath.Join(config.Output, typeName+".csv")
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return total, err
+		}
+		fmt.Fprintf(stderr, "📄 %s: %d fait(s)\n", path, count)
+		total += count
+	}
+	return total, nil
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont les fichiers ingérés, dans l'ordre.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	var format, types string
+	flagSet := flag.NewFlagSet("export", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.StringVar(&format, "format", string(rete.FactFormatTSD), "Format de sortie (tsd, json, ndjson, csv)")
+	flagSet.StringVar(&types, "type", "", "Types exportés, séparés par des virgules")
+	flagSet.StringVar(&config.Condition, "where", "", "Condition sur le fait f (ex: f.age >= 18)")
+	flagSet.StringVar(&config.Output, "o", "", "Fichier de sortie (répertoire en csv)")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	config.Format = rete.FactFormat(format)
+	switch config.Format {
+	case rete.FactFormatTSD, rete.FactFormatJSON, rete.FactFormatNDJSON, rete.FactFormatCSV:
+	default:
+		return nil, fmt.Errorf("format invalide: %s (doit être 'tsd', 'json', 'ndjson' ou 'csv')", format)
+	}
+
+	for _, typeName := range strings.Split(types, ",") {
+		if typeName = strings.TrimSpace(typeName); typeName != "" {
+			config.Types = append(config.Types, typeName)
+		}
+	}
+	config.Files = flagSet.Args()
+	return config, nil
+}
+
+// printHelp displays the export command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Export - Export des faits de la mémoire de travail")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd export [options] fichier.tsd [fichier.tsd...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Les fichiers sont ingérés dans l'ordre et les règles exécutées, puis les faits")
+	fmt.Fprintln(w, "de la mémoire de travail, faits dérivés compris, sont écrits sur la sortie")
+	fmt.Fprintln(w, "standard. L'export TSD se recharge après le programme qui déclare les types :")
+	fmt.Fprintln(w, "les faits référencés par un autre y sont nommés par une variable.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -format <format>   Format: tsd, json, ndjson ou csv (défaut: tsd)")
+	fmt.Fprintln(w, "  -type <liste>      N'exporter que ces types (séparés par des virgules)")
+	fmt.Fprintln(w, "  -where <cond>      Condition TSD sur le fait, désigné par f")
+	fmt.Fprintln(w, "  -o <chemin>        Fichier de sortie ; en csv, répertoire recevant un")
+	fmt.Fprintln(w, "                     fichier Type.csv par type")
+	fmt.Fprintln(w, "  -h, --help         Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd export rules.tsd facts.tsd > snapshot.tsd")
+	fmt.Fprintln(w, "  tsd export -format ndjson -type Alert rules.tsd facts.tsd")
+	fmt.Fprintln(w, "  tsd export -format csv -type Person -where 'f.age >= 18' rules.tsd")
+	fmt.Fprintln(w, "  tsd export -format csv -o export/ rules.tsd facts.tsd")
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package exportcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const exportProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: Customer, total: number)
+type Alert(#order: string, level: string)
+
+rule bigOrder : {o: Order} / o.total > 100 ==> Insert(Alert(order: o.id, level: "high"))
+
+c1 = Customer(id: "c1", tier: "gold")
+Order(id: "o1", customer: c1, total: 250)
+Order(id: "o2", customer: c1, total: 20)
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+func run(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(args, nil, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := ParseFlags([]string{"-format", "csv", "-type", "A, B", "-where", "f.x > 1", "-o", "out", "rules.tsd"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if config.Format != rete.FactFormatCSV || strings.Join(config.Types, ",") != "A,B" || config.Condition != "f.x > 1" ||
+		config.Output != "out" || strings.Join(config.Files, ",") != "rules.tsd" {
+		t.Errorf("config = %+v", config)
+	}
+
+	if config, _ := ParseFlags([]string{"rules.tsd"}); config.Format != rete.FactFormatTSD || config.Types != nil {
+		t.Errorf("défauts = %+v", config)
+	}
+	if _, err := ParseFlags([]string{"-format", "xml", "rules.tsd"}); err == nil {
+		t.Error("format invalide accepté")
+	}
+}
+
+func TestRun_TSD(t *testing.T) {
+	program := writeFile(t, "rules.tsd", exportProgram)
+
+	code, stdout, stderr := run(t, program)
+	if code != ExitSuccess {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	for _, want := range []string{
+		`customer1 = Customer(id: "c1", tier: "gold")`,
+		`Order(id: "o1", customer: customer1, total: 250)`,
+		`Alert(order: "o1", level: "high")`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("export sans %q:\n%s", want, stdout)
+		}
+	}
+	if !strings.Contains(stderr, "4 fait(s) exporté(s)") {
+		t.Errorf("stderr = %s", stderr)
+	}
+
+	code, stdout, _ = run(t, "-format", "ndjson", "-type", "Order", "-where", "f.total > 100", program)
+	if code != ExitSuccess || strings.Count(stdout, "\n") != 1 || !strings.Contains(stdout, `"id":"o1"`) {
+		t.Errorf("export filtré (code %d) = %s", code, stdout)
+	}
+}
+
+func TestRun_CSVDirectory(t *testing.T) {
+	program := writeFile(t, "rules.tsd", exportProgram)
+	dir := filepath.Join(t.TempDir(), "export")
+
+	code, _, stderr := run(t, "-format", "csv", "-o", dir, program)
+	if code != ExitSuccess {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	orders, err := os.ReadFile(filepath.Join(dir, "Order.csv"))
+	if err != nil {
+		t.Fatalf("Order.csv: %v", err)
+	}
+	if string(orders) != "id,customer,total\no1,Customer~c1,250\no2,Customer~c1,20\n" {
+		t.Errorf("Order.csv =\n%s", orders)
+	}
+	for _, name := range []string{"Customer.csv", "Alert.csv"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s absent: %v", name, err)
+		}
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	program := writeFile(t, "rules.tsd", exportProgram)
+
+	if code, _, stderr := run(t); code != ExitError || !strings.Contains(stderr, ErrNoFile.Error()) {
+		t.Errorf("sans fichier: code %d, stderr %s", code, stderr)
+	}
+	if code, _, stderr := run(t, "-where", "o.total > 1", program); code != ExitError || !strings.Contains(stderr, "variable 'o' inconnue") {
+		t.Errorf("condition invalide: code %d, stderr %s", code, stderr)
+	}
+	if code, _, stderr := run(t, "-format", "csv", program); code != ExitError || !strings.Contains(stderr, "un seul type") {
+		t.Errorf("csv multi-types: code %d, stderr %s", code, stderr)
+	}
+}
+
+// captureStdout exécute run avec os.Stdout redirigé vers un tube, comme
+// `tsd export > snapshot.tsd`, et retourne ce qui y a été écrit
+func captureStdout(t *testing.T, run func(stdout *os.File)) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+	run(writer)
+	os.Stdout = stdout
+	writer.Close()
+	return <-output
+}
+
+func TestRun_ProcessStdoutRoundTrip(t *testing.T) {
+	program := writeFile(t, "rules.tsd", exportProgram+`rule printed : {o: Order} / o.total > 100 ==> Print(o.id)
+`)
+	export := func(args ...string) string {
+		var stderr bytes.Buffer
+		return captureStdout(t, func(stdout *os.File) {
+			if code := Run(args, nil, stdout, &stderr); code != ExitSuccess {
+				t.Errorf("Run(%v) = %d, stderr: %s", args, code, stderr.String())
+			}
+		})
+	}
+
+	// L'export TSD se recharge après les seules déclarations de types et
+	// redonne le même export
+	snapshot := export(program)
+	types := writeFile(t, "types.tsd", exportProgram[:strings.Index(exportProgram, "\nrule")])
+	reloaded := export(types, writeFile(t, "snapshot.tsd", snapshot))
+	if reloaded != snapshot {
+		t.Errorf("export rechargé différent:\n%s\nattendu:\n%s", reloaded, snapshot)
+	}
+
+	var facts []map[string]interface{}
+	if output := export("-format", "json", program); json.Unmarshal([]byte(output), &facts) != nil || len(facts) != 4 {
+		t.Errorf("stdout n'est pas un tableau JSON de 4 faits:\n%s", output)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(export("-format", "ndjson", program)), "\n") {
+		var fact map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &fact); err != nil {
+			t.Errorf("ligne NDJSON invalide %q: %v", line, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package fmtcmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+	// ExitUnformatted est retourné par -check lorsqu'au moins un fichier
+	// n'est pas au format canonique
+	ExitUnformatted = 2
+)
+
+// SourceFileExtension est l'extension des fichiers formatés dans un répertoire
+const SourceFileExtension = ".tsd"
+
+// SourceNameStdin est le nom donné à l'entrée standard dans les messages
+const SourceNameStdin = "<stdin>"
+
+// ErrWriteStdin est retourné lorsque -w est demandé sans fichier
+var ErrWriteStdin = errors.New("-w nécessite au moins un fichier")
+
+// Config holds the fmt command configuration
+type Config struct {
+	Paths    []string // Fichiers .tsd ou répertoires ; vide : entrée standard
+	Write    bool     // Réécrire les fichiers plutôt qu'afficher le résultat
+	Check    bool     // Lister les fichiers non formatés, sans rien écrire
+	ShowHelp bool
+}
+
+// Run executes the fmt command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	if len(config.Paths) == 0 {
+		return formatStdin(config, stdin, stdout, stderr)
+	}
+
+	files, err := findSourceFiles(config.Paths)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	exitCode := ExitSuccess
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			exitCode = ExitError
+			continue
+		}
+		formatted, err := constraint.Format(file, src)
+		if err != nil {
+			reportError(stderr, err)
+			exitCode = ExitError
+			continue
+		}
+
+		switch {
+		case config.Check:
+			if !bytes.Equal(src, formatted) {
+				fmt.Fprintln(stdout, file)
+				if exitCode == ExitSuccess {
+					exitCode = ExitUnformatted
+				}
+			}
+		case config.Write:
+			if bytes.Equal(src, formatted) {
+				continue
+			}
+			if err := writeFile(file, formatted); err != nil {
+				fmt.Fprintf(stderr, "Erreur: %v\n", err)
+				exitCode = ExitError
+			}
+		default:
+			stdout.Write(formatted)
+		}
+	}
+	return exitCode
+}
+
+// formatStdin formate l'entrée standard vers la sortie standard
+func formatStdin(config *Config, stdin io.Reader, stdout, stderr io.Writer) int {
+	if config.Write {
+		fmt.Fprintf(stderr, "Erreur: %v\n", ErrWriteStdin)
+		return ExitError
+	}
+	src, err := io.ReadAll(stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: lecture de l'entrée standard: %v\n", err)
+		return ExitError
+	}
+	formatted, err := constraint.Format(SourceNameStdin, src)
+	if err != nil {
+		reportError(stderr, err)
+		return ExitError
+	}
+
+	if config.Check {
+		if !bytes.Equal(src, formatted) {
+			fmt.Fprintln(stdout, SourceNameStdin)
+			return ExitUnformatted
+		}
+		return ExitSuccess
+	}
+	stdout.Write(formatted)
+	return ExitSuccess
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont des fichiers ou des répertoires, dont les
+// fichiers .tsd sont formatés récursivement.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	flagSet := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.BoolVar(&config.Write, "w", false, "Réécrire les fichiers")
+	flagSet.BoolVar(&config.Check, "check", false, "Vérifier le formatage sans rien écrire")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	if config.Write && config.Check {
+		return nil, errors.New("-w et -check sont incompatibles")
+	}
+
+	config.Paths = flagSet.Args()
+	return config, nil
+}
+
+// findSourceFiles retourne les fichiers désignés : un fichier tel quel, les
+// fichiers .tsd d'un répertoire (triés)
+func findSourceFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && strings.HasSuffix(file, SourceFileExtension) {
+				found = append(found, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+// writeFile remplace le contenu d'un fichier en conservant ses permissions
+func writeFile(path string, content []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, info.Mode().Perm())
+}
+
+// reportError affiche les diagnostics d'une erreur de syntaxe, ou l'erreur
+func reportError(w io.Writer, err error) {
+	diagnostics := constraint.Diagnostics(err)
+	if len(diagnostics) == 0 {
+		fmt.Fprintf(w, "Erreur: %v\n", err)
+		return
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintln(w, diagnostic.String())
+	}
+}
+
+// printHelp displays the fmt command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Fmt - Formatage canonique des fichiers .tsd")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd fmt [options] [fichier.tsd|répertoire...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Sans fichier, l'entrée standard est formatée vers la sortie standard.")
+	fmt.Fprintln(w, "Les commentaires sont conservés ; les mots-clés sont écrits en majuscules")
+	fmt.Fprintln(w, "(AND, OR, NOT, EXISTS...) ; les types trop longs ont un champ par ligne,")
+	fmt.Fprintln(w, "aligné ; les longues chaînes AND/OR ont une condition par ligne.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -w             Réécrire les fichiers au lieu d'afficher le résultat")
+	fmt.Fprintln(w, "  -check         Lister les fichiers non formatés, sans rien écrire")
+	fmt.Fprintln(w, "  -h, --help     Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "CODES DE SORTIE:")
+	fmt.Fprintln(w, "  0  Succès (avec -check : tous les fichiers sont formatés)")
+	fmt.Fprintln(w, "  1  Erreur (fichier introuvable, erreur de syntaxe)")
+	fmt.Fprintln(w, "  2  -check : au moins un fichier n'est pas formaté")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd fmt -w rules/")
+	fmt.Fprintln(w, "  tsd fmt -check rules/ orders.tsd")
+	fmt.Fprintln(w, "  cat rules.tsd | tsd fmt")
+}
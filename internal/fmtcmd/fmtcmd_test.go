@@ -0,0 +1,125 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package fmtcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const unformatted = "type  Person(#id:string, age:number) // personnes\nrule adult:{p:Person}/p.age>=18 and p.id!='x' ==> Print(p.id)\n"
+
+const formatted = "type Person(#id: string, age: number) // personnes\nrule adult : {p: Person} / p.age >= 18 AND p.id != \"x\" ==> Print(p.id)\n"
+
+// writeSources crée clean.tsd (formaté), sub/dirty.tsd (non formaté) et un
+// fichier d'une autre extension, ignoré dans un répertoire
+func writeSources(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"clean.tsd":                       formatted,
+		filepath.Join("sub", "dirty.tsd"): unformatted,
+		"notes.txt":                       "pas du TSD",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("❌ Impossible de créer le répertoire: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestRun_Stdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Run(nil, strings.NewReader(unformatted), &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("code = %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != formatted {
+		t.Errorf("sortie =\n%s\nattendu:\n%s", stdout.String(), formatted)
+	}
+}
+
+func TestRun_Check(t *testing.T) {
+	dir := writeSources(t)
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"-check", dir}, nil, &stdout, &stderr)
+	if code != ExitUnformatted {
+		t.Fatalf("code = %d, attendu %d (stderr: %s)", code, ExitUnformatted, stderr.String())
+	}
+	if got := strings.TrimSpace(stdout.String()); got != filepath.Join(dir, "sub", "dirty.tsd") {
+		t.Errorf("fichiers signalés = %q", got)
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"-check", filepath.Join(dir, "clean.tsd")}, nil, &stdout, &stderr); code != ExitSuccess || stdout.Len() != 0 {
+		t.Errorf("fichier formaté: code %d, sortie %q", code, stdout.String())
+	}
+}
+
+func TestRun_Write(t *testing.T) {
+	dir := writeSources(t)
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-w", dir}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("code = %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("-w ne doit rien afficher: %q", stdout.String())
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "sub", "dirty.tsd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != formatted {
+		t.Errorf("fichier réécrit =\n%s", content)
+	}
+
+	if code := Run([]string{"-check", dir}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Errorf("après -w, -check retourne %d", code)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+	}{
+		{"syntaxe", nil, "rule r  {p: P} / p.id == 1 ==> Print(p.id)\n", "<stdin>:1:9"},
+		{"-w sans fichier", []string{"-w"}, "", ErrWriteStdin.Error()},
+		{"-w et -check", []string{"-w", "-check", "a.tsd"}, "", "incompatibles"},
+		{"fichier absent", []string{"/nonexistent/rules.tsd"}, "", "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := Run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != ExitError {
+				t.Errorf("code = %d, attendu %d", code, ExitError)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, attendu %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-h"}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("code = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "tsd fmt") {
+		t.Errorf("aide = %q", stdout.String())
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lintcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+	"github.com/treivax/tsd/tsdlint"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+	// ExitFindings est retourné lorsqu'au moins un constat est signalé
+	ExitFindings = 2
+)
+
+// Formats de sortie
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// SourceFileExtension est l'extension des fichiers analysés dans un répertoire
+const SourceFileExtension = ".tsd"
+
+// ErrNoSources est retourné lorsqu'aucun fichier .tsd n'est trouvé
+var ErrNoSources = errors.New("aucun fichier " + SourceFileExtension + " trouvé")
+
+// Config holds the lint command configuration
+type Config struct {
+	Paths      []string // Fichiers .tsd ou répertoires ; vide : répertoire courant
+	Format     string   // text, json ou sarif
+	Enable     []string // Vérifications à exécuter (vide : toutes)
+	Disable    []string // Vérifications à ne pas exécuter
+	ListChecks bool     // Lister les vérifications disponibles
+	ShowHelp   bool
+}
+
+// Run executes the lint command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+	if config.ListChecks {
+		for _, check := range tsdlint.Checks {
+			fmt.Fprintf(stdout, "%-20s %s\n", check.ID, check.Description)
+		}
+		return ExitSuccess
+	}
+
+	files, err := findSourceFiles(config.Paths)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	// stdout est réservé au rapport : les traces du parser et de la
+	// validation sont coupées
+	tsdio.Mute()
+	defer tsdio.Unmute()
+
+	report, err := tsdlint.Lint(files, &tsdlint.Config{Enable: config.Enable, Disable: config.Disable})
+	if err != nil {
+		reportError(stderr, err)
+		// Les outils qui lisent le SARIF reçoivent aussi les erreurs de
+		// syntaxe et de validation
+		if config.Format == FormatSARIF {
+			failed := &tsdlint.Report{Files: files, Findings: constraint.Diagnostics(err)}
+			if err := tsdlint.WriteSARIF(stdout, failed); err != nil {
+				fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			}
+		}
+		return ExitError
+	}
+
+	if err := writeReport(stdout, config.Format, report); err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	if len(report.Findings) > 0 {
+		return ExitFindings
+	}
+	return ExitSuccess
+}
+
+// writeReport écrit le rapport dans le format demandé
+func writeReport(w io.Writer, format string, report *tsdlint.Report) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(report)
+	case FormatSARIF:
+		return tsdlint.WriteSARIF(w, report)
+	}
+
+	for _, finding := range report.Findings {
+		fmt.Fprintln(w, finding.String())
+	}
+	if report.Suppressed > 0 {
+		fmt.Fprintf(w, "%d constat(s) supprimé(s) par %s\n", report.Suppressed, tsdlint.IgnoreDirective)
+	}
+	return nil
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont des fichiers ou des répertoires, dont les
+// fichiers .tsd sont analysés récursivement.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	var enable, disable string
+	flagSet := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.StringVar(&config.Format, "format", FormatText, "Format de sortie (text, json, sarif)")
+	flagSet.StringVar(&enable, "enable", "", "Vérifications à exécuter, séparées par des virgules")
+	flagSet.StringVar(&disable, "disable", "", "Vérifications à ne pas exécuter, séparées par des virgules")
+	flagSet.BoolVar(&config.ListChecks, "list", false, "Lister les vérifications")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	if config.Format != FormatText && config.Format != FormatJSON && config.Format != FormatSARIF {
+		return nil, fmt.Errorf("format invalide: %s (doit être 'text', 'json' ou 'sarif')", config.Format)
+	}
+
+	config.Enable = splitList(enable)
+	config.Disable = splitList(disable)
+	lintConfig := tsdlint.Config{Enable: config.Enable, Disable: config.Disable}
+	if err := lintConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	config.Paths = flagSet.Args()
+	if len(config.Paths) == 0 {
+		config.Paths = []string{"."}
+	}
+	return config, nil
+}
+
+// splitList découpe une liste séparée par des virgules
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// findSourceFiles retourne les fichiers désignés : un fichier tel quel, les
+// fichiers .tsd d'un répertoire (triés)
+func findSourceFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && strings.HasSuffix(file, SourceFileExtension) {
+				found = append(found, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	if len(files) == 0 {
+		return nil, ErrNoSources
+	}
+	return files, nil
+}
+
+// reportError affiche les diagnostics d'une erreur de parsing ou de
+// validation, ou l'erreur
+func reportError(w io.Writer, err error) {
+	diagnostics := constraint.Diagnostics(err)
+	if len(diagnostics) == 0 {
+		fmt.Fprintf(w, "Erreur: %v\n", err)
+		return
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintln(w, diagnostic.String())
+	}
+}
+
+// printHelp displays the lint command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Lint - Analyse statique des règles .tsd")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd lint [options] [fichier.tsd|répertoire...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Sans argument, les fichiers .tsd du répertoire courant sont analysés.")
+	fmt.Fprintln(w, "Les constats sont des erreurs probables : règles contradictoires, dupliquées")
+	fmt.Fprintln(w, "ou couvertes par une autre, déclarations inutilisées, comparaisons de types")
+	fmt.Fprintln(w, "différents, jointures sans égalité, règles qui se redéclenchent (tsd lint -list).")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Un commentaire « // tsd:ignore » supprime les constats de sa ligne et de la")
+	fmt.Fprintln(w, "suivante ; avant une règle, il couvre toute la règle. « // tsd:ignore")
+	fmt.Fprintln(w, "cartesian-join » ne supprime que les constats de cette vérification.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -format <format>   Format de sortie: text, json ou sarif (défaut: text)")
+	fmt.Fprintln(w, "  -enable <liste>    N'exécuter que ces vérifications (séparées par des virgules)")
+	fmt.Fprintln(w, "  -disable <liste>   Ne pas exécuter ces vérifications")
+	fmt.Fprintln(w, "  -list              Lister les vérifications disponibles")
+	fmt.Fprintln(w, "  -h, --help         Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "CODES DE SORTIE:")
+	fmt.Fprintln(w, "  0  Aucun constat")
+	fmt.Fprintln(w, "  1  Erreur (fichier introuvable, erreur de syntaxe ou de validation)")
+	fmt.Fprintln(w, "     (en sarif, les erreurs de syntaxe et de validation sont aussi écrites")
+	fmt.Fprintln(w, "     dans le rapport)")
+	fmt.Fprintln(w, "  2  Au moins un constat signalé")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd lint rules/")
+	fmt.Fprintln(w, "  tsd lint -disable unused-type,unused-action rules/")
+	fmt.Fprintln(w, "  tsd lint -format sarif rules/ > lint.sarif")
+}
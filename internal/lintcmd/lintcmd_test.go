@@ -0,0 +1,174 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lintcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const cleanSource = `type Person(#id: string, age: number)
+action notify(id: string)
+rule adults : {p: Person} / p.age >= 18 ==> notify(p.id)
+`
+
+const buggySource = `type Person(#id: string, age: number)
+action notify(id: string)
+rule never : {p: Person} / p.age > 5 AND p.age < 3 ==> notify(p.id)
+`
+
+// writeSources crée clean.tsd et sub/buggy.tsd dans un répertoire
+func writeSources(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"clean.tsd":                       cleanSource,
+		filepath.Join("sub", "buggy.tsd"): buggySource,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("❌ Impossible de créer le répertoire: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestRun_Text(t *testing.T) {
+	dir := writeSources(t)
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{dir}, nil, &stdout, &stderr)
+	if code != ExitFindings {
+		t.Fatalf("code = %d, attendu %d (stderr: %s)", code, ExitFindings, stderr.String())
+	}
+	want := filepath.Join(dir, "sub", "buggy.tsd") + ":3:1: warning[unsatisfiable-rule]"
+	if !strings.HasPrefix(stdout.String(), want) || strings.Count(stdout.String(), "\n") != 1 {
+		t.Errorf("sortie = %q, attendu %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if code := Run([]string{filepath.Join(dir, "clean.tsd")}, nil, &stdout, &stderr); code != ExitSuccess || stdout.Len() != 0 {
+		t.Errorf("fichier sans constat: code %d, sortie %q", code, stdout.String())
+	}
+}
+
+func TestRun_Formats(t *testing.T) {
+	dir := writeSources(t)
+	for _, format := range []string{FormatJSON, FormatSARIF} {
+		t.Run(format, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run([]string{"-format", format, dir}, nil, &stdout, &stderr); code != ExitFindings {
+				t.Fatalf("code = %d (stderr: %s)", code, stderr.String())
+			}
+			var output map[string]interface{}
+			if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+				t.Fatalf("sortie JSON invalide: %v\n%s", err, stdout.String())
+			}
+			if !strings.Contains(stdout.String(), "unsatisfiable-rule") {
+				t.Errorf("constat absent: %s", stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_SARIFValidationError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invalid.tsd")
+	source := "type Person(#id: string, age: number)\nPerson(id: \"p1\", size: 3)\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-format", FormatSARIF, path}, nil, &stdout, &stderr); code != ExitError {
+		t.Fatalf("code = %d, attendu %d", code, ExitError)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("sortie SARIF invalide: %v\n%s", err, stdout.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("journal SARIF inattendu: %s", stdout.String())
+	}
+	result := log.Runs[0].Results[0]
+	if result.RuleID != "unknown-field" || result.Level != "error" || len(result.Locations) != 1 {
+		t.Fatalf("résultat inattendu: %+v", result)
+	}
+	location := result.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != filepath.ToSlash(path) || location.Region.StartLine != 2 {
+		t.Errorf("position = %+v, attendu %s ligne 2", location, path)
+	}
+	if !strings.Contains(stderr.String(), "size") {
+		t.Errorf("stderr = %q, diagnostic attendu", stderr.String())
+	}
+}
+
+func TestRun_Disable(t *testing.T) {
+	dir := writeSources(t)
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-disable", "unsatisfiable-rule", dir}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Errorf("code = %d, sortie %q", code, stdout.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"format inconnu", []string{"-format", "xml"}, "format invalide"},
+		{"vérification inconnue", []string{"-enable", "nope"}, "vérification inconnue: nope"},
+		{"fichier absent", []string{"/nonexistent/rules.tsd"}, "no such file"},
+		{"répertoire vide", []string{t.TempDir()}, ErrNoSources.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, nil, &stdout, &stderr); code != ExitError {
+				t.Errorf("code = %d, attendu %d", code, ExitError)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, attendu %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_ListAndHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-list"}, nil, &stdout, &stderr); code != ExitSuccess || !strings.Contains(stdout.String(), "cartesian-join") {
+		t.Errorf("-list: code %d, sortie %q", code, stdout.String())
+	}
+	stdout.Reset()
+	if code := Run([]string{"-h"}, nil, &stdout, &stderr); code != ExitSuccess || !strings.Contains(stdout.String(), "tsd lint") {
+		t.Errorf("-h: code %d, sortie %q", code, stdout.String())
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lspcmd
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// diagnosticSource identifie les diagnostics du serveur dans l'éditeur
+const diagnosticSource = "tsd"
+
+// document est un fichier .tsd ouvert dans l'éditeur, avec le résultat de sa
+// dernière analyse
+type document struct {
+	uri   string
+	path  string
+	text  string
+	lines []string
+
+	// program est le dernier programme analysé sans erreur de syntaxe : la
+	// complétion reste disponible pendant la saisie d'une ligne incomplète
+	program     *constraint.Program
+	diagnostics []Diagnostic
+}
+
+// newDocument analyse le texte d'un document. previous est le programme de la
+// version précédente, conservé si le texte ne se parse pas.
+func newDocument(uri, text string, previous *constraint.Program) *document {
+	doc := &document{
+		uri:   uri,
+		path:  uriToPath(uri),
+		text:  text,
+		lines: strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"),
+	}
+
+	doc.program = previous
+	diagnostics := doc.analyze()
+	doc.diagnostics = make([]Diagnostic, 0, len(diagnostics))
+	// La validation du compilateur et ProgramState signalent souvent la même
+	// erreur : un seul diagnostic est gardé par position et code
+	seen := make(map[string]bool)
+	for _, diagnostic := range diagnostics {
+		converted := doc.toLSPDiagnostic(diagnostic)
+		key := fmt.Sprintf("%d:%d:%s:%s", converted.Range.Start.Line, converted.Range.Start.Character, converted.Code, converted.Message)
+		if doc.contains(diagnostic.Span) {
+			key = fmt.Sprintf("%d:%d:%s", converted.Range.Start.Line, converted.Range.Start.Character, converted.Code)
+		}
+		if !seen[key] {
+			seen[key] = true
+			doc.diagnostics = append(doc.diagnostics, converted)
+		}
+	}
+	return doc
+}
+
+// analyze parse le document (imports compris), puis le valide comme le
+// compilateur et ProgramState. Le programme est mis à jour si le parsing réussit.
+func (d *document) analyze() []constraint.Diagnostic {
+	result, err := constraint.NewModuleLoader().LoadContent(d.text, d.path)
+	if err != nil {
+		return constraint.Diagnostics(err)
+	}
+	program, err := constraint.ConvertResultToProgram(result)
+	if err != nil {
+		return constraint.Diagnostics(err)
+	}
+	d.program = program
+
+	var diagnostics []constraint.Diagnostic
+	if err := constraint.ValidateConstraintProgram(result); err != nil {
+		diagnostics = append(diagnostics, constraint.Diagnostics(err)...)
+	}
+
+	// ProgramState refuse un contenu vide, qui n'a rien à valider
+	if strings.TrimSpace(d.text) != "" {
+		state := constraint.NewProgramState()
+		if err := state.ParseAndMergeContent(d.text, d.path); err != nil {
+			diagnostics = append(diagnostics, constraint.Diagnostics(err)...)
+		}
+		for _, validationErr := range state.GetErrors() {
+			diagnostics = append(diagnostics, validationErr.Diagnostic())
+		}
+	}
+	return diagnostics
+}
+
+// formatting retourne le document au format canonique (tsd fmt), en une
+// modification remplaçant tout le texte. Un document qui ne se parse pas
+// n'est pas modifié : l'erreur est déjà signalée par les diagnostics.
+func (d *document) formatting() []TextEdit {
+	formatted, err := constraint.Format(d.path, []byte(d.text))
+	if err != nil || string(formatted) == d.text {
+		return []TextEdit{}
+	}
+	last := len(d.lines) - 1
+	return []TextEdit{{
+		Range:   Range{End: d.position(last, len([]rune(d.lines[last])))},
+		NewText: string(formatted),
+	}}
+}
+
+// toLSPDiagnostic convertit un diagnostic TSD. Un diagnostic sans position ou
+// situé dans un fichier importé est placé au début du document, préfixé de sa
+// position.
+func (d *document) toLSPDiagnostic(diagnostic constraint.Diagnostic) Diagnostic {
+	message := diagnostic.Message
+	if diagnostic.SuggestedFix != "" {
+		message += "\ncorrection : " + diagnostic.SuggestedFix
+	}
+
+	result := Diagnostic{
+		Severity: lspSeverity(diagnostic.Severity),
+		Code:     diagnostic.Code,
+		Source:   diagnosticSource,
+		Message:  message,
+	}
+	switch {
+	case d.contains(diagnostic.Span):
+		result.Range = d.spanRange(diagnostic.Span)
+	case diagnostic.Span != nil:
+		result.Message = diagnostic.Span.String() + ": " + message
+	}
+	return result
+}
+
+// lspSeverity convertit une gravité TSD en gravité LSP
+func lspSeverity(severity tsdio.Severity) int {
+	switch severity {
+	case tsdio.SeverityWarning:
+		return severityWarning
+	case tsdio.SeverityInfo:
+		return severityInformation
+	}
+	return severityError
+}
+
+// contains indique si span désigne une portion de ce document
+func (d *document) contains(span *constraint.Span) bool {
+	return span != nil && span.Line >= 1 && (span.File == "" || span.File == d.path)
+}
+
+// spanRange convertit une position TSD (lignes et colonnes en runes à partir
+// de 1) en portion LSP. Sans fin connue, la portion couvre le nom désigné,
+// accès aux champs compris (p.age).
+func (d *document) spanRange(span *constraint.Span) Range {
+	start := d.position(span.Line-1, span.Column-1)
+	if span.EndLine >= span.Line && span.EndColumn > 0 {
+		return Range{Start: start, End: d.position(span.EndLine-1, span.EndColumn-1)}
+	}
+
+	column := max(span.Column-1, 0)
+	end := column
+	runes := []rune(d.line(span.Line - 1))
+	for end < len(runes) && (isIdentifierRune(runes[end]) || (runes[end] == '.' && end+1 < len(runes) && isIdentifierRune(runes[end+1]))) {
+		end++
+	}
+	if end == column && end < len(runes) {
+		end++
+	}
+	return Range{Start: start, End: d.position(span.Line-1, end)}
+}
+
+// line retourne une ligne du document (vide hors du document)
+func (d *document) line(index int) string {
+	if index < 0 || index >= len(d.lines) {
+		return ""
+	}
+	return d.lines[index]
+}
+
+// position convertit une colonne en runes en position LSP (unités UTF-16)
+func (d *document) position(line, column int) Position {
+	runes := []rune(d.line(line))
+	column = min(max(column, 0), len(runes))
+	return Position{Line: max(line, 0), Character: len(utf16.Encode(runes[:column]))}
+}
+
+// column convertit une position LSP en colonne en runes dans sa ligne
+func (d *document) column(position Position) int {
+	units := 0
+	runes := []rune(d.line(position.Line))
+	for i, r := range runes {
+		if units >= position.Character {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(runes)
+}
+
+// prefix retourne le texte de la ligne situé avant la position
+func (d *document) prefix(position Position) string {
+	return string([]rune(d.line(position.Line))[:d.column(position)])
+}
+
+// textBefore retourne tout le texte du document situé avant la position
+func (d *document) textBefore(position Position) string {
+	lines := append([]string(nil), d.lines[:min(max(position.Line, 0), len(d.lines))]...)
+	return strings.Join(append(lines, d.prefix(position)), "\n")
+}
+
+// wordAt retourne l'identifiant sous la position, sa portion et, s'il est
+// précédé de « variable. », le nom de la variable
+func (d *document) wordAt(position Position) (word, qualifier string, span Range) {
+	runes := []rune(d.line(position.Line))
+	start := min(d.column(position), len(runes))
+	end := start
+	for start > 0 && isIdentifierRune(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isIdentifierRune(runes[end]) {
+		end++
+	}
+	if start == end {
+		return "", "", Range{}
+	}
+
+	if start > 0 && runes[start-1] == '.' {
+		qualifierStart := start - 1
+		for qualifierStart > 0 && isIdentifierRune(runes[qualifierStart-1]) {
+			qualifierStart--
+		}
+		qualifier = string(runes[qualifierStart : start-1])
+	}
+	span = Range{Start: d.position(position.Line, start), End: d.position(position.Line, end)}
+	return string(runes[start:end]), qualifier, span
+}
+
+// isIdentifierRune indique si r peut faire partie d'un identifiant
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// uriToPath convertit une URI file:// en chemin ; une autre URI est gardée telle quelle
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(parsed.Path)
+}
+
+// pathToURI convertit un chemin en URI file://
+func pathToURI(path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
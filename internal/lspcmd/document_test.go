@@ -0,0 +1,60 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lspcmd
+
+import (
+	"testing"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+func TestDocument_PositionsUTF16(t *testing.T) {
+	doc := &document{lines: []string{`Msg(text: "é😀", n: 1)`}}
+
+	// « n » est la 17e rune ; 😀 compte pour deux unités UTF-16
+	if got := doc.position(0, 16); got.Character != 17 {
+		t.Errorf("position = %+v, attendu caractère 17", got)
+	}
+	if got := doc.column(Position{Line: 0, Character: 17}); got != 16 {
+		t.Errorf("column = %d, attendu 16", got)
+	}
+
+	word, _, span := doc.wordAt(Position{Line: 0, Character: 17})
+	if word != "n" || span.Start.Character != 17 || span.End.Character != 18 {
+		t.Errorf("wordAt = %q %+v", word, span)
+	}
+}
+
+func TestDocument_SpanRange(t *testing.T) {
+	doc := &document{path: "/tmp/r.tsd", lines: []string{"", "rule r : {p: P} / p.agee > 1 ==> Print(p.id)"}}
+
+	// Sans fin connue, la portion couvre l'accès au champ
+	r := doc.spanRange(&constraint.Span{File: "/tmp/r.tsd", Line: 2, Column: 19})
+	if r.Start != (Position{Line: 1, Character: 18}) || r.End != (Position{Line: 1, Character: 24}) {
+		t.Errorf("spanRange = %+v", r)
+	}
+
+	if doc.contains(&constraint.Span{File: "/tmp/other.tsd", Line: 1}) {
+		t.Error("une position d'un fichier importé n'est pas dans le document")
+	}
+	diagnostic := doc.toLSPDiagnostic(constraint.Diagnostic{
+		Code:    "unknown-type",
+		Message: "type inconnu",
+		Span:    &constraint.Span{File: "/tmp/other.tsd", Line: 3, Column: 5},
+	})
+	if diagnostic.Range != (Range{}) || diagnostic.Message != "/tmp/other.tsd:3:5: type inconnu" {
+		t.Errorf("diagnostic importé = %+v", diagnostic)
+	}
+}
+
+func TestURIConversion(t *testing.T) {
+	path := uriToPath("file:///tmp/my%20rules/a.tsd")
+	if path != "/tmp/my rules/a.tsd" {
+		t.Errorf("uriToPath = %q", path)
+	}
+	if uri := pathToURI(path); uri != "file:///tmp/my%20rules/a.tsd" {
+		t.Errorf("pathToURI = %q", uri)
+	}
+}
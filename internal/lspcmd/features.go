@@ -0,0 +1,442 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lspcmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/internal/actiondefs"
+	"github.com/treivax/tsd/rete"
+)
+
+// keywords sont les mots-clés proposés à la complétion
+var keywords = []string{
+	"type", "action", "rule", "query", "import", "package", "private", "xuple-space", "reset",
+	"let", "if", "then", "else", "true", "false",
+	"AND", "OR", "NOT", "EXISTS", "FORALL", "COLLECT", "CONTAINS", "LIKE", "MATCHES", "IN",
+}
+
+// primitiveTypes sont les types de champ prédéfinis
+var primitiveTypes = []string{"string", "number", "bool"}
+
+var (
+	// fieldAccessPattern reconnaît « variable.début_du_champ » en fin de texte
+	fieldAccessPattern = regexp.MustCompile(`([\p{L}_][\p{L}\p{N}_]*)\.([\p{L}\p{N}_]*)$`)
+	// typeAnnotationPattern reconnaît « nom: début_du_type » en fin de texte
+	typeAnnotationPattern = regexp.MustCompile(`[\p{L}\p{N}_]\s*:\s*[\p{L}\p{N}_.]*$`)
+	// declarationPattern reconnaît une ligne de déclaration dont les paramètres sont typés
+	declarationPattern = regexp.MustCompile(`^\s*(private\s+)?(type|action|query)\s`)
+)
+
+// catalog regroupe les symboles prédéfinis : actions par défaut et fonctions
+type catalog struct {
+	defaultActions []constraint.ActionDefinition
+	actionDocs     map[string]string
+}
+
+// newCatalog charge les actions par défaut et leur documentation, tirée des
+// commentaires de defaults.tsd
+func newCatalog(defaultActions []constraint.ActionDefinition) *catalog {
+	c := &catalog{defaultActions: defaultActions, actionDocs: make(map[string]string)}
+	lines := strings.Split(actiondefs.DefaultActionsTSD, "\n")
+	for _, action := range defaultActions {
+		if action.Pos == nil || action.Pos.Line < 2 || action.Pos.Line > len(lines) {
+			continue
+		}
+		var comments []string
+		for i := action.Pos.Line - 2; i >= 0; i-- {
+			line := strings.TrimSpace(lines[i])
+			if !strings.HasPrefix(line, "//") {
+				break
+			}
+			comments = append([]string{strings.TrimSpace(strings.TrimPrefix(line, "//"))}, comments...)
+		}
+		c.actionDocs[action.Name] = strings.Join(comments, "\n")
+	}
+	return c
+}
+
+// actions retourne les actions connues du document : actions par défaut puis
+// actions déclarées
+func (c *catalog) actions(doc *document) []constraint.ActionDefinition {
+	actions := append([]constraint.ActionDefinition(nil), c.defaultActions...)
+	if doc.program != nil {
+		actions = append(actions, doc.program.Actions...)
+	}
+	return actions
+}
+
+// findType retourne la définition d'un type du document
+func findType(doc *document, name string) *constraint.TypeDefinition {
+	if doc.program == nil {
+		return nil
+	}
+	for i := range doc.program.Types {
+		if doc.program.Types[i].Name == name {
+			return &doc.program.Types[i]
+		}
+	}
+	return nil
+}
+
+// findFactAssignment retourne l'affectation d'une variable de fait
+func findFactAssignment(doc *document, name string) *constraint.FactAssignment {
+	if doc.program == nil {
+		return nil
+	}
+	for i := range doc.program.FactAssignments {
+		if doc.program.FactAssignments[i].Variable == name {
+			return &doc.program.FactAssignments[i]
+		}
+	}
+	return nil
+}
+
+// variableType retourne le type d'une variable visible à la position : variable
+// de la règle qui contient la position, à défaut la dernière déclaration
+// « nom: Type » qui la précède (texte en cours de saisie), puis variable de fait
+func variableType(doc *document, name string, position Position) string {
+	if doc.program != nil {
+		for _, expression := range doc.program.Expressions {
+			if !spanContains(expression.Pos, doc, position) {
+				continue
+			}
+			sets := append([]constraint.Set{expression.Set}, expression.Patterns...)
+			for _, set := range sets {
+				for _, variable := range set.Variables {
+					if variable.Name == name && findType(doc, variable.DataType) != nil {
+						return variable.DataType
+					}
+				}
+			}
+		}
+	}
+
+	declaration := regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.])` + regexp.QuoteMeta(name) + `\s*:\s*([\p{L}_][\p{L}\p{N}_.]*)`)
+	matches := declaration.FindAllStringSubmatch(doc.textBefore(position), -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		if findType(doc, matches[i][1]) != nil {
+			return matches[i][1]
+		}
+	}
+
+	if assignment := findFactAssignment(doc, name); assignment != nil {
+		return assignment.Fact.TypeName
+	}
+	return ""
+}
+
+// spanContains indique si la portion, située dans le document, contient la position
+func spanContains(span *constraint.Span, doc *document, position Position) bool {
+	if !doc.contains(span) {
+		return false
+	}
+	r := doc.spanRange(span)
+	afterStart := position.Line > r.Start.Line || (position.Line == r.Start.Line && position.Character >= r.Start.Character)
+	beforeEnd := position.Line < r.End.Line || (position.Line == r.End.Line && position.Character <= r.End.Character)
+	return afterStart && beforeEnd
+}
+
+// completion retourne les propositions à la position : champs après
+// « variable. », types après « nom: », sinon mots-clés, types, actions,
+// fonctions et variables de faits
+func (c *catalog) completion(doc *document, position Position) []CompletionItem {
+	prefix := doc.prefix(position)
+
+	if match := fieldAccessPattern.FindStringSubmatch(prefix); match != nil {
+		items := []CompletionItem{}
+		if typeDef := findType(doc, variableType(doc, match[1], position)); typeDef != nil {
+			for _, field := range typeDef.Fields {
+				items = append(items, CompletionItem{Label: field.Name, Kind: completionKindField, Detail: fieldDetail(field)})
+			}
+		}
+		return items
+	}
+
+	items := []CompletionItem{}
+	if typeAnnotationPattern.MatchString(prefix) && (declarationPattern.MatchString(prefix) || insideBlock(prefix)) {
+		if declarationPattern.MatchString(prefix) {
+			for _, primitive := range primitiveTypes {
+				items = append(items, CompletionItem{Label: primitive, Kind: completionKindClass, Detail: "type primitif"})
+			}
+		}
+		return append(items, c.typeItems(doc)...)
+	}
+
+	for _, keyword := range keywords {
+		items = append(items, CompletionItem{Label: keyword, Kind: completionKindKeyword})
+	}
+	items = append(items, c.typeItems(doc)...)
+	for _, action := range c.actions(doc) {
+		items = append(items, CompletionItem{
+			Label:         action.Name,
+			Kind:          completionKindFunction,
+			Detail:        actionSignature(action),
+			Documentation: c.actionDocs[action.Name],
+		})
+	}
+	for _, function := range constraint.DefaultFunctionRegistry.Functions() {
+		items = append(items, CompletionItem{Label: function.Name, Kind: completionKindFunction, Detail: functionDetail(function)})
+	}
+	for _, aggregate := range rete.AggregateFunctions {
+		items = append(items, CompletionItem{Label: aggregate, Kind: completionKindFunction, Detail: "fonction d'agrégation"})
+	}
+	if doc.program != nil {
+		for _, assignment := range doc.program.FactAssignments {
+			items = append(items, CompletionItem{Label: assignment.Variable, Kind: completionKindVariable, Detail: assignment.Fact.TypeName})
+		}
+	}
+	return items
+}
+
+// typeItems retourne les types déclarés du document
+func (c *catalog) typeItems(doc *document) []CompletionItem {
+	var items []CompletionItem
+	if doc.program == nil {
+		return items
+	}
+	for _, typeDef := range doc.program.Types {
+		items = append(items, CompletionItem{Label: typeDef.Name, Kind: completionKindClass, Detail: typeSignature(typeDef)})
+	}
+	return items
+}
+
+// insideBlock indique si la ligne a un « { » non refermé : déclaration des
+// variables d'une règle
+func insideBlock(prefix string) bool {
+	return strings.LastIndex(prefix, "{") > strings.LastIndex(prefix, "}")
+}
+
+// hover retourne la description de l'identifiant sous la position : type
+// (champs et clé primaire), action, fonction, champ ou variable
+func (c *catalog) hover(doc *document, position Position) *Hover {
+	word, qualifier, span := doc.wordAt(position)
+	if word == "" {
+		return nil
+	}
+
+	var value string
+	if qualifier != "" {
+		typeName := variableType(doc, qualifier, position)
+		if typeDef := findType(doc, typeName); typeDef != nil {
+			for _, field := range typeDef.Fields {
+				if field.Name == word {
+					value = fmt.Sprintf("```tsd\n%s.%s: %s\n```\nChamp de `%s`", qualifier, word, field.Type, typeName)
+				}
+			}
+		}
+	}
+	if value == "" {
+		value = c.describe(doc, word, position)
+	}
+	if value == "" {
+		return nil
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: value}, Range: &span}
+}
+
+// describe retourne la description Markdown d'un nom (vide s'il est inconnu)
+func (c *catalog) describe(doc *document, name string, position Position) string {
+	if typeDef := findType(doc, name); typeDef != nil {
+		return typeDescription(*typeDef)
+	}
+	for _, action := range c.actions(doc) {
+		if action.Name == name {
+			value := "```tsd\n" + actionSignature(action) + "\n```"
+			if action.IsDefault {
+				value += "\nAction par défaut"
+			}
+			if doc := c.actionDocs[name]; doc != "" {
+				value += "\n\n" + doc
+			}
+			return value
+		}
+	}
+	if function, ok := constraint.DefaultFunctionRegistry.GetSignature(name); ok {
+		return "```tsd\n" + functionDetail(*function) + "\n```"
+	}
+	for _, aggregate := range rete.AggregateFunctions {
+		if aggregate == name {
+			return "```tsd\n" + name + "(...)\n```\nFonction d'agrégation"
+		}
+	}
+	if typeName := variableType(doc, name, position); typeName != "" {
+		return fmt.Sprintf("```tsd\n%s: %s\n```", name, typeName)
+	}
+	return ""
+}
+
+// typeDescription décrit un type : signature, clé primaire et champs
+func typeDescription(typeDef constraint.TypeDefinition) string {
+	var sb strings.Builder
+	sb.WriteString("```tsd\n" + typeSignature(typeDef) + "\n```\n")
+
+	var keys []string
+	for _, field := range typeDef.Fields {
+		if field.IsPrimaryKey {
+			keys = append(keys, "`"+field.Name+"`")
+		}
+	}
+	if len(keys) > 0 {
+		sb.WriteString("Clé primaire : " + strings.Join(keys, ", ") + "\n")
+	} else {
+		sb.WriteString("Clé primaire : aucune (identifiant calculé)\n")
+	}
+
+	sb.WriteString("\nChamps :\n")
+	for _, field := range typeDef.Fields {
+		sb.WriteString(fmt.Sprintf("- `%s` : %s\n", field.Name, field.Type))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// typeSignature retourne la déclaration d'un type
+func typeSignature(typeDef constraint.TypeDefinition) string {
+	fields := make([]string, 0, len(typeDef.Fields))
+	for _, field := range typeDef.Fields {
+		fields = append(fields, fieldDetail(field))
+	}
+	return fmt.Sprintf("type %s(%s)", typeDef.Name, strings.Join(fields, ", "))
+}
+
+// fieldDetail retourne la déclaration d'un champ
+func fieldDetail(field constraint.Field) string {
+	if field.IsPrimaryKey {
+		return fmt.Sprintf("#%s: %s", field.Name, field.Type)
+	}
+	return fmt.Sprintf("%s: %s", field.Name, field.Type)
+}
+
+// actionSignature retourne la déclaration d'une action
+func actionSignature(action constraint.ActionDefinition) string {
+	parameters := make([]string, 0, len(action.Parameters))
+	for _, parameter := range action.Parameters {
+		declaration := parameter.Name + ": " + parameter.Type
+		if parameter.Optional {
+			declaration += "?"
+		}
+		if parameter.DefaultValue != nil {
+			declaration += fmt.Sprintf(" = %v", parameter.DefaultValue)
+		}
+		parameters = append(parameters, declaration)
+	}
+	return fmt.Sprintf("action %s(%s)", action.Name, strings.Join(parameters, ", "))
+}
+
+// functionDetail retourne la signature d'une fonction prédéfinie
+func functionDetail(function constraint.FunctionSignature) string {
+	return fmt.Sprintf("%s(%s): %s", function.Name, strings.Join(function.ParamTypes, ", "), function.ReturnType)
+}
+
+// definition retourne la déclaration de l'identifiant sous la position : type,
+// action déclarée ou variable de fait. Le type d'une variable de règle est
+// rejoint depuis ses accès aux champs.
+func (c *catalog) definition(doc *document, position Position) *Location {
+	word, qualifier, _ := doc.wordAt(position)
+	if word == "" {
+		return nil
+	}
+	if qualifier != "" {
+		if typeDef := findType(doc, variableType(doc, qualifier, position)); typeDef != nil {
+			return doc.location(typeDef.Pos)
+		}
+	}
+	if typeDef := findType(doc, word); typeDef != nil {
+		return doc.location(typeDef.Pos)
+	}
+	if doc.program != nil {
+		for _, action := range doc.program.Actions {
+			if action.Name == word {
+				return doc.location(action.Pos)
+			}
+		}
+	}
+	if assignment := findFactAssignment(doc, word); assignment != nil {
+		return doc.location(assignment.Pos)
+	}
+	return nil
+}
+
+// location convertit une position TSD, dans ce document ou un fichier importé
+func (d *document) location(span *constraint.Span) *Location {
+	if span == nil || span.Line < 1 {
+		return nil
+	}
+	if d.contains(span) {
+		return &Location{URI: d.uri, Range: d.spanRange(span)}
+	}
+
+	// Fichier importé : les colonnes sont gardées telles quelles, le fichier
+	// n'étant pas ouvert
+	start := Position{Line: span.Line - 1, Character: max(span.Column-1, 0)}
+	end := start
+	if span.EndLine >= span.Line && span.EndColumn > 0 {
+		end = Position{Line: span.EndLine - 1, Character: span.EndColumn - 1}
+	}
+	return &Location{URI: pathToURI(span.File), Range: Range{Start: start, End: end}}
+}
+
+// symbols retourne le plan du document : types (avec leurs champs), règles et
+// xuple-spaces déclarés dans ce fichier, dans l'ordre du source
+func (c *catalog) symbols(doc *document) []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+	if doc.program == nil {
+		return symbols
+	}
+
+	for _, typeDef := range doc.program.Types {
+		if !doc.contains(typeDef.Pos) {
+			continue
+		}
+		symbol := doc.symbol(typeDef.Name, typeSignature(typeDef), symbolKindStruct, typeDef.Pos)
+		for _, field := range typeDef.Fields {
+			child := doc.symbol(field.Name, field.Type, symbolKindField, typeDef.Pos)
+			child.Range = child.SelectionRange
+			symbol.Children = append(symbol.Children, child)
+		}
+		symbols = append(symbols, symbol)
+	}
+	for _, expression := range doc.program.Expressions {
+		if !doc.contains(expression.Pos) {
+			continue
+		}
+		var variables []string
+		for _, set := range append([]constraint.Set{expression.Set}, expression.Patterns...) {
+			for _, variable := range set.Variables {
+				variables = append(variables, variable.Name+": "+variable.DataType)
+			}
+		}
+		symbols = append(symbols, doc.symbol(expression.RuleId, "{"+strings.Join(variables, ", ")+"}", symbolKindFunction, expression.Pos))
+	}
+	for _, space := range doc.program.XupleSpaces {
+		if doc.contains(space.Pos) {
+			symbols = append(symbols, doc.symbol(space.Name, "xuple-space", symbolKindNamespace, space.Pos))
+		}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		a, b := symbols[i].Range.Start, symbols[j].Range.Start
+		return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+	})
+	return symbols
+}
+
+// symbol crée une entrée du plan ; la portion sélectionnée est le nom dans la
+// première ligne de la déclaration
+func (d *document) symbol(name, detail string, kind int, span *constraint.Span) DocumentSymbol {
+	r := d.spanRange(span)
+	selection := r
+	line := []rune(d.line(span.Line - 1))
+	from := min(max(span.Column-1, 0), len(line))
+	if index := strings.Index(string(line[from:]), name); index >= 0 {
+		start := from + len([]rune(string(line[from:])[:index]))
+		selection = Range{Start: d.position(span.Line-1, start), End: d.position(span.Line-1, start+len([]rune(name)))}
+	}
+	return DocumentSymbol{Name: name, Detail: detail, Kind: kind, Range: r, SelectionRange: selection}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lspcmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/treivax/tsd/internal/defaultactions"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+)
+
+// Config holds the lsp command configuration
+type Config struct {
+	ShowHelp bool
+}
+
+// Run executes the lsp command and returns an exit code. Le serveur dialogue
+// avec l'éditeur sur stdin et stdout jusqu'à la notification exit.
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	defaultActions, err := defaultactions.LoadDefaultActions()
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	// stdout est réservé au protocole : les traces du parser et de la
+	// validation sont coupées, et toute écriture directe va sur stderr
+	tsdio.Mute()
+	defer tsdio.Unmute()
+	realStdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = realStdout }()
+
+	if err := NewServer(stdin, stdout, defaultActions).Serve(); err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	return ExitSuccess
+}
+
+// ParseFlags parses command-line flags and returns a Config
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	flagSet := flag.NewFlagSet("lsp", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	// Options acceptées par convention par les clients LSP ; stdio est le seul transport
+	var stdio bool
+	flagSet.BoolVar(&stdio, "stdio", true, "Dialoguer sur stdin/stdout")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	if flagSet.NArg() > 0 {
+		return nil, fmt.Errorf("argument inattendu: %s", flagSet.Arg(0))
+	}
+	return config, nil
+}
+
+// printHelp displays the lsp command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD LSP - Serveur de langage pour les fichiers .tsd")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd lsp [--stdio]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Le serveur dialogue avec l'éditeur selon le Language Server Protocol sur")
+	fmt.Fprintln(w, "stdin/stdout. Il est lancé par l'éditeur, pas directement.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "FONCTIONNALITÉS:")
+	fmt.Fprintln(w, "  - Diagnostics du parser et de la validation à chaque modification")
+	fmt.Fprintln(w, "  - Complétion : types, champs après 'variable.', actions (par défaut et")
+	fmt.Fprintln(w, "    déclarées), fonctions, mots-clés")
+	fmt.Fprintln(w, "  - Survol : champs et clé primaire d'un type, signature d'une action")
+	fmt.Fprintln(w, "  - Aller à la définition : types, actions, variables de faits")
+	fmt.Fprintln(w, "  - Plan du document : types, règles, xuple-spaces")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  --stdio           Dialoguer sur stdin/stdout (défaut, seul transport)")
+	fmt.Fprintln(w, "  -h, --help        Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  # Neovim (nvim-lspconfig, configuration personnalisée)")
+	fmt.Fprintln(w, "  cmd = { \"tsd\", \"lsp\" }, filetypes = { \"tsd\" }")
+}
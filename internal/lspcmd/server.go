@@ -0,0 +1,237 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lspcmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// ServerName est le nom annoncé à l'éditeur lors de l'initialisation
+const ServerName = "tsd-lsp"
+
+// errExit signale la notification exit : la boucle de Serve s'arrête
+var errExit = errors.New("exit")
+
+// Server est un serveur de langage pour les fichiers .tsd. Il lit les
+// messages de l'éditeur sur in et écrit réponses et diagnostics sur out ;
+// les messages sont traités un par un.
+type Server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	catalog   *catalog
+	documents map[string]*document
+}
+
+// NewServer crée un serveur de langage. defaultActions sont les actions par
+// défaut proposées à la complétion.
+func NewServer(in io.Reader, out io.Writer, defaultActions []constraint.ActionDefinition) *Server {
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		catalog:   newCatalog(defaultActions),
+		documents: make(map[string]*document),
+	}
+}
+
+// Serve traite les messages jusqu'à la notification exit ou la fin de l'entrée
+func (s *Server) Serve() error {
+	for {
+		req, err := s.readRequest()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *responseError
+		if errors.As(err, &parseErr) {
+			// Corps illisible : l'ID est inconnu
+			if err := writeMessage(s.out, errorResponse{JSONRPC: "2.0", Error: parseErr}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err := s.handle(req)
+		if err == errExit {
+			return nil
+		}
+		if req.ID == nil {
+			// Notification : pas de réponse
+			continue
+		}
+
+		var reply interface{} = response{JSONRPC: "2.0", ID: req.ID, Result: result}
+		if err != nil {
+			replyErr, ok := err.(*responseError)
+			if !ok {
+				replyErr = &responseError{Code: codeInvalidParams, Message: err.Error()}
+			}
+			reply = errorResponse{JSONRPC: "2.0", ID: req.ID, Error: replyErr}
+		}
+		if err := writeMessage(s.out, reply); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) readRequest() (*request, error) {
+	req, err := readRequest(s.in)
+	if err == io.ErrUnexpectedEOF {
+		return nil, io.EOF
+	}
+	return req, err
+}
+
+// handle traite une requête ou une notification et retourne le résultat de la réponse
+func (s *Server) handle(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized", "$/cancelRequest", "$/setTrace", "workspace/didChangeConfiguration":
+		return nil, nil
+	case "shutdown":
+		return nil, nil
+	case "exit":
+		return nil, errExit
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		return nil, s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didSave":
+		return nil, nil
+	case "textDocument/didClose":
+		var params DocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, s.publish(params.TextDocument.URI, []Diagnostic{})
+
+	case "textDocument/completion":
+		doc, position, err := s.position(req)
+		if err != nil || doc == nil {
+			return []CompletionItem{}, err
+		}
+		return s.catalog.completion(doc, position), nil
+	case "textDocument/hover":
+		doc, position, err := s.position(req)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+		if hover := s.catalog.hover(doc, position); hover != nil {
+			return hover, nil
+		}
+		return nil, nil
+	case "textDocument/definition":
+		doc, position, err := s.position(req)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+		if location := s.catalog.definition(doc, position); location != nil {
+			return location, nil
+		}
+		return nil, nil
+	case "textDocument/documentSymbol":
+		var params DocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		doc := s.documents[params.TextDocument.URI]
+		if doc == nil {
+			return []DocumentSymbol{}, nil
+		}
+		return s.catalog.symbols(doc), nil
+	case "textDocument/formatting":
+		var params DocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		doc := s.documents[params.TextDocument.URI]
+		if doc == nil {
+			return []TextEdit{}, nil
+		}
+		return doc.formatting(), nil
+	}
+
+	if req.ID == nil {
+		// Notification inconnue : ignorée, comme le prévoit le protocole
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("méthode non supportée: %s", req.Method)}
+}
+
+// initialize retourne les capacités du serveur : synchronisation complète
+// des documents, complétion déclenchée par « . », survol, définition, plan
+// et formatage
+func (s *Server) initialize() interface{} {
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync":           1,
+			"completionProvider":         map[string]interface{}{"triggerCharacters": []string{"."}},
+			"hoverProvider":              true,
+			"definitionProvider":         true,
+			"documentSymbolProvider":     true,
+			"documentFormattingProvider": true,
+		},
+		"serverInfo": map[string]interface{}{"name": ServerName},
+	}
+}
+
+// update analyse la nouvelle version d'un document et publie ses diagnostics
+func (s *Server) update(uri, text string) error {
+	var previous *constraint.Program
+	if doc := s.documents[uri]; doc != nil {
+		previous = doc.program
+	}
+	doc := newDocument(uri, text, previous)
+	s.documents[uri] = doc
+	return s.publish(uri, doc.diagnostics)
+}
+
+// publish envoie les diagnostics d'un document à l'éditeur
+func (s *Server) publish(uri string, diagnostics []Diagnostic) error {
+	return writeMessage(s.out, notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  PublishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+}
+
+// position décode les paramètres d'une requête portant sur une position ; le
+// document est nil s'il n'est pas ouvert
+func (s *Server) position(req *request) (*document, Position, error) {
+	var params TextDocumentPositionParams
+	if err := decodeParams(req, &params); err != nil {
+		return nil, Position{}, err
+	}
+	return s.documents[params.TextDocument.URI], params.Position, nil
+}
+
+// decodeParams décode les paramètres d'un message
+func decodeParams(req *request, params interface{}) error {
+	if err := json.Unmarshal(req.Params, params); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("paramètres invalides pour %s: %v", req.Method, err)}
+	}
+	return nil
+}
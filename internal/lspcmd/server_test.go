@@ -0,0 +1,299 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package lspcmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/internal/defaultactions"
+)
+
+const testURI = "file:///tmp/lsp/rules.tsd"
+
+// call construit une requête
+func call(id int, method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+// notify construit une notification
+func notify(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func didOpen(text string) map[string]interface{} {
+	return notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": testURI, "languageId": "tsd", "version": 1, "text": text},
+	})
+}
+
+func at(line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": testURI},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	}
+}
+
+func TestReadWriteMessage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeMessage(&buf, call(1, "shutdown", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "Content-Length: ") {
+		t.Fatalf("en-tête manquant: %q", buf.String())
+	}
+	req, err := readRequest(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Method != "shutdown" || req.ID == nil || string(*req.ID) != "1" {
+		t.Errorf("requête = %+v", req)
+	}
+
+	_, err = readRequest(bufio.NewReader(strings.NewReader("Content-Length: 3\r\n\r\n{x}")))
+	if _, ok := err.(*responseError); !ok {
+		t.Errorf("corps invalide: erreur %T, attendu *responseError", err)
+	}
+}
+
+func TestServe_Lifecycle(t *testing.T) {
+	replies := serveReplies(t,
+		call(1, "initialize", map[string]interface{}{}),
+		notify("initialized", map[string]interface{}{}),
+		call(2, "workspace/unknown", nil),
+		call(3, "shutdown", nil),
+		notify("exit", nil),
+		call(4, "shutdown", nil), // après exit : ignoré
+	)
+	if len(replies) != 3 {
+		t.Fatalf("%d réponses, attendu 3: %v", len(replies), replies)
+	}
+
+	capabilities := replies[0]["result"].(map[string]interface{})["capabilities"].(map[string]interface{})
+	for _, capability := range []string{"hoverProvider", "definitionProvider", "documentSymbolProvider", "completionProvider", "documentFormattingProvider"} {
+		if capabilities[capability] == nil {
+			t.Errorf("capacité %s absente", capability)
+		}
+	}
+	if code := replies[1]["error"].(map[string]interface{})["code"].(float64); code != codeMethodNotFound {
+		t.Errorf("méthode inconnue: code %v", code)
+	}
+	if result, ok := replies[2]["result"]; !ok || result != nil {
+		t.Errorf("shutdown doit retourner result: null, obtenu %v", replies[2])
+	}
+}
+
+func TestServe_Diagnostics(t *testing.T) {
+	text := "type Person(#id: string, age: number)\n" +
+		"rule adult : {p: Person} / p.agee >= 18 ==> Print(p.id)\n"
+	replies := serveReplies(t, didOpen(text))
+	if len(replies) != 1 || replies[0]["method"] != "textDocument/publishDiagnostics" {
+		t.Fatalf("réponses = %v", replies)
+	}
+
+	diagnostics := replies[0]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+	if len(diagnostics) == 0 {
+		t.Fatal("aucun diagnostic pour un champ inconnu")
+	}
+	diagnostic := diagnostics[0].(map[string]interface{})
+	start := diagnostic["range"].(map[string]interface{})["start"].(map[string]interface{})
+	if start["line"].(float64) != 1 {
+		t.Errorf("diagnostic ligne %v, attendu 1: %v", start["line"], diagnostic)
+	}
+	if !strings.Contains(diagnostic["message"].(string), "agee") {
+		t.Errorf("message = %q", diagnostic["message"])
+	}
+}
+
+func TestServe_SyntaxErrorKeepsCompletion(t *testing.T) {
+	valid := "type Person(#id: string, age: number)\n"
+	broken := valid + "rule r : {p: Person} / p."
+	replies := serveReplies(t,
+		didOpen(valid),
+		notify("textDocument/didChange", map[string]interface{}{
+			"textDocument":   map[string]interface{}{"uri": testURI, "version": 2},
+			"contentChanges": []interface{}{map[string]interface{}{"text": broken}},
+		}),
+		call(1, "textDocument/completion", at(1, len("rule r : {p: Person} / p."))),
+	)
+	if len(replies) != 3 {
+		t.Fatalf("%d réponses, attendu 3", len(replies))
+	}
+
+	diagnostics := replies[1]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+	if len(diagnostics) != 1 || diagnostics[0].(map[string]interface{})["code"] != "syntax" {
+		t.Errorf("diagnostics = %v", diagnostics)
+	}
+	if labels := completionLabels(replies[2]); strings.Join(labels, ",") != "id,age" {
+		t.Errorf("complétion = %v, attendu [id age]", labels)
+	}
+}
+
+func TestServe_Features(t *testing.T) {
+	text := strings.Join([]string{
+		"type Person(#id: string, age: number)",
+		"action notify(id: string)",
+		"xuple-space alerts {",
+		"selection: fifo",
+		"consumption: once",
+		"retention: unlimited",
+		"}",
+		"rule adult : {p: Person} / p.age >= 18 ==> notify(p.id)",
+		"alice = Person(id: \"a\", age: 30)",
+	}, "\n")
+	replies := serveReplies(t,
+		didOpen(text),
+		call(1, "textDocument/hover", at(7, 18)),         // Person
+		call(2, "textDocument/definition", at(7, 45)),    // notify
+		call(3, "textDocument/definition", at(7, 31)),    // p.age
+		call(4, "textDocument/documentSymbol", at(0, 0)), // plan
+		call(5, "textDocument/completion", at(7, 44)),    // début de l'action
+		call(6, "textDocument/hover", at(0, 0)),          // mot-clé type
+		call(7, "textDocument/definition", at(8, 2)),     // alice
+	)
+	if len(replies) != 8 {
+		t.Fatalf("%d réponses, attendu 8", len(replies))
+	}
+	if diagnostics := replies[0]["params"].(map[string]interface{})["diagnostics"].([]interface{}); len(diagnostics) != 0 {
+		t.Errorf("diagnostics inattendus: %v", diagnostics)
+	}
+
+	hover := replies[1]["result"].(map[string]interface{})["contents"].(map[string]interface{})["value"].(string)
+	if !strings.Contains(hover, "type Person(#id: string, age: number)") || !strings.Contains(hover, "Clé primaire : `id`") {
+		t.Errorf("survol = %q", hover)
+	}
+
+	for i, wantLine := range map[int]float64{2: 1, 3: 0, 7: 8} {
+		location := replies[i]["result"].(map[string]interface{})
+		line := location["range"].(map[string]interface{})["start"].(map[string]interface{})["line"].(float64)
+		if location["uri"] != testURI || line != wantLine {
+			t.Errorf("définition %d = %v, attendu ligne %v", i, location, wantLine)
+		}
+	}
+
+	var names []string
+	for _, symbol := range replies[4]["result"].([]interface{}) {
+		names = append(names, symbol.(map[string]interface{})["name"].(string))
+	}
+	if strings.Join(names, ",") != "Person,alerts,adult" {
+		t.Errorf("plan = %v", names)
+	}
+
+	labels := strings.Join(completionLabels(replies[5]), ",")
+	for _, want := range []string{"notify", "Print", "Xuple", "Person", "rule", "COUNT", "alice"} {
+		if !strings.Contains(","+labels+",", ","+want+",") {
+			t.Errorf("complétion sans %s: %s", want, labels)
+		}
+	}
+
+	if replies[6]["result"] != nil {
+		t.Errorf("survol d'un mot-clé = %v, attendu null", replies[6]["result"])
+	}
+}
+
+// serveReplies envoie les messages au serveur et retourne les messages écrits, décodés
+func serveReplies(t *testing.T, messages ...interface{}) []map[string]interface{} {
+	t.Helper()
+	var in bytes.Buffer
+	for _, msg := range messages {
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatalf("writeMessage: %v", err)
+		}
+	}
+	defaults, err := defaultactions.LoadDefaultActions()
+	if err != nil {
+		t.Fatalf("LoadDefaultActions: %v", err)
+	}
+	var out bytes.Buffer
+	if err := NewServer(&in, &out, defaults).Serve(); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	return decodeMessages(t, out.String())
+}
+
+// decodeMessages découpe la sortie du serveur en messages JSON
+func decodeMessages(t *testing.T, output string) []map[string]interface{} {
+	t.Helper()
+	var messages []map[string]interface{}
+	for output != "" {
+		var length int
+		if _, err := fmt.Sscanf(output, "Content-Length: %d\r\n\r\n", &length); err != nil {
+			t.Fatalf("en-tête invalide: %q", output)
+		}
+		body := output[strings.Index(output, "\r\n\r\n")+4:]
+		var msg map[string]interface{}
+		if err := json.Unmarshal([]byte(body[:length]), &msg); err != nil {
+			t.Fatalf("message invalide: %v", err)
+		}
+		messages = append(messages, msg)
+		output = body[length:]
+	}
+	return messages
+}
+
+func completionLabels(reply map[string]interface{}) []string {
+	var labels []string
+	for _, item := range reply["result"].([]interface{}) {
+		labels = append(labels, item.(map[string]interface{})["label"].(string))
+	}
+	return labels
+}
+
+func TestServe_DefinitionInImportedFile(t *testing.T) {
+	dir := t.TempDir()
+	common := filepath.Join(dir, "common.tsd")
+	if err := os.WriteFile(common, []byte("type Person(#id: string, age: number)\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	uri := pathToURI(filepath.Join(dir, "main.tsd"))
+	text := "import \"common.tsd\"\nrule adult : {p: Person} / p.age >= 18 ==> Print(p.id)\n"
+
+	replies := serveReplies(t,
+		notify("textDocument/didOpen", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri, "languageId": "tsd", "version": 1, "text": text},
+		}),
+		call(1, "textDocument/definition", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri},
+			"position":     map[string]interface{}{"line": 1, "character": 19},
+		}),
+	)
+	if diagnostics := replies[0]["params"].(map[string]interface{})["diagnostics"].([]interface{}); len(diagnostics) != 0 {
+		t.Errorf("diagnostics inattendus: %v", diagnostics)
+	}
+	location := replies[1]["result"].(map[string]interface{})
+	if location["uri"] != pathToURI(common) {
+		t.Errorf("définition = %v, attendu %s", location, pathToURI(common))
+	}
+}
+
+func TestServe_Formatting(t *testing.T) {
+	text := "type  Person(#id:string)\r\nrule r:{p:Person}/p.id=='a' ==> Print(p.id)"
+	replies := serveReplies(t,
+		didOpen(text),
+		call(1, "textDocument/formatting", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": testURI},
+			"options":      map[string]interface{}{"tabSize": 4, "insertSpaces": true},
+		}),
+	)
+	edits := replies[1]["result"].([]interface{})
+	if len(edits) != 1 {
+		t.Fatalf("modifications = %v, attendu 1", edits)
+	}
+	edit := edits[0].(map[string]interface{})
+	want := "type Person(#id: string)\nrule r : {p: Person} / p.id == \"a\" ==> Print(p.id)\n"
+	if edit["newText"] != want {
+		t.Errorf("newText = %q", edit["newText"])
+	}
+	end := edit["range"].(map[string]interface{})["end"].(map[string]interface{})
+	if end["line"].(float64) != 1 || end["character"].(float64) != 43 {
+		t.Errorf("fin de la modification = %v, attendu 1:43", end)
+	}
+}
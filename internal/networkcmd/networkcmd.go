@@ -0,0 +1,159 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package networkcmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+)
+
+// Formats de sortie
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// ErrNoFile est retourné lorsqu'aucun fichier n'est donné
+var ErrNoFile = errors.New("aucun fichier TSD spécifié")
+
+// Config holds the network command configuration
+type Config struct {
+	Files    []string // Programmes TSD ingérés dans l'ordre (types, règles, faits)
+	Format   string   // dot, mermaid ou json
+	Rules    []string // Règles dont le sous-graphe est exporté (vide : toutes)
+	ShowHelp bool
+}
+
+// Run executes the network command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	if len(config.Files) == 0 {
+		fmt.Fprintf(stderr, "Erreur: %v\n\n", ErrNoFile)
+		printHelp(stderr)
+		return ExitError
+	}
+
+	graph, err := buildGraph(config, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if err := writeGraph(stdout, config.Format, graph); err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	return ExitSuccess
+}
+
+// buildGraph ingère les fichiers puis retourne le graphe du réseau
+func buildGraph(config *Config, stderr io.Writer) (*rete.NetworkGraph, error) {
+	pipelineConfig := api.DefaultConfig()
+	pipelineConfig.LogLevel = api.LogLevelSilent
+	pipelineConfig.Output = stderr
+	pipeline := api.NewPipelineWithConfig(pipelineConfig)
+
+	// Les loggers du moteur et les actions Print/Log écrivent sur Output ;
+	// les traces fmt.Printf des constructeurs de nœuds suivent os.Stdout,
+	// redirigé le temps de l'ingestion : stdout ne contient que le graphe
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+	for _, file := range config.Files {
+		if _, err := pipeline.IngestFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return pipeline.NetworkGraph(config.Rules...)
+}
+
+// writeGraph écrit le graphe dans le format demandé
+func writeGraph(w io.Writer, format string, graph *rete.NetworkGraph) error {
+	switch format {
+	case FormatMermaid:
+		return graph.WriteMermaid(w)
+	case FormatJSON:
+		return graph.WriteJSON(w)
+	}
+	return graph.WriteDOT(w)
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont les fichiers ingérés, dans l'ordre.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	var rules string
+	flagSet := flag.NewFlagSet("network", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.StringVar(&config.Format, "format", FormatDOT, "Format de sortie (dot, mermaid, json)")
+	flagSet.StringVar(&rules, "rule", "", "Règles à exporter, séparées par des virgules")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	if config.Format != FormatDOT && config.Format != FormatMermaid && config.Format != FormatJSON {
+		return nil, fmt.Errorf("format invalide: %s (doit être 'dot', 'mermaid' ou 'json')", config.Format)
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			config.Rules = append(config.Rules, rule)
+		}
+	}
+	config.Files = flagSet.Args()
+	return config, nil
+}
+
+// printHelp displays the network command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Network - Export du réseau RETE compilé")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd network [options] fichier.tsd [fichier.tsd...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Les fichiers sont ingérés dans l'ordre, puis le graphe du réseau est écrit")
+	fmt.Fprintln(w, "sur la sortie standard : nœuds de type, alpha, passthrough, jointure, exists,")
+	fmt.Fprintln(w, "accumulateur, routeur et terminal. Les nœuds partagés entre plusieurs règles")
+	fmt.Fprintln(w, "sont mis en évidence ; si des faits sont chargés, la taille des mémoires de")
+	fmt.Fprintln(w, "chaque nœud est indiquée.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -format <format>   Format de sortie: dot, mermaid ou json (défaut: dot)")
+	fmt.Fprintln(w, "  -rule <liste>      N'exporter que les nœuds de ces règles (séparées par des virgules)")
+	fmt.Fprintln(w, "  -h, --help         Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd network rules.tsd | dot -Tsvg > network.svg")
+	fmt.Fprintln(w, "  tsd network -format mermaid -rule customerOrders rules.tsd")
+	fmt.Fprintln(w, "  tsd network -format json rules.tsd facts.tsd")
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package networkcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const networkProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: string, total: number)
+
+rule bigOrder : {c: Customer, o: Order} / o.customer == c.id AND o.total > 100 ==> Print(o.id)
+rule goldCustomer : {c: Customer} / c.tier == "gold" ==> Print(c.id)
+`
+
+const networkFacts = `Customer(id: "c1", tier: "gold")
+Order(id: "o1", customer: "c1", total: 250)
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+func run(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(args, nil, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := ParseFlags([]string{"-format", "mermaid", "-rule", "a, b", "rules.tsd", "facts.tsd"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if config.Format != FormatMermaid || strings.Join(config.Rules, ",") != "a,b" || strings.Join(config.Files, ",") != "rules.tsd,facts.tsd" {
+		t.Errorf("config = %+v", config)
+	}
+
+	if config, _ := ParseFlags([]string{"rules.tsd"}); config.Format != FormatDOT || config.Rules != nil {
+		t.Errorf("défauts = %+v", config)
+	}
+	if _, err := ParseFlags([]string{"-format", "svg", "rules.tsd"}); err == nil {
+		t.Error("format invalide accepté")
+	}
+}
+
+func TestRun_DOT(t *testing.T) {
+	code, stdout, _ := run(t, writeFile(t, "rules.tsd", networkProgram))
+	if code != ExitSuccess {
+		t.Fatalf("code = %d", code)
+	}
+	if !strings.HasPrefix(stdout, "digraph rete {\n") || !strings.HasSuffix(stdout, "}\n") {
+		t.Errorf("sortie:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, `label="terminal\ngoldCustomer ⇒ Print"`) || strings.Contains(stdout, "facts:") {
+		t.Errorf("sortie:\n%s", stdout)
+	}
+}
+
+func TestRun_JSONWithFacts(t *testing.T) {
+	code, stdout, _ := run(t, "-format", "json", "-rule", "bigOrder",
+		writeFile(t, "rules.tsd", networkProgram), writeFile(t, "facts.tsd", networkFacts))
+	if code != ExitSuccess {
+		t.Fatalf("code = %d", code)
+	}
+
+	var graph rete.NetworkGraph
+	if err := json.Unmarshal([]byte(stdout), &graph); err != nil {
+		t.Fatalf("JSON invalide: %v\n%s", err, stdout)
+	}
+	if graph.Facts != 2 || strings.Join(graph.Rules, ",") != "bigOrder" {
+		t.Errorf("graph = %+v", graph)
+	}
+	for _, node := range graph.Nodes {
+		if node.Kind == rete.GraphNodeJoin && node.Memory["results"] != 1 {
+			t.Errorf("mémoire de la jointure = %v", node.Memory)
+		}
+		if strings.Contains(node.Label, "gold") {
+			t.Errorf("nœud hors du filtre: %+v", node)
+		}
+	}
+}
+
+func TestRun_Mermaid(t *testing.T) {
+	code, stdout, _ := run(t, "-format", "mermaid", writeFile(t, "rules.tsd", networkProgram))
+	if code != ExitSuccess || !strings.HasPrefix(stdout, "flowchart TD\n") || !strings.Contains(stdout, " -- right --> ") {
+		t.Errorf("code %d, sortie:\n%s", code, stdout)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	rules := writeFile(t, "rules.tsd", networkProgram)
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"sans fichier", nil, ErrNoFile.Error()},
+		{"fichier absent", []string{"/nonexistent/rules.tsd"}, "Erreur"},
+		{"règle inconnue", []string{"-rule", "nope", rules}, "nope"},
+		{"format invalide", []string{"-format", "png", rules}, "format invalide"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := run(t, tt.args...)
+			if code != ExitError || !strings.Contains(stderr, tt.want) {
+				t.Errorf("code %d, stderr %q", code, stderr)
+			}
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	code, stdout, _ := run(t, "-h")
+	if code != ExitSuccess || !strings.Contains(stdout, "tsd network [options]") {
+		t.Errorf("code %d, sortie %q", code, stdout)
+	}
+}
+
+// captureStdout exécute run avec os.Stdout redirigé vers un tube, comme
+// `tsd network > graph.dot`, et retourne ce qui y a été écrit
+func captureStdout(t *testing.T, run func(stdout *os.File)) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+	run(writer)
+	os.Stdout = stdout
+	writer.Close()
+	return <-output
+}
+
+func TestRun_ProcessStdoutOnlyGraph(t *testing.T) {
+	rules := writeFile(t, "rules.tsd", networkProgram)
+	facts := writeFile(t, "facts.tsd", networkFacts)
+
+	// Traces du moteur et actions Print vont sur stderr, jamais avant le graphe
+	var stderr bytes.Buffer
+	output := captureStdout(t, func(stdout *os.File) {
+		if code := Run([]string{"-format", "json", rules, facts}, nil, stdout, &stderr); code != ExitSuccess {
+			t.Errorf("code = %d, stderr: %s", code, stderr.String())
+		}
+	})
+	var graph rete.NetworkGraph
+	if err := json.Unmarshal([]byte(output), &graph); err != nil {
+		t.Fatalf("stdout n'est pas du JSON: %v\n%s", err, output)
+	}
+
+	output = captureStdout(t, func(stdout *os.File) {
+		if code := Run([]string{rules, facts}, nil, stdout, &stderr); code != ExitSuccess {
+			t.Errorf("code = %d, stderr: %s", code, stderr.String())
+		}
+	})
+	if !strings.HasPrefix(output, "digraph rete {\n") || !strings.HasSuffix(output, "}\n") {
+		t.Errorf("stdout n'est pas un graphe DOT:\n%s", output)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package replcmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MaxHistoryEntries est le nombre de lignes conservées dans l'historique
+const MaxHistoryEntries = 1000
+
+// errInterrupted est retourné par readLine lorsque la saisie est abandonnée (Ctrl-C)
+var errInterrupted = errors.New("saisie interrompue")
+
+// Completer propose les complétions du mot se terminant à pos dans line :
+// start est le début du mot remplacé, candidates les mots complets proposés
+type Completer func(line string, pos int) (start int, candidates []string)
+
+// history conserve les lignes saisies, en mémoire et dans un fichier
+type history struct {
+	entries []string
+	path    string // Fichier d'historique ("" : en mémoire seulement)
+}
+
+// loadHistory charge l'historique d'un fichier ; un fichier absent donne un historique vide
+func loadHistory(path string) (*history, error) {
+	h := &history{path: path}
+	if path == "" {
+		return h, nil
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if len(h.entries) > MaxHistoryEntries {
+		h.entries = h.entries[len(h.entries)-MaxHistoryEntries:]
+	}
+	return h, scanner.Err()
+}
+
+// add ajoute une ligne à l'historique, sauf si elle est vide ou répète la précédente
+func (h *history) add(line string) error {
+	if strings.TrimSpace(line) == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return nil
+	}
+	h.entries = append(h.entries, line)
+	if len(h.entries) > MaxHistoryEntries {
+		h.entries = h.entries[1:]
+	}
+	if h.path == "" {
+		return nil
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(file, line); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Codes des touches reconnues par l'éditeur de ligne
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyBackspace = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyCtrlK     = 11
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// lineEditor lit une ligne sur un terminal en mode brut : édition, historique
+// (flèches haut/bas) et complétion (tabulation)
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  *history
+	complete Completer
+}
+
+// newLineEditor crée un éditeur de ligne
+func newLineEditor(in io.Reader, out io.Writer, history *history, complete Completer) *lineEditor {
+	return &lineEditor{in: bufio.NewReader(in), out: out, history: history, complete: complete}
+}
+
+// lineState est la ligne en cours d'édition
+type lineState struct {
+	prompt  string
+	buf     []rune
+	pos     int
+	browse  int    // Position dans l'historique (len(entries) : ligne en cours)
+	pending []rune // Ligne en cours, conservée pendant le parcours de l'historique
+}
+
+// readLine lit une ligne. Retourne io.EOF sur Ctrl-D en début de ligne vide
+// et errInterrupted sur Ctrl-C.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	state := &lineState{prompt: prompt, browse: len(e.history.entries)}
+	e.refresh(state)
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(e.out, "\r\n")
+			return string(state.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(state.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			state.deleteAt(state.pos)
+		case keyBackspace, keyDelete:
+			if state.pos > 0 {
+				state.pos--
+				state.deleteAt(state.pos)
+			}
+		case keyCtrlA:
+			state.pos = 0
+		case keyCtrlE:
+			state.pos = len(state.buf)
+		case keyCtrlB:
+			state.move(-1)
+		case keyCtrlF:
+			state.move(1)
+		case keyCtrlK:
+			state.buf = state.buf[:state.pos]
+		case keyCtrlU:
+			state.buf = state.buf[state.pos:]
+			state.pos = 0
+		case keyCtrlP:
+			e.browse(state, -1)
+		case keyCtrlN:
+			e.browse(state, 1)
+		case keyTab:
+			e.completeWord(state)
+		case keyEscape:
+			e.escape(state)
+		default:
+			if r >= ' ' {
+				state.insert([]rune{r})
+			}
+		}
+		e.refresh(state)
+	}
+}
+
+// escape traite une séquence d'échappement (flèches, début, fin, suppression)
+func (e *lineEditor) escape(state *lineState) {
+	introducer, _, err := e.in.ReadRune()
+	if err != nil || (introducer != '[' && introducer != 'O') {
+		return
+	}
+	code, _, err := e.in.ReadRune()
+	if err != nil {
+		return
+	}
+	switch code {
+	case 'A':
+		e.browse(state, -1)
+	case 'B':
+		e.browse(state, 1)
+	case 'C':
+		state.move(1)
+	case 'D':
+		state.move(-1)
+	case 'H':
+		state.pos = 0
+	case 'F':
+		state.pos = len(state.buf)
+	case '3':
+		if next, _, err := e.in.ReadRune(); err == nil && next == '~' {
+			state.deleteAt(state.pos)
+		}
+	}
+}
+
+// browse remonte (-1) ou redescend (+1) dans l'historique
+func (e *lineEditor) browse(state *lineState, delta int) {
+	entries := e.history.entries
+	target := state.browse + delta
+	if target < 0 || target > len(entries) {
+		return
+	}
+	if state.browse == len(entries) {
+		state.pending = append([]rune(nil), state.buf...)
+	}
+	state.browse = target
+	if target == len(entries) {
+		state.buf = append([]rune(nil), state.pending...)
+	} else {
+		state.buf = []rune(entries[target])
+	}
+	state.pos = len(state.buf)
+}
+
+// completeWord complète le mot sous le curseur : le candidat unique, sinon
+// leur préfixe commun, sinon la liste des candidats est affichée
+func (e *lineEditor) completeWord(state *lineState) {
+	if e.complete == nil {
+		return
+	}
+	line := string(state.buf[:state.pos])
+	start, candidates := e.complete(string(state.buf), len(line))
+	if len(candidates) == 0 {
+		fmt.Fprint(e.out, "\a")
+		return
+	}
+	word := line[start:]
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	}
+	if len(completion) > len(word) {
+		state.insert([]rune(completion[len(word):]))
+		return
+	}
+	fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+}
+
+// refresh réaffiche la ligne et place le curseur
+func (e *lineEditor) refresh(state *lineState) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", state.prompt, string(state.buf))
+	if back := len(state.buf) - state.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+func (s *lineState) insert(runes []rune) {
+	buf := make([]rune, 0, len(s.buf)+len(runes))
+	buf = append(buf, s.buf[:s.pos]...)
+	buf = append(buf, runes...)
+	s.buf = append(buf, s.buf[s.pos:]...)
+	s.pos += len(runes)
+}
+
+func (s *lineState) deleteAt(pos int) {
+	if pos < len(s.buf) {
+		s.buf = append(s.buf[:pos], s.buf[pos+1:]...)
+	}
+}
+
+func (s *lineState) move(delta int) {
+	if pos := s.pos + delta; pos >= 0 && pos <= len(s.buf) {
+		s.pos = pos
+	}
+}
+
+// commonPrefix retourne le plus long préfixe commun des mots
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
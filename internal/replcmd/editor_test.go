@@ -0,0 +1,132 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package replcmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readKeys lit une ligne saisie touche par touche
+func readKeys(t *testing.T, history *history, complete Completer, keys string) (string, string, error) {
+	t.Helper()
+	var out strings.Builder
+	editor := newLineEditor(strings.NewReader(keys), &out, history, complete)
+	line, err := editor.readLine(Prompt)
+	return line, out.String(), err
+}
+
+func TestLineEditor_Editing(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want string
+	}{
+		{"saisie", "type T\r", "type T"},
+		{"effacement", "typo\x7f\x7fpe\r", "type"},
+		{"flèches", "tpe\x1b[D\x1b[D\x1b[Dy\x1b[C\x1b[C\x1b[C!\r", "ytpe!"},
+		{"début et fin", "bc\x01a\x05d\r", "abcd"},
+		{"suppression", "abc\x01\x1b[3~\r", "bc"},
+		{"Ctrl-K", "abcdef\x1b[D\x1b[D\x0b\r", "abcd"},
+		{"Ctrl-U", "abcdef\x1b[D\x1b[D\x15\r", "ef"},
+		{"UTF-8", "é\x7fè\r", "è"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, _, err := readKeys(t, &history{}, nil, tt.keys)
+			if err != nil || line != tt.want {
+				t.Errorf("readLine() = %q, %v, want %q", line, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineEditor_History(t *testing.T) {
+	h := &history{entries: []string{"first", "second"}}
+	tests := []struct {
+		keys string
+		want string
+	}{
+		{"\x1b[A\r", "second"},
+		{"\x1b[A\x1b[A\x1b[A\r", "first"},
+		{"draft\x1b[A\x1b[B\r", "draft"},
+		{"\x10\x10\x0e\r", "second"},
+	}
+	for _, tt := range tests {
+		if line, _, _ := readKeys(t, h, nil, tt.keys); line != tt.want {
+			t.Errorf("readLine(%q) = %q, want %q", tt.keys, line, tt.want)
+		}
+	}
+}
+
+func TestLineEditor_Completion(t *testing.T) {
+	complete := func(line string, pos int) (int, []string) {
+		start := strings.LastIndex(line[:pos], " ") + 1
+		return start, matching([]string{"Person", "Persona", "Order"}, line[start:pos], "")
+	}
+	tests := []struct {
+		name     string
+		keys     string
+		want     string
+		wantList bool
+	}{
+		{"unique", "x O\t\r", "x Order ", false},
+		{"préfixe commun", "Pe\t\r", "Person", false},
+		{"liste", "Person\t\r", "Person", true},
+		{"aucun", "Z\t\r", "Z", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, out, _ := readKeys(t, &history{}, complete, tt.keys)
+			if line != tt.want {
+				t.Errorf("readLine() = %q, want %q", line, tt.want)
+			}
+			if listed := strings.Contains(out, "Person  Persona"); listed != tt.wantList {
+				t.Errorf("candidats affichés = %v, sortie %q", listed, out)
+			}
+		})
+	}
+}
+
+func TestLineEditor_Interrupt(t *testing.T) {
+	if _, _, err := readKeys(t, &history{}, nil, "abc\x03"); !errors.Is(err, errInterrupted) {
+		t.Errorf("Ctrl-C: err = %v", err)
+	}
+	if _, _, err := readKeys(t, &history{}, nil, "\x04"); !errors.Is(err, io.EOF) {
+		t.Errorf("Ctrl-D: err = %v", err)
+	}
+	if line, _, _ := readKeys(t, &history{}, nil, "ab\x01\x04\r"); line != "b" {
+		t.Errorf("Ctrl-D sur une ligne non vide = %q, want %q", line, "b")
+	}
+}
+
+func TestHistory_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory() error = %v", err)
+	}
+	for _, line := range []string{"a", "a", "", "b"} {
+		if err := h.add(line); err != nil {
+			t.Fatalf("add() error = %v", err)
+		}
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "a\nb\n" {
+		t.Errorf("fichier = %q", content)
+	}
+
+	lines := strings.Repeat("x\n", MaxHistoryEntries+5)
+	if err := os.WriteFile(path, []byte(lines), 0600); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	if h, err = loadHistory(path); err != nil || len(h.entries) != MaxHistoryEntries {
+		t.Errorf("loadHistory() = %d entrées, %v", len(h.entries), err)
+	}
+}
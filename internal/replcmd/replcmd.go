@@ -0,0 +1,290 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package replcmd
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+)
+
+// Invites affichées sur un terminal
+const (
+	Prompt             = "tsd> "
+	ContinuationPrompt = "...> "
+)
+
+// DefaultHistoryFile est le fichier d'historique, relatif au répertoire personnel
+const DefaultHistoryFile = ".tsd_history"
+
+// Config holds the repl command configuration
+type Config struct {
+	Files       []string // Fichiers .tsd chargés au démarrage
+	HistoryFile string   // Fichier d'historique ("" : pas d'historique persistant)
+	ShowHelp    bool
+}
+
+// Run executes the repl command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	history, err := loadHistory(config.HistoryFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: historique %s: %v\n", config.HistoryFile, err)
+		return ExitError
+	}
+	s, err := newSession(history, stdout, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	defer s.close()
+
+	for _, file := range config.Files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitError
+		}
+		s.execute(string(content))
+	}
+
+	input := newInput(stdin, stdout, history, s.complete)
+	if input.terminal {
+		fmt.Fprintln(stdout, "TSD REPL - :help pour les commandes, :quit pour quitter")
+	}
+	if err := loop(s, input); err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	return ExitSuccess
+}
+
+// loop lit les lignes jusqu'à la fin de l'entrée ou :quit. Les lignes d'une
+// instruction sont accumulées jusqu'à ce qu'elle soit complète ; une ligne
+// vide soumet l'instruction en cours.
+func loop(s *session, input *input) error {
+	var pending []string
+	for {
+		prompt := Prompt
+		if len(pending) > 0 {
+			prompt = ContinuationPrompt
+		}
+		line, err := input.readLine(prompt)
+		if errors.Is(err, errInterrupted) {
+			pending = nil
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			if len(pending) > 0 {
+				s.execute(strings.Join(pending, "\n"))
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.history.add(line); err != nil {
+			fmt.Fprintf(s.stderr, "Erreur: historique: %v\n", err)
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case len(pending) == 0 && trimmed == "":
+			continue
+		case len(pending) == 0 && strings.HasPrefix(trimmed, ":"):
+			if err := s.command(trimmed); errors.Is(err, errQuit) {
+				return nil
+			} else if err != nil {
+				fmt.Fprintf(s.stderr, "Erreur: %v\n", err)
+			}
+			continue
+		}
+
+		if trimmed != "" {
+			pending = append(pending, line)
+		}
+		statement := strings.Join(pending, "\n")
+		if trimmed != "" && incomplete(statement) {
+			continue
+		}
+		pending = nil
+		s.execute(statement)
+	}
+}
+
+// input lit les lignes de l'entrée : avec édition, historique et complétion
+// sur un terminal, telles quelles sinon (script redirigé)
+type input struct {
+	terminal bool
+	fd       uintptr
+	editor   *lineEditor
+	scanner  *bufio.Scanner
+}
+
+// newInput prépare la lecture de stdin
+func newInput(stdin io.Reader, stdout io.Writer, history *history, complete Completer) *input {
+	if file, ok := stdin.(*os.File); ok {
+		if restore, err := makeRaw(file.Fd()); err == nil {
+			restore()
+			return &input{terminal: true, fd: file.Fd(), editor: newLineEditor(stdin, stdout, history, complete)}
+		}
+	}
+	return &input{scanner: bufio.NewScanner(stdin)}
+}
+
+// readLine lit une ligne ; le terminal n'est en mode brut que pendant la
+// saisie, pour que les actions et Ctrl-C se comportent normalement ensuite
+func (in *input) readLine(prompt string) (string, error) {
+	if !in.terminal {
+		if !in.scanner.Scan() {
+			if err := in.scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+		return in.scanner.Text(), nil
+	}
+	restore, err := makeRaw(in.fd)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	return in.editor.readLine(prompt)
+}
+
+// continuations sont les fins de ligne qui annoncent la suite de l'instruction
+var continuations = []string{"/", "==>", ",", ":", "=", "+", "-", "*", "<", ">", "AND", "OR", "NOT"}
+
+// incomplete indique si une instruction se poursuit sur la ligne suivante :
+// parenthèse, accolade, crochet, chaîne ou commentaire non fermé, ligne
+// terminée par un opérateur, ou règle sans « ==> »
+func incomplete(statement string) bool {
+	depth := 0
+	last := -1 // Dernier caractère significatif (hors commentaires)
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case c == '"':
+			end := strings.IndexByte(statement[i+1:], '"')
+			for end >= 0 && strings.HasSuffix(statement[i+1:i+1+end], `\`) {
+				next := strings.IndexByte(statement[i+end+2:], '"')
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += next + 1
+			}
+			if end < 0 {
+				return true
+			}
+			i += end + 1
+		case strings.HasPrefix(statement[i:], "//"):
+			if end := strings.IndexByte(statement[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(statement)
+			}
+			continue
+		case strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				return true
+			}
+			i += end + 3
+			continue
+		case strings.IndexByte("({[", c) >= 0:
+			depth++
+		case strings.IndexByte(")}]", c) >= 0:
+			depth--
+		}
+		if !unicode.IsSpace(rune(c)) {
+			last = i
+		}
+	}
+	if depth > 0 {
+		return true
+	}
+
+	significant := statement[:last+1]
+	for _, suffix := range continuations {
+		if strings.HasSuffix(significant, suffix) {
+			return true
+		}
+	}
+	fields := strings.Fields(significant)
+	return len(fields) > 0 && fields[0] == "rule" && !strings.Contains(significant, "==>")
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont des fichiers .tsd chargés au démarrage.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	flagSet := flag.NewFlagSet("repl", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	defaultHistory := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		defaultHistory = filepath.Join(home, DefaultHistoryFile)
+	}
+	flagSet.StringVar(&config.HistoryFile, "history", defaultHistory, "Fichier d'historique")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	config.Files = flagSet.Args()
+	return config, nil
+}
+
+// printHelp displays the repl command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD REPL - Session interactive sur un réseau de règles")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd repl [options] [fichier.tsd...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Les fichiers sont chargés au démarrage. Chaque instruction saisie (type,")
+	fmt.Fprintln(w, "action, règle, fait, remove fact, reset) est ingérée dans le même réseau ;")
+	fmt.Fprintln(w, "une instruction incomplète se poursuit sur la ligne suivante, une ligne vide")
+	fmt.Fprintln(w, "la soumet. Les actions déclenchées sont affichées (→ règle: action(...)).")
+	fmt.Fprintln(w, "Sur un terminal : historique (flèches haut/bas) et complétion des types,")
+	fmt.Fprintln(w, "champs, règles et commandes (tabulation).")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "COMMANDES:")
+	printCommands(w)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -history fichier  Fichier d'historique (défaut : ~/"+DefaultHistoryFile+", vide : aucun)")
+	fmt.Fprintln(w, "  -h, --help        Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd repl")
+	fmt.Fprintln(w, "  tsd repl rules.tsd")
+	fmt.Fprintln(w, "  tsd repl -history '' < session.txt")
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package replcmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sessionTypes = `type Person(#id: string, age: number)
+action notify(id: string)
+rule adults : {p: Person} /
+    p.age >= 18
+    ==> notify(p.id), Print(p.id)
+`
+
+// runSession exécute une session sur un script et retourne stdout et stderr
+func runSession(t *testing.T, script string, args ...string) (string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-history", ""}, args...)
+	if code := Run(args, strings.NewReader(script), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("code = %d (stderr: %s)", code, stderr.String())
+	}
+	return stdout.String(), stderr.String()
+}
+
+func TestRun_Statements(t *testing.T) {
+	stdout, stderr := runSession(t, sessionTypes+
+		`Person(id: "alice", age: 30)
+Person(id: "bob", age: 10)
+:facts Person
+remove fact Person bob
+:facts
+:rules
+`)
+	if stderr != "" {
+		t.Errorf("stderr = %q", stderr)
+	}
+	for _, want := range []string{
+		"✓ 1 règle(s)\n",
+		"alice\n→ adults: notify [p=Person~alice]\n✓ 1 fait(s)\n",
+		"Person(id: \"alice\", age: 30)\nPerson(id: \"bob\", age: 10)\n2 fait(s)\n",
+		"Person(id: \"alice\", age: 30)\n1 fait(s)\n",
+		"adults  active\n1 règle(s)\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("sortie sans %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRun_UndoAndSave(t *testing.T) {
+	saved := filepath.Join(t.TempDir(), "session.tsd")
+	stdout, _ := runSession(t, sessionTypes+
+		`Person(id: "alice", age: 30)
+Person(id: "bob", age: 40)
+Person(id: "carol", age: 50)
+:undo
+:undo
+:explain adults
+:facts
+:save `+saved+"\n")
+
+	if !strings.Contains(stdout, "✓ annulé: Person(id: \"carol\", age: 50)\n✓ annulé: Person(id: \"bob\", age: 40)\n") {
+		t.Errorf("annulations absentes:\n%s", stdout)
+	}
+	// Les activations annulées ne sont plus expliquées
+	explained := stdout[strings.Index(stdout, "✓ annulé: Person(id: \"bob\""):]
+	if !strings.Contains(explained, "Person~alice") || strings.Contains(explained, "Person~bob") || strings.Contains(explained, "Person~carol") {
+		t.Errorf(":explain après :undo:\n%s", explained)
+	}
+	if !strings.HasSuffix(stdout, "Person(id: \"alice\", age: 30)\n1 fait(s)\n✓ 4 instruction(s) enregistrée(s) dans "+saved+"\n") {
+		t.Errorf("sortie:\n%s", stdout)
+	}
+
+	content, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatalf("fichier non enregistré: %v", err)
+	}
+	if strings.Contains(string(content), "bob") || !strings.Contains(string(content), "rule adults") {
+		t.Errorf("contenu enregistré:\n%s", content)
+	}
+
+	// Le fichier enregistré recharge la session
+	stdout, _ = runSession(t, ":facts\n", saved)
+	if !strings.Contains(stdout, "alice\n→ adults: notify [p=Person~alice]\n") || !strings.HasSuffix(stdout, "1 fait(s)\n") {
+		t.Errorf("rechargement:\n%s", stdout)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	stdout, stderr := runSession(t, ":undo\n"+sessionTypes+
+		`rule broken : {p: Nope} / p.x == 1 ==> notify(p.id)
+:undo
+:facts Nope
+:nope
+Person(id: "alice",
+    age: 30)
+`)
+	for _, want := range []string{"undefined type: Nope", "Erreur: rien à annuler", "Erreur: type inconnu: Nope", "Erreur: commande inconnue: :nope"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr sans %q:\n%s", want, stderr)
+		}
+	}
+	// L'instruction en échec n'est pas annulable : :undo annule la règle valide qui la précède
+	if strings.Count(stdout, "✓ annulé") != 1 || !strings.Contains(stdout, "✓ annulé: rule adults") || !strings.HasSuffix(stdout, "✓ 1 fait(s)\n") {
+		t.Errorf("sortie:\n%s", stdout)
+	}
+}
+
+func TestRun_XuplesAndReset(t *testing.T) {
+	stdout, stderr := runSession(t, `xuple-space alerts {
+  selection: fifo
+}
+type Alert(#id: string)
+type Person(#id: string, age: number)
+rule old : {p: Person} / p.age > 60 ==> Xuple("alerts", Alert(id: p.id))
+Person(id: "carl", age: 70)
+:xuples
+:xuples alerts
+:retrieve alerts agent1
+:retrieve alerts agent1
+reset
+:facts
+`)
+	if !strings.Contains(stdout, "alerts  1 xuple(s)\n") || strings.Count(stdout, `Alert(id: "carl")`) != 2 || !strings.Contains(stdout, "[consumed]") {
+		t.Errorf("sortie:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Erreur: ") {
+		t.Errorf("un xuple consommé ne doit pas être récupéré deux fois:\n%s", stderr)
+	}
+	if !strings.HasSuffix(stdout, "✓ session réinitialisée\n") || !strings.Contains(stderr, ErrNoProgram.Error()) {
+		t.Errorf("reset:\n%s\n%s", stdout, stderr)
+	}
+}
+
+func TestRun_ExplainAndNetwork(t *testing.T) {
+	stdout, _ := runSession(t, sessionTypes+`Person(id: "alice", age: 30)
+:explain adults
+:network
+`)
+	if !strings.Contains(stdout, "Résultat : règle déclenchée (1 activation(s))") {
+		t.Errorf("explication absente:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "adults_terminal") || strings.Contains(stdout, "DIAGRAMME DE FLUX") {
+		t.Errorf("diagramme:\n%s", stdout)
+	}
+}
+
+func TestRun_HelpAndFlags(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-h"}, nil, &stdout, &stderr); code != ExitSuccess || !strings.Contains(stdout.String(), ":retrieve space agent") {
+		t.Errorf("-h: code %d, sortie %q", code, stdout.String())
+	}
+	if code := Run([]string{"-nope"}, nil, &stdout, &stderr); code != ExitError {
+		t.Errorf("option inconnue: code %d", code)
+	}
+	if code := Run([]string{"-history", "", "/nonexistent/rules.tsd"}, strings.NewReader(""), &stdout, &stderr); code != ExitError {
+		t.Errorf("fichier absent: code %d", code)
+	}
+}
+
+func TestRun_History(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	var stdout bytes.Buffer
+	Run([]string{"-history", path}, strings.NewReader("type T(#id: string)\n\n:history\n"), &stdout, io.Discard)
+	if !strings.Contains(stdout.String(), "    1  type T(#id: string)\n    2  :history\n") {
+		t.Errorf(":history:\n%s", stdout.String())
+	}
+
+	// L'historique est conservé d'une session à l'autre
+	stdout.Reset()
+	Run([]string{"-history", path}, strings.NewReader(":rules\n:history\n"), &stdout, io.Discard)
+	if !strings.Contains(stdout.String(), "    3  :rules\n    4  :history\n") {
+		t.Errorf("historique rechargé:\n%s", stdout.String())
+	}
+}
+
+func TestIncomplete(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{`type Person(#id: string, age: number)`, false},
+		{`type Person(#id: string,`, true},
+		{`rule r : {p: Person} /`, true},
+		{"rule r : {p: Person} / p.age > 1\n  AND", true},
+		{"rule r : {p: Person} / p.age > 1", true},
+		{`rule r : {p: Person} / p.age > 1 ==> notify(p.id) // fin`, false},
+		{`Person(id: "a)", age: 1)`, false},
+		{`Person(id: "a\"`, true},
+		{"/* commentaire", true},
+		{`remove fact Person alice`, false},
+		{`reset`, false},
+	}
+	for _, tt := range tests {
+		if got := incomplete(tt.statement); got != tt.want {
+			t.Errorf("incomplete(%q) = %v, want %v", tt.statement, got, tt.want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	s, err := newSession(&history{}, io.Discard, io.Discard)
+	if err != nil {
+		t.Fatalf("newSession() error = %v", err)
+	}
+	defer s.close()
+	s.execute(sessionTypes + "type Order(#ref: string, amount: number)\n")
+
+	tests := []struct {
+		line  string
+		start int
+		want  []string
+	}{
+		{":f", 0, []string{":facts"}},
+		{":facts P", 7, []string{"Person"}},
+		{":explain ad", 9, []string{"adults"}},
+		{"Per", 0, []string{"Person"}},
+		{"ty", 0, []string{"type"}},
+		{"rule r : {o: Order} / o.am", 22, []string{"o.amount"}},
+		{"rule r : {p: Person} / p.", 23, []string{"p.age", "p.id"}},
+		{"rule r : {p: O", 13, []string{"Order"}},
+		{"remove fact O", 12, []string{"Order"}},
+		{"Person(id: \"x\", a", 16, []string{"age", "amount"}},
+		{"Person.a", 0, []string{"Person.age"}},
+	}
+	for _, tt := range tests {
+		start, got := s.complete(tt.line, len(tt.line))
+		if start != tt.start || strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("complete(%q) = %d %v, want %d %v", tt.line, start, got, tt.start, tt.want)
+		}
+	}
+}
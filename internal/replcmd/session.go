@@ -0,0 +1,567 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package replcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/internal/explaincmd"
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdio"
+	"github.com/treivax/tsd/xuples"
+)
+
+// StatementReset réinitialise la session (types, règles, faits, xuple-spaces)
+const StatementReset = "reset"
+
+// UndoDepth est le nombre d'instructions réussies que :undo peut annuler
+const UndoDepth = 100
+
+// Méta-commandes de la session
+const (
+	CommandFacts    = ":facts"
+	CommandRules    = ":rules"
+	CommandExplain  = ":explain"
+	CommandNetwork  = ":network"
+	CommandXuples   = ":xuples"
+	CommandRetrieve = ":retrieve"
+	CommandUndo     = ":undo"
+	CommandSave     = ":save"
+	CommandHistory  = ":history"
+	CommandHelp     = ":help"
+	CommandQuit     = ":quit"
+	CommandExit     = ":exit"
+)
+
+// Commands liste les méta-commandes et leur description
+var Commands = []struct{ Name, Usage, Description string }{
+	{CommandFacts, ":facts [Type]", "Faits en mémoire, d'un type ou de tous"},
+	{CommandRules, ":rules", "Règles avec leur état"},
+	{CommandExplain, ":explain règle [faits...]", "Pourquoi une règle s'est déclenchée ou non"},
+	{CommandNetwork, ":network", "Diagramme du réseau RETE"},
+	{CommandXuples, ":xuples [space]", "Xuples d'un xuple-space, ou la liste des xuple-spaces"},
+	{CommandRetrieve, ":retrieve space agent", "Récupère (consomme) un xuple pour un agent"},
+	{CommandUndo, ":undo", "Annule la dernière instruction (répétable)"},
+	{CommandSave, ":save fichier", "Enregistre les instructions de la session dans un fichier .tsd"},
+	{CommandHistory, ":history", "Lignes saisies"},
+	{CommandHelp, ":help", "Cette aide"},
+	{CommandQuit, ":quit", "Quitte la session (aussi :exit, Ctrl-D)"},
+}
+
+// ErrNoProgram est retourné par les méta-commandes qui demandent un réseau
+// avant la première instruction
+var ErrNoProgram = errors.New("aucune instruction chargée")
+
+// errQuit signale la fin de la session
+var errQuit = errors.New("fin de session")
+
+// keywords sont les mots-clés proposés par la complétion en début d'instruction
+var keywords = []string{"action", "import", "query", "remove", StatementReset, "rule", "type", "xuple-space"}
+
+// session est une session interactive : un pipeline gardé en vie et les
+// instructions qu'il a acceptées
+type session struct {
+	pipeline   *api.Pipeline
+	result     *api.Result // Résultat de la dernière ingestion (nil : aucune)
+	statements []string    // Instructions acceptées, pour :save
+	undoable   int         // Instructions, les plus récentes, pouvant être annulées
+	history    *history
+	stdout     io.Writer
+	stderr     io.Writer
+	devNull    *os.File // Destination des traces du moteur pendant l'ingestion
+}
+
+// newSession crée une session avec un pipeline vierge
+func newSession(history *history, stdout, stderr io.Writer) (*session, error) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	s := &session{history: history, stdout: stdout, stderr: stderr, devNull: devNull}
+	s.reset()
+	return s, nil
+}
+
+// close libère les ressources de la session
+func (s *session) close() {
+	s.devNull.Close()
+}
+
+// reset remplace le pipeline par un pipeline vierge
+func (s *session) reset() {
+	config := api.DefaultConfig()
+	config.LogLevel = api.LogLevelSilent
+	config.Output = s.stdout
+	_ = s.quiet(func() error {
+		s.pipeline = api.NewPipelineWithConfig(config)
+		return nil
+	})
+	s.pipeline.SetUndoDepth(UndoDepth)
+	s.pipeline.SetActionObserver(&activationPrinter{w: s.stdout})
+	s.result = nil
+	s.statements = nil
+	s.undoable = 0
+}
+
+// quiet exécute fn sans les traces du moteur (validation, construction du
+// réseau, journal des actions) ; la sortie des actions Print est conservée
+func (s *session) quiet(fn func() error) error {
+	tsdio.Mute()
+	defer tsdio.Unmute()
+	stdout := os.Stdout
+	os.Stdout = s.devNull
+	defer func() { os.Stdout = stdout }()
+	logOutput := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(logOutput)
+	return fn()
+}
+
+// execute traite une instruction TSD complète
+func (s *session) execute(statement string) {
+	if strings.TrimSpace(statement) == StatementReset {
+		s.reset()
+		fmt.Fprintln(s.stdout, "✓ session réinitialisée")
+		return
+	}
+
+	var result *api.Result
+	err := s.quiet(func() error {
+		var err error
+		result, err = s.pipeline.IngestString(statement)
+		return err
+	})
+	if err != nil {
+		s.reportError(err)
+		return
+	}
+	s.result = result
+	s.statements = append(s.statements, statement)
+	if s.undoable < UndoDepth {
+		s.undoable++
+	}
+	fmt.Fprintln(s.stdout, summary(result.Metrics()))
+}
+
+// summary résume ce qu'une instruction a ajouté au réseau
+func summary(metrics *api.Metrics) string {
+	var parts []string
+	for _, count := range []struct {
+		n    int
+		name string
+	}{{metrics.TypeCount, "type(s)"}, {metrics.RuleCount, "règle(s)"}, {metrics.FactCount, "fait(s)"}} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.name))
+		}
+	}
+	if len(parts) == 0 {
+		return "✓"
+	}
+	return "✓ " + strings.Join(parts, ", ")
+}
+
+// reportError affiche les diagnostics d'une instruction refusée ; leur
+// position est relative à l'instruction
+func (s *session) reportError(err error) {
+	diagnostics := constraint.Diagnostics(err)
+	if len(diagnostics) == 0 || diagnostics[0].Span == nil {
+		fmt.Fprintf(s.stderr, "Erreur: %v\n", err)
+		return
+	}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Span != nil {
+			fmt.Fprintf(s.stderr, "%d:%d: %s: %s\n", diagnostic.Span.Line, diagnostic.Span.Column, diagnostic.Severity, diagnostic.Message)
+			continue
+		}
+		fmt.Fprintf(s.stderr, "%s: %s\n", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+// command exécute une méta-commande ; retourne errQuit pour terminer la session
+func (s *session) command(line string) error {
+	fields := strings.Fields(line)
+	name, args := fields[0], fields[1:]
+	switch name {
+	case CommandFacts:
+		return s.facts(args)
+	case CommandRules:
+		return s.rules()
+	case CommandExplain:
+		if len(args) == 0 {
+			return errors.New("usage: :explain règle [faits...]")
+		}
+		explanation, err := s.pipeline.Explain(args[0], args[1:]...)
+		if err != nil {
+			return err
+		}
+		explaincmd.PrintExplanation(s.stdout, explanation)
+	case CommandNetwork:
+		if s.result == nil {
+			return ErrNoProgram
+		}
+		rete.NewNetworkDiagram(s.result.Network()).WriteDetailedDiagram(s.stdout)
+	case CommandXuples:
+		return s.xuples(args)
+	case CommandRetrieve:
+		if len(args) != 2 {
+			return errors.New("usage: :retrieve space agent")
+		}
+		if s.result == nil {
+			return ErrNoProgram
+		}
+		xuple, err := s.result.Retrieve(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		printXuple(s.stdout, xuple)
+	case CommandUndo:
+		return s.undo()
+	case CommandSave:
+		if len(args) != 1 {
+			return errors.New("usage: :save fichier")
+		}
+		return s.save(args[0])
+	case CommandHistory:
+		for i, entry := range s.history.entries {
+			fmt.Fprintf(s.stdout, "%5d  %s\n", i+1, entry)
+		}
+	case CommandHelp:
+		printCommands(s.stdout)
+	case CommandQuit, CommandExit:
+		return errQuit
+	default:
+		return fmt.Errorf("commande inconnue: %s (:help pour la liste)", name)
+	}
+	return nil
+}
+
+// facts affiche les faits en mémoire, d'un type ou de tous, triés par identifiant
+func (s *session) facts(args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: :facts [Type]")
+	}
+	if s.result == nil {
+		return ErrNoProgram
+	}
+	network := s.result.Network()
+	var facts []*rete.Fact
+	for _, fact := range network.Storage.GetAllFacts() {
+		if len(args) == 0 || fact.Type == args[0] {
+			facts = append(facts, fact)
+		}
+	}
+	if len(args) == 1 && findType(network, args[0]) == nil {
+		return fmt.Errorf("type inconnu: %s", args[0])
+	}
+	sort.Slice(facts, func(i, j int) bool { return facts[i].ID < facts[j].ID })
+	for _, fact := range facts {
+		fmt.Fprintln(s.stdout, formatFact(fact, findType(network, fact.Type)))
+	}
+	fmt.Fprintf(s.stdout, "%d fait(s)\n", len(facts))
+	return nil
+}
+
+// rules affiche les règles avec leur état
+func (s *session) rules() error {
+	statuses := s.pipeline.RuleStatuses()
+	for _, status := range statuses {
+		line := fmt.Sprintf("%s  %s", status.Rule, status.State)
+		if status.Pending > 0 {
+			line += fmt.Sprintf("  (%d en attente)", status.Pending)
+		}
+		fmt.Fprintln(s.stdout, line)
+	}
+	fmt.Fprintf(s.stdout, "%d règle(s)\n", len(statuses))
+	return nil
+}
+
+// xuples liste les xuple-spaces, ou les xuples d'un xuple-space
+func (s *session) xuples(args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: :xuples [space]")
+	}
+	if s.result == nil {
+		return ErrNoProgram
+	}
+	if len(args) == 0 {
+		names := s.result.XupleSpaceNames()
+		sort.Strings(names)
+		for _, name := range names {
+			count, _ := s.result.XupleCount(name)
+			fmt.Fprintf(s.stdout, "%s  %d xuple(s)\n", name, count)
+		}
+		return nil
+	}
+	list, err := s.result.GetXuples(args[0])
+	if err != nil {
+		return err
+	}
+	for _, xuple := range list {
+		printXuple(s.stdout, xuple)
+	}
+	fmt.Fprintf(s.stdout, "%d xuple(s)\n", len(list))
+	return nil
+}
+
+// undo annule la dernière instruction acceptée ; des appels successifs
+// remontent jusqu'à UndoDepth instructions
+func (s *session) undo() error {
+	if s.undoable == 0 {
+		return errors.New("rien à annuler")
+	}
+	if err := s.quiet(s.pipeline.Undo); err != nil {
+		return err
+	}
+	s.undoable--
+	undone := s.statements[len(s.statements)-1]
+	s.statements = s.statements[:len(s.statements)-1]
+	fmt.Fprintf(s.stdout, "✓ annulé: %s\n", firstLine(undone))
+	return nil
+}
+
+// save enregistre les instructions acceptées, au format canonique si possible
+func (s *session) save(path string) error {
+	source := []byte(strings.Join(s.statements, "\n") + "\n")
+	if formatted, err := constraint.Format(path, source); err == nil {
+		source = formatted
+	}
+	if err := os.WriteFile(path, source, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.stdout, "✓ %d instruction(s) enregistrée(s) dans %s\n", len(s.statements), path)
+	return nil
+}
+
+// activationPrinter affiche chaque action exécutée par une règle
+type activationPrinter struct {
+	w io.Writer
+}
+
+func (p *activationPrinter) OnActionExecuted(result rete.ExecutionResult) {
+	var bindings []string
+	if token := result.Context.Token; token != nil {
+		for _, variable := range token.GetVariables() {
+			if fact := token.GetBinding(variable); fact != nil {
+				bindings = append(bindings, variable+"="+fact.ID)
+			}
+		}
+	}
+	line := fmt.Sprintf("→ %s: %s [%s]", result.Context.RuleName, result.Context.ActionName, strings.Join(bindings, ", "))
+	if result.Error != nil {
+		line += fmt.Sprintf(" ✗ %v", result.Error)
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// findType retourne la définition d'un type du réseau (nil si inconnu)
+func findType(network *rete.ReteNetwork, name string) *rete.TypeDefinition {
+	for i := range network.Types {
+		if network.Types[i].Name == name {
+			return &network.Types[i]
+		}
+	}
+	return nil
+}
+
+// formatFact écrit un fait dans la syntaxe TSD, champs dans l'ordre du type
+func formatFact(fact *rete.Fact, typeDef *rete.TypeDefinition) string {
+	var names []string
+	seen := make(map[string]bool)
+	if typeDef != nil {
+		for _, field := range typeDef.Fields {
+			if _, ok := fact.Fields[field.Name]; ok {
+				names = append(names, field.Name)
+				seen[field.Name] = true
+			}
+		}
+	}
+	var others []string
+	for name := range fact.Fields {
+		if !seen[name] && name != constraint.FieldNameInternalID {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	names = append(names, others...)
+
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = name + ": " + formatValue(fact.Fields[name])
+	}
+	return fmt.Sprintf("%s(%s)", fact.Type, strings.Join(fields, ", "))
+}
+
+// formatValue écrit une valeur comme un littéral TSD
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case *rete.Fact:
+		return v.ID
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// printXuple affiche un xuple et son fait
+func printXuple(w io.Writer, xuple *xuples.Xuple) {
+	if xuple.Fact == nil {
+		fmt.Fprintf(w, "%s  [%s]\n", xuple.ID, xuple.Metadata.State)
+		return
+	}
+	fmt.Fprintf(w, "%s  %s  [%s]\n", xuple.ID, formatFact(xuple.Fact, nil), xuple.Metadata.State)
+}
+
+// printCommands affiche les méta-commandes
+func printCommands(w io.Writer) {
+	for _, command := range Commands {
+		fmt.Fprintf(w, "  %-26s %s\n", command.Usage, command.Description)
+	}
+}
+
+// firstLine retourne la première ligne d'une instruction
+func firstLine(statement string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(statement), "\n")
+	return line
+}
+
+// variablePattern reconnaît les déclarations de variables d'une règle : {p: Person, ...}
+var variablePattern = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*:\s*([A-Z][A-Za-z0-9_.]*)`)
+
+// complete propose les complétions du mot se terminant à pos : méta-commandes,
+// puis selon le contexte noms de règles ou de xuple-spaces, champs d'une
+// variable (p.ag → p.age) ou d'un type, noms de types et mots-clés
+func (s *session) complete(line string, pos int) (int, []string) {
+	before := line[:pos]
+	start := strings.LastIndexFunc(before, func(r rune) bool {
+		return !(r == '_' || r == '.' || r == ':' || r == '-' || r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z')
+	}) + 1
+	word := before[start:]
+	fields := strings.Fields(before)
+	if start == 0 && strings.HasPrefix(word, ":") {
+		var names []string
+		for _, command := range Commands {
+			names = append(names, command.Name)
+		}
+		return start, matching(names, word, "")
+	}
+
+	// Arguments des méta-commandes
+	if len(fields) > 0 && strings.HasPrefix(fields[0], ":") {
+		argIndex := len(fields) - 1
+		if word == "" {
+			argIndex++
+		}
+		switch {
+		case fields[0] == CommandFacts && argIndex == 1:
+			return start, matching(s.typeNames(), word, "")
+		case fields[0] == CommandExplain && argIndex == 1:
+			return start, matching(s.ruleNames(), word, "")
+		case (fields[0] == CommandXuples || fields[0] == CommandRetrieve) && argIndex == 1:
+			return start, matching(s.spaceNames(), word, "")
+		}
+		return start, nil
+	}
+
+	// Champ d'une variable ou d'un type : p.ag → p.age
+	if dot := strings.LastIndex(word, "."); dot >= 0 {
+		owner, prefix := word[:dot], word[:dot+1]
+		typeName := owner
+		for _, match := range variablePattern.FindAllStringSubmatch(line, -1) {
+			if match[1] == owner {
+				typeName = match[2]
+			}
+		}
+		return start, matching(s.fieldNames(typeName), word[dot+1:], prefix)
+	}
+
+	candidates := s.typeNames()
+	switch {
+	case start == 0:
+		candidates = append(candidates, keywords...)
+	case !typePosition(before[:start]):
+		candidates = append(candidates, s.fieldNames("")...)
+	}
+	return start, matching(candidates, word, "")
+}
+
+// typePosition indique si le mot qui suit text est forcément un nom de type :
+// après « remove fact » ou dans une déclaration de variables « {p: »
+func typePosition(text string) bool {
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, " fact") {
+		return true
+	}
+	return strings.HasSuffix(text, ":") && strings.LastIndex(text, "{") > strings.LastIndex(text, "}")
+}
+
+// matching retourne les candidats commençant par word, précédés de prefix
+func matching(candidates []string, word, prefix string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) && !seen[candidate] {
+			seen[candidate] = true
+			result = append(result, prefix+candidate)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// typeNames retourne les noms des types déclarés
+func (s *session) typeNames() []string {
+	if s.result == nil {
+		return nil
+	}
+	var names []string
+	for _, typeDef := range s.result.Network().Types {
+		names = append(names, typeDef.Name)
+	}
+	return names
+}
+
+// fieldNames retourne les champs d'un type, ou de tous les types si typeName est vide
+func (s *session) fieldNames(typeName string) []string {
+	if s.result == nil {
+		return nil
+	}
+	var names []string
+	for _, typeDef := range s.result.Network().Types {
+		if typeName == "" || typeDef.Name == typeName {
+			for _, field := range typeDef.Fields {
+				names = append(names, field.Name)
+			}
+		}
+	}
+	return names
+}
+
+// ruleNames retourne les identifiants des règles
+func (s *session) ruleNames() []string {
+	var names []string
+	for _, status := range s.pipeline.RuleStatuses() {
+		names = append(names, status.Rule)
+	}
+	return names
+}
+
+// spaceNames retourne les noms des xuple-spaces
+func (s *session) spaceNames() []string {
+	if s.result == nil {
+		return nil
+	}
+	return s.result.XupleSpaceNames()
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+//go:build linux
+
+package replcmd
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw passe le terminal fd en mode brut (sans écho ni tampon de ligne) et
+// retourne la fonction qui restaure son état. Échoue si fd n'est pas un terminal.
+func makeRaw(fd uintptr) (func(), error) {
+	var original syscall.Termios
+	if err := termios(fd, syscall.TCGETS, &original); err != nil {
+		return nil, err
+	}
+	raw := original
+	raw.Iflag &^= syscall.BRKINT | syscall.ICRNL | syscall.INPCK | syscall.ISTRIP | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.IEXTEN | syscall.ISIG
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(fd, syscall.TCSETS, &raw); err != nil {
+		return nil, err
+	}
+	return func() { _ = termios(fd, syscall.TCSETS, &original) }, nil
+}
+
+// termios lit ou modifie les attributs du terminal fd
+func termios(fd uintptr, request uintptr, attributes *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(unsafe.Pointer(attributes)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+//go:build !linux
+
+package replcmd
+
+import "errors"
+
+// makeRaw n'est disponible que sous Linux : ailleurs, les lignes sont lues
+// sans édition, historique ni complétion
+func makeRaw(fd uintptr) (func(), error) {
+	return nil, errors.New("mode brut du terminal non supporté")
+}
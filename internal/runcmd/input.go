@@ -0,0 +1,176 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package runcmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+// socketPrefix désigne une socket Unix dans -input
+const socketPrefix = "unix:"
+
+// inputLine est une ligne non vide lue sur une entrée, ou une erreur de lecture
+type inputLine struct {
+	source string // "-", chemin du fichier ou unix:chemin#connexion
+	line   int
+	data   []byte
+	err    error
+}
+
+// input lit une ou plusieurs entrées dans une file bornée : lorsque la file
+// est pleine, les lecteurs se bloquent et le producteur avec eux (pipe,
+// socket), ce qui limite les événements lus en avance.
+type input struct {
+	queue chan inputLine // Fermée quand toutes les entrées sont épuisées
+
+	stop    chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	closers []io.Closer // Fichiers, listener et connexions fermés par close
+	wg      sync.WaitGroup
+}
+
+// openInput ouvre l'entrée des événements : "-" pour stdin, unix:chemin pour
+// une socket Unix acceptant plusieurs clients, sinon un fichier ou un tube
+// nommé. Un tube nommé est ouvert en lecture-écriture : il ne voit jamais la
+// fin de fichier et reste ouvert entre deux écrivains.
+func openInput(name string, stdin io.Reader, size int) (*input, error) {
+	in := &input{queue: make(chan inputLine, size), stop: make(chan struct{})}
+
+	switch {
+	case name == "-":
+		in.start(name, stdin)
+
+	case strings.HasPrefix(name, socketPrefix):
+		listener, err := net.Listen("unix", strings.TrimPrefix(name, socketPrefix))
+		if err != nil {
+			return nil, err
+		}
+		in.track(listener)
+		in.wg.Add(1)
+		go in.accept(name, listener)
+
+	default:
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		flag := os.O_RDONLY
+		if info.Mode()&os.ModeNamedPipe != 0 {
+			flag = os.O_RDWR
+		}
+		file, err := os.OpenFile(name, flag, 0)
+		if err != nil {
+			return nil, err
+		}
+		in.track(file)
+		in.start(name, file)
+	}
+
+	go func() {
+		in.wg.Wait()
+		close(in.queue)
+	}()
+	return in, nil
+}
+
+// start lit une entrée dans une goroutine
+func (in *input) start(source string, r io.Reader) {
+	in.wg.Add(1)
+	go func() {
+		defer in.wg.Done()
+		in.read(source, r)
+	}()
+}
+
+// accept lit chaque connexion à la socket jusqu'à la fermeture du listener
+func (in *input) accept(name string, listener net.Listener) {
+	defer in.wg.Done()
+	for n := 1; ; n++ {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !in.stopped() {
+				in.send(inputLine{source: name, err: err})
+			}
+			return
+		}
+		if !in.track(conn) {
+			conn.Close()
+			return
+		}
+		in.start(fmt.Sprintf("%s#%d", name, n), conn)
+	}
+}
+
+// read envoie les lignes non vides de r dans la file
+func (in *input) read(source string, r io.Reader) {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 {
+			if !in.send(inputLine{source: source, line: line, data: trimmed}) {
+				return
+			}
+		}
+		if err != nil {
+			if err != io.EOF && !in.stopped() {
+				in.send(inputLine{source: source, line: line, err: err})
+			}
+			return
+		}
+	}
+}
+
+// send attend une place dans la file ; retourne false si la lecture est arrêtée
+func (in *input) send(line inputLine) bool {
+	select {
+	case in.queue <- line:
+		return true
+	case <-in.stop:
+		return false
+	}
+}
+
+// track enregistre une ressource fermée par close ; retourne false (sans
+// l'enregistrer) si la lecture est déjà arrêtée
+func (in *input) track(closer io.Closer) bool {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.stopped() {
+		return false
+	}
+	in.closers = append(in.closers, closer)
+	return true
+}
+
+func (in *input) stopped() bool {
+	select {
+	case <-in.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// close arrête la lecture : les lecteurs bloqués sur la file abandonnent et
+// les fichiers, le listener et les connexions sont fermés. La lecture de
+// stdin, qui ne peut pas être interrompue, est abandonnée.
+func (in *input) close() {
+	in.once.Do(func() {
+		in.mu.Lock()
+		defer in.mu.Unlock()
+		close(in.stop)
+		for _, closer := range in.closers {
+			closer.Close()
+		}
+	})
+}
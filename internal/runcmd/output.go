@@ -0,0 +1,121 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package runcmd
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/treivax/tsd/rete"
+)
+
+// Genres d'enregistrements écrits sur la sortie
+const (
+	RecordActivation = "activation" // Action exécutée par une règle
+	RecordXuple      = "xuple"      // Xuple créé par l'action Xuple
+	RecordAck        = "ack"        // Événement appliqué (-ack)
+	RecordError      = "error"      // Événement refusé
+)
+
+// Record est un enregistrement NDJSON de la sortie. Seq est le numéro de
+// l'événement d'entrée qui l'a produit, dans l'ordre de traitement.
+type Record struct {
+	Event    string                 `json:"event"`
+	Seq      int                    `json:"seq"`
+	Source   string                 `json:"source,omitempty"`
+	Line     int                    `json:"line,omitempty"`
+	Op       rete.FactOperation     `json:"op,omitempty"`
+	ID       string                 `json:"id,omitempty"`      // Fait inséré, mis à jour ou retiré (ack)
+	Rule     string                 `json:"rule,omitempty"`    // Règle déclenchée
+	Actions  []Call                 `json:"actions,omitempty"` // Actions exécutées par l'activation
+	Bindings map[string]string      `json:"bindings,omitempty"`
+	Space    string                 `json:"space,omitempty"` // Xuple-space
+	Type     string                 `json:"type,omitempty"`  // Type du fait du xuple
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// Call est un appel d'action d'une activation ; un fait passé en argument
+// est donné par son identifiant
+type Call struct {
+	Name string        `json:"name"`
+	Args []interface{} `json:"args"`
+}
+
+// recorder écrit les enregistrements ; observateur des actions du pipeline,
+// il est appelé pendant la propagation de l'événement seq
+type recorder struct {
+	encoder     *json.Encoder
+	seq         int
+	activations int
+	xuples      int
+	err         error // Première erreur d'écriture : le traitement s'arrête
+}
+
+func newRecorder(w io.Writer) *recorder {
+	return &recorder{encoder: json.NewEncoder(w)}
+}
+
+// write écrit un enregistrement, sauf après une erreur d'écriture
+func (r *recorder) write(record Record) {
+	if r.err == nil {
+		r.err = r.encoder.Encode(record)
+	}
+}
+
+// OnActionExecuted écrit l'activation d'une règle, puis un enregistrement
+// par xuple créé par ses appels à Xuple
+func (r *recorder) OnActionExecuted(result rete.ExecutionResult) {
+	bindings := make(map[string]string)
+	if token := result.Context.Token; token != nil {
+		for _, variable := range token.GetVariables() {
+			if fact := token.GetBinding(variable); fact != nil {
+				bindings[variable] = fact.ID
+			}
+		}
+	}
+
+	activation := Record{Event: RecordActivation, Seq: r.seq, Rule: result.Context.RuleName, Actions: []Call{}, Bindings: bindings}
+	var xuples []Record
+	for _, call := range result.Calls {
+		args := make([]interface{}, len(call.Args))
+		for i, arg := range call.Args {
+			if fact, ok := arg.(*rete.Fact); ok && fact != nil {
+				args[i] = fact.ID
+			} else {
+				args[i] = arg
+			}
+		}
+		activation.Actions = append(activation.Actions, Call{Name: call.Name, Args: args})
+
+		if call.Name == "Xuple" && len(call.Args) == 2 {
+			space, _ := call.Args[0].(string)
+			if fact, ok := call.Args[1].(*rete.Fact); ok && fact != nil {
+				xuples = append(xuples, Record{Event: RecordXuple, Seq: r.seq, Rule: result.Context.RuleName, Space: space, Type: fact.Type, Fields: factFields(fact), Bindings: bindings})
+			}
+		}
+	}
+	if result.Error != nil {
+		activation.Error = result.Error.Error()
+	}
+
+	r.activations++
+	r.write(activation)
+	for _, xuple := range xuples {
+		r.xuples++
+		r.write(xuple)
+	}
+}
+
+// factFields retourne les champs d'un fait, sans l'identifiant interne
+func factFields(fact *rete.Fact) map[string]interface{} {
+	fields := make(map[string]interface{}, len(fact.Fields))
+	for name, value := range fact.Fields {
+		if name != rete.FieldNameID {
+			fields[name] = value
+		}
+	}
+	return fields
+}
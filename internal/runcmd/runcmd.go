@@ -0,0 +1,317 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package runcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+	// ExitRejected est retourné avec -strict lorsqu'au moins un événement a
+	// été refusé
+	ExitRejected = 2
+)
+
+// DefaultBuffer est le nombre d'événements lus en avance par défaut
+const DefaultBuffer = 64
+
+// ErrNoRules est retourné lorsqu'aucun fichier de règles n'est donné
+var ErrNoRules = errors.New("aucun fichier de règles spécifié (-rules)")
+
+// Config holds the run command configuration
+type Config struct {
+	Rules    []string // Programmes TSD chargés au démarrage (types, règles, faits initiaux)
+	Input    string   // "-" (entrée standard), fichier, tube nommé ou unix:chemin
+	Buffer   int      // Événements lus en avance avant de bloquer la lecture
+	Ack      bool     // Écrire un accusé de traitement par événement
+	Strict   bool     // Code de sortie ExitRejected si un événement a été refusé
+	Verbose  bool     // Traces du moteur sur la sortie d'erreur
+	ShowHelp bool
+}
+
+// fileList est un flag répétable
+type fileList []string
+
+func (l *fileList) String() string { return strings.Join(*l, ",") }
+
+func (l *fileList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Run executes the run command and returns an exit code.
+// SIGTERM et SIGINT arrêtent la lecture ; les événements déjà reçus sont
+// traités avant la sortie.
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	if len(config.Rules) == 0 {
+		fmt.Fprintf(stderr, "Erreur: %v\n\n", ErrNoRules)
+		printHelp(stderr)
+		return ExitError
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	return run(ctx, config, stdin, stdout, stderr)
+}
+
+// run charge les règles puis traite les événements jusqu'à la fin de
+// l'entrée ou l'annulation de ctx
+func run(ctx context.Context, config *Config, stdin io.Reader, stdout, stderr io.Writer) int {
+	restore, err := redirectTraces(config.Verbose, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	defer restore()
+
+	pipelineConfig := api.DefaultConfig()
+	pipelineConfig.LogLevel = api.LogLevelSilent
+	pipelineConfig.Output = stderr
+	pipeline := api.NewPipelineWithConfig(pipelineConfig)
+	for _, file := range config.Rules {
+		if _, err := pipeline.IngestFile(file); err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitError
+		}
+	}
+
+	out := newRecorder(stdout)
+	pipeline.SetActionObserver(out)
+
+	in, err := openInput(config.Input, stdin, config.Buffer)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	defer in.close()
+	fmt.Fprintf(stderr, "🚀 %d règle(s) chargée(s), lecture des événements sur %s\n", len(pipeline.RuleStatuses()), config.Input)
+
+	d := &daemon{pipeline: pipeline, out: out, ack: config.Ack, stderr: stderr}
+	d.loop(ctx, in)
+
+	fmt.Fprintf(stderr, "✅ %d événement(s) traité(s), %d refusé(s), %d activation(s), %d xuple(s)\n",
+		out.seq, d.rejected, out.activations, out.xuples)
+	if out.err != nil {
+		fmt.Fprintf(stderr, "Erreur: écriture des résultats: %v\n", out.err)
+		return ExitError
+	}
+	if config.Strict && d.rejected > 0 {
+		return ExitRejected
+	}
+	return ExitSuccess
+}
+
+// daemon applique les événements reçus au pipeline, un à la fois
+type daemon struct {
+	pipeline *api.Pipeline
+	out      *recorder
+	ack      bool
+	rejected int
+	stderr   io.Writer
+}
+
+// loop traite les lignes jusqu'à la fin de l'entrée ; une fois ctx annulé,
+// la lecture est arrêtée et les lignes déjà en file sont traitées
+func (d *daemon) loop(ctx context.Context, in *input) {
+	for d.out.err == nil {
+		select {
+		case line, ok := <-in.queue:
+			if !ok {
+				return
+			}
+			d.process(line)
+		case <-ctx.Done():
+			in.close()
+			for d.out.err == nil {
+				select {
+				case line, ok := <-in.queue:
+					if !ok {
+						return
+					}
+					d.process(line)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// process décode une enveloppe et l'applique ; les activations produites
+// sont écrites par le recorder pendant la propagation
+func (d *daemon) process(line inputLine) {
+	if line.err != nil {
+		fmt.Fprintf(d.stderr, "Erreur: %s: %v\n", line.source, line.err)
+		return
+	}
+	d.out.seq++
+
+	event, err := decodeEvent(line.data)
+	if err == nil {
+		var fact *rete.Fact
+		if fact, err = d.pipeline.ApplyFactEvent(event); err == nil {
+			if d.ack {
+				d.out.write(Record{Event: RecordAck, Seq: d.out.seq, Source: line.source, Line: line.line, Op: event.Op, ID: fact.ID})
+			}
+			return
+		}
+	}
+	d.rejected++
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) && apiErr.Cause != nil {
+		err = apiErr.Cause
+	}
+	d.out.write(Record{Event: RecordError, Seq: d.out.seq, Source: line.source, Line: line.line, Op: event.Op, Error: err.Error()})
+}
+
+// decodeEvent décode une enveloppe NDJSON ; les nombres restent exacts
+// (json.Number) jusqu'à leur conversion selon le type du champ
+func decodeEvent(data []byte) (rete.FactEvent, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	var event rete.FactEvent
+	if err := decoder.Decode(&event); err != nil {
+		return event, fmt.Errorf("enveloppe JSON invalide: %w", err)
+	}
+	if decoder.More() {
+		return event, errors.New("enveloppe JSON invalide: un seul objet par ligne")
+	}
+	return event, nil
+}
+
+// redirectTraces envoie les traces du moteur (sortie standard, journal) sur
+// la sortie d'erreur en mode verbeux, et les supprime sinon : la sortie
+// standard ne reçoit que les enregistrements NDJSON
+func redirectTraces(verbose bool, stderr io.Writer) (func(), error) {
+	stdout, logOutput := os.Stdout, log.Writer()
+	if verbose {
+		os.Stdout = os.Stderr
+		log.SetOutput(stderr)
+		return func() {
+			os.Stdout = stdout
+			log.SetOutput(logOutput)
+		}, nil
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	tsdio.Mute()
+	os.Stdout = devNull
+	log.SetOutput(io.Discard)
+	return func() {
+		os.Stdout = stdout
+		log.SetOutput(logOutput)
+		tsdio.Unmute()
+		devNull.Close()
+	}, nil
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont des fichiers de règles supplémentaires.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	var rules fileList
+	flagSet := flag.NewFlagSet("run", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.Var(&rules, "rules", "Fichier TSD chargé au démarrage (répétable)")
+	flagSet.StringVar(&config.Input, "input", "-", "Entrée des événements: -, fichier, tube nommé ou unix:chemin")
+	flagSet.IntVar(&config.Buffer, "buffer", DefaultBuffer, "Événements lus en avance avant de bloquer la lecture")
+	flagSet.BoolVar(&config.Ack, "ack", false, "Écrire un accusé de traitement par événement")
+	flagSet.BoolVar(&config.Strict, "strict", false, "Code de sortie 2 si au moins un événement a été refusé")
+	flagSet.BoolVar(&config.Verbose, "v", false, "Traces du moteur sur la sortie d'erreur")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	if config.Buffer < 1 {
+		return nil, fmt.Errorf("taille de file invalide: %d (au moins 1)", config.Buffer)
+	}
+	if config.Input == "" || config.Input == socketPrefix {
+		return nil, errors.New("entrée des événements vide (-input)")
+	}
+	config.Rules = append(rules, flagSet.Args()...)
+	return config, nil
+}
+
+// printHelp displays the run command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Run - Traitement continu d'un flux d'événements")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd run -rules fichier.tsd [-rules fichier.tsd...] [options]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Les règles sont chargées puis le réseau reste en vie : chaque ligne NDJSON")
+	fmt.Fprintln(w, "reçue insère, met à jour ou retire un fait, propagé aussitôt. Les activations")
+	fmt.Fprintln(w, "et les xuples produits sont écrits sur la sortie standard, un objet JSON par")
+	fmt.Fprintln(w, "ligne ; les traces vont sur la sortie d'erreur.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "ÉVÉNEMENTS:")
+	fmt.Fprintln(w, `  {"op": "insert", "type": "Order", "fields": {"id": "o1", "total": 250}}`)
+	fmt.Fprintln(w, `  {"op": "update", "type": "Order", "id": "o1", "fields": {"total": 90}}`)
+	fmt.Fprintln(w, `  {"op": "retract", "type": "Order", "id": "o1"}`)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "SORTIE:")
+	fmt.Fprintln(w, `  {"event": "activation", "seq": 1, "rule": "big", "actions": [{"name": "notify", "args": ["o1"]}], ...}`)
+	fmt.Fprintln(w, `  {"event": "xuple", "seq": 1, "space": "alerts", "type": "Alert", ...}`)
+	fmt.Fprintln(w, `  {"event": "error", "seq": 2, "source": "-", "line": 2, "error": "..."}`)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -rules <fichier>   Fichier TSD chargé au démarrage (répétable)")
+	fmt.Fprintln(w, "  -input <entrée>    - (entrée standard, défaut), fichier, tube nommé")
+	fmt.Fprintln(w, "                     (relu sans fin) ou unix:chemin (socket, plusieurs clients)")
+	fmt.Fprintf(w, "  -buffer <n>        Événements lus en avance avant de bloquer la lecture (défaut: %d)\n", DefaultBuffer)
+	fmt.Fprintln(w, "  -ack               Écrire un accusé de traitement par événement")
+	fmt.Fprintln(w, "  -strict            Code de sortie 2 si au moins un événement a été refusé")
+	fmt.Fprintln(w, "  -v                 Traces du moteur sur la sortie d'erreur")
+	fmt.Fprintln(w, "  -h, --help         Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "SIGTERM ou SIGINT arrête la lecture ; les événements déjà reçus sont traités")
+	fmt.Fprintln(w, "avant la sortie.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "CODES DE SORTIE:")
+	fmt.Fprintln(w, "  0  Entrée traitée (les événements refusés sont signalés sur la sortie)")
+	fmt.Fprintln(w, "  1  Erreur (règles invalides, entrée inaccessible, écriture impossible)")
+	fmt.Fprintln(w, "  2  Au moins un événement refusé (-strict)")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  producer | tsd run -rules rules.tsd | consumer")
+	fmt.Fprintln(w, "  tsd run -rules types.tsd -rules rules.tsd -input /run/tsd/events.fifo")
+	fmt.Fprintln(w, "  tsd run -rules rules.tsd -input unix:/run/tsd/events.sock -ack")
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package runcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const runProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: Customer, total: number)
+type Alert(#order: string, level: string)
+xuple-space alerts {
+  selection: fifo
+}
+action notify(id: string)
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+rule alert : {o: Order} / o.total > 1000 ==> Xuple("alerts", Alert(order: o.id, level: "high"))
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+// syncBuffer est une sortie lue pendant que run écrit
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func decodeRecords(t *testing.T, output string) []Record {
+	t.Helper()
+	var records []Record
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("ligne NDJSON invalide %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := ParseFlags([]string{"-rules", "a.tsd", "-rules", "b.tsd", "-input", "unix:/tmp/s", "-buffer", "8", "-ack", "-strict", "c.tsd"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if strings.Join(config.Rules, ",") != "a.tsd,b.tsd,c.tsd" || config.Input != "unix:/tmp/s" || config.Buffer != 8 || !config.Ack || !config.Strict {
+		t.Errorf("config = %+v", config)
+	}
+
+	if config, _ := ParseFlags([]string{"rules.tsd"}); config.Input != "-" || config.Buffer != DefaultBuffer || config.Ack || config.Strict {
+		t.Errorf("défauts = %+v", config)
+	}
+	for _, args := range [][]string{{"-buffer", "0"}, {"-input", ""}, {"-input", "unix:"}} {
+		if _, err := ParseFlags(args); err == nil {
+			t.Errorf("ParseFlags(%v) accepté", args)
+		}
+	}
+}
+
+func TestRun_NoRules(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run(nil, strings.NewReader(""), &stdout, &stderr); code != ExitError {
+		t.Errorf("code = %d, attendu %d", code, ExitError)
+	}
+	if !strings.Contains(stderr.String(), ErrNoRules.Error()) {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestRun_Stdin(t *testing.T) {
+	rules := writeFile(t, "rules.tsd", runProgram)
+	events := strings.Join([]string{
+		`{"op":"insert","type":"Customer","fields":{"id":"c1","tier":"gold"}}`,
+		`{"op":"insert","type":"Order","fields":{"id":"o1","customer":"c1","total":50}}`,
+		``,
+		`{"op":"update","type":"Order","id":"o1","fields":{"total":5000}}`,
+		`not json`,
+		`{"op":"retract","id":"Order~o9"}`,
+		`{"op":"retract","id":"Order~o1"}`,
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-rules", rules, "-ack"}, strings.NewReader(events), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("code = %d, stderr = %s", code, stderr.String())
+	}
+
+	var kinds []string
+	for _, record := range decodeRecords(t, stdout.String()) {
+		kinds = append(kinds, record.Event)
+		switch record.Event {
+		case RecordActivation:
+			if record.Seq != 3 || len(record.Actions) != 1 || record.Bindings["o"] != "Order~o1" {
+				t.Errorf("activation = %+v", record)
+			}
+			if record.Rule == "big" && (record.Actions[0].Name != "notify" || record.Actions[0].Args[0] != "o1") {
+				t.Errorf("activation big = %+v", record)
+			}
+		case RecordXuple:
+			if record.Space != "alerts" || record.Type != "Alert" || record.Fields["order"] != "o1" || record.Fields["level"] != "high" {
+				t.Errorf("xuple = %+v", record)
+			}
+		case RecordError:
+			if record.Line != 5 && record.Line != 6 {
+				t.Errorf("erreur à la ligne %d: %+v", record.Line, record)
+			}
+		}
+	}
+	want := "ack,ack,activation,activation,xuple,ack,error,error,ack"
+	if got := strings.Join(kinds, ","); got != want {
+		t.Errorf("enregistrements = %s, attendu %s\n%s", got, want, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "6 événement(s) traité(s), 2 refusé(s), 2 activation(s), 1 xuple(s)") {
+		t.Errorf("résumé absent: %s", stderr.String())
+	}
+}
+
+func TestRun_Strict(t *testing.T) {
+	rules := writeFile(t, "rules.tsd", runProgram)
+	events := strings.Join([]string{
+		`{"op":"insert","type":"Customer","fields":{"id":"c1","tier":"gold"}}`,
+		`{"op":"bogus","type":"Customer","fields":{"id":"c2","tier":"gold"}}`,
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-rules", rules}, strings.NewReader(events), &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("sans -strict: code = %d, stderr = %s", code, stderr.String())
+	}
+	records := decodeRecords(t, stdout.String())
+	if len(records) != 1 || records[0].Event != RecordError || records[0].Error != "opération 'bogus' inconnue (insert, update, retract)" {
+		t.Errorf("enregistrements = %+v", records)
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"-rules", rules, "-strict"}, strings.NewReader(events), &stdout, &stderr); code != ExitRejected {
+		t.Errorf("-strict: code = %d, attendu %d", code, ExitRejected)
+	}
+	if code := Run([]string{"-rules", rules, "-strict"}, strings.NewReader(events[:strings.Index(events, "\n")]), &stdout, &stderr); code != ExitSuccess {
+		t.Errorf("-strict sans refus: code = %d, attendu %d", code, ExitSuccess)
+	}
+}
+
+func TestRun_SocketDrain(t *testing.T) {
+	rules := writeFile(t, "rules.tsd", runProgram)
+	socket := filepath.Join(t.TempDir(), "events.sock")
+	config, err := ParseFlags([]string{"-rules", rules, "-input", socketPrefix + socket, "-ack"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stdout, stderr := &syncBuffer{}, &syncBuffer{}
+	done := make(chan int)
+	go func() { done <- run(ctx, config, nil, stdout, stderr) }()
+
+	var conn net.Conn
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if conn, err = net.Dial("unix", socket); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("socket non disponible: %v\n%s", err, stderr.String())
+		}
+	}
+	defer conn.Close()
+	conn.Write([]byte(`{"op":"insert","type":"Customer","fields":{"id":"c1","tier":"gold"}}` + "\n" +
+		`{"op":"insert","type":"Order","fields":{"id":"o1","customer":"c1","total":250}}` + "\n"))
+
+	for deadline := time.Now().Add(5 * time.Second); strings.Count(stdout.String(), "\n") < 3; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("événements non traités: %s", stdout.String())
+		}
+	}
+
+	// Arrêt (SIGTERM) : la connexion encore ouverte est fermée et run se termine
+	cancel()
+	select {
+	case code := <-done:
+		if code != ExitSuccess {
+			t.Fatalf("code = %d, stderr = %s", code, stderr.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run ne s'est pas arrêté après l'annulation")
+	}
+
+	records := decodeRecords(t, stdout.String())
+	if len(records) != 3 || records[2].Event != RecordAck || records[2].ID != "Order~o1" || records[2].Source != socketPrefix+socket+"#1" {
+		t.Errorf("enregistrements = %+v", records)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// parseTypeQuotas lit des quotas par type au format "Type=N,Type=N"
+func parseTypeQuotas(value string) (map[string]int, error) {
+	quotas := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		factType, count, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(factType) == "" {
+			return nil, fmt.Errorf("quota invalide '%s' (attendu: Type=N)", entry)
+		}
+		quota, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || quota < 0 {
+			return nil, fmt.Errorf("quota invalide pour %s: '%s'", factType, count)
+		}
+		quotas[strings.TrimSpace(factType)] = quota
+	}
+	return quotas, nil
+}
+
+// factLimits retourne les limites de faits appliquées à chaque réseau du serveur
+func (c *Config) factLimits() rete.FactLimits {
+	return rete.FactLimits{
+		MaxFacts:        c.MaxFacts,
+		MaxFactsPerType: c.MaxFactsPerType,
+		Policy:          rete.FactLimitPolicy(c.FactLimitPolicy),
+		BlockTimeout:    c.FactLimitTimeout,
+	}
+}
+
+// cascadeLimits retourne les garde-fous de cascade appliqués à chaque réseau du serveur
+func (c *Config) cascadeLimits() rete.CascadeLimits {
+	return rete.CascadeLimits{
+		MaxDepth:           c.MaxCascadeDepth,
+		MaxActivations:     c.MaxActivations,
+		MaxRuleActivations: c.MaxRuleActivations,
+	}
+}
+
+// pipelineConfig retourne la configuration des pipelines de session
+func (c *Config) pipelineConfig() *api.Config {
+	config := api.DefaultConfig()
+	config.MaxFactsInMemory = c.MaxFacts
+	config.MaxFactsPerType = c.MaxFactsPerType
+	config.FactLimitPolicy = api.FactLimitPolicy(c.FactLimitPolicy)
+	config.FactLimitTimeout = c.FactLimitTimeout
+	config.MaxCascadeDepth = c.MaxCascadeDepth
+	config.MaxActivations = c.MaxActivations
+	config.MaxRuleActivations = c.MaxRuleActivations
+	return config
+}
+
+// recordFactLimits cumule les évictions et refus d'une exécution /api/v1/execute
+func (m *serverMetrics) recordFactLimits(stats rete.FactLimitStats) {
+	for factType, count := range stats.EvictionsByType {
+		m.factEvictions.add(float64(count), factType)
+	}
+	for factType, count := range stats.RejectionsByType {
+		m.factRejections.add(float64(count), factType)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdio"
+)
+
+const factLimitsTestProgram = `type Reading(#id: string, value: number)
+
+Reading(id: "r1", value: 1)
+Reading(id: "r2", value: 2)
+Reading(id: "r3", value: 3)
+`
+
+func TestParseFlags_FactLimits(t *testing.T) {
+	config := parseFlags([]string{"-insecure", "-max-facts", "100", "-max-facts-per-type", "Reading=10, Event=5",
+		"-fact-limit-policy", "block", "-fact-limit-timeout", "2s"})
+
+	if config.MaxFacts != 100 || config.FactLimitPolicy != "block" || config.FactLimitTimeout != 2*time.Second {
+		t.Errorf("config = %+v", config)
+	}
+	if config.MaxFactsPerType["Reading"] != 10 || config.MaxFactsPerType["Event"] != 5 {
+		t.Errorf("MaxFactsPerType = %v", config.MaxFactsPerType)
+	}
+
+	defaults := parseFlags([]string{"-insecure"})
+	if defaults.MaxFacts != 0 || defaults.FactLimitPolicy != "reject" || defaults.MaxFactsPerType != nil {
+		t.Errorf("defaults = %+v", defaults)
+	}
+}
+
+func TestParseTypeQuotas_Invalid(t *testing.T) {
+	for _, value := range []string{"Reading", "=3", "Reading=x", "Reading=-1"} {
+		if _, err := parseTypeQuotas(value); err == nil {
+			t.Errorf("parseTypeQuotas(%q) should fail", value)
+		}
+	}
+}
+
+func TestNewServer_InvalidFactLimitPolicy(t *testing.T) {
+	_, err := NewServer(&Config{AuthType: "none", MaxFacts: 10, FactLimitPolicy: "drop"}, log.New(io.Discard, "", 0))
+	if err == nil || !strings.Contains(err.Error(), "drop") {
+		t.Errorf("NewServer() error = %v, want invalid policy", err)
+	}
+}
+
+func TestExecute_FactLimitRejects(t *testing.T) {
+	server, err := NewServer(&Config{AuthType: "none", MaxFacts: 2}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	body, _ := json.Marshal(tsdio.ExecuteRequest{Source: factLimitsTestProgram})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/execute", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.mux.ServeHTTP(w, req)
+
+	var response tsdio.ExecuteResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if response.Success || !strings.Contains(response.Error, "limite globale de 2 faits") {
+		t.Errorf("response = %+v, want fact limit error", response)
+	}
+
+	metrics := scrapeMetrics(t, server.mux, "").Body.String()
+	if !strings.Contains(metrics, `tsd_facts_rejected_total{session="",type="Reading"} 1`) {
+		t.Errorf("metrics missing /execute rejection\n%s", metrics)
+	}
+}
+
+func TestSession_FactLimitEvictOldest(t *testing.T) {
+	config := &Config{AuthType: "none", MaxFactsPerType: map[string]int{"Reading": 2}, FactLimitPolicy: "evict-oldest"}
+	server, err := NewServer(config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: factLimitsTestProgram})
+	if w.Code != StatusCreated {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, StatusCreated, w.Body.String())
+	}
+
+	metrics := scrapeMetrics(t, server.mux, "").Body.String()
+	for _, line := range []string{
+		`# TYPE tsd_facts_evicted_total counter`,
+		`type="Reading"} 2`,
+	} {
+		if !strings.Contains(metrics, line) {
+			t.Errorf("metrics missing %q\n%s", line, metrics)
+		}
+	}
+	if !strings.Contains(metrics, "tsd_facts_evicted_total{session=") {
+		t.Errorf("metrics missing session evictions\n%s", metrics)
+	}
+}
+
+func TestParseFlags_CascadeLimits(t *testing.T) {
+	config := parseFlags([]string{"-insecure", "-max-cascade-depth", "20", "-max-activations", "1000", "-max-rule-activations", "50"})
+	if config.MaxCascadeDepth != 20 || config.MaxActivations != 1000 || config.MaxRuleActivations != 50 {
+		t.Errorf("config = %+v", config)
+	}
+
+	defaults := parseFlags([]string{"-insecure"})
+	if defaults.MaxCascadeDepth != rete.DefaultMaxCascadeDepth || defaults.MaxActivations != 0 || defaults.MaxRuleActivations != 0 {
+		t.Errorf("defaults = %+v", defaults)
+	}
+
+	if _, err := NewServer(&Config{AuthType: "none", MaxActivations: -1}, log.New(io.Discard, "", 0)); err == nil {
+		t.Error("NewServer() should reject a negative activation limit")
+	}
+}
+
+func TestSession_CascadeLimit(t *testing.T) {
+	server, err := NewServer(&Config{AuthType: "none", MaxCascadeDepth: 10}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: runawayTestProgram})
+	var response tsdio.SessionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if response.Success || !strings.Contains(response.Error, "profondeur de cascade maximale (10)") ||
+		!strings.Contains(response.Error, "boucle: loop[") {
+		t.Errorf("response = %+v, want cascade limit error", response)
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/xuples"
+)
+
+const (
+	// MetricsPath est la route d'exposition des métriques Prometheus
+	MetricsPath = "/metrics"
+
+	// ContentTypePrometheus est le format texte d'exposition Prometheus
+	ContentTypePrometheus = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// defaultDurationBuckets sont les bornes (en secondes) des histogrammes de durée
+var defaultDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// counterVec est un compteur Prometheus indexé par valeurs de labels
+type counterVec struct {
+	name   string
+	help   string
+	labels []string
+	mu     sync.Mutex
+	values map[string]float64 // clé : valeurs de labels jointes par \xff
+}
+
+func newCounterVec(name, help string, labels ...string) *counterVec {
+	return &counterVec{name: name, help: help, labels: labels, values: make(map[string]float64)}
+}
+
+// inc incrémente la série identifiée par les valeurs de labels
+func (c *counterVec) inc(labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[strings.Join(labelValues, "\xff")]++
+}
+
+// add ajoute une valeur à la série identifiée par les valeurs de labels
+func (c *counterVec) add(value float64, labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[strings.Join(labelValues, "\xff")] += value
+}
+
+// value retourne la valeur courante d'une série
+func (c *counterVec) value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[strings.Join(labelValues, "\xff")]
+}
+
+// each parcourt les séries du compteur
+func (c *counterVec) each(fn func(labelValues []string, value float64)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, value := range c.values {
+		fn(splitKey(key), value)
+	}
+}
+
+func (c *counterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labels, splitKey(key)), formatFloat(c.values[key]))
+	}
+}
+
+// histogramSeries accumule les observations d'une série d'histogramme
+type histogramSeries struct {
+	counts []uint64 // une entrée par borne, non cumulée
+	sum    float64
+	count  uint64
+}
+
+// histogramVec est un histogramme Prometheus indexé par valeurs de labels
+type histogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+func newHistogramVec(name, help string, labels ...string) *histogramVec {
+	return &histogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: defaultDurationBuckets,
+		series:  make(map[string]*histogramSeries),
+	}
+}
+
+// observe enregistre une durée dans la série identifiée par les valeurs de labels
+func (h *histogramVec) observe(d time.Duration, labelValues ...string) {
+	seconds := d.Seconds()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	series, exists := h.series[key]
+	if !exists {
+		series = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = series
+	}
+	for i, bound := range h.buckets {
+		if seconds <= bound {
+			series.counts[i]++
+			break
+		}
+	}
+	series.sum += seconds
+	series.count++
+}
+
+func (h *histogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	bucketLabels := append(append([]string(nil), h.labels...), "le")
+	for _, key := range keys {
+		series := h.series[key]
+		values := splitKey(key)
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += series.counts[i]
+			labels := formatLabels(bucketLabels, append(append([]string(nil), values...), formatFloat(bound)))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels, cumulative)
+		}
+		labels := formatLabels(bucketLabels, append(append([]string(nil), values...), "+Inf"))
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels, series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, values), formatFloat(series.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, values), series.count)
+	}
+}
+
+// gaugeFamily regroupe les échantillons d'une gauge calculée au moment du scrape
+type gaugeFamily struct {
+	name    string
+	help    string
+	kind    string // "gauge" ou "counter" (compteurs lus depuis une autre source)
+	labels  []string
+	samples map[string]float64
+}
+
+func newGaugeFamily(name, help, kind string, labels ...string) *gaugeFamily {
+	return &gaugeFamily{name: name, help: help, kind: kind, labels: labels, samples: make(map[string]float64)}
+}
+
+func (g *gaugeFamily) add(value float64, labelValues ...string) {
+	g.samples[strings.Join(labelValues, "\xff")] += value
+}
+
+func (g *gaugeFamily) write(w io.Writer) {
+	writeHeader(w, g.name, g.help, g.kind)
+	for _, key := range sortedKeys(g.samples) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, formatLabels(g.labels, splitKey(key)), formatFloat(g.samples[key]))
+	}
+}
+
+// serverMetrics regroupe les métriques du serveur, du moteur et des xuple-spaces.
+//
+// Les compteurs HTTP, d'activations et d'authentification sont mis à jour au fil
+// de l'eau ; l'occupation mémoire des sessions est lue au moment du scrape.
+// Implémente rete.ActionObserver.
+type serverMetrics struct {
+	httpRequests    *counterVec
+	httpDuration    *histogramVec
+	authFailures    *counterVec
+	ruleActivations *counterVec
+	actionDuration  *histogramVec
+	actionErrors    *counterVec
+
+	// Évictions et refus des exécutions éphémères (/api/v1/execute), par type ;
+	// exposés avec ceux des sessions sous le label session=""
+	factEvictions  *counterVec
+	factRejections *counterVec
+
+	// Rechargements de la base de règles (--rules), par origine
+	ruleReloads        *counterVec
+	ruleReloadDuration *histogramVec
+}
+
+// newServerMetrics crée le registre des métriques du serveur
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		httpRequests: newCounterVec("tsd_http_requests_total",
+			"Requêtes HTTP traitées par endpoint, méthode et code de statut", "endpoint", "method", "status"),
+		httpDuration: newHistogramVec("tsd_http_request_duration_seconds",
+			"Latence des requêtes HTTP par endpoint", "endpoint"),
+		authFailures: newCounterVec("tsd_auth_failures_total",
+			"Échecs d'authentification par endpoint", "endpoint"),
+		ruleActivations: newCounterVec("tsd_rule_activations_total",
+			"Activations (actions exécutées) par règle", "rule"),
+		actionDuration: newHistogramVec("tsd_action_duration_seconds",
+			"Durée d'exécution des actions", "action"),
+		actionErrors: newCounterVec("tsd_action_errors_total",
+			"Actions en échec", "action"),
+		factEvictions:  newCounterVec("tsd_facts_evicted_total", "", "type"),
+		factRejections: newCounterVec("tsd_facts_rejected_total", "", "type"),
+		ruleReloads: newCounterVec("tsd_rules_reloads_total",
+			"Rechargements de la base de règles par origine et issue", "trigger", "outcome"),
+		ruleReloadDuration: newHistogramVec("tsd_rules_reload_duration_seconds",
+			"Durée des rechargements de la base de règles", "trigger"),
+	}
+}
+
+// OnActionExecuted comptabilise une exécution d'action.
+// Implémente rete.ActionObserver.
+func (m *serverMetrics) OnActionExecuted(result rete.ExecutionResult) {
+	m.ruleActivations.inc(result.Context.RuleName)
+	m.actionDuration.observe(result.Duration, result.Context.ActionName)
+	if !result.Success {
+		m.actionErrors.inc(result.Context.ActionName)
+	}
+}
+
+// statusRecorder capture le code de statut écrit par un handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// instrument mesure la latence et le code de statut des requêtes d'un endpoint
+func (s *Server) instrument(endpoint string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.metrics == nil {
+			handler(w, r)
+			return
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handler(recorder, r)
+
+		s.metrics.httpRequests.inc(endpoint, r.Method, strconv.Itoa(recorder.status))
+		s.metrics.httpDuration.observe(time.Since(start), endpoint)
+	}
+}
+
+// handleMetrics expose les métriques au format texte Prometheus
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", time.Now())
+		return
+	}
+
+	if s.config.MetricsAuth {
+		if err := s.authenticate(r); err != nil {
+			s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), time.Now())
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", ContentTypePrometheus)
+	w.WriteHeader(StatusOK)
+	s.writeMetrics(w)
+}
+
+// writeMetrics écrit toutes les familles de métriques, dans un ordre stable
+func (s *Server) writeMetrics(w io.Writer) {
+	m := s.metrics
+	m.httpRequests.write(w)
+	m.httpDuration.write(w)
+	m.authFailures.write(w)
+	m.ruleActivations.write(w)
+	m.actionDuration.write(w)
+	m.actionErrors.write(w)
+
+	commits, rollbacks := rete.TransactionCounts()
+	transactions := newGaugeFamily("tsd_transactions_total", "Transactions terminées par issue", "counter", "outcome")
+	transactions.add(float64(commits), "commit")
+	transactions.add(float64(rollbacks), "rollback")
+	transactions.write(w)
+
+	for _, family := range s.collectSessionMetrics() {
+		family.write(w)
+	}
+
+	m.ruleReloads.write(w)
+	m.ruleReloadDuration.write(w)
+	for _, family := range s.collectRuleMetrics() {
+		family.write(w)
+	}
+}
+
+// collectSessionMetrics lit l'occupation mémoire et l'état des xuple-spaces de chaque session
+func (s *Server) collectSessionMetrics() []*gaugeFamily {
+	sessionsActive := newGaugeFamily("tsd_sessions_active", "Sessions ouvertes", "gauge")
+	facts := newGaugeFamily("tsd_facts", "Faits en mémoire de travail par type", "gauge", "session", "type")
+	joinMemory := newGaugeFamily("tsd_join_memory_tokens", "Tokens mémorisés par les nœuds de jointure", "gauge", "session", "node", "side")
+	depth := newGaugeFamily("tsd_xuplespace_depth", "Xuples disponibles par xuple-space", "gauge", "session", "xuplespace")
+	inserted := newGaugeFamily("tsd_xuplespace_inserted_total", "Xuples insérés par xuple-space", "counter", "session", "xuplespace")
+	consumed := newGaugeFamily("tsd_xuplespace_consumed_total", "Consommations de xuples par xuple-space", "counter", "session", "xuplespace")
+	evicted := newGaugeFamily("tsd_facts_evicted_total", "Faits rétractés pour respecter les limites de faits en mémoire", "counter", "session", "type")
+	rejected := newGaugeFamily("tsd_facts_rejected_total", "Faits refusés par les limites de faits en mémoire", "counter", "session", "type")
+
+	sessions := s.sessions.list()
+	sessionsActive.add(float64(len(sessions)))
+
+	s.metrics.factEvictions.each(func(labelValues []string, value float64) {
+		evicted.add(value, "", labelValues[0])
+	})
+	s.metrics.factRejections.each(func(labelValues []string, value float64) {
+		rejected.add(value, "", labelValues[0])
+	})
+
+	for _, sess := range sessions {
+		limits := sess.pipeline.FactLimitStats()
+		for factType, count := range limits.EvictionsByType {
+			evicted.add(float64(count), sess.id, factType)
+		}
+		for factType, count := range limits.RejectionsByType {
+			rejected.add(float64(count), sess.id, factType)
+		}
+
+		snapshot := sess.pipeline.MemorySnapshot()
+		for factType, count := range snapshot.FactsByType {
+			facts.add(float64(count), sess.id, factType)
+		}
+		for node, size := range snapshot.JoinMemory {
+			joinMemory.add(float64(size.Left), sess.id, node, "left")
+			joinMemory.add(float64(size.Right), sess.id, node, "right")
+		}
+
+		manager := sess.pipeline.XupleManager()
+		for _, name := range manager.ListXupleSpaces() {
+			space, err := manager.GetXupleSpace(name)
+			if err != nil {
+				continue
+			}
+			observable, ok := space.(interface{ Stats() xuples.XupleSpaceStats })
+			if !ok {
+				depth.add(float64(space.Count()), sess.id, name)
+				continue
+			}
+			stats := observable.Stats()
+			depth.add(float64(stats.Available), sess.id, name)
+			inserted.add(float64(stats.Inserted), sess.id, name)
+			consumed.add(float64(stats.Consumed), sess.id, name)
+		}
+	}
+
+	return []*gaugeFamily{sessionsActive, facts, joinMemory, depth, inserted, consumed, evicted, rejected}
+}
+
+// writeHeader écrit les lignes HELP et TYPE d'une famille
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+// formatLabels formate un ensemble de labels ({a="x",b="y"}), vide si aucun label
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		parts[i] = name + `="` + labelEscaper.Replace(value) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// labelEscaper échappe une valeur de label selon le format d'exposition
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func splitKey(key string) []string {
+	return strings.Split(key, "\xff")
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
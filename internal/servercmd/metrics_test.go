@@ -0,0 +1,163 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdio"
+)
+
+const metricsTestProgram = `xuple-space alerts {
+    selection: fifo
+    consumption: once
+}
+
+type Sensor(#id: string, room: string)
+type Temperature(#id: string, sensor: string, value: number)
+type Alert(room: string, temp: number)
+
+rule hot_room : {s: Sensor, t: Temperature} / t.sensor == s.id AND t.value > 30 ==> Xuple("alerts", Alert(room: s.room, temp: t.value))
+
+Sensor(id: "s1", room: "lab")
+Temperature(id: "t1", sensor: "s1", value: 35)
+Temperature(id: "t2", sensor: "s1", value: 20)
+`
+
+// scrapeMetrics retourne le corps de GET /metrics sur le mux donné
+func scrapeMetrics(t *testing.T, mux *http.ServeMux, header string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, MetricsPath, nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestMetrics_SessionEngineAndXuples(t *testing.T) {
+	server := newSessionTestServer(t)
+
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: metricsTestProgram})
+	if w.Code != StatusCreated {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, StatusCreated, w.Body.String())
+	}
+	doSessionRequest(server, http.MethodGet, "/api/v1/sessions/unknown/query", nil)
+
+	w = scrapeMetrics(t, server.mux, "")
+	if w.Code != StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, StatusOK)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentTypePrometheus {
+		t.Errorf("Content-Type = %q, want %q", got, ContentTypePrometheus)
+	}
+
+	body := w.Body.String()
+	expected := []string{
+		`tsd_http_requests_total{endpoint="/api/v1/sessions",method="POST",status="201"} 1`,
+		`tsd_http_requests_total{endpoint="/api/v1/sessions/{id}/query",method="GET",status="405"} 1`,
+		`tsd_http_request_duration_seconds_count{endpoint="/api/v1/sessions"} 1`,
+		`tsd_rule_activations_total{rule="hot_room"} 1`,
+		`tsd_action_duration_seconds_count{action="Xuple"} 1`,
+		`tsd_sessions_active 1`,
+		`type="Temperature"} 2`,
+		`type="Sensor"} 1`,
+		`side="right"}`,
+		`xuplespace="alerts"} 1`,
+		`# TYPE tsd_transactions_total counter`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("metrics missing %q\n%s", line, body)
+		}
+	}
+}
+
+func TestMetrics_AuthToggleAndFailures(t *testing.T) {
+	key := "metrics-test-key-with-at-least-32-characters"
+	config := &Config{AuthType: "key", AuthKeys: []string{key}, MetricsAuth: true}
+	server, err := NewServer(config, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	if w := scrapeMetrics(t, server.mux, ""); w.Code != StatusUnauthorized {
+		t.Fatalf("unauthenticated scrape status = %d, want %d", w.Code, StatusUnauthorized)
+	}
+	w := scrapeMetrics(t, server.mux, "Bearer "+key)
+	if w.Code != StatusOK {
+		t.Fatalf("authenticated scrape status = %d, want %d", w.Code, StatusOK)
+	}
+	if line := `tsd_auth_failures_total{endpoint="/metrics"} 1`; !strings.Contains(w.Body.String(), line) {
+		t.Errorf("metrics missing %q\n%s", line, w.Body.String())
+	}
+
+	// Sans --metrics-auth, /metrics reste accessible même si l'API est protégée
+	config.MetricsAuth = false
+	if w := scrapeMetrics(t, server.mux, ""); w.Code != StatusOK {
+		t.Errorf("scrape without metrics auth status = %d, want %d", w.Code, StatusOK)
+	}
+}
+
+func TestMetrics_SeparateListener(t *testing.T) {
+	server, err := NewServer(&Config{AuthType: "none", MetricsAddr: "127.0.0.1:0"}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	if w := scrapeMetrics(t, server.mux, ""); w.Code != http.StatusNotFound {
+		t.Errorf("main mux /metrics status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if server.metricsMux == nil {
+		t.Fatal("metricsMux not created")
+	}
+	if w := scrapeMetrics(t, server.metricsMux, ""); w.Code != StatusOK {
+		t.Errorf("metrics mux /metrics status = %d, want %d", w.Code, StatusOK)
+	}
+}
+
+func TestServerMetrics_ObserverAndHistogram(t *testing.T) {
+	metrics := newServerMetrics()
+	metrics.OnActionExecuted(rete.ExecutionResult{
+		Success:  false,
+		Duration: 3 * time.Millisecond,
+		Context:  rete.ActionContext{ActionName: "notify", RuleName: "r1"},
+	})
+
+	if got := metrics.ruleActivations.value("r1"); got != 1 {
+		t.Errorf("activations = %v, want 1", got)
+	}
+	if got := metrics.actionErrors.value("notify"); got != 1 {
+		t.Errorf("errors = %v, want 1", got)
+	}
+
+	var out strings.Builder
+	metrics.actionDuration.write(&out)
+	for _, line := range []string{
+		`tsd_action_duration_seconds_bucket{action="notify",le="0.001"} 0`,
+		`tsd_action_duration_seconds_bucket{action="notify",le="0.005"} 1`,
+		`tsd_action_duration_seconds_bucket{action="notify",le="+Inf"} 1`,
+		`tsd_action_duration_seconds_sum{action="notify"} 0.003`,
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("histogram missing %q\n%s", line, out.String())
+		}
+	}
+}
+
+func TestFormatLabels_Escaping(t *testing.T) {
+	got := formatLabels([]string{"rule"}, []string{"a\"b\\c\nd"})
+	if want := `{rule="a\"b\\c\nd"}`; got != want {
+		t.Errorf("formatLabels() = %s, want %s", got, want)
+	}
+}
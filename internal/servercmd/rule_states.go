@@ -0,0 +1,154 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// États des règles d'une session (active, disabled, dry-run)
+//
+//	GET  /api/v1/sessions/{id}/rules                  état de toutes les règles
+//	POST /api/v1/sessions/{id}/rules/{rule}/state     {"state": "disabled"}
+//	GET  /api/v1/sessions/{id}/rules/{rule}/dry-runs  activations à blanc
+//
+// La session épinglée de la base de règles (RulesSessionID) est administrée
+// de la même façon ; l'état des règles y survit aux rechargements.
+
+// handleSessionRules liste l'état des règles d'une session
+func (s *Server) handleSessionRules(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodGet {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	sess, ok := s.lookupSession(w, r, startTime)
+	if !ok {
+		return
+	}
+
+	statuses := sess.pipeline.RuleStatuses()
+	response := &tsdio.RuleStatusesResponse{Rules: make([]tsdio.RuleStatus, 0, len(statuses))}
+	for _, status := range statuses {
+		response.Rules = append(response.Rules, toRuleStatus(status))
+	}
+	s.writeJSON(w, response, StatusOK)
+}
+
+// handleSessionRuleState change l'état d'une règle d'une session
+func (s *Server) handleSessionRuleState(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodPost {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	sess, ok := s.lookupSession(w, r, startTime)
+	if !ok {
+		return
+	}
+	rule := r.PathValue("rule")
+	if _, err := sess.pipeline.RuleStatus(rule); err != nil {
+		s.sendErrorResponse(w, StatusNotFound, fmt.Sprintf("Règle '%s' introuvable", rule), startTime)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+	var req tsdio.RuleStateRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		s.sendErrorResponse(w, StatusBadRequest, fmt.Sprintf("JSON invalide: %v", err), startTime)
+		return
+	}
+	state, err := rete.ParseRuleState(req.State)
+	if err != nil {
+		s.sendErrorResponse(w, StatusBadRequest, err.Error(), startTime)
+		return
+	}
+
+	if err := sess.pipeline.SetRuleState(rule, state); err != nil {
+		s.sendErrorResponse(w, StatusBadRequest, err.Error(), startTime)
+		return
+	}
+	s.logger.Printf("🔀 Session %s: règle %s → %s", sess.id, rule, state)
+
+	status, err := sess.pipeline.RuleStatus(rule)
+	if err != nil {
+		s.sendErrorResponse(w, StatusNotFound, err.Error(), startTime)
+		return
+	}
+	s.writeJSON(w, toRuleStatus(status), StatusOK)
+}
+
+// handleSessionRuleDryRuns retourne les activations à blanc d'une règle d'une session
+func (s *Server) handleSessionRuleDryRuns(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodGet {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	sess, ok := s.lookupSession(w, r, startTime)
+	if !ok {
+		return
+	}
+	rule := r.PathValue("rule")
+	if _, err := sess.pipeline.RuleStatus(rule); err != nil {
+		s.sendErrorResponse(w, StatusNotFound, fmt.Sprintf("Règle '%s' introuvable", rule), startTime)
+		return
+	}
+
+	records := sess.pipeline.DryRunActivations(rule)
+	response := &tsdio.DryRunsResponse{Rule: rule, Activations: make([]tsdio.DryRunActivation, 0, len(records))}
+	for _, record := range records {
+		response.Activations = append(response.Activations, tsdio.DryRunActivation{
+			Sequence: record.Sequence,
+			Rule:     record.Rule,
+			Actions:  record.Actions,
+			Bindings: record.Bindings,
+			Time:     record.Time,
+		})
+	}
+	s.writeJSON(w, response, StatusOK)
+}
+
+// toRuleStatus convertit l'état d'une règle au format de l'API HTTP
+func toRuleStatus(status rete.RuleStatus) tsdio.RuleStatus {
+	return tsdio.RuleStatus{
+		Rule:    status.Rule,
+		State:   string(status.State),
+		Version: status.Metadata.Version,
+		Owner:   status.Metadata.Owner,
+		Tags:    status.Metadata.Tags,
+		Pending: status.Pending,
+		DryRuns: status.DryRuns,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+const ruleStateTestProgram = `type Order(#id: string, total: number)
+action notify(id: string)
+@version("2") @owner("pricing") @tags("promo")
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+`
+
+func TestSessions_RuleStates(t *testing.T) {
+	server := newSessionTestServer(t)
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: ruleStateTestProgram})
+	var created tsdio.SessionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || !created.Success {
+		t.Fatalf("create session: %d %s", w.Code, w.Body.String())
+	}
+	base := "/api/v1/sessions/" + created.SessionID + "/rules"
+
+	w = doSessionRequest(server, http.MethodPost, base+"/big/state", tsdio.RuleStateRequest{State: "disabled"})
+	if w.Code != StatusOK {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, StatusOK, w.Body.String())
+	}
+	doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+created.SessionID+"/ingest",
+		tsdio.ExecuteRequest{Source: `Order(id: "o1", total: 150)`})
+
+	w = doSessionRequest(server, http.MethodGet, base, nil)
+	var statuses tsdio.RuleStatusesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &statuses); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	want := tsdio.RuleStatus{Rule: "big", State: "disabled", Version: "2", Owner: "pricing", Tags: []string{"promo"}, Pending: 1}
+	if len(statuses.Rules) != 1 || !reflect.DeepEqual(statuses.Rules[0], want) {
+		t.Errorf("Rules = %+v, want [%+v]", statuses.Rules, want)
+	}
+
+	w = doSessionRequest(server, http.MethodPost, base+"/big/state", tsdio.RuleStateRequest{State: "dry-run"})
+	var status tsdio.RuleStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil || status.State != "dry-run" || status.DryRuns != 1 {
+		t.Errorf("status = %+v, %v; want the held match recorded in dry-run", status, err)
+	}
+
+	w = doSessionRequest(server, http.MethodGet, base+"/big/dry-runs", nil)
+	var dryRuns tsdio.DryRunsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &dryRuns); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if len(dryRuns.Activations) != 1 || dryRuns.Activations[0].Bindings["o"] != "Order~o1" {
+		t.Errorf("Activations = %+v", dryRuns.Activations)
+	}
+}
+
+func TestSessions_RuleStateErrors(t *testing.T) {
+	server := newSessionTestServer(t)
+	id := createTestSession(t, server)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		want   int
+	}{
+		{"unknown session", http.MethodGet, "/api/v1/sessions/missing/rules", nil, StatusNotFound},
+		{"unknown rule", http.MethodPost, "/api/v1/sessions/" + id + "/rules/missing/state", tsdio.RuleStateRequest{State: "disabled"}, StatusNotFound},
+		{"query", http.MethodPost, "/api/v1/sessions/" + id + "/rules/query:adults/state", tsdio.RuleStateRequest{State: "disabled"}, StatusNotFound},
+		{"wrong method", http.MethodGet, "/api/v1/sessions/" + id + "/rules/x/state", nil, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doSessionRequest(server, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: ruleStateTestProgram})
+	var created tsdio.SessionResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	w = doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+created.SessionID+"/rules/big/state", tsdio.RuleStateRequest{State: "paused"})
+	if w.Code != StatusBadRequest {
+		t.Errorf("Status = %d, want %d for an unknown state", w.Code, StatusBadRequest)
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Base de règles chargée depuis un répertoire (tsd server --rules dir/)
+//
+// Les fichiers *.tsd du répertoire (types, actions, règles et requêtes) sont
+// chargés dans la session épinglée RulesSessionID : les clients y ingèrent
+// leurs faits et l'interrogent avec les endpoints de session habituels. Le
+// répertoire est surveillé par scrutation ; à chaque modification, les règles
+// sont rechargées avec api.Pipeline.ReloadRules : validation contre le réseau
+// en place, puis application des seules règles ajoutées, supprimées ou
+// modifiées, les faits restant en mémoire. Un rechargement en échec conserve
+// la base de règles précédente.
+
+const (
+	// RulesSessionID est l'identifiant de la session hébergeant la base de règles
+	RulesSessionID = "rules"
+
+	// DefaultRulesPollInterval est l'intervalle de scrutation du répertoire de règles
+	DefaultRulesPollInterval = 2 * time.Second
+
+	// maxRuleReloadHistory borne l'historique des rechargements conservé
+	maxRuleReloadHistory = 50
+)
+
+// Origines d'un rechargement de règles
+const (
+	RuleReloadStartup = "startup"
+	RuleReloadWatch   = "watch"
+	RuleReloadAdmin   = "admin"
+)
+
+// ruleBase gère la base de règles chargée depuis un répertoire
+type ruleBase struct {
+	dir     string
+	session *session
+
+	mu          sync.Mutex // Sérialise les rechargements
+	seq         int
+	history     []tsdio.RuleReload // Du plus ancien au plus récent
+	state       string             // État du répertoire lors du dernier rechargement réussi
+	rules       int                // Règles chargées par le dernier rechargement réussi
+	lastSuccess time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newRuleBase crée la session épinglée de la base de règles
+func (s *Server) newRuleBase(dir string) (*ruleBase, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("répertoire de règles inaccessible: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s n'est pas un répertoire", dir)
+	}
+
+	sess := s.sessions.pin(RulesSessionID)
+	if s.metrics != nil {
+		sess.pipeline.SetActionObserver(s.metrics)
+	}
+	return &ruleBase{dir: dir, session: sess}, nil
+}
+
+// ruleFiles liste les fichiers *.tsd du répertoire, triés par nom.
+// Les sous-répertoires ne sont pas chargés directement : ils peuvent contenir
+// des fichiers importés par ceux du répertoire.
+func ruleFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".tsd" {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// dirState résume le contenu du répertoire, sous-répertoires compris
+// (chemin, taille et date de modification de chaque fichier)
+func dirState(dir string) (string, error) {
+	var state strings.Builder
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&state, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return state.String(), err
+}
+
+// reload recharge les règles du répertoire et enregistre le résultat dans l'historique
+func (rb *ruleBase) reload(ctx context.Context, trigger string, metrics *serverMetrics) tsdio.RuleReload {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	startTime := time.Now()
+	rb.seq++
+	record := tsdio.RuleReload{ID: rb.seq, Trigger: trigger, StartedAt: startTime}
+
+	state, err := dirState(rb.dir)
+	var files []string
+	if err == nil {
+		files, err = ruleFiles(rb.dir)
+	}
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("aucun fichier .tsd dans %s", rb.dir)
+	}
+
+	var reload *api.RuleReload
+	if err == nil {
+		record.Files = make([]string, len(files))
+		for i, file := range files {
+			record.Files[i] = filepath.Base(file)
+		}
+		reload, err = rb.session.pipeline.ReloadRules(ctx, files...)
+	}
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+		record.Error = err.Error()
+		record.ErrorType = reloadErrorType(err)
+		record.Diagnostics = errorDiagnostics(err)
+		record.Rules = rb.rules
+	} else {
+		record.Success = true
+		record.Added = reload.Added
+		record.Removed = reload.Removed
+		record.Changed = reload.Changed
+		record.Rules = reload.Rules
+		rb.rules = reload.Rules
+		rb.lastSuccess = time.Now()
+		// Un rechargement en échec est retenté par la scrutation suivante,
+		// même si le répertoire n'a pas changé
+		rb.state = state
+	}
+	record.DurationMs = time.Since(startTime).Milliseconds()
+
+	rb.history = append(rb.history, record)
+	if len(rb.history) > maxRuleReloadHistory {
+		rb.history = rb.history[len(rb.history)-maxRuleReloadHistory:]
+	}
+
+	if metrics != nil {
+		metrics.ruleReloads.inc(trigger, outcome)
+		metrics.ruleReloadDuration.observe(time.Since(startTime), trigger)
+	}
+	return record
+}
+
+// reloadErrorType traduit une erreur de rechargement en type d'erreur de l'API HTTP
+func reloadErrorType(err error) string {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return tsdio.ErrorTypeServerError
+	}
+	switch apiErr.Type {
+	case api.ErrorTypeParse:
+		return tsdio.ErrorTypeParsingError
+	case api.ErrorTypeValidation:
+		return tsdio.ErrorTypeValidationError
+	case api.ErrorTypeCanceled:
+		return tsdio.ErrorTypeTimeoutError
+	default:
+		return tsdio.ErrorTypeExecutionError
+	}
+}
+
+// changed indique si le répertoire a changé depuis le dernier rechargement
+func (rb *ruleBase) changed() bool {
+	state, err := dirState(rb.dir)
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return err != nil || state != rb.state
+}
+
+// reloads retourne l'historique, du rechargement le plus récent au plus ancien
+func (rb *ruleBase) reloads() []tsdio.RuleReload {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	reloads := make([]tsdio.RuleReload, len(rb.history))
+	for i, record := range rb.history {
+		reloads[len(rb.history)-1-i] = record
+	}
+	return reloads
+}
+
+// status retourne le nombre de règles chargées et l'heure du dernier rechargement réussi
+func (rb *ruleBase) status() (int, time.Time) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return rb.rules, rb.lastSuccess
+}
+
+// startRulesWatcher scrute le répertoire de règles et recharge à chaque modification
+func (s *Server) startRulesWatcher(interval time.Duration) {
+	rb := s.rules
+	if rb == nil || interval <= 0 || rb.stop != nil {
+		return
+	}
+	rb.stop = make(chan struct{})
+	rb.done = make(chan struct{})
+
+	go func() {
+		defer close(rb.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-rb.stop:
+				return
+			case <-ticker.C:
+				if !rb.changed() {
+					continue
+				}
+				ctx, cancel := s.executionContext(context.Background())
+				record := rb.reload(ctx, RuleReloadWatch, s.metrics)
+				cancel()
+				s.logRuleReload(record)
+			}
+		}
+	}()
+}
+
+// stopRulesWatcher arrête la scrutation du répertoire de règles
+func (s *Server) stopRulesWatcher() {
+	if s.rules == nil || s.rules.stop == nil {
+		return
+	}
+	close(s.rules.stop)
+	<-s.rules.done
+	s.rules.stop = nil
+}
+
+// logRuleReload journalise le résultat d'un rechargement
+func (s *Server) logRuleReload(record tsdio.RuleReload) {
+	if !record.Success {
+		s.logger.Printf("❌ Rechargement des règles (%s) en échec, règles précédentes conservées: %s", record.Trigger, record.Error)
+		return
+	}
+	s.logger.Printf("🔄 Règles rechargées (%s): %d règle(s), +%d -%d ~%d en %dms",
+		record.Trigger, record.Rules, len(record.Added), len(record.Removed), len(record.Changed), record.DurationMs)
+}
+
+// handleRulesReload recharge la base de règles à la demande
+func (s *Server) handleRulesReload(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodPost {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	if s.rules == nil {
+		s.sendErrorResponse(w, StatusNotFound, "Aucun répertoire de règles configuré (--rules)", startTime)
+		return
+	}
+
+	ctx, cancel := s.executionContext(r.Context())
+	defer cancel()
+	record := s.rules.reload(ctx, RuleReloadAdmin, s.metrics)
+	s.logRuleReload(record)
+
+	statusCode := StatusOK
+	if !record.Success {
+		statusCode = StatusBadRequest
+	}
+	s.writeJSON(w, record, statusCode)
+}
+
+// handleRulesReloads retourne l'historique des rechargements de règles
+func (s *Server) handleRulesReloads(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodGet {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	if s.rules == nil {
+		s.sendErrorResponse(w, StatusNotFound, "Aucun répertoire de règles configuré (--rules)", startTime)
+		return
+	}
+
+	s.writeJSON(w, &tsdio.RuleReloadsResponse{
+		RulesDir: s.rules.dir,
+		Reloads:  s.rules.reloads(),
+	}, StatusOK)
+}
+
+// collectRuleMetrics lit l'état de la base de règles (nil sans --rules)
+func (s *Server) collectRuleMetrics() []*gaugeFamily {
+	if s.rules == nil {
+		return nil
+	}
+	loaded := newGaugeFamily("tsd_rules_loaded", "Règles chargées depuis le répertoire de règles", "gauge")
+	lastSuccess := newGaugeFamily("tsd_rules_last_reload_success_timestamp_seconds",
+		"Heure du dernier rechargement de règles réussi (secondes Unix)", "gauge")
+
+	rules, at := s.rules.status()
+	loaded.add(float64(rules))
+	if !at.IsZero() {
+		lastSuccess.add(float64(at.UnixNano()) / float64(time.Second))
+	}
+	return []*gaugeFamily{loaded, lastSuccess}
+}
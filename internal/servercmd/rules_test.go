@@ -0,0 +1,224 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+const rulesTestTypes = `type Order(#id: string, total: number)
+action notify(id: string)
+
+query orders() : {o: Order}
+`
+
+// newRulesTestServer crée un serveur chargeant les règles d'un répertoire temporaire
+func newRulesTestServer(t *testing.T, rules string) (*Server, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeRules(t, dir, "types.tsd", rulesTestTypes)
+	writeRules(t, dir, "rules.tsd", rules)
+
+	server, err := NewServer(&Config{AuthType: "none", RulesDir: dir}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return server, dir
+}
+
+func writeRules(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+// hasRule indique si la règle est chargée dans la session de la base de règles
+func hasRule(server *Server, rule string) bool {
+	_, err := server.rules.session.pipeline.Explain(rule)
+	return err == nil
+}
+
+func TestParseFlags_Rules(t *testing.T) {
+	config := parseFlags([]string{"-insecure", "-rules", "rules/", "-rules-poll-interval", "500ms"})
+	if config.RulesDir != "rules/" || config.RulesPollInterval != 500*time.Millisecond {
+		t.Errorf("config = %+v", config)
+	}
+
+	defaults := parseFlags([]string{"-insecure"})
+	if defaults.RulesDir != "" || defaults.RulesPollInterval != DefaultRulesPollInterval {
+		t.Errorf("defaults = %+v", defaults)
+	}
+}
+
+func TestNewServer_InvalidRules(t *testing.T) {
+	dir := t.TempDir()
+	writeRules(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 100 ==> notify(o.id)`)
+
+	_, err := NewServer(&Config{AuthType: "none", RulesDir: dir}, log.New(io.Discard, "", 0))
+	if err == nil || !strings.Contains(err.Error(), "chargement des règles") {
+		t.Errorf("NewServer() error = %v, want rules loading error", err)
+	}
+	if _, err := NewServer(&Config{AuthType: "none", RulesDir: filepath.Join(dir, "missing")}, log.New(io.Discard, "", 0)); err == nil {
+		t.Errorf("NewServer() with a missing directory should fail")
+	}
+}
+
+func TestRules_AdminReload(t *testing.T) {
+	server, dir := newRulesTestServer(t, `rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+rule small : {o: Order} / o.total < 10 ==> notify(o.id)
+`)
+
+	// Les faits sont ingérés dans la session de la base de règles
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+RulesSessionID+"/ingest",
+		tsdio.ExecuteRequest{Source: `Order(id: "o1", total: 50)`})
+	if w.Code != StatusOK {
+		t.Fatalf("ingest status = %d: %s", w.Code, w.Body.String())
+	}
+
+	writeRules(t, dir, "rules.tsd", `rule big : {o: Order} / o.total > 20 ==> notify(o.id)
+rule medium : {o: Order} / o.total > 40 ==> notify(o.id)
+`)
+	w = doSessionRequest(server, http.MethodPost, "/api/v1/admin/rules/reload", nil)
+	if w.Code != StatusOK {
+		t.Fatalf("reload status = %d: %s", w.Code, w.Body.String())
+	}
+	var record tsdio.RuleReload
+	if err := json.Unmarshal(w.Body.Bytes(), &record); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if !record.Success || record.Trigger != RuleReloadAdmin || record.Rules != 2 ||
+		!reflect.DeepEqual(record.Added, []string{"medium"}) ||
+		!reflect.DeepEqual(record.Removed, []string{"small"}) ||
+		!reflect.DeepEqual(record.Changed, []string{"big"}) {
+		t.Errorf("reload = %+v", record)
+	}
+
+	// Le fait ingéré avant le rechargement est toujours en mémoire
+	w = doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+RulesSessionID+"/query", tsdio.QueryRequest{Query: "orders"})
+	var result tsdio.QueryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || result.Count != 1 {
+		t.Errorf("orders after reload = %s", w.Body.String())
+	}
+
+	// Un rechargement invalide conserve la base de règles en place
+	writeRules(t, dir, "rules.tsd", `rule big : {o: Order} / o.missing > 20 ==> notify(o.id)`)
+	w = doSessionRequest(server, http.MethodPost, "/api/v1/admin/rules/reload", nil)
+	if w.Code != StatusBadRequest {
+		t.Fatalf("invalid reload status = %d: %s", w.Code, w.Body.String())
+	}
+	if !hasRule(server, "medium") || !hasRule(server, "big") {
+		t.Errorf("failed reload changed the loaded rules")
+	}
+
+	w = doSessionRequest(server, http.MethodGet, "/api/v1/admin/rules/reloads", nil)
+	var history tsdio.RuleReloadsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if history.RulesDir != dir || len(history.Reloads) != 3 {
+		t.Fatalf("history = %+v", history)
+	}
+	latest, first := history.Reloads[0], history.Reloads[2]
+	if latest.Success || latest.ErrorType != tsdio.ErrorTypeValidationError || latest.Rules != 2 {
+		t.Errorf("latest reload = %+v", latest)
+	}
+	if first.Trigger != RuleReloadStartup || first.ID != 1 || !reflect.DeepEqual(first.Files, []string{"rules.tsd", "types.tsd"}) {
+		t.Errorf("first reload = %+v", first)
+	}
+
+	metrics := scrapeMetrics(t, server.mux, "").Body.String()
+	for _, want := range []string{
+		`tsd_rules_reloads_total{trigger="startup",outcome="success"} 1`,
+		`tsd_rules_reloads_total{trigger="admin",outcome="success"} 1`,
+		`tsd_rules_reloads_total{trigger="admin",outcome="failure"} 1`,
+		`tsd_rules_reload_duration_seconds_count{trigger="admin"} 2`,
+		"tsd_rules_loaded 2",
+		"tsd_rules_last_reload_success_timestamp_seconds ",
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+}
+
+func TestRules_SessionIsPinned(t *testing.T) {
+	server, _ := newRulesTestServer(t, `rule big : {o: Order} / o.total > 100 ==> notify(o.id)`)
+
+	w := doSessionRequest(server, http.MethodDelete, "/api/v1/sessions/"+RulesSessionID, nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("DELETE status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	if _, exists := server.sessions.get(RulesSessionID); !exists {
+		t.Errorf("rules session was removed")
+	}
+}
+
+func TestRules_WithoutRulesDir(t *testing.T) {
+	server := newSessionTestServer(t)
+	for _, tt := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/admin/rules/reload"},
+		{http.MethodGet, "/api/v1/admin/rules/reloads"},
+	} {
+		if w := doSessionRequest(server, tt.method, tt.path, nil); w.Code != StatusNotFound {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, w.Code, StatusNotFound)
+		}
+	}
+}
+
+func TestRules_WatchReloadsOnChange(t *testing.T) {
+	server, dir := newRulesTestServer(t, `rule big : {o: Order} / o.total > 100 ==> notify(o.id)`)
+	server.startRulesWatcher(10 * time.Millisecond)
+	defer server.stopRulesWatcher()
+
+	writeRules(t, dir, "extra.tsd", `rule huge : {o: Order} / o.total > 1000 ==> notify(o.id)`)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !hasRule(server, "huge") {
+		if time.Now().After(deadline) {
+			t.Fatalf("huge not loaded by the watcher, history = %+v", server.rules.reloads())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if latest := server.rules.reloads()[0]; latest.Trigger != RuleReloadWatch || !reflect.DeepEqual(latest.Added, []string{"huge"}) {
+		t.Errorf("latest reload = %+v", latest)
+	}
+}
+
+func TestRules_FailedReloadIsRetried(t *testing.T) {
+	server, dir := newRulesTestServer(t, `rule big : {o: Order} / o.total > 100 ==> notify(o.id)`)
+	if server.rules.changed() {
+		t.Fatal("rules directory reported as changed after the startup reload")
+	}
+
+	writeRules(t, dir, "extra.tsd", `rule huge : {o: Order} / o.total > 1000 ==> notify(o.id)`)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if record := server.rules.reload(canceled, RuleReloadWatch, nil); record.Success {
+		t.Fatalf("reload with a canceled context = %+v, want failure", record)
+	}
+	if !server.rules.changed() {
+		t.Error("a failed reload must be retried by the next poll")
+	}
+
+	if record := server.rules.reload(context.Background(), RuleReloadWatch, nil); !record.Success {
+		t.Fatalf("reload = %+v", record)
+	}
+	if server.rules.changed() || !hasRule(server, "huge") {
+		t.Errorf("changed() = %v, huge loaded = %v after a successful reload", server.rules.changed(), hasRule(server, "huge"))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -88,6 +89,10 @@ const (
 	// DefaultShutdownTimeout est le timeout pour le graceful shutdown (30 secondes)
 	DefaultShutdownTimeout = 30 * time.Second
 
+	// DefaultExecutionTimeout borne l'exécution d'un programme (10 secondes),
+	// en deçà de DefaultWriteTimeout pour que l'erreur parvienne au client
+	DefaultExecutionTimeout = 10 * time.Second
+
 	// Headers de sécurité HTTP recommandés pour API TSD
 
 	// HeaderStrictTransportSecurity force HTTPS pour 1 an avec subdomains
@@ -144,6 +149,27 @@ type Config struct {
 	TLSCertFile   string
 	TLSKeyFile    string
 	Insecure      bool
+	MetricsAddr   string // Adresse d'écoute dédiée à /metrics (vide : servi sur le port principal)
+	MetricsAuth   bool   // Exiger l'authentification sur /metrics
+	TraceEndpoint string // Collecteur OTLP/HTTP recevant les spans de propagation
+	TraceFile     string // Fichier JSON recevant les spans de propagation
+
+	// Limites de faits en mémoire, appliquées à chaque exécution et session
+	MaxFacts         int            // Nombre maximal de faits (0 : illimité)
+	MaxFactsPerType  map[string]int // Quotas par type
+	FactLimitPolicy  string         // reject, evict-oldest ou block
+	FactLimitTimeout time.Duration  // Attente maximale avec la politique block
+
+	// Garde-fous contre les cascades de règles, par exécution et par ingestion de session
+	MaxCascadeDepth    int // Actions imbriquées au plus (0 : rete.DefaultMaxCascadeDepth)
+	MaxActivations     int // Activations de règles (0 : illimité)
+	MaxRuleActivations int // Activations d'une même règle (0 : illimité)
+
+	ExecutionTimeout time.Duration // Durée maximale d'une ingestion (0 : illimitée)
+
+	// Base de règles rechargée à chaud (session RulesSessionID)
+	RulesDir          string        // Répertoire des fichiers de règles (vide : pas de base de règles)
+	RulesPollInterval time.Duration // Intervalle de scrutation du répertoire (0 : pas de surveillance)
 }
 
 // Server représente le serveur HTTP TSD
@@ -153,6 +179,18 @@ type Server struct {
 	mux         *http.ServeMux
 	authManager *auth.Manager
 	httpServer  *http.Server
+	sessions    *sessionStore
+	metrics     *serverMetrics
+
+	// Exporteur des traces de propagation (nil : traçage désactivé)
+	spanExporter rete.SpanExporter
+
+	// Base de règles chargée depuis --rules (nil sans répertoire de règles)
+	rules *ruleBase
+
+	// Serveur dédié aux métriques (si --metrics-addr est fourni)
+	metricsMux    *http.ServeMux
+	metricsServer *http.Server
 }
 
 // Run démarre le serveur TSD avec les arguments donnés et retourne un code de sortie
@@ -167,6 +205,7 @@ type ServerInfo struct {
 	AuthEnabled bool
 	AuthType    string
 	Endpoints   []string
+	MetricsURL  string
 }
 
 // prepareServerInfo prépare les informations du serveur (logique testable)
@@ -198,6 +237,18 @@ func prepareServerInfo(config *Config, server *Server) *ServerInfo {
 		fmt.Sprintf("GET  %s://%s/health - Health check", protocol, addr),
 		fmt.Sprintf("GET  %s://%s/api/v1/version - Version info", protocol, addr),
 	}
+	if config.RulesDir != "" {
+		info.Endpoints = append(info.Endpoints,
+			fmt.Sprintf("POST %s://%s/api/v1/admin/rules/reload - Recharger les règles de %s", protocol, addr, config.RulesDir),
+			fmt.Sprintf("GET  %s://%s/api/v1/admin/rules/reloads - Historique des rechargements", protocol, addr),
+		)
+	}
+
+	metricsAddr := addr
+	if config.MetricsAddr != "" {
+		metricsAddr = config.MetricsAddr
+	}
+	info.MetricsURL = fmt.Sprintf("%s://%s%s", protocol, metricsAddr, MetricsPath)
 
 	return info
 }
@@ -228,6 +279,10 @@ func logServerInfo(logger *log.Logger, info *ServerInfo) {
 	for _, endpoint := range info.Endpoints {
 		logger.Printf("   %s", endpoint)
 	}
+
+	if info.MetricsURL != "" {
+		logger.Printf("📈 Métriques Prometheus: %s", info.MetricsURL)
+	}
 }
 
 // createTLSConfig crée la configuration TLS (logique testable)
@@ -276,8 +331,38 @@ func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 		server.httpServer.TLSConfig = tlsConf
 	}
 
+	// Serveur de métriques sur une adresse dédiée
+	if server.metricsMux != nil {
+		server.metricsServer = &http.Server{
+			Addr:              config.MetricsAddr,
+			Handler:           server.metricsMux,
+			ReadTimeout:       DefaultReadTimeout,
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			WriteTimeout:      DefaultWriteTimeout,
+			IdleTimeout:       DefaultIdleTimeout,
+			MaxHeaderBytes:    DefaultMaxHeaderBytes,
+			TLSConfig:         server.httpServer.TLSConfig,
+		}
+	}
+
 	// Canal pour capturer les erreurs du serveur
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, 2)
+
+	server.startRulesWatcher(config.RulesPollInterval)
+
+	if server.metricsServer != nil {
+		go func() {
+			var err error
+			if config.Insecure {
+				err = server.metricsServer.ListenAndServe()
+			} else {
+				err = server.metricsServer.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)
+			}
+			if err != nil && err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("serveur de métriques: %w", err)
+			}
+		}()
+	}
 
 	// Démarrer le serveur dans une goroutine
 	go func() {
@@ -342,6 +427,35 @@ func parseFlags(args []string) *Config {
 	fs.DurationVar(&config.JWTExpiration, "jwt-expiration", 24*time.Hour, "Durée de validité JWT")
 	fs.StringVar(&config.JWTIssuer, "jwt-issuer", "tsd-server", "Émetteur JWT")
 
+	// Métriques
+	fs.StringVar(&config.MetricsAddr, "metrics-addr", "", "Adresse d'écoute dédiée à /metrics (ex: :9090, vide: port principal)")
+	fs.BoolVar(&config.MetricsAuth, "metrics-auth", false, "Exiger l'authentification sur /metrics")
+
+	// Traçage
+	fs.StringVar(&config.TraceEndpoint, "trace-endpoint", "", "Collecteur OTLP/HTTP des traces (ex: http://localhost:4318)")
+	fs.StringVar(&config.TraceFile, "trace-file", "", "Fichier JSON recevant les traces (OTLP JSON, une ligne par export)")
+
+	fs.DurationVar(&config.ExecutionTimeout, "execution-timeout", DefaultExecutionTimeout, "Durée maximale d'exécution d'un programme (0: illimitée)")
+
+	// Limites de faits en mémoire
+	fs.IntVar(&config.MaxFacts, "max-facts", 0, "Nombre maximal de faits en mémoire par exécution ou session (0: illimité)")
+	fs.Func("max-facts-per-type", "Quotas de faits par type (ex: Reading=10000,Event=500)", func(value string) error {
+		quotas, err := parseTypeQuotas(value)
+		config.MaxFactsPerType = quotas
+		return err
+	})
+	fs.StringVar(&config.FactLimitPolicy, "fact-limit-policy", string(rete.FactLimitReject), "Politique à la limite: reject, evict-oldest, block")
+	fs.DurationVar(&config.FactLimitTimeout, "fact-limit-timeout", rete.DefaultFactLimitBlockTimeout, "Attente maximale d'une soumission bloquée (politique block)")
+
+	// Garde-fous contre les cascades de règles
+	fs.IntVar(&config.MaxCascadeDepth, "max-cascade-depth", rete.DefaultMaxCascadeDepth, "Nombre maximal d'actions de règles imbriquées")
+	fs.IntVar(&config.MaxActivations, "max-activations", 0, "Nombre maximal d'activations de règles par exécution (0: illimité)")
+	fs.IntVar(&config.MaxRuleActivations, "max-rule-activations", 0, "Nombre maximal d'activations d'une même règle par exécution (0: illimité)")
+
+	// Base de règles rechargée à chaud
+	fs.StringVar(&config.RulesDir, "rules", "", "Répertoire des fichiers de règles chargés dans la session \""+RulesSessionID+"\" et rechargés à chaud")
+	fs.DurationVar(&config.RulesPollInterval, "rules-poll-interval", DefaultRulesPollInterval, "Intervalle de scrutation du répertoire de règles (0: rechargement via l'API uniquement)")
+
 	fs.Parse(args)
 
 	// Variables d'environnement pour TLS
@@ -410,11 +524,40 @@ func NewServer(config *Config, logger *log.Logger) (*Server, error) {
 		return nil, fmt.Errorf("erreur initialisation authentification: %w", err)
 	}
 
+	spanExporter, err := newSpanExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.factLimits().Validate(); err != nil {
+		return nil, fmt.Errorf("limites de faits invalides: %w", err)
+	}
+	if err := config.cascadeLimits().Validate(); err != nil {
+		return nil, fmt.Errorf("limites de cascade invalides: %w", err)
+	}
+
 	s := &Server{
-		config:      config,
-		logger:      logger,
-		mux:         http.NewServeMux(),
-		authManager: authManager,
+		config:       config,
+		logger:       logger,
+		mux:          http.NewServeMux(),
+		authManager:  authManager,
+		sessions:     newSessionStore(DefaultMaxSessions),
+		metrics:      newServerMetrics(),
+		spanExporter: spanExporter,
+	}
+	s.sessions.pipelineConfig = config.pipelineConfig()
+
+	if config.RulesDir != "" {
+		if s.rules, err = s.newRuleBase(config.RulesDir); err != nil {
+			return nil, err
+		}
+		ctx, cancel := s.executionContext(context.Background())
+		record := s.rules.reload(ctx, RuleReloadStartup, s.metrics)
+		cancel()
+		if !record.Success {
+			return nil, fmt.Errorf("chargement des règles de %s: %s", config.RulesDir, record.Error)
+		}
+		s.logRuleReload(record)
 	}
 
 	// Enregistrer les routes
@@ -425,21 +568,55 @@ func NewServer(config *Config, logger *log.Logger) (*Server, error) {
 
 // registerRoutes enregistre les routes HTTP
 func (s *Server) registerRoutes() {
-	s.mux.HandleFunc("/api/v1/execute", s.withSecurityHeaders(s.validateContentType(s.handleExecute)))
-	s.mux.HandleFunc("/health", s.withSecurityHeaders(s.handleHealth))
-	s.mux.HandleFunc("/api/v1/version", s.withSecurityHeaders(s.handleVersion))
+	s.route(s.mux, "/api/v1/execute", s.withSecurityHeaders(s.validateContentType(s.handleExecute)))
+	s.route(s.mux, "/health", s.withSecurityHeaders(s.handleHealth))
+	s.route(s.mux, "/api/v1/version", s.withSecurityHeaders(s.handleVersion))
+
+	// Sessions : pipelines persistants interrogeables par requêtes nommées
+	s.route(s.mux, "/api/v1/sessions", s.withSecurityHeaders(s.validateContentType(s.handleCreateSession)))
+	s.route(s.mux, "/api/v1/sessions/{id}", s.withSecurityHeaders(s.handleDeleteSession))
+	s.route(s.mux, "/api/v1/sessions/{id}/ingest", s.withSecurityHeaders(s.validateContentType(s.handleSessionIngest)))
+	s.route(s.mux, "/api/v1/sessions/{id}/query", s.withSecurityHeaders(s.validateContentType(s.handleSessionQuery)))
+	s.route(s.mux, "/api/v1/sessions/{id}/rules", s.withSecurityHeaders(s.handleSessionRules))
+	s.route(s.mux, "/api/v1/sessions/{id}/rules/{rule}/state", s.withSecurityHeaders(s.validateContentType(s.handleSessionRuleState)))
+	s.route(s.mux, "/api/v1/sessions/{id}/rules/{rule}/dry-runs", s.withSecurityHeaders(s.handleSessionRuleDryRuns))
+
+	// Base de règles : rechargement à la demande et historique
+	s.route(s.mux, "/api/v1/admin/rules/reload", s.withSecurityHeaders(s.handleRulesReload))
+	s.route(s.mux, "/api/v1/admin/rules/reloads", s.withSecurityHeaders(s.handleRulesReloads))
+
+	// Métriques : sur le mux principal ou sur un listener dédié
+	metricsMux := s.mux
+	if s.config.MetricsAddr != "" {
+		s.metricsMux = http.NewServeMux()
+		metricsMux = s.metricsMux
+	}
+	s.route(metricsMux, MetricsPath, s.withSecurityHeaders(s.handleMetrics))
+}
+
+// route enregistre un handler instrumenté (latence et code de statut par endpoint)
+func (s *Server) route(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	mux.HandleFunc(pattern, s.instrument(pattern, handler))
 }
 
 // Shutdown effectue un arrêt gracieux du serveur.
 // Les nouvelles connexions sont refusées et les requêtes en cours sont
 // terminées dans la limite du timeout spécifié via le contexte.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopRulesWatcher()
+
 	if s.httpServer == nil {
 		return nil
 	}
 
 	s.logger.Printf("🛑 Arrêt gracieux du serveur démarré...")
 
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Shutdown(ctx); err != nil {
+			s.logger.Printf("❌ Erreur lors du shutdown des métriques: %v", err)
+		}
+	}
+
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		s.logger.Printf("❌ Erreur lors du shutdown: %v", err)
 		return fmt.Errorf("erreur shutdown serveur: %w", err)
@@ -564,7 +741,7 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Exécuter le programme TSD
-	response := s.executeTSDProgram(&req, startTime)
+	response := s.executeTSDProgramWithContext(traceContext(r), &req, startTime)
 
 	// Écrire la réponse
 	s.writeJSON(w, response, StatusOK)
@@ -580,19 +757,67 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// executionContext borne ctx par la durée maximale d'exécution configurée
+func (s *Server) executionContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.config == nil || s.config.ExecutionTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.config.ExecutionTimeout)
+}
+
+// ingestionErrorType distingue les ingestions interrompues (échéance ou
+// déconnexion du client) des erreurs d'exécution
+func ingestionErrorType(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return tsdio.ErrorTypeTimeoutError
+	}
+	return tsdio.ErrorTypeExecutionError
+}
+
+// errorDiagnostics retourne les diagnostics d'une erreur de parsing, de
+// validation ou d'ingestion ; nil si aucun n'est localisé dans le source
+func errorDiagnostics(err error) []tsdio.Diagnostic {
+	diagnostics := constraint.Diagnostics(err)
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Span != nil {
+			return diagnostics
+		}
+	}
+	return nil
+}
+
+// newSourceErrorResponse crée une réponse d'erreur portant les diagnostics de err
+func newSourceErrorResponse(errorType, errorMsg string, err error, executionTimeMs int64) *tsdio.ExecuteResponse {
+	response := tsdio.NewErrorResponse(errorType, errorMsg, executionTimeMs)
+	response.Diagnostics = errorDiagnostics(err)
+	return response
+}
+
 // executeTSDProgram exécute un programme TSD et retourne la réponse
 func (s *Server) executeTSDProgram(req *tsdio.ExecuteRequest, startTime time.Time) *tsdio.ExecuteResponse {
+	return s.executeTSDProgramWithContext(context.Background(), req, startTime)
+}
+
+// executeTSDProgramWithContext exécute un programme TSD ; si le traçage est actif,
+// les spans sont rattachés au span parent porté par ctx (en-tête traceparent)
+func (s *Server) executeTSDProgramWithContext(ctx context.Context, req *tsdio.ExecuteRequest, startTime time.Time) *tsdio.ExecuteResponse {
 	// Parser le programme TSD
 	resultRaw, err := constraint.ParseConstraint(req.SourceName, []byte(req.Source))
 	if err != nil {
 		executionTimeMs := time.Since(startTime).Milliseconds()
-		return tsdio.NewErrorResponse(tsdio.ErrorTypeParsingError, fmt.Sprintf("Erreur de parsing: %v", err), executionTimeMs)
+		return newSourceErrorResponse(tsdio.ErrorTypeParsingError, fmt.Sprintf("Erreur de parsing: %v", err), err, executionTimeMs)
+	}
+
+	// Les imports donneraient accès au système de fichiers du serveur
+	if err := rejectImports(resultRaw); err != nil {
+		executionTimeMs := time.Since(startTime).Milliseconds()
+		return tsdio.NewErrorResponse(tsdio.ErrorTypeValidationError, err.Error(), executionTimeMs)
 	}
 
 	// Valider le programme
 	if err := constraint.ValidateConstraintProgram(resultRaw); err != nil {
 		executionTimeMs := time.Since(startTime).Milliseconds()
-		return tsdio.NewErrorResponse(tsdio.ErrorTypeValidationError, fmt.Sprintf("Erreur de validation: %v", err), executionTimeMs)
+		return newSourceErrorResponse(tsdio.ErrorTypeValidationError, fmt.Sprintf("Erreur de validation: %v", err), err, executionTimeMs)
 	}
 
 	// Convertir le résultat en Program pour accéder aux XupleSpaces
@@ -615,6 +840,16 @@ func (s *Server) executeTSDProgram(req *tsdio.ExecuteRequest, startTime time.Tim
 
 	// Créer le réseau RETE et configurer le XupleHandler AVANT l'ingestion
 	network := rete.NewReteNetwork(storage)
+	if s.config != nil {
+		if err := network.SetFactLimits(s.config.factLimits()); err != nil {
+			executionTimeMs := time.Since(startTime).Milliseconds()
+			return tsdio.NewErrorResponse(tsdio.ErrorTypeServerError, fmt.Sprintf("Erreur limites de faits: %v", err), executionTimeMs)
+		}
+		if err := network.SetCascadeLimits(s.config.cascadeLimits()); err != nil {
+			executionTimeMs := time.Since(startTime).Milliseconds()
+			return tsdio.NewErrorResponse(tsdio.ErrorTypeServerError, fmt.Sprintf("Erreur limites de cascade: %v", err), executionTimeMs)
+		}
+	}
 	network.SetXupleManager(xupleManager)
 	network.SetXupleHandler(func(xuplespace string, fact *rete.Fact, triggeringFacts []*rete.Fact) error {
 		return xupleManager.CreateXuple(xuplespace, fact, triggeringFacts)
@@ -622,7 +857,11 @@ func (s *Server) executeTSDProgram(req *tsdio.ExecuteRequest, startTime time.Tim
 
 	// Créer un collecteur d'exécutions (observer pattern) et le configurer AVANT l'ingestion
 	statsCollector := NewExecutionStatsCollector()
-	network.SetActionObserver(statsCollector)
+	if s.metrics != nil {
+		network.SetActionObserver(rete.NewCompositeObserver(statsCollector, s.metrics))
+	} else {
+		network.SetActionObserver(statsCollector)
+	}
 
 	// Créer un fichier temporaire pour le source
 	tmpFile, err := os.CreateTemp("", "tsd-*.tsd")
@@ -641,10 +880,17 @@ func (s *Server) executeTSDProgram(req *tsdio.ExecuteRequest, startTime time.Tim
 	tmpFile.Close()
 
 	// Ingérer le fichier avec le réseau pré-configuré
-	network, _, err = pipeline.IngestFile(tmpFile.Name(), network, storage)
+	endTrace := s.startExecutionTrace(ctx, network, req.SourceName)
+	execCtx, cancel := s.executionContext(ctx)
+	defer cancel()
+	network, _, err = pipeline.IngestFileContext(execCtx, tmpFile.Name(), network, storage)
+	endTrace(err)
+	if s.metrics != nil {
+		s.metrics.recordFactLimits(network.FactLimitStats())
+	}
 	if err != nil {
 		executionTimeMs := time.Since(startTime).Milliseconds()
-		return tsdio.NewErrorResponse(tsdio.ErrorTypeExecutionError, fmt.Sprintf("Erreur ingestion: %v", err), executionTimeMs)
+		return newSourceErrorResponse(ingestionErrorType(err), fmt.Sprintf("Erreur ingestion: %v", err), err, executionTimeMs)
 	}
 
 	// Configurer le BuiltinActionExecutor avec le XupleManager
@@ -823,9 +1069,8 @@ func (s *Server) collectActivations(network *rete.ReteNetwork) []tsdio.Activatio
 
 		actionName := "unknown"
 		if terminal.Action != nil {
-			jobs := terminal.Action.GetJobs()
-			if len(jobs) > 0 {
-				actionName = jobs[0].Name
+			if call := terminal.Action.FirstCall(); call != nil {
+				actionName = call.Name
 			}
 		}
 
@@ -939,6 +1184,9 @@ func (s *Server) authenticate(r *http.Request) error {
 
 	// Valider le token
 	if err := s.authManager.ValidateToken(token); err != nil {
+		if s.metrics != nil {
+			s.metrics.authFailures.inc(r.Pattern)
+		}
 		return err
 	}
 
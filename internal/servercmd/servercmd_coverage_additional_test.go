@@ -425,6 +425,53 @@ type Address(street: string, city: string)`,
 	}
 }
 
+// TestExecuteTSDProgram_Diagnostics vérifie que les erreurs de parsing et de
+// validation sont localisées dans le source
+func TestExecuteTSDProgram_Diagnostics(t *testing.T) {
+	server, err := NewServer(&Config{Host: "localhost", Insecure: true, AuthType: "none"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		wantCode string
+		wantLine int
+		wantFix  string
+	}{
+		{
+			name:     "syntax error",
+			source:   "type Person(#id: string)\nrule r : {p: Person} / p.id == ",
+			wantCode: "syntax",
+			wantLine: 2,
+		},
+		{
+			name:     "unknown type",
+			source:   "type Person(#id: string)\naction log(id: string)\nrule r : {p: Persn} / p.id == \"a\" ==> log(p.id)",
+			wantCode: "unknown-type",
+			wantLine: 3,
+			wantFix:  "remplacer 'Persn' par 'Person'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := server.executeTSDProgram(&tsdio.ExecuteRequest{Source: tt.source, SourceName: "prog.tsd"}, time.Now())
+			if response.Success || len(response.Diagnostics) == 0 {
+				t.Fatalf("response = %+v, want diagnostics", response)
+			}
+			d := response.Diagnostics[0]
+			if d.Code != tt.wantCode || d.Span == nil || d.Span.File != "prog.tsd" || d.Span.Line != tt.wantLine {
+				t.Errorf("diagnostic = %+v (span %v), want %s at prog.tsd:%d", d, d.Span, tt.wantCode, tt.wantLine)
+			}
+			if tt.wantFix != "" && d.SuggestedFix != tt.wantFix {
+				t.Errorf("SuggestedFix = %q, want %q", d.SuggestedFix, tt.wantFix)
+			}
+		})
+	}
+}
+
 // TestPrepareServerInfo tests the prepareServerInfo function
 func TestPrepareServerInfo(t *testing.T) {
 	tests := []struct {
@@ -6,6 +6,7 @@ package servercmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -186,6 +187,36 @@ func TestHandleExecute_MissingSource(t *testing.T) {
 	}
 }
 
+// TestHandleExecute_RejectsImports vérifie que les imports sont refusés via HTTP
+func TestHandleExecute_RejectsImports(t *testing.T) {
+	config := &Config{
+		AuthType: "none",
+	}
+	logger := log.New(io.Discard, "", 0)
+	server, err := NewServer(config, logger)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	reqBody := `{"source": "import \"/etc/rules.tsd\"\ntype A(#id: string)", "source_name": "test.tsd"}`
+	req := httptest.NewRequest("POST", "/api/v1/execute", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.handleExecute(w, req)
+
+	var resp tsdio.ExecuteResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if resp.Success || resp.ErrorType != tsdio.ErrorTypeValidationError {
+		t.Errorf("Expected validation error, got %+v", resp)
+	}
+	if !strings.Contains(resp.Error, "imports") {
+		t.Errorf("Error should mention imports: %s", resp.Error)
+	}
+}
+
 // TestHandleExecute_VerboseMode teste le mode verbose
 func TestHandleExecute_VerboseMode(t *testing.T) {
 	var buf bytes.Buffer
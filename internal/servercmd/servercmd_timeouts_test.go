@@ -7,6 +7,7 @@ package servercmd
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -16,6 +17,8 @@ import (
 	"os/exec"
 	"testing"
 	"time"
+
+	"github.com/treivax/tsd/tsdio"
 )
 
 // TestServerTimeouts vérifie que les timeouts par défaut sont correctement configurés
@@ -126,6 +129,7 @@ func TestTimeoutConstants(t *testing.T) {
 		{"DefaultIdleTimeout", DefaultIdleTimeout, 60 * time.Second},
 		{"DefaultReadHeaderTimeout", DefaultReadHeaderTimeout, 5 * time.Second},
 		{"DefaultShutdownTimeout", DefaultShutdownTimeout, 30 * time.Second},
+		{"DefaultExecutionTimeout", DefaultExecutionTimeout, 10 * time.Second},
 	}
 
 	for _, tt := range tests {
@@ -559,3 +563,45 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+const runawayTestProgram = `type Tick(n: number)
+
+rule loop : {t: Tick} / t.n >= 0 ==> Insert(Tick(n: t.n + 1))
+
+Tick(n: 0)
+`
+
+// TestExecutionTimeout vérifie qu'une cascade de règles sans fin est
+// interrompue à l'échéance de -execution-timeout, sur /execute et les sessions
+func TestExecutionTimeout(t *testing.T) {
+	config := parseFlags([]string{"-insecure"})
+	if config.ExecutionTimeout != DefaultExecutionTimeout {
+		t.Errorf("ExecutionTimeout par défaut = %v, attendu %v", config.ExecutionTimeout, DefaultExecutionTimeout)
+	}
+
+	// /execute : échéance dépassée avant la fin de l'ingestion
+	server, err := NewServer(&Config{AuthType: "none", ExecutionTimeout: time.Nanosecond}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	response := server.executeTSDProgramWithContext(context.Background(), &tsdio.ExecuteRequest{Source: runawayTestProgram}, time.Now())
+	if response.Success || response.ErrorType != tsdio.ErrorTypeTimeoutError {
+		t.Errorf("réponse /execute = %+v, attendu %s", response, tsdio.ErrorTypeTimeoutError)
+	}
+
+	// Sessions : la cascade Insert sans fin est interrompue
+	// (profondeur de cascade pratiquement illimitée : seule l'échéance l'arrête)
+	server, err = NewServer(&Config{AuthType: "none", ExecutionTimeout: 100 * time.Millisecond, MaxCascadeDepth: 1 << 30}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: runawayTestProgram})
+	var sessionResponse tsdio.SessionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &sessionResponse); err != nil {
+		t.Fatalf("réponse invalide: %v", err)
+	}
+	if sessionResponse.Success || sessionResponse.ErrorType != tsdio.ErrorTypeTimeoutError {
+		t.Errorf("réponse session = %+v, attendu %s", sessionResponse, tsdio.ErrorTypeTimeoutError)
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+const (
+	// DefaultMaxSessions est le nombre maximal de sessions simultanées
+	DefaultMaxSessions = 100
+
+	// StatusCreated est retourné à la création d'une session
+	StatusCreated = http.StatusCreated // 201
+
+	// StatusNotFound est retourné pour une session inconnue
+	StatusNotFound = http.StatusNotFound // 404
+
+	// StatusTooManyRequests est retourné lorsque la limite de sessions est atteinte
+	StatusTooManyRequests = http.StatusTooManyRequests // 429
+)
+
+// session est un pipeline TSD persistant entre plusieurs requêtes HTTP.
+// Contrairement à /api/v1/execute, le réseau et la mémoire de travail sont conservés.
+type session struct {
+	id        string
+	pipeline  *api.Pipeline
+	createdAt time.Time
+	pinned    bool // Session du serveur (base de règles), non fermable
+}
+
+// sessionStore gère les sessions actives du serveur
+type sessionStore struct {
+	mu          sync.RWMutex
+	sessions    map[string]*session
+	maxSessions int
+
+	// Configuration des pipelines créés (nil : configuration par défaut)
+	pipelineConfig *api.Config
+}
+
+// newSessionStore crée un gestionnaire de sessions
+func newSessionStore(maxSessions int) *sessionStore {
+	if maxSessions <= 0 {
+		maxSessions = DefaultMaxSessions
+	}
+	return &sessionStore{
+		sessions:    make(map[string]*session),
+		maxSessions: maxSessions,
+	}
+}
+
+// errTooManySessions est retourné lorsque la limite de sessions est atteinte
+var errTooManySessions = errors.New("nombre maximal de sessions atteint")
+
+// errSessionNotFound est retourné pour une session inconnue
+var errSessionNotFound = errors.New("session introuvable")
+
+// create ouvre une nouvelle session avec un pipeline vierge
+func (ss *sessionStore) create() (*session, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if len(ss.sessions)-ss.pinnedCount() >= ss.maxSessions {
+		return nil, errTooManySessions
+	}
+
+	sess := &session{
+		id:        uuid.NewString(),
+		pipeline:  api.NewPipelineWithConfig(ss.pipelineConfig),
+		createdAt: time.Now(),
+	}
+	ss.sessions[sess.id] = sess
+	return sess, nil
+}
+
+// pin crée la session d'identifiant donné, hors limite de sessions.
+// Une session épinglée ne peut pas être fermée par l'API.
+func (ss *sessionStore) pin(id string) *session {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	sess := &session{
+		id:        id,
+		pipeline:  api.NewPipelineWithConfig(ss.pipelineConfig),
+		createdAt: time.Now(),
+		pinned:    true,
+	}
+	ss.sessions[id] = sess
+	return sess
+}
+
+// get retourne une session par son identifiant
+func (ss *sessionStore) get(id string) (*session, bool) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	sess, exists := ss.sessions[id]
+	return sess, exists
+}
+
+// pinnedCount compte les sessions épinglées (verrou tenu par l'appelant)
+func (ss *sessionStore) pinnedCount() int {
+	count := 0
+	for _, sess := range ss.sessions {
+		if sess.pinned {
+			count++
+		}
+	}
+	return count
+}
+
+// errPinnedSession est retourné à la fermeture d'une session épinglée
+var errPinnedSession = errors.New("session du serveur, non fermable")
+
+// remove ferme une session
+func (ss *sessionStore) remove(id string) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	sess, exists := ss.sessions[id]
+	if !exists {
+		return errSessionNotFound
+	}
+	if sess.pinned {
+		return errPinnedSession
+	}
+	delete(ss.sessions, id)
+	return nil
+}
+
+// list retourne les sessions actives, triées par identifiant
+func (ss *sessionStore) list() []*session {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	sessions := make([]*session, 0, len(ss.sessions))
+	for _, sess := range ss.sessions {
+		sessions = append(sessions, sess)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].id < sessions[j].id })
+	return sessions
+}
+
+// count retourne le nombre de sessions actives
+func (ss *sessionStore) count() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return len(ss.sessions)
+}
+
+// handleCreateSession crée une session, avec un programme initial optionnel
+func (s *Server) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodPost {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	req, ok := s.decodeOptionalExecuteRequest(w, r, startTime)
+	if !ok {
+		return
+	}
+
+	sess, err := s.sessions.create()
+	if err != nil {
+		s.sendErrorResponse(w, StatusTooManyRequests, err.Error(), startTime)
+		return
+	}
+	if s.metrics != nil {
+		sess.pipeline.SetActionObserver(s.metrics)
+	}
+
+	if s.config.Verbose {
+		s.logger.Printf("🆕 Session créée: %s", sess.id)
+	}
+
+	response := s.ingestIntoSession(r.Context(), sess, req, startTime)
+	if !response.Success {
+		_ = s.sessions.remove(sess.id)
+		s.writeJSON(w, response, StatusBadRequest)
+		return
+	}
+	s.writeJSON(w, response, StatusCreated)
+}
+
+// handleSessionIngest ingère un programme TSD dans une session existante
+func (s *Server) handleSessionIngest(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodPost {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	sess, ok := s.lookupSession(w, r, startTime)
+	if !ok {
+		return
+	}
+
+	req, ok := s.decodeOptionalExecuteRequest(w, r, startTime)
+	if !ok {
+		return
+	}
+	if req.Source == "" {
+		s.sendErrorResponse(w, StatusBadRequest, "Le champ 'source' est requis", startTime)
+		return
+	}
+
+	response := s.ingestIntoSession(r.Context(), sess, req, startTime)
+	statusCode := StatusOK
+	if !response.Success {
+		statusCode = StatusBadRequest
+	}
+	s.writeJSON(w, response, statusCode)
+}
+
+// handleSessionQuery exécute une requête nommée dans une session
+func (s *Server) handleSessionQuery(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodPost {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	sess, ok := s.lookupSession(w, r, startTime)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+	var req tsdio.QueryRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		s.sendErrorResponse(w, StatusBadRequest, fmt.Sprintf("JSON invalide: %v", err), startTime)
+		return
+	}
+	if req.Query == "" {
+		s.sendErrorResponse(w, StatusBadRequest, "Le champ 'query' est requis", startTime)
+		return
+	}
+
+	result, err := sess.pipeline.Query(req.Query, req.Args...)
+	if err != nil {
+		response := &tsdio.QueryResponse{
+			Success:         false,
+			Error:           err.Error(),
+			ErrorType:       tsdio.ErrorTypeValidationError,
+			Query:           req.Query,
+			Rows:            []map[string]tsdio.Fact{},
+			ExecutionTimeMs: time.Since(startTime).Milliseconds(),
+		}
+		s.writeJSON(w, response, StatusBadRequest)
+		return
+	}
+
+	rows := make([]map[string]tsdio.Fact, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		converted := make(map[string]tsdio.Fact, len(result.Variables))
+		for _, variable := range row.Variables() {
+			fact := row.Fact(variable)
+			converted[variable] = tsdio.Fact{Type: fact.Type, Fields: fact.Fields}
+		}
+		rows = append(rows, converted)
+	}
+
+	s.writeJSON(w, &tsdio.QueryResponse{
+		Success:         true,
+		Query:           result.Query,
+		Variables:       result.Variables,
+		Rows:            rows,
+		Count:           len(rows),
+		ExecutionTimeMs: time.Since(startTime).Milliseconds(),
+	}, StatusOK)
+}
+
+// handleDeleteSession ferme une session
+func (s *Server) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	if r.Method != http.MethodDelete {
+		s.sendErrorResponse(w, http.StatusMethodNotAllowed, "Méthode non autorisée", startTime)
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		s.sendErrorResponse(w, StatusUnauthorized, "Authentification échouée: "+err.Error(), startTime)
+		return
+	}
+
+	id := r.PathValue("id")
+	switch err := s.sessions.remove(id); {
+	case errors.Is(err, errPinnedSession):
+		s.sendErrorResponse(w, http.StatusConflict, fmt.Sprintf("Session '%s': %v", id, err), startTime)
+		return
+	case err != nil:
+		s.sendErrorResponse(w, StatusNotFound, fmt.Sprintf("Session '%s' introuvable", id), startTime)
+		return
+	}
+
+	if s.config.Verbose {
+		s.logger.Printf("🗑️ Session fermée: %s", id)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rejectImports refuse les programmes qui importent des fichiers :
+// un client HTTP ne doit pas pouvoir charger de fichiers du serveur.
+func rejectImports(ast interface{}) error {
+	if imports := constraint.DeclaredImports(ast); len(imports) > 0 {
+		return fmt.Errorf("les imports ne sont pas autorisés via l'API HTTP: %v", imports)
+	}
+	return nil
+}
+
+// lookupSession retrouve la session désignée par le chemin, ou répond 404
+func (s *Server) lookupSession(w http.ResponseWriter, r *http.Request, startTime time.Time) (*session, bool) {
+	id := r.PathValue("id")
+	sess, exists := s.sessions.get(id)
+	if !exists {
+		s.sendErrorResponse(w, StatusNotFound, fmt.Sprintf("Session '%s' introuvable", id), startTime)
+		return nil, false
+	}
+	return sess, true
+}
+
+// decodeOptionalExecuteRequest décode un corps ExecuteRequest, vide accepté
+func (s *Server) decodeOptionalExecuteRequest(w http.ResponseWriter, r *http.Request, startTime time.Time) (*tsdio.ExecuteRequest, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+
+	var req tsdio.ExecuteRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		s.sendErrorResponse(w, StatusBadRequest, fmt.Sprintf("JSON invalide: %v", err), startTime)
+		return nil, false
+	}
+	return &req, true
+}
+
+// ingestIntoSession ingère le programme de la requête (s'il y en a un) dans la session
+func (s *Server) ingestIntoSession(ctx context.Context, sess *session, req *tsdio.ExecuteRequest, startTime time.Time) *tsdio.SessionResponse {
+	response := &tsdio.SessionResponse{
+		SessionID: sess.id,
+		Success:   true,
+	}
+
+	if req.Source != "" {
+		if ast, err := constraint.ParseConstraint(req.SourceName, []byte(req.Source)); err == nil {
+			if err := rejectImports(ast); err != nil {
+				response.Success = false
+				response.Error = err.Error()
+				response.ErrorType = tsdio.ErrorTypeValidationError
+				response.ExecutionTimeMs = time.Since(startTime).Milliseconds()
+				return response
+			}
+		}
+		execCtx, cancel := s.executionContext(ctx)
+		defer cancel()
+		if _, err := sess.pipeline.IngestStringContext(execCtx, req.Source); err != nil {
+			response.Success = false
+			response.Error = err.Error()
+			response.ErrorType = ingestionErrorType(err)
+			response.Diagnostics = errorDiagnostics(err)
+		}
+	}
+
+	response.Queries = sess.pipeline.Queries()
+	response.ExecutionTimeMs = time.Since(startTime).Milliseconds()
+	return response
+}
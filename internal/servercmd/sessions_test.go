@@ -0,0 +1,162 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+const sessionTestProgram = `type Person(#id: string, age: number)
+query adults(min: number) : {p: Person} / p.age >= min
+Person(id: "alice", age: 30)
+Person(id: "bob", age: 12)
+`
+
+// newSessionTestServer crée un serveur sans authentification pour les tests de session
+func newSessionTestServer(t *testing.T) *Server {
+	t.Helper()
+	server, err := NewServer(&Config{AuthType: "none"}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	return server
+}
+
+// doSessionRequest envoie une requête JSON au mux du serveur
+func doSessionRequest(server *Server, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = strings.NewReader(string(data))
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.mux.ServeHTTP(w, req)
+	return w
+}
+
+// createTestSession crée une session avec le programme de test
+func createTestSession(t *testing.T, server *Server) string {
+	t.Helper()
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: sessionTestProgram})
+	if w.Code != StatusCreated {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, StatusCreated, w.Body.String())
+	}
+
+	var resp tsdio.SessionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if resp.SessionID == "" || !resp.Success {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	if len(resp.Queries) != 1 || resp.Queries[0] != "adults" {
+		t.Errorf("Queries = %v, want [adults]", resp.Queries)
+	}
+	return resp.SessionID
+}
+
+func TestSessions_QueryLifecycle(t *testing.T) {
+	server := newSessionTestServer(t)
+	id := createTestSession(t, server)
+
+	w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+id+"/query",
+		tsdio.QueryRequest{Query: "adults", Args: []interface{}{18}})
+	if w.Code != StatusOK {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, StatusOK, w.Body.String())
+	}
+
+	var resp tsdio.QueryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if resp.Count != 1 || resp.Rows[0]["p"].Fields["id"] != "alice" {
+		t.Errorf("Unexpected rows: %+v", resp.Rows)
+	}
+
+	// Les faits ingérés ensuite sont visibles par la même session
+	w = doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+id+"/ingest",
+		tsdio.ExecuteRequest{Source: `Person(id: "carol", age: 45)`})
+	if w.Code != StatusOK {
+		t.Fatalf("Ingest status = %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doSessionRequest(server, http.MethodPost, "/api/v1/sessions/"+id+"/query",
+		tsdio.QueryRequest{Query: "adults", Args: []interface{}{18}})
+	resp = tsdio.QueryResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if resp.Count != 2 {
+		t.Errorf("Count = %d, want 2", resp.Count)
+	}
+
+	w = doSessionRequest(server, http.MethodDelete, "/api/v1/sessions/"+id, nil)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Delete status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if server.sessions.count() != 0 {
+		t.Errorf("Session count = %d, want 0", server.sessions.count())
+	}
+}
+
+func TestSessions_Errors(t *testing.T) {
+	server := newSessionTestServer(t)
+	id := createTestSession(t, server)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		want   int
+	}{
+		{"unknown session", http.MethodPost, "/api/v1/sessions/unknown/query", tsdio.QueryRequest{Query: "adults"}, StatusNotFound},
+		{"unknown query", http.MethodPost, "/api/v1/sessions/" + id + "/query", tsdio.QueryRequest{Query: "missing"}, StatusBadRequest},
+		{"wrong arity", http.MethodPost, "/api/v1/sessions/" + id + "/query", tsdio.QueryRequest{Query: "adults"}, StatusBadRequest},
+		{"wrong argument type", http.MethodPost, "/api/v1/sessions/" + id + "/query", tsdio.QueryRequest{Query: "adults", Args: []interface{}{"x"}}, StatusBadRequest},
+		{"missing query name", http.MethodPost, "/api/v1/sessions/" + id + "/query", tsdio.QueryRequest{}, StatusBadRequest},
+		{"empty ingest", http.MethodPost, "/api/v1/sessions/" + id + "/ingest", tsdio.ExecuteRequest{}, StatusBadRequest},
+		{"import rejected", http.MethodPost, "/api/v1/sessions/" + id + "/ingest", tsdio.ExecuteRequest{Source: `import "/etc/rules.tsd"`}, StatusBadRequest},
+		{"invalid program", http.MethodPost, "/api/v1/sessions", tsdio.ExecuteRequest{Source: "type ("}, StatusBadRequest},
+		{"wrong method", http.MethodGet, "/api/v1/sessions/" + id + "/query", nil, http.StatusMethodNotAllowed},
+		{"delete unknown", http.MethodDelete, "/api/v1/sessions/unknown", nil, StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doSessionRequest(server, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	// Une création échouée ne laisse pas de session orpheline
+	if server.sessions.count() != 1 {
+		t.Errorf("Session count = %d, want 1", server.sessions.count())
+	}
+}
+
+func TestSessions_Limit(t *testing.T) {
+	server := newSessionTestServer(t)
+	server.sessions = newSessionStore(1)
+
+	if w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", nil); w.Code != StatusCreated {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, StatusCreated, w.Body.String())
+	}
+	if w := doSessionRequest(server, http.MethodPost, "/api/v1/sessions", nil); w.Code != StatusTooManyRequests {
+		t.Errorf("Status = %d, want %d", w.Code, StatusTooManyRequests)
+	}
+}
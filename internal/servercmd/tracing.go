@@ -0,0 +1,72 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/treivax/tsd/rete"
+)
+
+const (
+	// HeaderTraceparent est l'en-tête W3C Trace Context propagé par les clients
+	HeaderTraceparent = "traceparent"
+
+	// SpanExecute est le span englobant l'exécution d'un programme
+	SpanExecute = "tsd.execute"
+)
+
+// newSpanExporter crée l'exporteur de traces configuré (nil si le traçage est désactivé)
+func newSpanExporter(config *Config) (rete.SpanExporter, error) {
+	switch {
+	case config.TraceEndpoint != "" && config.TraceFile != "":
+		return nil, fmt.Errorf("--trace-endpoint et --trace-file sont exclusifs")
+	case config.TraceEndpoint != "":
+		return rete.NewOTLPHTTPExporter(config.TraceEndpoint), nil
+	case config.TraceFile != "":
+		return rete.NewJSONFileExporter(config.TraceFile), nil
+	default:
+		return nil, nil
+	}
+}
+
+// traceContext attache au contexte de la requête le span parent reçu dans
+// l'en-tête traceparent. Un en-tête absent ou invalide démarre une nouvelle trace.
+func traceContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	header := r.Header.Get(HeaderTraceparent)
+	if header == "" {
+		return ctx
+	}
+	sc, err := rete.ParseTraceparent(header)
+	if err != nil {
+		return ctx
+	}
+	return rete.ContextWithSpanContext(ctx, sc)
+}
+
+// startExecutionTrace active le traçage sur le réseau d'une exécution.
+// Retourne une fonction qui ferme le span d'exécution et exporte les spans.
+func (s *Server) startExecutionTrace(ctx context.Context, network *rete.ReteNetwork, sourceName string) func(err error) {
+	if s.spanExporter == nil {
+		return func(error) {}
+	}
+
+	tracer := rete.NewTracer(s.spanExporter)
+	if sc, ok := rete.SpanContextFromContext(ctx); ok {
+		tracer.SetRemoteParent(sc)
+	}
+	network.SetTracer(tracer)
+	span := tracer.StartSpan(SpanExecute, map[string]interface{}{"tsd.source": sourceName})
+
+	return func(err error) {
+		tracer.EndSpan(span, err)
+		if flushErr := tracer.Flush(); flushErr != nil {
+			s.logger.Printf("⚠️  Export des traces échoué: %v", flushErr)
+		}
+	}
+}
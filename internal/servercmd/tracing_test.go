@@ -0,0 +1,106 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package servercmd
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+const tracingTestProgram = `type Sensor(#id: string, room: string)
+type Reading(#id: string, sensor: string, value: number)
+action notify(room: string)
+
+rule hot_room : {s: Sensor, r: Reading} / r.sensor == s.id AND r.value > 30 ==> notify(s.room)
+
+Sensor(id: "s1", room: "lab")
+Reading(id: "r1", sensor: "s1", value: 35)
+`
+
+func TestExecute_TraceContextPropagation(t *testing.T) {
+	traceFile := filepath.Join(t.TempDir(), "traces.json")
+	server, err := NewServer(&Config{AuthType: "none", TraceFile: traceFile}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentID := "00f067aa0ba902b7"
+	body, _ := json.Marshal(tsdio.ExecuteRequest{Source: tracingTestProgram, SourceName: "trace.tsd"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/execute", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTraceparent, "00-"+traceID+"-"+parentID+"-01")
+	w := httptest.NewRecorder()
+	server.mux.ServeHTTP(w, req)
+	if w.Code != StatusOK {
+		t.Fatalf("Status = %d: %s", w.Code, w.Body.String())
+	}
+
+	data, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("trace file not written: %v", err)
+	}
+
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		t.Fatalf("invalid trace file: %v", err)
+	}
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	names := map[string]int{}
+	executeSpanID := ""
+	for _, span := range spans {
+		names[span.Name]++
+		if span.TraceID != traceID {
+			t.Errorf("span %s trace = %s, want %s", span.Name, span.TraceID, traceID)
+		}
+		if span.Name == SpanExecute {
+			executeSpanID = span.SpanID
+			if span.ParentSpanID != parentID {
+				t.Errorf("execute span parent = %s, want %s", span.ParentSpanID, parentID)
+			}
+		}
+	}
+	if names[SpanExecute] != 1 || names["rete.fact.submit"] != 2 || names["rete.terminal"] != 1 {
+		t.Errorf("span names = %v", names)
+	}
+	for _, span := range spans {
+		if span.Name == "rete.fact.submit" && span.ParentSpanID != executeSpanID {
+			t.Errorf("fact span parent = %s, want execute span %s", span.ParentSpanID, executeSpanID)
+		}
+	}
+}
+
+func TestNewSpanExporter(t *testing.T) {
+	if exporter, err := newSpanExporter(&Config{}); exporter != nil || err != nil {
+		t.Errorf("newSpanExporter(empty) = %v, %v; want nil, nil", exporter, err)
+	}
+	if _, err := newSpanExporter(&Config{TraceEndpoint: "http://localhost:4318", TraceFile: "t.json"}); err == nil {
+		t.Error("newSpanExporter(endpoint+file) expected error")
+	}
+	if exporter, err := newSpanExporter(&Config{TraceEndpoint: "http://localhost:4318"}); exporter == nil || err != nil {
+		t.Errorf("newSpanExporter(endpoint) = %v, %v", exporter, err)
+	}
+}
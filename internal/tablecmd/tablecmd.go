@@ -0,0 +1,164 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tablecmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/treivax/tsd/tsdio"
+	"github.com/treivax/tsd/tsdtable"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+)
+
+// ErrNoFile est retourné lorsqu'aucune table n'est donnée
+var ErrNoFile = errors.New("aucune table de décision spécifiée")
+
+// Config holds the table compile configuration
+type Config struct {
+	Files    []string // Tables CSV compilées dans l'ordre
+	Output   string   // Fichier TSD écrit (vide : sortie standard)
+	Strict   bool     // Les avertissements font échouer la compilation
+	ShowHelp bool
+}
+
+// Run executes the table command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printHelp(stderr)
+		return ExitError
+	}
+
+	switch args[0] {
+	case "compile":
+		return compile(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		printHelp(stdout)
+		return ExitSuccess
+	default:
+		fmt.Fprintf(stderr, "Commande inconnue: %s\n\n", args[0])
+		printHelp(stderr)
+		return ExitError
+	}
+}
+
+// compile compile les tables et écrit les règles générées ; rien n'est
+// écrit si l'une des tables est en erreur
+func compile(args []string, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+	if len(config.Files) == 0 {
+		fmt.Fprintf(stderr, "Erreur: %v\n\n", ErrNoFile)
+		printHelp(stderr)
+		return ExitError
+	}
+
+	var sources []string
+	failed := false
+	for _, file := range config.Files {
+		compilation, err := tsdtable.CompileFile(file)
+		if compilation == nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			failed = true
+			continue
+		}
+		for _, diagnostic := range compilation.Diagnostics {
+			fmt.Fprintln(stderr, diagnostic.String())
+			if diagnostic.Severity == tsdio.SeverityError ||
+				(config.Strict && diagnostic.Severity == tsdio.SeverityWarning) {
+				failed = true
+			}
+		}
+		sources = append(sources, compilation.Source())
+	}
+	if failed {
+		return ExitError
+	}
+
+	output := strings.Join(sources, "\n")
+	if config.Output != "" {
+		if err := os.WriteFile(config.Output, []byte(output), 0644); err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitError
+		}
+		return ExitSuccess
+	}
+	if _, err := io.WriteString(stdout, output); err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+	return ExitSuccess
+}
+
+// ParseFlags parses the compile flags and returns a Config.
+// Les arguments positionnels sont les tables, dans l'ordre.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	flagSet := flag.NewFlagSet("table compile", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.StringVar(&config.Output, "o", "", "Fichier TSD écrit (défaut: sortie standard)")
+	flagSet.BoolVar(&config.Strict, "strict", false, "Échouer aussi sur les avertissements")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	config.Files = flagSet.Args()
+	return config, nil
+}
+
+// printHelp displays the table command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Table - Tables de décision compilées en règles")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd table compile [options] table.csv [table.csv...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Chaque ligne de la table devient une règle TSD <table>_<n>, écrite sur la")
+	fmt.Fprintln(w, "sortie standard. La table est vérifiée : lignes qui se chevauchent, lignes")
+	fmt.Fprintln(w, "contradictoires ou masquées, combinaisons de valeurs qu'aucune ligne ne couvre.")
+	fmt.Fprintln(w, "Les diagnostics vont sur la sortie d'erreur ; en cas d'erreur rien n'est écrit.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "FORMAT:")
+	fmt.Fprintln(w, "  # table: pricing                                  (défaut: nom du fichier)")
+	fmt.Fprintln(w, "  # hit: first                                      unique (défaut), first, collect, priority")
+	fmt.Fprintln(w, "  # when: {o: Order, c: Customer} / o.customer == c")
+	fmt.Fprintln(w, `  @description,o.total >=,c.tier,"discount(o.id, ?)"`)
+	fmt.Fprintln(w, "  Gros clients gold,1000,gold,20")
+	fmt.Fprintln(w, "  Par défaut,,,0")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "  Colonnes de condition : expression suivie d'un opérateur (==, !=, <, <=, >, >=),")
+	fmt.Fprintln(w, "  ou opérateur en tête de chaque cellule. Colonnes d'action : appel dont les ?")
+	fmt.Fprintln(w, "  reçoivent la cellule. Cellule vide ou - : pas de condition, pas d'appel.")
+	fmt.Fprintln(w, "  Valeurs : nombre, true, false, =expression TSD, sinon chaîne.")
+	fmt.Fprintln(w, "  Colonnes réservées : @priority (politique priority), @description.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -o <fichier>       Écrire les règles dans un fichier")
+	fmt.Fprintln(w, "  -strict            Échouer aussi sur les avertissements")
+	fmt.Fprintln(w, "  -h, --help         Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd table compile pricing.csv > pricing.tsd")
+	fmt.Fprintln(w, "  tsd table compile -strict -o rules/tables.tsd pricing.csv eligibility.csv")
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tablecmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pricingTable = `# hit: first
+# when: {o: Order}
+o.total >=,"discount(o.id, ?)"
+1000,10
+,0
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := ParseFlags([]string{"-strict", "-o", "out.tsd", "a.csv", "b.csv"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if !config.Strict || config.Output != "out.tsd" || strings.Join(config.Files, ",") != "a.csv,b.csv" {
+		t.Errorf("ParseFlags() = %+v", config)
+	}
+
+	if _, err := ParseFlags([]string{"-format", "json"}); err == nil {
+		t.Error("ParseFlags() devrait rejeter une option inconnue")
+	}
+}
+
+func TestRun_Dispatch(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"sans commande", nil, ExitError},
+		{"aide", []string{"--help"}, ExitSuccess},
+		{"commande inconnue", []string{"check"}, ExitError},
+		{"aide de compile", []string{"compile", "-h"}, ExitSuccess},
+		{"compile sans table", []string{"compile"}, ExitError},
+		{"table absente", []string{"compile", "missing.csv"}, ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := Run(tt.args, nil, &stdout, &stderr); got != tt.want {
+				t.Errorf("Run() = %d, want %d (stderr: %s)", got, tt.want, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_Compile(t *testing.T) {
+	dir := t.TempDir()
+	pricing := writeFile(t, dir, "pricing.csv", pricingTable)
+	tags := writeFile(t, dir, "tags.csv", "# hit: collect\n# when: {o: Order}\no.status,\"tag(o.id, ?)\"\nnew,fresh\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"compile", pricing, tags}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Run() = %d, stderr: %s", code, stderr.String())
+	}
+	output := stdout.String()
+	for _, expected := range []string{
+		"rule pricing_1 : {o: Order} / o.total >= 1000 ==> discount(o.id, 10)",
+		"rule pricing_2 : {o: Order} / NOT (o.total >= 1000) ==> discount(o.id, 0)",
+		"rule tags_1 : {o: Order} / o.status == \"new\" ==> tag(o.id, \"fresh\")",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("sortie sans %q:\n%s", expected, output)
+		}
+	}
+	// La table tags ne couvre pas les autres statuts
+	if !strings.Contains(stderr.String(), "tags.csv:3: warning[table-gap]") {
+		t.Errorf("stderr = %s", stderr.String())
+	}
+
+	// En mode strict, l'avertissement fait échouer la compilation
+	stdout.Reset()
+	stderr.Reset()
+	if code := Run([]string{"compile", "-strict", pricing, tags}, nil, &stdout, &stderr); code != ExitError || stdout.Len() != 0 {
+		t.Errorf("Run(-strict) = %d, stdout: %s", code, stdout.String())
+	}
+}
+
+func TestRun_CompileOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	pricing := writeFile(t, dir, "pricing.csv", pricingTable)
+	output := filepath.Join(dir, "pricing.tsd")
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"compile", "-o", output, pricing}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Run() = %d, stderr: %s", code, stderr.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil || !strings.Contains(string(data), "rule pricing_1") || stdout.Len() != 0 {
+		t.Errorf("fichier = %s, %v ; stdout = %s", data, err, stdout.String())
+	}
+}
+
+func TestRun_CompileErrors(t *testing.T) {
+	dir := t.TempDir()
+	pricing := writeFile(t, dir, "pricing.csv", pricingTable)
+	overlap := writeFile(t, dir, "overlap.csv", "# when: {o: Order}\no.total >,\"discount(o.id, ?)\"\n100,5\n1000,10\n")
+
+	// Rien n'est écrit si l'une des tables est en erreur
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"compile", pricing, overlap}, nil, &stdout, &stderr); code != ExitError {
+		t.Fatalf("Run() = %d, want %d", code, ExitError)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "overlap.csv:4: error[table-overlap]: chevauche la ligne 3") {
+		t.Errorf("stderr = %s", stderr.String())
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package testcmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdtest"
+)
+
+// Exit codes
+const (
+	ExitSuccess = 0
+	ExitError   = 1
+	// ExitFailed est retourné lorsqu'au moins un cas de test échoue
+	ExitFailed = 2
+)
+
+// TestFileExtension est l'extension des fichiers de tests de règles
+const TestFileExtension = ".tsdtest"
+
+// ErrNoTests est retourné lorsqu'aucun fichier .tsdtest n'est trouvé
+var ErrNoTests = errors.New("aucun fichier " + TestFileExtension + " trouvé")
+
+// Config holds the test command configuration
+type Config struct {
+	Paths    []string // Fichiers .tsdtest ou répertoires
+	JUnit    string   // Fichier de rapport JUnit XML (optionnel)
+	Coverage bool     // Afficher la couverture des règles
+	JSON     bool     // Sortie JSON plutôt que texte
+	Verbose  bool     // Afficher aussi les cas réussis
+	ShowHelp bool
+}
+
+// Run executes the test command and returns an exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	config, err := ParseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	if config.ShowHelp {
+		printHelp(stdout)
+		return ExitSuccess
+	}
+
+	files, err := findTestFiles(config.Paths)
+	if err != nil {
+		fmt.Fprintf(stderr, "Erreur: %v\n", err)
+		return ExitError
+	}
+
+	suites := make([]*tsdtest.Suite, 0, len(files))
+	for _, file := range files {
+		suite, err := tsdtest.ParseFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitError
+		}
+		suites = append(suites, suite)
+	}
+
+	// Les traces du moteur vont sur stderr : stdout ne contient que les résultats
+	realStdout := os.Stdout
+	os.Stdout = os.Stderr
+	results := make([]*tsdtest.SuiteResult, 0, len(suites))
+	for _, suite := range suites {
+		results = append(results, tsdtest.RunSuite(context.Background(), suite))
+	}
+	os.Stdout = realStdout
+
+	if config.JUnit != "" {
+		if err := writeJUnitFile(config.JUnit, results); err != nil {
+			fmt.Fprintf(stderr, "Erreur: rapport JUnit: %v\n", err)
+			return ExitError
+		}
+	}
+
+	var coverage []rete.RuleCoverage
+	for _, result := range results {
+		coverage = tsdtest.MergeCoverage(coverage, result.Coverage)
+	}
+
+	if config.JSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		output := struct {
+			Suites   []*tsdtest.SuiteResult `json:"suites"`
+			Coverage []rete.RuleCoverage    `json:"coverage"`
+		}{results, coverage}
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(stderr, "Erreur: %v\n", err)
+			return ExitError
+		}
+	} else {
+		PrintResults(stdout, results, config.Verbose)
+		if config.Coverage {
+			PrintCoverage(stdout, coverage)
+		}
+	}
+
+	for _, result := range results {
+		if result.Failed() > 0 {
+			return ExitFailed
+		}
+	}
+	return ExitSuccess
+}
+
+// ParseFlags parses command-line flags and returns a Config.
+// Les arguments positionnels sont des fichiers .tsdtest ou des répertoires
+// (parcourus récursivement) ; par défaut, le répertoire courant.
+func ParseFlags(args []string) (*Config, error) {
+	config := &Config{}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	flagSet.StringVar(&config.JUnit, "junit", "", "Fichier de rapport JUnit XML")
+	flagSet.BoolVar(&config.Coverage, "coverage", false, "Afficher la couverture des règles")
+	flagSet.BoolVar(&config.JSON, "json", false, "Sortie JSON")
+	flagSet.BoolVar(&config.Verbose, "v", false, "Afficher aussi les cas réussis")
+	flagSet.BoolVar(&config.ShowHelp, "h", false, "Afficher l'aide")
+	flagSet.BoolVar(&config.ShowHelp, "help", false, "Afficher l'aide")
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	config.Paths = flagSet.Args()
+	if len(config.Paths) == 0 {
+		config.Paths = []string{"."}
+	}
+	return config, nil
+}
+
+// findTestFiles retourne les fichiers .tsdtest désignés, triés
+func findTestFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && strings.HasSuffix(file, TestFileExtension) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(files) == 0 {
+		return nil, ErrNoTests
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// writeJUnitFile écrit le rapport JUnit XML dans un fichier
+func writeJUnitFile(path string, results []*tsdtest.SuiteResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := tsdtest.WriteJUnit(file, results); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// PrintResults affiche les résultats des tests : les échecs avec le résultat
+// obtenu, puis un résumé
+func PrintResults(w io.Writer, results []*tsdtest.SuiteResult, verbose bool) {
+	total, failed := 0, 0
+	for _, suite := range results {
+		for _, result := range suite.Results {
+			total++
+			switch {
+			case result.Error != "":
+				failed++
+				fmt.Fprintf(w, "✗ %s:%d %s\n    erreur : %s\n", suite.File, result.Line, result.Name, result.Error)
+			case len(result.Failures) > 0:
+				failed++
+				fmt.Fprintf(w, "✗ %s:%d %s\n", suite.File, result.Line, result.Name)
+				for _, failure := range result.Failures {
+					fmt.Fprintf(w, "    %s\n", strings.ReplaceAll(failure, "\n", "\n    "))
+				}
+			case verbose:
+				fmt.Fprintf(w, "✓ %s:%d %s (%s)\n", suite.File, result.Line, result.Name, result.Duration.Round(time.Millisecond))
+			}
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(w, "ÉCHEC : %d/%d test(s) en échec\n", failed, total)
+		return
+	}
+	fmt.Fprintf(w, "OK : %d test(s) réussi(s)\n", total)
+}
+
+// PrintCoverage affiche la couverture des règles : activations de chaque
+// règle et, pour chaque condition, le nombre d'évaluations et de passages
+func PrintCoverage(w io.Writer, coverage []rete.RuleCoverage) {
+	fired, conditions, passed := 0, 0, 0
+	for _, rule := range coverage {
+		if rule.Fired > 0 {
+			fired++
+		}
+		for _, condition := range rule.Conditions {
+			conditions++
+			if condition.Passed > 0 {
+				passed++
+			}
+		}
+	}
+	fmt.Fprintf(w, "\nCouverture : %d/%d règle(s) déclenchée(s), %d/%d condition(s) franchie(s)\n",
+		fired, len(coverage), passed, conditions)
+
+	for _, rule := range coverage {
+		fmt.Fprintf(w, "  %s %s : %d activation(s)\n", mark(rule.Covered()), rule.Rule, rule.Fired)
+		for _, condition := range rule.Conditions {
+			label := condition.Kind
+			if condition.Expression != "" {
+				label += " " + condition.Expression
+			} else if len(condition.Variables) > 0 {
+				label += " [" + strings.Join(condition.Variables, ", ") + "]"
+			}
+			fmt.Fprintf(w, "      %s %s : évaluée %d, franchie %d\n", mark(condition.Passed > 0), label, condition.Evaluated, condition.Passed)
+		}
+	}
+}
+
+func mark(ok bool) string {
+	if ok {
+		return "✓"
+	}
+	return "✗"
+}
+
+// printHelp displays the test command help
+func printHelp(w io.Writer) {
+	fmt.Fprintln(w, "TSD Test - Tests unitaires de règles")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  tsd test [options] [fichier.tsdtest|répertoire...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Chaque cas de test s'exécute dans un pipeline isolé : les fichiers de règles")
+	fmt.Fprintln(w, "sont ingérés, puis les faits du bloc given le sont en simulation (actions")
+	fmt.Fprintln(w, "personnalisées, Print et Xuple enregistrées, non exécutées). Les attentes")
+	fmt.Fprintln(w, "portent sur les activations, l'état final des faits et les xuples créés.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "FICHIER .tsdtest:")
+	fmt.Fprintln(w, "  rules \"orders.tsd\"")
+	fmt.Fprintln(w, "  test \"grosse commande signalée\" {")
+	fmt.Fprintln(w, "      given {")
+	fmt.Fprintln(w, "          Order(id: \"o1\", total: 500, status: \"new\")")
+	fmt.Fprintln(w, "      }")
+	fmt.Fprintln(w, "      expect activation flag(o: Order~o1) calls notify(\"o1\")")
+	fmt.Fprintln(w, "      expect fact Order~o1 {status: \"review\"}")
+	fmt.Fprintln(w, "      expect xuple alerts Alert {order: \"o1\"} times 1")
+	fmt.Fprintln(w, "      expect no activation cancelled")
+	fmt.Fprintln(w, "  }")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "OPTIONS:")
+	fmt.Fprintln(w, "  -junit <fichier>  Écrire un rapport JUnit XML")
+	fmt.Fprintln(w, "  -coverage         Afficher la couverture des règles et des conditions")
+	fmt.Fprintln(w, "  -json             Sortie JSON (résultats et couverture)")
+	fmt.Fprintln(w, "  -v                Afficher aussi les cas réussis")
+	fmt.Fprintln(w, "  -h, --help        Afficher cette aide")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "CODES DE SORTIE:")
+	fmt.Fprintln(w, "  0  Tous les tests réussissent")
+	fmt.Fprintln(w, "  1  Erreur (fichier introuvable, syntaxe .tsdtest)")
+	fmt.Fprintln(w, "  2  Au moins un test en échec")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXEMPLES:")
+	fmt.Fprintln(w, "  tsd test rules/")
+	fmt.Fprintln(w, "  tsd test -coverage -junit report.xml orders.tsdtest")
+}
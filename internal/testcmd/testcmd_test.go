@@ -0,0 +1,184 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package testcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const rulesProgram = `type Order(#id: string, total: number, status: string)
+action notify(id: string)
+
+rule flag : {o: Order} / o.total > 100 ==> Update(o, {status: "review"}), notify(o.id)
+rule cancelled : {o: Order} / o.status == "cancelled" ==> notify(o.id)
+`
+
+const passingSuite = `rules "orders.tsd"
+
+test "grosse commande" {
+    given {
+        Order(id: "o1", total: 500, status: "new")
+    }
+    expect activation flag calls notify("o1")
+    expect fact Order~o1 {status: "review"}
+}
+`
+
+const failingSuite = `rules "../orders.tsd"
+
+test "petite commande" {
+    given {
+        Order(id: "o2", total: 50, status: "new")
+    }
+    expect fact Order~o2 {status: "review"}
+}
+`
+
+// writeTests crée orders.tsd, pass.tsdtest et, si demandé, sub/fail.tsdtest
+func writeTests(t *testing.T, withFailure bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"orders.tsd": rulesProgram, "pass.tsdtest": passingSuite}
+	if withFailure {
+		files[filepath.Join("sub", "fail.tsdtest")] = failingSuite
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("❌ Impossible de créer le répertoire: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := ParseFlags([]string{"-junit", "report.xml", "-coverage", "a.tsdtest", "rules/"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if config.JUnit != "report.xml" || !config.Coverage || len(config.Paths) != 2 {
+		t.Errorf("config = %+v", config)
+	}
+
+	config, err = ParseFlags(nil)
+	if err != nil || len(config.Paths) != 1 || config.Paths[0] != "." {
+		t.Errorf("default paths = %+v, err = %v", config, err)
+	}
+}
+
+func TestRun_Passing(t *testing.T) {
+	dir := writeTests(t, false)
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"-v", "-coverage", dir}, nil, &stdout, &stderr)
+	if code != ExitSuccess {
+		t.Fatalf("Run() = %d, want %d\n%s%s", code, ExitSuccess, stdout.String(), stderr.String())
+	}
+	output := stdout.String()
+	for _, want := range []string{
+		"✓ " + filepath.Join(dir, "pass.tsdtest") + ":3 grosse commande",
+		"OK : 1 test(s) réussi(s)",
+		"Couverture : 1/2 règle(s) déclenchée(s), 1/2 condition(s) franchie(s)",
+		"✗ cancelled : 0 activation(s)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRun_FailingWithJUnit(t *testing.T) {
+	dir := writeTests(t, true)
+	junit := filepath.Join(t.TempDir(), "report.xml")
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"-junit", junit, dir}, nil, &stdout, &stderr)
+	if code != ExitFailed {
+		t.Fatalf("Run() = %d, want %d\n%s%s", code, ExitFailed, stdout.String(), stderr.String())
+	}
+	output := stdout.String()
+	for _, want := range []string{"✗ " + filepath.Join(dir, "sub", "fail.tsdtest") + ":3 petite commande",
+		`status : attendu "review", obtenu "new"`, "ÉCHEC : 1/2 test(s) en échec"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	report, err := os.ReadFile(junit)
+	if err != nil {
+		t.Fatalf("JUnit report not written: %v", err)
+	}
+	if !strings.Contains(string(report), `<testsuites tests="2" failures="1"`) {
+		t.Errorf("JUnit report = %s", report)
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	dir := writeTests(t, false)
+	var stdout, stderr bytes.Buffer
+
+	if code := Run([]string{"-json", filepath.Join(dir, "pass.tsdtest")}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Run() = %d (stderr: %s)", code, stderr.String())
+	}
+	var output struct {
+		Suites []struct {
+			Results []struct{ Name string }
+		}
+		Coverage []struct{ Rule string }
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(output.Suites) != 1 || len(output.Suites[0].Results) != 1 || len(output.Coverage) != 2 {
+		t.Errorf("output = %+v", output)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	empty := t.TempDir()
+	invalid := filepath.Join(t.TempDir(), "bad.tsdtest")
+	if err := os.WriteFile(invalid, []byte("test \"t\" {\n"), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no tests", []string{empty}, "aucun fichier .tsdtest"},
+		{"missing path", []string{filepath.Join(empty, "none")}, "none"},
+		{"syntax", []string{invalid}, "bad.tsdtest:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, nil, &stdout, &stderr); code != ExitError {
+				t.Errorf("Run() = %d, want %d", code, ExitError)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want mention of %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-h"}, nil, &stdout, &stderr); code != ExitSuccess {
+		t.Fatalf("Run(-h) = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "tsd test") {
+		t.Errorf("help output = %q", stdout.String())
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// recordingActionHandler enregistre chaque appel sous la forme "nom(args)"
+type recordingActionHandler struct {
+	name  string
+	mutex *sync.Mutex
+	calls *[]string
+}
+
+func (r *recordingActionHandler) GetName() string                   { return r.name }
+func (r *recordingActionHandler) Validate(args []interface{}) error { return nil }
+func (r *recordingActionHandler) Execute(args []interface{}, ctx *ExecutionContext) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	*r.calls = append(*r.calls, fmt.Sprintf("%s%v", r.name, args))
+	return nil
+}
+
+const controlFlowProgram = `type Customer(#id: string, tier: string, spent: number)
+action discount(customer: string, rate: number)
+action flag(customer: string, label: string)
+
+rule discounts : {c: Customer} / c.spent > 0 ==>
+    let base = c.spent * 0.01,
+    if c.tier == "gold" then { discount(c.id, base * 2), flag(c.id, "gold") }
+    else if c.tier == "silver" then discount(c.id, base)
+    else flag(c.id, (c.spent > 1000 ? "watch" : "standard"))
+
+query boosted() : {c: Customer} / (c.tier == "gold" ? c.spent * 2 : c.spent) > 1500
+
+Customer(id: "g", tier: "gold", spent: 1000)
+Customer(id: "s", tier: "silver", spent: 500)
+Customer(id: "b", tier: "bronze", spent: 2000)
+Customer(id: "n", tier: "bronze", spent: 10)
+`
+
+func TestActionControlFlow(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+
+	var mutex sync.Mutex
+	var calls []string
+	for _, name := range []string{"discount", "flag"} {
+		handler := &recordingActionHandler{name: name, mutex: &mutex, calls: &calls}
+		if err := network.ActionExecutor.RegisterAction(handler); err != nil {
+			t.Fatalf("RegisterAction(%s) error = %v", name, err)
+		}
+	}
+
+	ingestQueryProgram(t, network, storage, controlFlowProgram)
+
+	sort.Strings(calls)
+	want := []string{
+		"discount[g 20]",
+		"discount[s 5]",
+		"flag[b watch]",
+		"flag[g gold]",
+		"flag[n standard]",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// Le ternaire est aussi évalué dans les contraintes
+	if got := len(activeTokens(t, network, "boosted")); got != 2 {
+		t.Errorf("boosted = %d, want 2", got)
+	}
+}
+
+func TestExecuteStatements_LetScope(t *testing.T) {
+	executor := NewActionExecutor(nil, nil)
+	ctx := NewExecutionContext(&Token{ID: "t1"}, nil)
+
+	jobs := []JobCall{
+		{Type: JobTypeLet, Name: "x", Value: map[string]interface{}{"type": "number", "value": 3.0}},
+		{Type: JobTypeIf,
+			Condition: map[string]interface{}{
+				"type":     "comparison",
+				"left":     map[string]interface{}{"type": "variable", "name": "x"},
+				"operator": ">",
+				"right":    map[string]interface{}{"type": "number", "value": 1.0},
+			},
+			Then: []JobCall{{Type: JobTypeLet, Name: "y", Value: map[string]interface{}{"type": "number", "value": 1.0}}},
+		},
+	}
+	if err := executor.executeStatements(jobs, ctx); err != nil {
+		t.Fatalf("executeStatements() error = %v", err)
+	}
+
+	if value, ok := ctx.GetLocal("x"); !ok || value != 3.0 {
+		t.Errorf("x = %v, %v, want 3", value, ok)
+	}
+	// Les let d'une branche restent locaux à la branche
+	if _, ok := ctx.GetLocal("y"); ok {
+		t.Error("y should not be visible after the if block")
+	}
+}
@@ -145,7 +145,7 @@ func (ae *ActionExecutor) SetLogging(enabled bool) {
 //  1. Valide les paramètres (action et token non nil)
 //  2. Récupère tous les jobs de l'action
 //  3. Crée un contexte d'exécution avec les bindings du token
-//  4. Exécute chaque job en séquence avec récupération sur panic
+//  4. Exécute chaque instruction en séquence (appels avec récupération sur panic, let, if)
 //
 // Thread-Safety :
 //   - Cette méthode est thread-safe
@@ -166,22 +166,71 @@ func (ae *ActionExecutor) ExecuteAction(action *Action, token *Token) error {
 		return fmt.Errorf("token is nil")
 	}
 
+	_, err := ae.executeAction(action, token)
+	return err
+}
+
+// executeAction exécute l'action et retourne les appels exécutés, avec leurs
+// arguments évalués (pour l'observateur des actions)
+func (ae *ActionExecutor) executeAction(action *Action, token *Token) ([]ActionCall, error) {
 	// Obtenir tous les jobs à exécuter
 	jobs := action.GetJobs()
 
 	// Créer un contexte d'exécution avec les faits disponibles
 	ctx := NewExecutionContext(token, ae.network)
 	if ctx == nil {
-		return fmt.Errorf("échec création contexte d'exécution")
+		return nil, fmt.Errorf("échec création contexte d'exécution")
 	}
 
-	// Exécuter chaque job en séquence
+	// Exécuter chaque instruction en séquence
+	err := ae.executeStatements(jobs, ctx)
+	return ctx.Calls(), err
+}
+
+// executeStatements exécute une liste d'instructions d'action.
+//
+// Un let lie une valeur pour les instructions suivantes. Un if exécute l'une de
+// ses branches dans une portée fille : les let d'une branche ne sont pas visibles
+// après le bloc.
+//
+// Paramètres :
+//   - jobs : instructions à exécuter
+//   - ctx : contexte d'exécution (reçoit les liaisons let)
+//
+// Retourne :
+//   - error : erreur de la première instruction en échec
+func (ae *ActionExecutor) executeStatements(jobs []JobCall, ctx *ExecutionContext) error {
 	for i, job := range jobs {
-		if err := ae.executeJob(job, ctx, i); err != nil {
-			return fmt.Errorf("erreur exécution job %s (index %d): %w", job.Name, i, err)
+		switch job.Type {
+		case JobTypeLet:
+			value, err := ae.evaluateArgument(job.Value, ctx)
+			if err != nil {
+				return fmt.Errorf("erreur évaluation let %s (index %d): %w", job.Name, i, err)
+			}
+			ctx.SetLocal(job.Name, value)
+
+		case JobTypeIf:
+			holds, err := ae.evaluateCondition(job.Condition, ctx)
+			if err != nil {
+				return fmt.Errorf("erreur évaluation condition if (index %d): %w", i, err)
+			}
+			branch := job.Else
+			if holds {
+				branch = job.Then
+			}
+			if err := ae.executeStatements(branch, ctx.newScope()); err != nil {
+				return err
+			}
+
+		default:
+			if err := ae.executeJob(job, ctx, i); err != nil {
+				if abortsPropagation(err) {
+					return err
+				}
+				return fmt.Errorf("erreur exécution job %s (index %d): %w", job.Name, i, err)
+			}
 		}
 	}
-
 	return nil
 }
 
@@ -229,6 +278,13 @@ func (ae *ActionExecutor) executeJob(job JobCall, ctx *ExecutionContext, jobInde
 		evaluatedArgs = append(evaluatedArgs, evaluated)
 	}
 
+	// Simulation : seules Insert, Update et Retract modifient la mémoire simulée
+	if ae.network != nil {
+		if sim := ae.network.currentSimulation(); sim != nil && !sim.intercept(job.Name, evaluatedArgs, ctx) {
+			return nil
+		}
+	}
+
 	// Vérifier si un handler est enregistré pour cette action
 	handler := ae.registry.Get(job.Name)
 	if handler != nil {
@@ -239,6 +295,9 @@ func (ae *ActionExecutor) executeJob(job JobCall, ctx *ExecutionContext, jobInde
 
 		// Exécuter l'action via son handler
 		if err := handler.Execute(evaluatedArgs, ctx); err != nil {
+			if abortsPropagation(err) {
+				return err
+			}
 			return fmt.Errorf("exécution échouée pour action '%s': %w", job.Name, err)
 		}
 
@@ -248,6 +307,7 @@ func (ae *ActionExecutor) executeJob(job JobCall, ctx *ExecutionContext, jobInde
 		// Aucun handler défini : comportement par défaut (simple log)
 		ae.logger.Printf("📋 ACTION NON DÉFINIE (log uniquement): %s(%v)", job.Name, formatArgs(evaluatedArgs))
 	}
+	ctx.recordCall(job.Name, evaluatedArgs)
 
 	return nil
 }
@@ -27,6 +27,8 @@ type ExecutionContext struct {
 	token    *Token
 	network  *ReteNetwork
 	bindings *BindingChain
+	locals   map[string]interface{} // Valeurs liées par let
+	calls    *[]ActionCall          // Appels exécutés, partagés avec les portées filles
 }
 
 // NewExecutionContext crée un nouveau contexte d'exécution.
@@ -52,6 +54,7 @@ func NewExecutionContext(token *Token, network *ReteNetwork) *ExecutionContext {
 		token:    token,
 		network:  network,
 		bindings: nil,
+		calls:    &[]ActionCall{},
 	}
 
 	// Référencer directement la chaîne de bindings du token si disponible
@@ -89,6 +92,93 @@ func (ctx *ExecutionContext) GetVariable(name string) *Fact {
 	return ctx.bindings.Get(name)
 }
 
+// GetCollection récupère une liste de faits liée par COLLECT.
+//
+// Paramètres:
+//   - name: nom de la variable de collecte
+//
+// Retourne:
+//   - []*Fact: faits collectés (vide si la liste est vide)
+//   - bool: false si aucune liste n'est liée à ce nom
+func (ctx *ExecutionContext) GetCollection(name string) ([]*Fact, bool) {
+	if ctx.token == nil || ctx.token.Collections == nil {
+		return nil, false
+	}
+	facts, ok := ctx.token.Collections[name]
+	return facts, ok
+}
+
+// GetAggregate récupère le résultat d'une variable d'agrégation.
+//
+// Paramètres:
+//   - name: nom de la variable d'agrégation
+//
+// Retourne:
+//   - interface{}: valeur agrégée (nombre, chaîne, liste ou nil)
+//   - bool: false si aucune agrégation n'est liée à ce nom
+func (ctx *ExecutionContext) GetAggregate(name string) (interface{}, bool) {
+	if ctx.token == nil || ctx.token.Aggregates == nil {
+		return nil, false
+	}
+	value, ok := ctx.token.Aggregates[name]
+	return value, ok
+}
+
+// SetLocal lie une valeur à un nom pour les instructions suivantes de l'action (let).
+//
+// Paramètres:
+//   - name: nom lié
+//   - value: valeur évaluée
+func (ctx *ExecutionContext) SetLocal(name string, value interface{}) {
+	if ctx.locals == nil {
+		ctx.locals = make(map[string]interface{})
+	}
+	ctx.locals[name] = value
+}
+
+// GetLocal récupère une valeur liée par let.
+//
+// Paramètres:
+//   - name: nom lié
+//
+// Retourne:
+//   - interface{}: valeur liée
+//   - bool: false si aucune valeur n'est liée à ce nom
+func (ctx *ExecutionContext) GetLocal(name string) (interface{}, bool) {
+	value, ok := ctx.locals[name]
+	return value, ok
+}
+
+// recordCall enregistre un appel d'action exécuté
+func (ctx *ExecutionContext) recordCall(name string, args []interface{}) {
+	if ctx.calls != nil {
+		*ctx.calls = append(*ctx.calls, ActionCall{Name: name, Args: args})
+	}
+}
+
+// Calls retourne les appels d'action exécutés dans ce contexte, dans l'ordre.
+func (ctx *ExecutionContext) Calls() []ActionCall {
+	if ctx.calls == nil {
+		return nil
+	}
+	return *ctx.calls
+}
+
+// newScope crée un contexte fils pour une branche if.
+// Le fils voit les liaisons courantes, mais ses propres let ne remontent pas au parent.
+func (ctx *ExecutionContext) newScope() *ExecutionContext {
+	child := &ExecutionContext{
+		token:    ctx.token,
+		network:  ctx.network,
+		bindings: ctx.bindings,
+		calls:    ctx.calls,
+	}
+	for name, value := range ctx.locals {
+		child.SetLocal(name, value)
+	}
+	return child
+}
+
 // GetToken retourne le token du contexte d'exécution.
 //
 // Le token contient les faits déclencheurs et les bindings.
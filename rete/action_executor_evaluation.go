@@ -21,6 +21,9 @@ import (
 //   - factModification : modification d'un fait existant
 //   - binaryOperation : opération arithmétique ou logique
 //   - cast : conversion de type explicite
+//   - functionCall : fonction intégrée (LENGTH accepte aussi une liste COLLECT)
+//   - ternary : expression conditionnelle (condition ? then : else)
+//   - Valeurs liées par let dans l'action
 //
 // Paramètres :
 //   - arg : argument à évaluer (structure du parser)
@@ -63,6 +66,18 @@ func (ae *ActionExecutor) evaluateArgument(arg interface{}, ctx *ExecutionContex
 		}
 		fact := ctx.GetVariable(varName)
 		if fact == nil {
+			// Valeur liée par let
+			if value, ok := ctx.GetLocal(varName); ok {
+				return value, nil
+			}
+			// Liste liée par COLLECT
+			if collection, ok := ctx.GetCollection(varName); ok {
+				return collection, nil
+			}
+			// Résultat d'une variable d'agrégation
+			if value, ok := ctx.GetAggregate(varName); ok {
+				return value, nil
+			}
 			// Message d'erreur détaillé avec liste des variables disponibles
 			availableVars := []string{}
 			if ctx.bindings != nil {
@@ -90,6 +105,10 @@ func (ae *ActionExecutor) evaluateArgument(arg interface{}, ctx *ExecutionContex
 		}
 
 		fact := ctx.GetVariable(objectName)
+		if fact == nil {
+			// Fait lié par let
+			fact, _ = localFact(ctx, objectName)
+		}
 		if fact == nil {
 			// Message d'erreur détaillé avec liste des variables disponibles
 			availableVars := []string{}
@@ -139,11 +158,109 @@ func (ae *ActionExecutor) evaluateArgument(arg interface{}, ctx *ExecutionContex
 		// Expression de cast
 		return ae.evaluateCastExpression(argMap, ctx)
 
+	case "functionCall":
+		// Fonction intégrée (LENGTH, UPPER, ...)
+		return ae.evaluateFunctionCall(argMap, ctx)
+
+	case "ternary":
+		// Expression conditionnelle (condition ? then : else)
+		return ae.evaluateTernary(argMap, ctx)
+
 	default:
 		return arg, nil
 	}
 }
 
+// evaluateFunctionCall évalue un appel de fonction intégrée.
+//
+// Les arguments sont évalués dans le contexte d'exécution puis la fonction est
+// déléguée à l'évaluateur de conditions, qui partage ainsi la même bibliothèque.
+//
+// Paramètres :
+//   - argMap : map contenant "name" et "args"
+//   - ctx : contexte d'exécution
+//
+// Retourne :
+//   - interface{} : résultat de la fonction
+//   - error : erreur si l'évaluation échoue
+func (ae *ActionExecutor) evaluateFunctionCall(argMap map[string]interface{}, ctx *ExecutionContext) (interface{}, error) {
+	name, ok := argMap["name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("nom de fonction invalide")
+	}
+
+	rawArgs, _ := argMap["args"].([]interface{})
+	args := make([]interface{}, len(rawArgs))
+	for i, rawArg := range rawArgs {
+		value, err := ae.evaluateArgument(rawArg, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("erreur évaluation argument[%d] pour %s: %w", i, name, err)
+		}
+		args[i] = value
+	}
+
+	return NewAlphaConditionEvaluator().callFunction(name, args)
+}
+
+// evaluateTernary évalue une expression conditionnelle.
+//
+// Seule la branche retenue est évaluée.
+//
+// Paramètres :
+//   - argMap : map contenant "condition", "then" et "else"
+//   - ctx : contexte d'exécution
+//
+// Retourne :
+//   - interface{} : valeur de la branche retenue
+//   - error : erreur si l'évaluation échoue
+func (ae *ActionExecutor) evaluateTernary(argMap map[string]interface{}, ctx *ExecutionContext) (interface{}, error) {
+	holds, err := ae.evaluateCondition(argMap["condition"], ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur évaluation condition ternaire: %w", err)
+	}
+	if holds {
+		return ae.evaluateArgument(argMap["then"], ctx)
+	}
+	return ae.evaluateArgument(argMap["else"], ctx)
+}
+
+// evaluateCondition évalue une condition (if ou ternaire) dans le contexte d'exécution.
+//
+// La condition est déléguée à l'évaluateur de conditions, lié aux faits du token,
+// aux listes COLLECT et aux valeurs liées par let.
+//
+// Paramètres :
+//   - condition : condition parsée (comparaison, expression logique, NOT)
+//   - ctx : contexte d'exécution
+//
+// Retourne :
+//   - bool : résultat de la condition
+//   - error : erreur si l'évaluation échoue
+func (ae *ActionExecutor) evaluateCondition(condition interface{}, ctx *ExecutionContext) (bool, error) {
+	evaluator := NewAlphaConditionEvaluator()
+	if ctx.token != nil {
+		evaluator = newTokenEvaluator(ctx.token)
+	}
+	for name := range ctx.locals {
+		if fact, ok := localFact(ctx, name); ok {
+			evaluator.variableBindings[name] = fact
+		} else {
+			evaluator.valueBindings[name] = ctx.locals[name]
+		}
+	}
+	return evaluator.evaluateExpression(condition)
+}
+
+// localFact retourne le fait lié par let à un nom, s'il s'agit d'un fait
+func localFact(ctx *ExecutionContext, name string) (*Fact, bool) {
+	value, ok := ctx.GetLocal(name)
+	if !ok {
+		return nil, false
+	}
+	fact, isFact := value.(*Fact)
+	return fact, isFact
+}
+
 // evaluateArithmetic évalue une expression arithmétique (format legacy).
 //
 // Format legacy supporté pour compatibilité avec ancien code.
@@ -1711,3 +1711,28 @@ func TestActionExecutor_ValidateFactFields_Coverage(t *testing.T) {
 		})
 	}
 }
+
+// callRecorder conserve les résultats transmis à l'observateur
+type callRecorder []ExecutionResult
+
+func (r *callRecorder) OnActionExecuted(result ExecutionResult) {
+	*r = append(*r, result)
+}
+
+func TestExecutionResult_Calls(t *testing.T) {
+	network := buildGraphNetwork(t, eventProgram)
+	results := &callRecorder{}
+	network.SetActionObserver(results)
+
+	_, err := network.ApplyFactEvent(FactEvent{Op: FactOperationInsert, Type: "Customer", Fields: map[string]interface{}{"id": "c1", "tier": "gold"}})
+	require.NoError(t, err)
+	_, err = network.ApplyFactEvent(FactEvent{Op: FactOperationInsert, Type: "Order", Fields: map[string]interface{}{"id": "o1", "customer": "c1", "total": 250.0}})
+	require.NoError(t, err)
+
+	require.Len(t, *results, 1)
+	result := (*results)[0]
+	require.Len(t, result.Calls, 1)
+	assert.Equal(t, "notify", result.Calls[0].Name)
+	assert.Equal(t, []interface{}{"o1"}, result.Calls[0].Args)
+	assert.Equal(t, result.Calls[0].Args, result.Arguments)
+}
@@ -40,7 +40,14 @@ type ExecutionResult struct {
 	Error     error         // erreur si l'exécution a échoué
 	Duration  time.Duration // durée d'exécution
 	Context   ActionContext // contexte d'exécution complet
-	Arguments []interface{} // arguments évalués
+	Arguments []interface{} // arguments évalués du premier appel
+	Calls     []ActionCall  // appels exécutés, dans l'ordre (plusieurs actions, branches if)
+}
+
+// ActionCall décrit un appel d'action exécuté avec ses arguments évalués.
+type ActionCall struct {
+	Name string        // Nom de l'action (ex: "Xuple", "notify")
+	Args []interface{} // Arguments évalués ; un fait est passé en *Fact
 }
 
 // ActionContext contient le contexte d'exécution d'une action.
@@ -60,3 +67,27 @@ type NoOpObserver struct{}
 func (n *NoOpObserver) OnActionExecuted(result ExecutionResult) {
 	// Intentionally empty
 }
+
+// CompositeObserver diffuse chaque exécution à plusieurs observateurs.
+type CompositeObserver struct {
+	observers []ActionObserver
+}
+
+// NewCompositeObserver crée un observateur qui notifie tous les observateurs
+// donnés, dans l'ordre. Les observateurs nil sont ignorés.
+func NewCompositeObserver(observers ...ActionObserver) *CompositeObserver {
+	composite := &CompositeObserver{}
+	for _, observer := range observers {
+		if observer != nil {
+			composite.observers = append(composite.observers, observer)
+		}
+	}
+	return composite
+}
+
+// OnActionExecuted notifie chaque observateur enregistré.
+func (c *CompositeObserver) OnActionExecuted(result ExecutionResult) {
+	for _, observer := range c.observers {
+		observer.OnActionExecuted(result)
+	}
+}
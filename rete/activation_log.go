@@ -0,0 +1,273 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultActivationLogSize est le nombre d'activations de règles conservées par réseau
+const DefaultActivationLogSize = 1000
+
+// ActivationRecord décrit une activation de règle : les faits liés à ses
+// variables et les faits que son action a soumis au réseau.
+type ActivationRecord struct {
+	Sequence   uint64                 `json:"sequence"`             // Numéro d'ordre de l'activation dans le réseau
+	Rule       string                 `json:"rule"`                 // Règle activée
+	Action     string                 `json:"action"`               // Première action exécutée
+	Time       time.Time              `json:"time"`                 // Début de l'exécution de l'action
+	Bindings   map[string]string      `json:"bindings"`             // Variable → identifiant interne du fait lié
+	Aggregates map[string]interface{} `json:"aggregates,omitempty"` // Résultats d'agrégation liés (optionnel)
+	Produced   []string               `json:"produced,omitempty"`   // Faits soumis par l'action (identifiants internes)
+	Error      string                 `json:"error,omitempty"`      // Erreur de l'action, vide si elle a réussi
+}
+
+// Involves indique si l'activation lie tous les faits donnés (identifiants internes)
+func (r *ActivationRecord) Involves(factIDs ...string) bool {
+	for _, factID := range factIDs {
+		found := false
+		for _, bound := range r.Bindings {
+			if bound == factID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// clone copie l'enregistrement, indépendamment du journal
+func (r *ActivationRecord) clone() ActivationRecord {
+	copied := *r
+	copied.Bindings = make(map[string]string, len(r.Bindings))
+	for variable, factID := range r.Bindings {
+		copied.Bindings[variable] = factID
+	}
+	if r.Aggregates != nil {
+		copied.Aggregates = make(map[string]interface{}, len(r.Aggregates))
+		for name, value := range r.Aggregates {
+			copied.Aggregates[name] = value
+		}
+	}
+	copied.Produced = append([]string(nil), r.Produced...)
+	return copied
+}
+
+// activationLog conserve les dernières activations d'un réseau et l'activation
+// à l'origine de chaque fait soumis par une action.
+//
+// Comme pour le traçage, la propagation étant synchrone, un fait soumis pendant
+// l'exécution d'une action est attribué à l'activation ouverte en dernier.
+type activationLog struct {
+	mutex     sync.Mutex
+	size      int
+	records   []*ActivationRecord
+	sequence  uint64
+	running   []*ActivationRecord
+	producers map[string]*ActivationRecord
+}
+
+// newActivationLog crée un journal de taille donnée (0 : désactivé)
+func newActivationLog(size int) *activationLog {
+	return &activationLog{size: size, producers: make(map[string]*ActivationRecord)}
+}
+
+// begin ouvre l'enregistrement d'une activation (nil si le journal est désactivé)
+func (l *activationLog) begin(rule, action string, token *Token) *ActivationRecord {
+	if l == nil {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.size <= 0 {
+		return nil
+	}
+
+	l.sequence++
+	record := &ActivationRecord{
+		Sequence: l.sequence,
+		Rule:     rule,
+		Action:   action,
+		Time:     time.Now(),
+		Bindings: make(map[string]string),
+	}
+	if token != nil {
+		if token.Bindings != nil {
+			for variable, fact := range token.Bindings.ToMap() {
+				if fact != nil {
+					record.Bindings[variable] = fact.GetInternalID()
+				}
+			}
+		}
+		if len(token.Aggregates) > 0 {
+			record.Aggregates = make(map[string]interface{}, len(token.Aggregates))
+			for name, value := range token.Aggregates {
+				record.Aggregates[name] = value
+			}
+		}
+	}
+
+	l.records = append(l.records, record)
+	if len(l.records) > l.size {
+		l.evict(l.records[0])
+		l.records = l.records[1:]
+	}
+	l.running = append(l.running, record)
+	return record
+}
+
+// end ferme l'enregistrement d'une activation
+func (l *activationLog) end(record *ActivationRecord, err error) {
+	if l == nil || record == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if err != nil {
+		record.Error = err.Error()
+	}
+	for i := len(l.running) - 1; i >= 0; i-- {
+		if l.running[i] == record {
+			l.running = l.running[:i]
+			break
+		}
+	}
+}
+
+// produced attribue un fait soumis à l'activation en cours, s'il y en a une
+func (l *activationLog) produced(factID string) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(l.running) == 0 {
+		return
+	}
+	record := l.running[len(l.running)-1]
+	record.Produced = append(record.Produced, factID)
+	l.producers[factID] = record
+}
+
+// evict oublie les faits produits par un enregistrement sorti du journal
+func (l *activationLog) evict(record *ActivationRecord) {
+	for _, factID := range record.Produced {
+		if l.producers[factID] == record {
+			delete(l.producers, factID)
+		}
+	}
+}
+
+// mark retourne le numéro de la dernière activation journalisée
+func (l *activationLog) mark() uint64 {
+	if l == nil {
+		return 0
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.sequence
+}
+
+// truncate oublie les activations journalisées après mark (annulation d'une
+// transaction) et l'origine des faits qu'elles avaient soumis
+func (l *activationLog) truncate(mark uint64) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.sequence <= mark {
+		return
+	}
+	kept := len(l.records)
+	for kept > 0 && l.records[kept-1].Sequence > mark {
+		kept--
+	}
+	l.records = l.records[:kept]
+	l.sequence = mark
+
+	// Un fait soumis à nouveau par une activation oubliée retrouve son
+	// activation d'origine, si elle est encore journalisée
+	l.producers = make(map[string]*ActivationRecord)
+	for _, record := range l.records {
+		for _, factID := range record.Produced {
+			l.producers[factID] = record
+		}
+	}
+}
+
+// resize change la taille du journal, en oubliant les activations les plus anciennes
+func (l *activationLog) resize(size int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.size = size
+	for len(l.records) > 0 && len(l.records) > size {
+		l.evict(l.records[0])
+		l.records = l.records[1:]
+	}
+}
+
+// find retourne des copies des activations d'une règle liant tous les faits donnés
+func (l *activationLog) find(rule string, factIDs []string) []ActivationRecord {
+	if l == nil {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var found []ActivationRecord
+	for _, record := range l.records {
+		if record.Rule == rule && record.Involves(factIDs...) {
+			found = append(found, record.clone())
+		}
+	}
+	return found
+}
+
+// producerOf retourne une copie de l'activation qui a soumis un fait
+func (l *activationLog) producerOf(factID string) (ActivationRecord, bool) {
+	if l == nil {
+		return ActivationRecord{}, false
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	record, exists := l.producers[factID]
+	if !exists {
+		return ActivationRecord{}, false
+	}
+	return record.clone(), true
+}
+
+// SetActivationLogSize fixe le nombre d'activations conservées pour Explain
+// (0 désactive le journal des activations)
+func (rn *ReteNetwork) SetActivationLogSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	if rn.activations == nil {
+		rn.activations = newActivationLog(size)
+		return
+	}
+	rn.activations.resize(size)
+}
+
+// Activations retourne les activations conservées d'une règle qui lient tous
+// les faits donnés (identifiants internes), de la plus ancienne à la plus récente
+func (rn *ReteNetwork) Activations(ruleID string, factIDs ...string) []ActivationRecord {
+	records := rn.activations.find(ruleID, factIDs)
+	sort.Slice(records, func(i, j int) bool { return records[i].Sequence < records[j].Sequence })
+	return records
+}
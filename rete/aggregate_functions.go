@@ -0,0 +1,443 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Fonctions d'agrégation supportées
+const (
+	AggregateCount         = "COUNT"
+	AggregateSum           = "SUM"
+	AggregateAvg           = "AVG"
+	AggregateMin           = "MIN"
+	AggregateMax           = "MAX"
+	AggregateCountDistinct = "COUNT_DISTINCT"
+	AggregateMedian        = "MEDIAN"
+	AggregatePercentile    = "PERCENTILE"
+	AggregateStddev        = "STDDEV"
+	AggregateFirst         = "FIRST"
+	AggregateLast          = "LAST"
+	AggregateStringAgg     = "STRING_AGG"
+	AggregateCollectSet    = "COLLECT_SET"
+)
+
+// AggregateFunctions liste les fonctions d'agrégation supportées
+var AggregateFunctions = []string{
+	AggregateCount, AggregateSum, AggregateAvg, AggregateMin, AggregateMax,
+	AggregateCountDistinct, AggregateMedian, AggregatePercentile, AggregateStddev,
+	AggregateFirst, AggregateLast, AggregateStringAgg, AggregateCollectSet,
+}
+
+// DefaultStringAggSeparator est le séparateur de STRING_AGG quand aucun n'est fourni
+const DefaultStringAggSeparator = ","
+
+// AggregateSpec décrit une fonction d'agrégation et ses paramètres.
+type AggregateSpec struct {
+	Function   string  // Nom de la fonction (COUNT, PERCENTILE, ...)
+	Field      string  // Champ agrégé (ignoré par COUNT)
+	OrderField string  // Champ d'ordre de FIRST et LAST
+	Percentile float64 // Rang de PERCENTILE, entre 0 et 100
+	Separator  string  // Séparateur de STRING_AGG
+}
+
+// AggregateState maintient le résultat d'une agrégation au fil des insertions et rétractations.
+//
+// Add et Remove sont idempotents par fait (identifiant interne) : un fait déjà
+// ajouté n'est pas compté deux fois, un fait inconnu est ignoré au retrait.
+type AggregateState interface {
+	Add(fact *Fact)
+	Remove(fact *Fact)
+	Result() interface{}
+	Len() int
+}
+
+// IsNumericAggregate indique si une fonction produit toujours un nombre
+func IsNumericAggregate(function string) bool {
+	switch function {
+	case AggregateFirst, AggregateLast, AggregateStringAgg, AggregateCollectSet:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsSupportedAggregate indique si une fonction d'agrégation est connue
+func IsSupportedAggregate(function string) bool {
+	switch function {
+	case AggregateCount, AggregateSum, AggregateAvg, AggregateMin, AggregateMax,
+		AggregateCountDistinct, AggregateMedian, AggregatePercentile, AggregateStddev,
+		AggregateFirst, AggregateLast, AggregateStringAgg, AggregateCollectSet:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewAggregateState crée l'état incrémental d'une fonction d'agrégation.
+//
+// COUNT, SUM, AVG et STDDEV sont maintenus en O(1) par mise à jour ; MIN, MAX,
+// MEDIAN et PERCENTILE conservent les valeurs triées ; COUNT_DISTINCT et
+// COLLECT_SET comptent les occurrences de chaque valeur. FIRST, LAST et
+// STRING_AGG conservent les contributions et calculent le résultat à la demande.
+func NewAggregateState(spec AggregateSpec) (AggregateState, error) {
+	switch spec.Function {
+	case AggregateCount, AggregateSum, AggregateAvg, AggregateStddev:
+		return &momentState{spec: spec, values: make(map[string]float64)}, nil
+	case AggregateMin, AggregateMax, AggregateMedian, AggregatePercentile:
+		if spec.Function == AggregatePercentile && (spec.Percentile < 0 || spec.Percentile > 100) {
+			return nil, fmt.Errorf("PERCENTILE: rang %v hors de [0, 100]", spec.Percentile)
+		}
+		return &sortedState{spec: spec, values: make(map[string]float64)}, nil
+	case AggregateCountDistinct, AggregateCollectSet:
+		return &distinctState{spec: spec, values: make(map[string]interface{}), counts: make(map[interface{}]int)}, nil
+	case AggregateFirst, AggregateLast:
+		if spec.OrderField == "" {
+			return nil, fmt.Errorf("%s nécessite un champ d'ordre", spec.Function)
+		}
+		return &orderedState{spec: spec, entries: make(map[string]orderedEntry)}, nil
+	case AggregateStringAgg:
+		if spec.Separator == "" {
+			spec.Separator = DefaultStringAggSeparator
+		}
+		return &stringAggState{spec: spec, entries: make(map[string]stringAggEntry)}, nil
+	default:
+		return nil, fmt.Errorf("fonction d'agrégation non supportée: %s", spec.Function)
+	}
+}
+
+// ComputeAggregate calcule une agrégation sur une liste de faits
+func ComputeAggregate(spec AggregateSpec, facts []*Fact) (interface{}, error) {
+	state, err := NewAggregateState(spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, fact := range facts {
+		state.Add(fact)
+	}
+	return state.Result(), nil
+}
+
+// aggregateNumber retourne la valeur numérique d'un champ, si elle existe
+func aggregateNumber(fact *Fact, field string) (float64, bool) {
+	value, exists := fact.Fields[field]
+	if !exists {
+		return 0, false
+	}
+	switch n := value.(type) {
+	case float32:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return toNumber(value)
+}
+
+// momentState maintient le nombre, la somme et la somme des carrés (COUNT, SUM, AVG, STDDEV)
+type momentState struct {
+	spec   AggregateSpec
+	values map[string]float64
+	sum    float64
+	sumSq  float64
+}
+
+func (s *momentState) Add(fact *Fact) {
+	id := fact.GetInternalID()
+	if _, exists := s.values[id]; exists {
+		return
+	}
+	value := 1.0
+	if s.spec.Function != AggregateCount {
+		number, ok := aggregateNumber(fact, s.spec.Field)
+		if !ok {
+			return
+		}
+		value = number
+	}
+	s.values[id] = value
+	s.sum += value
+	s.sumSq += value * value
+}
+
+func (s *momentState) Remove(fact *Fact) {
+	id := fact.GetInternalID()
+	value, exists := s.values[id]
+	if !exists {
+		return
+	}
+	delete(s.values, id)
+	s.sum -= value
+	s.sumSq -= value * value
+}
+
+func (s *momentState) Len() int { return len(s.values) }
+
+func (s *momentState) Result() interface{} {
+	n := float64(len(s.values))
+	switch s.spec.Function {
+	case AggregateCount:
+		return n
+	case AggregateSum:
+		return s.sum
+	case AggregateAvg:
+		if n == 0 {
+			return 0.0
+		}
+		return s.sum / n
+	default: // STDDEV : écart type d'échantillon
+		if n < 2 {
+			return 0.0
+		}
+		variance := (s.sumSq - s.sum*s.sum/n) / (n - 1)
+		if variance < 0 {
+			variance = 0
+		}
+		return math.Sqrt(variance)
+	}
+}
+
+// sortedState conserve les valeurs triées (MIN, MAX, MEDIAN, PERCENTILE)
+type sortedState struct {
+	spec   AggregateSpec
+	values map[string]float64
+	sorted []float64
+}
+
+func (s *sortedState) Add(fact *Fact) {
+	id := fact.GetInternalID()
+	if _, exists := s.values[id]; exists {
+		return
+	}
+	value, ok := aggregateNumber(fact, s.spec.Field)
+	if !ok {
+		return
+	}
+	s.values[id] = value
+	i := sort.SearchFloat64s(s.sorted, value)
+	s.sorted = append(s.sorted, 0)
+	copy(s.sorted[i+1:], s.sorted[i:])
+	s.sorted[i] = value
+}
+
+func (s *sortedState) Remove(fact *Fact) {
+	id := fact.GetInternalID()
+	value, exists := s.values[id]
+	if !exists {
+		return
+	}
+	delete(s.values, id)
+	i := sort.SearchFloat64s(s.sorted, value)
+	s.sorted = append(s.sorted[:i], s.sorted[i+1:]...)
+}
+
+func (s *sortedState) Len() int { return len(s.sorted) }
+
+func (s *sortedState) Result() interface{} {
+	n := len(s.sorted)
+	if n == 0 {
+		return 0.0
+	}
+	switch s.spec.Function {
+	case AggregateMin:
+		return s.sorted[0]
+	case AggregateMax:
+		return s.sorted[n-1]
+	case AggregateMedian:
+		return interpolatePercentile(s.sorted, 50)
+	default:
+		return interpolatePercentile(s.sorted, s.spec.Percentile)
+	}
+}
+
+// interpolatePercentile calcule un percentile par interpolation linéaire entre les rangs voisins
+func interpolatePercentile(sorted []float64, percentile float64) float64 {
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// distinctState compte les occurrences de chaque valeur (COUNT_DISTINCT, COLLECT_SET)
+type distinctState struct {
+	spec   AggregateSpec
+	values map[string]interface{}
+	counts map[interface{}]int
+}
+
+func (s *distinctState) Add(fact *Fact) {
+	id := fact.GetInternalID()
+	if _, exists := s.values[id]; exists {
+		return
+	}
+	value, ok := fact.Fields[s.spec.Field]
+	if !ok {
+		return
+	}
+	key := distinctKey(value)
+	s.values[id] = key
+	s.counts[key]++
+}
+
+func (s *distinctState) Remove(fact *Fact) {
+	id := fact.GetInternalID()
+	key, exists := s.values[id]
+	if !exists {
+		return
+	}
+	delete(s.values, id)
+	if s.counts[key]--; s.counts[key] <= 0 {
+		delete(s.counts, key)
+	}
+}
+
+func (s *distinctState) Len() int { return len(s.values) }
+
+func (s *distinctState) Result() interface{} {
+	if s.spec.Function == AggregateCountDistinct {
+		return float64(len(s.counts))
+	}
+	set := make([]interface{}, 0, len(s.counts))
+	for value := range s.counts {
+		set = append(set, value)
+	}
+	sort.Slice(set, func(i, j int) bool { return lessValue(set[i], set[j]) })
+	return set
+}
+
+// distinctKey normalise une valeur pour la comparaison (1 et 1.0 sont la même valeur)
+func distinctKey(value interface{}) interface{} {
+	if number, ok := toNumber(value); ok {
+		return number
+	}
+	switch value.(type) {
+	case string, bool:
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// lessValue ordonne des valeurs hétérogènes : nombres, puis chaînes et autres valeurs
+func lessValue(a, b interface{}) bool {
+	an, aIsNumber := a.(float64)
+	bn, bIsNumber := b.(float64)
+	switch {
+	case aIsNumber && bIsNumber:
+		return an < bn
+	case aIsNumber != bIsNumber:
+		return aIsNumber
+	default:
+		return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+	}
+}
+
+// orderedEntry est la contribution d'un fait à FIRST ou LAST
+type orderedEntry struct {
+	order interface{}
+	value interface{}
+}
+
+// orderedState retient la valeur du fait au plus petit (FIRST) ou plus grand (LAST) champ d'ordre
+type orderedState struct {
+	spec    AggregateSpec
+	entries map[string]orderedEntry
+}
+
+func (s *orderedState) Add(fact *Fact) {
+	order, ok := fact.Fields[s.spec.OrderField]
+	if !ok {
+		return
+	}
+	s.entries[fact.GetInternalID()] = orderedEntry{order: distinctKey(order), value: fact.Fields[s.spec.Field]}
+}
+
+func (s *orderedState) Remove(fact *Fact) {
+	delete(s.entries, fact.GetInternalID())
+}
+
+func (s *orderedState) Len() int { return len(s.entries) }
+
+func (s *orderedState) Result() interface{} {
+	var best *orderedEntry
+	var bestID string
+	for id, entry := range s.entries {
+		entry := entry
+		if best == nil || s.better(entry, id, *best, bestID) {
+			best, bestID = &entry, id
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.value
+}
+
+// better indique si une contribution l'emporte ; l'identifiant départage les égalités
+func (s *orderedState) better(candidate orderedEntry, candidateID string, current orderedEntry, currentID string) bool {
+	if lessValue(candidate.order, current.order) {
+		return s.spec.Function == AggregateFirst
+	}
+	if lessValue(current.order, candidate.order) {
+		return s.spec.Function == AggregateLast
+	}
+	return candidateID < currentID
+}
+
+// stringAggEntry est la contribution d'un fait à STRING_AGG
+type stringAggEntry struct {
+	seq   int
+	value string
+}
+
+// stringAggState concatène les valeurs dans l'ordre d'insertion des faits
+type stringAggState struct {
+	spec    AggregateSpec
+	entries map[string]stringAggEntry
+	nextSeq int
+}
+
+func (s *stringAggState) Add(fact *Fact) {
+	id := fact.GetInternalID()
+	if _, exists := s.entries[id]; exists {
+		return
+	}
+	value, ok := fact.Fields[s.spec.Field]
+	if !ok {
+		return
+	}
+	s.entries[id] = stringAggEntry{seq: s.nextSeq, value: fmt.Sprintf("%v", value)}
+	s.nextSeq++
+}
+
+func (s *stringAggState) Remove(fact *Fact) {
+	delete(s.entries, fact.GetInternalID())
+}
+
+func (s *stringAggState) Len() int { return len(s.entries) }
+
+func (s *stringAggState) Result() interface{} {
+	entries := make([]stringAggEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+
+	parts := make([]string, len(entries))
+	for i, entry := range entries {
+		parts[i] = entry.value
+	}
+	return strings.Join(parts, s.spec.Separator)
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func scoreFact(id string, score interface{}, day float64, tag string) *Fact {
+	return &Fact{
+		ID:     id,
+		Type:   "Review",
+		Fields: map[string]interface{}{"id": id, "score": score, "day": day, "tag": tag},
+	}
+}
+
+func TestAggregateState_Results(t *testing.T) {
+	facts := []*Fact{
+		scoreFact("r1", 4, 3, "b"),
+		scoreFact("r2", 8.0, 1, "a"),
+		scoreFact("r3", 6, 2, "b"),
+		scoreFact("r4", 2, 4, "c"),
+	}
+
+	tests := []struct {
+		spec AggregateSpec
+		want interface{}
+	}{
+		{AggregateSpec{Function: AggregateCount}, 4.0},
+		{AggregateSpec{Function: AggregateSum, Field: "score"}, 20.0},
+		{AggregateSpec{Function: AggregateAvg, Field: "score"}, 5.0},
+		{AggregateSpec{Function: AggregateMin, Field: "score"}, 2.0},
+		{AggregateSpec{Function: AggregateMax, Field: "score"}, 8.0},
+		{AggregateSpec{Function: AggregateMedian, Field: "score"}, 5.0},
+		{AggregateSpec{Function: AggregatePercentile, Field: "score", Percentile: 90}, 7.4},
+		{AggregateSpec{Function: AggregateStddev, Field: "score"}, math.Sqrt(20.0 / 3)},
+		{AggregateSpec{Function: AggregateCountDistinct, Field: "tag"}, 3.0},
+		{AggregateSpec{Function: AggregateFirst, Field: "tag", OrderField: "day"}, "a"},
+		{AggregateSpec{Function: AggregateLast, Field: "score", OrderField: "day"}, 2},
+		{AggregateSpec{Function: AggregateStringAgg, Field: "tag", Separator: "|"}, "b|a|b|c"},
+		{AggregateSpec{Function: AggregateCollectSet, Field: "tag"}, []interface{}{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec.Function, func(t *testing.T) {
+			got, err := ComputeAggregate(tt.spec, facts)
+			if err != nil {
+				t.Fatalf("ComputeAggregate() error = %v", err)
+			}
+			if number, ok := got.(float64); ok {
+				if want, ok := tt.want.(float64); ok && math.Abs(number-want) < 1e-9 {
+					return
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ComputeAggregate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateState_Empty(t *testing.T) {
+	tests := []struct {
+		spec AggregateSpec
+		want interface{}
+	}{
+		{AggregateSpec{Function: AggregateMedian, Field: "score"}, 0.0},
+		{AggregateSpec{Function: AggregateStddev, Field: "score"}, 0.0},
+		{AggregateSpec{Function: AggregateFirst, Field: "score", OrderField: "day"}, nil},
+		{AggregateSpec{Function: AggregateStringAgg, Field: "tag"}, ""},
+		{AggregateSpec{Function: AggregateCollectSet, Field: "tag"}, []interface{}{}},
+	}
+	for _, tt := range tests {
+		got, err := ComputeAggregate(tt.spec, nil)
+		if err != nil {
+			t.Fatalf("%s: error = %v", tt.spec.Function, err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.spec.Function, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateState_IncrementalRemove(t *testing.T) {
+	r1, r2, r3 := scoreFact("r1", 4, 3, "x"), scoreFact("r2", 8, 1, "y"), scoreFact("r3", 6, 2, "x")
+
+	specs := []AggregateSpec{
+		{Function: AggregateSum, Field: "score"},
+		{Function: AggregateMedian, Field: "score"},
+		{Function: AggregateStddev, Field: "score"},
+		{Function: AggregateCountDistinct, Field: "tag"},
+		{Function: AggregateFirst, Field: "tag", OrderField: "day"},
+		{Function: AggregateStringAgg, Field: "tag"},
+	}
+	for _, spec := range specs {
+		t.Run(spec.Function, func(t *testing.T) {
+			state, err := NewAggregateState(spec)
+			if err != nil {
+				t.Fatalf("NewAggregateState() error = %v", err)
+			}
+			state.Add(r1)
+			state.Add(r2)
+			state.Add(r2) // ignoré : déjà agrégé
+			state.Add(r3)
+			state.Remove(r2)
+			state.Remove(r2) // ignoré : déjà retiré
+
+			want, _ := ComputeAggregate(spec, []*Fact{r1, r3})
+			if fmt.Sprint(state.Result()) != fmt.Sprint(want) {
+				t.Errorf("after remove = %v, want %v", state.Result(), want)
+			}
+			if state.Len() != 2 {
+				t.Errorf("Len() = %d, want 2", state.Len())
+			}
+		})
+	}
+}
+
+func TestNewAggregateState_InvalidSpec(t *testing.T) {
+	invalid := []AggregateSpec{
+		{Function: "MODE", Field: "score"},
+		{Function: AggregatePercentile, Field: "score", Percentile: 120},
+		{Function: AggregateLast, Field: "score"},
+	}
+	for _, spec := range invalid {
+		if _, err := NewAggregateState(spec); err == nil {
+			t.Errorf("NewAggregateState(%+v) expected error", spec)
+		}
+	}
+}
+
+func TestExtractAggregationArguments(t *testing.T) {
+	number := func(v float64) interface{} { return map[string]interface{}{"type": "number", "value": v} }
+	field := func(object, name string) interface{} {
+		return map[string]interface{}{"type": "fieldAccess", "object": object, "field": name}
+	}
+
+	spec, err := extractAggregationArguments(AggregatePercentile, "r", []interface{}{number(95)})
+	if err != nil || spec.Percentile != 95 {
+		t.Errorf("PERCENTILE = %+v, %v", spec, err)
+	}
+	spec, err = extractAggregationArguments(AggregateFirst, "r", []interface{}{field("r", "day")})
+	if err != nil || spec.OrderField != "day" {
+		t.Errorf("FIRST = %+v, %v", spec, err)
+	}
+	spec, err = extractAggregationArguments(AggregateStringAgg, "r", nil)
+	if err != nil || spec.Separator != DefaultStringAggSeparator {
+		t.Errorf("STRING_AGG = %+v, %v", spec, err)
+	}
+
+	failures := []struct {
+		function string
+		args     []interface{}
+	}{
+		{AggregatePercentile, nil},
+		{AggregatePercentile, []interface{}{number(101)}},
+		{AggregateFirst, nil},
+		{AggregateLast, []interface{}{field("other", "day")}},
+		{AggregateSum, []interface{}{number(1)}},
+		{AggregateStringAgg, []interface{}{number(1)}},
+	}
+	for _, f := range failures {
+		if _, err := extractAggregationArguments(f.function, "r", f.args); err == nil {
+			t.Errorf("%s%v expected error", f.function, f.args)
+		}
+	}
+}
+
+const extendedAggregatesProgram = `type Product(#id: string)
+type Review(#id: string, product: string, score: number, day: number, author: string)
+action stats(product: string, median: number, p90: number, authors: number)
+action latest(product: string, author: string, names: string)
+
+rule review_stats : {p: Product, med: MEDIAN(r.score), p90: PERCENTILE(r.score, 90), authors: COUNT_DISTINCT(r.author)} / {r: Review} / r.product == p.id ==> stats(p.id, med, p90, authors)
+rule latest_review : {p: Product, last: LAST(r.author, r.day)} / {r: Review} / r.product == p.id ==> latest(p.id, last, "")
+
+Product(id: "p1")
+Review(id: "r1", product: "p1", score: 3, day: 1, author: "ann")
+Review(id: "r2", product: "p1", score: 5, day: 3, author: "bob")
+Review(id: "r3", product: "p1", score: 4, day: 2, author: "ann")
+`
+
+func TestExtendedAggregates_Integration(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+
+	var mutex sync.Mutex
+	var calls []string
+	for _, name := range []string{"stats", "latest"} {
+		handler := &recordingActionHandler{name: name, mutex: &mutex, calls: &calls}
+		if err := network.ActionExecutor.RegisterAction(handler); err != nil {
+			t.Fatalf("RegisterAction(%s) error = %v", name, err)
+		}
+	}
+
+	network = ingestQueryProgram(t, network, storage, extendedAggregatesProgram)
+
+	// Le dernier déclenchement de chaque règle porte les valeurs finales
+	last := func(prefix string) string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		found := ""
+		for _, call := range calls {
+			if len(call) >= len(prefix) && call[:len(prefix)] == prefix {
+				found = call
+			}
+		}
+		return found
+	}
+	if got, want := last("stats"), "stats[p1 4 4.8 2]"; got != want {
+		t.Errorf("stats = %q, want %q", got, want)
+	}
+	if got, want := last("latest"), "latest[p1 bob ]"; got != want {
+		t.Errorf("latest = %q, want %q", got, want)
+	}
+
+	// La rétractation met à jour l'agrégat sans tout recalculer
+	calls = nil
+	if err := network.RetractFact("Review~r2"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	sorted := append([]string(nil), calls...)
+	sort.Strings(sorted)
+	if got, want := last("latest"), "latest[p1 ann ]"; got != want {
+		t.Errorf("after retract latest = %q, want %q (calls %v)", got, want, sorted)
+	}
+	if got, want := last("stats"), "stats[p1 3.5 3.9 1]"; got != want {
+		t.Errorf("after retract stats = %q, want %q (calls %v)", got, want, sorted)
+	}
+}
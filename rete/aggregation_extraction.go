@@ -190,3 +190,57 @@ func (cp *ConstraintPipeline) extractThresholdConditions(thresholdConditions []m
 
 	return operator, threshold
 }
+
+// extractAggregationArguments interprète les arguments supplémentaires d'une fonction
+// d'agrégation : rang de PERCENTILE, champ d'ordre de FIRST/LAST, séparateur de STRING_AGG.
+// sourceVar est la variable du champ agrégé ; le champ d'ordre doit porter sur la même variable.
+func extractAggregationArguments(function, sourceVar string, argsData interface{}) (AggregateSpec, error) {
+	spec := AggregateSpec{Function: function}
+	args, _ := argsData.([]interface{})
+
+	maxArgs := 0
+	switch function {
+	case AggregatePercentile, AggregateFirst, AggregateLast, AggregateStringAgg:
+		maxArgs = 1
+	}
+	if len(args) > maxArgs {
+		return spec, fmt.Errorf("%s: %d argument(s) supplémentaire(s), %d attendu(s) au plus", function, len(args), maxArgs)
+	}
+
+	var arg map[string]interface{}
+	if len(args) == 1 {
+		arg, _ = args[0].(map[string]interface{})
+	}
+
+	switch function {
+	case AggregatePercentile:
+		value, ok := arg["value"].(float64)
+		if !ok || arg["type"] != "number" {
+			return spec, fmt.Errorf("PERCENTILE nécessite un rang numérique, ex: PERCENTILE(x.score, 95)")
+		}
+		if value < 0 || value > 100 {
+			return spec, fmt.Errorf("rang de PERCENTILE hors de [0, 100]: %v", value)
+		}
+		spec.Percentile = value
+	case AggregateFirst, AggregateLast:
+		object, _ := arg["object"].(string)
+		field, ok := arg["field"].(string)
+		if !ok || arg["type"] != "fieldAccess" {
+			return spec, fmt.Errorf("%s nécessite un champ d'ordre, ex: %s(x.value, x.date)", function, function)
+		}
+		if sourceVar != "" && object != sourceVar {
+			return spec, fmt.Errorf("%s: le champ d'ordre %s.%s doit porter sur la variable %s", function, object, field, sourceVar)
+		}
+		spec.OrderField = field
+	case AggregateStringAgg:
+		spec.Separator = DefaultStringAggSeparator
+		if arg != nil {
+			separator, ok := arg["value"].(string)
+			if !ok || arg["type"] != "string" {
+				return spec, fmt.Errorf("STRING_AGG: le séparateur doit être une chaîne")
+			}
+			spec.Separator = separator
+		}
+	}
+	return spec, nil
+}
@@ -167,7 +167,7 @@ func isPassthroughRightNode(condition interface{}) bool {
 
 // propagateToAlphaChild propage le fait à un enfant AlphaNode
 func propagateToAlphaChild(alphaChild *AlphaNode, fact *Fact, context *EvaluationContext) error {
-	if err := alphaChild.ActivateWithContext(fact, context); err != nil {
+	if err := activateAlphaWithContext(alphaChild, fact, context); err != nil {
 		return fmt.Errorf("error propagating to alpha child %s: %w", alphaChild.GetID(), err)
 	}
 	return nil
@@ -177,7 +177,7 @@ func propagateToAlphaChild(alphaChild *AlphaNode, fact *Fact, context *Evaluatio
 func propagateToNonAlphaChild(an *AlphaNode, child Node, fact *Fact) error {
 	if isPassthroughRightNode(an.Condition) {
 		// Passthrough RIGHT: use ActivateRight for JoinNode
-		if err := child.ActivateRight(fact); err != nil {
+		if err := activateRight(child, fact); err != nil {
 			return fmt.Errorf("error propagating fact to %s: %w", child.GetID(), err)
 		}
 	} else {
@@ -188,7 +188,7 @@ func propagateToNonAlphaChild(an *AlphaNode, child Node, fact *Fact) error {
 			NodeID:   an.ID,
 			Bindings: NewBindingChainWith(an.VariableName, fact),
 		}
-		if err := child.ActivateLeft(token); err != nil {
+		if err := activateLeft(child, token); err != nil {
 			return fmt.Errorf("error propagating token to %s: %w", child.GetID(), err)
 		}
 	}
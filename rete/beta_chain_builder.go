@@ -5,7 +5,6 @@
 package rete
 
 import (
-	"fmt"
 	"sync"
 )
 
@@ -239,7 +238,9 @@ func (bcb *BetaChainBuilder) SetOptimizationEnabled(enabled bool) {
 	bcb.mutex.Lock()
 	defer bcb.mutex.Unlock()
 	bcb.enableOptimization = enabled
-	fmt.Printf("⚙️  [BetaChainBuilder] Optimisation de l'ordre: %v\n", enabled)
+	if bcb.network != nil {
+		bcb.network.GetLogger().Debug("⚙️  [BetaChainBuilder] Optimisation de l'ordre: %v", enabled)
+	}
 }
 
 // SetPrefixSharingEnabled active/désactive le partage de préfixes.
@@ -260,5 +261,7 @@ func (bcb *BetaChainBuilder) SetPrefixSharingEnabled(enabled bool) {
 	bcb.mutex.Lock()
 	defer bcb.mutex.Unlock()
 	bcb.enablePrefixSharing = enabled
-	fmt.Printf("⚙️  [BetaChainBuilder] Partage de préfixes: %v\n", enabled)
+	if bcb.network != nil {
+		bcb.network.GetLogger().Debug("⚙️  [BetaChainBuilder] Partage de préfixes: %v", enabled)
+	}
 }
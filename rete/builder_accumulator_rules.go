@@ -66,6 +66,8 @@ func (arb *AccumulatorRuleBuilder) CreateAccumulatorRule(
 		condition,
 		arb.utils.storage,
 	)
+	accumNode.Spec = aggInfo.Spec()
+	accumNode.ResultVar = aggInfo.ResultVar
 	accumNode.AddChild(terminalNode)
 	network.BetaNodes[accumNode.ID] = accumNode
 
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+)
+
+// CollectRuleBuilder handles the creation of COLLECT rules
+type CollectRuleBuilder struct {
+	utils *BuilderUtils
+}
+
+// NewCollectRuleBuilder creates a new CollectRuleBuilder instance
+func NewCollectRuleBuilder(utils *BuilderUtils) *CollectRuleBuilder {
+	return &CollectRuleBuilder{
+		utils: utils,
+	}
+}
+
+// CreateCollectRule creates a COLLECT rule as a chain of CollectNodes, one per COLLECT variable.
+// The rule constraints are evaluated by the last node, once every list is bound.
+func (crb *CollectRuleBuilder) CreateCollectRule(
+	network *ReteNetwork,
+	ruleID string,
+	exprMap map[string]interface{},
+	action *Action,
+) error {
+	mainVariable, mainVarType, err := crb.ExtractMainVariable(exprMap)
+	if err != nil {
+		return err
+	}
+
+	collects := collectVariables(exprMap)
+	if len(collects) == 0 {
+		return fmt.Errorf("aucune variable COLLECT trouvée pour la règle %s", ruleID)
+	}
+
+	terminalNode := crb.utils.CreateTerminalNode(network, ruleID, action)
+
+	var previous *CollectNode
+	for i, collectVar := range collects {
+		name, _ := collectVar["name"].(string)
+		elementMap, _ := collectVar["variable"].(map[string]interface{})
+		elementVariable, _ := elementMap["name"].(string)
+		elementType, _ := elementMap["dataType"].(string)
+		if name == "" || elementVariable == "" {
+			return fmt.Errorf("variable COLLECT invalide dans la règle %s", ruleID)
+		}
+
+		collectNode := NewCollectNode(ruleID+"_collect_"+name, mainVariable, name, elementVariable, elementType,
+			collectVar["condition"], crb.utils.storage)
+		if i == len(collects)-1 {
+			collectNode.Filter = exprMap["constraints"]
+		}
+		network.BetaNodes[collectNode.ID] = collectNode
+
+		if previous == nil {
+			crb.utils.ConnectTypeNodeToBetaNode(network, ruleID, mainVariable, mainVarType, collectNode, NodeSideLeft)
+		} else {
+			previous.AddChild(collectNode)
+		}
+		crb.utils.ConnectTypeNodeToBetaNode(network, ruleID, elementVariable, elementType, collectNode, NodeSideRight)
+		previous = collectNode
+
+		fmt.Printf("   ✅ CollectNode %s créé pour %s COLLECT %s\n", collectNode.ID, mainVariable, name)
+	}
+
+	previous.AddChild(terminalNode)
+	return nil
+}
+
+// ExtractMainVariable returns the single non-COLLECT variable of a COLLECT rule
+func (crb *CollectRuleBuilder) ExtractMainVariable(exprMap map[string]interface{}) (string, string, error) {
+	setMap, _ := exprMap["set"].(map[string]interface{})
+	varsList, _ := setMap["variables"].([]interface{})
+
+	var name, dataType string
+	count := 0
+	for _, varInterface := range varsList {
+		varMap, ok := varInterface.(map[string]interface{})
+		if !ok || varMap["type"] == "collectVariable" {
+			continue
+		}
+		count++
+		name, _ = varMap["name"].(string)
+		dataType, _ = varMap["dataType"].(string)
+	}
+
+	if count != 1 {
+		return "", "", fmt.Errorf("une règle COLLECT nécessite exactement une variable principale, %d trouvée(s)", count)
+	}
+	return name, dataType, nil
+}
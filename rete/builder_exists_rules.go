@@ -68,6 +68,83 @@ func (erb *ExistsRuleBuilder) CreateExistsRule(
 	return nil
 }
 
+// CreateForallRule creates a FORALL rule on a negated ExistsNode.
+// The FORALL must be a top-level conjunct; the other conjuncts filter the main variable.
+func (erb *ExistsRuleBuilder) CreateForallRule(
+	network *ReteNetwork,
+	ruleID string,
+	exprMap map[string]interface{},
+	action *Action,
+) error {
+	forall, mainCondition, err := erb.ExtractForallConstraint(exprMap)
+	if err != nil {
+		return err
+	}
+
+	mainVariable, mainVarType := firstSetVariable(exprMap)
+	forallVarMap, _ := forall["variable"].(map[string]interface{})
+	forallVariable, _ := forallVarMap["name"].(string)
+	forallVarType, _ := forallVarMap["dataType"].(string)
+	if mainVariable == "" || forallVariable == "" {
+		return fmt.Errorf("variables FORALL non trouvées: main=%s, forall=%s", mainVariable, forallVariable)
+	}
+
+	terminalNode := erb.utils.CreateTerminalNode(network, ruleID, action)
+
+	varTypes := map[string]string{
+		mainVariable:   mainVarType,
+		forallVariable: forallVarType,
+	}
+	forallNode := NewForallNode(ruleID+"_forall", mainVariable, forallVariable, varTypes,
+		forall["condition"], forall["then"], mainCondition, erb.utils.storage)
+	forallNode.AddChild(terminalNode)
+	network.BetaNodes[forallNode.ID] = forallNode
+
+	erb.ConnectExistsNodeToTypeNodes(network, ruleID, forallNode, mainVariable, mainVarType, forallVariable, forallVarType)
+
+	fmt.Printf("   ✅ ExistsNode %s créé pour %s FORALL %s\n", forallNode.ID, mainVariable, forallVariable)
+	return nil
+}
+
+// ExtractForallConstraint separates the FORALL constraint of a rule from the other
+// top-level conjuncts, which are returned as a condition on the main variable (nil if none)
+func (erb *ExistsRuleBuilder) ExtractForallConstraint(exprMap map[string]interface{}) (map[string]interface{}, interface{}, error) {
+	conjuncts, ok := topLevelConjuncts(exprMap["constraints"])
+	if !ok {
+		return nil, nil, fmt.Errorf("FORALL ne peut pas être combiné par OR")
+	}
+
+	var forall map[string]interface{}
+	var others []interface{}
+	for _, conjunct := range conjuncts {
+		if conjunctMap, ok := conjunct.(map[string]interface{}); ok && conjunctMap["type"] == "forallConstraint" {
+			if forall != nil {
+				return nil, nil, fmt.Errorf("une règle ne peut contenir qu'un seul FORALL")
+			}
+			forall = conjunctMap
+			continue
+		}
+		others = append(others, conjunct)
+	}
+	if forall == nil {
+		return nil, nil, fmt.Errorf("contrainte FORALL non trouvée")
+	}
+	return forall, joinConjuncts(others), nil
+}
+
+// firstSetVariable returns the name and type of the first variable of the pattern set
+func firstSetVariable(exprMap map[string]interface{}) (string, string) {
+	setMap, _ := exprMap["set"].(map[string]interface{})
+	varsList, _ := setMap["variables"].([]interface{})
+	if len(varsList) == 0 {
+		return "", ""
+	}
+	varMap, _ := varsList[0].(map[string]interface{})
+	name, _ := varMap["name"].(string)
+	dataType, _ := varMap["dataType"].(string)
+	return name, dataType
+}
+
 // ExtractExistsVariables extracts variables from an EXISTS rule
 func (erb *ExistsRuleBuilder) ExtractExistsVariables(exprMap map[string]interface{}) (string, string, string, string, error) {
 	var mainVariable, existsVariable string
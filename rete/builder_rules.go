@@ -6,6 +6,8 @@ package rete
 
 import (
 	"fmt"
+
+	"github.com/treivax/tsd/constraint"
 )
 
 // RuleBuilder orchestrates the creation of all types of rules
@@ -14,6 +16,7 @@ type RuleBuilder struct {
 	joinBuilder        *JoinRuleBuilder
 	existsBuilder      *ExistsRuleBuilder
 	accumulatorBuilder *AccumulatorRuleBuilder
+	collectBuilder     *CollectRuleBuilder
 	utils              *BuilderUtils
 
 	// Pipeline helper for accessing constraint pipeline functionality
@@ -27,6 +30,7 @@ func NewRuleBuilder(utils *BuilderUtils, pipeline PipelineHelper) *RuleBuilder {
 		joinBuilder:        NewJoinRuleBuilder(utils),
 		existsBuilder:      NewExistsRuleBuilder(utils),
 		accumulatorBuilder: NewAccumulatorRuleBuilder(utils),
+		collectBuilder:     NewCollectRuleBuilder(utils),
 		utils:              utils,
 		pipeline:           pipeline,
 	}
@@ -120,8 +124,15 @@ func (rb *RuleBuilder) CreateSingleRule(network *ReteNetwork, ruleID string, exp
 	rb.pipeline.logRuleCreation(ruleType, ruleID, variableNames)
 
 	// Delegate to the appropriate specialized builder
-	return rb.createRuleByType(network, ruleID, ruleType, exprMap, condition, action,
-		variables, variableNames, variableTypes, constraintsData, hasConstraints)
+	if err := rb.createRuleByType(network, ruleID, ruleType, exprMap, condition, action,
+		variables, variableNames, variableTypes, constraintsData, hasConstraints); err != nil {
+		return err
+	}
+
+	// Step 5: Record rule annotations (@version, @owner, @tags) and source position
+	network.setRuleMetadata(ruleID, exprMap["annotations"])
+	network.setRuleSpan(ruleID, constraint.SpanOf(exprMap))
+	return nil
 }
 
 // createRuleByType delegates rule creation to the appropriate builder based on type
@@ -142,6 +153,12 @@ func (rb *RuleBuilder) createRuleByType(
 	case "exists":
 		return rb.existsBuilder.CreateExistsRule(network, ruleID, exprMap, condition, action)
 
+	case "forall":
+		return rb.existsBuilder.CreateForallRule(network, ruleID, exprMap, action)
+
+	case "collect":
+		return rb.collectBuilder.CreateCollectRule(network, ruleID, exprMap, action)
+
 	case "accumulator":
 		return rb.createAccumulatorRuleWithInfo(network, ruleID, exprMap, condition, action,
 			variables, variableNames, variableTypes, constraintsData, hasConstraints)
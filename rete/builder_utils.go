@@ -14,6 +14,8 @@ const (
 	ConditionTypePassthrough = "passthrough"
 	ConditionTypeSimple      = "simple"
 	ConditionTypeExists      = "exists"
+	ConditionTypeForall      = "forall"
+	ConditionTypeCollect     = "collect"
 	ConditionTypeComparison  = "comparison"
 )
 
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Annulation de la propagation
+//
+// Un context.Context attaché au réseau (SubmitFactContext, IngestFileContext)
+// est vérifié entre deux propagations : à chaque soumission, mise à jour ou
+// rétractation de fait, y compris celles déclenchées par une action, et avant
+// chaque action. Une cascade de règles qui s'alimente elle-même (Insert dans
+// une action) est ainsi interrompue à l'échéance du contexte ; l'erreur remonte
+// la propagation et la transaction en cours est annulée.
+//
+// L'erreur retournée enveloppe ctx.Err() :
+//
+//	errors.Is(err, context.DeadlineExceeded)
+
+// canceledError signale une propagation interrompue par son contexte.
+//
+// Elle traverse telle quelle les niveaux de propagation (abortsPropagation) :
+// une cascade interrompue à grande profondeur remonterait sinon un message
+// réenveloppé à chaque niveau, de taille quadratique.
+type canceledError struct {
+	cause error
+}
+
+func (e *canceledError) Error() string {
+	return "propagation interrompue: " + e.cause.Error()
+}
+
+func (e *canceledError) Unwrap() error {
+	return e.cause
+}
+
+// abortsPropagation : l'erreur remonte la propagation sans être réenveloppée
+func (e *canceledError) abortsPropagation() {}
+
+// propagationAbort est implémentée par les erreurs qui interrompent toute la
+// propagation : annulation du contexte, limite de cascade atteinte
+type propagationAbort interface {
+	error
+	abortsPropagation()
+}
+
+// abortsPropagation indique si err interrompt toute la propagation en cours
+func abortsPropagation(err error) bool {
+	var abort propagationAbort
+	return errors.As(err, &abort)
+}
+
+// checkContext retourne une erreur si le contexte attaché au réseau est terminé
+func (rn *ReteNetwork) checkContext() error {
+	ctx := rn.executionContext()
+	if ctx == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return &canceledError{cause: ctx.Err()}
+	default:
+		return nil
+	}
+}
+
+// executionContext retourne le contexte attaché au réseau (nil si aucun)
+func (rn *ReteNetwork) executionContext() context.Context {
+	rn.ctxMutex.RLock()
+	defer rn.ctxMutex.RUnlock()
+	return rn.execCtx
+}
+
+// withContext exécute fn avec ctx attaché au réseau, puis restaure le contexte précédent
+func (rn *ReteNetwork) withContext(ctx context.Context, fn func() error) error {
+	if ctx == nil || ctx.Done() == nil {
+		// context.Background() : rien à surveiller
+		return fn()
+	}
+	if err := ctx.Err(); err != nil {
+		return &canceledError{cause: err}
+	}
+
+	rn.ctxMutex.Lock()
+	previous := rn.execCtx
+	rn.execCtx = ctx
+	rn.ctxMutex.Unlock()
+
+	defer func() {
+		rn.ctxMutex.Lock()
+		rn.execCtx = previous
+		rn.ctxMutex.Unlock()
+	}()
+	return fn()
+}
+
+// SubmitFactContext soumet un fait comme SubmitFact, en interrompant la
+// propagation dès que ctx est terminé.
+//
+// Hors transaction, la soumission est exécutée dans une transaction propre :
+// en cas d'erreur (annulation comprise), les faits ajoutés ou retirés par la
+// cascade sont restaurés. Dans une transaction existante, l'annulation est
+// laissée à l'appelant.
+func (rn *ReteNetwork) SubmitFactContext(ctx context.Context, fact *Fact) error {
+	return rn.withContext(ctx, func() error {
+		if tx := rn.GetTransaction(); tx != nil && tx.IsActive {
+			return rn.SubmitFact(fact)
+		}
+
+		tx := rn.BeginTransaction()
+		rn.SetTransaction(tx)
+		defer rn.SetTransaction(nil)
+
+		if err := rn.SubmitFact(fact); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("%w; erreur rollback: %v", err, rollbackErr)
+			}
+			return err
+		}
+		return tx.Commit()
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// runawayProgram contient une règle qui s'alimente elle-même sans fin
+const runawayProgram = `type Tick(#n: number)
+type Reading(#id: string, value: number)
+action next(n: number)
+
+rule loop : {t: Tick} / t.n >= 0 ==> next(t.n)
+`
+
+// nextTickHandler insère le Tick suivant, ce qui redéclenche la règle loop
+type nextTickHandler struct{}
+
+func (nextTickHandler) GetName() string                   { return "next" }
+func (nextTickHandler) Validate(args []interface{}) error { return nil }
+func (nextTickHandler) Execute(args []interface{}, ctx *ExecutionContext) error {
+	n, _ := args[0].(float64)
+	return ctx.network.SubmitFact(tick(n + 1))
+}
+
+func tick(n float64) *Fact {
+	return &Fact{ID: fmt.Sprintf("Tick~%g", n), Type: "Tick", Fields: map[string]interface{}{"n": n}}
+}
+
+// newRunawayNetwork crée un réseau dont seule l'annulation du contexte
+// interrompt la cascade (profondeur de cascade pratiquement illimitée)
+func newRunawayNetwork(storage Storage) (*ReteNetwork, error) {
+	network := NewReteNetwork(storage)
+	if err := network.SetCascadeLimits(CascadeLimits{MaxDepth: 1 << 30}); err != nil {
+		return nil, err
+	}
+	return network, network.ActionExecutor.RegisterAction(nextTickHandler{})
+}
+
+func TestSubmitFactContext_StopsRunawayCascade(t *testing.T) {
+	storage := NewMemoryStorage()
+	network, err := newRunawayNetwork(storage)
+	if err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, runawayProgram)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = network.SubmitFactContext(ctx, tick(0))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SubmitFactContext() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cascade stopped after %v", elapsed)
+	}
+
+	// La transaction propre à la soumission a été annulée
+	if facts := storage.GetAllFacts(); len(facts) != 0 {
+		t.Errorf("%d facts left after rollback, want 0", len(facts))
+	}
+	if network.GetTransaction() != nil || network.executionContext() != nil {
+		t.Error("transaction or context still attached to the network")
+	}
+
+	// Le réseau reste utilisable
+	if err := network.SubmitFact(reading("r1")); err != nil {
+		t.Errorf("SubmitFact() after cancellation error = %v", err)
+	}
+}
+
+func TestSubmitFactContext_CanceledBeforeSubmission(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := network.SubmitFactContext(ctx, reading("r1")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubmitFactContext() error = %v, want canceled", err)
+	}
+	if network.Storage.GetFact("Reading~r1") != nil {
+		t.Error("fact submitted despite canceled context")
+	}
+
+	if err := network.SubmitFactContext(context.Background(), reading("r2")); err != nil {
+		t.Errorf("SubmitFactContext(Background) error = %v", err)
+	}
+	if network.Storage.GetFact("Reading~r2") == nil {
+		t.Error("fact not committed")
+	}
+}
+
+func TestSubmitFactContext_EndsBlockedSubmission(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFacts: 1, Policy: FactLimitBlock, BlockTimeout: time.Minute})
+	if err := network.SubmitFact(reading("r1")); err != nil {
+		t.Fatalf("SubmitFact(r1) error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := network.SubmitFactContext(ctx, reading("r2")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SubmitFactContext() error = %v, want deadline exceeded", err)
+	}
+	if stats := network.FactLimitStats(); stats.Rejections != 1 || stats.Facts != 1 {
+		t.Errorf("stats = %+v, want one rejection", stats)
+	}
+}
+
+func TestIngestFileContext_RollsBackOnDeadline(t *testing.T) {
+	tsdFile := filepath.Join(t.TempDir(), "runaway.tsd")
+	program := runawayProgram + "\nReading(id: \"r1\", value: 1)\nTick(n: 0)\n"
+	if err := os.WriteFile(tsdFile, []byte(program), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	storage := NewMemoryStorage()
+	network, err := newRunawayNetwork(storage)
+	if err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network, _, err = NewConstraintPipeline().IngestFileContext(ctx, tsdFile, network, storage)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("IngestFileContext() error = %v, want deadline exceeded", err)
+	}
+	if facts := storage.GetAllFacts(); len(facts) != 0 {
+		t.Errorf("%d facts left after rollback, want 0", len(facts))
+	}
+	if network == nil || network.executionContext() != nil {
+		t.Error("context still attached to the network")
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Garde-fous contre les cascades de règles
+//
+// Une règle dont l'action modifie les faits qu'elle filtre (Update d'un champ
+// lu par ses conditions, Insert d'un fait de son propre type) peut se
+// redéclencher sans fin. Le réseau borne :
+//   - la profondeur de cascade : nombre d'actions imbriquées, une action
+//     déclenchant la suivante par Insert, Update ou Retract ;
+//   - le nombre total d'activations par ingestion (ou par soumission de fait
+//     hors ingestion) ;
+//   - le nombre d'activations d'une même règle sur cette même durée.
+//
+// Le dépassement d'une limite interrompt la propagation avec une
+// *CascadeLimitError décrivant la boucle de règles et de faits en cause ; la
+// transaction d'ingestion est alors annulée.
+
+// DefaultMaxCascadeDepth est la profondeur de cascade appliquée par défaut
+const DefaultMaxCascadeDepth = 100
+
+// ErrCascadeLimitExceeded est enveloppée par toutes les erreurs de limite de cascade
+var ErrCascadeLimitExceeded = errors.New("limite de cascade de règles atteinte")
+
+// Limites de cascade, reportées dans CascadeLimitError.Limit
+const (
+	CascadeLimitDepth           = "depth"
+	CascadeLimitActivations     = "activations"
+	CascadeLimitRuleActivations = "rule-activations"
+)
+
+// maxCascadePath borne le chemin rapporté lorsqu'aucune boucle n'est identifiée
+const maxCascadePath = 10
+
+// CascadeLimits configure les garde-fous contre les cascades de règles
+type CascadeLimits struct {
+	MaxDepth           int // Actions imbriquées au plus (0 : DefaultMaxCascadeDepth)
+	MaxActivations     int // Activations par ingestion (0 : illimité)
+	MaxRuleActivations int // Activations d'une même règle par ingestion (0 : illimité)
+}
+
+// Validate vérifie que les limites sont cohérentes
+func (l CascadeLimits) Validate() error {
+	if l.MaxDepth < 0 {
+		return fmt.Errorf("profondeur de cascade négative: %d", l.MaxDepth)
+	}
+	if l.MaxActivations < 0 {
+		return fmt.Errorf("nombre d'activations négatif: %d", l.MaxActivations)
+	}
+	if l.MaxRuleActivations < 0 {
+		return fmt.Errorf("nombre d'activations par règle négatif: %d", l.MaxRuleActivations)
+	}
+	return nil
+}
+
+// CascadeStep est une activation de règle dans une cascade
+type CascadeStep struct {
+	Rule     string            `json:"rule"`
+	Bindings map[string]string `json:"bindings,omitempty"` // Variable → identifiant interne du fait
+}
+
+// String rend l'étape sous la forme regle[v=Type~id, ...]
+func (s CascadeStep) String() string {
+	parts := make([]string, 0, len(s.Bindings))
+	for _, variable := range sortedKeys(s.Bindings) {
+		parts = append(parts, variable+"="+s.Bindings[variable])
+	}
+	return s.Rule + "[" + strings.Join(parts, ", ") + "]"
+}
+
+// CascadeLimitError signale le dépassement d'une limite de cascade
+type CascadeLimitError struct {
+	Limit string        // CascadeLimitDepth, CascadeLimitActivations ou CascadeLimitRuleActivations
+	Max   int           // Valeur de la limite dépassée
+	Rule  string        // Règle dont l'activation a dépassé la limite
+	Loop  bool          // Path décrit une boucle (il commence et finit par la même règle)
+	Path  []CascadeStep // Activations en cause, de la plus ancienne à la plus récente
+}
+
+func (e *CascadeLimitError) Error() string {
+	var limit string
+	switch e.Limit {
+	case CascadeLimitDepth:
+		limit = fmt.Sprintf("profondeur de cascade maximale (%d) atteinte", e.Max)
+	case CascadeLimitActivations:
+		limit = fmt.Sprintf("nombre maximal d'activations (%d) atteint", e.Max)
+	default:
+		limit = fmt.Sprintf("nombre maximal d'activations de la règle (%d) atteint", e.Max)
+	}
+
+	steps := make([]string, len(e.Path))
+	for i, step := range e.Path {
+		steps[i] = step.String()
+	}
+	path := "cascade"
+	if e.Loop {
+		path = "boucle"
+	}
+	return fmt.Sprintf("%s par la règle %s, %s: %s", limit, e.Rule, path, strings.Join(steps, " → "))
+}
+
+func (e *CascadeLimitError) Unwrap() error {
+	return ErrCascadeLimitExceeded
+}
+
+// abortsPropagation : l'erreur remonte la propagation sans être réenveloppée
+func (e *CascadeLimitError) abortsPropagation() {}
+
+// cascadeGuard suit les activations de règles imbriquées d'un réseau.
+//
+// Comme le traceur, il suppose une seule propagation à la fois par réseau.
+type cascadeGuard struct {
+	mutex       sync.Mutex
+	limits      CascadeLimits
+	runs        int            // Opérations de premier niveau en cours
+	stack       []CascadeStep  // Actions en cours d'exécution, imbriquées
+	activations int            // Activations depuis le début de l'opération
+	byRule      map[string]int // Activations par règle depuis le début de l'opération
+}
+
+func newCascadeGuard() *cascadeGuard {
+	return &cascadeGuard{
+		limits: CascadeLimits{MaxDepth: DefaultMaxCascadeDepth},
+		byRule: make(map[string]int),
+	}
+}
+
+// begin ouvre une opération (ingestion, soumission de fait) ; les compteurs
+// d'activations repartent de zéro à l'ouverture de l'opération de premier niveau
+func (g *cascadeGuard) begin() func() {
+	if g == nil {
+		return func() {}
+	}
+	g.mutex.Lock()
+	if g.runs == 0 && len(g.stack) == 0 {
+		g.reset()
+	}
+	g.runs++
+	g.mutex.Unlock()
+
+	return func() {
+		g.mutex.Lock()
+		g.runs--
+		g.mutex.Unlock()
+	}
+}
+
+// enter enregistre l'activation d'une règle avant l'exécution de son action
+func (g *cascadeGuard) enter(rule string, token *Token) error {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	// Activation hors de toute opération (propagation directe dans un nœud)
+	if g.runs == 0 && len(g.stack) == 0 {
+		g.reset()
+	}
+
+	step := CascadeStep{Rule: rule, Bindings: tokenBindings(token)}
+	g.activations++
+	g.byRule[rule]++
+
+	switch {
+	case len(g.stack) >= g.limits.MaxDepth:
+		return g.limitError(CascadeLimitDepth, g.limits.MaxDepth, step)
+	case g.limits.MaxActivations > 0 && g.activations > g.limits.MaxActivations:
+		return g.limitError(CascadeLimitActivations, g.limits.MaxActivations, step)
+	case g.limits.MaxRuleActivations > 0 && g.byRule[rule] > g.limits.MaxRuleActivations:
+		return g.limitError(CascadeLimitRuleActivations, g.limits.MaxRuleActivations, step)
+	}
+
+	g.stack = append(g.stack, step)
+	return nil
+}
+
+// exit termine l'activation enregistrée par le dernier enter réussi
+func (g *cascadeGuard) exit() {
+	if g == nil {
+		return
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if len(g.stack) > 0 {
+		g.stack = g.stack[:len(g.stack)-1]
+	}
+}
+
+func (g *cascadeGuard) reset() {
+	g.activations = 0
+	g.byRule = make(map[string]int)
+}
+
+// limitError construit l'erreur de dépassement : la boucle la plus récente
+// passant par la règle, à défaut les dernières activations imbriquées
+func (g *cascadeGuard) limitError(limit string, max int, step CascadeStep) error {
+	err := &CascadeLimitError{Limit: limit, Max: max, Rule: step.Rule}
+
+	start := -1
+	for i := len(g.stack) - 1; i >= 0; i-- {
+		if g.stack[i].Rule == step.Rule {
+			start = i
+			break
+		}
+	}
+	if start >= 0 {
+		err.Loop = true
+	} else {
+		start = len(g.stack) - maxCascadePath
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	err.Path = append(append([]CascadeStep(nil), g.stack[start:]...), step)
+	return err
+}
+
+// tokenBindings retourne les faits liés aux variables d'un token
+func tokenBindings(token *Token) map[string]string {
+	if token == nil || token.Bindings == nil {
+		return nil
+	}
+	bindings := make(map[string]string)
+	for variable, fact := range token.Bindings.ToMap() {
+		if fact != nil {
+			bindings[variable] = fact.GetInternalID()
+		}
+	}
+	return bindings
+}
+
+// SetCascadeLimits configure les garde-fous contre les cascades de règles
+func (rn *ReteNetwork) SetCascadeLimits(limits CascadeLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	if limits.MaxDepth == 0 {
+		limits.MaxDepth = DefaultMaxCascadeDepth
+	}
+
+	if rn.cascade == nil {
+		rn.cascade = newCascadeGuard()
+	}
+	rn.cascade.mutex.Lock()
+	defer rn.cascade.mutex.Unlock()
+	rn.cascade.limits = limits
+	return nil
+}
+
+// CascadeLimits retourne les garde-fous configurés
+func (rn *ReteNetwork) CascadeLimits() CascadeLimits {
+	if rn.cascade == nil {
+		return CascadeLimits{MaxDepth: DefaultMaxCascadeDepth}
+	}
+	rn.cascade.mutex.Lock()
+	defer rn.cascade.mutex.Unlock()
+	return rn.cascade.limits
+}
+
+// beginCascade ouvre une opération dont les activations sont comptées ensemble
+func (rn *ReteNetwork) beginCascade() func() {
+	return rn.cascade.begin()
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// newCascadeNetwork compile runawayProgram avec les limites de cascade données
+func newCascadeNetwork(t *testing.T, limits CascadeLimits) *ReteNetwork {
+	t.Helper()
+	storage := NewMemoryStorage()
+	network, err := newRunawayNetwork(storage)
+	if err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, runawayProgram)
+	if err := network.SetCascadeLimits(limits); err != nil {
+		t.Fatalf("SetCascadeLimits() error = %v", err)
+	}
+	return network
+}
+
+func TestCascadeLimits_Depth(t *testing.T) {
+	network := newCascadeNetwork(t, CascadeLimits{MaxDepth: 20})
+
+	err := network.SubmitFact(tick(0))
+	var limitErr *CascadeLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, ErrCascadeLimitExceeded) {
+		t.Fatalf("SubmitFact() error = %v, want cascade limit error", err)
+	}
+	if limitErr.Limit != CascadeLimitDepth || limitErr.Max != 20 || limitErr.Rule != "loop" {
+		t.Errorf("error = %+v", limitErr)
+	}
+
+	// La boucle va de la dernière activation de loop à celle qui a dépassé la limite
+	if !limitErr.Loop || len(limitErr.Path) != 2 {
+		t.Fatalf("Path = %v, want a two-step loop", limitErr.Path)
+	}
+	for i, want := range []string{"Tick~19", "Tick~20"} {
+		if step := limitErr.Path[i]; step.Rule != "loop" || step.Bindings["t"] != want {
+			t.Errorf("Path[%d] = %v, want loop[t=%s]", i, step, want)
+		}
+	}
+	if msg := err.Error(); !strings.Contains(msg, "boucle: loop[t=Tick~19] → loop[t=Tick~20]") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
+
+func TestCascadeLimits_Activations(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits CascadeLimits
+		limit  string
+	}{
+		{"total", CascadeLimits{MaxActivations: 5}, CascadeLimitActivations},
+		{"per rule", CascadeLimits{MaxRuleActivations: 5}, CascadeLimitRuleActivations},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := newCascadeNetwork(t, tt.limits)
+
+			var limitErr *CascadeLimitError
+			if err := network.SubmitFact(tick(0)); !errors.As(err, &limitErr) {
+				t.Fatalf("SubmitFact() error = %v, want cascade limit error", err)
+			}
+			if limitErr.Limit != tt.limit || limitErr.Max != 5 {
+				t.Errorf("error = %+v, want %s limit", limitErr, tt.limit)
+			}
+			if last := limitErr.Path[len(limitErr.Path)-1]; last.Bindings["t"] != "Tick~5" {
+				t.Errorf("last step = %v, want the sixth activation", last)
+			}
+		})
+	}
+}
+
+func TestCascadeLimits_CountersResetPerSubmission(t *testing.T) {
+	network := newCascadeNetwork(t, CascadeLimits{MaxActivations: 1})
+
+	// Une activation par soumission : les compteurs repartent de zéro à chaque fois
+	for _, n := range []float64{-1, -2, -3} {
+		if err := network.SubmitFact(tick(n)); err != nil {
+			t.Fatalf("SubmitFact(%g) error = %v", n, err)
+		}
+	}
+}
+
+func TestCascadeLimits_IngestionRollsBack(t *testing.T) {
+	tsdFile := filepath.Join(t.TempDir(), "runaway.tsd")
+	program := runawayProgram + "\nTick(n: 0)\n"
+	if err := os.WriteFile(tsdFile, []byte(program), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	storage := NewMemoryStorage()
+	network, err := newRunawayNetwork(storage)
+	if err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	if err := network.SetCascadeLimits(CascadeLimits{}); err != nil {
+		t.Fatalf("SetCascadeLimits() error = %v", err)
+	}
+	_, _, err = NewConstraintPipeline().IngestFile(tsdFile, network, storage)
+	if !errors.Is(err, ErrCascadeLimitExceeded) {
+		t.Fatalf("IngestFile() error = %v, want cascade limit error", err)
+	}
+	if facts := storage.GetAllFacts(); len(facts) != 0 {
+		t.Errorf("%d facts left after rollback, want 0", len(facts))
+	}
+}
+
+func TestCascadeLimits_SurviveReset(t *testing.T) {
+	limits := CascadeLimits{MaxDepth: 7, MaxActivations: 50, MaxRuleActivations: 10}
+	network := newCascadeNetwork(t, limits)
+
+	network = ingestQueryProgram(t, network, network.Storage, "reset\n"+runawayProgram)
+	if got := network.CascadeLimits(); got != limits {
+		t.Errorf("CascadeLimits() after reset = %+v, want %+v", got, limits)
+	}
+}
+
+func TestSetCascadeLimits(t *testing.T) {
+	network := NewReteNetwork(NewMemoryStorage())
+	if got := network.CascadeLimits(); got.MaxDepth != DefaultMaxCascadeDepth {
+		t.Errorf("default MaxDepth = %d, want %d", got.MaxDepth, DefaultMaxCascadeDepth)
+	}
+
+	for _, limits := range []CascadeLimits{{MaxDepth: -1}, {MaxActivations: -1}, {MaxRuleActivations: -1}} {
+		if err := network.SetCascadeLimits(limits); err == nil {
+			t.Errorf("SetCascadeLimits(%+v) should fail", limits)
+		}
+	}
+
+	if err := network.SetCascadeLimits(CascadeLimits{MaxActivations: 3}); err != nil {
+		t.Fatalf("SetCascadeLimits() error = %v", err)
+	}
+	if got := network.CascadeLimits(); got.MaxDepth != DefaultMaxCascadeDepth || got.MaxActivations != 3 {
+		t.Errorf("CascadeLimits() = %+v", got)
+	}
+}
+
+const selfTriggerProgram = `type Product(#id: string, stock: number, price: number, label: string)
+type Order(#id: string, product: string, qty: number)
+
+rule restock : {p: Product} / p.stock < 10 ==> Update(p, {stock: p.stock - 1})
+rule relabel : {p: Product} / p.price > 100 ==> Update(p, {label: "premium"})
+rule reorder : {p: Product, o: Order} / o.product == p.id AND o.qty > 0 ==> Insert(Order(id: "next", product: p.id, qty: o.qty - 1))
+rule audit : {p: Product} / p.stock == 0 ==> if p.price > 0 then Update(p, {stock: 100, price: 0})
+`
+
+func TestSelfTriggeringRules(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, selfTriggerProgram)
+
+	want := []SelfTriggerWarning{
+		{Rule: "audit", Action: "Update", Variable: "p", Type: "Product", Fields: []string{"stock"}},
+		{Rule: "reorder", Action: "Insert", Variable: "o", Type: "Order"},
+		{Rule: "restock", Action: "Update", Variable: "p", Type: "Product", Fields: []string{"stock"}},
+	}
+	got := network.SelfTriggeringRules()
+
+	// Chaque avertissement est localisé sur la règle
+	wantLines := map[string]int{"audit": 7, "reorder": 6, "restock": 4}
+	for i := range got {
+		if span := got[i].Span; span == nil || span.Line != wantLines[got[i].Rule] || span.Column != 1 {
+			t.Errorf("%s: Span = %v, want line %d", got[i].Rule, span, wantLines[got[i].Rule])
+		}
+		if diagnostic := got[i].Diagnostic(); diagnostic.Severity != tsdio.SeverityWarning || diagnostic.Span != got[i].Span {
+			t.Errorf("%s: Diagnostic() = %+v", got[i].Rule, diagnostic)
+		}
+		got[i].Span = nil
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelfTriggeringRules() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestExpressionSelfTriggers(t *testing.T) {
+	result, err := constraint.ParseConstraint("self.tsd", []byte(selfTriggerProgram))
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	program, err := constraint.ConvertResultToProgram(result)
+	if err != nil {
+		t.Fatalf("ConvertResultToProgram() error = %v", err)
+	}
+
+	var got []SelfTriggerWarning
+	for _, expression := range program.Expressions {
+		for _, warning := range ExpressionSelfTriggers(expression) {
+			if warning.Span == nil || warning.Span.File != "self.tsd" {
+				t.Errorf("%s: Span = %v", warning.Rule, warning.Span)
+			}
+			warning.Span = nil
+			got = append(got, warning)
+		}
+	}
+
+	// Mêmes avertissements que sur le réseau compilé, dans l'ordre du source
+	want := []SelfTriggerWarning{
+		{Rule: "restock", Action: "Update", Variable: "p", Type: "Product", Fields: []string{"stock"}},
+		{Rule: "reorder", Action: "Insert", Variable: "o", Type: "Order"},
+		{Rule: "audit", Action: "Update", Variable: "p", Type: "Product", Fields: []string{"stock"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpressionSelfTriggers() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -92,6 +92,10 @@ func (ce *ConditionEvaluator) EvaluateWithContext(
 		if innerCondition, ok := condMap["condition"]; ok {
 			return ce.EvaluateWithContext(innerCondition, fact, context)
 		}
+		// Parser wrapper format: {"type": "constraint", "constraint": ...}
+		if innerCondition, ok := condMap["constraint"]; ok {
+			return ce.EvaluateWithContext(innerCondition, fact, context)
+		}
 		// If no nested condition, treat as always true
 		return true, nil
 
@@ -6,6 +6,7 @@ package rete
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ConditionType indicates whether a condition is Alpha or Beta
@@ -61,8 +62,9 @@ func (cs *ConditionSplitter) SplitConditions(
 		}
 	}
 
-	// Handle logicalExpr (AND operations)
-	if exprType, ok := condition["type"].(string); ok && exprType == "logicalExpr" {
+	// Handle logicalExpr (AND operations). Une expression contenant OR reste
+	// entière : la découper en filtres indépendants la transformerait en AND.
+	if exprType, ok := condition["type"].(string); ok && exprType == "logicalExpr" && isConjunction(condition) {
 		return cs.splitLogicalExpression(condition)
 	}
 
@@ -157,6 +159,27 @@ func (cs *ConditionSplitter) splitLogicalExpression(
 	return alphaConditions, betaConditions, nil
 }
 
+// isConjunction indique si toutes les opérations d'un logicalExpr sont des AND
+func isConjunction(logicalExpr map[string]interface{}) bool {
+	var operations []map[string]interface{}
+	switch ops := logicalExpr["operations"].(type) {
+	case []interface{}:
+		for _, op := range ops {
+			if opMap, ok := op.(map[string]interface{}); ok {
+				operations = append(operations, opMap)
+			}
+		}
+	case []map[string]interface{}:
+		operations = ops
+	}
+	for _, op := range operations {
+		if operator, _ := op["op"].(string); !strings.EqualFold(operator, "AND") {
+			return false
+		}
+	}
+	return true
+}
+
 // ClassifyCondition determines if a condition is alpha or beta
 func (cs *ConditionSplitter) ClassifyCondition(condition map[string]interface{}) ConditionType {
 	vars := cs.extractVariables(condition)
@@ -910,3 +910,51 @@ func TestConditionSplitter_WithConstraintWrapper(t *testing.T) {
 	}
 	t.Log("✅ Condition with constraint wrapper correctly handled")
 }
+
+// TestConditionSplitter_DisjunctionNotSplit vérifie qu'une disjonction portant
+// sur deux variables reste une seule condition : la découper en filtres
+// indépendants la transformerait en conjonction
+func TestConditionSplitter_DisjunctionNotSplit(t *testing.T) {
+	splitter := NewConditionSplitter()
+	// o.total < 1000 OR c.tier != "gold"
+	condition := map[string]interface{}{
+		"type": "logicalExpr",
+		"left": map[string]interface{}{
+			"type":     "comparison",
+			"left":     map[string]interface{}{"type": "fieldAccess", "object": "o", "field": "total"},
+			"operator": "<",
+			"right":    map[string]interface{}{"type": "number", "value": 1000.0},
+		},
+		"operations": []interface{}{
+			map[string]interface{}{
+				"op": "OR",
+				"right": map[string]interface{}{
+					"type":     "comparison",
+					"left":     map[string]interface{}{"type": "fieldAccess", "object": "c", "field": "tier"},
+					"operator": "!=",
+					"right":    map[string]interface{}{"type": "string", "value": "gold"},
+				},
+			},
+		},
+	}
+	alphas, betas, err := splitter.SplitConditions(condition)
+	if err != nil {
+		t.Fatalf("Error splitting condition: %v", err)
+	}
+	if len(alphas) != 0 || len(betas) != 1 {
+		t.Fatalf("Expected the disjunction as 1 beta condition, got %d alpha, %d beta", len(alphas), len(betas))
+	}
+	if len(betas[0].Variables) != 2 {
+		t.Errorf("Expected 2 variables, got %v", betas[0].Variables)
+	}
+
+	// La même expression en AND est découpée en deux filtres alpha
+	condition["operations"].([]interface{})[0].(map[string]interface{})["op"] = "AND"
+	alphas, betas, err = splitter.SplitConditions(condition)
+	if err != nil {
+		t.Fatalf("Error splitting condition: %v", err)
+	}
+	if len(alphas) != 2 || len(betas) != 0 {
+		t.Errorf("Expected 2 alpha conditions for a conjunction, got %d alpha, %d beta", len(alphas), len(betas))
+	}
+}
@@ -5,6 +5,7 @@
 package rete
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -17,6 +18,7 @@ import (
 type ConstraintPipeline struct {
 	logger                *Logger                                                     // Logger structuré pour instrumentation
 	onXupleSpacesDetected func(network *ReteNetwork, definitions []interface{}) error // Callback appelé après détection des xuple-spaces
+	undoDepth             int                                                         // Ingestions annulables conservées (0 : transactions validées)
 }
 
 // GetLogger retourne le logger, en l'initialisant si nécessaire
@@ -47,6 +49,27 @@ func (cp *ConstraintPipeline) SetOnXupleSpacesDetected(callback func(network *Re
 	cp.onXupleSpacesDetected = callback
 }
 
+// SetUndoable laisse ouverte la transaction de chaque ingestion réussie, pour
+// qu'elle puisse être annulée par ReteNetwork.RollbackTransaction. Elle est
+// validée par la prochaine ingestion réussie.
+func (cp *ConstraintPipeline) SetUndoable(enabled bool) {
+	cp.undoDepth = 0
+	if enabled {
+		cp.undoDepth = 1
+	}
+}
+
+// SetUndoDepth laisse ouvertes les transactions des depth dernières
+// ingestions réussies : ReteNetwork.UndoTransaction les annule de la plus
+// récente à la plus ancienne. Les transactions plus anciennes sont validées
+// (0 : aucune ingestion annulable).
+func (cp *ConstraintPipeline) SetUndoDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	cp.undoDepth = depth
+}
+
 // IngestFile est la fonction unique et incrémentale pour étendre le réseau RETE.
 // Elle peut être appelée plusieurs fois avec des fichiers différents pour :
 // - Parser le fichier (types, règles, faits)
@@ -63,11 +86,21 @@ func (cp *ConstraintPipeline) SetOnXupleSpacesDetected(callback func(network *Re
 //
 // Les métriques sont toujours collectées et retournées (coût négligeable < 0.1%).
 func (cp *ConstraintPipeline) IngestFile(filename string, network *ReteNetwork, storage Storage) (*ReteNetwork, *IngestionMetrics, error) {
+	return cp.IngestFileContext(context.Background(), filename, network, storage)
+}
+
+// IngestFileContext ingère un fichier comme IngestFile, en interrompant
+// l'ingestion dès que execCtx est terminé : entre deux étapes du pipeline et,
+// pendant la propagation, entre deux faits ou actions (voir SubmitFactContext).
+// L'interruption retourne une erreur enveloppant execCtx.Err() après rollback
+// de la transaction d'ingestion.
+func (cp *ConstraintPipeline) IngestFileContext(execCtx context.Context, filename string, network *ReteNetwork, storage Storage) (*ReteNetwork, *IngestionMetrics, error) {
 	cp.logger.Info("========================================")
 	cp.logger.Info("📁 Ingestion incrémentale: %s", filename)
 
 	// Initialiser le contexte d'ingestion
 	ctx := &ingestionContext{
+		execCtx:               execCtx,
 		filename:              filename,
 		network:               network,
 		storage:               storage,
@@ -87,6 +120,33 @@ func (cp *ConstraintPipeline) IngestFile(filename string, network *ReteNetwork,
 	return ctx.network, ctx.metrics.Finalize(), nil
 }
 
+// IngestProgramContext ingère un programme déjà chargé (AST fusionné retourné
+// par constraint.ModuleLoader), comme IngestFileContext. name ne sert qu'aux
+// journaux. Les imports de l'AST doivent avoir été résolus par le chargeur.
+func (cp *ConstraintPipeline) IngestProgramContext(execCtx context.Context, name string, program map[string]interface{}, network *ReteNetwork, storage Storage) (*ReteNetwork, *IngestionMetrics, error) {
+	cp.logger.Info("========================================")
+	cp.logger.Info("📁 Ingestion incrémentale: %s", name)
+
+	ctx := &ingestionContext{
+		execCtx:               execCtx,
+		filename:              name,
+		network:               network,
+		storage:               storage,
+		metrics:               NewMetricsCollector(),
+		parsedAST:             program,
+		onXupleSpacesDetected: cp.onXupleSpacesDetected,
+	}
+
+	if err := cp.executePipeline(ctx); err != nil {
+		return cp.handlePipelineError(ctx, err)
+	}
+
+	cp.logger.Info("🎯 INGESTION TERMINÉE")
+	cp.logger.Info("========================================")
+
+	return ctx.network, ctx.metrics.Finalize(), nil
+}
+
 // enrichProgramWithNetworkTypes merges types from the network into the program
 // This is crucial for incremental validation when facts reference types defined in previous files
 func (cp *ConstraintPipeline) enrichProgramWithNetworkTypes(program *constraint.Program, network *ReteNetwork) constraint.Program {
@@ -133,17 +193,29 @@ func (cp *ConstraintPipeline) executePipeline(ctx *ingestionContext) error {
 	}
 
 	// Phase 2: Construction réseau
-	if err := cp.buildNetworkFromContext(ctx); err != nil {
+	if err := ctx.canceled(); err != nil {
 		return err
 	}
-
-	// Phase 3: Gestion faits
-	if err := cp.manageFacts(ctx); err != nil {
+	if err := cp.buildNetworkFromContext(ctx); err != nil {
 		return err
 	}
 
-	// Phase 4: Finalisation
-	return cp.finalizeIngestion(ctx)
+	// Le réseau est désormais créé : la propagation des faits et les actions
+	// s'exécutent sous le contexte d'annulation, les activations de toute
+	// l'ingestion étant comptées ensemble (limites de cascade)
+	defer ctx.network.beginCascade()()
+	return ctx.network.withContext(ctx.execCtx, func() error {
+		// Phase 3: Gestion faits
+		if err := cp.manageFacts(ctx); err != nil {
+			return err
+		}
+
+		// Phase 4: Finalisation
+		if err := ctx.canceled(); err != nil {
+			return err
+		}
+		return cp.finalizeIngestion(ctx)
+	})
 }
 
 // prepareIngestion prépare le contexte d'ingestion (parsing, reset, transaction, validation)
@@ -199,7 +271,11 @@ func (cp *ConstraintPipeline) manageFacts(ctx *ingestionContext) error {
 		return err
 	}
 
-	return cp.submitNewFacts(ctx)
+	if err := cp.submitNewFacts(ctx); err != nil {
+		return err
+	}
+
+	return cp.retractFacts(ctx)
 }
 
 // finalizeIngestion finalise l'ingestion avec validation et commit
@@ -208,26 +284,54 @@ func (cp *ConstraintPipeline) finalizeIngestion(ctx *ingestionContext) error {
 		return err
 	}
 
-	return cp.verifyAndCommit(ctx)
+	if err := cp.verifyAndCommit(ctx); err != nil {
+		return err
+	}
+
+	ctx.network.Modules = ctx.modules
+	return nil
 }
 
 // handlePipelineError gère les erreurs du pipeline avec rollback automatique
 func (cp *ConstraintPipeline) handlePipelineError(ctx *ingestionContext, err error) (*ReteNetwork, *IngestionMetrics, error) {
 	if ctx.tx != nil && ctx.tx.IsActive {
-		rollbackErr := ctx.tx.Rollback()
+		var rollbackErr error
+		if ctx.tx.journal != nil {
+			// Transaction annulable : restaurer aussi les mémoires du réseau
+			rollbackErr = ctx.network.RollbackTransaction()
+		} else {
+			rollbackErr = ctx.tx.Rollback()
+		}
 		if rollbackErr != nil {
 			cp.logger.Error("❌ Erreur rollback: %v", rollbackErr)
 			return ctx.network, ctx.metrics.Finalize(), fmt.Errorf("erreur ingestion: %w; erreur rollback: %v", err, rollbackErr)
 		}
 		cp.logger.Warn("🔙 Rollback automatique effectué")
 	}
+	if ctx.previousTx != nil && ctx.previousTx.IsActive && ctx.previousTx.Network == ctx.network {
+		ctx.network.SetTransaction(ctx.previousTx)
+	}
 	return ctx.network, ctx.metrics.Finalize(), err
 }
 
 // readAndParseFile lit et parse le fichier de contraintes
 func (cp *ConstraintPipeline) readAndParseFile(ctx *ingestionContext) error {
 	parsingStart := time.Now()
-	parsedAST, err := constraint.ParseConstraintFile(ctx.filename)
+
+	// Les imports déjà chargés dans ce réseau ne sont pas rechargés
+	if ctx.network != nil && ctx.network.Modules != nil {
+		ctx.modules = ctx.network.Modules.Clone()
+	} else {
+		ctx.modules = constraint.NewModuleLoader()
+	}
+
+	// Programme déjà chargé (IngestProgramContext)
+	if ctx.parsedAST != nil {
+		cp.logger.Info("✅ Programme déjà chargé")
+		return nil
+	}
+
+	parsedAST, err := ctx.modules.Load(ctx.filename)
 	if err != nil {
 		ctx.metrics.RecordParsingDuration(time.Since(parsingStart))
 		return fmt.Errorf("❌ Erreur parsing fichier %s: %w", ctx.filename, err)
@@ -259,14 +363,25 @@ func (cp *ConstraintPipeline) initializeOrResetNetwork(ctx *ingestionContext) er
 	if ctx.hasResets {
 		cp.logger.Info("🔄 Commande reset détectée - Garbage Collection de l'ancien réseau")
 
+		// Les limites de faits en mémoire et de cascade survivent au reset
+		var factLimits FactLimits
+		var cascadeLimits CascadeLimits
 		if ctx.network != nil {
 			cp.logger.Debug("🗑️ GC du réseau existant...")
+			factLimits = ctx.network.FactLimits()
+			cascadeLimits = ctx.network.CascadeLimits()
 			ctx.network.GarbageCollect()
 			cp.logger.Debug("✅ GC terminé")
 		}
 
 		cp.logger.Info("🆕 Création d'un nouveau réseau RETE")
 		ctx.network = NewReteNetwork(ctx.storage)
+		if err := ctx.network.SetFactLimits(factLimits); err != nil {
+			return err
+		}
+		if err := ctx.network.SetCascadeLimits(cascadeLimits); err != nil {
+			return err
+		}
 		ctx.metrics.SetWasReset(true)
 	}
 	return nil
@@ -274,11 +389,31 @@ func (cp *ConstraintPipeline) initializeOrResetNetwork(ctx *ingestionContext) er
 
 // beginTransactionIfNeeded démarre une transaction si le réseau existe
 func (cp *ConstraintPipeline) beginTransactionIfNeeded(ctx *ingestionContext) error {
-	if ctx.network != nil {
+	if ctx.network == nil {
+		return nil
+	}
+	if ctx.atomic {
+		// Annulable en cas d'échec, validée en cas de succès
+		ctx.tx = ctx.network.BeginUndoableTransaction()
+		ctx.network.SetTransaction(ctx.tx)
+		cp.logger.Info("🔒 Transaction annulable démarrée: %s", ctx.tx.ID)
+		return nil
+	}
+	if cp.undoDepth == 0 {
 		ctx.tx = ctx.network.BeginTransaction()
 		ctx.network.SetTransaction(ctx.tx)
 		cp.logger.Info("🔒 Transaction démarrée automatiquement: %s", ctx.tx.ID)
+		return nil
+	}
+	// La transaction laissée ouverte par l'ingestion précédente reste
+	// annulable si celle-ci échoue ; elle est conservée comme point
+	// d'annulation (ou validée) en cas de succès
+	if previous := ctx.network.GetTransaction(); previous != nil && previous.IsActive {
+		ctx.previousTx = previous
 	}
+	ctx.tx = ctx.network.BeginUndoableTransaction()
+	ctx.network.SetTransaction(ctx.tx)
+	cp.logger.Info("🔒 Transaction annulable démarrée: %s", ctx.tx.ID)
 	return nil
 }
 
@@ -384,14 +519,41 @@ func (cp *ConstraintPipeline) addTypesAndRules(ctx *ingestionContext) error {
 		ctx.metrics.SetRulesAdded(len(ctx.expressions))
 	}
 
+	// Compiler les requêtes
+	if len(ctx.program.Queries) > 0 {
+		if err := cp.createQueryNodes(ctx.network, ctx.program.Queries, ctx.storage); err != nil {
+			return fmt.Errorf("❌ Erreur ajout requêtes: %w", err)
+		}
+		cp.logger.Info("✅ %d requête(s) ajoutée(s) au réseau", len(ctx.program.Queries))
+	}
+
 	// Traiter les suppressions de règles
 	if err := cp.processRuleRemovals(ctx.network, reteResultMap); err != nil {
 		return fmt.Errorf("❌ Erreur traitement suppressions de règles: %w", err)
 	}
 
+	// Conserver les déclarations pour reconstruire le réseau (Definitions)
+	if ast, ok := ctx.parsedAST.(map[string]interface{}); ok {
+		ctx.network.definitions.record(ast)
+	}
+
+	cp.warnSelfTriggeringRules(ctx)
 	return nil
 }
 
+// warnSelfTriggeringRules signale les nouvelles règles susceptibles de se redéclencher elles-mêmes
+func (cp *ConstraintPipeline) warnSelfTriggeringRules(ctx *ingestionContext) {
+	var terminals []*TerminalNode
+	for terminalID, terminal := range ctx.network.TerminalNodes {
+		if !ctx.existingTerminals[terminalID] {
+			terminals = append(terminals, terminal)
+		}
+	}
+	for _, warning := range ctx.network.selfTriggeringRules(terminals) {
+		cp.logger.Warn("🔁 Boucle possible, %s", warning)
+	}
+}
+
 // collectExistingFactsIfNeeded collecte les faits existants si nécessaire
 func (cp *ConstraintPipeline) collectExistingFactsIfNeeded(ctx *ingestionContext) error {
 	if ctx.hasResets {
@@ -461,6 +623,23 @@ func (cp *ConstraintPipeline) submitNewFacts(ctx *ingestionContext) error {
 	return nil
 }
 
+// retractFacts applique les commandes « remove fact » du programme, après la
+// soumission des nouveaux faits
+func (cp *ConstraintPipeline) retractFacts(ctx *ingestionContext) error {
+	for _, retraction := range ctx.program.Retractions {
+		internalID := retraction.TypeName + constraint.IDSeparatorType + retraction.FactID
+		cp.logger.Info("🗑️  Suppression du fait: %s", internalID)
+		if err := ctx.network.RetractFact(internalID); err != nil {
+			return fmt.Errorf("❌ Erreur suppression du fait %s: %w", internalID, err)
+		}
+		if ctx.retractedFactsIDs == nil {
+			ctx.retractedFactsIDs = make(map[string]bool)
+		}
+		ctx.retractedFactsIDs[internalID] = true
+	}
+	return nil
+}
+
 // validateNetworkAndState valide le réseau et enregistre son état
 func (cp *ConstraintPipeline) validateNetworkAndState(ctx *ingestionContext) error {
 	if err := cp.validateNetwork(ctx.network); err != nil {
@@ -621,14 +800,26 @@ func (cp *ConstraintPipeline) verifyAndCommit(ctx *ingestionContext) error {
 		cp.logger.Info("✅ Storage synchronisé")
 	}
 
-	// Commit transaction
-	if ctx.tx != nil && ctx.tx.IsActive {
+	// Commit transaction (laissée ouverte si annulable)
+	if ctx.tx != nil && ctx.tx.IsActive && (cp.undoDepth == 0 || ctx.atomic) {
 		if err := ctx.tx.Commit(); err != nil {
 			return fmt.Errorf("❌ Erreur commit transaction: %w", err)
 		}
 		cp.logger.Info("✅ Transaction committée: %d changements", ctx.tx.GetCommandCount())
 	}
 
+	if ctx.previousTx != nil && ctx.previousTx.IsActive {
+		var err error
+		if ctx.previousTx.Network == ctx.network {
+			err = ctx.network.PushUndoPoint(ctx.previousTx, cp.undoDepth-1)
+		} else {
+			err = ctx.previousTx.Commit()
+		}
+		if err != nil {
+			return fmt.Errorf("❌ Erreur commit transaction précédente: %w", err)
+		}
+	}
+
 	// Nettoyer le contexte de soumission après le commit
 	ctx.network.ClearSubmissionContext()
 
@@ -40,6 +40,7 @@ func (cp *ConstraintPipeline) extractAggregationInfoFromVariables(exprMap map[st
 		return nil, err
 	}
 	aggInfo.Function = function
+	aggInfo.ResultVar, _ = aggVar["name"].(string)
 
 	// Étape 4: Extraire le champ agrégé et la variable source
 	aggVariable, field, err := cp.extractAggregationField(aggVar)
@@ -49,6 +50,12 @@ func (cp *ConstraintPipeline) extractAggregationInfoFromVariables(exprMap map[st
 	aggInfo.AggVariable = aggVariable
 	aggInfo.Field = field
 
+	spec, err := extractAggregationArguments(function, aggVariable, aggVar["args"])
+	if err != nil {
+		return nil, err
+	}
+	aggInfo.applySpec(spec)
+
 	// Étape 5: Extraire le type source depuis le second pattern
 	aggType, err := cp.extractSourceType(exprMap)
 	if err != nil {
@@ -174,6 +181,13 @@ func (cp *ConstraintPipeline) extractBasicAggregationFields(constraintMap map[st
 		aggInfo.Field = field
 	}
 
+	// Arguments supplémentaires (rang, champ d'ordre, séparateur)
+	spec, err := extractAggregationArguments(aggInfo.Function, aggInfo.AggVariable, constraintMap["args"])
+	if err != nil {
+		return err
+	}
+	aggInfo.applySpec(spec)
+
 	return nil
 }
 
@@ -252,16 +266,23 @@ func (cp *ConstraintPipeline) extractAggregationJoinFields(constraintMap map[str
 	}
 }
 
+// detectedAggregateFunctions liste les noms recherchés par detectAggregation
+// (COUNT couvre aussi COUNT_DISTINCT)
+var detectedAggregateFunctions = []string{
+	AggregateAvg, AggregateSum, AggregateCount, AggregateMin, AggregateMax,
+	AggregateMedian, AggregatePercentile, AggregateStddev, AggregateFirst,
+	AggregateLast, AggregateStringAgg, AggregateCollectSet, "ACCUMULATE",
+}
+
 // detectAggregation détecte si une contrainte contient une agrégation
 // Cette fonction utilise une détection simple par string matching
 func (cp *ConstraintPipeline) detectAggregation(constraintsData interface{}) bool {
 	if constraintStr := fmt.Sprintf("%v", constraintsData); constraintStr != "" {
-		return strings.Contains(constraintStr, "AVG") ||
-			strings.Contains(constraintStr, "SUM") ||
-			strings.Contains(constraintStr, "COUNT") ||
-			strings.Contains(constraintStr, "MIN") ||
-			strings.Contains(constraintStr, "MAX") ||
-			strings.Contains(constraintStr, "ACCUMULATE")
+		for _, function := range detectedAggregateFunctions {
+			if strings.Contains(constraintStr, function) {
+				return true
+			}
+		}
 	}
 	return false
 }
@@ -122,6 +122,14 @@ func (ctx *aggregationExtractionContext) extractAggregationVariable(varMap map[s
 		}
 	}
 
+	spec, err := extractAggregationArguments(aggVar.Function, aggVar.SourceVar, varMap["args"])
+	if err != nil {
+		return fmt.Errorf("aggregation variable %s: %w", aggVar.Name, err)
+	}
+	aggVar.OrderField = spec.OrderField
+	aggVar.Percentile = spec.Percentile
+	aggVar.Separator = spec.Separator
+
 	ctx.aggInfo.AggregationVars = append(ctx.aggInfo.AggregationVars, aggVar)
 	return nil
 }
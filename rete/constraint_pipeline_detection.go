@@ -14,6 +14,37 @@ func (cp *ConstraintPipeline) isExistsConstraint(constraintsData interface{}) bo
 	return false
 }
 
+// isForallConstraint vérifie si les contraintes contiennent un FORALL au premier niveau
+// (seul ou combiné par AND avec d'autres contraintes)
+func (cp *ConstraintPipeline) isForallConstraint(constraintsData interface{}) bool {
+	conjuncts, _ := topLevelConjuncts(constraintsData)
+	for _, conjunct := range conjuncts {
+		if conjunctMap, ok := conjunct.(map[string]interface{}); ok && conjunctMap["type"] == "forallConstraint" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCollectVariables vérifie si le bloc de motifs déclare une variable COLLECT
+func (cp *ConstraintPipeline) hasCollectVariables(exprMap map[string]interface{}) bool {
+	return len(collectVariables(exprMap)) > 0
+}
+
+// collectVariables retourne les variables COLLECT déclarées dans le bloc de motifs
+func collectVariables(exprMap map[string]interface{}) []map[string]interface{} {
+	setMap, _ := exprMap["set"].(map[string]interface{})
+	varsList, _ := setMap["variables"].([]interface{})
+
+	var result []map[string]interface{}
+	for _, varInterface := range varsList {
+		if varMap, ok := varInterface.(map[string]interface{}); ok && varMap["type"] == "collectVariable" {
+			result = append(result, varMap)
+		}
+	}
+	return result
+}
+
 // getStringField extrait un champ string d'une map avec une valeur par défaut
 func getStringField(m map[string]interface{}, key, defaultValue string) string {
 	if value, ok := m[key].(string); ok {
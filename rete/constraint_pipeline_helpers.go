@@ -16,33 +16,13 @@ func (cp *ConstraintPipeline) createAction(actionMap map[string]interface{}) *Ac
 	if jobsData, hasJobs := actionMap["jobs"]; hasJobs {
 		if jobsList, ok := jobsData.([]interface{}); ok && len(jobsList) > 0 {
 			// Convertir chaque job
-			jobs := make([]JobCall, 0, len(jobsList))
-			for _, jobData := range jobsList {
-				if jobMap, ok := jobData.(map[string]interface{}); ok {
-					jobName := getStringField(jobMap, "name", actionType)
-					jobArgs := []interface{}{}
-					if argsData, hasArgs := jobMap["args"]; hasArgs {
-						if argsList, ok := argsData.([]interface{}); ok {
-							jobArgs = argsList
-						}
-					}
-					jobs = append(jobs, JobCall{
-						Type: "jobCall",
-						Name: jobName,
-						Args: jobArgs,
-					})
-				}
-			}
+			jobs := cp.createJobs(jobsList, actionType)
 
 			// Si un seul job, utiliser l'ancien format pour rétrocompatibilité
 			if len(jobs) == 1 {
 				return &Action{
 					Type: actionType,
-					Job: &JobCall{
-						Type: jobs[0].Type,
-						Name: jobs[0].Name,
-						Args: jobs[0].Args,
-					},
+					Job:  &jobs[0],
 				}
 			}
 
@@ -99,6 +79,46 @@ func (cp *ConstraintPipeline) createAction(actionMap map[string]interface{}) *Ac
 	return action
 }
 
+// createJobs convertit une liste d'instructions parsées (appels, let, if) en JobCalls
+func (cp *ConstraintPipeline) createJobs(jobsList []interface{}, actionType string) []JobCall {
+	jobs := make([]JobCall, 0, len(jobsList))
+	for _, jobData := range jobsList {
+		jobMap, ok := jobData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch jobMap["type"] {
+		case JobTypeLet:
+			jobs = append(jobs, JobCall{
+				Type:  JobTypeLet,
+				Name:  getStringField(jobMap, "name", ""),
+				Value: jobMap["value"],
+			})
+		case JobTypeIf:
+			thenList, _ := jobMap["then"].([]interface{})
+			elseList, _ := jobMap["else"].([]interface{})
+			jobs = append(jobs, JobCall{
+				Type:      JobTypeIf,
+				Condition: jobMap["condition"],
+				Then:      cp.createJobs(thenList, actionType),
+				Else:      cp.createJobs(elseList, actionType),
+			})
+		default:
+			jobArgs := []interface{}{}
+			if argsList, ok := jobMap["args"].([]interface{}); ok {
+				jobArgs = argsList
+			}
+			jobs = append(jobs, JobCall{
+				Type: JobTypeCall,
+				Name: getStringField(jobMap, "name", actionType),
+				Args: jobArgs,
+			})
+		}
+	}
+	return jobs
+}
+
 // buildConditionFromConstraints construit une condition appropriée à partir de contraintes
 func (cp *ConstraintPipeline) buildConditionFromConstraints(constraintsData interface{}) (map[string]interface{}, error) {
 	if constraintsData == nil {
@@ -162,6 +182,14 @@ func (cp *ConstraintPipeline) determineRuleType(
 		if cp.isExistsConstraint(constraintsData) {
 			return "exists"
 		}
+		if cp.isForallConstraint(constraintsData) {
+			return "forall"
+		}
+	}
+
+	// Si le bloc de motifs déclare une variable COLLECT
+	if cp.hasCollectVariables(exprMap) {
+		return "collect"
 	}
 
 	// Si c'est une agrégation
@@ -324,6 +352,10 @@ func (cp *ConstraintPipeline) logRuleCreation(ruleType string, ruleID string, va
 		cp.GetLogger().Info("   📍 Règle multi-variables détectée (%d variables): %v", len(variableNames), variableNames)
 	case "exists":
 		cp.GetLogger().Debug("   🔍 Règle EXISTS détectée pour: %s", ruleID)
+	case "forall":
+		cp.GetLogger().Debug("   🔍 Règle FORALL détectée pour: %s", ruleID)
+	case "collect":
+		cp.GetLogger().Debug("   📦 Règle COLLECT détectée pour: %s", ruleID)
 	case "accumulator":
 		cp.GetLogger().Debug("   📊 Règle d'agrégation détectée pour: %s", ruleID)
 	case "alpha":
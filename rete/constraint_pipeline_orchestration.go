@@ -5,6 +5,7 @@
 package rete
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,11 +14,13 @@ import (
 
 // ingestionContext encapsule l'état d'une ingestion de fichier
 type ingestionContext struct {
+	execCtx               context.Context // Contexte d'annulation fourni par l'appelant
 	filename              string
 	network               *ReteNetwork
 	storage               Storage
 	metrics               *MetricsCollector
 	parsedAST             interface{}
+	modules               *constraint.ModuleLoader // Chargeur de modules provisoire, conservé sur le réseau en cas de succès
 	program               *constraint.Program
 	reteProgram           interface{}
 	types                 []interface{}
@@ -29,12 +32,25 @@ type ingestionContext struct {
 	newTerminals          []*TerminalNode
 	hasResets             bool
 	tx                    *Transaction
+	previousTx            *Transaction                                                // Transaction annulable de l'ingestion précédente, validée en cas de succès
+	atomic                bool                                                        // Transaction annulable validée en fin d'ingestion, quel que soit SetUndoDepth
 	xupleManager          interface{}                                                 // Gestionnaire de xuples (créé si des xuple-spaces sont déclarés)
 	xupleSpaces           []interface{}                                               // Liste des xuple-spaces parsés depuis l'AST
 	onXupleSpacesDetected func(network *ReteNetwork, definitions []interface{}) error // Callback appelé après détection des xuple-spaces
 	retractedFactsIDs     map[string]bool                                             // IDs des faits rétractés pendant la soumission
 }
 
+// canceled retourne une erreur si le contexte d'annulation de l'ingestion est terminé
+func (ctx *ingestionContext) canceled() error {
+	if ctx.execCtx == nil {
+		return nil
+	}
+	if err := ctx.execCtx.Err(); err != nil {
+		return fmt.Errorf("ingestion interrompue: %w", err)
+	}
+	return nil
+}
+
 // beginIngestionTransaction démarre une transaction pour l'ingestion
 func (ctx *ingestionContext) beginIngestionTransaction(cp *ConstraintPipeline) error {
 	if ctx.network == nil {
@@ -113,14 +129,25 @@ func (cp *ConstraintPipeline) initializeNetworkWithReset(ctx *ingestionContext)
 
 	cp.GetLogger().Info("🔄 Commande reset détectée - Garbage Collection de l'ancien réseau")
 
+	// Les limites de faits en mémoire et de cascade survivent au reset
+	var factLimits FactLimits
+	var cascadeLimits CascadeLimits
 	if ctx.network != nil {
 		cp.GetLogger().Debug("🗑️ GC du réseau existant...")
+		factLimits = ctx.network.FactLimits()
+		cascadeLimits = ctx.network.CascadeLimits()
 		ctx.network.GarbageCollect()
 		cp.GetLogger().Debug("✅ GC terminé")
 	}
 
 	cp.GetLogger().Info("🆕 Création d'un nouveau réseau RETE")
 	ctx.network = NewReteNetwork(ctx.storage)
+	if err := ctx.network.SetFactLimits(factLimits); err != nil {
+		return err
+	}
+	if err := ctx.network.SetCascadeLimits(cascadeLimits); err != nil {
+		return err
+	}
 
 	if ctx.metrics != nil {
 		ctx.metrics.SetWasReset(true)
@@ -292,6 +319,7 @@ func (cp *ConstraintPipeline) manageRules(ctx *ingestionContext) error {
 		return fmt.Errorf("❌ Erreur traitement suppressions de règles: %w", err)
 	}
 
+	cp.warnSelfTriggeringRules(ctx)
 	return nil
 }
 
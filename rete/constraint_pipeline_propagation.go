@@ -4,10 +4,6 @@
 
 package rete
 
-import (
-	"fmt"
-)
-
 // identifyNewTerminals identifie les nœuds terminaux qui viennent d'être ajoutés
 func (cp *ConstraintPipeline) identifyNewTerminals(network *ReteNetwork, existingTerminals map[string]bool) []*TerminalNode {
 	var newTerminals []*TerminalNode
@@ -19,7 +15,11 @@ func (cp *ConstraintPipeline) identifyNewTerminals(network *ReteNetwork, existin
 	return newTerminals
 }
 
-// propagateToNewTerminals propage les faits existants uniquement vers les nouvelles chaînes de règles
+// propagateToNewTerminals propage les faits existants uniquement vers les nouvelles chaînes de règles.
+//
+// Chaque fait n'est propagé qu'une fois, et seulement vers les enfants de son
+// TypeNode qui mènent à une nouvelle règle : les règles existantes ne sont pas
+// redéclenchées, sauf si elles partagent ces nœuds avec la nouvelle règle.
 func (cp *ConstraintPipeline) propagateToNewTerminals(
 	network *ReteNetwork,
 	newTerminals []*TerminalNode,
@@ -27,33 +27,31 @@ func (cp *ConstraintPipeline) propagateToNewTerminals(
 ) int {
 	propagatedCount := 0
 
-	// Pour chaque nouveau terminal, identifier les types de faits qu'il attend
-	for _, terminal := range newTerminals {
-		// Identifier les types de faits attendus par cette règle
-		expectedTypes := cp.identifyExpectedTypesForTerminal(network, terminal)
-
-		// Propager uniquement les faits des types attendus
-		for _, typeName := range expectedTypes {
-			if facts, exists := factsByType[typeName]; exists {
-				for _, fact := range facts {
-					// Propager le fait via le TypeNode correspondant
-					if typeNode, exists := network.TypeNodes[typeName]; exists {
-						// Créer un token pour ce fait
-						token := &Token{
-							ID:     fmt.Sprintf("retro_%s_%s", typeName, fact.ID),
-							NodeID: typeNode.GetID(),
-							Facts:  []*Fact{fact},
-						}
-
-						// Propager aux enfants du TypeNode
-						err := typeNode.PropagateToChildren(fact, token)
-						if err == nil {
-							propagatedCount++
-						}
-					}
+	for typeName, typeNode := range network.TypeNodes {
+		facts := factsByType[typeName]
+		if len(facts) == 0 {
+			continue
+		}
+
+		// Enfants du TypeNode menant à au moins une nouvelle règle
+		var children []Node
+		for _, child := range typeNode.GetChildren() {
+			for _, terminal := range newTerminals {
+				if cp.isTerminalReachableFrom(child, terminal.GetID()) {
+					children = append(children, child)
+					break
 				}
 			}
 		}
+		if len(children) == 0 {
+			continue
+		}
+
+		for _, fact := range facts {
+			if err := typeNode.activateChildren(fact, children); err == nil {
+				propagatedCount++
+			}
+		}
 	}
 
 	return propagatedCount
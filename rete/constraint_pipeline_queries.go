@@ -0,0 +1,92 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// queryActionType est le type de l'action factice portée par le terminal d'une requête
+const queryActionType = "query"
+
+// createQueryNodes compile les requêtes déclarées dans le réseau.
+// Chaque requête devient une branche de règle dont le terminal conserve les tokens.
+func (cp *ConstraintPipeline) createQueryNodes(network *ReteNetwork, queries []constraint.QueryDefinition, storage Storage) error {
+	if network.Queries == nil {
+		network.Queries = make(map[string]*Query)
+	}
+
+	for _, def := range queries {
+		if _, exists := network.Queries[def.Name]; exists {
+			return fmt.Errorf("requête '%s' déjà définie dans le réseau", def.Name)
+		}
+
+		compiled, deferred := splitQueryConstraints(def.Constraints, def.Parameters)
+		exprMap, err := queryExpressionMap(def, compiled)
+		if err != nil {
+			return fmt.Errorf("requête '%s': %w", def.Name, err)
+		}
+
+		ruleID := QueryRuleIDPrefix + def.Name
+		if err := cp.createSingleRule(network, ruleID, exprMap, storage); err != nil {
+			return fmt.Errorf("erreur création requête %s: %w", def.Name, err)
+		}
+
+		terminal, exists := network.TerminalNodes[ruleID+"_terminal"]
+		if !exists {
+			return fmt.Errorf("requête '%s': terminal introuvable après compilation", def.Name)
+		}
+
+		network.Queries[def.Name] = newQuery(def, deferred, terminal)
+		cp.GetLogger().Info("   ✓ Requête créée: %s", def.Name)
+	}
+
+	return nil
+}
+
+// queryExpressionMap convertit une requête en expression au format RETE,
+// avec une action factice que le terminal en mode requête n'exécute jamais.
+func queryExpressionMap(def constraint.QueryDefinition, constraints interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(def.QueryExpression(constraints))
+	if err != nil {
+		return nil, fmt.Errorf("erreur conversion JSON: %w", err)
+	}
+
+	var exprMap map[string]interface{}
+	if err := json.Unmarshal(data, &exprMap); err != nil {
+		return nil, fmt.Errorf("erreur parsing JSON: %w", err)
+	}
+	// Sans positions, les conditions d'une requête partagent les nœuds des règles
+	exprMap = constraint.StripPositions(exprMap).(map[string]interface{})
+
+	if constraints == nil {
+		delete(exprMap, "constraints")
+	}
+	if len(def.Patterns) == 0 {
+		delete(exprMap, "patterns")
+	}
+	exprMap["action"] = map[string]interface{}{"type": queryActionType}
+	return exprMap, nil
+}
+
+// GetQuery retourne une requête compilée par son nom
+func (rn *ReteNetwork) GetQuery(name string) (*Query, bool) {
+	query, exists := rn.Queries[name]
+	return query, exists
+}
+
+// QueryNames retourne les noms triés des requêtes compilées dans le réseau
+func (rn *ReteNetwork) QueryNames() []string {
+	names := make([]string, 0, len(rn.Queries))
+	for name := range rn.Queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
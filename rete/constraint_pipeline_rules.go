@@ -0,0 +1,117 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ReplaceRulesContext remplace des règles du réseau : les règles removed sont
+// supprimées (RemoveRule), puis addition est ingérée comme par
+// IngestProgramContext, ses règles étant propagées aux faits existants. Les
+// ingestions encore annulables (SetUndoDepth) sont validées au préalable.
+//
+// Le remplacement est atomique. En cas d'erreur ou d'annulation de execCtx,
+// l'ingestion de addition est annulée dans le réseau (RollbackTransaction :
+// faits modifiés par les actions, règles ajoutées), puis les règles
+// supprimées sont réingérées sans exécuter d'action, leurs correspondances
+// étant reconstruites à partir de la mémoire de travail.
+func (cp *ConstraintPipeline) ReplaceRulesContext(execCtx context.Context, removed []string, addition map[string]interface{}, network *ReteNetwork, storage Storage) (*IngestionMetrics, error) {
+	if err := network.commitUndoPoints(); err != nil {
+		return nil, fmt.Errorf("validation des ingestions annulables: %w", err)
+	}
+
+	previous := network.ruleExpressions()
+	var restored []interface{}
+	for _, ruleID := range removed {
+		if err := network.RemoveRule(ruleID); err != nil {
+			return nil, cp.restoreRules(network, storage, restored, err)
+		}
+		if expression, exists := previous[ruleID]; exists {
+			restored = append(restored, expression)
+		}
+	}
+
+	metrics, err := cp.ingestAtomic(execCtx, "règles", addition, network, storage)
+	if err != nil {
+		return metrics, cp.restoreRules(network, storage, restored, err)
+	}
+	return metrics, nil
+}
+
+// ingestAtomic ingère un programme dans une transaction annulable validée en
+// cas de succès : en cas d'échec, les mémoires du réseau sont restaurées et
+// les règles ajoutées supprimées, pas seulement le storage
+func (cp *ConstraintPipeline) ingestAtomic(execCtx context.Context, name string, program map[string]interface{}, network *ReteNetwork, storage Storage) (*IngestionMetrics, error) {
+	cp.logger.Info("========================================")
+	cp.logger.Info("📁 Ingestion incrémentale: %s", name)
+
+	ctx := &ingestionContext{
+		execCtx:               execCtx,
+		filename:              name,
+		network:               network,
+		storage:               storage,
+		metrics:               NewMetricsCollector(),
+		parsedAST:             program,
+		onXupleSpacesDetected: cp.onXupleSpacesDetected,
+		atomic:                true,
+	}
+
+	if err := cp.executePipeline(ctx); err != nil {
+		_, metrics, err := cp.handlePipelineError(ctx, err)
+		return metrics, err
+	}
+	return ctx.metrics.Finalize(), nil
+}
+
+// restoreRules réingère sans exécuter d'action les règles supprimées par un
+// remplacement en échec, puis retourne cause
+func (cp *ConstraintPipeline) restoreRules(network *ReteNetwork, storage Storage, rules []interface{}, cause error) error {
+	if len(rules) == 0 {
+		return cause
+	}
+
+	network.seeding.Store(true)
+	defer network.seeding.Store(false)
+	program := map[string]interface{}{
+		"types":       []interface{}{},
+		"actions":     []interface{}{},
+		"queries":     []interface{}{},
+		"expressions": rules,
+	}
+	// La restauration ne doit pas être interrompue par l'annulation qui l'a provoquée
+	if _, err := cp.ingestAtomic(context.Background(), "règles restaurées", program, network, storage); err != nil {
+		return fmt.Errorf("%w; restauration des règles supprimées: %v", cause, err)
+	}
+	return cause
+}
+
+// ruleExpressions indexe par identifiant les déclarations (format AST) des
+// règles présentes dans le réseau
+func (rn *ReteNetwork) ruleExpressions() map[string]interface{} {
+	rules := make(map[string]interface{})
+	expressions, _ := rn.Definitions()["expressions"].([]interface{})
+	for _, expression := range expressions {
+		exprMap, _ := expression.(map[string]interface{})
+		if id, _ := exprMap["ruleId"].(string); id != "" {
+			rules[id] = expression
+		}
+	}
+	return rules
+}
+
+// commitUndoPoints valide la transaction annulable courante et celles
+// conservées par PushUndoPoint : elles ne pourraient plus être annulées
+// correctement une fois les règles du réseau remplacées
+func (rn *ReteNetwork) commitUndoPoints() error {
+	var errs []error
+	if tx := rn.GetTransaction(); tx != nil && tx.IsActive {
+		errs = append(errs, tx.Commit())
+	}
+	errs = append(errs, rn.PushUndoPoint(nil, 0))
+	return errors.Join(errs...)
+}
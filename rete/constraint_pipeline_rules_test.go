@@ -0,0 +1,103 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// loadTestProgram charge un programme TSD sous forme d'AST
+func loadTestProgram(t *testing.T, program string) map[string]interface{} {
+	t.Helper()
+	tsdFile := filepath.Join(t.TempDir(), "rules.tsd")
+	if err := os.WriteFile(tsdFile, []byte(program), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	ast, err := constraint.NewModuleLoader().Load(tsdFile)
+	if err != nil {
+		t.Fatalf("❌ Erreur de chargement: %v", err)
+	}
+	return ast
+}
+
+// cancelingCounter compte les exécutions d'actions par règle et annule un
+// contexte à la première exécution de la règle rule
+type cancelingCounter struct {
+	ruleCounter
+	rule   string
+	cancel context.CancelFunc
+}
+
+func (c cancelingCounter) OnActionExecuted(result ExecutionResult) {
+	c.ruleCounter.OnActionExecuted(result)
+	if result.Context.RuleName == c.rule {
+		c.cancel()
+	}
+}
+
+const replaceRulesTestProgram = `type Order(#id: string, total: number)
+action notify(id: string)
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+Order(id: "o1", total: 200)
+Order(id: "o2", total: 50)
+`
+
+func TestReplaceRules(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, replaceRulesTestProgram)
+	counter := ruleCounter{}
+	network.SetActionObserver(counter)
+
+	addition := loadTestProgram(t, `rule big : {o: Order} / o.total > 10 ==> notify(o.id)
+`)
+	if _, err := NewConstraintPipeline().ReplaceRulesContext(context.Background(), []string{"big"}, addition, network, storage); err != nil {
+		t.Fatalf("ReplaceRulesContext() error = %v", err)
+	}
+	// La règle recompilée est propagée aux deux commandes existantes
+	if counter["big"] != 2 {
+		t.Errorf("activations = %v, want big: 2", counter)
+	}
+	if storage.GetFact("Order~o1") == nil || storage.GetFact("Order~o2") == nil {
+		t.Error("working memory must be kept by a rule replacement")
+	}
+}
+
+func TestReplaceRules_FailureRestoresRemovedRules(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, replaceRulesTestProgram)
+	ctx, cancel := context.WithCancel(context.Background())
+	counter := cancelingCounter{ruleCounter: ruleCounter{}, rule: "any", cancel: cancel}
+	network.SetActionObserver(counter)
+
+	addition := loadTestProgram(t, `rule any : {o: Order} / o.total > 0 ==> notify(o.id)
+`)
+	_, err := NewConstraintPipeline().ReplaceRulesContext(ctx, []string{"big"}, addition, network, storage)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReplaceRulesContext() error = %v, want context.Canceled", err)
+	}
+
+	if network.findRuleTerminal("any") != nil {
+		t.Error("rule added by a failed replacement must be removed")
+	}
+	if network.findRuleTerminal("big") == nil {
+		t.Fatal("rule removed by a failed replacement must be restored")
+	}
+	// La restauration reconstruit les correspondances sans exécuter d'action
+	if counter.ruleCounter["big"] != 0 {
+		t.Errorf("restored rule fired %d time(s) on existing facts", counter.ruleCounter["big"])
+	}
+
+	submitTestFact(t, network, &Fact{ID: "Order~o3", Type: "Order",
+		Fields: map[string]interface{}{"id": "o3", "total": 300.0}})
+	if counter.ruleCounter["big"] != 1 {
+		t.Errorf("activations = %v, want big: 1 for the new order", counter.ruleCounter)
+	}
+}
@@ -686,6 +686,34 @@ func TestPropagateToNewTerminals(t *testing.T) {
 	})
 }
 
+// ruleCounter compte les exécutions d'actions par règle
+type ruleCounter map[string]int
+
+func (c ruleCounter) OnActionExecuted(result ExecutionResult) {
+	c[result.Context.RuleName]++
+}
+
+// TestPropagateToNewTerminals_ExistingRulesNotRefired vérifie qu'une règle
+// ajoutée reçoit chaque fait existant une fois sans redéclencher les autres
+func TestPropagateToNewTerminals_ExistingRulesNotRefired(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, `type Order(#id: string, total: number)
+action notify(id: string)
+rule keep : {o: Order} / o.total > 1 ==> notify(o.id)
+Order(id: "o1", total: 50)
+`)
+	counter := ruleCounter{}
+	network.SetActionObserver(counter)
+
+	ingestQueryProgram(t, network, storage, `rule big : {o: Order} / o.total > 20 ==> notify(o.id)
+rule medium : {o: Order} / o.total > 40 ==> notify(o.id)
+`)
+	want := ruleCounter{"big": 1, "medium": 1}
+	if len(counter) != len(want) || counter["big"] != 1 || counter["medium"] != 1 {
+		t.Errorf("activations = %v, want %v", counter, want)
+	}
+}
+
 // TestOrganizeFactsByType tests the organizeFactsByType helper function
 func TestOrganizeFactsByType(t *testing.T) {
 	pipeline := NewConstraintPipeline()
@@ -1457,3 +1485,71 @@ func TestProcessRuleRemovals(t *testing.T) {
 		}
 	})
 }
+
+// TestIngestFile_Imports vérifie le chargement des imports et des packages
+func TestIngestFile_Imports(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"common/types.tsd": `type Customer(#id: string, name: string)`,
+		"billing/invoice.tsd": `package billing
+import "../common/types.tsd"
+type Invoice(#id: string, customer: string, amount: number)
+query large(min: number) : {i: Invoice} / i.amount > min
+`,
+		"main.tsd": `import "billing/invoice.tsd"
+Customer(id: "c1", name: "Ann")
+billing.Invoice(id: "i1", customer: "c1", amount: 500)
+`,
+		"more.tsd": `import "common/types.tsd"
+Customer(id: "c2", name: "Bob")
+`,
+		"broken.tsd": `import "billing/invoice.tsd"
+Unknown(id: "x")
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	storage := NewMemoryStorage()
+	pipeline := NewConstraintPipeline()
+	network, _, err := pipeline.IngestFile(filepath.Join(tmpDir, "main.tsd"), nil, storage)
+	if err != nil {
+		t.Fatalf("IngestFile() error = %v", err)
+	}
+	if _, exists := network.TypeNodes["billing.Invoice"]; !exists {
+		t.Error("type billing.Invoice should be qualified by its package")
+	}
+	if _, exists := network.GetQuery("billing.large"); !exists {
+		t.Error("query billing.large should be qualified by its package")
+	}
+	if network.Modules == nil || network.Modules.LoadedFiles() != 3 {
+		t.Fatalf("network should remember the loaded modules")
+	}
+
+	// Une ingestion en échec ne modifie pas les modules connus du réseau
+	if _, _, err := pipeline.IngestFile(filepath.Join(tmpDir, "broken.tsd"), network, storage); err == nil {
+		t.Fatal("IngestFile() should fail on an undefined type")
+	}
+	if network.Modules.LoadedFiles() != 3 {
+		t.Errorf("LoadedFiles() = %d after failed ingestion, want 3", network.Modules.LoadedFiles())
+	}
+
+	// Un import déjà chargé dans le réseau n'est pas réingéré
+	metricsNetwork, metrics, err := pipeline.IngestFile(filepath.Join(tmpDir, "more.tsd"), network, storage)
+	if err != nil {
+		t.Fatalf("IngestFile() error = %v", err)
+	}
+	if metrics.TypesAdded != 0 {
+		t.Errorf("TypesAdded = %d, want 0", metrics.TypesAdded)
+	}
+	if got := len(metricsNetwork.Storage.GetAllFacts()); got != 3 {
+		t.Errorf("facts = %d, want 3", got)
+	}
+}
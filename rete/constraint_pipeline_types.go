@@ -6,12 +6,16 @@ package rete
 
 // AggregationInfo contient les informations extraites d'une agrégation
 type AggregationInfo struct {
-	Function      string      // AVG, SUM, COUNT, MIN, MAX
+	Function      string      // AVG, SUM, COUNT, MIN, MAX, MEDIAN, ... (voir NewAggregateState)
+	ResultVar     string      // Variable liée au résultat (ex: "avg_sal")
 	MainVariable  string      // Variable principale (ex: "e" pour Employee)
 	MainType      string      // Type principal (ex: "Employee")
 	AggVariable   string      // Variable à agréger (ex: "p" pour Performance)
 	AggType       string      // Type à agréger (ex: "Performance")
 	Field         string      // Champ à agréger (ex: "score")
+	OrderField    string      // Champ d'ordre de FIRST et LAST
+	Percentile    float64     // Rang de PERCENTILE (0 à 100)
+	Separator     string      // Séparateur de STRING_AGG
 	Operator      string      // Opérateur de comparaison (>=, >, etc.)
 	Threshold     float64     // Valeur de seuil
 	JoinField     string      // Champ de jointure dans faits agrégés (ex: "employee_id")
@@ -24,14 +28,46 @@ type AggregationInfo struct {
 	JoinConditions  []JoinCondition       // Join conditions between patterns
 }
 
+// applySpec reprend les paramètres de fonction d'une description d'agrégation
+func (ai *AggregationInfo) applySpec(spec AggregateSpec) {
+	ai.OrderField = spec.OrderField
+	ai.Percentile = spec.Percentile
+	ai.Separator = spec.Separator
+}
+
+// Spec retourne la description de la fonction d'agrégation
+func (ai *AggregationInfo) Spec() AggregateSpec {
+	return AggregateSpec{
+		Function:   ai.Function,
+		Field:      ai.Field,
+		OrderField: ai.OrderField,
+		Percentile: ai.Percentile,
+		Separator:  ai.Separator,
+	}
+}
+
 // AggregationVariable represents a single aggregation variable
 type AggregationVariable struct {
-	Name      string  // Variable name (ex: "avg_sal")
-	Function  string  // AVG, SUM, COUNT, MIN, MAX
-	SourceVar string  // Source variable (ex: "e")
-	Field     string  // Field to aggregate (ex: "salary")
-	Operator  string  // Threshold operator (>=, >, etc.)
-	Threshold float64 // Threshold value
+	Name       string  // Variable name (ex: "avg_sal")
+	Function   string  // AVG, SUM, COUNT, MEDIAN, PERCENTILE, FIRST, STRING_AGG, ...
+	SourceVar  string  // Source variable (ex: "e")
+	Field      string  // Field to aggregate (ex: "salary")
+	OrderField string  // Ordering field for FIRST/LAST (ex: "date")
+	Percentile float64 // Rank for PERCENTILE, between 0 and 100
+	Separator  string  // Separator for STRING_AGG
+	Operator   string  // Threshold operator (>=, >, etc.)
+	Threshold  float64 // Threshold value
+}
+
+// Spec returns the aggregate function description of the variable
+func (av AggregationVariable) Spec() AggregateSpec {
+	return AggregateSpec{
+		Function:   av.Function,
+		Field:      av.Field,
+		OrderField: av.OrderField,
+		Percentile: av.Percentile,
+		Separator:  av.Separator,
+	}
 }
 
 // SourcePattern represents a pattern block in multi-source aggregation
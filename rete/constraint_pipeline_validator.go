@@ -154,13 +154,15 @@ func (cp *ConstraintPipeline) validateAggregationInfo(aggInfo *AggregationInfo)
 		return fmt.Errorf("fonction d'agrégation vide")
 	}
 
-	validFunctions := map[string]bool{
-		"AVG": true, "SUM": true, "COUNT": true,
-		"MIN": true, "MAX": true, "ACCUMULATE": true,
-	}
-	if !validFunctions[aggInfo.Function] {
+	if !IsSupportedAggregate(aggInfo.Function) && aggInfo.Function != "ACCUMULATE" {
 		return fmt.Errorf("fonction d'agrégation invalide: %s", aggInfo.Function)
 	}
+	if aggInfo.Function == AggregatePercentile && (aggInfo.Percentile < 0 || aggInfo.Percentile > 100) {
+		return fmt.Errorf("rang de PERCENTILE hors de [0, 100]: %v", aggInfo.Percentile)
+	}
+	if (aggInfo.Function == AggregateFirst || aggInfo.Function == AggregateLast) && aggInfo.OrderField == "" {
+		return fmt.Errorf("%s nécessite un champ d'ordre", aggInfo.Function)
+	}
 
 	// Vérifier l'opérateur
 	if aggInfo.Operator == "" {
@@ -111,16 +111,10 @@ func (ac *ASTConverter) convertAction(constraintAction constraint.Action) (*Acti
 	// Obtenir tous les jobs (supporte ancien et nouveau format)
 	jobs := constraintAction.GetJobs()
 
+	reteJobs := convertJobCalls(jobs)
+
 	// Si on a plusieurs jobs, utiliser le nouveau format
-	if len(jobs) > 1 {
-		reteJobs := make([]JobCall, len(jobs))
-		for i, job := range jobs {
-			reteJobs[i] = JobCall{
-				Type: job.Type,
-				Name: job.Name,
-				Args: job.Args,
-			}
-		}
+	if len(reteJobs) > 1 {
 		return &Action{
 			Type: constraintAction.Type,
 			Jobs: reteJobs,
@@ -128,14 +122,10 @@ func (ac *ASTConverter) convertAction(constraintAction constraint.Action) (*Acti
 	}
 
 	// Si on a un seul job, utiliser l'ancien format pour rétrocompatibilité
-	if len(jobs) == 1 {
+	if len(reteJobs) == 1 {
 		return &Action{
 			Type: constraintAction.Type,
-			Job: &JobCall{
-				Type: jobs[0].Type,
-				Name: jobs[0].Name,
-				Args: jobs[0].Args,
-			},
+			Job:  &reteJobs[0],
 		}, nil
 	}
 
@@ -144,3 +134,23 @@ func (ac *ASTConverter) convertAction(constraintAction constraint.Action) (*Acti
 		Type: constraintAction.Type,
 	}, nil
 }
+
+// convertJobCalls convertit une liste d'instructions d'action, y compris les blocs let et if
+func convertJobCalls(jobs []constraint.JobCall) []JobCall {
+	if len(jobs) == 0 {
+		return nil
+	}
+	reteJobs := make([]JobCall, len(jobs))
+	for i, job := range jobs {
+		reteJobs[i] = JobCall{
+			Type:      job.Type,
+			Name:      job.Name,
+			Args:      job.Args,
+			Value:     job.Value,
+			Condition: job.Condition,
+			Then:      convertJobCalls(job.Then),
+			Else:      convertJobCalls(job.Else),
+		}
+	}
+	return reteJobs
+}
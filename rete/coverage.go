@@ -0,0 +1,142 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"sort"
+	"sync"
+)
+
+// Couverture des règles : quelles règles se sont déclenchées et quelles
+// conditions ont été évaluées, à partir des spans de propagation.
+//
+// Un nœud de condition est « évalué » lorsqu'il reçoit un fait ou un token et
+// « franchi » lorsqu'il propage au moins une fois vers un enfant. Les nœuds
+// partagés entre plusieurs règles comptent pour chacune d'elles.
+
+// ConditionCoverage décrit la couverture d'un nœud de condition d'une règle
+type ConditionCoverage struct {
+	NodeID     string   `json:"node_id"`
+	Kind       string   `json:"kind"` // alpha, join, exists, forall, accumulate, collect
+	Variables  []string `json:"variables,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+	Evaluated  int      `json:"evaluated"` // Activations reçues par le nœud
+	Passed     int      `json:"passed"`    // Activations propagées vers un enfant
+}
+
+// RuleCoverage décrit la couverture d'une règle
+type RuleCoverage struct {
+	Rule       string              `json:"rule"`
+	Fired      int                 `json:"fired"` // Activations du nœud terminal
+	Conditions []ConditionCoverage `json:"conditions"`
+}
+
+// Covered indique si la règle s'est déclenchée et si chaque condition a été franchie
+func (c *RuleCoverage) Covered() bool {
+	if c.Fired == 0 {
+		return false
+	}
+	for _, condition := range c.Conditions {
+		if condition.Passed == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CoverageRecorder est un exporteur de spans qui compte les activations de
+// chaque nœud. Il s'installe avec un traceur :
+//
+//	recorder := rete.NewCoverageRecorder()
+//	network.SetTracer(rete.NewTracer(recorder))
+//	// ... soumission des faits ...
+//	coverage := network.RuleCoverage(recorder)
+type CoverageRecorder struct {
+	mutex     sync.Mutex
+	evaluated map[string]int
+	passed    map[string]int
+}
+
+// NewCoverageRecorder crée un enregistreur de couverture vide
+func NewCoverageRecorder() *CoverageRecorder {
+	return &CoverageRecorder{evaluated: make(map[string]int), passed: make(map[string]int)}
+}
+
+// ExportSpans compte les spans de nœuds. Un span parent et ses enfants sont
+// toujours exportés dans le même lot (le traceur n'exporte qu'une fois la
+// pile des spans actifs vide).
+func (c *CoverageRecorder) ExportSpans(spans []*Span) error {
+	nodes := make(map[string]string, len(spans))
+	for _, span := range spans {
+		if nodeID, ok := span.Attributes["rete.node.id"].(string); ok {
+			nodes[span.SpanID] = nodeID
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	propagated := make(map[string]bool)
+	for _, span := range spans {
+		if nodeID, ok := nodes[span.SpanID]; ok {
+			c.evaluated[nodeID]++
+		}
+		if nodeID, ok := nodes[span.ParentSpanID]; ok && !propagated[span.ParentSpanID] {
+			propagated[span.ParentSpanID] = true
+			c.passed[nodeID]++
+		}
+	}
+	return nil
+}
+
+// counts retourne le nombre d'activations reçues et propagées d'un nœud
+func (c *CoverageRecorder) counts(nodeID string) (evaluated, passed int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.evaluated[nodeID], c.passed[nodeID]
+}
+
+// RuleCoverage retourne la couverture de chaque règle du réseau (hors
+// requêtes), triée par nom, d'après les spans enregistrés. Le traceur du
+// réseau est vidé au préalable.
+func (rn *ReteNetwork) RuleCoverage(recorder *CoverageRecorder) []RuleCoverage {
+	if rn.tracer != nil {
+		_ = rn.tracer.Flush()
+	}
+
+	env := explainEnv{network: rn}
+	coverage := make([]RuleCoverage, 0, len(rn.TerminalNodes))
+	for _, terminal := range rn.TerminalNodes {
+		if terminal.IsQuery() {
+			continue
+		}
+		rule := RuleCoverage{Rule: terminal.getRuleName(), Conditions: []ConditionCoverage{}}
+		rule.Fired, _ = recorder.counts(terminal.GetID())
+
+		chain, _ := rn.ruleNodeChain(terminal)
+		for _, node := range chain {
+			// Sans fait fourni, l'explication donne le type et les variables du nœud
+			step, ok := env.explainNode(node)
+			if !ok {
+				continue
+			}
+			condition := ConditionCoverage{NodeID: node.GetID(), Kind: step.Kind, Variables: step.Variables}
+			switch n := node.(type) {
+			case *AlphaNode:
+				// Règle sans condition : le filtre accepte tous les faits du type
+				if simple, _ := n.Condition.(map[string]interface{}); simple["type"] == ConditionTypeSimple {
+					continue
+				}
+				condition.Expression = conditionText(n.Condition)
+			case *JoinNode:
+				condition.Expression = conditionText(n.unwrapCompositeCondition())
+			}
+			condition.Evaluated, condition.Passed = recorder.counts(condition.NodeID)
+			rule.Conditions = append(rule.Conditions, condition)
+		}
+		coverage = append(coverage, rule)
+	}
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Rule < coverage[j].Rule })
+	return coverage
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import "testing"
+
+const coverageProgram = `type Customer(#id: string)
+type Order(#id: string, customer: string, total: number)
+action notify(id: string)
+
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+rule owned : {c: Customer, o: Order} / o.customer == c.id ==> notify(o.id)
+`
+
+func TestRuleCoverage(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, coverageProgram)
+	recorder := NewCoverageRecorder()
+	network.SetTracer(NewTracer(recorder))
+
+	for _, fact := range []*Fact{
+		{ID: "Order~o1", Type: "Order", Fields: map[string]interface{}{"id": "o1", "customer": "c1", "total": 50.0}},
+		{ID: "Order~o2", Type: "Order", Fields: map[string]interface{}{"id": "o2", "customer": "c1", "total": 150.0}},
+	} {
+		if err := network.SubmitFact(fact); err != nil {
+			t.Fatalf("SubmitFact() error = %v", err)
+		}
+	}
+
+	coverage := network.RuleCoverage(recorder)
+	if len(coverage) != 2 || coverage[0].Rule != "big" || coverage[1].Rule != "owned" {
+		t.Fatalf("RuleCoverage() = %+v, want big and owned", coverage)
+	}
+
+	big := coverage[0]
+	if big.Fired != 1 || len(big.Conditions) != 1 {
+		t.Fatalf("big = %+v, want 1 activation and 1 condition", big)
+	}
+	if condition := big.Conditions[0]; condition.Kind != ExplainStepAlpha || condition.Evaluated != 2 ||
+		condition.Passed != 1 || condition.Expression == "" {
+		t.Errorf("big condition = %+v, want alpha evaluated twice and passed once", condition)
+	}
+	if !big.Covered() {
+		t.Errorf("big should be covered")
+	}
+
+	// Sans client, la jointure reçoit les commandes mais ne propage rien
+	owned := coverage[1]
+	var join *ConditionCoverage
+	for i := range owned.Conditions {
+		if owned.Conditions[i].Kind == ExplainStepJoin {
+			join = &owned.Conditions[i]
+		}
+	}
+	if join == nil || join.Evaluated == 0 || join.Passed != 0 {
+		t.Errorf("owned conditions = %+v, want a join evaluated but never passed", owned.Conditions)
+	}
+	if owned.Fired != 0 || owned.Covered() {
+		t.Errorf("owned = %+v, want not fired nor covered", owned)
+	}
+}
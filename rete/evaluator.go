@@ -14,7 +14,9 @@ package rete
 //   - evaluator_functions.go: Fonctions intégrées (LENGTH, UPPER, ABS, etc.)
 type AlphaConditionEvaluator struct {
 	variableBindings    map[string]*Fact
-	partialEvalMode     bool // Mode d'évaluation partielle pour les jointures en cascade
+	collectionBindings  map[string][]*Fact     // Listes liées par COLLECT
+	valueBindings       map[string]interface{} // Valeurs liées par let dans une action
+	partialEvalMode     bool                   // Mode d'évaluation partielle pour les jointures en cascade
 	fieldResolver       *FieldResolver
 	comparisonEvaluator *ComparisonEvaluator
 }
@@ -23,6 +25,8 @@ type AlphaConditionEvaluator struct {
 func NewAlphaConditionEvaluator() *AlphaConditionEvaluator {
 	return &AlphaConditionEvaluator{
 		variableBindings:    make(map[string]*Fact),
+		collectionBindings:  make(map[string][]*Fact),
+		valueBindings:       make(map[string]interface{}),
 		partialEvalMode:     false,
 		fieldResolver:       nil,
 		comparisonEvaluator: nil,
@@ -66,6 +70,8 @@ func (e *AlphaConditionEvaluator) EvaluateCondition(condition interface{}, fact
 // Utilisé pour réinitialiser l'évaluateur entre différentes évaluations.
 func (e *AlphaConditionEvaluator) ClearBindings() {
 	e.variableBindings = make(map[string]*Fact)
+	e.collectionBindings = make(map[string][]*Fact)
+	e.valueBindings = make(map[string]interface{})
 }
 
 // SetPartialEvalMode active ou désactive le mode d'évaluation partielle.
@@ -40,6 +40,21 @@ func (e *AlphaConditionEvaluator) compareValues(left interface{}, operator strin
 		)
 	}
 
+	// Référence comparée au fait lui-même (o.customer == c) : le champ
+	// contient l'identifiant du fait référencé, comme pour les conditions de
+	// jointure (champ _id_). Seules l'égalité et la différence ont un sens.
+	if isEqualityOperator(operator) {
+		if leftIsFact && !rightIsFact {
+			if _, isString := right.(string); isString {
+				left = leftFact.ID
+			}
+		} else if rightIsFact && !leftIsFact {
+			if _, isString := left.(string); isString {
+				right = rightFact.ID
+			}
+		}
+	}
+
 	// Normaliser les valeurs numériques
 	leftVal := e.normalizeValue(left)
 	rightVal := e.normalizeValue(right)
@@ -124,3 +139,8 @@ func (e *AlphaConditionEvaluator) isGreater(left, right interface{}) (bool, erro
 	}
 	return false, fmt.Errorf("impossible de comparer %T avec %T", left, right)
 }
+
+// isEqualityOperator indique si l'opérateur teste l'égalité ou la différence
+func isEqualityOperator(operator string) bool {
+	return operator == "==" || operator == "!=" || operator == "<>"
+}
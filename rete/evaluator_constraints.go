@@ -64,6 +64,8 @@ func (e *AlphaConditionEvaluator) evaluateConstraintMapInternal(actualConstraint
 			return e.evaluateLogicalExpressionMap(actualConstraint)
 		case "existsConstraint":
 			return e.evaluateExistsConstraint(actualConstraint)
+		case "forallConstraint":
+			return false, errForallNotCompiled
 		case "notConstraint":
 			return e.evaluateNotConstraint(actualConstraint)
 		case "negation":
@@ -125,6 +127,9 @@ func (e *AlphaConditionEvaluator) evaluateNotConstraint(expr map[string]interfac
 	return !result, nil
 }
 
+// errForallNotCompiled signale un FORALL placé hors du premier niveau des contraintes d'une règle
+var errForallNotCompiled = fmt.Errorf("FORALL n'est supporté qu'au premier niveau des contraintes d'une règle, combiné par AND")
+
 // evaluateExistsConstraint évalue une contrainte EXISTS
 func (e *AlphaConditionEvaluator) evaluateExistsConstraint(expr map[string]interface{}) (bool, error) {
 	// Note: L'évaluation réelle EXISTS est gérée par les ExistsNodes dans le réseau RETE
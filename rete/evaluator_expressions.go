@@ -54,6 +54,9 @@ func (e *AlphaConditionEvaluator) evaluateMapExpression(expr map[string]interfac
 	case "existsConstraint":
 		// Traitement spécial pour les contraintes EXISTS
 		return e.evaluateExistsConstraint(expr)
+	case "forallConstraint":
+		// FORALL est compilé en ExistsNode négatif, jamais évalué directement
+		return false, errForallNotCompiled
 	case "booleanLiteral":
 		value, ok := expr["value"].(bool)
 		if !ok {
@@ -40,7 +40,11 @@ func (e *AlphaConditionEvaluator) evaluateFunctionCall(val map[string]interface{
 		evaluatedArgs[i] = evaluatedArg
 	}
 
-	// Appeler la fonction appropriée
+	return e.callFunction(functionName, evaluatedArgs)
+}
+
+// callFunction appelle une fonction intégrée avec des arguments déjà évalués
+func (e *AlphaConditionEvaluator) callFunction(functionName string, evaluatedArgs []interface{}) (interface{}, error) {
 	switch functionName {
 	case "LENGTH":
 		return e.evaluateLength(evaluatedArgs)
@@ -65,18 +69,22 @@ func (e *AlphaConditionEvaluator) evaluateFunctionCall(val map[string]interface{
 	}
 }
 
-// evaluateLength retourne la longueur d'une chaîne
+// evaluateLength retourne la longueur d'une chaîne ou le nombre d'éléments d'une liste
 func (e *AlphaConditionEvaluator) evaluateLength(args []interface{}) (interface{}, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("LENGTH() attend 1 argument, reçu %d", len(args))
 	}
 
-	str, ok := args[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("LENGTH() nécessite une chaîne, reçu: %T", args[0])
+	switch value := args[0].(type) {
+	case string:
+		return float64(len(value)), nil
+	case []*Fact:
+		return float64(len(value)), nil
+	case []interface{}:
+		return float64(len(value)), nil
+	default:
+		return nil, fmt.Errorf("LENGTH() nécessite une chaîne ou une liste, reçu: %T", args[0])
 	}
-
-	return float64(len(str)), nil
 }
 
 // evaluateUpper convertit une chaîne en majuscules
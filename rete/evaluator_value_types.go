@@ -98,6 +98,18 @@ func (e *AlphaConditionEvaluator) evaluateCastValue(val map[string]interface{})
 	return e.evaluateCastExpression(val)
 }
 
+// evaluateTernaryValue évalue une valeur de type "ternary" (condition ? then : else)
+func (e *AlphaConditionEvaluator) evaluateTernaryValue(val map[string]interface{}) (interface{}, error) {
+	condition, err := e.evaluateExpression(val["condition"])
+	if err != nil {
+		return nil, fmt.Errorf("erreur évaluation condition ternaire: %w", err)
+	}
+	if condition {
+		return e.evaluateValue(val["then"])
+	}
+	return e.evaluateValue(val["else"])
+}
+
 // evaluateBinaryOpValue évalue une valeur de type "binaryOp", "binary_operation", ou "binaryOperation"
 func (e *AlphaConditionEvaluator) evaluateBinaryOpValue(val map[string]interface{}) (interface{}, error) {
 	// Extraire et normaliser l'opérateur en utilisant l'utilitaire centralisé
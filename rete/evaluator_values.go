@@ -72,6 +72,8 @@ func (e *AlphaConditionEvaluator) evaluateValueFromMap(val map[string]interface{
 		return e.evaluateCastValue(val)
 	case "binaryOp", "binary_operation", "binaryOperation":
 		return e.evaluateBinaryOpValue(val)
+	case "ternary":
+		return e.evaluateTernaryValue(val)
 	default:
 		return nil, fmt.Errorf("type de valeur non supporté: %s", valType)
 	}
@@ -108,8 +110,12 @@ func (e *AlphaConditionEvaluator) evaluateFieldAccessByName(object, field string
 		)
 	}
 
-	// Cas spécial : le champ 'id' retourne l'ID interne du fait
+	// Cas spécial : le champ 'id' retourne l'ID interne du fait,
+	// sauf si le type déclare lui-même un champ 'id'
 	if field == "id" {
+		if value, declared := fact.Fields[field]; declared {
+			return value, nil
+		}
 		return fact.ID, nil
 	}
 
@@ -140,6 +146,12 @@ func (e *AlphaConditionEvaluator) evaluateVariableByName(name string) (interface
 
 	fact, exists := e.variableBindings[name]
 	if !exists {
+		if collection, isCollection := e.collectionBindings[name]; isCollection {
+			return collection, nil
+		}
+		if value, isValue := e.valueBindings[name]; isValue {
+			return value, nil
+		}
 		return nil, fmt.Errorf("variable non liée: %s", name)
 	}
 
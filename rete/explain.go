@@ -0,0 +1,705 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// ExplainOutcome est le résultat d'une étape de la chaîne d'une règle
+type ExplainOutcome string
+
+const (
+	// ExplainPassed : les faits fournis franchissent le nœud
+	ExplainPassed ExplainOutcome = "passed"
+	// ExplainFailed : les faits fournis sont arrêtés par le nœud
+	ExplainFailed ExplainOutcome = "failed"
+	// ExplainSkipped : le nœud porte sur une variable sans fait fourni
+	ExplainSkipped ExplainOutcome = "skipped"
+)
+
+// Types d'étapes d'une explication
+const (
+	ExplainStepAlpha      = "alpha"
+	ExplainStepJoin       = "join"
+	ExplainStepExists     = "exists"
+	ExplainStepForall     = "forall"
+	ExplainStepAccumulate = "accumulate"
+	ExplainStepCollect    = "collect"
+)
+
+// AggregateCheck compare une valeur agrégée (ou un nombre de faits pour
+// EXISTS et FORALL) au seuil de la règle
+type AggregateCheck struct {
+	Function  string      `json:"function"`
+	Variable  string      `json:"variable,omitempty"` // Variable liée au résultat
+	Field     string      `json:"field,omitempty"`    // Champ agrégé (x.champ)
+	Count     int         `json:"count"`              // Nombre de faits pris en compte
+	Value     interface{} `json:"value"`
+	Operator  string      `json:"operator,omitempty"`
+	Threshold interface{} `json:"threshold,omitempty"`
+	Passed    bool        `json:"passed"`
+}
+
+// ExplainStep décrit l'évaluation des faits fournis par un nœud de la règle
+type ExplainStep struct {
+	NodeID     string           `json:"node_id"`
+	Kind       string           `json:"kind"`
+	Variables  []string         `json:"variables,omitempty"`
+	Outcome    ExplainOutcome   `json:"outcome"`
+	Reason     string           `json:"reason,omitempty"`
+	Checks     []ConditionCheck `json:"checks,omitempty"`
+	Aggregates []AggregateCheck `json:"aggregates,omitempty"`
+	Matches    []string         `json:"matches,omitempty"` // Faits de la mémoire qui complètent les faits fournis
+}
+
+// FactProvenance indique l'origine d'un fait lié à une activation
+type FactProvenance struct {
+	Variable   string                `json:"variable"`
+	FactID     string                `json:"fact_id"`
+	ProducedBy *ActivationProvenance `json:"produced_by,omitempty"` // nil : fait asserté directement
+}
+
+// ActivationProvenance est une activation et, récursivement, l'origine de ses faits
+type ActivationProvenance struct {
+	ActivationRecord
+	Facts []FactProvenance `json:"facts"`
+}
+
+// Explanation explique pourquoi une règle s'est déclenchée, ou non, pour des faits
+type Explanation struct {
+	Rule        string                 `json:"rule"`
+	Facts       []string               `json:"facts"`    // Faits fournis (identifiants internes)
+	Bindings    map[string]string      `json:"bindings"` // Variable → fait fourni
+	Steps       []ExplainStep          `json:"steps"`
+	Matched     bool                   `json:"matched"` // Toutes les étapes sont franchies
+	Fired       bool                   `json:"fired"`   // La règle a une activation liant les faits fournis
+	Activations []ActivationProvenance `json:"activations,omitempty"`
+}
+
+// ruleVariable est une variable du motif d'une règle et son type
+type ruleVariable struct {
+	name     string
+	typeName string
+}
+
+// Explain parcourt la chaîne de nœuds compilée d'une règle et évalue chaque
+// condition sur les faits donnés (identifiants internes Type~id, ou
+// variable=Type~id pour choisir la variable liée).
+//
+// Pour une règle qui s'est déclenchée, l'explication liste aussi les
+// activations conservées liant ces faits et l'origine de chacun de leurs faits.
+func (rn *ReteNetwork) Explain(ruleID string, factIDs ...string) (*Explanation, error) {
+	terminal := rn.findRuleTerminal(ruleID)
+	if terminal == nil {
+		return nil, fmt.Errorf("règle '%s' inconnue", ruleID)
+	}
+
+	chain, alphaTypes := rn.ruleNodeChain(terminal)
+	variables := ruleVariables(chain, alphaTypes)
+	bound, err := rn.bindExplainFacts(ruleID, variables, factIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{
+		Rule:     ruleID,
+		Facts:    make([]string, 0, len(bound)),
+		Bindings: make(map[string]string, len(bound)),
+		Steps:    make([]ExplainStep, 0, len(chain)),
+		Matched:  true,
+	}
+	for _, variable := range variables {
+		if fact := bound[variable.name]; fact != nil {
+			explanation.Facts = append(explanation.Facts, fact.GetInternalID())
+			explanation.Bindings[variable.name] = fact.GetInternalID()
+		}
+	}
+
+	env := explainEnv{network: rn, facts: bound}
+	for _, node := range chain {
+		step, ok := env.explainNode(node)
+		if !ok {
+			continue
+		}
+		if step.Outcome != ExplainPassed {
+			explanation.Matched = false
+		}
+		explanation.Steps = append(explanation.Steps, step)
+	}
+
+	for _, record := range rn.Activations(ruleID, explanation.Facts...) {
+		explanation.Activations = append(explanation.Activations, rn.provenance(record, map[uint64]bool{}))
+	}
+	explanation.Fired = len(explanation.Activations) > 0
+	return explanation, nil
+}
+
+// findRuleTerminal retrouve le nœud terminal d'une règle (ou d'une requête)
+func (rn *ReteNetwork) findRuleTerminal(ruleID string) *TerminalNode {
+	if terminal, exists := rn.TerminalNodes[ruleID+"_terminal"]; exists {
+		return terminal
+	}
+	for _, terminal := range rn.TerminalNodes {
+		if terminal.getRuleName() == ruleID {
+			return terminal
+		}
+	}
+	return nil
+}
+
+// ruleNodeChain retourne les ancêtres du terminal dans l'ordre de propagation,
+// ainsi que le type des faits reçus par chaque nœud alpha
+func (rn *ReteNetwork) ruleNodeChain(terminal Node) ([]Node, map[Node]string) {
+	parents := make(map[Node][]Node)
+	depth := make(map[Node]int)
+	alphaTypes := make(map[Node]string)
+
+	var visit func(node Node, level int, typeName string)
+	visit = func(node Node, level int, typeName string) {
+		if previous, seen := depth[node]; seen && previous >= level {
+			return
+		}
+		depth[node] = level
+		if typeNode, ok := node.(*TypeNode); ok {
+			typeName = typeNode.TypeName
+		}
+		if _, ok := node.(*AlphaNode); ok && typeName != "" {
+			alphaTypes[node] = typeName
+		}
+		childType := typeName
+		if _, ok := node.(*AlphaNode); !ok {
+			if _, isType := node.(*TypeNode); !isType {
+				childType = ""
+			}
+		}
+		for _, child := range node.GetChildren() {
+			if !containsNode(parents[child], node) {
+				parents[child] = append(parents[child], node)
+			}
+			visit(child, level+1, childType)
+		}
+	}
+	if rn.RootNode != nil {
+		visit(rn.RootNode, 0, "")
+	}
+
+	var chain []Node
+	seen := map[Node]bool{terminal: true}
+	queue := []Node{terminal}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, parent := range parents[node] {
+			if seen[parent] {
+				continue
+			}
+			seen[parent] = true
+			queue = append(queue, parent)
+			switch parent.(type) {
+			case *RootNode, *TypeNode:
+			default:
+				chain = append(chain, parent)
+			}
+		}
+	}
+
+	sort.SliceStable(chain, func(i, j int) bool {
+		if depth[chain[i]] != depth[chain[j]] {
+			return depth[chain[i]] < depth[chain[j]]
+		}
+		return chain[i].GetID() < chain[j].GetID()
+	})
+	return chain, alphaTypes
+}
+
+// containsNode indique si un nœud figure dans la liste
+func containsNode(nodes []Node, node Node) bool {
+	for _, candidate := range nodes {
+		if candidate == node {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleVariables collecte les variables du motif de la règle, dans l'ordre de la chaîne
+func ruleVariables(chain []Node, alphaTypes map[Node]string) []ruleVariable {
+	var variables []ruleVariable
+	seen := make(map[string]bool)
+	add := func(name, typeName string) {
+		if name == "" || typeName == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		variables = append(variables, ruleVariable{name: name, typeName: typeName})
+	}
+
+	for _, node := range chain {
+		switch n := node.(type) {
+		case *AlphaNode:
+			add(n.VariableName, alphaTypes[node])
+		case *JoinNode:
+			for _, name := range n.AllVariables {
+				add(name, n.VariableTypes[name])
+			}
+		case *ExistsNode:
+			add(n.MainVariable, n.VariableTypes[n.MainVariable])
+			add(n.ExistsVariable, n.VariableTypes[n.ExistsVariable])
+		case *AccumulatorNode:
+			add(n.MainVariable, n.MainType)
+			add(n.AggVariable, n.AggType)
+		case *MultiSourceAccumulatorNode:
+			add(n.MainVariable, n.MainType)
+			for _, source := range n.SourcePatterns {
+				add(source.Variable, source.Type)
+			}
+		case *CollectNode:
+			add(n.ElementVariable, n.ElementType)
+		}
+	}
+	return variables
+}
+
+// bindExplainFacts lie les faits demandés aux variables de la règle : explicitement
+// (variable=fait) ou à la première variable libre de même type
+func (rn *ReteNetwork) bindExplainFacts(ruleID string, variables []ruleVariable, factIDs []string) (map[string]*Fact, error) {
+	types := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		types[variable.name] = variable.typeName
+	}
+
+	bound := make(map[string]*Fact)
+	var implicit []*Fact
+	for _, arg := range factIDs {
+		variable, factID, explicit := strings.Cut(arg, "=")
+		if !explicit {
+			factID, variable = arg, ""
+		}
+		fact, err := rn.lookupExplainFact(strings.TrimSpace(factID))
+		if err != nil {
+			return nil, err
+		}
+		if !explicit {
+			implicit = append(implicit, fact)
+			continue
+		}
+
+		variable = strings.TrimSpace(variable)
+		typeName, exists := types[variable]
+		if !exists {
+			return nil, fmt.Errorf("la règle '%s' n'a pas de variable '%s'", ruleID, variable)
+		}
+		if typeName != fact.Type {
+			return nil, fmt.Errorf("la variable '%s' est de type %s, le fait %s est de type %s", variable, typeName, fact.ID, fact.Type)
+		}
+		bound[variable] = fact
+	}
+
+	for _, fact := range implicit {
+		assigned := false
+		for _, variable := range variables {
+			if bound[variable.name] == nil && variable.typeName == fact.Type {
+				bound[variable.name] = fact
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			return nil, fmt.Errorf("aucune variable libre de type %s dans la règle '%s' pour le fait %s", fact.Type, ruleID, fact.ID)
+		}
+	}
+	return bound, nil
+}
+
+// lookupExplainFact retrouve un fait de la mémoire de travail par identifiant
+// interne, ou par identifiant seul s'il n'est pas ambigu
+func (rn *ReteNetwork) lookupExplainFact(factID string) (*Fact, error) {
+	if rn.Storage == nil {
+		return nil, fmt.Errorf("fait '%s' introuvable : aucun stockage", factID)
+	}
+	if fact := rn.Storage.GetFact(factID); fact != nil {
+		return fact, nil
+	}
+	if !strings.Contains(factID, constraint.IDSeparatorType) {
+		var found []*Fact
+		for _, fact := range rn.Storage.GetAllFacts() {
+			if strings.HasSuffix(fact.GetInternalID(), constraint.IDSeparatorType+factID) {
+				found = append(found, fact)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], nil
+		}
+		if len(found) > 1 {
+			return nil, fmt.Errorf("identifiant '%s' ambigu, préciser le type (Type%sid)", factID, constraint.IDSeparatorType)
+		}
+	}
+	return nil, fmt.Errorf("fait '%s' introuvable dans la mémoire de travail", factID)
+}
+
+// factsOfType retourne les faits d'un type présents dans la mémoire de travail
+func (rn *ReteNetwork) factsOfType(typeName string) []*Fact {
+	if rn.Storage == nil {
+		return nil
+	}
+	var facts []*Fact
+	for _, fact := range rn.Storage.GetAllFacts() {
+		if fact.Type == typeName {
+			facts = append(facts, fact)
+		}
+	}
+	sortFactsByID(facts)
+	return facts
+}
+
+// sortFactsByID trie des faits par identifiant interne
+func sortFactsByID(facts []*Fact) {
+	sort.Slice(facts, func(i, j int) bool {
+		return facts[i].GetInternalID() < facts[j].GetInternalID()
+	})
+}
+
+// explainNode évalue un nœud de la chaîne ; ok est faux pour les nœuds sans condition
+func (env explainEnv) explainNode(node Node) (ExplainStep, bool) {
+	switch n := node.(type) {
+	case *AlphaNode:
+		if n.Condition == nil || isPassthroughCondition(n.Condition) {
+			return ExplainStep{}, false
+		}
+		return env.explainAlpha(n), true
+	case *JoinNode:
+		return env.explainJoin(n), true
+	case *ExistsNode:
+		return env.explainExists(n), true
+	case *AccumulatorNode:
+		return env.explainAccumulator(n), true
+	case *MultiSourceAccumulatorNode:
+		return env.explainMultiSourceAccumulator(n), true
+	case *CollectNode:
+		return env.explainCollect(n), true
+	}
+	return ExplainStep{}, false
+}
+
+// skipped marque une étape dont une variable n'est liée à aucun fait fourni
+func (step *ExplainStep) skipped(variables ...string) {
+	step.Outcome = ExplainSkipped
+	step.Reason = fmt.Sprintf("aucun fait fourni pour %s", strings.Join(variables, ", "))
+}
+
+// result fixe l'issue d'une étape évaluée
+func (step *ExplainStep) result(passed bool) {
+	if passed {
+		step.Outcome = ExplainPassed
+	} else {
+		step.Outcome = ExplainFailed
+	}
+}
+
+// explainAlpha évalue le filtre d'un nœud alpha sur le fait de sa variable
+func (env explainEnv) explainAlpha(node *AlphaNode) ExplainStep {
+	step := ExplainStep{NodeID: node.ID, Kind: ExplainStepAlpha, Variables: []string{node.VariableName}}
+	fact := env.facts[node.VariableName]
+	if fact == nil {
+		step.skipped(node.VariableName)
+		return step
+	}
+	step.Checks = env.checks(node.Condition)
+	step.result(node.evaluateAlphaCondition(fact))
+	return step
+}
+
+// explainJoin évalue les conditions de jointure sur les faits fournis. Si une
+// seule variable n'est pas fournie, les tokens des mémoires du nœud qui la lient
+// sont essayés à sa place.
+func (env explainEnv) explainJoin(node *JoinNode) ExplainStep {
+	step := ExplainStep{NodeID: node.ID, Kind: ExplainStepJoin, Variables: append([]string(nil), node.AllVariables...)}
+
+	var missing []string
+	for _, variable := range node.AllVariables {
+		if env.facts[variable] == nil {
+			missing = append(missing, variable)
+		}
+	}
+
+	switch {
+	case len(missing) == 0:
+		step.Checks = env.checks(node.unwrapCompositeCondition())
+		step.result(node.evaluateJoinConditions(env.bindingChain(node.AllVariables)))
+	case len(missing) == 1 && len(node.AllVariables) > 1:
+		variable := missing[0]
+		for _, fact := range env.joinCandidates(node, variable) {
+			candidate := env.with(variable, fact)
+			if node.evaluateJoinConditions(candidate.bindingChain(node.AllVariables)) {
+				step.Matches = append(step.Matches, fact.GetInternalID())
+			}
+		}
+		step.Reason = fmt.Sprintf("aucun fait fourni pour %s : %d fait(s) en mémoire satisfont la jointure", variable, len(step.Matches))
+		step.result(len(step.Matches) > 0)
+	default:
+		step.skipped(missing...)
+	}
+	return step
+}
+
+// joinCandidates retourne les faits liés à une variable dans les mémoires d'une jointure
+func (env explainEnv) joinCandidates(node *JoinNode, variable string) []*Fact {
+	node.mutex.RLock()
+	tokens := append(node.LeftMemory.GetTokens(), node.RightMemory.GetTokens()...)
+	node.mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	var candidates []*Fact
+	for _, token := range tokens {
+		if token.Bindings == nil {
+			continue
+		}
+		fact := token.Bindings.Get(variable)
+		if fact == nil || seen[fact.GetInternalID()] {
+			continue
+		}
+		seen[fact.GetInternalID()] = true
+		candidates = append(candidates, fact)
+	}
+	sortFactsByID(candidates)
+	return candidates
+}
+
+// bindingChain construit la chaîne de bindings des variables données
+func (env explainEnv) bindingChain(variables []string) *BindingChain {
+	chain := NewBindingChain()
+	for _, variable := range variables {
+		if fact := env.facts[variable]; fact != nil {
+			chain = chain.Add(variable, fact)
+		}
+	}
+	return chain
+}
+
+// explainExists compte les faits d'existence qui correspondent au fait principal
+func (env explainEnv) explainExists(node *ExistsNode) ExplainStep {
+	step := ExplainStep{NodeID: node.ID, Kind: ExplainStepExists, Variables: []string{node.MainVariable, node.ExistsVariable}}
+	aggregate := AggregateCheck{Function: "EXISTS", Operator: ">", Threshold: 0}
+	if node.Negated {
+		step.Kind = ExplainStepForall
+		aggregate = AggregateCheck{Function: "FORALL", Operator: "==", Threshold: 0}
+	}
+
+	mainFact := env.facts[node.MainVariable]
+	if mainFact == nil {
+		step.skipped(node.MainVariable)
+		return step
+	}
+
+	condition := node.MatchCondition
+	if condition == nil {
+		condition = node.Condition
+	}
+	if conditions, ok := node.Condition["conditions"].([]interface{}); ok && node.MatchCondition == nil && len(conditions) == 1 {
+		condition = conditions[0]
+	}
+	if env.facts[node.ExistsVariable] != nil {
+		step.Checks = env.checks(condition)
+	}
+
+	node.mutex.RLock()
+	candidates := node.ExistsMemory.GetFacts()
+	node.mutex.RUnlock()
+	sortFactsByID(candidates)
+	for _, fact := range candidates {
+		if node.matches(mainFact, fact) {
+			step.Matches = append(step.Matches, fact.GetInternalID())
+		}
+	}
+
+	aggregate.Count = len(candidates)
+	aggregate.Value = len(step.Matches)
+	if node.Negated {
+		// FORALL : les correspondances sont des contre-exemples
+		mainToken := &Token{Facts: []*Fact{mainFact}, Bindings: NewBindingChainWith(node.MainVariable, mainFact)}
+		if node.MainCondition != nil {
+			step.Checks = append(env.checks(node.MainCondition), step.Checks...)
+		}
+		aggregate.Passed = len(step.Matches) == 0 && node.passesMainCondition(mainToken)
+	} else {
+		aggregate.Passed = len(step.Matches) > 0
+	}
+	step.Aggregates = []AggregateCheck{aggregate}
+	step.result(aggregate.Passed)
+	return step
+}
+
+// explainAccumulator compare la valeur agrégée du fait principal au seuil
+func (env explainEnv) explainAccumulator(node *AccumulatorNode) ExplainStep {
+	step := ExplainStep{NodeID: node.ID, Kind: ExplainStepAccumulate, Variables: []string{node.MainVariable, node.AggVariable}}
+	mainFact := env.facts[node.MainVariable]
+	if mainFact == nil {
+		step.skipped(node.MainVariable)
+		return step
+	}
+
+	aggregate := AggregateCheck{Function: node.AggregateFunc, Variable: node.ResultVar}
+	if node.Field != "" {
+		aggregate.Field = node.AggVariable + "." + node.Field
+	}
+	if node.Condition != nil {
+		aggregate.Operator, _ = node.Condition["operator"].(string)
+		aggregate.Threshold = node.Condition["value"]
+	}
+
+	// Valeur maintenue par le nœud ; à défaut, recalcul sur la mémoire de travail
+	node.mutex.RLock()
+	if state := node.states[mainFact.GetInternalID()]; state != nil {
+		aggregate.Value, aggregate.Count = state.Result(), state.Len()
+		node.mutex.RUnlock()
+	} else {
+		node.mutex.RUnlock()
+		state, err := NewAggregateState(node.aggregateSpec())
+		if err != nil {
+			step.Reason = err.Error()
+			step.result(false)
+			return step
+		}
+		for _, fact := range env.network.factsOfType(node.AggType) {
+			if node.joins(mainFact, fact) {
+				state.Add(fact)
+			}
+		}
+		aggregate.Value, aggregate.Count = state.Result(), state.Len()
+		step.Reason = "fait principal absent du nœud : valeur recalculée sur la mémoire de travail"
+	}
+
+	passed, err := node.evaluateCondition(aggregate.Value)
+	if err != nil {
+		step.Reason = err.Error()
+	}
+	aggregate.Passed = passed
+	step.Aggregates = []AggregateCheck{aggregate}
+	step.result(passed)
+	return step
+}
+
+// explainMultiSourceAccumulator compare chaque agrégat du fait principal à son seuil
+func (env explainEnv) explainMultiSourceAccumulator(node *MultiSourceAccumulatorNode) ExplainStep {
+	step := ExplainStep{NodeID: node.ID, Kind: ExplainStepAccumulate, Variables: []string{node.MainVariable}}
+	mainFact := env.facts[node.MainVariable]
+	if mainFact == nil {
+		step.skipped(node.MainVariable)
+		return step
+	}
+
+	node.mutex.RLock()
+	states := node.states[mainFact.GetInternalID()]
+	passed := states != nil
+	for _, aggVar := range node.AggregationVars {
+		aggregate := AggregateCheck{
+			Function:  aggVar.Function,
+			Variable:  aggVar.Name,
+			Operator:  aggVar.Operator,
+			Threshold: aggVar.Threshold,
+			Passed:    true,
+		}
+		if aggVar.Field != "" {
+			aggregate.Field = aggVar.SourceVar + "." + aggVar.Field
+		}
+		if state := states[aggVar.Name]; state != nil {
+			aggregate.Value, aggregate.Count = state.Result(), state.Len()
+		} else {
+			aggregate.Passed = false
+		}
+		if aggregate.Passed && (aggVar.Operator != "" && aggVar.Operator != ">=" || aggVar.Threshold != 0) {
+			aggregate.Passed = node.evaluateThreshold(aggregate.Value, aggVar.Operator, aggVar.Threshold)
+		}
+		passed = passed && aggregate.Passed
+		step.Aggregates = append(step.Aggregates, aggregate)
+	}
+	node.mutex.RUnlock()
+
+	if states == nil {
+		step.Reason = "aucune combinaison de faits jointe au fait principal"
+	}
+	step.result(passed)
+	return step
+}
+
+// explainCollect construit la liste du fait principal puis évalue le filtre de la règle
+func (env explainEnv) explainCollect(node *CollectNode) ExplainStep {
+	step := ExplainStep{NodeID: node.ID, Kind: ExplainStepCollect, Variables: []string{node.MainVariable, node.CollectVariable}}
+	mainFact := env.facts[node.MainVariable]
+	if mainFact == nil {
+		step.skipped(node.MainVariable)
+		return step
+	}
+
+	mainToken := &Token{Facts: []*Fact{mainFact}, Bindings: env.bindingChain(sortedVariables(env.facts))}
+	node.mutex.RLock()
+	candidates := node.ElementMemory.GetFacts()
+	node.mutex.RUnlock()
+	sortFactsByID(candidates)
+
+	var collected []*Fact
+	for _, fact := range candidates {
+		if node.matches(mainToken, fact) {
+			collected = append(collected, fact)
+			step.Matches = append(step.Matches, fact.GetInternalID())
+		}
+	}
+	mainToken.Collections = map[string][]*Fact{node.CollectVariable: collected}
+
+	passed := node.passesFilter(mainToken)
+	if node.Filter != nil {
+		env.collections = mainToken.Collections
+		step.Checks = env.checks(node.Filter)
+	}
+	step.Aggregates = []AggregateCheck{{
+		Function: "COLLECT",
+		Variable: node.CollectVariable,
+		Count:    len(candidates),
+		Value:    len(collected),
+		Passed:   passed,
+	}}
+	step.result(passed)
+	return step
+}
+
+// sortedVariables retourne les variables liées, triées
+func sortedVariables(facts map[string]*Fact) []string {
+	variables := make([]string, 0, len(facts))
+	for variable := range facts {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// provenance rattache à chaque fait d'une activation l'activation qui l'a produit
+func (rn *ReteNetwork) provenance(record ActivationRecord, visited map[uint64]bool) ActivationProvenance {
+	visited[record.Sequence] = true
+	result := ActivationProvenance{ActivationRecord: record}
+	for _, variable := range sortedKeys(record.Bindings) {
+		fact := FactProvenance{Variable: variable, FactID: record.Bindings[variable]}
+		if producer, found := rn.activations.producerOf(fact.FactID); found && !visited[producer.Sequence] {
+			origin := rn.provenance(producer, visited)
+			fact.ProducedBy = &origin
+		}
+		result.Facts = append(result.Facts, fact)
+	}
+	return result
+}
+
+// sortedKeys retourne les clés d'une map triées
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
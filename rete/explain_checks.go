@@ -0,0 +1,237 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConditionCheck est le résultat d'une comparaison élémentaire d'une condition,
+// avec les valeurs évaluées de part et d'autre de l'opérateur
+type ConditionCheck struct {
+	Expression string      `json:"expression"`
+	Operator   string      `json:"operator,omitempty"`
+	Left       interface{} `json:"left,omitempty"`
+	Right      interface{} `json:"right,omitempty"`
+	Passed     bool        `json:"passed"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// explainEnv lie les variables d'une règle aux faits (et listes) examinés
+type explainEnv struct {
+	network     *ReteNetwork
+	facts       map[string]*Fact
+	collections map[string][]*Fact
+}
+
+// with retourne une copie de l'environnement où variable est liée à fact
+func (env explainEnv) with(variable string, fact *Fact) explainEnv {
+	facts := make(map[string]*Fact, len(env.facts)+1)
+	for name, bound := range env.facts {
+		facts[name] = bound
+	}
+	facts[variable] = fact
+	env.facts = facts
+	return env
+}
+
+// evaluator crée un évaluateur lié aux faits et listes de l'environnement
+func (env explainEnv) evaluator() *AlphaConditionEvaluator {
+	evaluator := NewAlphaConditionEvaluator()
+	for variable, fact := range env.facts {
+		evaluator.variableBindings[variable] = fact
+	}
+	for name, facts := range env.collections {
+		evaluator.collectionBindings[name] = facts
+	}
+	return evaluator
+}
+
+// checks décompose une condition en comparaisons élémentaires (conjonctions)
+// et évalue chacune d'elles
+func (env explainEnv) checks(expr interface{}) []ConditionCheck {
+	exprMap, ok := expr.(map[string]interface{})
+	if !ok {
+		return []ConditionCheck{env.wholeCheck(expr)}
+	}
+
+	exprType, _ := exprMap["type"].(string)
+	switch exprType {
+	case "constraint":
+		if inner, ok := exprMap["constraint"]; ok {
+			return env.checks(inner)
+		}
+	case "logicalExpr", "logicalExpression", "logical_op":
+		if parts, conjunctive := conjunctionParts(exprMap); conjunctive {
+			var checks []ConditionCheck
+			for _, part := range parts {
+				checks = append(checks, env.checks(part)...)
+			}
+			return checks
+		}
+	case "comparison", "binaryOperation", "binary_op":
+		return []ConditionCheck{env.comparisonCheck(exprMap)}
+	case "notConstraint", "negation":
+		inner := exprMap["expression"]
+		if inner == nil {
+			inner = exprMap["condition"]
+		}
+		return append(env.checks(inner), env.wholeCheck(exprMap))
+	case "existsConstraint":
+		return []ConditionCheck{env.existsCheck(exprMap)}
+	}
+	return []ConditionCheck{env.wholeCheck(exprMap)}
+}
+
+// conjunctionParts retourne les opérandes d'une expression logique composée uniquement de AND
+func conjunctionParts(expr map[string]interface{}) ([]interface{}, bool) {
+	parts := []interface{}{expr["left"]}
+	operations, _ := expr["operations"].([]interface{})
+	for _, operation := range operations {
+		opMap, ok := operation.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if op, _ := opMap["op"].(string); !strings.EqualFold(op, "AND") {
+			return nil, false
+		}
+		parts = append(parts, opMap["right"])
+	}
+	return parts, true
+}
+
+// comparisonCheck évalue les deux côtés d'une comparaison puis la comparaison
+func (env explainEnv) comparisonCheck(expr map[string]interface{}) ConditionCheck {
+	check := ConditionCheck{Expression: conditionText(expr), Operator: operatorText(expr)}
+
+	evaluator := env.evaluator()
+	left, err := evaluator.evaluateValue(expr["left"])
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	right, err := evaluator.evaluateValue(expr["right"])
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.Left, check.Right = checkValue(left), checkValue(right)
+
+	passed, err := evaluator.evaluateExpression(expr)
+	if err != nil {
+		check.Error = err.Error()
+	}
+	check.Passed = passed
+	return check
+}
+
+// checkValue rend une valeur évaluée : un fait lié à une variable (comparaison
+// de faits) est désigné par son identifiant interne
+func checkValue(value interface{}) interface{} {
+	if fact, ok := value.(*Fact); ok && fact != nil {
+		return fact.GetInternalID()
+	}
+	return value
+}
+
+// operatorText retourne l'opérateur décodé d'une comparaison ou opération binaire
+func operatorText(expr map[string]interface{}) string {
+	if operator, err := ExtractOperatorFromMap(expr); err == nil {
+		return operator
+	}
+	operator, _ := expr["op"].(string)
+	return DecodeOperator(operator)
+}
+
+// wholeCheck évalue une expression sans la décomposer
+func (env explainEnv) wholeCheck(expr interface{}) ConditionCheck {
+	check := ConditionCheck{Expression: conditionText(expr)}
+	passed, err := env.evaluator().evaluateExpression(expr)
+	if err != nil {
+		check.Error = err.Error()
+	}
+	check.Passed = passed
+	return check
+}
+
+// existsCheck compte les faits de la mémoire de travail qui vérifient un EXISTS
+func (env explainEnv) existsCheck(expr map[string]interface{}) ConditionCheck {
+	check := ConditionCheck{Expression: conditionText(expr), Operator: ">", Right: 0}
+	variable, _ := expr["variable"].(map[string]interface{})
+	name, _ := variable["name"].(string)
+	dataType, _ := variable["dataType"].(string)
+
+	matches := 0
+	for _, candidate := range env.network.factsOfType(dataType) {
+		passed, err := env.with(name, candidate).evaluator().evaluateExpression(expr["condition"])
+		if err == nil && passed {
+			matches++
+		}
+	}
+	check.Left = matches
+	check.Passed = matches > 0
+	return check
+}
+
+// conditionText rend une condition ou une valeur dans la syntaxe TSD
+func conditionText(expr interface{}) string {
+	exprMap, ok := expr.(map[string]interface{})
+	if !ok {
+		if expr == nil {
+			return ""
+		}
+		return fmt.Sprint(expr)
+	}
+
+	exprType, _ := exprMap["type"].(string)
+	switch exprType {
+	case "constraint":
+		return conditionText(exprMap["constraint"])
+	case "fieldAccess", "field_access":
+		return fmt.Sprintf("%v.%v", exprMap["object"], exprMap["field"])
+	case "variable":
+		return fmt.Sprint(exprMap["name"])
+	case "number", "numberLiteral":
+		if value, ok := exprMap["value"].(float64); ok {
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	case "string", "stringLiteral":
+		return strconv.Quote(fmt.Sprint(exprMap["value"]))
+	case "boolean", "booleanLiteral":
+		return fmt.Sprint(exprMap["value"])
+	case "comparison", "binaryOperation", "binary_op", "binaryOp", "binary_operation":
+		text := fmt.Sprintf("%s %s %s", conditionText(exprMap["left"]), operatorText(exprMap), conditionText(exprMap["right"]))
+		if exprType == "comparison" {
+			return text
+		}
+		return "(" + text + ")"
+	case "logicalExpr", "logicalExpression", "logical_op":
+		parts := []string{conditionText(exprMap["left"])}
+		operations, _ := exprMap["operations"].([]interface{})
+		for _, operation := range operations {
+			if opMap, ok := operation.(map[string]interface{}); ok {
+				parts = append(parts, fmt.Sprint(opMap["op"]), conditionText(opMap["right"]))
+			}
+		}
+		return strings.Join(parts, " ")
+	case "notConstraint":
+		return "NOT (" + conditionText(exprMap["expression"]) + ")"
+	case "negation":
+		return "NOT (" + conditionText(exprMap["condition"]) + ")"
+	case "existsConstraint":
+		variable, _ := exprMap["variable"].(map[string]interface{})
+		return fmt.Sprintf("EXISTS (%v: %v / %s)", variable["name"], variable["dataType"], conditionText(exprMap["condition"]))
+	case "functionCall", "function_call":
+		args, _ := exprMap["args"].([]interface{})
+		formatted := make([]string, 0, len(args))
+		for _, arg := range args {
+			formatted = append(formatted, conditionText(arg))
+		}
+		return fmt.Sprintf("%v(%s)", exprMap["name"], strings.Join(formatted, ", "))
+	}
+	return fmt.Sprint(expr)
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"strings"
+	"testing"
+)
+
+const explainTestProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: string, total: number)
+type Refund(#id: string, order: string)
+type Alert(#room: string)
+action notify(id: string)
+action raise(room: string)
+
+rule bigCustomer : {c: Customer} / c.tier == "gold" ==> raise(c.id)
+rule alerted : {a: Alert, c: Customer} / a.room == c.id ==> notify(a.room)
+rule customerOrders : {c: Customer, o: Order} / o.customer == c.id AND o.total > 100 AND c.tier == "gold" ==> notify(o.id)
+rule withOrders : {c: Customer} / EXISTS (o: Order / o.customer == c.id) ==> notify(c.id)
+rule noRefund : {o: Order} / NOT (EXISTS (r: Refund / r.order == o.id)) ==> notify(o.id)
+rule spend : {c: Customer, total: SUM(o.total)} / {o: Order} / o.customer == c.id AND total > 500 ==> notify(c.id)
+rule doubled : {o: Order} / o.total * 2 > 100 ==> notify(o.id)
+
+Customer(id: "c1", tier: "gold")
+Customer(id: "c2", tier: "silver")
+Order(id: "o1", customer: "c1", total: 250)
+Order(id: "42", customer: "c2", total: 50)
+Refund(id: "r1", order: "o1")
+`
+
+func newExplainTestNetwork(t *testing.T) *ReteNetwork {
+	t.Helper()
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+	if err := network.ActionExecutor.RegisterAction(alertActionHandler{}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	return ingestQueryProgram(t, network, storage, explainTestProgram)
+}
+
+// findCheck retourne la première vérification dont l'expression est donnée
+func findCheck(explanation *Explanation, expression string) *ConditionCheck {
+	for i := range explanation.Steps {
+		for j := range explanation.Steps[i].Checks {
+			if explanation.Steps[i].Checks[j].Expression == expression {
+				return &explanation.Steps[i].Checks[j]
+			}
+		}
+	}
+	return nil
+}
+
+// findStep retourne la première étape d'un type donné
+func findStep(explanation *Explanation, kind string) *ExplainStep {
+	for i := range explanation.Steps {
+		if explanation.Steps[i].Kind == kind {
+			return &explanation.Steps[i]
+		}
+	}
+	return nil
+}
+
+func TestExplain_AlphaFailureReportsValues(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	explanation, err := network.Explain("customerOrders", "Order~42")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if explanation.Matched || explanation.Fired {
+		t.Errorf("Matched = %v, Fired = %v, want false/false", explanation.Matched, explanation.Fired)
+	}
+	if explanation.Bindings["o"] != "Order~42" {
+		t.Errorf("Bindings = %v, want o bound to Order~42", explanation.Bindings)
+	}
+
+	check := findCheck(explanation, "o.total > 100")
+	if check == nil {
+		t.Fatalf("no check for o.total > 100 in %+v", explanation.Steps)
+	}
+	if check.Passed || check.Operator != ">" || check.Left != 50.0 || check.Right != 100.0 {
+		t.Errorf("check = %+v, want failed 50 > 100", *check)
+	}
+}
+
+func TestExplain_JoinCandidatesForMissingVariable(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	explanation, err := network.Explain("customerOrders", "Customer~c1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	join := findStep(explanation, ExplainStepJoin)
+	if join == nil {
+		t.Fatalf("no join step in %+v", explanation.Steps)
+	}
+	if join.Outcome != ExplainPassed || len(join.Matches) != 1 || join.Matches[0] != "Order~o1" {
+		t.Errorf("join = %+v, want passed with match Order~o1", *join)
+	}
+}
+
+func TestExplain_FiredRuleListsActivation(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	explanation, err := network.Explain("customerOrders", "o=Order~o1", "c=Customer~c1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !explanation.Matched || !explanation.Fired {
+		t.Fatalf("Matched = %v, Fired = %v, want true/true: %+v", explanation.Matched, explanation.Fired, explanation.Steps)
+	}
+	check := findCheck(explanation, "o.customer == c.id")
+	if check == nil || !check.Passed || check.Left != "c1" || check.Right != "c1" {
+		t.Errorf("join check = %+v, want passed c1 == c1", check)
+	}
+	activation := explanation.Activations[0]
+	if activation.Rule != "customerOrders" || activation.Bindings["o"] != "Order~o1" || activation.Bindings["c"] != "Customer~c1" {
+		t.Errorf("activation = %+v", activation.ActivationRecord)
+	}
+}
+
+func TestExplain_ProvenanceOfProducedFacts(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	// bigCustomer ne doit se déclencher que pour le client gold
+	if records := network.Activations("bigCustomer", "Customer~c2"); len(records) != 0 {
+		t.Errorf("bigCustomer fired for silver customer: %+v", records)
+	}
+
+	explanation, err := network.Explain("alerted", "Alert~c1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !explanation.Fired || len(explanation.Activations) != 1 {
+		t.Fatalf("Fired = %v, activations = %d, want one activation", explanation.Fired, len(explanation.Activations))
+	}
+
+	var alert *FactProvenance
+	for i, fact := range explanation.Activations[0].Facts {
+		if fact.FactID == "Alert~c1" {
+			alert = &explanation.Activations[0].Facts[i]
+		}
+	}
+	if alert == nil || alert.ProducedBy == nil {
+		t.Fatalf("Alert~c1 provenance missing: %+v", explanation.Activations[0].Facts)
+	}
+	if alert.ProducedBy.Rule != "bigCustomer" || alert.ProducedBy.Bindings["c"] != "Customer~c1" {
+		t.Errorf("Alert~c1 produced by %+v, want bigCustomer on Customer~c1", alert.ProducedBy.ActivationRecord)
+	}
+	for _, fact := range alert.ProducedBy.Facts {
+		if fact.ProducedBy != nil {
+			t.Errorf("asserted fact %s should have no producer", fact.FactID)
+		}
+	}
+}
+
+func TestExplain_ExistsAndNot(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	explanation, err := network.Explain("withOrders", "Customer~c2")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	exists := findStep(explanation, ExplainStepExists)
+	if exists == nil || exists.Outcome != ExplainPassed || len(exists.Aggregates) != 1 || exists.Aggregates[0].Value != 1 {
+		t.Errorf("exists step = %+v, want passed with one matching Order", exists)
+	}
+
+	explanation, err = network.Explain("noRefund", "Order~o1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if explanation.Matched {
+		t.Error("noRefund should not match Order~o1, which has a refund")
+	}
+	check := findCheck(explanation, "EXISTS (r: Refund / r.order == o.id)")
+	if check == nil || !check.Passed || check.Left != 1 {
+		t.Errorf("EXISTS check = %+v, want 1 matching Refund", check)
+	}
+	check = findCheck(explanation, "NOT (EXISTS (r: Refund / r.order == o.id))")
+	if check == nil || check.Passed {
+		t.Errorf("NOT check = %+v, want failed", check)
+	}
+}
+
+func TestExplain_AccumulateAgainstThreshold(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	explanation, err := network.Explain("spend", "Customer~c1")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	step := findStep(explanation, ExplainStepAccumulate)
+	if step == nil || len(step.Aggregates) != 1 {
+		t.Fatalf("accumulate step = %+v", step)
+	}
+	aggregate := step.Aggregates[0]
+	if aggregate.Passed || aggregate.Value != 250.0 || aggregate.Operator != ">" || aggregate.Threshold != 500.0 {
+		t.Errorf("aggregate = %+v, want failed 250 > 500", aggregate)
+	}
+	if explanation.Matched {
+		t.Error("spend should not match Customer~c1")
+	}
+}
+
+func TestExplain_DecodesArithmeticOperators(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	explanation, err := network.Explain("doubled", "Order~42")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	check := findCheck(explanation, "(o.total * 2) > 100")
+	if check == nil || check.Passed || check.Left != 100.0 {
+		t.Errorf("check = %+v, want failed 100 > 100", check)
+	}
+}
+
+func TestExplain_Errors(t *testing.T) {
+	network := newExplainTestNetwork(t)
+
+	tests := []struct {
+		name    string
+		rule    string
+		facts   []string
+		wantErr string
+	}{
+		{"unknown rule", "missing", nil, "missing"},
+		{"unknown fact", "customerOrders", []string{"Order~nope"}, "Order~nope"},
+		{"unknown variable", "customerOrders", []string{"x=Order~42"}, "x"},
+		{"type mismatch", "customerOrders", []string{"c=Order~42"}, "c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := network.Explain(tt.rule, tt.facts...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Explain() error = %v, want mention of %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActivationLog_SizeLimit(t *testing.T) {
+	network := newExplainTestNetwork(t)
+	if len(network.Activations("customerOrders")) != 1 {
+		t.Fatalf("Activations(customerOrders) = %d, want 1", len(network.Activations("customerOrders")))
+	}
+
+	network.SetActivationLogSize(0)
+	if records := network.Activations(""); len(records) != 0 {
+		t.Errorf("log disabled but %d records kept", len(records))
+	}
+	if err := network.SubmitFact(&Fact{ID: "Order~o2", Type: "Order", Fields: map[string]interface{}{"id": "o2", "customer": "c1", "total": 300.0}}); err != nil {
+		t.Fatalf("SubmitFact() error = %v", err)
+	}
+	if records := network.Activations("customerOrders"); len(records) != 0 {
+		t.Errorf("log disabled but %d customerOrders activations recorded", len(records))
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// FactOperation est l'opération portée par un FactEvent
+type FactOperation string
+
+const (
+	FactOperationInsert  FactOperation = "insert"
+	FactOperationUpdate  FactOperation = "update"
+	FactOperationRetract FactOperation = "retract"
+)
+
+// FactEvent est une modification de la mémoire de travail reçue d'un flux,
+// par exemple une ligne NDJSON lue par tsd run :
+//
+//	{"op": "insert", "type": "Order", "fields": {"id": "o1", "total": 250}}
+//	{"op": "update", "type": "Order", "id": "o1", "fields": {"total": 90}}
+//	{"op": "retract", "type": "Order", "id": "o1"}
+//
+// Le fait visé par une mise à jour ou une rétractation est désigné par son
+// identifiant (id, préfixé du type ou non) ou, à défaut, par les champs de sa
+// clé primaire (tous ses champs pour un type sans clé primaire).
+type FactEvent struct {
+	Op     FactOperation          `json:"op"`
+	Type   string                 `json:"type,omitempty"` // Déduit de l'identifiant Type~id s'il est absent
+	ID     string                 `json:"id,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// ApplyFactEvent applique un événement et le propage dans le réseau :
+//   - insert : les champs sont convertis et validés comme par LoadFacts ;
+//     le fait ne doit pas déjà être présent ;
+//   - update : les champs donnés remplacent ceux du fait en mémoire, les
+//     autres sont conservés ; la clé primaire ne peut pas changer ;
+//   - retract : le fait est retiré.
+//
+// Retourne le fait inséré, mis à jour ou retiré.
+func (rn *ReteNetwork) ApplyFactEvent(event FactEvent) (*Fact, error) {
+	if err := event.Op.validate(); err != nil {
+		return nil, err
+	}
+	typeName := event.TypeName()
+	if typeName == "" {
+		return nil, fmt.Errorf("type du fait non précisé")
+	}
+	typeDef := rn.GetTypeDefinition(typeName)
+	if typeDef == nil {
+		return nil, fmt.Errorf("type '%s' non défini", typeName)
+	}
+
+	switch event.Op {
+	case FactOperationInsert:
+		fact, err := rn.buildEventFact(typeName, event.Fields)
+		if err != nil {
+			return nil, err
+		}
+		if event.ID != "" && eventFactID(typeName, event.ID) != fact.ID {
+			return nil, fmt.Errorf("identifiant %s différent de celui généré par les champs (%s)", event.ID, fact.ID)
+		}
+		if rn.Storage.GetFact(fact.ID) != nil {
+			return nil, fmt.Errorf("fait %s déjà présent", fact.ID)
+		}
+		return fact, rn.SubmitFact(fact)
+
+	case FactOperationUpdate:
+		target, err := rn.eventTarget(typeDef, event)
+		if err != nil {
+			return nil, err
+		}
+		values := exportFields(target)
+		for name, value := range event.Fields {
+			values[name] = value
+		}
+		fact, err := rn.buildEventFact(typeName, values)
+		if err != nil {
+			return nil, err
+		}
+		if typeDef.toConstraint().HasPrimaryKey() && fact.ID != target.ID {
+			return nil, fmt.Errorf("clé primaire de %s modifiée (%s) : retirer le fait puis insérer le nouveau", target.ID, fact.ID)
+		}
+		// Sans clé primaire, l'identifiant (hash des champs) est conservé
+		fact.ID = target.ID
+		fact.Fields[FieldNameID] = target.ID
+		return fact, rn.replaceFact(target, fact)
+
+	default: // FactOperationRetract
+		target, err := rn.eventTarget(typeDef, event)
+		if err != nil {
+			return nil, err
+		}
+		return target, rn.RetractFact(target.ID)
+	}
+}
+
+// TypeName retourne le type du fait visé, déduit de l'identifiant Type~id
+// s'il n'est pas précisé ("" : inconnu)
+func (e FactEvent) TypeName() string {
+	if e.Type != "" {
+		return e.Type
+	}
+	typeName, _, _ := strings.Cut(e.ID, constraint.IDSeparatorType)
+	return typeName
+}
+
+// validate vérifie que l'opération est insert, update ou retract
+func (op FactOperation) validate() error {
+	switch op {
+	case FactOperationInsert, FactOperationUpdate, FactOperationRetract:
+		return nil
+	case "":
+		return fmt.Errorf("opération non précisée (insert, update, retract)")
+	}
+	return fmt.Errorf("opération '%s' inconnue (insert, update, retract)", op)
+}
+
+// replaceFact propage la mise à jour d'un fait par rétractation puis
+// insertion. UpdateFact n'est pas utilisé : la propagation delta ne
+// réévalue pas encore les conditions (voir propagateDeltaToNode), un fait
+// mis à jour hors d'une ingestion ne déclencherait pas les règles qu'il
+// satisfait désormais.
+func (rn *ReteNetwork) replaceFact(existing, fact *Fact) error {
+	if areFactsEqual(existing, fact) {
+		return nil
+	}
+	defer rn.beginCascade()()
+	if err := rn.RetractFact(existing.ID); err != nil {
+		return err
+	}
+	return rn.SubmitFact(fact)
+}
+
+// buildEventFact construit un fait complet à partir de ses valeurs brutes
+func (rn *ReteNetwork) buildEventFact(typeName string, values map[string]interface{}) (*Fact, error) {
+	record := newJSONRecord(values, 0)
+	if record.err != nil {
+		return nil, record.err
+	}
+	return rn.buildLoadedFact(record, FactMapping{Type: typeName})
+}
+
+// eventTarget retourne le fait en mémoire visé par une mise à jour ou une
+// rétractation
+func (rn *ReteNetwork) eventTarget(typeDef *TypeDefinition, event FactEvent) (*Fact, error) {
+	id := eventFactID(typeDef.Name, event.ID)
+	if id == "" {
+		var err error
+		if id, err = keyFactID(typeDef, event.Fields); err != nil {
+			return nil, fmt.Errorf("fait visé non identifié (id ou clé primaire): %w", err)
+		}
+	}
+	target := rn.Storage.GetFact(id)
+	if target == nil {
+		return nil, fmt.Errorf("fait %s absent de la mémoire", id)
+	}
+	return target, nil
+}
+
+// eventFactID préfixe du type un identifiant donné sans lui
+func eventFactID(typeName, id string) string {
+	if id == "" || strings.HasPrefix(id, typeName+constraint.IDSeparatorType) {
+		return id
+	}
+	return typeName + constraint.IDSeparatorType + id
+}
+
+// keyFactID génère l'identifiant d'un fait à partir des champs de sa clé
+// primaire, ou de tous ses champs pour un type sans clé primaire
+func keyFactID(typeDef *TypeDefinition, values map[string]interface{}) (string, error) {
+	definition := typeDef.toConstraint()
+	keyFields := definition.GetPrimaryKeyFields()
+	if len(keyFields) == 0 {
+		keyFields = definition.Fields
+	}
+
+	fact := constraint.Fact{Type: "fact", TypeName: typeDef.Name}
+	for _, keyField := range keyFields {
+		value, present, err := coerceFactValue(values[keyField.Name], *typeDef.field(keyField.Name))
+		if err != nil {
+			return "", err
+		}
+		if !present {
+			return "", fmt.Errorf("champ '%s' manquant", keyField.Name)
+		}
+		fact.Fields = append(fact.Fields, constraint.FactField{Name: keyField.Name, Value: value})
+	}
+	return constraint.GenerateFactID(fact, definition, nil)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const eventProgram = `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: Customer, total: number)
+type Click(page: string, count: number)
+action notify(id: string)
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+`
+
+// decodeEvent décode une enveloppe comme tsd run (nombres json.Number)
+func decodeEvent(t *testing.T, data string) FactEvent {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var event FactEvent
+	if err := decoder.Decode(&event); err != nil {
+		t.Fatalf("enveloppe %s invalide: %v", data, err)
+	}
+	return event
+}
+
+func TestApplyFactEvent(t *testing.T) {
+	network := buildGraphNetwork(t, eventProgram)
+	calls := 0
+	if err := network.ActionExecutor.RegisterAction(notifyHandler{calls: &calls}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+
+	for _, data := range []string{
+		`{"op": "insert", "type": "Customer", "fields": {"id": "c1", "tier": "gold"}}`,
+		`{"op": "insert", "type": "Order", "fields": {"id": "o1", "customer": "c1", "total": 50}}`,
+		`{"op": "update", "type": "Order", "id": "o1", "fields": {"total": 250}}`,
+		`{"op": "insert", "type": "Click", "fields": {"page": "home", "count": 1}}`,
+	} {
+		if _, err := network.ApplyFactEvent(decodeEvent(t, data)); err != nil {
+			t.Fatalf("ApplyFactEvent(%s) error = %v", data, err)
+		}
+	}
+	order := network.Storage.GetFact("Order~o1")
+	if order == nil || order.Fields["total"] != 250.0 || order.Fields["customer"] != "Customer~c1" {
+		t.Fatalf("commande = %v", order)
+	}
+	if calls != 1 {
+		t.Errorf("notify appelée %d fois, attendu 1", calls)
+	}
+
+	// Clé primaire donnée par les champs ; type déduit de l'identifiant
+	fact, err := network.ApplyFactEvent(decodeEvent(t, `{"op": "update", "type": "Customer", "fields": {"id": "c1", "tier": "silver"}}`))
+	if err != nil || fact.ID != "Customer~c1" || network.Storage.GetFact("Customer~c1").Fields["tier"] != "silver" {
+		t.Fatalf("update par clé primaire = %v, %v", fact, err)
+	}
+	if _, err := network.ApplyFactEvent(decodeEvent(t, `{"op": "retract", "id": "Order~o1"}`)); err != nil {
+		t.Fatalf("retract error = %v", err)
+	}
+	if network.Storage.GetFact("Order~o1") != nil {
+		t.Error("commande toujours en mémoire après retract")
+	}
+
+	// Sans clé primaire, l'identifiant est conservé par la mise à jour
+	var click *Fact
+	for _, f := range network.Storage.GetAllFacts() {
+		if f.Type == "Click" {
+			click = f
+		}
+	}
+	updated, err := network.ApplyFactEvent(FactEvent{Op: FactOperationUpdate, ID: click.ID, Fields: map[string]interface{}{"count": 2.0}})
+	if err != nil || updated.ID != click.ID || network.Storage.GetFact(click.ID).Fields["count"] != 2.0 {
+		t.Fatalf("update sans clé primaire = %v, %v", updated, err)
+	}
+}
+
+func TestApplyFactEvent_Errors(t *testing.T) {
+	network := buildGraphNetwork(t, eventProgram)
+	if _, err := network.ApplyFactEvent(decodeEvent(t, `{"op": "insert", "type": "Customer", "fields": {"id": "c1", "tier": "gold"}}`)); err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+
+	tests := []struct {
+		data    string
+		message string
+	}{
+		{`{"op": "insert", "type": "Customer", "fields": {"id": "c1", "tier": "gold"}}`, "déjà présent"},
+		{`{"op": "insert", "type": "Ghost", "fields": {}}`, "type 'Ghost' non défini"},
+		{`{"op": "insert", "fields": {"id": "c2"}}`, "type du fait non précisé"},
+		{`{"op": "insert", "type": "Customer", "fields": {"id": "c2"}}`, "champ 'tier' manquant"},
+		{`{"op": "insert", "type": "Customer", "fields": {"id": "c2", "tier": {"a": 1}}}`, "valeur composite"},
+		{`{"op": "update", "type": "Customer", "id": "c9", "fields": {"tier": "gold"}}`, "Customer~c9 absent"},
+		{`{"op": "update", "type": "Customer", "id": "c1", "fields": {"id": "c2"}}`, "clé primaire"},
+		{`{"op": "retract", "type": "Customer", "fields": {"tier": "gold"}}`, "champ 'id' manquant"},
+		{`{"op": "upsert", "type": "Customer"}`, "opération 'upsert' inconnue"},
+		{`{"op": "bogus", "fields": {"id": "c2"}}`, "opération 'bogus' inconnue"},
+		{`{"type": "Customer", "fields": {"id": "c2", "tier": "gold"}}`, "opération non précisée"},
+	}
+	for _, tt := range tests {
+		if _, err := network.ApplyFactEvent(decodeEvent(t, tt.data)); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("ApplyFactEvent(%s) error = %v, attendu %q", tt.data, err, tt.message)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// FactFormatTSD : assertions de faits TSD, rechargeables après le programme
+// qui déclare leurs types
+const FactFormatTSD FactFormat = "tsd"
+
+// FactFilterVariable est la variable liée au fait dans FactFilter.Condition
+const FactFilterVariable = "f"
+
+// FactFilter sélectionne les faits exportés par ExportFacts
+type FactFilter struct {
+	// Types exportés (vide : tous les types déclarés)
+	Types []string
+	// Condition TSD portant sur le fait lié à la variable f, par exemple
+	// `f.age >= 18 AND f.city == "Paris"`. Les faits dont le type n'a pas
+	// l'un des champs lus sont écartés.
+	Condition string
+}
+
+// factSelector applique un FactFilter
+type factSelector struct {
+	types     map[string]bool
+	condition interface{}
+	fields    []string // Champs lus par la condition
+}
+
+// ExportFacts écrit les faits de la mémoire de travail retenus par le filtre :
+//   - tsd : une assertion par fait ; les faits référencés par un autre sont
+//     exportés aussi, avant lui, sous une variable (user1 = User(...)) ;
+//   - json : tableau d'objets {type, fields} (format tsdio.Fact) ;
+//   - ndjson : un objet {type, fields} par ligne ;
+//   - csv : en-tête des champs puis une ligne par fait, d'un seul type.
+//
+// Les faits sont triés par type, dans l'ordre de déclaration, puis par
+// identifiant. Retourne le nombre de faits écrits.
+func (rn *ReteNetwork) ExportFacts(w io.Writer, format FactFormat, filter FactFilter) (int, error) {
+	selector, err := rn.newFactSelector(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var facts []*Fact
+	for _, fact := range rn.exportableFacts() {
+		ok, err := selector.matches(rn.GetTypeDefinition(fact.Type), fact)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			facts = append(facts, fact)
+		}
+	}
+
+	switch format {
+	case FactFormatTSD:
+		return rn.exportTSD(w, facts)
+	case FactFormatJSON:
+		return exportJSON(w, facts)
+	case FactFormatNDJSON:
+		return exportNDJSON(w, facts)
+	case FactFormatCSV:
+		return rn.exportCSV(w, facts, filter.Types)
+	default:
+		return 0, fmt.Errorf("format d'export '%s' inconnu (tsd, json, ndjson, csv)", format)
+	}
+}
+
+// newFactSelector vérifie les types du filtre et compile sa condition
+func (rn *ReteNetwork) newFactSelector(filter FactFilter) (*factSelector, error) {
+	selector := &factSelector{}
+	if len(filter.Types) > 0 {
+		selector.types = make(map[string]bool, len(filter.Types))
+		for _, typeName := range filter.Types {
+			if rn.GetTypeDefinition(typeName) == nil {
+				return nil, fmt.Errorf("type '%s' non défini", typeName)
+			}
+			selector.types[typeName] = true
+		}
+	}
+	if strings.TrimSpace(filter.Condition) == "" {
+		return selector, nil
+	}
+
+	// La condition est analysée comme celle d'une requête sur la variable f
+	source := fmt.Sprintf("query __export() : {%s: __Fact} / %s\n", FactFilterVariable, filter.Condition)
+	result, err := constraint.ParseConstraint("<filtre>", []byte(source))
+	if err != nil {
+		return nil, fmt.Errorf("condition invalide: %w", err)
+	}
+	program, err := constraint.ConvertResultToProgram(result)
+	if err != nil || len(program.Queries) != 1 {
+		return nil, fmt.Errorf("condition invalide: %s", filter.Condition)
+	}
+	selector.condition = program.Queries[0].Constraints
+
+	var unknown string
+	walkConstraintNodes(selector.condition, func(node map[string]interface{}) {
+		if node["type"] != FieldAccessType {
+			return
+		}
+		object, _ := node["object"].(string)
+		field, _ := node["field"].(string)
+		if object != FactFilterVariable {
+			unknown = object
+			return
+		}
+		selector.fields = append(selector.fields, field)
+	})
+	if unknown != "" {
+		return nil, fmt.Errorf("condition invalide: variable '%s' inconnue (le fait est désigné par %s)", unknown, FactFilterVariable)
+	}
+	return selector, nil
+}
+
+// matches indique si le fait est retenu par le filtre
+func (s *factSelector) matches(typeDef *TypeDefinition, fact *Fact) (bool, error) {
+	if s.types != nil && !s.types[fact.Type] {
+		return false, nil
+	}
+	if s.condition == nil {
+		return true, nil
+	}
+	for _, field := range s.fields {
+		if typeDef.field(field) == nil {
+			return false, nil
+		}
+	}
+	ok, err := NewAlphaConditionEvaluator().EvaluateCondition(s.condition, fact, FactFilterVariable)
+	if err != nil {
+		return false, fmt.Errorf("condition sur %s: %w", fact.ID, err)
+	}
+	return ok, nil
+}
+
+// exportableFacts retourne les faits des types déclarés, triés par type
+// (ordre de déclaration) puis par identifiant
+func (rn *ReteNetwork) exportableFacts() []*Fact {
+	rank := make(map[string]int, len(rn.Types))
+	for i, typeDef := range rn.Types {
+		rank[typeDef.Name] = i
+	}
+	var facts []*Fact
+	for _, fact := range rn.Storage.GetAllFacts() {
+		if _, declared := rank[fact.Type]; declared {
+			facts = append(facts, fact)
+		}
+	}
+	sort.Slice(facts, func(i, j int) bool {
+		if facts[i].Type != facts[j].Type {
+			return rank[facts[i].Type] < rank[facts[j].Type]
+		}
+		return facts[i].ID < facts[j].ID
+	})
+	return facts
+}
+
+// exportFields retourne les champs d'un fait, sans l'identifiant interne
+func exportFields(fact *Fact) map[string]interface{} {
+	fields := make(map[string]interface{}, len(fact.Fields))
+	for name, value := range fact.Fields {
+		if name != FieldNameID {
+			fields[name] = value
+		}
+	}
+	return fields
+}
+
+func exportJSON(w io.Writer, facts []*Fact) (int, error) {
+	records := make([]tsdio.Fact, len(facts))
+	for i, fact := range facts {
+		records[i] = tsdio.Fact{Type: fact.Type, Fields: exportFields(fact)}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return 0, err
+	}
+	return len(facts), nil
+}
+
+func exportNDJSON(w io.Writer, facts []*Fact) (int, error) {
+	encoder := json.NewEncoder(w)
+	for i, fact := range facts {
+		if err := encoder.Encode(tsdio.Fact{Type: fact.Type, Fields: exportFields(fact)}); err != nil {
+			return i, err
+		}
+	}
+	return len(facts), nil
+}
+
+// exportCSV écrit des faits d'un seul type ; les références sont écrites
+// sous forme d'identifiant interne (Type~id), relu tel quel par LoadFacts.
+// Sans fait, l'en-tête est écrit si le filtre désigne un seul type.
+func (rn *ReteNetwork) exportCSV(w io.Writer, facts []*Fact, types []string) (int, error) {
+	var typeName string
+	switch {
+	case len(facts) > 0:
+		typeName = facts[0].Type
+	case len(types) == 1:
+		typeName = types[0]
+	default:
+		return 0, nil
+	}
+	for _, fact := range facts {
+		if fact.Type != typeName {
+			return 0, fmt.Errorf("le format csv exporte un seul type (%s et %s sélectionnés)", typeName, fact.Type)
+		}
+	}
+	typeDef := rn.GetTypeDefinition(typeName)
+
+	writer := csv.NewWriter(w)
+	header := make([]string, len(typeDef.Fields))
+	for i, field := range typeDef.Fields {
+		header[i] = field.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return 0, err
+	}
+	for _, fact := range facts {
+		row := make([]string, len(typeDef.Fields))
+		for i, field := range typeDef.Fields {
+			switch value := fact.Fields[field.Name].(type) {
+			case nil:
+			case string:
+				row[i] = value
+			default:
+				row[i] = constraint.FormatLiteral(value)
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return 0, err
+		}
+	}
+	writer.Flush()
+	return len(facts), writer.Error()
+}
+
+// tsdExport écrit des faits en TSD, références résolues par des variables
+type tsdExport struct {
+	network   *ReteNetwork
+	variables map[string]string // Identifiant → variable des faits référencés
+	counters  map[string]int
+	written   map[string]bool
+	lines     []string
+}
+
+// exportTSD écrit les faits sélectionnés, précédés des faits qu'ils référencent
+func (rn *ReteNetwork) exportTSD(w io.Writer, facts []*Fact) (int, error) {
+	export := &tsdExport{
+		network:   rn,
+		variables: make(map[string]string),
+		counters:  make(map[string]int),
+		written:   make(map[string]bool),
+	}
+	for _, fact := range facts {
+		if err := export.collectReferences(fact); err != nil {
+			return 0, err
+		}
+	}
+	for _, fact := range facts {
+		export.write(fact)
+	}
+
+	if _, err := fmt.Fprintf(w, "// %d fait(s) exporté(s) de la mémoire de travail\n", len(export.lines)); err != nil {
+		return 0, err
+	}
+	for _, line := range export.lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return 0, err
+		}
+	}
+	return len(export.lines), nil
+}
+
+// references retourne les identifiants des faits référencés par un fait
+func (e *tsdExport) references(fact *Fact) []string {
+	var refs []string
+	for _, field := range e.network.GetTypeDefinition(fact.Type).Fields {
+		if e.network.GetTypeDefinition(field.Type) == nil {
+			continue
+		}
+		if id, ok := fact.Fields[field.Name].(string); ok {
+			refs = append(refs, id)
+		}
+	}
+	return refs
+}
+
+// collectReferences attribue une variable à chaque fait référencé, en
+// remontant les références (les types ne peuvent pas former de cycle)
+func (e *tsdExport) collectReferences(fact *Fact) error {
+	for _, id := range e.references(fact) {
+		if _, known := e.variables[id]; known {
+			continue
+		}
+		target := e.network.Storage.GetFact(id)
+		if target == nil {
+			return fmt.Errorf("fait %s référencé par %s absent de la mémoire", id, fact.ID)
+		}
+		e.counters[target.Type]++
+		e.variables[id] = fmt.Sprintf("%s%d", lowerFirst(target.Type), e.counters[target.Type])
+		if err := e.collectReferences(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write écrit un fait après ceux qu'il référence
+func (e *tsdExport) write(fact *Fact) {
+	if e.written[fact.ID] {
+		return
+	}
+	e.written[fact.ID] = true
+	for _, id := range e.references(fact) {
+		e.write(e.network.Storage.GetFact(id))
+	}
+
+	typeDef := e.network.GetTypeDefinition(fact.Type)
+	args := make([]string, 0, len(typeDef.Fields))
+	for _, field := range typeDef.Fields {
+		value, exists := fact.Fields[field.Name]
+		if !exists {
+			continue
+		}
+		text := constraint.FormatLiteral(value)
+		if variable, ok := e.variables[fmt.Sprint(value)]; ok && e.network.GetTypeDefinition(field.Type) != nil {
+			text = variable
+		}
+		args = append(args, field.Name+": "+text)
+	}
+	line := fmt.Sprintf("%s(%s)", fact.Type, strings.Join(args, ", "))
+	if variable, ok := e.variables[fact.ID]; ok {
+		line = variable + " = " + line
+	}
+	e.lines = append(e.lines, line)
+}
+
+// lowerFirst met en minuscule la première lettre d'un nom de type
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return name
+	}
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const exportTypes = `type User(#name: string, age: number, admin: bool)
+type Post(#id: string, author: User, title: string)
+type Event(kind: string, value: number)
+`
+
+const exportFacts = `alice = User(name: "alice", age: 30, admin: true)
+User(name: "bob", age: 15, admin: false)
+Post(id: "p1", author: alice, title: "Hello \"world\"")
+Event(kind: "click", value: 2.5)
+`
+
+// exportString exporte les faits du réseau et retourne le texte produit
+func exportString(t *testing.T, network *ReteNetwork, format FactFormat, filter FactFilter) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := network.ExportFacts(&buf, format, filter); err != nil {
+		t.Fatalf("ExportFacts() error = %v", err)
+	}
+	return buf.String()
+}
+
+// factFields indexe les champs des faits par identifiant
+func factFields(network *ReteNetwork) map[string]map[string]interface{} {
+	facts := make(map[string]map[string]interface{})
+	for _, fact := range network.Storage.GetAllFacts() {
+		facts[fact.ID] = fact.Fields
+	}
+	return facts
+}
+
+func TestExportFacts_TSDRoundTrip(t *testing.T) {
+	network := buildGraphNetwork(t, exportTypes+exportFacts)
+
+	output := exportString(t, network, FactFormatTSD, FactFilter{})
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	want := []string{
+		"// 4 fait(s) exporté(s) de la mémoire de travail",
+		`user1 = User(name: "alice", age: 30, admin: true)`,
+		`User(name: "bob", age: 15, admin: false)`,
+		`Post(id: "p1", author: user1, title: "Hello \"world\"")`,
+		`Event(kind: "click", value: 2.5)`,
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("export TSD =\n%s\nattendu:\n%s", output, strings.Join(want, "\n"))
+	}
+
+	reloaded := buildGraphNetwork(t, exportTypes, output)
+	if got, want := factFields(reloaded), factFields(network); !reflect.DeepEqual(got, want) {
+		t.Errorf("faits rechargés = %v, attendu %v", got, want)
+	}
+}
+
+func TestExportFacts_Filter(t *testing.T) {
+	network := buildGraphNetwork(t, exportTypes+exportFacts)
+
+	// Un fait référencé est exporté avec celui qui le référence
+	output := exportString(t, network, FactFormatTSD, FactFilter{Types: []string{"Post"}})
+	if !strings.Contains(output, "user1 = User(name: \"alice\"") || !strings.Contains(output, "author: user1") || strings.Contains(output, "bob") {
+		t.Errorf("export des Post =\n%s", output)
+	}
+
+	// Les types sans champ age sont écartés par la condition
+	output = exportString(t, network, FactFormatNDJSON, FactFilter{Condition: "f.age >= 18 AND f.admin == true"})
+	if strings.TrimSpace(output) != `{"type":"User","fields":{"admin":true,"age":30,"name":"alice"}}` {
+		t.Errorf("export filtré = %s", output)
+	}
+
+	for _, filter := range []FactFilter{
+		{Types: []string{"Ghost"}},
+		{Condition: "u.age > 1"},
+		{Condition: "f.age >"},
+	} {
+		if _, err := network.ExportFacts(&bytes.Buffer{}, FactFormatTSD, filter); err == nil {
+			t.Errorf("ExportFacts(%+v) doit échouer", filter)
+		}
+	}
+	if _, err := network.ExportFacts(&bytes.Buffer{}, "xml", FactFilter{}); err == nil {
+		t.Error("ExportFacts() doit refuser un format inconnu")
+	}
+}
+
+func TestExportFacts_JSON(t *testing.T) {
+	network := buildGraphNetwork(t, exportTypes+exportFacts)
+
+	var records []struct {
+		Type   string                 `json:"type"`
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(exportString(t, network, FactFormatJSON, FactFilter{})), &records); err != nil {
+		t.Fatalf("JSON invalide: %v", err)
+	}
+	if len(records) != 4 || records[2].Type != "Post" || records[2].Fields["author"] != "User~alice" {
+		t.Fatalf("records = %+v", records)
+	}
+	for _, record := range records {
+		if _, exists := record.Fields[FieldNameID]; exists {
+			t.Errorf("identifiant interne exporté: %v", record)
+		}
+	}
+}
+
+func TestExportFacts_CSVRoundTrip(t *testing.T) {
+	network := buildGraphNetwork(t, exportTypes+exportFacts)
+
+	output := exportString(t, network, FactFormatCSV, FactFilter{Types: []string{"User"}})
+	if output != "name,age,admin\nalice,30,true\nbob,15,false\n" {
+		t.Errorf("export CSV =\n%s", output)
+	}
+	if _, err := network.ExportFacts(&bytes.Buffer{}, FactFormatCSV, FactFilter{}); err == nil {
+		t.Error("l'export CSV de plusieurs types doit échouer")
+	}
+	if header := exportString(t, network, FactFormatCSV, FactFilter{Types: []string{"User"}, Condition: "f.age > 100"}); header != "name,age,admin\n" {
+		t.Errorf("export CSV vide = %q", header)
+	}
+
+	posts := exportString(t, network, FactFormatCSV, FactFilter{Types: []string{"Post"}})
+	reloaded := buildGraphNetwork(t, exportTypes)
+	for typeName, data := range map[string]string{"User": output, "Post": posts} {
+		report, err := reloaded.LoadFacts(strings.NewReader(data), FactFormatCSV, FactMapping{Type: typeName})
+		if err != nil || len(report.Errors) > 0 {
+			t.Fatalf("LoadFacts(%s) = %+v, %v", typeName, report, err)
+		}
+	}
+	want := factFields(network)
+	for id := range want {
+		if strings.HasPrefix(id, "Event~") {
+			delete(want, id)
+		}
+	}
+	if got := factFields(reloaded); !reflect.DeepEqual(got, want) {
+		t.Errorf("faits rechargés = %v, attendu %v", got, want)
+	}
+}
@@ -0,0 +1,509 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FactLimitPolicy définit le comportement lorsqu'une limite de faits est atteinte
+type FactLimitPolicy string
+
+const (
+	// FactLimitReject refuse le nouveau fait avec une *FactLimitError
+	FactLimitReject FactLimitPolicy = "reject"
+	// FactLimitEvictOldest rétracte les plus anciens faits du même type
+	// (à défaut, les plus anciens faits tous types confondus)
+	FactLimitEvictOldest FactLimitPolicy = "evict-oldest"
+	// FactLimitBlock bloque la soumission jusqu'à ce qu'une rétractation libère
+	// de la place, jusqu'à l'expiration de BlockTimeout ou jusqu'à la fin du
+	// contexte d'exécution (SubmitFactContext)
+	FactLimitBlock FactLimitPolicy = "block"
+)
+
+// DefaultFactLimitBlockTimeout est l'attente maximale d'une soumission bloquée
+const DefaultFactLimitBlockTimeout = 30 * time.Second
+
+// ErrFactLimitExceeded est la cause de toutes les *FactLimitError (errors.Is)
+var ErrFactLimitExceeded = errors.New("limite de faits en mémoire atteinte")
+
+// FactLimitError est retournée lorsqu'un fait est refusé faute de place
+type FactLimitError struct {
+	FactID  string // Identifiant interne du fait refusé
+	Type    string // Type du fait refusé
+	Limit   int    // Limite atteinte
+	PerType bool   // true : quota du type, false : limite globale
+	Timeout bool   // true : refus après attente (politique block)
+}
+
+func (e *FactLimitError) Error() string {
+	scope := "globale"
+	if e.PerType {
+		scope = fmt.Sprintf("du type %s", e.Type)
+	}
+	if e.Timeout {
+		return fmt.Sprintf("fait %s refusé: limite %s de %d faits toujours atteinte après attente", e.FactID, scope, e.Limit)
+	}
+	return fmt.Sprintf("fait %s refusé: limite %s de %d faits atteinte", e.FactID, scope, e.Limit)
+}
+
+func (e *FactLimitError) Unwrap() error {
+	return ErrFactLimitExceeded
+}
+
+// FactLimits configure le nombre maximal de faits en mémoire de travail
+type FactLimits struct {
+	MaxFacts        int             // Limite globale (0 : illimité)
+	MaxFactsPerType map[string]int  // Quotas par type (absent ou 0 : pas de quota)
+	Policy          FactLimitPolicy // Défaut : FactLimitReject
+	BlockTimeout    time.Duration   // Politique block (défaut : DefaultFactLimitBlockTimeout)
+}
+
+// Validate vérifie la cohérence des limites
+func (l FactLimits) Validate() error {
+	if l.MaxFacts < 0 {
+		return fmt.Errorf("MaxFacts ne peut pas être négatif: %d", l.MaxFacts)
+	}
+	for factType, quota := range l.MaxFactsPerType {
+		if quota < 0 {
+			return fmt.Errorf("quota du type %s ne peut pas être négatif: %d", factType, quota)
+		}
+	}
+	switch l.Policy {
+	case "", FactLimitReject, FactLimitEvictOldest, FactLimitBlock:
+	default:
+		return fmt.Errorf("politique de limite inconnue: %s", l.Policy)
+	}
+	if l.BlockTimeout < 0 {
+		return fmt.Errorf("BlockTimeout ne peut pas être négatif: %v", l.BlockTimeout)
+	}
+	return nil
+}
+
+// enabled indique si au moins une limite est active
+func (l FactLimits) enabled() bool {
+	if l.MaxFacts > 0 {
+		return true
+	}
+	for _, quota := range l.MaxFactsPerType {
+		if quota > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FactLimitStats compte les faits suivis et les décisions prises par le limiteur
+type FactLimitStats struct {
+	Facts            int               // Faits en mémoire de travail
+	FactsByType      map[string]int    // Faits par type
+	Evictions        uint64            // Faits rétractés pour faire de la place
+	Rejections       uint64            // Faits refusés (y compris après attente)
+	Blocked          uint64            // Soumissions ayant dû attendre
+	EvictionsByType  map[string]uint64 // Évictions par type du fait rétracté
+	RejectionsByType map[string]uint64 // Refus par type du fait refusé
+}
+
+// trackedFact est une entrée de la file d'insertion d'un type
+type trackedFact struct {
+	id       string
+	sequence uint64
+}
+
+// factLimiter suit les faits de la mémoire de travail par ordre d'insertion
+// et applique les limites configurées.
+//
+// Les files par type sont purgées paresseusement : une entrée n'est vivante
+// que si present[id] porte encore son numéro de séquence.
+type factLimiter struct {
+	mutex    sync.Mutex
+	limits   FactLimits
+	sequence uint64
+	present  map[string]uint64 // id → séquence d'insertion
+	types    map[string]string // id → type
+	queues   map[string][]trackedFact
+	counts   map[string]int
+	total    int
+	released chan struct{} // fermé (puis remplacé) à chaque retrait
+
+	evictions       map[string]uint64
+	rejections      map[string]uint64
+	blocked         uint64
+	evictionsTotal  uint64
+	rejectionsTotal uint64
+}
+
+// newFactLimiter crée un limiteur vide
+func newFactLimiter(limits FactLimits) *factLimiter {
+	return &factLimiter{
+		limits:     limits,
+		present:    make(map[string]uint64),
+		types:      make(map[string]string),
+		queues:     make(map[string][]trackedFact),
+		counts:     make(map[string]int),
+		released:   make(chan struct{}),
+		evictions:  make(map[string]uint64),
+		rejections: make(map[string]uint64),
+	}
+}
+
+// admission est la décision du limiteur pour un fait soumis
+type admission struct {
+	registered bool          // Le fait est désormais suivi (à oublier si l'ajout échoue)
+	victim     string        // Fait à rétracter avant de réessayer (evict-oldest)
+	wait       chan struct{} // Canal à attendre avant de réessayer (block)
+	err        *FactLimitError
+}
+
+// tryAdmit enregistre le fait s'il reste de la place, sinon indique quoi faire
+func (l *factLimiter) tryAdmit(fact *Fact) admission {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	id := fact.GetInternalID()
+	if _, exists := l.present[id]; exists {
+		// Fait déjà suivi : l'ajout échouera ou le remplacera, sans occuper de place en plus
+		return admission{}
+	}
+
+	limitErr := l.exceeded(fact.Type, id)
+	if limitErr == nil {
+		l.track(id, fact.Type)
+		return admission{registered: true}
+	}
+
+	switch l.limits.Policy {
+	case FactLimitEvictOldest:
+		victimType := fact.Type
+		if !limitErr.PerType && l.counts[fact.Type] == 0 {
+			victimType = ""
+		}
+		if victim := l.oldest(victimType); victim != "" {
+			return admission{victim: victim}
+		}
+	case FactLimitBlock:
+		return admission{wait: l.released, err: limitErr}
+	}
+	return admission{err: limitErr}
+}
+
+// full retourne l'erreur de la limite qui empêche d'admettre le fait, et le
+// canal fermé au prochain retrait ; nil si le fait peut entrer
+func (l *factLimiter) full(fact *Fact) (chan struct{}, *FactLimitError) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	id := fact.GetInternalID()
+	if _, exists := l.present[id]; exists {
+		return nil, nil
+	}
+	return l.released, l.exceeded(fact.Type, id)
+}
+
+// exceeded retourne l'erreur de la limite atteinte par un nouveau fait du type, ou nil
+func (l *factLimiter) exceeded(factType, id string) *FactLimitError {
+	if quota := l.limits.MaxFactsPerType[factType]; quota > 0 && l.counts[factType] >= quota {
+		return &FactLimitError{FactID: id, Type: factType, Limit: quota, PerType: true}
+	}
+	if l.limits.MaxFacts > 0 && l.total >= l.limits.MaxFacts {
+		return &FactLimitError{FactID: id, Type: factType, Limit: l.limits.MaxFacts}
+	}
+	return nil
+}
+
+// track ajoute un fait à la fin de la file de son type
+func (l *factLimiter) track(id, factType string) {
+	l.sequence++
+	l.present[id] = l.sequence
+	l.types[id] = factType
+	l.queues[factType] = append(l.queues[factType], trackedFact{id: id, sequence: l.sequence})
+	l.counts[factType]++
+	l.total++
+}
+
+// oldest retourne le plus ancien fait vivant d'un type ("" : tous types confondus)
+func (l *factLimiter) oldest(factType string) string {
+	if factType != "" {
+		if head, ok := l.head(factType); ok {
+			return head.id
+		}
+		return ""
+	}
+
+	var oldest trackedFact
+	for queueType := range l.queues {
+		if head, ok := l.head(queueType); ok && (oldest.id == "" || head.sequence < oldest.sequence) {
+			oldest = head
+		}
+	}
+	return oldest.id
+}
+
+// head purge les entrées mortes en tête de file et retourne la première vivante
+func (l *factLimiter) head(factType string) (trackedFact, bool) {
+	queue := l.queues[factType]
+	for len(queue) > 0 && l.present[queue[0].id] != queue[0].sequence {
+		queue = queue[1:]
+	}
+	if len(queue) == 0 {
+		delete(l.queues, factType)
+		return trackedFact{}, false
+	}
+	l.queues[factType] = queue
+	return queue[0], true
+}
+
+// removed oublie un fait retiré de la mémoire de travail et réveille les soumissions bloquées
+func (l *factLimiter) removed(id string) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.forget(id)
+}
+
+// forget retire un fait du suivi (mutex tenu)
+func (l *factLimiter) forget(id string) {
+	if _, exists := l.present[id]; !exists {
+		return
+	}
+	factType := l.types[id]
+	delete(l.present, id)
+	delete(l.types, id)
+	l.counts[factType]--
+	if l.counts[factType] == 0 {
+		delete(l.counts, factType)
+	}
+	l.total--
+
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// resync aligne le suivi sur le contenu du storage (après un rollback ou un Clear).
+// Les faits inconnus du limiteur sont ajoutés en fin de file.
+func (l *factLimiter) resync(storage Storage) {
+	if l == nil || storage == nil {
+		return
+	}
+
+	stored := make(map[string]*Fact)
+	for _, fact := range storage.GetAllFacts() {
+		stored[fact.GetInternalID()] = fact
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for id := range l.present {
+		if stored[id] == nil {
+			l.forget(id)
+		}
+	}
+	for id, fact := range stored {
+		if _, exists := l.present[id]; !exists {
+			l.track(id, fact.Type)
+		}
+	}
+}
+
+// recordEviction comptabilise un fait rétracté pour faire de la place
+func (l *factLimiter) recordEviction(factType string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.evictions[factType]++
+	l.evictionsTotal++
+}
+
+// recordRejection comptabilise un fait refusé
+func (l *factLimiter) recordRejection(factType string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.rejections[factType]++
+	l.rejectionsTotal++
+}
+
+// recordBlocked comptabilise une soumission qui a dû attendre
+func (l *factLimiter) recordBlocked() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.blocked++
+}
+
+// typeOf retourne le type d'un fait suivi
+func (l *factLimiter) typeOf(id string) string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.types[id]
+}
+
+// stats retourne une copie des compteurs du limiteur
+func (l *factLimiter) stats() FactLimitStats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	stats := FactLimitStats{
+		Facts:            l.total,
+		FactsByType:      make(map[string]int, len(l.counts)),
+		Evictions:        l.evictionsTotal,
+		Rejections:       l.rejectionsTotal,
+		Blocked:          l.blocked,
+		EvictionsByType:  make(map[string]uint64, len(l.evictions)),
+		RejectionsByType: make(map[string]uint64, len(l.rejections)),
+	}
+	for factType, count := range l.counts {
+		stats.FactsByType[factType] = count
+	}
+	for factType, count := range l.evictions {
+		stats.EvictionsByType[factType] = count
+	}
+	for factType, count := range l.rejections {
+		stats.RejectionsByType[factType] = count
+	}
+	return stats
+}
+
+// SetFactLimits active (ou désactive, avec des limites nulles) la limitation
+// du nombre de faits en mémoire de travail. Les faits déjà présents sont pris
+// en compte, par ordre d'insertion inconnu ; les compteurs d'évictions et de
+// refus repartent de zéro.
+func (rn *ReteNetwork) SetFactLimits(limits FactLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	if limits.Policy == "" {
+		limits.Policy = FactLimitReject
+	}
+	if limits.BlockTimeout == 0 {
+		limits.BlockTimeout = DefaultFactLimitBlockTimeout
+	}
+
+	if !limits.enabled() {
+		rn.factLimiter = nil
+		return nil
+	}
+
+	limiter := newFactLimiter(limits)
+	limiter.resync(rn.Storage)
+	rn.factLimiter = limiter
+	return nil
+}
+
+// FactLimits retourne les limites actives (valeur nulle si aucune)
+func (rn *ReteNetwork) FactLimits() FactLimits {
+	if rn.factLimiter == nil {
+		return FactLimits{}
+	}
+	return rn.factLimiter.limits
+}
+
+// FactLimitStats retourne les compteurs du limiteur (valeur nulle si aucune limite)
+func (rn *ReteNetwork) FactLimitStats() FactLimitStats {
+	if rn.factLimiter == nil {
+		return FactLimitStats{}
+	}
+	return rn.factLimiter.stats()
+}
+
+// AwaitFactCapacity attend, avec la politique block, qu'il y ait de la place
+// pour le fait (Type et ID suffisent ; un ID vide désigne un nouveau fait du
+// type), au plus BlockTimeout ou jusqu'à la fin de ctx. La place n'est pas
+// réservée : la soumission qui suit peut encore attendre si une autre l'a
+// prise entre-temps. Permet d'attendre avant d'ouvrir une transaction, sans
+// tenir les verrous qui empêcheraient une rétractation concurrente. Sans
+// limite ou avec une autre politique, retourne aussitôt.
+func (rn *ReteNetwork) AwaitFactCapacity(ctx context.Context, fact *Fact) error {
+	limiter := rn.factLimiter
+	if limiter == nil || limiter.limits.Policy != FactLimitBlock {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var deadline <-chan time.Time
+	for {
+		released, limitErr := limiter.full(fact)
+		if limitErr == nil {
+			return nil
+		}
+		if deadline == nil {
+			limiter.recordBlocked()
+			timer := time.NewTimer(limiter.limits.BlockTimeout)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			limiter.recordRejection(fact.Type)
+			return &canceledError{cause: ctx.Err()}
+		case <-deadline:
+			limitErr.Timeout = true
+			limiter.recordRejection(fact.Type)
+			return limitErr
+		}
+	}
+}
+
+// admitFact réserve la place d'un fait avant son ajout au storage, selon la
+// politique configurée. Retourne true si le fait a été enregistré par le
+// limiteur (il doit être oublié si l'ajout échoue).
+func (rn *ReteNetwork) admitFact(fact *Fact) (bool, error) {
+	limiter := rn.factLimiter
+	if limiter == nil {
+		return false, nil
+	}
+
+	var deadline <-chan time.Time
+	for {
+		decision := limiter.tryAdmit(fact)
+		switch {
+		case decision.victim != "":
+			victimType := limiter.typeOf(decision.victim)
+			rn.logger.Info("🧹 Limite de faits atteinte: éviction de %s pour %s", decision.victim, fact.GetInternalID())
+			if err := rn.RetractFact(decision.victim); err != nil {
+				// Fait déjà absent du storage : l'oublier simplement
+				limiter.removed(decision.victim)
+				continue
+			}
+			limiter.recordEviction(victimType)
+			continue
+
+		case decision.wait != nil:
+			if deadline == nil {
+				limiter.recordBlocked()
+				timer := time.NewTimer(limiter.limits.BlockTimeout)
+				defer timer.Stop()
+				deadline = timer.C
+			}
+			var done <-chan struct{}
+			if ctx := rn.executionContext(); ctx != nil {
+				done = ctx.Done()
+			}
+			select {
+			case <-decision.wait:
+				continue
+			case <-done:
+				limiter.recordRejection(fact.Type)
+				return false, rn.checkContext()
+			case <-deadline:
+				decision.err.Timeout = true
+				limiter.recordRejection(fact.Type)
+				return false, decision.err
+			}
+
+		case decision.err != nil:
+			limiter.recordRejection(fact.Type)
+			return false, decision.err
+		}
+		return decision.registered, nil
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+const factLimitsProgram = `type Sensor(#id: string)
+type Reading(#id: string, value: number)
+query readings() : {r: Reading}
+`
+
+func newFactLimitsNetwork(t *testing.T, limits FactLimits) *ReteNetwork {
+	t.Helper()
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, factLimitsProgram)
+	if err := network.SetFactLimits(limits); err != nil {
+		t.Fatalf("SetFactLimits() error = %v", err)
+	}
+	return network
+}
+
+func reading(id string) *Fact {
+	return &Fact{ID: "Reading~" + id, Type: "Reading", Fields: map[string]interface{}{"id": id, "value": 1.0}}
+}
+
+func sensor(id string) *Fact {
+	return &Fact{ID: "Sensor~" + id, Type: "Sensor", Fields: map[string]interface{}{"id": id}}
+}
+
+// queryReadings retourne les identifiants des lectures vues par la requête readings
+func queryReadings(t *testing.T, network *ReteNetwork) []string {
+	t.Helper()
+	query, _ := network.GetQuery("readings")
+	rows, err := query.Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.Bindings["r"].ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestFactLimits_Validate(t *testing.T) {
+	invalid := []FactLimits{
+		{MaxFacts: -1},
+		{MaxFactsPerType: map[string]int{"Reading": -1}},
+		{MaxFacts: 1, Policy: "drop"},
+		{MaxFacts: 1, BlockTimeout: -time.Second},
+	}
+	for _, limits := range invalid {
+		if err := limits.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", limits)
+		}
+	}
+	if err := (FactLimits{MaxFacts: 10, Policy: FactLimitBlock}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestFactLimits_Reject(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFacts: 3, MaxFactsPerType: map[string]int{"Reading": 2}})
+
+	for _, fact := range []*Fact{reading("r1"), reading("r2"), sensor("s1")} {
+		if err := network.SubmitFact(fact); err != nil {
+			t.Fatalf("SubmitFact(%s) error = %v", fact.ID, err)
+		}
+	}
+
+	err := network.SubmitFact(reading("r3"))
+	var limitErr *FactLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, ErrFactLimitExceeded) {
+		t.Fatalf("SubmitFact(r3) error = %v, want *FactLimitError", err)
+	}
+	if !limitErr.PerType || limitErr.Type != "Reading" || limitErr.Limit != 2 {
+		t.Errorf("error = %+v, want Reading quota of 2", limitErr)
+	}
+
+	err = network.SubmitFact(sensor("s2"))
+	if !errors.As(err, &limitErr) || limitErr.PerType || limitErr.Limit != 3 {
+		t.Errorf("SubmitFact(s2) error = %v, want global limit of 3", err)
+	}
+	if network.Storage.GetFact("Sensor~s2") != nil || network.Storage.GetFact("Reading~r3") != nil {
+		t.Error("rejected facts must not reach the storage")
+	}
+
+	// Une rétractation libère de la place
+	if err := network.RetractFact("Reading~r1"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	if err := network.SubmitFact(reading("r3")); err != nil {
+		t.Errorf("SubmitFact(r3) after retract error = %v", err)
+	}
+
+	stats := network.FactLimitStats()
+	if stats.Facts != 3 || stats.FactsByType["Reading"] != 2 || stats.Rejections != 2 || stats.RejectionsByType["Reading"] != 1 || stats.RejectionsByType["Sensor"] != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestFactLimits_EvictOldestRetractsThroughNetwork(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFactsPerType: map[string]int{"Reading": 2}, Policy: FactLimitEvictOldest})
+
+	for _, id := range []string{"r1", "r2", "r3", "r4"} {
+		if err := network.SubmitFact(reading(id)); err != nil {
+			t.Fatalf("SubmitFact(%s) error = %v", id, err)
+		}
+	}
+
+	if got := queryReadings(t, network); len(got) != 2 || got[0] != "Reading~r3" || got[1] != "Reading~r4" {
+		t.Errorf("readings = %v, want the two most recent", got)
+	}
+	if network.Storage.GetFact("Reading~r1") != nil {
+		t.Error("evicted fact still in storage")
+	}
+
+	stats := network.FactLimitStats()
+	if stats.Evictions != 2 || stats.EvictionsByType["Reading"] != 2 || stats.Rejections != 0 {
+		t.Errorf("stats = %+v, want 2 Reading evictions", stats)
+	}
+}
+
+func TestFactLimits_EvictOldestGlobal(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFacts: 2, Policy: FactLimitEvictOldest})
+
+	for _, fact := range []*Fact{reading("r1"), reading("r2"), sensor("s1")} {
+		if err := network.SubmitFact(fact); err != nil {
+			t.Fatalf("SubmitFact(%s) error = %v", fact.ID, err)
+		}
+	}
+
+	// Aucun Sensor à évincer : le plus ancien fait, tous types confondus, est rétracté
+	if network.Storage.GetFact("Reading~r1") != nil || network.Storage.GetFact("Sensor~s1") == nil {
+		t.Errorf("facts = %v, want Reading~r1 evicted", network.FactLimitStats().FactsByType)
+	}
+
+	// Un Reading de plus évince le Reading restant, pas le Sensor
+	if err := network.SubmitFact(reading("r3")); err != nil {
+		t.Fatalf("SubmitFact(r3) error = %v", err)
+	}
+	if network.Storage.GetFact("Reading~r2") != nil || network.Storage.GetFact("Sensor~s1") == nil {
+		t.Error("expected Reading~r2 to be evicted before Sensor~s1")
+	}
+}
+
+func TestFactLimits_BlockUntilRetract(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFacts: 1, Policy: FactLimitBlock, BlockTimeout: 5 * time.Second})
+	if err := network.SubmitFact(reading("r1")); err != nil {
+		t.Fatalf("SubmitFact(r1) error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- network.SubmitFact(reading("r2")) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("SubmitFact(r2) returned %v before space was freed", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := network.RetractFact("Reading~r1"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("SubmitFact(r2) error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("SubmitFact(r2) still blocked after retract")
+	}
+
+	if stats := network.FactLimitStats(); stats.Blocked != 1 || stats.Facts != 1 {
+		t.Errorf("stats = %+v, want one blocked submission", stats)
+	}
+}
+
+func TestFactLimits_BlockTimeout(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFacts: 1, Policy: FactLimitBlock, BlockTimeout: 20 * time.Millisecond})
+	if err := network.SubmitFact(reading("r1")); err != nil {
+		t.Fatalf("SubmitFact(r1) error = %v", err)
+	}
+
+	err := network.SubmitFact(reading("r2"))
+	var limitErr *FactLimitError
+	if !errors.As(err, &limitErr) || !limitErr.Timeout {
+		t.Fatalf("SubmitFact(r2) error = %v, want timeout *FactLimitError", err)
+	}
+	if stats := network.FactLimitStats(); stats.Rejections != 1 || stats.Blocked != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestFactLimits_RollbackResync(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{MaxFacts: 2})
+
+	tx := network.BeginTransaction()
+	network.SetTransaction(tx)
+	if err := network.SubmitFact(reading("r1")); err != nil {
+		t.Fatalf("SubmitFact(r1) error = %v", err)
+	}
+	if err := network.SubmitFact(reading("r2")); err != nil {
+		t.Fatalf("SubmitFact(r2) error = %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	network.SetTransaction(nil)
+
+	if stats := network.FactLimitStats(); stats.Facts != 0 {
+		t.Errorf("Facts = %d after rollback, want 0", stats.Facts)
+	}
+	if err := network.SubmitFact(reading("r3")); err != nil {
+		t.Errorf("SubmitFact(r3) after rollback error = %v", err)
+	}
+}
+
+func TestFactLimits_ExistingFactsAndDisable(t *testing.T) {
+	network := newFactLimitsNetwork(t, FactLimits{})
+	for _, id := range []string{"r1", "r2"} {
+		if err := network.SubmitFact(reading(id)); err != nil {
+			t.Fatalf("SubmitFact(%s) error = %v", id, err)
+		}
+	}
+
+	// Les faits déjà présents comptent dès l'activation des limites
+	if err := network.SetFactLimits(FactLimits{MaxFacts: 2}); err != nil {
+		t.Fatalf("SetFactLimits() error = %v", err)
+	}
+	if network.FactLimits().Policy != FactLimitReject {
+		t.Errorf("default policy = %q, want reject", network.FactLimits().Policy)
+	}
+	if err := network.SubmitFact(reading("r3")); !errors.Is(err, ErrFactLimitExceeded) {
+		t.Errorf("SubmitFact(r3) error = %v, want limit exceeded", err)
+	}
+
+	if err := network.SetFactLimits(FactLimits{}); err != nil {
+		t.Fatalf("SetFactLimits() error = %v", err)
+	}
+	if err := network.SubmitFact(reading("r3")); err != nil {
+		t.Errorf("SubmitFact(r3) with limits disabled error = %v", err)
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// FactFormat désigne le format d'un flux de faits chargé par LoadFacts
+type FactFormat string
+
+const (
+	// FactFormatJSON : tableau JSON d'objets
+	FactFormatJSON FactFormat = "json"
+	// FactFormatNDJSON : un objet JSON par ligne (lignes vides ignorées)
+	FactFormatNDJSON FactFormat = "ndjson"
+	// FactFormatCSV : première ligne d'en-tête, puis une ligne par fait
+	FactFormatCSV FactFormat = "csv"
+)
+
+// FactMapping décrit comment les enregistrements d'un flux deviennent des faits
+type FactMapping struct {
+	// Type des faits chargés. Vide : lu dans la colonne TypeField de chaque enregistrement
+	Type string
+	// Colonne portant le type de chaque enregistrement (utilisée si Type est vide)
+	TypeField string
+	// Correspondance colonne → champ. Une colonne absente garde son nom,
+	// une colonne associée à "" est ignorée
+	Fields map[string]string
+}
+
+// FactLoadReport résume un chargement : les enregistrements invalides sont
+// écartés et signalés, les autres sont insérés
+type FactLoadReport struct {
+	Records int             `json:"records"`
+	Loaded  int             `json:"loaded"`
+	Errors  []FactLoadError `json:"errors,omitempty"`
+}
+
+// FactLoadError signale un enregistrement rejeté
+type FactLoadError struct {
+	Record  int    `json:"record"`         // Rang de l'enregistrement (à partir de 1)
+	Line    int    `json:"line,omitempty"` // Ligne dans le flux, si connue
+	Message string `json:"message"`
+}
+
+func (e FactLoadError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("ligne %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("enregistrement %d: %s", e.Record, e.Message)
+}
+
+// factRecord est un enregistrement brut lu dans le flux
+type factRecord struct {
+	line    int
+	columns []string // Ordre des colonnes, pour des messages déterministes
+	values  map[string]interface{}
+	err     error // Enregistrement illisible (ligne NDJSON ou CSV malformée)
+}
+
+// LoadFacts charge en masse des faits depuis un flux JSON, NDJSON ou CSV.
+// Chaque enregistrement est associé à un type déclaré (mapping), ses valeurs
+// sont converties selon la définition du type (number, bool, références) et
+// son identifiant est généré par constraint.GenerateFactID à partir de la clé
+// primaire. Les enregistrements invalides ou déjà présents sont signalés dans
+// le rapport ; les autres sont soumis dans une seule transaction, annulée en
+// entier si une soumission échoue.
+func (rn *ReteNetwork) LoadFacts(r io.Reader, format FactFormat, mapping FactMapping) (*FactLoadReport, error) {
+	if mapping.Type == "" && mapping.TypeField == "" {
+		return nil, errors.New("type des faits non précisé (Type ou TypeField)")
+	}
+	if mapping.Type != "" && rn.GetTypeDefinition(mapping.Type) == nil {
+		return nil, fmt.Errorf("type '%s' non défini", mapping.Type)
+	}
+
+	records, err := readFactRecords(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FactLoadReport{Records: len(records)}
+	facts := make([]*Fact, 0, len(records))
+	seen := make(map[string]int, len(records))
+	for i, record := range records {
+		reject := func(err error) {
+			report.Errors = append(report.Errors, FactLoadError{Record: i + 1, Line: record.line, Message: err.Error()})
+		}
+		if record.err != nil {
+			reject(record.err)
+			continue
+		}
+		fact, err := rn.buildLoadedFact(record, mapping)
+		if err != nil {
+			reject(err)
+			continue
+		}
+		if previous, exists := seen[fact.ID]; exists {
+			reject(fmt.Errorf("fait %s en double (enregistrement %d)", fact.ID, previous))
+			continue
+		}
+		if rn.Storage.GetFact(fact.ID) != nil {
+			reject(fmt.Errorf("fait %s déjà présent", fact.ID))
+			continue
+		}
+		seen[fact.ID] = i + 1
+		facts = append(facts, fact)
+	}
+
+	if err := rn.submitFactBatch(facts); err != nil {
+		return nil, err
+	}
+	report.Loaded = len(facts)
+	return report, nil
+}
+
+// submitFactBatch soumet les faits dans une transaction annulable, validée si
+// toutes les soumissions réussissent et annulée sinon. Une transaction déjà
+// ouverte (ingestion annulable) est remise en place ensuite.
+func (rn *ReteNetwork) submitFactBatch(facts []*Fact) error {
+	if len(facts) == 0 {
+		return nil
+	}
+	previous := rn.GetTransaction()
+	if previous != nil && !previous.IsActive {
+		previous = nil
+	}
+	defer rn.SetTransaction(previous)
+
+	tx := rn.BeginUndoableTransaction()
+	rn.SetTransaction(tx)
+	for _, fact := range facts {
+		if err := rn.SubmitFact(fact); err != nil {
+			if rollbackErr := rn.RollbackTransaction(); rollbackErr != nil {
+				return errors.Join(fmt.Errorf("soumission du fait %s: %w", fact.ID, err), rollbackErr)
+			}
+			return fmt.Errorf("soumission du fait %s: %w", fact.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// buildLoadedFact convertit un enregistrement en fait du réseau
+func (rn *ReteNetwork) buildLoadedFact(record factRecord, mapping FactMapping) (*Fact, error) {
+	typeName := mapping.Type
+	if typeName == "" {
+		value, ok := record.values[mapping.TypeField].(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("colonne de type '%s' absente", mapping.TypeField)
+		}
+		typeName = value
+	}
+	typeDef := rn.GetTypeDefinition(typeName)
+	if typeDef == nil {
+		return nil, fmt.Errorf("type '%s' non défini", typeName)
+	}
+
+	values := make(map[string]constraint.FactValue, len(typeDef.Fields))
+	for _, column := range record.columns {
+		if mapping.Type == "" && column == mapping.TypeField {
+			continue
+		}
+		fieldName := column
+		if mapped, ok := mapping.Fields[column]; ok {
+			if mapped == "" {
+				continue
+			}
+			fieldName = mapped
+		}
+		field := typeDef.field(fieldName)
+		if field == nil {
+			return nil, fmt.Errorf("colonne '%s': champ '%s' non défini dans le type %s", column, fieldName, typeName)
+		}
+		if _, exists := values[fieldName]; exists {
+			return nil, fmt.Errorf("colonne '%s': champ '%s' déjà renseigné", column, fieldName)
+		}
+		value, present, err := coerceFactValue(record.values[column], *field)
+		if err != nil {
+			return nil, fmt.Errorf("colonne '%s': %w", column, err)
+		}
+		if present {
+			values[fieldName] = value
+		}
+	}
+
+	fact := constraint.Fact{Type: "fact", TypeName: typeName}
+	for _, field := range typeDef.Fields {
+		value, exists := values[field.Name]
+		if !exists {
+			return nil, fmt.Errorf("champ '%s' manquant pour le type %s", field.Name, typeName)
+		}
+		if err := constraint.ValidateFactFieldType(value, field.Type, typeName, field.Name); err != nil {
+			return nil, err
+		}
+		fact.Fields = append(fact.Fields, constraint.FactField{Name: field.Name, Value: value})
+	}
+
+	id, err := constraint.GenerateFactID(fact, typeDef.toConstraint(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("génération de l'identifiant: %w", err)
+	}
+
+	fields := make(map[string]interface{}, len(fact.Fields)+1)
+	for _, field := range fact.Fields {
+		fields[field.Name] = field.Value.Value
+	}
+	fields[FieldNameID] = id
+	return &Fact{ID: id, Type: typeName, Fields: fields}, nil
+}
+
+// coerceFactValue convertit une valeur brute selon le type du champ. Une
+// valeur nulle, ou une cellule vide pour un champ non string, est absente.
+func coerceFactValue(raw interface{}, field Field) (constraint.FactValue, bool, error) {
+	if raw == nil {
+		return constraint.FactValue{}, false, nil
+	}
+	if text, ok := raw.(string); ok && text == "" && field.Type != constraint.ValueTypeString {
+		return constraint.FactValue{}, false, nil
+	}
+
+	switch field.Type {
+	case constraint.ValueTypeString:
+		switch value := raw.(type) {
+		case string:
+			return constraint.FactValue{Type: constraint.ValueTypeString, Value: value}, true, nil
+		case json.Number:
+			return constraint.FactValue{Type: constraint.ValueTypeString, Value: value.String()}, true, nil
+		case bool:
+			return constraint.FactValue{Type: constraint.ValueTypeString, Value: strconv.FormatBool(value)}, true, nil
+		}
+	case constraint.ValueTypeNumber:
+		var number float64
+		var err error
+		switch value := raw.(type) {
+		case json.Number:
+			number, err = value.Float64()
+		case float64:
+			number = value
+		case string:
+			number, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+		default:
+			err = errors.New("type incompatible")
+		}
+		if err != nil {
+			return constraint.FactValue{}, false, fmt.Errorf("valeur '%v' non numérique pour le champ '%s'", raw, field.Name)
+		}
+		return constraint.FactValue{Type: constraint.ValueTypeNumber, Value: number}, true, nil
+	case constraint.ValueTypeBool, constraint.ValueTypeBoolean:
+		switch value := raw.(type) {
+		case bool:
+			return constraint.FactValue{Type: constraint.ValueTypeBoolean, Value: value}, true, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+				return constraint.FactValue{Type: constraint.ValueTypeBoolean, Value: b}, true, nil
+			}
+		}
+		return constraint.FactValue{}, false, fmt.Errorf("valeur '%v' non booléenne pour le champ '%s'", raw, field.Name)
+	default:
+		if constraint.IsPrimitiveType(field.Type) {
+			break
+		}
+		// Référence vers un fait : identifiant interne, préfixé du type si besoin
+		var ref string
+		switch value := raw.(type) {
+		case string:
+			ref = value
+		case json.Number:
+			ref = value.String()
+		default:
+			return constraint.FactValue{}, false, fmt.Errorf("référence '%v' invalide pour le champ '%s'", raw, field.Name)
+		}
+		if !strings.HasPrefix(ref, field.Type+constraint.IDSeparatorType) {
+			ref = field.Type + constraint.IDSeparatorType + ref
+		}
+		return constraint.FactValue{Type: constraint.ValueTypeString, Value: ref}, true, nil
+	}
+	return constraint.FactValue{}, false, fmt.Errorf("valeur %v (%T) incompatible avec le champ '%s' de type %s", raw, raw, field.Name, field.Type)
+}
+
+// field retourne la définition d'un champ du type, nil s'il n'existe pas
+func (td *TypeDefinition) field(name string) *Field {
+	for i := range td.Fields {
+		if td.Fields[i].Name == name {
+			return &td.Fields[i]
+		}
+	}
+	return nil
+}
+
+// toConstraint convertit la définition vers le modèle du package constraint
+func (td *TypeDefinition) toConstraint() constraint.TypeDefinition {
+	fields := make([]constraint.Field, len(td.Fields))
+	for i, field := range td.Fields {
+		fields[i] = constraint.Field{Name: field.Name, Type: field.Type, IsPrimaryKey: field.IsPrimaryKey}
+	}
+	return constraint.TypeDefinition{Type: "typeDefinition", Name: td.Name, Fields: fields}
+}
+
+// readFactRecords lit tous les enregistrements du flux
+func readFactRecords(r io.Reader, format FactFormat) ([]factRecord, error) {
+	switch format {
+	case FactFormatJSON:
+		return readJSONRecords(r)
+	case FactFormatNDJSON:
+		return readNDJSONRecords(r)
+	case FactFormatCSV:
+		return readCSVRecords(r)
+	default:
+		return nil, fmt.Errorf("format de faits '%s' inconnu (json, ndjson, csv)", format)
+	}
+}
+
+// readJSONRecords lit un tableau JSON d'objets
+func readJSONRecords(r io.Reader) ([]factRecord, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("tableau JSON attendu")
+	}
+	var records []factRecord
+	for decoder.More() {
+		var raw interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("enregistrement %d: %w", len(records)+1, err)
+		}
+		records = append(records, newJSONRecord(raw, 0))
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("fin du tableau JSON: %w", err)
+	}
+	return records, nil
+}
+
+// readNDJSONRecords lit un objet JSON par ligne ; une ligne malformée est
+// rejetée sans interrompre la lecture
+func readNDJSONRecords(r io.Reader) ([]factRecord, error) {
+	reader := bufio.NewReader(r)
+	var records []factRecord
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("ligne %d: %w", line, err)
+		}
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(trimmed))
+			decoder.UseNumber()
+			var raw interface{}
+			if decodeErr := decoder.Decode(&raw); decodeErr != nil {
+				records = append(records, factRecord{line: line, err: fmt.Errorf("JSON invalide: %w", decodeErr)})
+			} else {
+				records = append(records, newJSONRecord(raw, line))
+			}
+		}
+		if err == io.EOF {
+			return records, nil
+		}
+	}
+}
+
+// newJSONRecord construit un enregistrement depuis un objet JSON décodé
+func newJSONRecord(raw interface{}, line int) factRecord {
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		return factRecord{line: line, err: errors.New("objet JSON attendu")}
+	}
+	columns := make([]string, 0, len(object))
+	for column, value := range object {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return factRecord{line: line, err: fmt.Errorf("colonne '%s': valeur composite non supportée", column)}
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return factRecord{line: line, columns: columns, values: object}
+}
+
+// readCSVRecords lit un CSV dont la première ligne nomme les colonnes
+func readCSVRecords(r io.Reader) ([]factRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("en-tête CSV: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	var records []factRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			records = append(records, factRecord{line: parseErr.StartLine, err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		values := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			values[column] = row[i]
+		}
+		records = append(records, factRecord{line: line, columns: header, values: values})
+	}
+}
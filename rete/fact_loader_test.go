@@ -0,0 +1,200 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const loaderProgram = `type Person(#id: string, age: number, active: bool, city: string)
+type Order(#id: string, buyer: Person, amount: number)
+action notify(id: string)
+rule adults : {p: Person} / p.age >= 18 ==> notify(p.id)
+`
+
+// loadErrors indexe les messages d'erreur du rapport par rang d'enregistrement
+func loadErrors(report *FactLoadReport) map[int]FactLoadError {
+	errs := make(map[int]FactLoadError, len(report.Errors))
+	for _, err := range report.Errors {
+		errs[err.Record] = err
+	}
+	return errs
+}
+
+func TestLoadFacts_CSV(t *testing.T) {
+	network := buildGraphNetwork(t, loaderProgram)
+	calls := 0
+	if err := network.ActionExecutor.RegisterAction(notifyHandler{calls: &calls}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+
+	csv := `ident,age,active,city,comment
+p1, 42,true,Paris,ok
+p2,abc,true,Lyon,ok
+p3,30,maybe,Lyon,ok
+p4,,true,Lyon,ok
+p1,50,false,Nice,ok
+p5,20,false
+`
+	report, err := network.LoadFacts(strings.NewReader(csv), FactFormatCSV, FactMapping{
+		Type:   "Person",
+		Fields: map[string]string{"ident": "id", "comment": ""},
+	})
+	if err != nil {
+		t.Fatalf("LoadFacts() error = %v", err)
+	}
+	if report.Records != 6 || report.Loaded != 1 || len(report.Errors) != 5 {
+		t.Fatalf("rapport = %+v", report)
+	}
+
+	errs := loadErrors(report)
+	expected := map[int]struct {
+		line    int
+		message string
+	}{
+		2: {3, "non numérique"},
+		3: {4, "non booléenne"},
+		4: {5, "champ 'age' manquant"},
+		5: {6, "en double"},
+		6: {7, "number of fields"},
+	}
+	for record, want := range expected {
+		got, ok := errs[record]
+		if !ok || got.Line != want.line || !strings.Contains(got.Message, want.message) {
+			t.Errorf("erreur de l'enregistrement %d = %+v, attendu ligne %d contenant %q", record, got, want.line, want.message)
+		}
+	}
+
+	fact := network.Storage.GetFact("Person~p1")
+	if fact == nil || fact.Fields["age"] != 42.0 || fact.Fields["active"] != true || fact.Fields["city"] != "Paris" {
+		t.Fatalf("fait chargé = %v", fact)
+	}
+	if _, exists := fact.Fields["comment"]; exists {
+		t.Errorf("colonne ignorée présente: %v", fact.Fields)
+	}
+	if calls != 1 {
+		t.Errorf("notify appelée %d fois, attendu 1", calls)
+	}
+}
+
+func TestLoadFacts_NDJSONTypeField(t *testing.T) {
+	network := buildGraphNetwork(t, loaderProgram)
+
+	ndjson := `{"type": "Person", "id": "p1", "age": 30, "active": "1", "city": "Paris"}
+
+{"type": "Order", "id": "o1", "buyer": "p1", "amount": "12.5"}
+{"type": "Order", "id": "o2", "buyer": "Person~p1", "amount": 3}
+not json
+{"type": "Ghost", "id": "g1"}
+{"id": "x"}
+{"type": "Person", "id": "p2", "age": 30, "active": true, "city": "Lyon", "zip": "69000"}
+`
+	report, err := network.LoadFacts(strings.NewReader(ndjson), FactFormatNDJSON, FactMapping{TypeField: "type"})
+	if err != nil {
+		t.Fatalf("LoadFacts() error = %v", err)
+	}
+	if report.Records != 7 || report.Loaded != 3 {
+		t.Fatalf("rapport = %+v", report)
+	}
+	expected := map[int]string{5: "JSON invalide", 6: "type 'Ghost' non défini", 7: "colonne de type 'type' absente", 8: "champ 'zip' non défini"}
+	for _, loadErr := range report.Errors {
+		if want, ok := expected[loadErr.Line]; !ok || !strings.Contains(loadErr.Message, want) {
+			t.Errorf("erreur inattendue: %v", loadErr)
+		}
+	}
+	if len(report.Errors) != len(expected) {
+		t.Errorf("erreurs = %v", report.Errors)
+	}
+
+	if order := network.Storage.GetFact("Order~o1"); order == nil || order.Fields["buyer"] != "Person~p1" || order.Fields["amount"] != 12.5 {
+		t.Errorf("Order~o1 = %v", order)
+	}
+	if order := network.Storage.GetFact("Order~o2"); order == nil || order.Fields["buyer"] != "Person~p1" {
+		t.Errorf("Order~o2 = %v", order)
+	}
+
+	// Un second chargement signale les faits déjà présents
+	report, err = network.LoadFacts(strings.NewReader(`{"type": "Order", "id": "o1", "buyer": "p1", "amount": 1}`), FactFormatNDJSON, FactMapping{TypeField: "type"})
+	if err != nil {
+		t.Fatalf("LoadFacts() error = %v", err)
+	}
+	if report.Loaded != 0 || len(report.Errors) != 1 || !strings.Contains(report.Errors[0].Message, "déjà présent") {
+		t.Errorf("rapport = %+v", report)
+	}
+}
+
+func TestLoadFacts_JSONMatchesTSDIdentifiers(t *testing.T) {
+	program := `type Event(kind: string, value: number)
+type Tag(#name: string, event: Event)
+`
+	written := buildGraphNetwork(t, program+`Event(kind: "click", value: 2)
+`)
+	loaded := buildGraphNetwork(t, program)
+
+	report, err := loaded.LoadFacts(strings.NewReader(`[{"kind": "click", "value": 2}]`), FactFormatJSON, FactMapping{Type: "Event"})
+	if err != nil || report.Loaded != 1 {
+		t.Fatalf("LoadFacts() = %+v, %v", report, err)
+	}
+	facts := loaded.Storage.GetAllFacts()
+	if len(facts) != 1 || written.Storage.GetFact(facts[0].ID) == nil {
+		t.Fatalf("identifiant différent de celui du fait écrit en TSD: %v / %v", facts, written.Storage.GetAllFacts())
+	}
+	eventID := facts[0].ID
+
+	report, err = loaded.LoadFacts(strings.NewReader(`[{"name": "t1", "event": "`+eventID+`"}]`), FactFormatJSON, FactMapping{Type: "Tag"})
+	if err != nil || report.Loaded != 1 {
+		t.Fatalf("LoadFacts() = %+v, %v", report, err)
+	}
+	if tag := loaded.Storage.GetFact("Tag~t1"); tag == nil || tag.Fields["event"] != eventID {
+		t.Errorf("Tag~t1 = %v", tag)
+	}
+}
+
+func TestLoadFacts_RollbackOnSubmissionFailure(t *testing.T) {
+	network := buildGraphNetwork(t, loaderProgram)
+	if err := network.SetFactLimits(FactLimits{MaxFactsPerType: map[string]int{"Person": 1}}); err != nil {
+		t.Fatalf("SetFactLimits() error = %v", err)
+	}
+
+	csv := "id,age,active,city\np1,20,true,Paris\np2,30,true,Lyon\n"
+	_, err := network.LoadFacts(strings.NewReader(csv), FactFormatCSV, FactMapping{Type: "Person"})
+	var limitErr *FactLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("LoadFacts() error = %v, attendu FactLimitError", err)
+	}
+	if facts := network.Storage.GetAllFacts(); len(facts) != 0 {
+		t.Errorf("faits conservés après annulation: %v", facts)
+	}
+	if tx := network.GetTransaction(); tx != nil {
+		t.Errorf("transaction restée ouverte: %v", tx)
+	}
+}
+
+func TestLoadFacts_InvalidInput(t *testing.T) {
+	network := buildGraphNetwork(t, loaderProgram)
+
+	tests := []struct {
+		name    string
+		input   string
+		format  FactFormat
+		mapping FactMapping
+		want    string
+	}{
+		{"format inconnu", "", "xml", FactMapping{Type: "Person"}, "format de faits 'xml' inconnu"},
+		{"type inconnu", "", FactFormatCSV, FactMapping{Type: "Ghost"}, "type 'Ghost' non défini"},
+		{"type absent", "", FactFormatCSV, FactMapping{}, "type des faits non précisé"},
+		{"pas un tableau", `{"id": "p1"}`, FactFormatJSON, FactMapping{Type: "Person"}, "tableau JSON attendu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := network.LoadFacts(strings.NewReader(tt.input), tt.format, tt.mapping)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadFacts() error = %v, attendu %q", err, tt.want)
+			}
+		})
+	}
+}
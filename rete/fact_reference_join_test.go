@@ -5,7 +5,9 @@
 package rete
 
 import (
+	"fmt"
 	"os"
+	"sort"
 	"testing"
 )
 
@@ -138,3 +140,61 @@ Commande(id: "CMD002", produit: p2, quantite: 5)
 		t.Logf("✅ Test réussi: %d jointures correctes", execCount)
 	}
 }
+
+// TestFactReferenceJoin_CompositeConditions vérifie les disjonctions et
+// négations portant sur les deux variables d'une jointure, et la comparaison
+// d'une référence au fait lui-même à l'intérieur de ces expressions
+func TestFactReferenceJoin_CompositeConditions(t *testing.T) {
+	network := buildGraphNetwork(t, `type Customer(#id: string, tier: string)
+type Order(#id: string, customer: Customer, total: number)
+action notify(id: string)
+rule either : {o: Order, c: Customer} / o.customer == c AND (o.total < 1000 OR c.tier != "gold") ==> notify(o.id)
+rule neither : {o: Order, c: Customer} / o.customer == c AND NOT (o.total >= 1000 AND c.tier == "gold") AND o.total < 100 ==> notify(o.id)
+rule foreign : {o: Order, c: Customer} / NOT (o.customer == c) AND c.tier == "gold" ==> notify(o.id)
+rule owned : {o: Order, c: Customer} / o.customer == c AND o.total > 1000 ==> notify(o.id)
+rule ownedOrHuge : {o: Order, c: Customer} / (o.customer == c OR o.total > 4000) AND c.tier == "silver" ==> notify(o.id)
+`)
+	results := &callRecorder{}
+	network.SetActionObserver(results)
+
+	for _, event := range []FactEvent{
+		{Op: FactOperationInsert, Type: "Customer", Fields: map[string]interface{}{"id": "gold", "tier": "gold"}},
+		{Op: FactOperationInsert, Type: "Customer", Fields: map[string]interface{}{"id": "silver", "tier": "silver"}},
+		{Op: FactOperationInsert, Type: "Order", Fields: map[string]interface{}{"id": "big-gold", "customer": "gold", "total": 5000.0}},
+		{Op: FactOperationInsert, Type: "Order", Fields: map[string]interface{}{"id": "big-silver", "customer": "silver", "total": 5000.0}},
+		{Op: FactOperationInsert, Type: "Order", Fields: map[string]interface{}{"id": "small-gold", "customer": "gold", "total": 50.0}},
+	} {
+		if _, err := network.ApplyFactEvent(event); err != nil {
+			t.Fatalf("ApplyFactEvent(%v) error = %v", event, err)
+		}
+	}
+
+	fired := map[string][]string{}
+	for _, result := range *results {
+		fired[result.Context.RuleName] = append(fired[result.Context.RuleName], fmt.Sprint(result.Arguments[0]))
+	}
+	tests := []struct {
+		rule string
+		want string
+	}{
+		// OR entre les deux variables de la jointure
+		{"either", "[big-silver small-gold]"},
+		// NOT autour d'une conjonction portant sur les deux variables
+		{"neither", "[small-gold]"},
+		// NOT autour de la condition de jointure elle-même
+		{"foreign", "[big-silver]"},
+		// Jointure simple sur la référence, inchangée
+		{"owned", "[big-gold big-silver]"},
+		// Référence comparée au fait à l'intérieur d'un OR
+		{"ownedOrHuge", "[big-gold big-silver]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			orders := fired[tt.rule]
+			sort.Strings(orders)
+			if got := fmt.Sprint(orders); got != tt.want {
+				t.Errorf("%s déclenchée pour %s, attendu %s", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
@@ -93,13 +93,15 @@ type TokenMetadata struct {
 // Changement majeur: Bindings utilise maintenant BindingChain au lieu de map[string]*Fact
 // pour garantir l'immutabilité et éviter la perte de bindings lors des jointures en cascade.
 type Token struct {
-	ID           string        `json:"id"`
-	Facts        []*Fact       `json:"facts"`
-	NodeID       string        `json:"node_id"`
-	Parent       *Token        `json:"parent,omitempty"`
-	Bindings     *BindingChain `json:"-"`                        // Chaîne immuable de bindings (non sérialisable)
-	IsJoinResult bool          `json:"is_join_result,omitempty"` // Indique si c'est un token de jointure réussie
-	Metadata     TokenMetadata `json:"metadata,omitempty"`       // Métadonnées pour traçage
+	ID           string                 `json:"id"`
+	Facts        []*Fact                `json:"facts"`
+	NodeID       string                 `json:"node_id"`
+	Parent       *Token                 `json:"parent,omitempty"`
+	Bindings     *BindingChain          `json:"-"`                        // Chaîne immuable de bindings (non sérialisable)
+	IsJoinResult bool                   `json:"is_join_result,omitempty"` // Indique si c'est un token de jointure réussie
+	Metadata     TokenMetadata          `json:"metadata,omitempty"`       // Métadonnées pour traçage
+	Collections  map[string][]*Fact     `json:"collections,omitempty"`    // Listes de faits liées par COLLECT
+	Aggregates   map[string]interface{} `json:"aggregates,omitempty"`     // Résultats des variables d'agrégation
 }
 
 // WorkingMemory représente la mémoire de travail d'un nœud
@@ -120,7 +120,10 @@ func (iv *IncrementalValidator) mergePrograms(
 		Types:       make([]constraint.TypeDefinition, 0),
 		Actions:     make([]constraint.ActionDefinition, 0),
 		Expressions: newProgram.Expressions,
+		Queries:     newProgram.Queries,
 		Facts:       newProgram.Facts,
+		// Les affectations sont nécessaires pour valider les références de variables des faits
+		FactAssignments: newProgram.FactAssignments,
 	}
 
 	// Créer un index des types existants
@@ -251,6 +254,11 @@ func (iv *IncrementalValidator) programToAST(program *constraint.Program) interf
 	}
 	result["expressions"] = expressions
 
+	// Ajouter les requêtes (validées avec les types fusionnés)
+	if len(program.Queries) > 0 {
+		result["queries"] = program.Queries
+	}
+
 	// Ajouter les faits s'ils existent
 	if len(program.Facts) > 0 {
 		facts := make([]interface{}, len(program.Facts))
@@ -264,6 +272,10 @@ func (iv *IncrementalValidator) programToAST(program *constraint.Program) interf
 		result["facts"] = facts
 	}
 
+	if len(program.FactAssignments) > 0 {
+		result["factAssignments"] = program.FactAssignments
+	}
+
 	return result
 }
 
@@ -324,8 +336,18 @@ func (iv *IncrementalValidator) validateCrossFileConsistency(
 func (iv *IncrementalValidator) extractTypesFromExpression(expr constraint.Expression) []string {
 	types := make([]string, 0)
 
-	// Extraire les types des variables
+	// Extraire les types des variables : une variable COLLECT porte le type
+	// des faits collectés, une variable d'agrégation n'a pas de type propre
 	for _, variable := range expr.Set.Variables {
+		switch variable.Type {
+		case constraint.VariableTypeAggregation:
+			continue
+		case constraint.VariableTypeCollect:
+			if variable.Variable != nil {
+				types = append(types, variable.Variable.DataType)
+			}
+			continue
+		}
 		types = append(types, variable.DataType)
 	}
 
@@ -5,10 +5,13 @@
 package rete
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/treivax/tsd/constraint"
 	"github.com/treivax/tsd/rete/delta"
 )
 
@@ -19,6 +22,8 @@ type ReteNetwork struct {
 	AlphaNodes            map[string]*AlphaNode    `json:"alpha_nodes"`
 	BetaNodes             map[string]interface{}   `json:"beta_nodes"` // Nœuds Beta pour les jointures multi-faits
 	TerminalNodes         map[string]*TerminalNode `json:"terminal_nodes"`
+	Queries               map[string]*Query        `json:"-"` // Requêtes nommées compilées dans le réseau
+	Modules               *constraint.ModuleLoader `json:"-"` // Fichiers importés et symboles des packages déjà chargés
 	Storage               Storage                  `json:"-"`
 	Types                 []TypeDefinition         `json:"types"`
 	Actions               []ActionDefinition       `json:"actions"` // Action definitions for incremental validation
@@ -33,9 +38,23 @@ type ReteNetwork struct {
 	Config                *ChainPerformanceConfig  `json:"-"`       // Configuration de performance
 	ActionExecutor        *ActionExecutor          `json:"-"`       // Exécuteur d'actions
 	actionObserver        ActionObserver           `json:"-"`       // Observateur d'actions (nouveau)
+	tracer                *Tracer                  `json:"-"`       // Traceur de la propagation (nil : désactivé)
+	tracedNodeCount       int                      `json:"-"`       // Nombre de nœuds rattachés au réseau lors du dernier traçage
+	activations           *activationLog           `json:"-"`       // Dernières activations de règles (pour Explain)
+	factLimiter           *factLimiter             `json:"-"`       // Limites de faits en mémoire (nil : illimité)
+	cascade               *cascadeGuard            `json:"-"`       // Garde-fous contre les cascades de règles
+	ruleStates            *ruleStates              `json:"-"`       // États (active, disabled, dry-run) et annotations des règles
+	simulation            *simulation              `json:"-"`       // Simulation en cours (nil : aucune)
+	simMutex              sync.RWMutex             `json:"-"`       // Mutex pour accès concurrent à la simulation
+	definitions           definitions              `json:"-"`       // Déclarations ingérées (voir Definitions)
+	seeding               atomic.Bool              `json:"-"`       // Recopie des faits d'un autre réseau en cours (actions suspendues)
 	ArithmeticResultCache *ArithmeticResultCache   `json:"-"`       // Cache global des résultats arithmétiques intermédiaires
 	currentTx             *Transaction             `json:"-"`       // Transaction courante (si en cours)
 	txMutex               sync.RWMutex             `json:"-"`       // Mutex pour accès concurrent à la transaction
+	undoing               atomic.Bool              `json:"-"`       // Annulation d'une transaction en cours (actions suspendues)
+	undoPoints            []*Transaction           `json:"-"`       // Transactions annulables précédant la transaction courante (UndoTransaction)
+	execCtx               context.Context          `json:"-"`       // Contexte d'annulation de la propagation en cours (nil : aucun)
+	ctxMutex              sync.RWMutex             `json:"-"`       // Mutex pour accès concurrent au contexte d'annulation
 	logger                *Logger                  `json:"-"`       // Logger structuré pour instrumentation
 	factIDCounter         int64                    `json:"-"`       // Compteur thread-safe pour génération d'IDs de faits
 	factIDMutex           sync.Mutex               `json:"-"`       // Mutex pour génération d'IDs de faits
@@ -47,6 +47,7 @@ func NewReteNetworkWithConfig(storage Storage, config *ChainPerformanceConfig) *
 		AlphaNodes:            make(map[string]*AlphaNode),
 		BetaNodes:             make(map[string]interface{}),
 		TerminalNodes:         make(map[string]*TerminalNode),
+		Queries:               make(map[string]*Query),
 		Storage:               storage,
 		Types:                 make([]TypeDefinition, 0),
 		BetaBuilder:           nil, // Deprecated field, kept for backward compatibility
@@ -59,6 +60,9 @@ func NewReteNetworkWithConfig(storage Storage, config *ChainPerformanceConfig) *
 		Config:                config,
 		ArithmeticResultCache: arithmeticCache,
 		logger:                NewLogger(LogLevelInfo, os.Stdout), // Logger par défaut niveau Info
+		activations:           newActivationLog(DefaultActivationLogSize),
+		cascade:               newCascadeGuard(),
+		ruleStates:            newRuleStates(),
 
 		// Phase 2: Initialiser les paramètres de synchronisation
 		SubmissionTimeout: DefaultSubmissionTimeout,
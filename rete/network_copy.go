@@ -0,0 +1,154 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// definitionKeys sont les clés d'AST conservées par le réseau, dans l'ordre
+// d'ingestion, avec le champ qui identifie chaque déclaration
+var definitionKeys = []struct{ key, name string }{
+	{"types", "name"},
+	{"actions", "name"},
+	{"xupleSpaces", "name"},
+	{"queries", "name"},
+	{"expressions", "ruleId"},
+}
+
+// definitions conserve les déclarations ingérées dans le réseau (format AST),
+// la dernière déclaration d'un nom remplaçant les précédentes
+type definitions struct {
+	mutex sync.Mutex
+	order map[string][]string               // Clé d'AST → noms, dans l'ordre de première déclaration
+	items map[string]map[string]interface{} // Clé d'AST → nom → déclaration
+}
+
+// record enregistre les déclarations d'un programme ingéré
+func (d *definitions) record(ast map[string]interface{}) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.items == nil {
+		d.order = make(map[string][]string)
+		d.items = make(map[string]map[string]interface{})
+	}
+	for _, def := range definitionKeys {
+		items, _ := ast[def.key].([]interface{})
+		for _, item := range items {
+			itemMap, _ := item.(map[string]interface{})
+			name, _ := itemMap[def.name].(string)
+			if name == "" {
+				continue
+			}
+			if d.items[def.key] == nil {
+				d.items[def.key] = make(map[string]interface{})
+			}
+			if _, exists := d.items[def.key][name]; !exists {
+				d.order[def.key] = append(d.order[def.key], name)
+			}
+			d.items[def.key][name] = item
+		}
+	}
+}
+
+// reset oublie toutes les déclarations
+func (d *definitions) reset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.order = nil
+	d.items = nil
+}
+
+// Definitions retourne un programme (format AST) qui reconstruit la structure
+// du réseau : types, actions, xuple-spaces, requêtes et règles encore
+// présentes, sans aucun fait. Les règles supprimées ou annulées depuis leur
+// ingestion sont écartées.
+func (rn *ReteNetwork) Definitions() map[string]interface{} {
+	rn.definitions.mutex.Lock()
+	defer rn.definitions.mutex.Unlock()
+
+	program := make(map[string]interface{}, len(definitionKeys))
+	for _, def := range definitionKeys {
+		items := []interface{}{}
+		for _, name := range rn.definitions.order[def.key] {
+			if def.key == "expressions" && rn.findRuleTerminal(name) == nil {
+				continue
+			}
+			if def.key == "queries" {
+				if _, exists := rn.Queries[name]; !exists {
+					continue
+				}
+			}
+			items = append(items, rn.definitions.items[def.key][name])
+		}
+		program[def.key] = items
+	}
+	return program
+}
+
+// SeedFacts recopie la mémoire de travail et l'état des règles d'un autre
+// réseau, construit à partir de ses Definitions : les faits sont insérés sans
+// exécuter d'action (leurs activations ont déjà eu lieu dans source), dans
+// leur ordre d'admission par les limites de faits lorsqu'elles sont actives ;
+// les règles désactivées conservent leurs correspondances, les activations à
+// blanc sont reprises et les identifiants générés reprennent après ceux de
+// source.
+func (rn *ReteNetwork) SeedFacts(source *ReteNetwork) error {
+	if source.ruleStates != nil {
+		if rn.ruleStates == nil {
+			rn.ruleStates = newRuleStates()
+		}
+		source.ruleStates.mutex.Lock()
+		rn.ruleStates.mutex.Lock()
+		for ruleID, state := range source.ruleStates.states {
+			rn.ruleStates.states[ruleID] = state
+		}
+		rn.ruleStates.dryRuns = append(rn.ruleStates.dryRuns, source.ruleStates.dryRuns...)
+		rn.ruleStates.sequence = source.ruleStates.sequence
+		rn.ruleStates.mutex.Unlock()
+		source.ruleStates.mutex.Unlock()
+	}
+
+	source.factIDMutex.Lock()
+	counter := source.factIDCounter
+	source.factIDMutex.Unlock()
+	rn.factIDMutex.Lock()
+	rn.factIDCounter = counter
+	rn.factIDMutex.Unlock()
+
+	facts := source.Storage.GetAllFacts()
+	order := make(map[string]uint64, len(facts))
+	if limiter := source.factLimiter; limiter != nil {
+		limiter.mutex.Lock()
+		for id, sequence := range limiter.present {
+			order[id] = sequence
+		}
+		limiter.mutex.Unlock()
+	}
+	sort.Slice(facts, func(i, j int) bool {
+		left, right := facts[i].GetInternalID(), facts[j].GetInternalID()
+		if order[left] != order[right] {
+			return order[left] < order[right]
+		}
+		return left < right
+	})
+
+	rn.seeding.Store(true)
+	defer rn.seeding.Store(false)
+	for _, fact := range facts {
+		if err := rn.SubmitFact(fact.Clone()); err != nil {
+			return fmt.Errorf("copie du fait %s: %w", fact.GetInternalID(), err)
+		}
+	}
+	return nil
+}
+
+// Seeding indique si des faits sont en cours de recopie ou des règles en
+// cours de restauration (actions suspendues)
+func (rn *ReteNetwork) Seeding() bool {
+	return rn.seeding.Load()
+}
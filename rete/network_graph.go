@@ -0,0 +1,468 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Genres de nœuds d'un NetworkGraph
+const (
+	GraphNodeType        = "type"
+	GraphNodeAlpha       = "alpha"
+	GraphNodePassthrough = "passthrough"
+	GraphNodeJoin        = "join"
+	GraphNodeExists      = "exists"
+	GraphNodeAccumulator = "accumulator"
+	GraphNodeCollect     = "collect"
+	GraphNodeRouter      = "router"
+	GraphNodeTerminal    = "terminal"
+)
+
+// graphKindOrder fixe l'ordre des nœuds dans le graphe, de l'entrée vers les actions
+var graphKindOrder = map[string]int{
+	GraphNodeType:        0,
+	GraphNodeAlpha:       1,
+	GraphNodePassthrough: 2,
+	GraphNodeJoin:        3,
+	GraphNodeExists:      4,
+	GraphNodeAccumulator: 5,
+	GraphNodeCollect:     6,
+	GraphNodeRouter:      7,
+	GraphNodeTerminal:    8,
+}
+
+// NetworkGraph est la topologie du réseau RETE compilé, prête à être exportée
+// (Graphviz DOT, Mermaid, JSON)
+type NetworkGraph struct {
+	Rules []string    `json:"rules"`           // Règles représentées (toutes, ou le filtre demandé)
+	Facts int         `json:"facts"`           // Faits en mémoire dans le réseau
+	Nodes []GraphNode `json:"nodes"`           // Nœuds, de l'entrée vers les actions
+	Edges []GraphEdge `json:"edges"`           // Arcs de propagation parent → enfant
+	Stats GraphStats  `json:"stats,omitempty"` // Comptes par genre de nœud
+}
+
+// GraphNode est un nœud du réseau
+type GraphNode struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`  // Genre (GraphNodeType, GraphNodeAlpha, ...)
+	Label string `json:"label"` // Condition, jointure, agrégat ou action du nœud
+	// Règles dont le terminal est alimenté par ce nœud
+	Rules []string `json:"rules"`
+	// Nœud partagé entre plusieurs règles
+	Shared bool `json:"shared"`
+	// Taille des mémoires du nœud (faits ou tokens), lorsque des faits sont chargés
+	Memory map[string]int `json:"memory,omitempty"`
+}
+
+// GraphEdge est un arc de propagation
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Side string `json:"side,omitempty"` // Entrée du nœud beta alimentée : "left" ou "right"
+}
+
+// GraphStats compte les nœuds du graphe
+type GraphStats struct {
+	Kinds  map[string]int `json:"kinds"`  // Nombre de nœuds par genre
+	Shared int            `json:"shared"` // Nombre de nœuds partagés
+}
+
+// Graph retourne la topologie du réseau. Si des règles sont données, le graphe
+// est restreint aux nœuds qui alimentent leurs terminaux.
+func (rn *ReteNetwork) Graph(rules ...string) (*NetworkGraph, error) {
+	builder := newGraphBuilder(rn)
+	builder.walk()
+
+	terminals := make([]*TerminalNode, 0, len(rules))
+	for _, rule := range rules {
+		terminal := rn.findRuleTerminal(rule)
+		if terminal == nil {
+			return nil, fmt.Errorf("règle '%s' inconnue", rule)
+		}
+		terminals = append(terminals, terminal)
+	}
+	if len(rules) == 0 {
+		for _, terminal := range rn.TerminalNodes {
+			terminals = append(terminals, terminal)
+		}
+	}
+
+	return builder.build(terminals), nil
+}
+
+// graphBuilder parcourt le réseau depuis la racine
+type graphBuilder struct {
+	network *ReteNetwork
+	nodes   []Node                   // Nœuds atteints, dans l'ordre du parcours
+	parents map[Node][]Node          // Parents de chaque nœud
+	rules   map[Node]map[string]bool // Règles alimentées par chaque nœud
+}
+
+// newGraphBuilder crée un parcours du réseau
+func newGraphBuilder(network *ReteNetwork) *graphBuilder {
+	return &graphBuilder{
+		network: network,
+		parents: make(map[Node][]Node),
+		rules:   make(map[Node]map[string]bool),
+	}
+}
+
+// walk relève les nœuds atteints depuis la racine et leurs parents
+func (gb *graphBuilder) walk() {
+	if gb.network.RootNode == nil {
+		return
+	}
+	seen := map[Node]bool{gb.network.RootNode: true}
+	queue := []Node{gb.network.RootNode}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, child := range graphChildren(node) {
+			if !containsNode(gb.parents[child], node) {
+				gb.parents[child] = append(gb.parents[child], node)
+			}
+			if !seen[child] {
+				seen[child] = true
+				gb.nodes = append(gb.nodes, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	// Chaque nœud est attribué aux règles dont il alimente le terminal
+	for _, terminal := range gb.network.TerminalNodes {
+		for _, ancestor := range gb.ancestors(terminal) {
+			if gb.rules[ancestor] == nil {
+				gb.rules[ancestor] = make(map[string]bool)
+			}
+			gb.rules[ancestor][terminal.getRuleName()] = true
+		}
+	}
+}
+
+// graphChildren retourne les enfants d'un nœud ; un routeur de règle est relié
+// à son terminal hors de la liste des enfants
+func graphChildren(node Node) []Node {
+	children := node.GetChildren()
+	if router, ok := node.(*RuleRouterNode); ok && router.TerminalNode != nil && !containsNode(children, router.TerminalNode) {
+		children = append(append([]Node{}, children...), router.TerminalNode)
+	}
+	return children
+}
+
+// ancestors retourne le nœud et ses ancêtres, racine exclue
+func (gb *graphBuilder) ancestors(node Node) []Node {
+	result := []Node{node}
+	seen := map[Node]bool{node: true}
+	for i := 0; i < len(result); i++ {
+		for _, parent := range gb.parents[result[i]] {
+			if _, root := parent.(*RootNode); root || seen[parent] {
+				continue
+			}
+			seen[parent] = true
+			result = append(result, parent)
+		}
+	}
+	return result
+}
+
+// build construit le graphe des nœuds qui alimentent les terminaux donnés
+func (gb *graphBuilder) build(terminals []*TerminalNode) *NetworkGraph {
+	kept := make(map[Node]bool)
+	ruleSet := make(map[string]bool)
+	for _, terminal := range terminals {
+		ruleSet[terminal.getRuleName()] = true
+		for _, node := range gb.ancestors(terminal) {
+			kept[node] = true
+		}
+	}
+
+	graph := &NetworkGraph{
+		Rules: sortedSet(ruleSet),
+		Nodes: []GraphNode{},
+		Edges: []GraphEdge{},
+		Stats: GraphStats{Kinds: make(map[string]int)},
+	}
+	if gb.network.Storage != nil {
+		graph.Facts = len(gb.network.Storage.GetAllFacts())
+	}
+
+	for _, node := range gb.nodes {
+		if !kept[node] {
+			continue
+		}
+		graphNode := gb.describe(node)
+		graphNode.Rules = sortedSet(gb.rules[node])
+		graphNode.Shared = gb.isShared(node, len(graphNode.Rules))
+		if graph.Facts > 0 {
+			graphNode.Memory = nodeMemorySizes(node)
+		}
+		graph.Nodes = append(graph.Nodes, graphNode)
+		graph.Stats.Kinds[graphNode.Kind]++
+		if graphNode.Shared {
+			graph.Stats.Shared++
+		}
+
+		for _, parent := range gb.parents[node] {
+			if _, root := parent.(*RootNode); root || !kept[parent] {
+				continue
+			}
+			graph.Edges = append(graph.Edges, GraphEdge{From: parent.GetID(), To: node.GetID(), Side: edgeSide(parent, node)})
+		}
+	}
+
+	sort.SliceStable(graph.Nodes, func(i, j int) bool {
+		a, b := graph.Nodes[i], graph.Nodes[j]
+		if graphKindOrder[a.Kind] != graphKindOrder[b.Kind] {
+			return graphKindOrder[a.Kind] < graphKindOrder[b.Kind]
+		}
+		return a.ID < b.ID
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	return graph
+}
+
+// isShared indique si un nœud sert plusieurs règles, d'après le cycle de vie
+// des nœuds, le registre de partage beta et la topologie. Les nœuds de type,
+// communs à toutes les règles d'un type, ne sont pas considérés comme partagés.
+func (gb *graphBuilder) isShared(node Node, ruleCount int) bool {
+	if _, ok := node.(*TypeNode); ok {
+		return false
+	}
+	if ruleCount > 1 {
+		return true
+	}
+	if join, ok := node.(*JoinNode); ok && gb.network.BetaSharingRegistry != nil &&
+		gb.network.BetaSharingRegistry.GetJoinNodeRefCount(join.ID) > 1 {
+		return true
+	}
+	if gb.network.LifecycleManager != nil {
+		if lifecycle, ok := gb.network.LifecycleManager.GetNodeLifecycle(node.GetID()); ok {
+			return lifecycle.GetRefCount() > 1
+		}
+	}
+	return false
+}
+
+// describe donne le genre et le libellé d'un nœud
+func (gb *graphBuilder) describe(node Node) GraphNode {
+	graphNode := GraphNode{ID: node.GetID(), Kind: node.GetType()}
+	switch n := node.(type) {
+	case *TypeNode:
+		graphNode.Kind = GraphNodeType
+		graphNode.Label = n.TypeName
+	case *AlphaNode:
+		graphNode.Kind = GraphNodeAlpha
+		if isPassthroughCondition(n.Condition) {
+			graphNode.Kind = GraphNodePassthrough
+			condition, _ := n.Condition.(map[string]interface{})
+			graphNode.Label = n.VariableName
+			if side, _ := condition["side"].(string); side != "" {
+				graphNode.Label += " (" + side + ")"
+			}
+			break
+		}
+		graphNode.Label = alphaLabel(n)
+	case *JoinNode:
+		graphNode.Kind = GraphNodeJoin
+		graphNode.Label = joinLabel(n)
+	case *ExistsNode:
+		graphNode.Kind = GraphNodeExists
+		graphNode.Label = existsLabel(n)
+	case *AccumulatorNode:
+		graphNode.Kind = GraphNodeAccumulator
+		graphNode.Label = accumulatorLabel(n)
+	case *MultiSourceAccumulatorNode:
+		graphNode.Kind = GraphNodeAccumulator
+		graphNode.Label = multiSourceLabel(n)
+	case *CollectNode:
+		graphNode.Kind = GraphNodeCollect
+		graphNode.Label = fmt.Sprintf("%s: COLLECT(%s: %s)", n.CollectVariable, n.ElementVariable, n.ElementType)
+	case *RuleRouterNode:
+		graphNode.Kind = GraphNodeRouter
+		graphNode.Label = n.RuleID
+	case *TerminalNode:
+		graphNode.Kind = GraphNodeTerminal
+		graphNode.Label = terminalLabel(n)
+	}
+	return graphNode
+}
+
+// alphaLabel décrit la condition d'un nœud alpha
+func alphaLabel(node *AlphaNode) string {
+	if condition, _ := node.Condition.(map[string]interface{}); condition["type"] == ConditionTypeSimple {
+		return node.VariableName + ": *"
+	}
+	text := conditionText(node.Condition)
+	if node.ResultName != "" {
+		text = node.ResultName + " = " + text
+	}
+	if node.VariableName == "" {
+		return text
+	}
+	return node.VariableName + ": " + text
+}
+
+// joinLabel décrit les conditions d'une jointure
+func joinLabel(node *JoinNode) string {
+	if len(node.JoinConditions) == 0 {
+		if text := conditionText(node.unwrapCompositeCondition()); text != "" && text != "map[]" {
+			return text
+		}
+		return strings.Join(node.AllVariables, " × ")
+	}
+	parts := make([]string, 0, len(node.JoinConditions))
+	for _, jc := range node.JoinConditions {
+		parts = append(parts, fmt.Sprintf("%s.%s %s %s.%s", jc.LeftVar, jc.LeftField, jc.Operator, jc.RightVar, jc.RightField))
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// existsLabel décrit un test d'existence (ou de non-existence pour FORALL)
+func existsLabel(node *ExistsNode) string {
+	prefix := "EXISTS"
+	if node.Negated {
+		prefix = "NOT EXISTS"
+	}
+	label := fmt.Sprintf("%s (%s: %s)", prefix, node.ExistsVariable, node.VariableTypes[node.ExistsVariable])
+	if len(node.ExistsCondition) > 0 {
+		parts := make([]string, 0, len(node.ExistsCondition))
+		for _, jc := range node.ExistsCondition {
+			parts = append(parts, fmt.Sprintf("%s.%s %s %s.%s", jc.LeftVar, jc.LeftField, jc.Operator, jc.RightVar, jc.RightField))
+		}
+		label += " / " + strings.Join(parts, " AND ")
+	}
+	return label
+}
+
+// accumulatorLabel décrit un agrégat sur une source
+func accumulatorLabel(node *AccumulatorNode) string {
+	argument := node.AggVariable
+	if node.Field != "" {
+		argument += "." + node.Field
+	}
+	label := fmt.Sprintf("%s(%s: %s)", node.AggregateFunc, argument, node.AggType)
+	if node.ResultVar != "" {
+		label = node.ResultVar + ": " + label
+	}
+	return label
+}
+
+// multiSourceLabel décrit les agrégats d'un accumulateur multi-sources
+func multiSourceLabel(node *MultiSourceAccumulatorNode) string {
+	parts := make([]string, 0, len(node.AggregationVars))
+	for _, aggregation := range node.AggregationVars {
+		argument := aggregation.SourceVar
+		if aggregation.Field != "" {
+			argument += "." + aggregation.Field
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s(%s)", aggregation.Name, aggregation.Function, argument))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// terminalLabel décrit la règle et les actions d'un terminal
+func terminalLabel(node *TerminalNode) string {
+	if node.IsQuery() {
+		return "query " + node.getRuleName()
+	}
+	if node.Action == nil {
+		return node.getRuleName()
+	}
+	jobs := node.Action.GetJobs()
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Name != "" {
+			names = append(names, job.Name)
+		}
+	}
+	if len(names) == 0 {
+		return node.getRuleName()
+	}
+	return node.getRuleName() + " ⇒ " + strings.Join(names, ", ")
+}
+
+// edgeSide indique l'entrée du nœud beta alimentée par un passthrough
+func edgeSide(parent, child Node) string {
+	alpha, ok := parent.(*AlphaNode)
+	if !ok || !isPassthroughCondition(alpha.Condition) {
+		return ""
+	}
+	switch child.(type) {
+	case *AlphaNode, *TerminalNode, *RuleRouterNode:
+		return ""
+	}
+	condition, _ := alpha.Condition.(map[string]interface{})
+	side, _ := condition["side"].(string)
+	return side
+}
+
+// nodeMemorySizes retourne la taille des mémoires d'un nœud
+func nodeMemorySizes(node Node) map[string]int {
+	switch n := node.(type) {
+	case *JoinNode:
+		n.mutex.RLock()
+		defer n.mutex.RUnlock()
+		return map[string]int{"left": memorySize(n.LeftMemory), "right": memorySize(n.RightMemory), "results": memorySize(n.ResultMemory)}
+	case *ExistsNode:
+		n.mutex.RLock()
+		defer n.mutex.RUnlock()
+		return map[string]int{"main": memorySize(n.MainMemory), "exists": memorySize(n.ExistsMemory), "results": memorySize(n.ResultMemory)}
+	case *AccumulatorNode:
+		n.mutex.RLock()
+		defer n.mutex.RUnlock()
+		return map[string]int{"main": len(n.MainFacts), "facts": len(n.AllFacts)}
+	case *MultiSourceAccumulatorNode:
+		n.mutex.RLock()
+		defer n.mutex.RUnlock()
+		tokens := 0
+		for _, group := range n.CombinedTokens {
+			tokens += len(group)
+		}
+		return map[string]int{"main": len(n.MainFacts), "tokens": tokens}
+	case *CollectNode:
+		n.mutex.RLock()
+		defer n.mutex.RUnlock()
+		return map[string]int{"main": memorySize(n.MainMemory), "elements": memorySize(n.ElementMemory)}
+	case *RuleRouterNode:
+		n.mutex.RLock()
+		defer n.mutex.RUnlock()
+		return map[string]int{"tokens": memorySize(n.Memory)}
+	case *TerminalNode:
+		// Seul le terminal d'une requête conserve ses tokens
+		if !n.IsQuery() {
+			return nil
+		}
+		return map[string]int{"tokens": memorySize(n.GetMemory())}
+	}
+	return map[string]int{"facts": memorySize(node.GetMemory())}
+}
+
+// memorySize compte les faits et tokens d'une mémoire
+func memorySize(memory *WorkingMemory) int {
+	if memory == nil {
+		return 0
+	}
+	return len(memory.Facts) + len(memory.Tokens)
+}
+
+// sortedSet retourne les éléments d'un ensemble, triés
+func sortedSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// graphNodeStyle est l'apparence d'un genre de nœud dans les exports
+type graphNodeStyle struct {
+	dotShape string // Forme Graphviz
+	color    string // Couleur de fond
+	mermaid  string // Délimiteurs Mermaid ouvrant et fermant, séparés par une espace
+}
+
+// graphStyles associe une apparence à chaque genre de nœud
+var graphStyles = map[string]graphNodeStyle{
+	GraphNodeType:        {dotShape: "box", color: "#dbeafe", mermaid: "[ ]"},
+	GraphNodeAlpha:       {dotShape: "ellipse", color: "#dcfce7", mermaid: "([ ])"},
+	GraphNodePassthrough: {dotShape: "ellipse", color: "#f3f4f6", mermaid: "([ ])"},
+	GraphNodeJoin:        {dotShape: "hexagon", color: "#fef3c7", mermaid: "{{ }}"},
+	GraphNodeExists:      {dotShape: "hexagon", color: "#fde68a", mermaid: "{{ }}"},
+	GraphNodeAccumulator: {dotShape: "parallelogram", color: "#ede9fe", mermaid: "[/ /]"},
+	GraphNodeCollect:     {dotShape: "parallelogram", color: "#fae8ff", mermaid: "[/ /]"},
+	GraphNodeRouter:      {dotShape: "cds", color: "#e0f2fe", mermaid: "> ]"},
+	GraphNodeTerminal:    {dotShape: "doubleoctagon", color: "#fee2e2", mermaid: "[[ ]]"},
+}
+
+// sharedColor est la couleur du contour des nœuds partagés
+const sharedColor = "#d97706"
+
+// WriteJSON écrit le graphe au format JSON indenté
+func (g *NetworkGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT écrit le graphe au format Graphviz DOT (dot -Tsvg). Les nœuds
+// partagés ont un contour épais orangé, les arcs vers un nœud beta indiquent
+// l'entrée alimentée.
+func (g *NetworkGraph) WriteDOT(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph rete {")
+	fmt.Fprintln(out, "  rankdir=TB;")
+	fmt.Fprintln(out, `  node [fontname="Helvetica", fontsize=10, style=filled];`)
+	fmt.Fprintln(out, `  edge [fontname="Helvetica", fontsize=9];`)
+
+	for _, node := range g.Nodes {
+		style := graphStyles[node.Kind]
+		attributes := []string{
+			"label=" + dotQuote(strings.Join(g.nodeLines(node), "\n")),
+			"shape=" + style.dotShape,
+			"fillcolor=" + dotQuote(style.color),
+		}
+		if node.Shared {
+			attributes = append(attributes, "color="+dotQuote(sharedColor), "penwidth=3")
+		}
+		fmt.Fprintf(out, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attributes, ", "))
+	}
+	for _, edge := range g.Edges {
+		if edge.Side != "" {
+			fmt.Fprintf(out, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Side))
+			continue
+		}
+		fmt.Fprintf(out, "  %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+// WriteMermaid écrit le graphe sous forme d'organigramme Mermaid, affichable
+// dans un fichier Markdown. Les nœuds partagés ont la classe "shared".
+func (g *NetworkGraph) WriteMermaid(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "flowchart TD")
+
+	// Les identifiants des nœuds ne sont pas tous des identifiants Mermaid valides
+	ids := make(map[string]string, len(g.Nodes))
+	classes := make(map[string][]string)
+	for i, node := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+		delimiters := strings.SplitN(graphStyles[node.Kind].mermaid, " ", 2)
+		fmt.Fprintf(out, "  %s%s\"%s\"%s\n", id, delimiters[0], mermaidEscape(strings.Join(g.nodeLines(node), "<br/>")), delimiters[1])
+		classes[node.Kind] = append(classes[node.Kind], id)
+		if node.Shared {
+			classes["shared"] = append(classes["shared"], id)
+		}
+	}
+	for _, edge := range g.Edges {
+		if edge.Side != "" {
+			fmt.Fprintf(out, "  %s -- %s --> %s\n", ids[edge.From], edge.Side, ids[edge.To])
+			continue
+		}
+		fmt.Fprintf(out, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+	}
+
+	kinds := make([]string, 0, len(classes))
+	for kind := range classes {
+		if kind != "shared" {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(out, "  classDef %s fill:%s\n", kind, graphStyles[kind].color)
+		fmt.Fprintf(out, "  class %s %s\n", strings.Join(classes[kind], ","), kind)
+	}
+	// La classe shared vient en dernier pour l'emporter sur celle du genre
+	if shared := classes["shared"]; len(shared) > 0 {
+		fmt.Fprintf(out, "  classDef shared stroke:%s,stroke-width:3px\n", sharedColor)
+		fmt.Fprintf(out, "  class %s shared\n", strings.Join(shared, ","))
+	}
+	return out.Flush()
+}
+
+// nodeLines retourne les lignes du libellé d'un nœud : genre, description,
+// règles qui le partagent et taille des mémoires
+func (g *NetworkGraph) nodeLines(node GraphNode) []string {
+	lines := []string{node.Kind}
+	if node.Label != "" {
+		lines = append(lines, node.Label)
+	}
+	if node.Shared {
+		lines = append(lines, "partagé : "+strings.Join(node.Rules, ", "))
+	}
+	if len(node.Memory) > 0 {
+		keys := make([]string, 0, len(node.Memory))
+		for key := range node.Memory {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		sizes := make([]string, 0, len(keys))
+		for _, key := range keys {
+			sizes = append(sizes, fmt.Sprintf("%s: %d", key, node.Memory[key]))
+		}
+		lines = append(lines, strings.Join(sizes, ", "))
+	}
+	return lines
+}
+
+// dotQuote retourne une chaîne DOT entre guillemets
+func dotQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(text) + `"`
+}
+
+// mermaidEscape échappe les caractères réservés d'un libellé Mermaid
+func mermaidEscape(text string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "<br/>", "<br/>", "<", "#lt;", ">", "#gt;")
+	return replacer.Replace(text)
+}
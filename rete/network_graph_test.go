@@ -0,0 +1,224 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const graphProgram = `type Person(#id: string, age: number, city: string)
+type Order(#id: string, pid: string, amount: number)
+action notify(id: string)
+rule adults : {p: Person} / p.age >= 18 ==> notify(p.id)
+rule parisians : {p: Person} / p.age >= 18 AND p.city == "Paris" ==> notify(p.id)
+rule bigOrders : {p: Person, o: Order} / p.id == o.pid AND o.amount > 100 ==> notify(o.id)
+rule bigBuyers : {p: Person, o: Order} / p.id == o.pid AND o.amount > 100 ==> notify(p.id)
+rule buyers : {p: Person} / EXISTS (o: Order / o.pid == p.id) ==> notify(p.id)
+rule spent : {p: Person, s: SUM(o.amount)} / {o: Order} / o.pid == p.id AND s > 10 ==> notify(p.id)
+`
+
+// buildGraphNetwork compile les programmes dans un même réseau
+func buildGraphNetwork(t *testing.T, programs ...string) *ReteNetwork {
+	t.Helper()
+	pipeline := NewConstraintPipeline()
+	pipeline.SetLogger(NewLogger(LogLevelSilent, io.Discard))
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+	if err := network.ActionExecutor.RegisterAction(notifyHandler{calls: new(int)}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	for _, program := range programs {
+		path := filepath.Join(t.TempDir(), "graph.tsd")
+		if err := os.WriteFile(path, []byte(program), 0644); err != nil {
+			t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+		}
+		var err error
+		if network, _, err = pipeline.IngestFile(path, network, storage); err != nil {
+			t.Fatalf("IngestFile() error = %v", err)
+		}
+	}
+	return network
+}
+
+// graphNode retourne le nœud du graphe de genre et libellé donnés
+func graphNode(t *testing.T, graph *NetworkGraph, kind, label string) GraphNode {
+	t.Helper()
+	for _, node := range graph.Nodes {
+		if node.Kind == kind && node.Label == label {
+			return node
+		}
+	}
+	t.Fatalf("nœud %s %q absent du graphe", kind, label)
+	return GraphNode{}
+}
+
+// hasEdge indique si le graphe relie deux nœuds
+func hasEdge(graph *NetworkGraph, from, to, side string) bool {
+	for _, edge := range graph.Edges {
+		if edge.From == from && edge.To == to && edge.Side == side {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGraph_Topology(t *testing.T) {
+	graph, err := buildGraphNetwork(t, graphProgram).Graph()
+	if err != nil {
+		t.Fatalf("Graph() error = %v", err)
+	}
+
+	if strings.Join(graph.Rules, ",") != "adults,bigBuyers,bigOrders,buyers,parisians,spent" {
+		t.Errorf("Rules = %v", graph.Rules)
+	}
+	for kind, want := range map[string]int{
+		GraphNodeType: 2, GraphNodeJoin: 1, GraphNodeExists: 1, GraphNodeAccumulator: 1,
+		GraphNodeRouter: 1, GraphNodeTerminal: 6,
+	} {
+		if got := graph.Stats.Kinds[kind]; got != want {
+			t.Errorf("%d nœud(s) %s, want %d", got, kind, want)
+		}
+	}
+
+	// La condition commune à adults et parisians est partagée
+	age := graphNode(t, graph, GraphNodeAlpha, "p: p.age >= 18")
+	if !age.Shared || strings.Join(age.Rules, ",") != "adults,parisians" {
+		t.Errorf("alpha p.age >= 18 = %+v", age)
+	}
+	person := graphNode(t, graph, GraphNodeType, "Person")
+	if person.Shared || len(person.Rules) != 6 {
+		t.Errorf("type Person = %+v", person)
+	}
+
+	// La jointure identique de bigOrders et bigBuyers est partagée via un routeur
+	join := graphNode(t, graph, GraphNodeJoin, "p.id == o.pid")
+	if !join.Shared || strings.Join(join.Rules, ",") != "bigBuyers,bigOrders" {
+		t.Errorf("join = %+v", join)
+	}
+	router := graphNode(t, graph, GraphNodeRouter, "bigBuyers")
+	terminal := graphNode(t, graph, GraphNodeTerminal, "bigBuyers ⇒ notify")
+	if !hasEdge(graph, join.ID, router.ID, "") || !hasEdge(graph, router.ID, terminal.ID, "") {
+		t.Errorf("routeur non relié: %+v", graph.Edges)
+	}
+	left := graphNode(t, graph, GraphNodePassthrough, "p (left)")
+	if !hasEdge(graph, left.ID, join.ID, "left") {
+		t.Errorf("arc gauche absent: %+v", graph.Edges)
+	}
+
+	exists := graphNode(t, graph, GraphNodeExists, "EXISTS (o: Order) / o.pid == p.id")
+	graphNode(t, graph, GraphNodeAccumulator, "s: SUM(o.amount: Order)")
+	if exists.Memory != nil || graph.Facts != 0 {
+		t.Errorf("mémoires sans faits chargés: %v, %d", exists.Memory, graph.Facts)
+	}
+}
+
+func TestGraph_Memory(t *testing.T) {
+	network := buildGraphNetwork(t, graphProgram, `Person(id: "a", age: 30, city: "Lyon")
+Person(id: "b", age: 12, city: "Paris")
+Order(id: "o1", pid: "a", amount: 200)
+`)
+	graph, err := network.Graph()
+	if err != nil {
+		t.Fatalf("Graph() error = %v", err)
+	}
+
+	if graph.Facts != 3 {
+		t.Errorf("Facts = %d, want 3", graph.Facts)
+	}
+	if got := graphNode(t, graph, GraphNodeType, "Person").Memory; got["facts"] != 2 {
+		t.Errorf("mémoire du type Person = %v", got)
+	}
+	if got := graphNode(t, graph, GraphNodeAlpha, "p: p.age >= 18").Memory; got["facts"] != 1 {
+		t.Errorf("mémoire de l'alpha = %v", got)
+	}
+	if got := graphNode(t, graph, GraphNodeJoin, "p.id == o.pid").Memory; got["left"] != 2 || got["right"] != 1 || got["results"] != 1 {
+		t.Errorf("mémoire de la jointure = %v", got)
+	}
+}
+
+func TestGraph_RuleFilter(t *testing.T) {
+	network := buildGraphNetwork(t, graphProgram)
+	graph, err := network.Graph("parisians")
+	if err != nil {
+		t.Fatalf("Graph() error = %v", err)
+	}
+
+	var kinds []string
+	for _, node := range graph.Nodes {
+		kinds = append(kinds, node.Kind+" "+node.Label)
+	}
+	want := []string{"type Person", "alpha p: (p.city == \"Paris\")", "alpha p: p.age >= 18", "terminal parisians ⇒ notify"}
+	if strings.Join(kinds, "|") != strings.Join(want, "|") {
+		t.Errorf("nœuds = %q, want %q", kinds, want)
+	}
+	if len(graph.Edges) != 3 || strings.Join(graph.Rules, ",") != "parisians" {
+		t.Errorf("Edges = %+v, Rules = %v", graph.Edges, graph.Rules)
+	}
+	// Le partage reste signalé, même avec une règle hors du filtre
+	if age := graphNode(t, graph, GraphNodeAlpha, "p: p.age >= 18"); !age.Shared {
+		t.Errorf("alpha partagé non signalé: %+v", age)
+	}
+
+	if _, err := network.Graph("nope"); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("règle inconnue: err = %v", err)
+	}
+}
+
+func TestGraph_Export(t *testing.T) {
+	network := buildGraphNetwork(t, graphProgram, `Person(id: "a", age: 30, city: "Paris")`)
+	graph, err := network.Graph("bigBuyers")
+	if err != nil {
+		t.Fatalf("Graph() error = %v", err)
+	}
+
+	var dot strings.Builder
+	if err := graph.WriteDOT(&dot); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	for _, want := range []string{
+		"digraph rete {\n",
+		`"type_Person" [label="type\nPerson\nfacts: 1", shape=box, fillcolor="#dbeafe"];`,
+		`[label="join\np.id == o.pid\npartagé : bigBuyers, bigOrders\nleft: 1, results: 0, right: 0", shape=hexagon, fillcolor="#fef3c7", color="#d97706", penwidth=3];`,
+		`-> "router_bigBuyers";`,
+		`[label="left"];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT sans %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid strings.Builder
+	if err := graph.WriteMermaid(&mermaid); err != nil {
+		t.Fatalf("WriteMermaid() error = %v", err)
+	}
+	for _, want := range []string{
+		"flowchart TD\n",
+		`n0["type<br/>Order<br/>facts: 0"]`,
+		`(["alpha<br/>o: temp_1 = o.amount #gt; 100<br/>partagé : bigBuyers, bigOrders<br/>facts: 0"])`,
+		" -- left --> ",
+		"classDef shared stroke:#d97706,stroke-width:3px\n",
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid sans %q:\n%s", want, mermaid.String())
+		}
+	}
+
+	var encoded strings.Builder
+	if err := graph.WriteJSON(&encoded); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded NetworkGraph
+	if err := json.Unmarshal([]byte(encoded.String()), &decoded); err != nil {
+		t.Fatalf("JSON invalide: %v", err)
+	}
+	if len(decoded.Nodes) != len(graph.Nodes) || len(decoded.Edges) != len(graph.Edges) || decoded.Facts != 1 {
+		t.Errorf("JSON = %+v", decoded)
+	}
+}
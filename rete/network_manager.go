@@ -6,33 +6,56 @@ package rete
 
 import (
 	"fmt"
-	"sync"
+	"strings"
 	"time"
-)
-
-// Protection contre les boucles infinies dans les Update en chaîne
-const maxUpdateDepth = 100
 
-var (
-	updateDepthMutex sync.Mutex
-	updateDepthCount int
+	"github.com/treivax/tsd/constraint"
 )
 
 // SubmitFact soumet un nouveau fait au réseau RETE
 // Si une transaction est active, la commande est enregistrée pour rollback
 func (rn *ReteNetwork) SubmitFact(fact *Fact) error {
+	defer rn.beginCascade()()
+	span := rn.startFactSpan(SpanFactSubmit, fact.Type, fact.ID)
+	err := rn.submitFact(fact)
+	rn.endSpan(span, err)
+	if err == nil {
+		rn.activations.produced(fact.GetInternalID())
+	}
+	return err
+}
+
+// submitFact ajoute le fait au storage (ou à la transaction active) et le propage
+func (rn *ReteNetwork) submitFact(fact *Fact) error {
 	rn.logger.Debug("🔥 Soumission fait: %s", fact.String())
 
 	// Debug logging for E2E debugging
 	debugLogger := GetDebugLogger()
 	debugLogger.LogFactSubmission(fact.Type, fact.ID, fact.Fields)
 
+	if err := rn.checkContext(); err != nil {
+		return err
+	}
+
+	// Réserver la place du fait (limites de faits en mémoire)
+	registered, err := rn.admitFact(fact)
+	if err != nil {
+		return err
+	}
+
+	// Simulation et transaction annulable : conserver l'état d'origine du fait (copie sur écriture)
+	rn.currentSimulation().beforeWrite(fact.GetInternalID(), rn.Storage.GetFact(fact.GetInternalID()))
+	rn.GetTransaction().beforeWrite(fact.GetInternalID(), rn.Storage.GetFact(fact.GetInternalID()))
+
 	// Vérifier si une transaction est active
 	tx := rn.GetTransaction()
 	if tx != nil && tx.IsActive {
 		// Mode transactionnel : enregistrer la commande
 		cmd := NewAddFactCommand(rn.Storage, fact)
 		if err := tx.RecordAndExecute(cmd); err != nil {
+			if registered {
+				rn.factLimiter.removed(fact.GetInternalID())
+			}
 			return err
 		}
 		// Propager le fait dans le réseau
@@ -41,6 +64,9 @@ func (rn *ReteNetwork) SubmitFact(fact *Fact) error {
 
 	// Mode normal : exécution directe
 	if err := rn.Storage.AddFact(fact); err != nil {
+		if registered {
+			rn.factLimiter.removed(fact.GetInternalID())
+		}
 		return err
 	}
 	return rn.RootNode.ActivateRight(fact)
@@ -52,10 +78,18 @@ func (rn *ReteNetwork) RemoveFact(factID string) error {
 	tx := rn.GetTransaction()
 	if tx != nil && tx.IsActive {
 		cmd := NewRemoveFactCommand(rn.Storage, factID)
-		return tx.RecordAndExecute(cmd)
+		if err := tx.RecordAndExecute(cmd); err != nil {
+			return err
+		}
+		rn.factLimiter.removed(factID)
+		return nil
 	}
 
-	return rn.Storage.RemoveFact(factID)
+	if err := rn.Storage.RemoveFact(factID); err != nil {
+		return err
+	}
+	rn.factLimiter.removed(factID)
+	return nil
 }
 
 // InsertFact insère dynamiquement un nouveau fait dans le réseau RETE.
@@ -104,10 +138,25 @@ func (rn *ReteNetwork) InsertFact(fact *Fact) error {
 // Retourne:
 //   - error: erreur si le fait est invalide ou n'existe pas
 func (rn *ReteNetwork) UpdateFact(fact *Fact) error {
+	if fact == nil {
+		return fmt.Errorf("fact cannot be nil")
+	}
+	defer rn.beginCascade()()
+	span := rn.startFactSpan(SpanFactUpdate, fact.Type, fact.ID)
+	err := rn.updateFact(fact)
+	rn.endSpan(span, err)
+	return err
+}
+
+// updateFact applique la mise à jour d'un fait existant (delta ou Retract + Insert)
+func (rn *ReteNetwork) updateFact(fact *Fact) error {
 	// Validation du fait
 	if fact == nil {
 		return fmt.Errorf("fact cannot be nil")
 	}
+	if err := rn.checkContext(); err != nil {
+		return err
+	}
 	if fact.Type == "" {
 		return fmt.Errorf("fact type cannot be empty")
 	}
@@ -129,23 +178,11 @@ func (rn *ReteNetwork) UpdateFact(fact *Fact) error {
 		return nil
 	}
 
-	// Protection contre les boucles infinies (règles qui se déclenchent en chaîne)
-	updateDepthMutex.Lock()
-	updateDepthCount++
-	currentDepth := updateDepthCount
-	updateDepthMutex.Unlock()
-
-	defer func() {
-		updateDepthMutex.Lock()
-		updateDepthCount--
-		updateDepthMutex.Unlock()
-	}()
-
-	if currentDepth > maxUpdateDepth {
-		return fmt.Errorf("maximum update depth exceeded (%d) - possible infinite loop in chained rules", maxUpdateDepth)
-	}
-
-	rn.logger.Debug("🔄 Mise à jour du fait: %s (depth: %d)", internalID, currentDepth)
+	// Les boucles de règles qui se déclenchent en chaîne sont bornées par
+	// le garde-fou de cascade (voir cascade.go)
+	rn.logger.Debug("🔄 Mise à jour du fait: %s", internalID)
+	rn.currentSimulation().beforeWrite(internalID, existingFact)
+	rn.GetTransaction().beforeWrite(internalID, existingFact)
 
 	// Tenter la propagation delta si activée
 	if rn.EnableDeltaPropagation && rn.IntegrationHelper != nil {
@@ -173,11 +210,17 @@ func (rn *ReteNetwork) UpdateFact(fact *Fact) error {
 
 	// 1. Rétracter l'ancien fait (propage la suppression)
 	if err := rn.RetractFact(internalID); err != nil {
+		if abortsPropagation(err) {
+			return err
+		}
 		return fmt.Errorf("failed to retract old fact: %w", err)
 	}
 
 	// 2. Insérer le nouveau fait avec les valeurs mises à jour (propage l'ajout)
 	if err := rn.SubmitFact(fact); err != nil {
+		if abortsPropagation(err) {
+			return err
+		}
 		return fmt.Errorf("failed to submit updated fact: %w", err)
 	}
 
@@ -274,6 +317,7 @@ func (rn *ReteNetwork) RepropagateExistingFact(fact *Fact) error {
 	}
 
 	// Propager directement aux enfants du TypeNode sans ajouter à sa mémoire
+	defer rn.beginCascade()()
 	return typeNode.PropagateToChildren(fact, token)
 }
 
@@ -525,10 +569,23 @@ func (rn *ReteNetwork) ClearSubmissionContext() {
 // Retourne:
 //   - error: erreur si l'ID est vide ou si le fait n'existe pas
 func (rn *ReteNetwork) RetractFact(factID string) error {
+	factType, _, _ := strings.Cut(factID, constraint.IDSeparatorType)
+	defer rn.beginCascade()()
+	span := rn.startFactSpan(SpanFactRetract, factType, factID)
+	err := rn.retractFact(factID)
+	rn.endSpan(span, err)
+	return err
+}
+
+// retractFact retire le fait du storage et propage la rétractation
+func (rn *ReteNetwork) retractFact(factID string) error {
 	// Validation de l'ID
 	if factID == "" {
 		return fmt.Errorf("fact ID cannot be empty")
 	}
+	if err := rn.checkContext(); err != nil {
+		return err
+	}
 
 	rn.logger.Info("🗑️ Rétractation du fait: %s", factID)
 
@@ -538,6 +595,9 @@ func (rn *ReteNetwork) RetractFact(factID string) error {
 		return fmt.Errorf("fact with ID '%s' not found", factID)
 	}
 
+	rn.currentSimulation().beforeWrite(factID, existingFact)
+	rn.GetTransaction().beforeWrite(factID, existingFact)
+
 	// Marquer le fait comme rétracté dans le contexte de soumission s'il y en a un actif
 	rn.submissionMutex.RLock()
 	if rn.currentSubmission != nil && rn.currentSubmission.WasSubmitted(factID) {
@@ -566,8 +626,12 @@ func (rn *ReteNetwork) Reset() {
 	rn.AlphaNodes = make(map[string]*AlphaNode)
 	rn.BetaNodes = make(map[string]interface{})
 	rn.TerminalNodes = make(map[string]*TerminalNode)
+	rn.Queries = make(map[string]*Query)
+	rn.Modules = nil
 	rn.Types = make([]TypeDefinition, 0)
 	rn.BetaBuilder = nil
+	rn.definitions.reset()
+	rn.undoPoints = nil
 
 	// Reset lifecycle manager (always initialized)
 	rn.LifecycleManager.Reset()
@@ -695,6 +759,7 @@ func (rn *ReteNetwork) GarbageCollect() {
 	// 7. Nettoyer le Storage
 	if rn.Storage != nil {
 		rn.Storage.Clear()
+		rn.factLimiter.resync(rn.Storage)
 	}
 
 	// 8. Réinitialiser le RootNode
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+// JoinMemorySize décrit l'occupation des mémoires d'un JoinNode
+type JoinMemorySize struct {
+	Left  int
+	Right int
+}
+
+// MemorySnapshot est un instantané de l'occupation mémoire du réseau,
+// destiné à l'observabilité (gauges Prometheus, tableaux de bord).
+type MemorySnapshot struct {
+	FactsByType map[string]int            // Nombre de faits en mémoire de travail par type
+	JoinMemory  map[string]JoinMemorySize // Tokens mémorisés par JoinNode (clé : ID du nœud)
+}
+
+// MemorySnapshot calcule l'occupation mémoire courante du réseau
+func (rn *ReteNetwork) MemorySnapshot() MemorySnapshot {
+	snapshot := MemorySnapshot{
+		FactsByType: make(map[string]int),
+		JoinMemory:  make(map[string]JoinMemorySize),
+	}
+
+	if rn.Storage != nil {
+		for _, fact := range rn.Storage.GetAllFacts() {
+			snapshot.FactsByType[fact.Type]++
+		}
+	}
+
+	// Un JoinNode partagé peut être référencé sous plusieurs clés : indexer par ID de nœud
+	for _, node := range rn.BetaNodes {
+		if joinNode, ok := node.(*JoinNode); ok {
+			snapshot.JoinMemory[joinNode.ID] = joinNode.memorySize()
+		}
+	}
+
+	return snapshot
+}
+
+// memorySize retourne le nombre de tokens mémorisés de chaque côté de la jointure
+func (jn *JoinNode) memorySize() JoinMemorySize {
+	jn.mutex.RLock()
+	defer jn.mutex.RUnlock()
+	return JoinMemorySize{
+		Left:  len(jn.LeftMemory.Tokens),
+		Right: len(jn.RightMemory.Tokens),
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"sync"
+	"testing"
+)
+
+const memorySnapshotProgram = `type Sensor(#id: string, room: string)
+type Reading(#id: string, sensor: string, value: number)
+action hot(room: string)
+
+rule hot_room : {s: Sensor, r: Reading} / r.sensor == s.id AND r.value > 30 ==> hot(s.room)
+rule hot_room_copy : {s: Sensor, r: Reading} / r.sensor == s.id AND r.value > 30 ==> hot(s.room)
+
+Sensor(id: "s1", room: "lab")
+Reading(id: "r1", sensor: "s1", value: 35)
+Reading(id: "r2", sensor: "s1", value: 20)
+`
+
+func TestReteNetwork_MemorySnapshot(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+
+	var mutex sync.Mutex
+	var calls []string
+	handler := &recordingActionHandler{name: "hot", mutex: &mutex, calls: &calls}
+	if err := network.ActionExecutor.RegisterAction(handler); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, memorySnapshotProgram)
+
+	snapshot := network.MemorySnapshot()
+	if snapshot.FactsByType["Sensor"] != 1 || snapshot.FactsByType["Reading"] != 2 {
+		t.Errorf("FactsByType = %v, want Sensor:1 Reading:2", snapshot.FactsByType)
+	}
+	if len(snapshot.JoinMemory) == 0 {
+		t.Fatal("JoinMemory is empty, want at least one join node")
+	}
+
+	// Le JoinNode partagé par les deux règles n'est compté qu'une fois
+	seen := make(map[*JoinNode]bool)
+	for _, node := range network.BetaNodes {
+		if joinNode, ok := node.(*JoinNode); ok {
+			seen[joinNode] = true
+		}
+	}
+	if len(snapshot.JoinMemory) != len(seen) {
+		t.Errorf("len(JoinMemory) = %d, want %d distinct join nodes", len(snapshot.JoinMemory), len(seen))
+	}
+	for id, size := range snapshot.JoinMemory {
+		if size.Left == 0 && size.Right == 0 {
+			t.Errorf("JoinMemory[%s] is empty", id)
+		}
+	}
+}
+
+func TestCompositeObserver(t *testing.T) {
+	first, second := NewTestActionObserver(t), NewTestActionObserver(t)
+	composite := NewCompositeObserver(first, nil, second)
+
+	composite.OnActionExecuted(ExecutionResult{Success: true, Context: ActionContext{RuleName: "r1"}})
+
+	if first.GetExecutionCount() != 1 || second.GetExecutionCount() != 1 {
+		t.Errorf("executions = (%d, %d), want (1, 1)", first.GetExecutionCount(), second.GetExecutionCount())
+	}
+}
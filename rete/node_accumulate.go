@@ -6,13 +6,12 @@ package rete
 
 import (
 	"fmt"
-	"math"
 	"sync"
 )
 
 type AccumulatorNode struct {
 	BaseNode
-	AggregateFunc string                 `json:"aggregate_func"` // "AVG", "SUM", "COUNT", "MIN", "MAX"
+	AggregateFunc string                 `json:"aggregate_func"` // "AVG", "SUM", "COUNT", "MEDIAN", "FIRST", ... (voir NewAggregateState)
 	MainVariable  string                 `json:"main_variable"`  // Variable principale (ex: "e")
 	MainType      string                 `json:"main_type"`      // Type principal (ex: "Employee")
 	AggVariable   string                 `json:"agg_variable"`   // Variable à agréger (ex: "p")
@@ -21,8 +20,12 @@ type AccumulatorNode struct {
 	JoinField     string                 `json:"join_field"`     // Champ de jointure (ex: "employee_id")
 	MainField     string                 `json:"main_field"`     // Champ principal pour jointure (ex: "id")
 	Condition     map[string]interface{} `json:"condition"`      // Condition de comparaison du résultat
-	MainFacts     map[string]*Fact       `json:"-"`              // Faits principaux indexés par ID
-	AllFacts      map[string]*Fact       `json:"-"`              // Tous les faits (principaux + agrégés) par ID
+	ResultVar     string                 `json:"result_var"`     // Variable liée au résultat (ex: "avg_sal"), vide en syntaxe legacy
+	Spec          AggregateSpec          `json:"spec"`           // Paramètres de la fonction (champ d'ordre, percentile, séparateur)
+	MainFacts     map[string]*Fact       `json:"-"`              // Faits principaux indexés par ID interne
+	AllFacts      map[string]*Fact       `json:"-"`              // Tous les faits (principaux + agrégés) par ID interne
+	states        map[string]AggregateState
+	emitted       map[string]bool
 	mutex         sync.RWMutex
 }
 
@@ -46,6 +49,8 @@ func NewAccumulatorNode(id string, mainVar, mainType, aggVar, aggType, field, jo
 		Condition:     condition,
 		MainFacts:     make(map[string]*Fact),
 		AllFacts:      make(map[string]*Fact),
+		states:        make(map[string]AggregateState),
+		emitted:       make(map[string]bool),
 	}
 }
 
@@ -54,23 +59,35 @@ func (an *AccumulatorNode) Activate(fact *Fact, token *Token) error {
 	an.mutex.Lock()
 	defer an.mutex.Unlock()
 
+	factID := fact.GetInternalID()
+
 	// Stocker tous les faits
-	an.AllFacts[fact.ID] = fact
+	an.AllFacts[factID] = fact
 
-	// Si c'est un fait principal, stocker et calculer l'agrégation
+	// Si c'est un fait principal, initialiser son état à partir des faits déjà reçus
 	if fact.Type == an.MainType {
-		an.MainFacts[fact.ID] = fact
+		an.MainFacts[factID] = fact
 		fmt.Printf("📊 ACCUMULATOR[%s]: Fait principal reçu %s\n", an.ID, fact.ID)
 
-		// Calculer l'agrégation pour ce fait principal
+		state, err := NewAggregateState(an.aggregateSpec())
+		if err != nil {
+			return fmt.Errorf("erreur calcul agrégation: %w", err)
+		}
+		for _, aggFact := range an.collectAggregatedFacts(fact) {
+			state.Add(aggFact)
+		}
+		an.states[factID] = state
 		return an.processMainFact(fact)
 	}
 
-	// Si c'est un fait à agréger, recalculer pour tous les faits principaux
+	// Si c'est un fait à agréger, mettre à jour l'état des faits principaux correspondants
 	if fact.Type == an.AggType {
 		fmt.Printf("📊 ACCUMULATOR[%s]: Fait agrégé reçu %s\n", an.ID, fact.ID)
-		// Recalculer pour tous les faits principaux existants
-		for _, mainFact := range an.MainFacts {
+		for mainID, mainFact := range an.MainFacts {
+			if !an.joins(mainFact, fact) {
+				continue
+			}
+			an.states[mainID].Add(fact)
 			if err := an.processMainFact(mainFact); err != nil {
 				fmt.Printf("⚠️  ACCUMULATOR[%s]: Erreur recalcul pour %s: %v\n", an.ID, mainFact.ID, err)
 			}
@@ -80,20 +97,29 @@ func (an *AccumulatorNode) Activate(fact *Fact, token *Token) error {
 	return nil
 }
 
-// processMainFact calcule l'agrégation pour un fait principal donné
-func (an *AccumulatorNode) processMainFact(mainFact *Fact) error {
-	// Collecter les faits à agréger qui correspondent à ce fait principal
-	aggregatedFacts := an.collectAggregatedFacts(mainFact)
-
-	fmt.Printf("📊 ACCUMULATOR[%s]: %d faits agrégés trouvés pour %s\n", an.ID, len(aggregatedFacts), mainFact.ID)
+// aggregateSpec retourne la description de la fonction d'agrégation du nœud
+func (an *AccumulatorNode) aggregateSpec() AggregateSpec {
+	spec := an.Spec
+	spec.Function = an.AggregateFunc
+	spec.Field = an.Field
+	return spec
+}
 
-	// Calculer l'agrégation
-	aggregatedValue, err := an.calculateAggregateForFacts(aggregatedFacts)
-	if err != nil {
-		return fmt.Errorf("erreur calcul agrégation: %w", err)
+// processMainFact évalue la condition sur la valeur agrégée d'un fait principal.
+//
+// Si la condition est satisfaite, le token du fait principal est (re)propagé avec
+// le résultat ; si elle ne l'est plus, l'activation précédente est rétractée.
+func (an *AccumulatorNode) processMainFact(mainFact *Fact) error {
+	mainID := mainFact.GetInternalID()
+	state := an.states[mainID]
+	if state == nil {
+		return nil
 	}
 
-	fmt.Printf("📊 ACCUMULATOR[%s]: Valeur agrégée = %.2f pour %s\n", an.ID, aggregatedValue, mainFact.ID)
+	fmt.Printf("📊 ACCUMULATOR[%s]: %d faits agrégés trouvés pour %s\n", an.ID, state.Len(), mainFact.ID)
+
+	aggregatedValue := state.Result()
+	fmt.Printf("📊 ACCUMULATOR[%s]: Valeur agrégée = %v pour %s\n", an.ID, aggregatedValue, mainFact.ID)
 
 	// Évaluer la condition
 	satisfied, err := an.evaluateCondition(aggregatedValue)
@@ -102,7 +128,7 @@ func (an *AccumulatorNode) processMainFact(mainFact *Fact) error {
 	}
 
 	if satisfied {
-		fmt.Printf("✅ ACCUMULATOR[%s]: Condition satisfaite (%.2f) pour %s\n", an.ID, aggregatedValue, mainFact.ID)
+		fmt.Printf("✅ ACCUMULATOR[%s]: Condition satisfaite (%v) pour %s\n", an.ID, aggregatedValue, mainFact.ID)
 
 		// Créer un token avec le fait et le résultat de l'agrégation
 		newToken := &Token{
@@ -110,143 +136,57 @@ func (an *AccumulatorNode) processMainFact(mainFact *Fact) error {
 			Facts:    []*Fact{mainFact},
 			Bindings: NewBindingChainWith(an.MainVariable, mainFact),
 		}
+		if an.ResultVar != "" {
+			newToken.Aggregates = map[string]interface{}{an.ResultVar: aggregatedValue}
+		}
 		an.Memory.AddToken(newToken)
+		an.emitted[mainID] = true
 
 		// Propager aux enfants - ne passer que le token, pas le fait
 		// car TerminalNode ne veut que des tokens
 		return an.PropagateToChildren(nil, newToken)
-	} else {
-		fmt.Printf("❌ ACCUMULATOR[%s]: Condition NON satisfaite (%.2f) pour %s\n", an.ID, aggregatedValue, mainFact.ID)
 	}
 
+	fmt.Printf("❌ ACCUMULATOR[%s]: Condition NON satisfaite (%v) pour %s\n", an.ID, aggregatedValue, mainFact.ID)
+	if an.emitted[mainID] {
+		delete(an.emitted, mainID)
+		an.Memory.RemoveToken(fmt.Sprintf("accum_%s", mainFact.ID))
+		return an.PropagateRetractToChildren(mainID)
+	}
 	return nil
 }
 
-// collectAggregatedFacts collecte les faits à agréger pour un fait principal
-func (an *AccumulatorNode) collectAggregatedFacts(mainFact *Fact) []*Fact {
-	collected := make([]*Fact, 0)
-
-	// Obtenir la valeur du champ de jointure du fait principal
+// joins indique si un fait à agréger correspond au fait principal
+func (an *AccumulatorNode) joins(mainFact *Fact, fact *Fact) bool {
 	mainValue, exists := mainFact.Fields[an.MainField]
 	if !exists {
 		// Essayer aussi dans fact.ID si c'est le champ "id"
-		if an.MainField == "id" {
-			mainValue = mainFact.ID
-		} else {
-			fmt.Printf("⚠️  ACCUMULATOR[%s]: Champ principal %s non trouvé dans %s\n", an.ID, an.MainField, mainFact.ID)
-			return collected
-		}
-	}
-
-	// Parcourir tous les faits pour trouver ceux qui correspondent
-	for _, fact := range an.AllFacts {
-		if fact.Type == an.AggType {
-			// Vérifier la condition de jointure
-			joinValue, exists := fact.Fields[an.JoinField]
-			if exists && joinValue == mainValue {
-				collected = append(collected, fact)
-			}
+		if an.MainField != "id" {
+			return false
 		}
+		mainValue = mainFact.ID
 	}
-
-	return collected
+	joinValue, exists := fact.Fields[an.JoinField]
+	return exists && joinValue == mainValue
 }
 
-// calculateAggregateForFacts calcule la valeur agrégée pour une liste de faits
-func (an *AccumulatorNode) calculateAggregateForFacts(facts []*Fact) (float64, error) {
-	if len(facts) == 0 {
-		// Pas de faits à agréger - retourner 0
-		return 0, nil
-	}
+// collectAggregatedFacts collecte les faits à agréger pour un fait principal
+func (an *AccumulatorNode) collectAggregatedFacts(mainFact *Fact) []*Fact {
+	collected := make([]*Fact, 0)
 
-	switch an.AggregateFunc {
-	case "COUNT":
-		return float64(len(facts)), nil
+	if _, exists := mainFact.Fields[an.MainField]; !exists && an.MainField != "id" {
+		fmt.Printf("⚠️  ACCUMULATOR[%s]: Champ principal %s non trouvé dans %s\n", an.ID, an.MainField, mainFact.ID)
+		return collected
+	}
 
-	case "SUM":
-		sum := 0.0
-		for _, f := range facts {
-			if val, ok := f.Fields[an.Field]; ok {
-				numVal := an.toFloat64(val)
-				sum += numVal
-			}
-		}
-		return sum, nil
-
-	case "AVG":
-		sum := 0.0
-		count := 0
-		for _, f := range facts {
-			if val, ok := f.Fields[an.Field]; ok {
-				numVal := an.toFloat64(val)
-				if numVal != 0 || val == 0 || val == 0.0 {
-					sum += numVal
-					count++
-				}
-			}
-		}
-		if count == 0 {
-			return 0, nil
-		}
-		return sum / float64(count), nil
-
-	case "MIN":
-		minVal := math.MaxFloat64
-		for _, f := range facts {
-			if val, ok := f.Fields[an.Field]; ok {
-				numVal := an.toFloat64(val)
-				if numVal < minVal {
-					minVal = numVal
-				}
-			}
-		}
-		if minVal == math.MaxFloat64 {
-			return 0, nil
-		}
-		return minVal, nil
-
-	case "MAX":
-		maxVal := -math.MaxFloat64
-		for _, f := range facts {
-			if val, ok := f.Fields[an.Field]; ok {
-				numVal := an.toFloat64(val)
-				if numVal > maxVal {
-					maxVal = numVal
-				}
-			}
-		}
-		if maxVal == -math.MaxFloat64 {
-			return 0, nil
+	// Parcourir tous les faits pour trouver ceux qui correspondent
+	for _, fact := range an.AllFacts {
+		if fact.Type == an.AggType && an.joins(mainFact, fact) {
+			collected = append(collected, fact)
 		}
-		return maxVal, nil
-
-	default:
-		return 0, fmt.Errorf("fonction d'agrégation non supportée: %s", an.AggregateFunc)
 	}
-}
 
-// toFloat64 converts various numeric types to float64
-func (an *AccumulatorNode) toFloat64(val interface{}) float64 {
-	switch v := val.(type) {
-	case float64:
-		return v
-	case float32:
-		return float64(v)
-	case int:
-		return float64(v)
-	case int32:
-		return float64(v)
-	case int64:
-		return float64(v)
-	case uint:
-		return float64(v)
-	case uint32:
-		return float64(v)
-	case uint64:
-		return float64(v)
-	default:
-		return 0
-	}
+	return collected
 }
 
 // ActivateLeft traite un token venant de la gauche (compatible avec interface Node)
@@ -264,11 +204,20 @@ func (an *AccumulatorNode) ActivateRight(fact *Fact) error {
 }
 
 // evaluateCondition évalue si la valeur agrégée satisfait la condition
-func (an *AccumulatorNode) evaluateCondition(aggregatedValue float64) (bool, error) {
+func (an *AccumulatorNode) evaluateCondition(result interface{}) (bool, error) {
 	if an.Condition == nil {
 		return true, nil
 	}
 
+	// Un résultat non numérique (FIRST, STRING_AGG, ...) ne se compare pas à un seuil :
+	// seul le seuil par défaut (aucune contrainte sur le résultat) est satisfait
+	aggregatedValue, isNumber := toNumber(result)
+	if !isNumber {
+		operator, _ := an.Condition["operator"].(string)
+		threshold, _ := an.Condition["value"].(float64)
+		return operator == DefaultThresholdOperator && threshold == DefaultThresholdValue, nil
+	}
+
 	condType, ok := an.Condition["type"].(string)
 	if !ok || condType != "comparison" {
 		return false, fmt.Errorf("type de condition invalide")
@@ -302,18 +251,36 @@ func (an *AccumulatorNode) evaluateCondition(aggregatedValue float64) (bool, err
 	}
 }
 
-// ActivateRetract gère la rétractation dans le nœud d'agrégation
+// ActivateRetract gère la rétractation dans le nœud d'agrégation.
+// factID doit être l'identifiant interne (Type_ID).
 func (an *AccumulatorNode) ActivateRetract(factID string) error {
 	an.mutex.Lock()
 	defer an.mutex.Unlock()
 
-	// Retirer des faits principaux et de tous les faits
-	delete(an.MainFacts, factID)
+	fact, known := an.AllFacts[factID]
 	delete(an.AllFacts, factID)
 
-	// Retirer des tokens
-	an.Memory.RemoveToken(factID)
+	if mainFact, isMain := an.MainFacts[factID]; isMain {
+		delete(an.MainFacts, factID)
+		delete(an.states, factID)
+		delete(an.emitted, factID)
+		an.Memory.RemoveToken(fmt.Sprintf("accum_%s", mainFact.ID))
+		fmt.Printf("🗑️  [ACCUMULATOR_%s] Rétractation: fait %s retiré\n", an.ID, factID)
+		return an.PropagateRetractToChildren(factID)
+	}
 
-	fmt.Printf("🗑️  [ACCUMULATOR_%s] Rétractation: fait %s retiré\n", an.ID, factID)
-	return an.PropagateRetractToChildren(factID)
+	// Un fait agrégé retiré ne met à jour que l'état des faits principaux correspondants
+	if known && fact.Type == an.AggType {
+		fmt.Printf("🗑️  [ACCUMULATOR_%s] Rétractation: fait agrégé %s retiré\n", an.ID, factID)
+		for mainID, mainFact := range an.MainFacts {
+			if !an.joins(mainFact, fact) {
+				continue
+			}
+			an.states[mainID].Remove(fact)
+			if err := an.processMainFact(mainFact); err != nil {
+				fmt.Printf("⚠️  ACCUMULATOR[%s]: Erreur recalcul pour %s: %v\n", an.ID, mainFact.ID, err)
+			}
+		}
+	}
+	return nil
 }
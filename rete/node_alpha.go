@@ -195,7 +195,10 @@ func (an *AlphaNode) propagateFactToChildren(fact *Fact) error {
 
 		if childType == "alpha" {
 			// Propager le fait directement aux AlphaNodes enfants (chaîne)
-			if err := child.ActivateRight(fact); err != nil {
+			if err := activateRight(child, fact); err != nil {
+				if abortsPropagation(err) {
+					return err
+				}
 				return fmt.Errorf("erreur propagation fait vers %s: %w", child.GetID(), err)
 			}
 		} else {
@@ -206,7 +209,10 @@ func (an *AlphaNode) propagateFactToChildren(fact *Fact) error {
 				NodeID:   an.ID,
 				Bindings: NewBindingChainWith(an.VariableName, fact),
 			}
-			if err := child.ActivateLeft(token); err != nil {
+			if err := activateLeft(child, token); err != nil {
+				if abortsPropagation(err) {
+					return err
+				}
 				return fmt.Errorf("erreur propagation token vers %s: %w", child.GetID(), err)
 			}
 		}
@@ -268,6 +274,12 @@ func (an *AlphaNode) ActivateWithContext(fact *Fact, context *EvaluationContext)
 func isComparisonCondition(condition interface{}) bool {
 	if condMap, ok := condition.(map[string]interface{}); ok {
 		if condType, exists := condMap["type"].(string); exists {
+			if condType == "constraint" {
+				// Condition enveloppée par le parser : examiner la condition interne
+				if inner, hasInner := condMap["constraint"]; hasInner {
+					return isComparisonCondition(inner)
+				}
+			}
 			return condType == "comparison"
 		}
 	}
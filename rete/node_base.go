@@ -82,12 +82,18 @@ func (bn *BaseNode) SetChildren(children []Node) {
 func (bn *BaseNode) PropagateToChildren(fact *Fact, token *Token) error {
 	for _, child := range bn.GetChildren() {
 		if fact != nil {
-			if err := child.ActivateRight(fact); err != nil {
+			if err := activateRight(child, fact); err != nil {
+				if abortsPropagation(err) {
+					return err
+				}
 				return fmt.Errorf("erreur propagation fait vers %s: %w", child.GetID(), err)
 			}
 		}
 		if token != nil {
-			if err := child.ActivateLeft(token); err != nil {
+			if err := activateLeft(child, token); err != nil {
+				if abortsPropagation(err) {
+					return err
+				}
 				return fmt.Errorf("erreur propagation token vers %s: %w", child.GetID(), err)
 			}
 		}
@@ -99,6 +105,9 @@ func (bn *BaseNode) PropagateToChildren(fact *Fact, token *Token) error {
 func (bn *BaseNode) PropagateRetractToChildren(factID string) error {
 	for _, child := range bn.GetChildren() {
 		if err := child.ActivateRetract(factID); err != nil {
+			if abortsPropagation(err) {
+				return err
+			}
 			return fmt.Errorf("erreur propagation rétractation vers %s: %w", child.GetID(), err)
 		}
 	}
@@ -0,0 +1,256 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CollectNode lie à chaque token principal la liste des faits qui vérifient une condition.
+//
+// Il implémente COLLECT(x: T / condition) : l'entrée gauche reçoit les tokens
+// principaux, l'entrée droite les faits candidats de type T. Chaque token principal
+// est propagé avec la liste courante dans Token.Collections[CollectVariable], y
+// compris lorsqu'elle est vide. Quand un fait inséré ou rétracté modifie la liste
+// d'un token, l'activation précédente est rétractée puis le token est propagé à
+// nouveau avec la liste à jour.
+//
+// Filter (optionnel) contient les contraintes de la règle, évaluées après la collecte
+// avec la liste liée : un token qui ne les vérifie pas n'est pas propagé.
+type CollectNode struct {
+	BaseNode
+	MainVariable    string      `json:"main_variable"`    // Variable principale (o)
+	CollectVariable string      `json:"collect_variable"` // Variable liée à la liste (lines)
+	ElementVariable string      `json:"element_variable"` // Variable d'élément (l)
+	ElementType     string      `json:"element_type"`     // Type des éléments collectés
+	Condition       interface{} `json:"condition"`        // Condition sur (token principal, élément)
+	Filter          interface{} `json:"filter,omitempty"` // Contraintes évaluées après collecte
+	mutex           sync.RWMutex
+	// Mémoires pour architecture RETE
+	MainMemory    *WorkingMemory // Tokens principaux
+	ElementMemory *WorkingMemory // Faits candidats à la collecte
+	emitted       map[string]*Token
+}
+
+// NewCollectNode crée un nouveau nœud de collecte
+func NewCollectNode(nodeID, mainVar, collectVar, elementVar, elementType string, condition interface{}, storage Storage) *CollectNode {
+	return &CollectNode{
+		BaseNode: BaseNode{
+			ID:       nodeID,
+			Type:     "collect",
+			Memory:   &WorkingMemory{NodeID: nodeID, Facts: make(map[string]*Fact), Tokens: make(map[string]*Token)},
+			Children: make([]Node, 0),
+			Storage:  storage,
+		},
+		MainVariable:    mainVar,
+		CollectVariable: collectVar,
+		ElementVariable: elementVar,
+		ElementType:     elementType,
+		Condition:       condition,
+		MainMemory:      &WorkingMemory{NodeID: nodeID + "_main", Facts: make(map[string]*Fact), Tokens: make(map[string]*Token)},
+		ElementMemory:   &WorkingMemory{NodeID: nodeID + "_elements", Facts: make(map[string]*Fact), Tokens: make(map[string]*Token)},
+		emitted:         make(map[string]*Token),
+	}
+}
+
+// ActivateLeft enregistre un token principal et le propage avec sa liste
+func (cn *CollectNode) ActivateLeft(token *Token) error {
+	cn.mutex.Lock()
+	cn.MainMemory.AddToken(token)
+	cn.mutex.Unlock()
+
+	return cn.emit(token)
+}
+
+// ActivateRight ajoute un fait candidat et met à jour les listes qu'il modifie
+func (cn *CollectNode) ActivateRight(fact *Fact) error {
+	cn.mutex.Lock()
+	if err := cn.ElementMemory.AddFact(fact); err != nil {
+		cn.mutex.Unlock()
+		return fmt.Errorf("erreur ajout fait dans collect node: %w", err)
+	}
+	cn.mutex.Unlock()
+
+	for _, mainToken := range cn.MainMemory.GetTokens() {
+		if cn.matches(mainToken, fact) {
+			if err := cn.refresh(mainToken); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ActivateRetract retire un token principal ou un élément collecté
+// factID doit être l'identifiant interne (Type_ID)
+func (cn *CollectNode) ActivateRetract(factID string) error {
+	cn.mutex.Lock()
+	var mainTokensToRemove []string
+	for tokenID, token := range cn.MainMemory.Tokens {
+		if tokenContainsFact(token, factID) {
+			mainTokensToRemove = append(mainTokensToRemove, tokenID)
+		}
+	}
+	for _, tokenID := range mainTokensToRemove {
+		delete(cn.MainMemory.Tokens, tokenID)
+		if emitted, ok := cn.emitted[tokenID]; ok {
+			delete(cn.Memory.Tokens, emitted.ID)
+			delete(cn.emitted, tokenID)
+		}
+	}
+
+	_, isElement := cn.ElementMemory.GetFact(factID)
+	var affected []*Token
+	if isElement {
+		cn.ElementMemory.RemoveFact(factID)
+		for tokenID, emitted := range cn.emitted {
+			if collectionContains(emitted.Collections[cn.CollectVariable], factID) {
+				affected = append(affected, cn.MainMemory.Tokens[tokenID])
+			}
+		}
+	}
+	cn.mutex.Unlock()
+
+	if err := cn.PropagateRetractToChildren(factID); err != nil {
+		return err
+	}
+	for _, mainToken := range affected {
+		if mainToken == nil {
+			continue
+		}
+		if err := cn.refresh(mainToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refresh rétracte l'activation courante d'un token principal puis le propage à nouveau
+func (cn *CollectNode) refresh(mainToken *Token) error {
+	cn.mutex.Lock()
+	previous, wasEmitted := cn.emitted[mainToken.ID]
+	if wasEmitted {
+		delete(cn.emitted, mainToken.ID)
+		delete(cn.Memory.Tokens, previous.ID)
+	}
+	cn.mutex.Unlock()
+
+	if wasEmitted {
+		if mainFact := cn.mainFact(mainToken); mainFact != nil {
+			if err := cn.PropagateRetractToChildren(mainFact.GetInternalID()); err != nil {
+				return err
+			}
+		}
+	}
+	return cn.emit(mainToken)
+}
+
+// emit construit la liste d'un token principal et propage le token résultant
+func (cn *CollectNode) emit(mainToken *Token) error {
+	var collected []*Fact
+	for _, fact := range cn.ElementMemory.GetFacts() {
+		if cn.matches(mainToken, fact) {
+			collected = append(collected, fact)
+		}
+	}
+	sort.Slice(collected, func(i, j int) bool {
+		return collected[i].GetInternalID() < collected[j].GetInternalID()
+	})
+
+	collections := make(map[string][]*Fact, len(mainToken.Collections)+1)
+	for name, facts := range mainToken.Collections {
+		collections[name] = facts
+	}
+	collections[cn.CollectVariable] = collected
+
+	token := &Token{
+		ID:           mainToken.ID + "_" + cn.CollectVariable,
+		Facts:        mainToken.Facts,
+		NodeID:       cn.ID,
+		Bindings:     mainToken.Bindings,
+		IsJoinResult: true,
+		Collections:  collections,
+	}
+
+	if !cn.passesFilter(token) {
+		return nil
+	}
+
+	cn.mutex.Lock()
+	cn.emitted[mainToken.ID] = token
+	cn.Memory.AddToken(token)
+	cn.mutex.Unlock()
+
+	return cn.PropagateToChildren(nil, token)
+}
+
+// matches indique si un fait candidat appartient à la liste d'un token principal
+func (cn *CollectNode) matches(mainToken *Token, fact *Fact) bool {
+	if cn.Condition == nil {
+		return true
+	}
+
+	evaluator := newTokenEvaluator(mainToken)
+	evaluator.variableBindings[cn.ElementVariable] = fact
+	result, err := evaluator.evaluateExpression(cn.Condition)
+	if err != nil {
+		return false
+	}
+	return result
+}
+
+// passesFilter évalue les contraintes de la règle sur le token complété
+func (cn *CollectNode) passesFilter(token *Token) bool {
+	if cn.Filter == nil {
+		return true
+	}
+
+	result, err := newTokenEvaluator(token).evaluateExpression(cn.Filter)
+	if err != nil {
+		return false
+	}
+	return result
+}
+
+// mainFact retourne le fait lié à la variable principale d'un token
+func (cn *CollectNode) mainFact(token *Token) *Fact {
+	if token.Bindings != nil {
+		if fact := token.Bindings.Get(cn.MainVariable); fact != nil {
+			return fact
+		}
+	}
+	if len(token.Facts) == 0 {
+		return nil
+	}
+	return token.Facts[0]
+}
+
+// newTokenEvaluator crée un évaluateur lié aux faits et aux listes d'un token
+func newTokenEvaluator(token *Token) *AlphaConditionEvaluator {
+	evaluator := NewAlphaConditionEvaluator()
+	if token.Bindings != nil {
+		for variable, fact := range token.Bindings.ToMap() {
+			if fact != nil {
+				evaluator.variableBindings[variable] = fact
+			}
+		}
+	}
+	for name, facts := range token.Collections {
+		evaluator.collectionBindings[name] = facts
+	}
+	return evaluator
+}
+
+// collectionContains indique si une liste contient le fait (identifiant interne Type_ID)
+func collectionContains(facts []*Fact, factID string) bool {
+	for _, fact := range facts {
+		if fact.GetInternalID() == factID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"testing"
+)
+
+const quantifierTestTypes = `type Order(#id: string, status: string)
+type OrderLine(#id: string, order: string, shipped: bool, amount: number)
+action notify(order: string, count: number)
+`
+
+// activeTokens retourne les tokens courants d'une requête
+func activeTokens(t *testing.T, network *ReteNetwork, queryName string) []*Token {
+	t.Helper()
+	query, exists := network.GetQuery(queryName)
+	if !exists {
+		t.Fatalf("requête %s introuvable", queryName)
+	}
+	return query.terminal.snapshotQueryTokens()
+}
+
+// submitTestFact soumet un fait au réseau
+func submitTestFact(t *testing.T, network *ReteNetwork, fact *Fact) {
+	t.Helper()
+	if err := network.SubmitFact(fact); err != nil {
+		t.Fatalf("SubmitFact(%s) error = %v", fact.ID, err)
+	}
+}
+
+func TestForall_InsertAndRetract(t *testing.T) {
+	network := ingestQueryProgram(t, nil, NewMemoryStorage(), quantifierTestTypes+`
+query allShipped() : {o: Order} / o.status == "open" AND FORALL(l: OrderLine / l.order == o.id ==> l.shipped == true)
+Order(id: "o1", status: "open")
+Order(id: "o2", status: "closed")
+OrderLine(id: "l1", order: "o1", shipped: true, amount: 10)
+`)
+
+	// o2 est filtré par la contrainte sur la variable principale
+	if got := len(activeTokens(t, network, "allShipped")); got != 1 {
+		t.Fatalf("activations = %d, want 1", got)
+	}
+
+	// Une ligne non expédiée invalide la commande
+	submitTestFact(t, network, &Fact{ID: "OrderLine~l2", Type: "OrderLine",
+		Fields: map[string]interface{}{"id": "l2", "order": "o1", "shipped": false, "amount": 5.0}})
+	if got := len(activeTokens(t, network, "allShipped")); got != 0 {
+		t.Fatalf("activations after counter-example = %d, want 0", got)
+	}
+
+	// Une ligne d'une autre commande est ignorée
+	submitTestFact(t, network, &Fact{ID: "OrderLine~l3", Type: "OrderLine",
+		Fields: map[string]interface{}{"id": "l3", "order": "o9", "shipped": false, "amount": 1.0}})
+
+	// Le retrait du dernier contre-exemple revalide la commande
+	if err := network.RetractFact("OrderLine~l2"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	if got := len(activeTokens(t, network, "allShipped")); got != 1 {
+		t.Fatalf("activations after retraction = %d, want 1", got)
+	}
+}
+
+func TestCollect_IncrementalList(t *testing.T) {
+	network := ingestQueryProgram(t, nil, NewMemoryStorage(), quantifierTestTypes+`
+query lines() : {o: Order, lines: COLLECT(l: OrderLine / l.order == o.id)} / LENGTH(lines) > 0
+rule notifyLines : {o: Order, lines: COLLECT(l: OrderLine / l.order == o.id)} / ==> notify(o.id, LENGTH(lines))
+Order(id: "o1", status: "open")
+Order(id: "o2", status: "open")
+OrderLine(id: "l1", order: "o1", shipped: true, amount: 10)
+OrderLine(id: "l2", order: "o1", shipped: false, amount: 5)
+`)
+
+	collectionSize := func() int {
+		t.Helper()
+		tokens := activeTokens(t, network, "lines")
+		if len(tokens) != 1 {
+			t.Fatalf("activations = %d, want 1", len(tokens))
+		}
+		for _, token := range tokens {
+			return len(token.Collections["lines"])
+		}
+		return 0
+	}
+
+	// o2 n'a aucune ligne : la contrainte LENGTH(lines) > 0 l'exclut
+	if got := collectionSize(); got != 2 {
+		t.Errorf("LENGTH(lines) = %d, want 2", got)
+	}
+
+	submitTestFact(t, network, &Fact{ID: "OrderLine~l3", Type: "OrderLine",
+		Fields: map[string]interface{}{"id": "l3", "order": "o1", "shipped": true, "amount": 1.0}})
+	if got := collectionSize(); got != 3 {
+		t.Errorf("LENGTH(lines) after insert = %d, want 3", got)
+	}
+
+	// La règle est redéclenchée à chaque mise à jour de la liste :
+	// o1 et o2 (listes vides), puis o1 après l'ajout de l1, l2 et l3
+	terminal := network.TerminalNodes["notifyLines_terminal"]
+	if count := terminal.GetExecutionCount(); count != 5 {
+		t.Errorf("executions = %d, want 5", count)
+	}
+	if result := terminal.GetLastExecutionResult(); result == nil || !result.Success {
+		t.Errorf("last execution = %+v", result)
+	}
+
+	if err := network.RetractFact("OrderLine~l1"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	if got := collectionSize(); got != 2 {
+		t.Errorf("LENGTH(lines) after retract = %d, want 2", got)
+	}
+
+	// Le retrait de la commande retire son activation
+	if err := network.RetractFact("Order~o1"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	if got := len(activeTokens(t, network, "lines")); got != 0 {
+		t.Errorf("activations after order retraction = %d, want 0", got)
+	}
+}
+
+func TestCollectNode_ActionArguments(t *testing.T) {
+	order := &Fact{ID: "o1", Type: "Order", Fields: map[string]interface{}{"status": "open"}}
+	line := &Fact{ID: "l1", Type: "OrderLine", Fields: map[string]interface{}{"order": "o1"}}
+	token := &Token{
+		ID:          "t1",
+		Facts:       []*Fact{order},
+		Bindings:    NewBindingChainWith("o", order),
+		Collections: map[string][]*Fact{"lines": {line}},
+	}
+
+	executor := NewActionExecutor(nil, nil)
+	ctx := NewExecutionContext(token, nil)
+
+	length, err := executor.evaluateArgument(map[string]interface{}{
+		"type": "functionCall",
+		"name": "LENGTH",
+		"args": []interface{}{map[string]interface{}{"type": "variable", "name": "lines"}},
+	}, ctx)
+	if err != nil || length != 1.0 {
+		t.Errorf("LENGTH(lines) = %v, %v, want 1", length, err)
+	}
+
+	list, err := executor.evaluateArgument(map[string]interface{}{"type": "variable", "name": "lines"}, ctx)
+	if facts, ok := list.([]*Fact); err != nil || !ok || len(facts) != 1 || facts[0] != line {
+		t.Errorf("lines = %v, %v", list, err)
+	}
+}
@@ -16,7 +16,11 @@ type ExistsNode struct {
 	ExistsVariable  string                 `json:"exists_variable"`  // Variable d'existence (o)
 	VariableTypes   map[string]string      `json:"variable_types"`   // Mapping variable -> type
 	ExistsCondition []JoinCondition        `json:"exists_condition"` // Condition d'existence (o.customer_id == p.id)
-	mutex           sync.RWMutex
+	// Mode négatif (FORALL) : le token principal est propagé tant qu'aucun fait ne correspond
+	Negated        bool        `json:"negated,omitempty"`
+	MatchCondition interface{} `json:"match_condition,omitempty"` // Condition générale sur (principal, existence)
+	MainCondition  interface{} `json:"main_condition,omitempty"`  // Filtre sur la variable principale seule
+	mutex          sync.RWMutex
 	// Mémoires pour architecture RETE
 	MainMemory   *WorkingMemory // Faits de la variable principale
 	ExistsMemory *WorkingMemory // Faits pour vérification d'existence
@@ -45,8 +49,45 @@ func NewExistsNode(nodeID string, condition map[string]interface{}, mainVar stri
 	}
 }
 
+// NewForallNode crée un nœud d'existence négatif pour FORALL(x / condition ==> then).
+//
+// FORALL est compilé en NOT EXISTS(x / condition AND NOT(then)) : un token principal
+// est propagé tant qu'aucun fait x ne vérifie condition sans vérifier then. Il est
+// rétracté dès qu'un tel contre-exemple apparaît, et propagé à nouveau quand le
+// dernier contre-exemple est rétracté. mainCondition (optionnel) filtre la variable
+// principale avant toute vérification.
+func NewForallNode(nodeID string, mainVar string, forallVar string, varTypes map[string]string,
+	condition interface{}, then interface{}, mainCondition interface{}, storage Storage) *ExistsNode {
+	counterExample := map[string]interface{}{
+		"type":       "notConstraint",
+		"expression": then,
+	}
+	if condition != nil {
+		counterExample = map[string]interface{}{
+			"type": "logicalExpr",
+			"left": condition,
+			"operations": []interface{}{
+				map[string]interface{}{"op": "AND", "right": counterExample},
+			},
+		}
+	}
+
+	node := NewExistsNode(nodeID, map[string]interface{}{"type": ConditionTypeForall}, mainVar, forallVar, varTypes, storage)
+	node.Negated = true
+	node.MatchCondition = counterExample
+	node.MainCondition = mainCondition
+	return node
+}
+
 // ActivateLeft traite les faits de la variable principale
 func (en *ExistsNode) ActivateLeft(token *Token) error {
+	if !en.passesMainCondition(token) {
+		return nil
+	}
+
+	if en.Negated {
+		return en.activateLeftNegated(token)
+	}
 
 	// Stocker le token dans la mémoire principale
 	en.mutex.Lock()
@@ -107,6 +148,15 @@ func (en *ExistsNode) ActivateRetract(factID string) error {
 		delete(en.Memory.Tokens, tokenID)
 	}
 	en.mutex.Unlock()
+
+	// FORALL : le retrait d'un contre-exemple peut revalider des tokens principaux
+	if en.Negated && existsInExistsMemory {
+		if err := en.PropagateRetractToChildren(factID); err != nil {
+			return err
+		}
+		return en.revalidateNegatedTokens()
+	}
+
 	totalRemoved := len(mainTokensToRemove) + len(resultTokensToRemove)
 	if existsInExistsMemory {
 		totalRemoved++
@@ -119,6 +169,9 @@ func (en *ExistsNode) ActivateRetract(factID string) error {
 
 // ActivateRight traite les faits pour vérification d'existence
 func (en *ExistsNode) ActivateRight(fact *Fact) error {
+	if en.Negated {
+		return en.activateRightNegated(fact)
+	}
 
 	// Stocker le fait dans la mémoire d'existence
 	en.mutex.Lock()
@@ -165,11 +218,11 @@ func (en *ExistsNode) checkExistence(mainToken *Token) bool {
 	if len(mainToken.Facts) == 0 {
 		return false
 	}
-	mainFact := mainToken.Facts[0]
+	mainFact := en.mainFact(mainToken)
 
 	// Vérifier les conditions d'existence
 	for _, existsFact := range existsFacts {
-		if en.evaluateExistsCondition(mainFact, existsFact) {
+		if en.matches(mainFact, existsFact) {
 			return true
 		}
 	}
@@ -237,3 +290,123 @@ func (en *ExistsNode) isAlreadyValidated(token *Token) bool {
 	}
 	return false
 }
+
+// activateLeftNegated propage un token principal qui n'a aucun contre-exemple
+func (en *ExistsNode) activateLeftNegated(token *Token) error {
+	en.mutex.Lock()
+	en.MainMemory.AddToken(token)
+	en.mutex.Unlock()
+
+	if en.checkExistence(token) {
+		return nil
+	}
+
+	token.IsJoinResult = true
+	en.mutex.Lock()
+	en.ResultMemory.AddToken(token)
+	en.Memory.AddToken(token)
+	en.mutex.Unlock()
+
+	return en.PropagateToChildren(nil, token)
+}
+
+// activateRightNegated rétracte les tokens validés pour lesquels le fait est un contre-exemple
+func (en *ExistsNode) activateRightNegated(fact *Fact) error {
+	en.mutex.Lock()
+	if err := en.ExistsMemory.AddFact(fact); err != nil {
+		en.mutex.Unlock()
+		return fmt.Errorf("erreur ajout fait dans exists node: %w", err)
+	}
+
+	var invalidated []*Fact
+	for tokenID, token := range en.ResultMemory.Tokens {
+		mainFact := en.mainFact(token)
+		if mainFact != nil && en.matches(mainFact, fact) {
+			delete(en.ResultMemory.Tokens, tokenID)
+			delete(en.Memory.Tokens, tokenID)
+			invalidated = append(invalidated, mainFact)
+		}
+	}
+	en.mutex.Unlock()
+
+	for _, mainFact := range invalidated {
+		if err := en.PropagateRetractToChildren(mainFact.GetInternalID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revalidateNegatedTokens propage les tokens principaux qui n'ont plus de contre-exemple
+func (en *ExistsNode) revalidateNegatedTokens() error {
+	for _, mainToken := range en.MainMemory.GetTokens() {
+		if en.isAlreadyValidated(mainToken) || en.checkExistence(mainToken) {
+			continue
+		}
+
+		validatedToken := &Token{
+			ID:           mainToken.ID + "_validated",
+			Facts:        mainToken.Facts,
+			NodeID:       en.ID,
+			Bindings:     mainToken.Bindings,
+			IsJoinResult: true,
+		}
+
+		en.mutex.Lock()
+		en.ResultMemory.AddToken(validatedToken)
+		en.Memory.AddToken(validatedToken)
+		en.mutex.Unlock()
+
+		if err := en.PropagateToChildren(nil, validatedToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mainFact retourne le fait lié à la variable principale d'un token
+func (en *ExistsNode) mainFact(token *Token) *Fact {
+	if token.Bindings != nil {
+		if fact := token.Bindings.Get(en.MainVariable); fact != nil {
+			return fact
+		}
+	}
+	if len(token.Facts) == 0 {
+		return nil
+	}
+	return token.Facts[0]
+}
+
+// matches indique si un fait d'existence correspond au fait principal
+func (en *ExistsNode) matches(mainFact *Fact, existsFact *Fact) bool {
+	if en.MatchCondition == nil {
+		return en.evaluateExistsCondition(mainFact, existsFact)
+	}
+
+	evaluator := NewAlphaConditionEvaluator()
+	evaluator.variableBindings[en.MainVariable] = mainFact
+	evaluator.variableBindings[en.ExistsVariable] = existsFact
+	result, err := evaluator.evaluateExpression(en.MatchCondition)
+	if err != nil {
+		return false
+	}
+	return result
+}
+
+// passesMainCondition évalue le filtre optionnel sur la variable principale
+func (en *ExistsNode) passesMainCondition(token *Token) bool {
+	if en.MainCondition == nil {
+		return true
+	}
+	mainFact := en.mainFact(token)
+	if mainFact == nil {
+		return false
+	}
+
+	evaluator := NewAlphaConditionEvaluator()
+	result, err := evaluator.EvaluateCondition(en.MainCondition, mainFact, en.MainVariable)
+	if err != nil {
+		return false
+	}
+	return result
+}
@@ -382,6 +382,11 @@ func (jn *JoinNode) evaluateComplexConditions(bindings *BindingChain) bool {
 		return true
 	}
 
+	// Une disjonction ou une négation est évaluée entière
+	if isCompositeCondition(actualCondition) {
+		return jn.evaluateAlphaConditions([]map[string]interface{}{actualCondition}, bindings)
+	}
+
 	// Déléguer selon le type de condition
 	switch condType {
 	case "constraint":
@@ -469,14 +474,15 @@ func (jn *JoinNode) bindVariablesToEvaluator(evaluator *AlphaConditionEvaluator,
 	}
 }
 
-// extractAlphaConditions extrait les conditions alpha (non-join) d'une logicalExpr.
+// extractAlphaConditions extrait les conditions alpha (non-join), disjonctions et
+// négations comprises, d'une logicalExpr.
 // Refactorisé pour améliorer la lisibilité et réduire la complexité.
 func (jn *JoinNode) extractAlphaConditions(condition map[string]interface{}) []map[string]interface{} {
 	var alphaConditions []map[string]interface{}
 
 	// Extraire de la partie gauche
 	if left, ok := condition["left"].(map[string]interface{}); ok {
-		if isAlphaCondition(left) {
+		if isAlphaCondition(left) || isCompositeCondition(left) {
 			alphaConditions = append(alphaConditions, left)
 		}
 	}
@@ -502,7 +508,7 @@ func (jn *JoinNode) extractAlphaFromOperations(condition map[string]interface{})
 		for _, op := range operations {
 			if opMap, ok := op.(map[string]interface{}); ok {
 				if right, ok := opMap["right"].(map[string]interface{}); ok {
-					if isAlphaCondition(right) {
+					if isAlphaCondition(right) || isCompositeCondition(right) {
 						alphaConditions = append(alphaConditions, right)
 					}
 				}
@@ -515,7 +521,7 @@ func (jn *JoinNode) extractAlphaFromOperations(condition map[string]interface{})
 	if operations, ok := operationsRaw.([]map[string]interface{}); ok {
 		for _, opMap := range operations {
 			if right, ok := opMap["right"].(map[string]interface{}); ok {
-				if isAlphaCondition(right) {
+				if isAlphaCondition(right) || isCompositeCondition(right) {
 					alphaConditions = append(alphaConditions, right)
 				}
 			}
@@ -551,6 +557,19 @@ func isAlphaCondition(condition map[string]interface{}) bool {
 	return false
 }
 
+// isCompositeCondition détermine si une condition est une disjonction ou une
+// négation, évaluée entière sur les faits joints : ses comparaisons ne sont
+// ni des filtres alpha ni des conditions de jointure indépendantes.
+func isCompositeCondition(condition map[string]interface{}) bool {
+	switch condition["type"] {
+	case "notConstraint":
+		return true
+	case "logicalExpr":
+		return !isConjunction(condition)
+	}
+	return false
+}
+
 // evaluateSimpleJoinConditions évalue les conditions de jointure simples (champ à champ).
 //
 // Accepte maintenant BindingChain au lieu de map[string]*Fact.
@@ -755,6 +774,11 @@ func extractJoinConditions(condition map[string]interface{}) []JoinCondition {
 	case "comparison":
 		return extractComparisonJoinConditions(condition)
 	case "logicalExpr":
+		// Une disjonction est évaluée entière par evaluateComplexConditions :
+		// ses comparaisons ne sont pas des conditions de jointure obligatoires
+		if !isConjunction(condition) {
+			return []JoinCondition{}
+		}
 		return extractLogicalExprJoinConditions(condition)
 	default:
 		return []JoinCondition{}
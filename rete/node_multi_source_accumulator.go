@@ -21,9 +21,14 @@ type MultiSourceAccumulatorNode struct {
 	mutex           sync.RWMutex
 
 	// Memory structures
-	MainFacts      map[string]*Fact              `json:"-"` // Main facts indexed by ID
-	CombinedTokens map[string]map[string]*Token  `json:"-"` // Tokens grouped by main fact ID
-	AggregateCache map[string]map[string]float64 `json:"-"` // Cached aggregation results: mainFactID -> aggVarName -> value
+	MainFacts      map[string]*Fact                  `json:"-"` // Main facts indexed by internal ID
+	CombinedTokens map[string]map[string]*Token      `json:"-"` // Tokens grouped by main fact ID
+	AggregateCache map[string]map[string]interface{} `json:"-"` // Cached aggregation results: mainFactID -> aggVarName -> value
+
+	// Incremental aggregation state: mainFactID -> aggVarName -> state / source fact reference counts
+	states  map[string]map[string]AggregateState
+	refs    map[string]map[string]map[string]int
+	emitted map[string]bool
 }
 
 // NewMultiSourceAccumulatorNode creates a new multi-source accumulator node
@@ -49,7 +54,10 @@ func NewMultiSourceAccumulatorNode(
 		Storage:         storage,
 		MainFacts:       make(map[string]*Fact),
 		CombinedTokens:  make(map[string]map[string]*Token),
-		AggregateCache:  make(map[string]map[string]float64),
+		AggregateCache:  make(map[string]map[string]interface{}),
+		states:          make(map[string]map[string]AggregateState),
+		refs:            make(map[string]map[string]map[string]int),
+		emitted:         make(map[string]bool),
 	}
 }
 
@@ -68,7 +76,7 @@ func (msn *MultiSourceAccumulatorNode) Activate(fact *Fact, token *Token) error
 		return fmt.Errorf("main variable %s not found in token bindings", msn.MainVariable)
 	}
 
-	mainFactID := mainFact.ID
+	mainFactID := mainFact.GetInternalID()
 
 	// Store main fact
 	msn.MainFacts[mainFactID] = mainFact
@@ -77,191 +85,167 @@ func (msn *MultiSourceAccumulatorNode) Activate(fact *Fact, token *Token) error
 	if msn.CombinedTokens[mainFactID] == nil {
 		msn.CombinedTokens[mainFactID] = make(map[string]*Token)
 	}
+	if _, seen := msn.CombinedTokens[mainFactID][token.ID]; !seen {
+		if err := msn.addTokenToStates(mainFactID, token); err != nil {
+			return err
+		}
+	}
 	msn.CombinedTokens[mainFactID][token.ID] = token
 
 	fmt.Printf("📊 MULTI_ACCUMULATOR[%s]: Received token %s for main fact %s\n",
-		msn.ID, token.ID, mainFactID)
+		msn.ID, token.ID, mainFact.ID)
 
-	// Recompute aggregations for this main fact
+	// Re-evaluate aggregations for this main fact
 	return msn.processMainFact(mainFact)
 }
 
-// processMainFact computes all aggregations for a main fact and evaluates thresholds
+// addTokenToStates adds the source facts of a token to the aggregation states of its main fact.
+// A source fact joined through several tokens is only aggregated once.
+func (msn *MultiSourceAccumulatorNode) addTokenToStates(mainFactID string, token *Token) error {
+	if msn.states[mainFactID] == nil {
+		msn.states[mainFactID] = make(map[string]AggregateState)
+		msn.refs[mainFactID] = make(map[string]map[string]int)
+	}
+	for _, aggVar := range msn.AggregationVars {
+		state, exists := msn.states[mainFactID][aggVar.Name]
+		if !exists {
+			var err error
+			state, err = NewAggregateState(aggVar.Spec())
+			if err != nil {
+				return fmt.Errorf("error computing aggregation %s: %w", aggVar.Name, err)
+			}
+			msn.states[mainFactID][aggVar.Name] = state
+			msn.refs[mainFactID][aggVar.Name] = make(map[string]int)
+		}
+
+		sourceFact := token.GetBinding(aggVar.SourceVar)
+		if sourceFact == nil {
+			continue
+		}
+		refs := msn.refs[mainFactID][aggVar.Name]
+		sourceID := sourceFact.GetInternalID()
+		if refs[sourceID]++; refs[sourceID] == 1 {
+			state.Add(sourceFact)
+		}
+	}
+	return nil
+}
+
+// removeTokenFromStates removes the source facts of a token from the aggregation states
+// once no remaining token references them.
+func (msn *MultiSourceAccumulatorNode) removeTokenFromStates(mainFactID string, token *Token) {
+	for _, aggVar := range msn.AggregationVars {
+		state := msn.states[mainFactID][aggVar.Name]
+		sourceFact := token.GetBinding(aggVar.SourceVar)
+		if state == nil || sourceFact == nil {
+			continue
+		}
+		refs := msn.refs[mainFactID][aggVar.Name]
+		sourceID := sourceFact.GetInternalID()
+		if refs[sourceID]--; refs[sourceID] <= 0 {
+			delete(refs, sourceID)
+			state.Remove(sourceFact)
+		}
+	}
+}
+
+// processMainFact reads all aggregations for a main fact and evaluates thresholds
 func (msn *MultiSourceAccumulatorNode) processMainFact(mainFact *Fact) error {
-	mainFactID := mainFact.ID
+	mainFactID := mainFact.GetInternalID()
 
 	// Get all tokens for this main fact
 	tokens := msn.CombinedTokens[mainFactID]
 	if len(tokens) == 0 {
-		fmt.Printf("📊 MULTI_ACCUMULATOR[%s]: No tokens for main fact %s\n", msn.ID, mainFactID)
+		fmt.Printf("📊 MULTI_ACCUMULATOR[%s]: No tokens for main fact %s\n", msn.ID, mainFact.ID)
 		return nil
 	}
 
 	// Initialize aggregate cache for this main fact
 	if msn.AggregateCache[mainFactID] == nil {
-		msn.AggregateCache[mainFactID] = make(map[string]float64)
+		msn.AggregateCache[mainFactID] = make(map[string]interface{})
 	}
 
-	// Compute each aggregation variable
+	// Read each aggregation variable
 	allThresholdsSatisfied := true
 	for _, aggVar := range msn.AggregationVars {
-		value, err := msn.computeAggregation(mainFactID, aggVar, tokens)
-		if err != nil {
-			return fmt.Errorf("error computing aggregation %s: %w", aggVar.Name, err)
-		}
+		value := msn.states[mainFactID][aggVar.Name].Result()
 
 		// Cache the result
 		msn.AggregateCache[mainFactID][aggVar.Name] = value
 
-		fmt.Printf("📊 MULTI_ACCUMULATOR[%s]: %s = %.2f for main fact %s\n",
-			msn.ID, aggVar.Name, value, mainFactID)
+		fmt.Printf("📊 MULTI_ACCUMULATOR[%s]: %s = %v for main fact %s\n",
+			msn.ID, aggVar.Name, value, mainFact.ID)
 
 		// Check threshold if specified
 		if aggVar.Operator != "" && aggVar.Operator != ">=" || aggVar.Threshold != 0 {
 			if !msn.evaluateThreshold(value, aggVar.Operator, aggVar.Threshold) {
-				fmt.Printf("❌ MULTI_ACCUMULATOR[%s]: Threshold not satisfied: %s (%.2f %s %.2f)\n",
+				fmt.Printf("❌ MULTI_ACCUMULATOR[%s]: Threshold not satisfied: %s (%v %s %.2f)\n",
 					msn.ID, aggVar.Name, value, aggVar.Operator, aggVar.Threshold)
 				allThresholdsSatisfied = false
 			} else {
-				fmt.Printf("✅ MULTI_ACCUMULATOR[%s]: Threshold satisfied: %s (%.2f %s %.2f)\n",
+				fmt.Printf("✅ MULTI_ACCUMULATOR[%s]: Threshold satisfied: %s (%v %s %.2f)\n",
 					msn.ID, aggVar.Name, value, aggVar.Operator, aggVar.Threshold)
 			}
 		}
 	}
 
 	// Only fire if all thresholds are satisfied
-	if allThresholdsSatisfied {
-		fmt.Printf("✅ MULTI_ACCUMULATOR[%s]: All thresholds satisfied for main fact %s\n",
-			msn.ID, mainFactID)
-
-		// Create a token with the main fact and aggregation results
-		// We'll use the first token as a template and add aggregate values
-		var firstToken *Token
-		for _, t := range tokens {
-			firstToken = t
-			break
-		}
-
-		if firstToken != nil {
-			newToken := &Token{
-				ID:       fmt.Sprintf("msaccum_%s", mainFactID),
-				Facts:    firstToken.Facts,
-				Bindings: firstToken.Bindings,
-			}
-
-			// Store token in memory
-			msn.Memory.AddToken(newToken)
-
-			// Propagate to children
-			for _, child := range msn.Children {
-				if err := child.ActivateLeft(newToken); err != nil {
-					fmt.Printf("⚠️  Error activating child: %v\n", err)
-				}
-			}
-		}
-	} else {
+	if !allThresholdsSatisfied {
 		fmt.Printf("❌ MULTI_ACCUMULATOR[%s]: Not all thresholds satisfied for main fact %s\n",
-			msn.ID, mainFactID)
-	}
-
-	return nil
-}
-
-// computeAggregation computes a single aggregation value
-func (msn *MultiSourceAccumulatorNode) computeAggregation(
-	mainFactID string,
-	aggVar AggregationVariable,
-	tokens map[string]*Token,
-) (float64, error) {
-	// Collect all relevant facts for this aggregation
-	var values []float64
-	seenFacts := make(map[string]bool) // Deduplicate facts
-
-	for _, token := range tokens {
-		// Get the source fact for this aggregation
-		sourceFact := token.GetBinding(aggVar.SourceVar)
-		if sourceFact == nil {
-			continue
-		}
-
-		// Skip if we've already seen this fact
-		if seenFacts[sourceFact.ID] {
-			continue
-		}
-		seenFacts[sourceFact.ID] = true
-
-		// For COUNT, just count the facts
-		if aggVar.Function == "COUNT" {
-			values = append(values, 1.0)
-			continue
+			msn.ID, mainFact.ID)
+		if msn.emitted[mainFactID] {
+			delete(msn.emitted, mainFactID)
+			msn.Memory.RemoveToken(fmt.Sprintf("msaccum_%s", mainFact.ID))
+			return msn.PropagateRetractToChildren(mainFactID)
 		}
+		return nil
+	}
 
-		// Get the field value
-		fieldValue, exists := sourceFact.Fields[aggVar.Field]
-		if !exists {
-			fmt.Printf("⚠️  MULTI_ACCUMULATOR[%s]: Field %s not found in fact %s\n",
-				msn.ID, aggVar.Field, sourceFact.ID)
-			continue
-		}
+	fmt.Printf("✅ MULTI_ACCUMULATOR[%s]: All thresholds satisfied for main fact %s\n",
+		msn.ID, mainFact.ID)
 
-		// Convert to float64
-		numValue := msn.toFloat64(fieldValue)
-		values = append(values, numValue)
+	// Create a token with the main fact and aggregation results
+	// We'll use the first token as a template and add aggregate values
+	var firstToken *Token
+	for _, t := range tokens {
+		firstToken = t
+		break
 	}
 
-	// Compute the aggregation based on function
-	return msn.calculateAggregate(aggVar.Function, values)
-}
-
-// calculateAggregate performs the actual aggregation calculation
-func (msn *MultiSourceAccumulatorNode) calculateAggregate(function string, values []float64) (float64, error) {
-	if len(values) == 0 {
-		return 0, nil
+	aggregates := make(map[string]interface{}, len(msn.AggregateCache[mainFactID]))
+	for name, value := range msn.AggregateCache[mainFactID] {
+		aggregates[name] = value
 	}
 
-	switch function {
-	case "COUNT":
-		return float64(len(values)), nil
+	newToken := &Token{
+		ID:         fmt.Sprintf("msaccum_%s", mainFact.ID),
+		Facts:      firstToken.Facts,
+		Bindings:   firstToken.Bindings,
+		Aggregates: aggregates,
+	}
 
-	case "SUM":
-		sum := 0.0
-		for _, v := range values {
-			sum += v
-		}
-		return sum, nil
+	// Store token in memory
+	msn.Memory.AddToken(newToken)
+	msn.emitted[mainFactID] = true
 
-	case "AVG":
-		sum := 0.0
-		for _, v := range values {
-			sum += v
+	// Propagate to children
+	for _, child := range msn.Children {
+		if err := activateLeft(child, newToken); err != nil {
+			fmt.Printf("⚠️  Error activating child: %v\n", err)
 		}
-		return sum / float64(len(values)), nil
-
-	case "MIN":
-		min := values[0]
-		for _, v := range values {
-			if v < min {
-				min = v
-			}
-		}
-		return min, nil
+	}
 
-	case "MAX":
-		max := values[0]
-		for _, v := range values {
-			if v > max {
-				max = v
-			}
-		}
-		return max, nil
+	return nil
+}
 
-	default:
-		return 0, fmt.Errorf("unsupported aggregation function: %s", function)
+// evaluateThreshold checks if a value satisfies a threshold condition.
+// Non-numeric results (FIRST, STRING_AGG, ...) never satisfy an explicit threshold.
+func (msn *MultiSourceAccumulatorNode) evaluateThreshold(result interface{}, operator string, threshold float64) bool {
+	value, isNumber := toNumber(result)
+	if !isNumber {
+		return false
 	}
-}
 
-// evaluateThreshold checks if a value satisfies a threshold condition
-func (msn *MultiSourceAccumulatorNode) evaluateThreshold(value float64, operator string, threshold float64) bool {
 	switch operator {
 	case ">":
 		return value > threshold
@@ -284,31 +268,6 @@ func (msn *MultiSourceAccumulatorNode) evaluateThreshold(value float64, operator
 	}
 }
 
-// toFloat64 converts various numeric types to float64
-func (msn *MultiSourceAccumulatorNode) toFloat64(val interface{}) float64 {
-	switch v := val.(type) {
-	case float64:
-		return v
-	case float32:
-		return float64(v)
-	case int:
-		return float64(v)
-	case int32:
-		return float64(v)
-	case int64:
-		return float64(v)
-	case uint:
-		return float64(v)
-	case uint32:
-		return float64(v)
-	case uint64:
-		return float64(v)
-	default:
-		fmt.Printf("⚠️  Cannot convert %v (type %T) to float64, returning 0\n", val, val)
-		return 0
-	}
-}
-
 // ActivateLeft handles left-side activation (from join chain)
 func (msn *MultiSourceAccumulatorNode) ActivateLeft(token *Token) error {
 	if len(token.Facts) > 0 {
@@ -322,15 +281,16 @@ func (msn *MultiSourceAccumulatorNode) ActivateRight(fact *Fact) error {
 	return fmt.Errorf("multi-source accumulator does not support right activation")
 }
 
-// ActivateRetract handles fact retraction
+// ActivateRetract handles fact retraction.
+// factID must be the internal identifier (Type_ID).
 func (msn *MultiSourceAccumulatorNode) ActivateRetract(factID string) error {
 	msn.mutex.Lock()
 	defer msn.mutex.Unlock()
 
 	// Check if this is a main fact being retracted
-	if mainFact, exists := msn.MainFacts[factID]; exists {
+	if _, exists := msn.MainFacts[factID]; exists {
 		fmt.Printf("🔄 MULTI_ACCUMULATOR[%s]: Retracting main fact %s\n", msn.ID, factID)
-		msn.ClearMainFact(mainFact.ID)
+		msn.ClearMainFact(factID)
 
 		// Propagate retraction to children
 		for _, child := range msn.Children {
@@ -341,37 +301,42 @@ func (msn *MultiSourceAccumulatorNode) ActivateRetract(factID string) error {
 		return nil
 	}
 
-	// Otherwise, scan tokens to find if this fact is part of any combined tokens
+	// Otherwise, remove the combined tokens that contain this fact
 	for mainFactID, tokens := range msn.CombinedTokens {
+		removed := false
 		for tokenID, token := range tokens {
-			// Check if this fact is in the token
-			factInToken := false
-			for _, f := range token.Facts {
-				if f.ID == factID {
-					factInToken = true
-					break
-				}
-			}
-
-			if factInToken {
-				// Remove this token
-				delete(msn.CombinedTokens[mainFactID], tokenID)
-				fmt.Printf("🔄 MULTI_ACCUMULATOR[%s]: Removed token %s due to fact %s retraction\n",
-					msn.ID, tokenID, factID)
+			if !tokenContainsFact(token, factID) {
+				continue
 			}
+			msn.removeTokenFromStates(mainFactID, token)
+			delete(tokens, tokenID)
+			removed = true
+			fmt.Printf("🔄 MULTI_ACCUMULATOR[%s]: Removed token %s due to fact %s retraction\n",
+				msn.ID, tokenID, factID)
+		}
+		if !removed {
+			continue
 		}
 
-		// If no tokens left for this main fact, clear its cache
-		if len(msn.CombinedTokens[mainFactID]) == 0 {
+		mainFact, exists := msn.MainFacts[mainFactID]
+		if len(tokens) == 0 {
+			// No joined sources left: the previous activation no longer holds
 			delete(msn.CombinedTokens, mainFactID)
 			delete(msn.AggregateCache, mainFactID)
-		} else {
-			// Recompute aggregations for this main fact
-			if mainFact, exists := msn.MainFacts[mainFactID]; exists {
-				if err := msn.processMainFact(mainFact); err != nil {
-					fmt.Printf("⚠️  Error recomputing aggregations after retraction: %v\n", err)
+			delete(msn.states, mainFactID)
+			delete(msn.refs, mainFactID)
+			if msn.emitted[mainFactID] && exists {
+				delete(msn.emitted, mainFactID)
+				msn.Memory.RemoveToken(fmt.Sprintf("msaccum_%s", mainFact.ID))
+				if err := msn.PropagateRetractToChildren(mainFactID); err != nil {
+					fmt.Printf("⚠️  Error propagating retraction to child: %v\n", err)
 				}
 			}
+		} else if exists {
+			// Re-evaluate aggregations for this main fact
+			if err := msn.processMainFact(mainFact); err != nil {
+				fmt.Printf("⚠️  Error recomputing aggregations after retraction: %v\n", err)
+			}
 		}
 	}
 
@@ -379,12 +344,12 @@ func (msn *MultiSourceAccumulatorNode) ActivateRetract(factID string) error {
 }
 
 // GetAggregateValue retrieves a cached aggregation value
-func (msn *MultiSourceAccumulatorNode) GetAggregateValue(mainFactID string, aggVarName string) (float64, bool) {
+func (msn *MultiSourceAccumulatorNode) GetAggregateValue(mainFactID string, aggVarName string) (interface{}, bool) {
 	msn.mutex.RLock()
 	defer msn.mutex.RUnlock()
 
 	if msn.AggregateCache[mainFactID] == nil {
-		return 0, false
+		return nil, false
 	}
 
 	value, exists := msn.AggregateCache[mainFactID][aggVarName]
@@ -396,6 +361,9 @@ func (msn *MultiSourceAccumulatorNode) ClearMainFact(mainFactID string) {
 	delete(msn.MainFacts, mainFactID)
 	delete(msn.CombinedTokens, mainFactID)
 	delete(msn.AggregateCache, mainFactID)
+	delete(msn.states, mainFactID)
+	delete(msn.refs, mainFactID)
+	delete(msn.emitted, mainFactID)
 
 	fmt.Printf("🧹 MULTI_ACCUMULATOR[%s]: Cleared data for main fact %s\n", msn.ID, mainFactID)
 }
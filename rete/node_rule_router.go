@@ -57,7 +57,7 @@ func (rrn *RuleRouterNode) ActivateLeft(token *Token) error {
 
 	// Route the token to the terminal node if it exists
 	if rrn.TerminalNode != nil {
-		return rrn.TerminalNode.ActivateLeft(token)
+		return activateLeft(rrn.TerminalNode, token)
 	}
 
 	// Otherwise propagate to children (fallback for flexibility)
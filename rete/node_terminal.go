@@ -6,6 +6,8 @@ package rete
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -17,6 +19,10 @@ type TerminalNode struct {
 	// Observer pour notification des exécutions
 	observer ActionObserver
 
+	// Requête alimentée par ce terminal (nil pour une règle classique).
+	// En mode requête, les tokens sont conservés au lieu d'exécuter l'action.
+	query *Query
+
 	// Statistiques d'exécution (pour debug/tests)
 	lastExecutionResult *ExecutionResult
 	executionCount      int64
@@ -44,9 +50,10 @@ func NewTerminalNode(nodeID string, action *Action, storage Storage) *TerminalNo
 //
 // Process :
 //  1. Enregistre l'activation (métriques)
-//  2. Exécute l'action immédiatement
-//  3. Notifie l'observer du résultat
-//  4. NE STOCKE PAS le token (exécution immédiate)
+//  2. Règle désactivée : conserve le token ; dry-run : enregistre l'activation
+//  3. Exécute l'action immédiatement
+//  4. Notifie l'observer du résultat
+//  5. NE STOCKE PAS le token (exécution immédiate)
 //
 // Le token contient tous les bindings (via BindingChain) nécessaires
 // pour l'évaluation des arguments de l'action.
@@ -65,10 +72,51 @@ func (tn *TerminalNode) ActivateLeft(token *Token) error {
 	// Enregistrer l'activation (métriques réseau)
 	tn.recordActivation()
 
+	if tn.query != nil {
+		tn.storeQueryToken(token)
+		return nil
+	}
+
 	// PAS DE STOCKAGE - Exécuter directement
+	// Journaliser l'activation (provenance des faits soumis par l'action)
+	var activations *activationLog
+	var cascade *cascadeGuard
+	if network := tn.BaseNode.GetNetwork(); network != nil {
+		// Ne plus déclencher d'action une fois le contexte d'exécution terminé
+		if err := network.checkContext(); err != nil {
+			return err
+		}
+		// Règle désactivée ou en dry-run : l'action n'est pas exécutée
+		state := network.ruleStates.state(tn.getRuleName())
+		if state == RuleStateDisabled {
+			tn.holdToken(token)
+			return nil
+		}
+		// Annulation d'une transaction : les faits d'origine sont repropagés sans
+		// action ; recopie d'une mémoire de travail : les activations ont déjà eu lieu
+		if network.Undoing() || network.Seeding() {
+			return nil
+		}
+		if state == RuleStateDryRun {
+			network.ruleStates.recordDryRun(tn.getRuleName(), tn.Action, token)
+			return nil
+		}
+		if sim := network.currentSimulation(); sim != nil {
+			return tn.simulateAction(network, sim, token)
+		}
+		// Borner les cascades de règles (profondeur, nombre d'activations)
+		if err := network.cascade.enter(tn.getRuleName(), token); err != nil {
+			return err
+		}
+		activations = network.activations
+		cascade = network.cascade
+	}
+	record := activations.begin(tn.getRuleName(), tn.getActionName(), token)
 	start := time.Now()
-	err := tn.executeAction(token)
+	calls, err := tn.executeAction(token)
 	duration := time.Since(start)
+	cascade.exit()
+	activations.end(record, err)
 
 	// Créer le résultat d'exécution
 	result := ExecutionResult{
@@ -83,6 +131,10 @@ func (tn *TerminalNode) ActivateLeft(token *Token) error {
 			Timestamp:  start,
 		},
 		Arguments: tn.extractArguments(token),
+		Calls:     calls,
+	}
+	if len(calls) > 0 {
+		result.Arguments = calls[0].Args
 	}
 
 	// Mettre à jour les statistiques (pour debug/tests)
@@ -96,29 +148,122 @@ func (tn *TerminalNode) ActivateLeft(token *Token) error {
 	return err
 }
 
+// simulateAction exécute l'action en simulation : les appels sont enregistrés,
+// sans journal des activations ni notification de l'observer
+func (tn *TerminalNode) simulateAction(network *ReteNetwork, sim *simulation, token *Token) error {
+	if err := network.cascade.enter(tn.getRuleName(), token); err != nil {
+		return err
+	}
+	activation := sim.begin(tn.getRuleName(), token)
+	_, err := tn.executeAction(token)
+	network.cascade.exit()
+	sim.end(activation, err)
+	return err
+}
+
 // ActivateRetract retrait des tokens contenant le fait rétracté
 // factID doit être l'identifiant interne (Type_ID)
 func (tn *TerminalNode) ActivateRetract(factID string) error {
 	tn.mutex.Lock()
 	var tokensToRemove []string
 	for tokenID, token := range tn.Memory.Tokens {
-		for _, fact := range token.Facts {
-			if fact.GetInternalID() == factID {
-				tokensToRemove = append(tokensToRemove, tokenID)
-				break
-			}
+		if tokenContainsFact(token, factID) {
+			tokensToRemove = append(tokensToRemove, tokenID)
 		}
 	}
+	removed := make([]*Token, 0, len(tokensToRemove))
 	for _, tokenID := range tokensToRemove {
+		removed = append(removed, tn.Memory.Tokens[tokenID])
 		delete(tn.Memory.Tokens, tokenID)
 	}
 	tn.mutex.Unlock()
 	if len(tokensToRemove) > 0 {
 		fmt.Printf("🗑️  [TERMINAL_%s] Rétractation: %d tokens retirés\n", tn.ID, len(tokensToRemove))
 	}
+
+	if tn.query != nil {
+		for _, token := range removed {
+			tn.query.notify(token, false)
+		}
+	}
 	return nil
 }
 
+// tokenContainsFact indique si un token référence le fait (identifiant interne Type_ID)
+func tokenContainsFact(token *Token, factID string) bool {
+	for _, fact := range token.Facts {
+		if fact.GetInternalID() == factID {
+			return true
+		}
+	}
+	if token.Bindings != nil {
+		for _, fact := range token.Bindings.ToMap() {
+			if fact != nil && fact.GetInternalID() == factID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// storeQueryToken conserve le token d'une requête et notifie les abonnements s'il est nouveau
+func (tn *TerminalNode) storeQueryToken(token *Token) {
+	key := queryTokenKey(token)
+
+	tn.mutex.Lock()
+	_, existed := tn.Memory.Tokens[key]
+	tn.Memory.Tokens[key] = token
+	tn.mutex.Unlock()
+
+	if !existed {
+		tn.query.notify(token, true)
+	}
+}
+
+// holdToken conserve la correspondance d'une règle désactivée
+func (tn *TerminalNode) holdToken(token *Token) {
+	tn.mutex.Lock()
+	tn.Memory.Tokens[queryTokenKey(token)] = token
+	tn.mutex.Unlock()
+}
+
+// takeHeldTokens retire et retourne les correspondances conservées, triées par clé
+func (tn *TerminalNode) takeHeldTokens() []*Token {
+	tokens := tn.snapshotQueryTokens()
+	tn.mutex.Lock()
+	tn.Memory.Tokens = make(map[string]*Token)
+	tn.mutex.Unlock()
+	return tokens
+}
+
+// heldTokenCount retourne le nombre de correspondances conservées
+func (tn *TerminalNode) heldTokenCount() int {
+	tn.mutex.RLock()
+	defer tn.mutex.RUnlock()
+	return len(tn.Memory.Tokens)
+}
+
+// snapshotQueryTokens retourne une copie des tokens conservés, triés par clé
+func (tn *TerminalNode) snapshotQueryTokens() []*Token {
+	tn.mutex.RLock()
+	keys := make([]string, 0, len(tn.Memory.Tokens))
+	for key := range tn.Memory.Tokens {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tokens := make([]*Token, 0, len(keys))
+	for _, key := range keys {
+		tokens = append(tokens, tn.Memory.Tokens[key])
+	}
+	tn.mutex.RUnlock()
+	return tokens
+}
+
+// IsQuery indique si le terminal alimente une requête plutôt qu'une action
+func (tn *TerminalNode) IsQuery() bool {
+	return tn.query != nil
+}
+
 // GetTriggeredActions retourne les actions déclenchées (pour les tests)
 // DEPRECATED: Utiliser GetExecutionCount() et observer pattern à la place
 func (tn *TerminalNode) GetTriggeredActions() []*Action {
@@ -161,12 +306,13 @@ func (tn *TerminalNode) SetNetwork(network *ReteNetwork) {
 //   - token : token contenant les faits et bindings
 //
 // Retourne :
+//   - []ActionCall : appels exécutés avec leurs arguments évalués
 //   - error : erreur si l'exécution échoue
-func (tn *TerminalNode) executeAction(token *Token) error {
+func (tn *TerminalNode) executeAction(token *Token) ([]ActionCall, error) {
 	// Les actions sont maintenant obligatoires dans la grammaire
 	// Mais nous gardons cette vérification par sécurité
 	if tn.Action == nil {
-		return fmt.Errorf("aucune action définie pour le nœud %s", tn.ID)
+		return nil, fmt.Errorf("aucune action définie pour le nœud %s", tn.ID)
 	}
 
 	// Future feature: Intégration avec XupleSpace
@@ -190,10 +336,10 @@ func (tn *TerminalNode) executeAction(token *Token) error {
 	// Exécuter réellement l'action avec l'ActionExecutor
 	network := tn.BaseNode.GetNetwork()
 	if network != nil && network.ActionExecutor != nil {
-		return network.ActionExecutor.ExecuteAction(tn.Action, token)
+		return network.ActionExecutor.executeAction(tn.Action, token)
 	}
 
-	return nil
+	return nil, nil
 }
 
 // Clone crée une copie profonde du TerminalNode
@@ -271,9 +417,8 @@ func (tn *TerminalNode) getActionName() string {
 	if tn.Action == nil {
 		return "unknown"
 	}
-	jobs := tn.Action.GetJobs()
-	if len(jobs) > 0 {
-		return jobs[0].Name
+	if call := tn.Action.FirstCall(); call != nil {
+		return call.Name
 	}
 	return "unknown"
 }
@@ -281,23 +426,27 @@ func (tn *TerminalNode) getActionName() string {
 // getRuleName extrait le nom de la règle depuis l'ID du nœud.
 func (tn *TerminalNode) getRuleName() string {
 	// L'ID du terminal node contient le nom de la règle
-	// Format: "terminal_<ruleName>"
+	// Format: "<ruleName>_terminal" (ou l'ancien "terminal_<ruleName>")
+	if name, found := strings.CutSuffix(tn.ID, "_terminal"); found && name != "" {
+		return name
+	}
 	if len(tn.ID) > 9 && tn.ID[:9] == "terminal_" {
 		return tn.ID[9:]
 	}
 	return tn.ID
 }
 
-// extractArguments extrait les arguments bruts de l'action.
+// extractArguments extrait les arguments bruts de l'action, transmis à
+// l'observateur lorsqu'aucun appel n'a été exécuté (échec d'évaluation).
 func (tn *TerminalNode) extractArguments(token *Token) []interface{} {
 	if tn.Action == nil {
 		return nil
 	}
 
-	jobs := tn.Action.GetJobs()
-	if len(jobs) == 0 {
+	call := tn.Action.FirstCall()
+	if call == nil {
 		return nil
 	}
 
-	return jobs[0].Args
+	return call.Args
 }
@@ -74,9 +74,13 @@ func (tn *TypeNode) ActivateRight(fact *Fact) error {
 
 	// Persistance désactivée pour les performances
 
-	// Propager aux enfants (AlphaNodes)
+	return tn.activateChildren(fact, tn.GetChildren())
+}
+
+// activateChildren propage un fait aux enfants donnés (AlphaNodes)
+func (tn *TypeNode) activateChildren(fact *Fact, children []Node) error {
 	// Check if any child is a decomposed alpha chain and use context-aware activation
-	for _, child := range tn.GetChildren() {
+	for _, child := range children {
 		if alphaNode, ok := child.(*AlphaNode); ok {
 			// Check if this AlphaNode is part of a decomposed chain
 			if alphaNode.IsAtomic || len(alphaNode.Dependencies) > 0 {
@@ -87,18 +91,27 @@ func (tn *TypeNode) ActivateRight(fact *Fact) error {
 				} else {
 					ctx = NewEvaluationContext(fact)
 				}
-				if err := alphaNode.ActivateWithContext(fact, ctx); err != nil {
+				if err := activateAlphaWithContext(alphaNode, fact, ctx); err != nil {
+					if abortsPropagation(err) {
+						return err
+					}
 					return fmt.Errorf("error activating decomposed alpha chain: %w", err)
 				}
 			} else {
 				// Standard activation for non-decomposed alpha nodes
-				if err := alphaNode.ActivateRight(fact); err != nil {
+				if err := activateRight(alphaNode, fact); err != nil {
+					if abortsPropagation(err) {
+						return err
+					}
 					return fmt.Errorf("error activating alpha node: %w", err)
 				}
 			}
 		} else {
 			// Non-alpha child, use standard propagation
-			if err := child.ActivateRight(fact); err != nil {
+			if err := activateRight(child, fact); err != nil {
+				if abortsPropagation(err) {
+					return err
+				}
 				return fmt.Errorf("error propagating to child: %w", err)
 			}
 		}
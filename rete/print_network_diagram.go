@@ -6,6 +6,8 @@ package rete
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 )
@@ -13,20 +15,33 @@ import (
 // NetworkDiagram génère un diagramme ASCII détaillé du réseau RETE
 type NetworkDiagram struct {
 	network *ReteNetwork
+	out     io.Writer
 }
 
 // NewNetworkDiagram crée un nouveau générateur de diagramme
 func NewNetworkDiagram(network *ReteNetwork) *NetworkDiagram {
-	return &NetworkDiagram{network: network}
+	return &NetworkDiagram{network: network, out: os.Stdout}
 }
 
 // PrintDetailedDiagram affiche un diagramme complet avec les opérateurs
 func (nd *NetworkDiagram) PrintDetailedDiagram() {
-	fmt.Println()
-	fmt.Println(strings.Repeat("═", 120))
-	fmt.Println("📊 DIAGRAMME DÉTAILLÉ DU RÉSEAU RETE")
-	fmt.Println(strings.Repeat("═", 120))
-	fmt.Println()
+	nd.writeDiagram(os.Stdout, true)
+}
+
+// WriteDetailedDiagram écrit dans w le diagramme des nœuds du réseau et son
+// résumé, sans l'illustration du diagramme de flux
+func (nd *NetworkDiagram) WriteDetailedDiagram(w io.Writer) {
+	nd.writeDiagram(w, false)
+}
+
+// writeDiagram écrit le diagramme dans w, avec ou sans le diagramme de flux
+func (nd *NetworkDiagram) writeDiagram(w io.Writer, withFlow bool) {
+	nd.out = w
+	fmt.Fprintln(nd.out)
+	fmt.Fprintln(nd.out, strings.Repeat("═", 120))
+	fmt.Fprintln(nd.out, "📊 DIAGRAMME DÉTAILLÉ DU RÉSEAU RETE")
+	fmt.Fprintln(nd.out, strings.Repeat("═", 120))
+	fmt.Fprintln(nd.out)
 
 	// 1. Type Nodes
 	nd.printTypeNodes()
@@ -47,21 +62,23 @@ func (nd *NetworkDiagram) PrintDetailedDiagram() {
 	nd.printTerminalNodes()
 
 	// 7. Flow Diagram
-	nd.printFlowDiagram()
+	if withFlow {
+		nd.printFlowDiagram()
+	}
 
 	// 8. Summary
 	nd.printSummary()
 
-	fmt.Println()
-	fmt.Println(strings.Repeat("═", 120))
-	fmt.Println()
+	fmt.Fprintln(nd.out)
+	fmt.Fprintln(nd.out, strings.Repeat("═", 120))
+	fmt.Fprintln(nd.out)
 }
 
 func (nd *NetworkDiagram) printTypeNodes() {
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 1️⃣  TYPE NODES (Routage par type)                                                                    │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 1️⃣  TYPE NODES (Routage par type)                                                                    │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	typeNames := make([]string, 0, len(nd.network.TypeNodes))
 	for typeName := range nd.network.TypeNodes {
@@ -71,19 +88,19 @@ func (nd *NetworkDiagram) printTypeNodes() {
 
 	for _, typeName := range typeNames {
 		node := nd.network.TypeNodes[typeName]
-		fmt.Printf("   [T] type_%s\n", typeName)
-		fmt.Printf("       │ Type: %s\n", typeName)
-		fmt.Printf("       │ Enfants: %d nœuds\n", len(node.Children))
-		fmt.Printf("       └─→ Propage tous les faits de type %s\n", typeName)
-		fmt.Println()
+		fmt.Fprintf(nd.out, "   [T] type_%s\n", typeName)
+		fmt.Fprintf(nd.out, "       │ Type: %s\n", typeName)
+		fmt.Fprintf(nd.out, "       │ Enfants: %d nœuds\n", len(node.Children))
+		fmt.Fprintf(nd.out, "       └─→ Propage tous les faits de type %s\n", typeName)
+		fmt.Fprintln(nd.out)
 	}
 }
 
 func (nd *NetworkDiagram) printAlphaNodes() {
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 2️⃣  ALPHA NODES (Filtres et calculs atomiques)                                                       │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 2️⃣  ALPHA NODES (Filtres et calculs atomiques)                                                       │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	// Grouper par variable
 	alphasByVar := make(map[string][]*AlphaNode)
@@ -101,8 +118,8 @@ func (nd *NetworkDiagram) printAlphaNodes() {
 
 	for _, varName := range vars {
 		nodes := alphasByVar[varName]
-		fmt.Printf("   📍 Variable: %s (%d nœuds)\n", varName, len(nodes))
-		fmt.Println()
+		fmt.Fprintf(nd.out, "   📍 Variable: %s (%d nœuds)\n", varName, len(nodes))
+		fmt.Fprintln(nd.out)
 
 		// Trier les nœuds par ID pour un affichage cohérent
 		sort.Slice(nodes, func(i, j int) bool {
@@ -112,7 +129,7 @@ func (nd *NetworkDiagram) printAlphaNodes() {
 		for i, node := range nodes {
 			nd.printAlphaNodeDetail(node, i+1, len(nodes))
 		}
-		fmt.Println()
+		fmt.Fprintln(nd.out)
 	}
 }
 
@@ -126,7 +143,7 @@ func (nd *NetworkDiagram) printAlphaNodeDetail(node *AlphaNode, index, total int
 		symbol = "├─"
 	}
 
-	fmt.Printf("      %s [α] %s\n", symbol, node.ID)
+	fmt.Fprintf(nd.out, "      %s [α] %s\n", symbol, node.ID)
 
 	// Extraire les détails de la condition
 	if condMap, ok := node.Condition.(map[string]interface{}); ok {
@@ -135,54 +152,54 @@ func (nd *NetworkDiagram) printAlphaNodeDetail(node *AlphaNode, index, total int
 		switch condType {
 		case "passthrough":
 			side, _ := condMap["side"].(string)
-			fmt.Printf("         │ Type: PASSTHROUGH\n")
+			fmt.Fprintf(nd.out, "         │ Type: PASSTHROUGH\n")
 			if side != "" {
-				fmt.Printf("         │ Side: %s\n", side)
+				fmt.Fprintf(nd.out, "         │ Side: %s\n", side)
 			}
-			fmt.Printf("         │ Opération: Propagation sans filtre\n")
+			fmt.Fprintf(nd.out, "         │ Opération: Propagation sans filtre\n")
 
 		case "comparison":
 			operator, _ := condMap["operator"].(string)
 			left := nd.formatExpression(condMap["left"])
 			right := nd.formatExpression(condMap["right"])
-			fmt.Printf("         │ Type: COMPARISON\n")
-			fmt.Printf("         │ Opérateur: %s\n", nd.symbolizeOperator(operator))
-			fmt.Printf("         │ Expression: %s %s %s\n", left, nd.symbolizeOperator(operator), right)
+			fmt.Fprintf(nd.out, "         │ Type: COMPARISON\n")
+			fmt.Fprintf(nd.out, "         │ Opérateur: %s\n", nd.symbolizeOperator(operator))
+			fmt.Fprintf(nd.out, "         │ Expression: %s %s %s\n", left, nd.symbolizeOperator(operator), right)
 
 		case "binaryOp":
 			operator, _ := condMap["operator"].(string)
 			left := nd.formatExpression(condMap["left"])
 			right := nd.formatExpression(condMap["right"])
-			fmt.Printf("         │ Type: BINARY OPERATION\n")
-			fmt.Printf("         │ Opérateur: %s\n", nd.symbolizeOperator(operator))
-			fmt.Printf("         │ Calcul: %s %s %s\n", left, nd.symbolizeOperator(operator), right)
+			fmt.Fprintf(nd.out, "         │ Type: BINARY OPERATION\n")
+			fmt.Fprintf(nd.out, "         │ Opérateur: %s\n", nd.symbolizeOperator(operator))
+			fmt.Fprintf(nd.out, "         │ Calcul: %s %s %s\n", left, nd.symbolizeOperator(operator), right)
 
 		case "tempResult":
 			stepName, _ := condMap["step_name"].(string)
 			stepIdx, _ := condMap["step_idx"].(int)
-			fmt.Printf("         │ Type: TEMP RESULT\n")
-			fmt.Printf("         │ Step: %s (étape %d)\n", stepName, stepIdx)
-			fmt.Printf("         │ Opération: Stockage résultat intermédiaire\n")
+			fmt.Fprintf(nd.out, "         │ Type: TEMP RESULT\n")
+			fmt.Fprintf(nd.out, "         │ Step: %s (étape %d)\n", stepName, stepIdx)
+			fmt.Fprintf(nd.out, "         │ Opération: Stockage résultat intermédiaire\n")
 
 		default:
-			fmt.Printf("         │ Type: %s\n", condType)
+			fmt.Fprintf(nd.out, "         │ Type: %s\n", condType)
 		}
 
 		// Note: Les informations de partage sont affichées dans le résumé
 	}
 
-	fmt.Printf("         │ Enfants: %d\n", len(node.Children))
+	fmt.Fprintf(nd.out, "         │ Enfants: %d\n", len(node.Children))
 }
 
 func (nd *NetworkDiagram) printPassthroughNodes() {
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 3️⃣  PASSTHROUGH NODES (Préparation pour jointure)                                                    │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 3️⃣  PASSTHROUGH NODES (Préparation pour jointure)                                                    │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	if len(nd.network.PassthroughRegistry) == 0 {
-		fmt.Println("   (Aucun passthrough node)")
-		fmt.Println()
+		fmt.Fprintln(nd.out, "   (Aucun passthrough node)")
+		fmt.Fprintln(nd.out)
 		return
 	}
 
@@ -201,34 +218,34 @@ func (nd *NetworkDiagram) printPassthroughNodes() {
 	sort.Strings(leftNodes)
 	sort.Strings(rightNodes)
 
-	fmt.Println("   LEFT Side (tokens pour jointure gauche):")
+	fmt.Fprintln(nd.out, "   LEFT Side (tokens pour jointure gauche):")
 	for _, key := range leftNodes {
 		node := nd.network.PassthroughRegistry[key]
-		fmt.Printf("      [⇒] %s\n", node.ID)
-		fmt.Printf("          │ Rôle: Passthrough LEFT\n")
-		fmt.Printf("          │ Enfants: %d\n", len(node.Children))
-		fmt.Println()
+		fmt.Fprintf(nd.out, "      [⇒] %s\n", node.ID)
+		fmt.Fprintf(nd.out, "          │ Rôle: Passthrough LEFT\n")
+		fmt.Fprintf(nd.out, "          │ Enfants: %d\n", len(node.Children))
+		fmt.Fprintln(nd.out)
 	}
 
-	fmt.Println("   RIGHT Side (tokens pour jointure droite):")
+	fmt.Fprintln(nd.out, "   RIGHT Side (tokens pour jointure droite):")
 	for _, key := range rightNodes {
 		node := nd.network.PassthroughRegistry[key]
-		fmt.Printf("      [⇒] %s\n", node.ID)
-		fmt.Printf("          │ Rôle: Passthrough RIGHT\n")
-		fmt.Printf("          │ Enfants: %d\n", len(node.Children))
-		fmt.Println()
+		fmt.Fprintf(nd.out, "      [⇒] %s\n", node.ID)
+		fmt.Fprintf(nd.out, "          │ Rôle: Passthrough RIGHT\n")
+		fmt.Fprintf(nd.out, "          │ Enfants: %d\n", len(node.Children))
+		fmt.Fprintln(nd.out)
 	}
 }
 
 func (nd *NetworkDiagram) printJoinNodes() {
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 4️⃣  JOIN NODES (Jointures Beta)                                                                      │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 4️⃣  JOIN NODES (Jointures Beta)                                                                      │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	if len(nd.network.BetaNodes) == 0 {
-		fmt.Println("   (Aucun join node)")
-		fmt.Println()
+		fmt.Fprintln(nd.out, "   (Aucun join node)")
+		fmt.Fprintln(nd.out)
 		return
 	}
 
@@ -256,16 +273,16 @@ func (nd *NetworkDiagram) printJoinNodes() {
 			symbol = "└─"
 		}
 
-		fmt.Printf("   %s [⋈] %s\n", symbol, joinNode.ID)
-		fmt.Printf("      │ Type: JOIN NODE\n")
-		fmt.Printf("      │ Variables LEFT: %v\n", joinNode.LeftVariables)
-		fmt.Printf("      │ Variables RIGHT: %v\n", joinNode.RightVariables)
+		fmt.Fprintf(nd.out, "   %s [⋈] %s\n", symbol, joinNode.ID)
+		fmt.Fprintf(nd.out, "      │ Type: JOIN NODE\n")
+		fmt.Fprintf(nd.out, "      │ Variables LEFT: %v\n", joinNode.LeftVariables)
+		fmt.Fprintf(nd.out, "      │ Variables RIGHT: %v\n", joinNode.RightVariables)
 
 		// Afficher les JoinConditions
 		if len(joinNode.JoinConditions) > 0 {
-			fmt.Printf("      │ Conditions de jointure:\n")
+			fmt.Fprintf(nd.out, "      │ Conditions de jointure:\n")
 			for _, jc := range joinNode.JoinConditions {
-				fmt.Printf("      │   • %s.%s %s %s.%s\n",
+				fmt.Fprintf(nd.out, "      │   • %s.%s %s %s.%s\n",
 					jc.LeftVar, jc.LeftField,
 					nd.symbolizeOperator(jc.Operator),
 					jc.RightVar, jc.RightField)
@@ -275,16 +292,16 @@ func (nd *NetworkDiagram) printJoinNodes() {
 		// Vérifier si partagé
 		rulesUsing := nd.findRulesUsingJoinNode(id)
 		if len(rulesUsing) > 1 {
-			fmt.Printf("      │ Partagé par: %d règles ✨ SHARED\n", len(rulesUsing))
+			fmt.Fprintf(nd.out, "      │ Partagé par: %d règles ✨ SHARED\n", len(rulesUsing))
 			for _, rule := range rulesUsing {
-				fmt.Printf("      │   - %s\n", rule)
+				fmt.Fprintf(nd.out, "      │   - %s\n", rule)
 			}
 		} else if len(rulesUsing) == 1 {
-			fmt.Printf("      │ Utilisé par: %s\n", rulesUsing[0])
+			fmt.Fprintf(nd.out, "      │ Utilisé par: %s\n", rulesUsing[0])
 		}
 
-		fmt.Printf("      │ Enfants: %d\n", len(joinNode.Children))
-		fmt.Println()
+		fmt.Fprintf(nd.out, "      │ Enfants: %d\n", len(joinNode.Children))
+		fmt.Fprintln(nd.out)
 	}
 }
 
@@ -311,28 +328,28 @@ func (nd *NetworkDiagram) printRouterNodes() {
 		return
 	}
 
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 5️⃣  ROUTER NODES (Routage des tokens vers les règles)                                                │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 5️⃣  ROUTER NODES (Routage des tokens vers les règles)                                                │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	for _, router := range routers {
-		fmt.Printf("   [🔀] %s\n", router.ID)
-		fmt.Printf("       │ Type: RULE ROUTER\n")
-		fmt.Printf("       │ Pour la règle: %s\n", router.RuleID)
-		fmt.Printf("       │ Depuis JoinNode: %s\n", router.JoinNodeID)
+		fmt.Fprintf(nd.out, "   [🔀] %s\n", router.ID)
+		fmt.Fprintf(nd.out, "       │ Type: RULE ROUTER\n")
+		fmt.Fprintf(nd.out, "       │ Pour la règle: %s\n", router.RuleID)
+		fmt.Fprintf(nd.out, "       │ Depuis JoinNode: %s\n", router.JoinNodeID)
 		if router.TerminalNode != nil {
-			fmt.Printf("       │ Vers TerminalNode: %s\n", router.TerminalNode.ID)
+			fmt.Fprintf(nd.out, "       │ Vers TerminalNode: %s\n", router.TerminalNode.ID)
 		}
-		fmt.Println()
+		fmt.Fprintln(nd.out)
 	}
 }
 
 func (nd *NetworkDiagram) printTerminalNodes() {
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 6️⃣  TERMINAL NODES (Actions)                                                                         │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 6️⃣  TERMINAL NODES (Actions)                                                                         │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	terminalIDs := make([]string, 0, len(nd.network.TerminalNodes))
 	for id := range nd.network.TerminalNodes {
@@ -347,18 +364,18 @@ func (nd *NetworkDiagram) printTerminalNodes() {
 			symbol = "└─"
 		}
 
-		fmt.Printf("   %s [⚡] %s\n", symbol, terminal.ID)
+		fmt.Fprintf(nd.out, "   %s [⚡] %s\n", symbol, terminal.ID)
 		if terminal.Action != nil {
-			fmt.Printf("      │ Action: %s\n", terminal.Action.Type)
+			fmt.Fprintf(nd.out, "      │ Action: %s\n", terminal.Action.Type)
 		}
-		fmt.Printf("      │ Tokens en mémoire: %d\n", len(terminal.Memory.Tokens))
-		fmt.Println()
+		fmt.Fprintf(nd.out, "      │ Tokens en mémoire: %d\n", len(terminal.Memory.Tokens))
+		fmt.Fprintln(nd.out)
 	}
 }
 
 func (nd *NetworkDiagram) printFlowDiagram() {
 	printDiagramHeader("7️⃣  DIAGRAMME DE FLUX (Architecture complète)", diagramWidth)
-	fmt.Println()
+	fmt.Fprintln(nd.out)
 
 	printRulesExpression()
 	printArchitectureDiagram()
@@ -367,10 +384,10 @@ func (nd *NetworkDiagram) printFlowDiagram() {
 }
 
 func (nd *NetworkDiagram) printSummary() {
-	fmt.Println("┌" + strings.Repeat("─", 118) + "┐")
-	fmt.Println("│ 📈 RÉSUMÉ DU RÉSEAU                                                                                  │")
-	fmt.Println("└" + strings.Repeat("─", 118) + "┘")
-	fmt.Println()
+	fmt.Fprintln(nd.out, "┌"+strings.Repeat("─", 118)+"┐")
+	fmt.Fprintln(nd.out, "│ 📈 RÉSUMÉ DU RÉSEAU                                                                                  │")
+	fmt.Fprintln(nd.out, "└"+strings.Repeat("─", 118)+"┘")
+	fmt.Fprintln(nd.out)
 
 	uniqueJoins := make(map[string]bool)
 	for _, node := range nd.network.BetaNodes {
@@ -379,12 +396,12 @@ func (nd *NetworkDiagram) printSummary() {
 		}
 	}
 
-	fmt.Printf("   Type Nodes:        %3d (routage par type)\n", len(nd.network.TypeNodes))
-	fmt.Printf("   Alpha Nodes:       %3d (filtres et calculs atomiques)\n", len(nd.network.AlphaNodes))
-	fmt.Printf("   Passthrough Nodes: %3d (préparation jointure)\n", len(nd.network.PassthroughRegistry))
-	fmt.Printf("   Join Nodes:        %3d (jointures beta)\n", len(uniqueJoins))
-	fmt.Printf("   Terminal Nodes:    %3d (actions)\n", len(nd.network.TerminalNodes))
-	fmt.Println()
+	fmt.Fprintf(nd.out, "   Type Nodes:        %3d (routage par type)\n", len(nd.network.TypeNodes))
+	fmt.Fprintf(nd.out, "   Alpha Nodes:       %3d (filtres et calculs atomiques)\n", len(nd.network.AlphaNodes))
+	fmt.Fprintf(nd.out, "   Passthrough Nodes: %3d (préparation jointure)\n", len(nd.network.PassthroughRegistry))
+	fmt.Fprintf(nd.out, "   Join Nodes:        %3d (jointures beta)\n", len(uniqueJoins))
+	fmt.Fprintf(nd.out, "   Terminal Nodes:    %3d (actions)\n", len(nd.network.TerminalNodes))
+	fmt.Fprintln(nd.out)
 
 	// Calculer les statistiques de partage alpha
 	sharedAlphaCount := 0
@@ -403,10 +420,10 @@ func (nd *NetworkDiagram) printSummary() {
 		}
 	}
 
-	fmt.Printf("   📊 Statistiques de partage:\n")
-	fmt.Printf("      • AlphaNodes partagés:  %d / %d\n", sharedAlphaCount, len(nd.network.AlphaNodes))
-	fmt.Printf("      • JoinNodes partagés:   %d / %d\n", sharedJoinCount, len(uniqueJoins))
-	fmt.Println()
+	fmt.Fprintf(nd.out, "   📊 Statistiques de partage:\n")
+	fmt.Fprintf(nd.out, "      • AlphaNodes partagés:  %d / %d\n", sharedAlphaCount, len(nd.network.AlphaNodes))
+	fmt.Fprintf(nd.out, "      • JoinNodes partagés:   %d / %d\n", sharedJoinCount, len(uniqueJoins))
+	fmt.Fprintln(nd.out)
 }
 
 // Helper functions
@@ -223,15 +223,18 @@ func (pe *PrometheusExporter) formatLabels(labels map[string]string) string {
 	return result
 }
 
-// ServeHTTP démarre un serveur HTTP pour exposer les métriques
+// ServeHTTP démarre un serveur HTTP pour exposer les métriques.
+// Les routes sont enregistrées sur un mux dédié : plusieurs exporteurs peuvent
+// coexister dans un même processus sans toucher au mux global.
 func (pe *PrometheusExporter) ServeHTTP(addr string) error {
-	http.Handle("/metrics", pe.Handler())
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", pe.Handler())
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, "OK")
 	})
 
-	return http.ListenAndServe(addr, nil)
+	return http.ListenAndServe(addr, mux)
 }
 
 // StartAutoUpdate démarre une goroutine qui met à jour automatiquement les métriques
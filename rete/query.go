@@ -0,0 +1,339 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// QueryRuleIDPrefix préfixe les identifiants de règles internes générées pour les requêtes.
+// Le caractère ':' ne peut pas apparaître dans un identifiant TSD, ce qui évite
+// toute collision avec les règles déclarées par l'utilisateur.
+const QueryRuleIDPrefix = "query:"
+
+// QueryRow représente une ligne de résultat d'une requête : une liaison variable → fait.
+type QueryRow struct {
+	Bindings map[string]*Fact
+}
+
+// Get retourne le fait lié à une variable (nil si absente)
+func (r QueryRow) Get(variable string) *Fact {
+	return r.Bindings[variable]
+}
+
+// Value retourne la valeur d'un champ du fait lié à une variable
+func (r QueryRow) Value(variable, field string) (interface{}, bool) {
+	fact := r.Bindings[variable]
+	if fact == nil {
+		return nil, false
+	}
+	return fact.GetField(field)
+}
+
+// QueryRowHandler est appelé lorsqu'une ligne entre ou sort du résultat d'une requête vivante
+type QueryRowHandler func(row QueryRow)
+
+// Query est une requête nommée compilée dans le réseau RETE.
+//
+// La partie des contraintes qui ne dépend pas des paramètres est compilée en
+// nœuds alpha/beta partagés avec les règles ; le terminal de la requête conserve
+// les tokens au lieu d'exécuter une action. Les contraintes qui référencent un
+// paramètre sont évaluées à l'exécution, une fois les arguments connus.
+type Query struct {
+	Name       string
+	Parameters []constraint.Parameter
+	Variables  []string
+
+	deferred interface{}
+	terminal *TerminalNode
+
+	mutex       sync.RWMutex
+	nextWatchID int
+	watches     map[int]*QueryWatch
+}
+
+// QueryWatch est un abonnement à une requête vivante.
+// Les callbacks sont appelés de manière synchrone pendant la propagation :
+// ils ne doivent pas soumettre de faits au réseau.
+type QueryWatch struct {
+	id        int
+	query     *Query
+	filter    interface{}
+	onAdded   QueryRowHandler
+	onRemoved QueryRowHandler
+}
+
+// newQuery crée une requête attachée à son terminal
+func newQuery(def constraint.QueryDefinition, deferred interface{}, terminal *TerminalNode) *Query {
+	variables := make([]string, 0)
+	for _, variable := range def.Variables() {
+		variables = append(variables, variable.Name)
+	}
+
+	q := &Query{
+		Name:       def.Name,
+		Parameters: def.Parameters,
+		Variables:  variables,
+		deferred:   deferred,
+		terminal:   terminal,
+		watches:    make(map[int]*QueryWatch),
+	}
+	terminal.query = q
+	return q
+}
+
+// BindArguments vérifie l'arité et le type des arguments et les associe aux paramètres
+func (q *Query) BindArguments(args []interface{}) (map[string]interface{}, error) {
+	if len(args) != len(q.Parameters) {
+		return nil, fmt.Errorf("requête '%s': %d argument(s) attendu(s), %d fourni(s)",
+			q.Name, len(q.Parameters), len(args))
+	}
+
+	values := make(map[string]interface{}, len(args))
+	for i, param := range q.Parameters {
+		value, err := constraint.CoerceQueryArgument(param, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("requête '%s': %w", q.Name, err)
+		}
+		values[param.Name] = value
+	}
+	return values, nil
+}
+
+// Execute évalue la requête avec les arguments donnés et retourne les lignes correspondantes,
+// triées de manière déterministe.
+func (q *Query) Execute(args ...interface{}) ([]QueryRow, error) {
+	filter, err := q.buildFilter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := q.terminal.snapshotQueryTokens()
+	rows := make([]QueryRow, 0, len(tokens))
+	for _, token := range tokens {
+		row := q.rowFromToken(token)
+		matched, err := q.matches(filter, row)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// Watch abonne des callbacks aux changements du résultat de la requête pour les arguments donnés.
+// Les lignes déjà présentes ne sont pas notifiées : utiliser Execute pour l'état initial.
+func (q *Query) Watch(args []interface{}, onAdded, onRemoved QueryRowHandler) (*QueryWatch, error) {
+	filter, err := q.buildFilter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.nextWatchID++
+	watch := &QueryWatch{
+		id:        q.nextWatchID,
+		query:     q,
+		filter:    filter,
+		onAdded:   onAdded,
+		onRemoved: onRemoved,
+	}
+	q.watches[watch.id] = watch
+	return watch, nil
+}
+
+// Close désabonne la requête vivante. Appels multiples sans effet.
+func (w *QueryWatch) Close() {
+	w.query.mutex.Lock()
+	defer w.query.mutex.Unlock()
+	delete(w.query.watches, w.id)
+}
+
+// WatchCount retourne le nombre d'abonnements actifs
+func (q *Query) WatchCount() int {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return len(q.watches)
+}
+
+// buildFilter lie les arguments et substitue les paramètres dans les contraintes différées
+func (q *Query) buildFilter(args []interface{}) (interface{}, error) {
+	values, err := q.BindArguments(args)
+	if err != nil {
+		return nil, err
+	}
+	if q.deferred == nil {
+		return nil, nil
+	}
+	return constraint.SubstituteQueryParameters(q.deferred, values), nil
+}
+
+// matches évalue le filtre différé sur une ligne
+func (q *Query) matches(filter interface{}, row QueryRow) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	evaluator := NewAlphaConditionEvaluator()
+	for variable, fact := range row.Bindings {
+		evaluator.variableBindings[variable] = fact
+	}
+	result, err := evaluator.evaluateExpression(filter)
+	if err != nil {
+		return false, fmt.Errorf("requête '%s': erreur évaluation: %w", q.Name, err)
+	}
+	return result, nil
+}
+
+// rowFromToken construit une ligne de résultat à partir d'un token terminal
+func (q *Query) rowFromToken(token *Token) QueryRow {
+	bindings := make(map[string]*Fact, len(q.Variables))
+	if token.Bindings != nil {
+		for variable, fact := range token.Bindings.ToMap() {
+			if fact != nil {
+				bindings[variable] = fact
+			}
+		}
+	}
+	// Repli sur l'ordre des faits du token si les liaisons sont absentes
+	if len(bindings) == 0 {
+		for i, fact := range token.Facts {
+			if i < len(q.Variables) && fact != nil {
+				bindings[q.Variables[i]] = fact
+			}
+		}
+	}
+	return QueryRow{Bindings: bindings}
+}
+
+// notify transmet l'ajout ou le retrait d'un token aux abonnements concernés
+func (q *Query) notify(token *Token, added bool) {
+	q.mutex.RLock()
+	if len(q.watches) == 0 {
+		q.mutex.RUnlock()
+		return
+	}
+	watches := make([]*QueryWatch, 0, len(q.watches))
+	for _, watch := range q.watches {
+		watches = append(watches, watch)
+	}
+	q.mutex.RUnlock()
+
+	sort.Slice(watches, func(i, j int) bool { return watches[i].id < watches[j].id })
+
+	row := q.rowFromToken(token)
+	for _, watch := range watches {
+		matched, err := q.matches(watch.filter, row)
+		if err != nil || !matched {
+			continue
+		}
+		if added && watch.onAdded != nil {
+			watch.onAdded(row)
+		} else if !added && watch.onRemoved != nil {
+			watch.onRemoved(row)
+		}
+	}
+}
+
+// queryTokenKey calcule une clé stable pour un token à partir des faits qu'il lie.
+// Une même combinaison de faits réactivée remplace le token existant au lieu de le dupliquer.
+func queryTokenKey(token *Token) string {
+	parts := make([]string, 0, len(token.Facts))
+	if token.Bindings != nil {
+		for variable, fact := range token.Bindings.ToMap() {
+			if fact != nil {
+				parts = append(parts, variable+"="+fact.GetInternalID())
+			}
+		}
+	}
+	if len(parts) == 0 {
+		for i, fact := range token.Facts {
+			if fact != nil {
+				parts = append(parts, fmt.Sprintf("%d=%s", i, fact.GetInternalID()))
+			}
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "|")
+}
+
+// splitQueryConstraints sépare les contraintes d'une requête en une partie compilable
+// dans le réseau (sans paramètre) et une partie différée (référençant des paramètres).
+// Seules les conjonctions de premier niveau sont séparées ; une disjonction qui
+// référence un paramètre est entièrement différée.
+func splitQueryConstraints(constraints interface{}, params []constraint.Parameter) (compiled, deferred interface{}) {
+	if constraints == nil {
+		return nil, nil
+	}
+	if !constraint.ReferencesQueryParameters(constraints, params) {
+		return constraints, nil
+	}
+
+	conjuncts, ok := topLevelConjuncts(constraints)
+	if !ok {
+		return nil, constraints
+	}
+
+	var compiledParts, deferredParts []interface{}
+	for _, conjunct := range conjuncts {
+		if constraint.ReferencesQueryParameters(conjunct, params) {
+			deferredParts = append(deferredParts, conjunct)
+		} else {
+			compiledParts = append(compiledParts, conjunct)
+		}
+	}
+	return joinConjuncts(compiledParts), joinConjuncts(deferredParts)
+}
+
+// topLevelConjuncts retourne les membres d'une chaîne de AND (false si un OR est présent)
+func topLevelConjuncts(constraints interface{}) ([]interface{}, bool) {
+	expr, ok := constraints.(map[string]interface{})
+	if !ok || expr["type"] != "logicalExpr" {
+		return []interface{}{constraints}, true
+	}
+
+	conjuncts := []interface{}{expr["left"]}
+	operations, _ := expr["operations"].([]interface{})
+	for _, op := range operations {
+		opMap, ok := op.(map[string]interface{})
+		if !ok || !strings.EqualFold(fmt.Sprint(opMap["op"]), "AND") {
+			return nil, false
+		}
+		conjuncts = append(conjuncts, opMap["right"])
+	}
+	return conjuncts, true
+}
+
+// joinConjuncts reconstruit une chaîne de AND à partir de ses membres
+func joinConjuncts(parts []interface{}) interface{} {
+	switch len(parts) {
+	case 0:
+		return nil
+	case 1:
+		return parts[0]
+	}
+
+	operations := make([]interface{}, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		operations = append(operations, map[string]interface{}{
+			"op":    "AND",
+			"right": part,
+		})
+	}
+	return map[string]interface{}{
+		"type":       "logicalExpr",
+		"left":       parts[0],
+		"operations": operations,
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// ingestQueryProgram ingère un programme TSD dans un réseau (nouveau si nil)
+func ingestQueryProgram(t *testing.T, network *ReteNetwork, storage Storage, program string) *ReteNetwork {
+	t.Helper()
+	tsdFile := filepath.Join(t.TempDir(), "query.tsd")
+	if err := os.WriteFile(tsdFile, []byte(program), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+
+	pipeline := NewConstraintPipeline()
+	result, _, err := pipeline.IngestFile(tsdFile, network, storage)
+	if err != nil {
+		t.Fatalf("❌ Erreur d'ingestion: %v", err)
+	}
+	return result
+}
+
+const queryTestProgram = `type Person(#id: string, age: number, city: string)
+type Order(#ref: string, owner: string, total: number)
+
+query adults(min: number) : {p: Person} / p.age >= min
+query everyone() : {p: Person}
+query bigSpenders(city: string, threshold: number) : {p: Person, o: Order} / o.owner == p.id AND p.city == city AND o.total > threshold
+
+Person(id: "alice", age: 30, city: "Paris")
+Person(id: "bob", age: 12, city: "Lyon")
+Person(id: "carol", age: 45, city: "Paris")
+Order(ref: "o1", owner: "alice", total: 250)
+Order(ref: "o2", owner: "carol", total: 50)
+`
+
+func TestQuery_Execute(t *testing.T) {
+	network := ingestQueryProgram(t, nil, NewMemoryStorage(), queryTestProgram)
+
+	names := network.QueryNames()
+	if len(names) != 3 || names[0] != "adults" || names[1] != "bigSpenders" || names[2] != "everyone" {
+		t.Fatalf("QueryNames() = %v", names)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+		want  int
+	}{
+		{"parameter filter", "adults", []interface{}{18}, 2},
+		{"parameter filter strict", "adults", []interface{}{40.0}, 1},
+		{"no constraints", "everyone", nil, 3},
+		{"join with parameters", "bigSpenders", []interface{}{"Paris", 100}, 1},
+		{"join no match", "bigSpenders", []interface{}{"Lyon", 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, exists := network.GetQuery(tt.query)
+			if !exists {
+				t.Fatalf("query %s not found", tt.query)
+			}
+			rows, err := query.Execute(tt.args...)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(rows) != tt.want {
+				t.Errorf("Execute() returned %d rows, want %d", len(rows), tt.want)
+			}
+		})
+	}
+
+	query, _ := network.GetQuery("bigSpenders")
+	rows, _ := query.Execute("Paris", 100)
+	if len(rows) == 1 {
+		if ref, _ := rows[0].Value("o", "ref"); ref != "o1" {
+			t.Errorf("o.ref = %v, want o1", ref)
+		}
+		if rows[0].Get("p") == nil {
+			t.Error("variable p should be bound")
+		}
+	}
+}
+
+func TestQuery_ExecuteArgumentErrors(t *testing.T) {
+	network := ingestQueryProgram(t, nil, NewMemoryStorage(), queryTestProgram)
+	query, _ := network.GetQuery("adults")
+
+	if _, err := query.Execute(); err == nil {
+		t.Error("Execute() without arguments should fail")
+	}
+	if _, err := query.Execute(1, 2); err == nil {
+		t.Error("Execute() with too many arguments should fail")
+	}
+	if _, err := query.Execute("eighteen"); err == nil {
+		t.Error("Execute() with a string for a number parameter should fail")
+	}
+}
+
+func TestQuery_TerminalDoesNotExecuteActions(t *testing.T) {
+	network := ingestQueryProgram(t, nil, NewMemoryStorage(), queryTestProgram)
+
+	terminal, exists := network.TerminalNodes[QueryRuleIDPrefix+"everyone_terminal"]
+	if !exists {
+		t.Fatal("query terminal not found")
+	}
+	if !terminal.IsQuery() {
+		t.Error("IsQuery() should be true for a query terminal")
+	}
+	if got := len(terminal.snapshotQueryTokens()); got != 3 {
+		t.Errorf("stored tokens = %d, want 3", got)
+	}
+}
+
+func TestQuery_WatchAndRetract(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, nil, storage, queryTestProgram)
+	query, _ := network.GetQuery("adults")
+
+	var added, removed []string
+	watch, err := query.Watch([]interface{}{18},
+		func(row QueryRow) {
+			id, _ := row.Value("p", "id")
+			added = append(added, id.(string))
+		},
+		func(row QueryRow) {
+			id, _ := row.Value("p", "id")
+			removed = append(removed, id.(string))
+		})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Un mineur n'est pas notifié, un majeur l'est
+	ingestQueryProgram(t, network, storage, `Person(id: "dave", age: 10, city: "Nice")
+Person(id: "erin", age: 60, city: "Nice")
+`)
+	if len(added) != 1 || added[0] != "erin" {
+		t.Errorf("added = %v, want [erin]", added)
+	}
+
+	if err := network.RetractFact("Person~alice"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "alice" {
+		t.Errorf("removed = %v, want [alice]", removed)
+	}
+
+	rows, _ := query.Execute(18)
+	if len(rows) != 2 {
+		t.Errorf("Execute() after retract returned %d rows, want 2", len(rows))
+	}
+
+	watch.Close()
+	watch.Close()
+	if query.WatchCount() != 0 {
+		t.Errorf("WatchCount() = %d, want 0", query.WatchCount())
+	}
+}
+
+func TestQuery_DuplicateAcrossIngestions(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, nil, storage, queryTestProgram)
+
+	tsdFile := filepath.Join(t.TempDir(), "dup.tsd")
+	if err := os.WriteFile(tsdFile, []byte(`query everyone() : {p: Person}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := NewConstraintPipeline().IngestFile(tsdFile, network, storage); err == nil {
+		t.Error("redefining an existing query should fail")
+	}
+}
+
+func TestSplitQueryConstraints(t *testing.T) {
+	params := []constraint.Parameter{{Name: "min", Type: "number"}}
+	fixed := map[string]interface{}{
+		"type": "comparison", "operator": ">",
+		"left":  map[string]interface{}{"type": "fieldAccess", "object": "p", "field": "age"},
+		"right": map[string]interface{}{"type": "number", "value": 0.0},
+	}
+	param := map[string]interface{}{
+		"type": "comparison", "operator": ">=",
+		"left":  map[string]interface{}{"type": "fieldAccess", "object": "p", "field": "age"},
+		"right": map[string]interface{}{"type": "variable", "name": "min"},
+	}
+
+	compiled, deferred := splitQueryConstraints(fixed, params)
+	if compiled == nil || deferred != nil {
+		t.Errorf("constraint without parameter should be compiled")
+	}
+
+	and := joinConjuncts([]interface{}{fixed, param})
+	compiled, deferred = splitQueryConstraints(and, params)
+	if compiled == nil || deferred == nil {
+		t.Fatalf("AND should be split, got compiled=%v deferred=%v", compiled, deferred)
+	}
+	if compiled.(map[string]interface{})["operator"] != ">" {
+		t.Errorf("compiled = %v, want the parameter-free conjunct", compiled)
+	}
+
+	or := map[string]interface{}{
+		"type": "logicalExpr",
+		"left": fixed,
+		"operations": []interface{}{
+			map[string]interface{}{"op": "OR", "right": param},
+		},
+	}
+	compiled, deferred = splitQueryConstraints(or, params)
+	if compiled != nil || deferred == nil {
+		t.Errorf("OR referencing a parameter should be fully deferred")
+	}
+}
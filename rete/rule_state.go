@@ -0,0 +1,359 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/treivax/tsd/constraint"
+)
+
+// RuleState est l'état d'exécution d'une règle
+type RuleState string
+
+const (
+	// RuleStateActive : les actions de la règle sont exécutées (état par défaut)
+	RuleStateActive RuleState = "active"
+
+	// RuleStateDisabled : la règle est mise en sourdine. Ses correspondances
+	// sont conservées par le nœud terminal (et retirées avec les faits
+	// rétractés) puis rejouées lorsque la règle est réactivée.
+	RuleStateDisabled RuleState = "disabled"
+
+	// RuleStateDryRun : les actions ne sont pas exécutées, seules les
+	// activations sont enregistrées (voir DryRunActivations)
+	RuleStateDryRun RuleState = "dry-run"
+)
+
+// DefaultDryRunLogSize est le nombre d'activations à blanc conservées par réseau
+const DefaultDryRunLogSize = 1000
+
+// ParseRuleState convertit le nom d'un état de règle
+func ParseRuleState(name string) (RuleState, error) {
+	switch state := RuleState(strings.ToLower(strings.TrimSpace(name))); state {
+	case RuleStateActive, RuleStateDisabled, RuleStateDryRun:
+		return state, nil
+	default:
+		return "", fmt.Errorf("état de règle inconnu '%s' (attendu: %s, %s ou %s)",
+			name, RuleStateActive, RuleStateDisabled, RuleStateDryRun)
+	}
+}
+
+// RuleMetadata regroupe les annotations d'une règle (@version, @owner, @tags)
+type RuleMetadata struct {
+	Version string   `json:"version,omitempty"`
+	Owner   string   `json:"owner,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// RuleStatus décrit l'état courant d'une règle
+type RuleStatus struct {
+	Rule     string       `json:"rule"`
+	State    RuleState    `json:"state"`
+	Metadata RuleMetadata `json:"metadata"`
+	Pending  int          `json:"pending"`  // Correspondances conservées pendant la désactivation
+	DryRuns  int          `json:"dry_runs"` // Activations à blanc conservées
+}
+
+// DryRunRecord décrit une activation d'une règle en mode dry-run : les
+// actions qui auraient été exécutées et les faits liés à ses variables
+type DryRunRecord struct {
+	Sequence uint64            `json:"sequence"` // Numéro d'ordre de l'activation à blanc
+	Rule     string            `json:"rule"`     // Règle activée
+	Actions  []string          `json:"actions"`  // Actions qui auraient été exécutées
+	Bindings map[string]string `json:"bindings"` // Variable → identifiant interne du fait lié
+	Time     time.Time         `json:"time"`     // Heure de l'activation
+}
+
+// ruleStates conserve l'état et les métadonnées des règles d'un réseau.
+//
+// L'état est indexé par identifiant de règle : il survit à la recompilation
+// d'une règle (rechargement à chaud), une règle supprimée puis ajoutée à
+// nouveau retrouve son état.
+type ruleStates struct {
+	mutex    sync.Mutex
+	states   map[string]RuleState
+	metadata map[string]RuleMetadata
+	spans    map[string]*constraint.Span
+	dryRuns  []DryRunRecord
+	sequence uint64
+	size     int
+}
+
+// newRuleStates crée le registre des états de règles
+func newRuleStates() *ruleStates {
+	return &ruleStates{
+		states:   make(map[string]RuleState),
+		metadata: make(map[string]RuleMetadata),
+		spans:    make(map[string]*constraint.Span),
+		size:     DefaultDryRunLogSize,
+	}
+}
+
+// state retourne l'état d'une règle (active par défaut)
+func (rs *ruleStates) state(ruleID string) RuleState {
+	if rs == nil {
+		return RuleStateActive
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	if state, exists := rs.states[ruleID]; exists {
+		return state
+	}
+	return RuleStateActive
+}
+
+// recordDryRun enregistre une activation à blanc
+func (rs *ruleStates) recordDryRun(ruleID string, action *Action, token *Token) {
+	if rs == nil {
+		return
+	}
+	var actions []string
+	if action != nil {
+		for _, job := range action.GetJobs() {
+			actions = append(actions, job.Name)
+		}
+	}
+	bindings := tokenBindings(token)
+	if bindings == nil {
+		bindings = make(map[string]string)
+	}
+
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	rs.sequence++
+	rs.dryRuns = append(rs.dryRuns, DryRunRecord{
+		Sequence: rs.sequence,
+		Rule:     ruleID,
+		Actions:  actions,
+		Bindings: bindings,
+		Time:     time.Now(),
+	})
+	if len(rs.dryRuns) > rs.size {
+		rs.dryRuns = rs.dryRuns[len(rs.dryRuns)-rs.size:]
+	}
+}
+
+// SetRuleState change l'état d'une règle.
+//
+// Lorsqu'une règle désactivée change d'état, les correspondances conservées
+// pendant la désactivation sont rejouées selon le nouvel état : actions
+// exécutées (active) ou enregistrées (dry-run). Les requêtes n'ont pas d'état.
+func (rn *ReteNetwork) SetRuleState(ruleID string, state RuleState) error {
+	if _, err := ParseRuleState(string(state)); err != nil {
+		return err
+	}
+	terminal, err := rn.ruleTerminal(ruleID)
+	if err != nil {
+		return err
+	}
+
+	if rn.ruleStates == nil {
+		rn.ruleStates = newRuleStates()
+	}
+	rn.ruleStates.mutex.Lock()
+	previous, exists := rn.ruleStates.states[ruleID]
+	if !exists {
+		previous = RuleStateActive
+	}
+	if state == RuleStateActive {
+		delete(rn.ruleStates.states, ruleID)
+	} else {
+		rn.ruleStates.states[ruleID] = state
+	}
+	rn.ruleStates.mutex.Unlock()
+
+	if previous == state {
+		return nil
+	}
+	rn.logger.Info("🔀 Règle %s: %s → %s", ruleID, previous, state)
+	if previous != RuleStateDisabled {
+		return nil
+	}
+	return rn.replayHeldTokens(terminal)
+}
+
+// replayHeldTokens rejoue les correspondances conservées par le terminal d'une
+// règle désactivée, comme une seule opération pour les garde-fous de cascade
+func (rn *ReteNetwork) replayHeldTokens(terminal *TerminalNode) error {
+	held := terminal.takeHeldTokens()
+	if len(held) == 0 {
+		return nil
+	}
+	defer rn.beginCascade()()
+
+	var errs []error
+	for _, token := range held {
+		err := terminal.ActivateLeft(token)
+		if abortsPropagation(err) {
+			return err
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// RuleState retourne l'état d'une règle
+func (rn *ReteNetwork) RuleState(ruleID string) (RuleState, error) {
+	if _, err := rn.ruleTerminal(ruleID); err != nil {
+		return "", err
+	}
+	return rn.ruleStates.state(ruleID), nil
+}
+
+// RuleStatus retourne l'état, les métadonnées et les correspondances en
+// attente d'une règle
+func (rn *ReteNetwork) RuleStatus(ruleID string) (RuleStatus, error) {
+	terminal, err := rn.ruleTerminal(ruleID)
+	if err != nil {
+		return RuleStatus{}, err
+	}
+	return rn.ruleStatus(ruleID, terminal), nil
+}
+
+// RuleStatuses retourne l'état de toutes les règles du réseau, triées par identifiant
+func (rn *ReteNetwork) RuleStatuses() []RuleStatus {
+	statuses := make([]RuleStatus, 0, len(rn.TerminalNodes))
+	for _, terminal := range rn.TerminalNodes {
+		if terminal.IsQuery() {
+			continue
+		}
+		statuses = append(statuses, rn.ruleStatus(terminal.getRuleName(), terminal))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Rule < statuses[j].Rule })
+	return statuses
+}
+
+// DryRunActivations retourne les activations à blanc conservées d'une règle
+// (de toutes les règles si ruleID est vide), de la plus ancienne à la plus récente
+func (rn *ReteNetwork) DryRunActivations(ruleID string) []DryRunRecord {
+	if rn.ruleStates == nil {
+		return nil
+	}
+	rn.ruleStates.mutex.Lock()
+	defer rn.ruleStates.mutex.Unlock()
+	var records []DryRunRecord
+	for _, record := range rn.ruleStates.dryRuns {
+		if ruleID == "" || record.Rule == ruleID {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// RuleMetadata retourne les annotations d'une règle
+func (rn *ReteNetwork) RuleMetadata(ruleID string) RuleMetadata {
+	if rn.ruleStates == nil {
+		return RuleMetadata{}
+	}
+	rn.ruleStates.mutex.Lock()
+	defer rn.ruleStates.mutex.Unlock()
+	return rn.ruleStates.metadata[ruleID]
+}
+
+// ruleStatus construit l'état d'une règle à partir de son terminal
+func (rn *ReteNetwork) ruleStatus(ruleID string, terminal *TerminalNode) RuleStatus {
+	status := RuleStatus{
+		Rule:     ruleID,
+		State:    rn.ruleStates.state(ruleID),
+		Metadata: rn.RuleMetadata(ruleID),
+		Pending:  terminal.heldTokenCount(),
+	}
+	if rn.ruleStates != nil {
+		rn.ruleStates.mutex.Lock()
+		for _, record := range rn.ruleStates.dryRuns {
+			if record.Rule == ruleID {
+				status.DryRuns++
+			}
+		}
+		rn.ruleStates.mutex.Unlock()
+	}
+	return status
+}
+
+// ruleTerminal retrouve le terminal d'une règle (les requêtes sont refusées)
+func (rn *ReteNetwork) ruleTerminal(ruleID string) (*TerminalNode, error) {
+	terminal := rn.findRuleTerminal(ruleID)
+	if terminal == nil {
+		return nil, fmt.Errorf("règle '%s' introuvable", ruleID)
+	}
+	if terminal.IsQuery() {
+		return nil, fmt.Errorf("'%s' est une requête, pas une règle", ruleID)
+	}
+	return terminal, nil
+}
+
+// setRuleMetadata enregistre les annotations d'une règle compilée
+// (format AST : [{type: "annotation", name, args}])
+func (rn *ReteNetwork) setRuleMetadata(ruleID string, annotations interface{}) {
+	items, _ := annotations.([]interface{})
+	var metadata RuleMetadata
+	for _, item := range items {
+		annotation, _ := item.(map[string]interface{})
+		name, _ := annotation["name"].(string)
+		var args []string
+		switch values := annotation["args"].(type) {
+		case []interface{}:
+			for _, value := range values {
+				args = append(args, fmt.Sprint(value))
+			}
+		case []string:
+			args = values
+		}
+		switch name {
+		case "version":
+			if len(args) > 0 {
+				metadata.Version = args[0]
+			}
+		case "owner":
+			if len(args) > 0 {
+				metadata.Owner = args[0]
+			}
+		case "tags":
+			metadata.Tags = append(metadata.Tags, args...)
+		}
+	}
+
+	if rn.ruleStates == nil {
+		rn.ruleStates = newRuleStates()
+	}
+	rn.ruleStates.mutex.Lock()
+	defer rn.ruleStates.mutex.Unlock()
+	if len(items) == 0 {
+		delete(rn.ruleStates.metadata, ruleID)
+		return
+	}
+	rn.ruleStates.metadata[ruleID] = metadata
+}
+
+// RuleSpan retourne la position de la définition d'une règle dans le source
+// (nil si inconnue, pour une règle ajoutée sans AST positionné par exemple)
+func (rn *ReteNetwork) RuleSpan(ruleID string) *constraint.Span {
+	if rn.ruleStates == nil {
+		return nil
+	}
+	rn.ruleStates.mutex.Lock()
+	defer rn.ruleStates.mutex.Unlock()
+	return rn.ruleStates.spans[ruleID]
+}
+
+// setRuleSpan enregistre la position de la définition d'une règle compilée
+func (rn *ReteNetwork) setRuleSpan(ruleID string, span *constraint.Span) {
+	if rn.ruleStates == nil {
+		rn.ruleStates = newRuleStates()
+	}
+	rn.ruleStates.mutex.Lock()
+	defer rn.ruleStates.mutex.Unlock()
+	if span == nil {
+		delete(rn.ruleStates.spans, ruleID)
+		return
+	}
+	rn.ruleStates.spans[ruleID] = span
+}
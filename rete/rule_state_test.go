@@ -0,0 +1,197 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const ruleStateProgram = `type Order(#id: string, total: number)
+action notify(id: string)
+
+@version("3") @owner("pricing") @tags("promo", "prix")
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+
+rule any : {o: Order} / ==> notify(o.id)
+
+query orders() : {o: Order}
+`
+
+func order(id string, total float64) *Fact {
+	return &Fact{ID: "Order~" + id, Type: "Order", Fields: map[string]interface{}{"id": id, "total": total}}
+}
+
+// newRuleStateNetwork compile ruleStateProgram et compte les actions exécutées
+func newRuleStateNetwork(t *testing.T) (*ReteNetwork, ruleCounter) {
+	t.Helper()
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, ruleStateProgram)
+	counter := ruleCounter{}
+	network.SetActionObserver(counter)
+	return network, counter
+}
+
+func TestRuleState_DisabledHoldsMatches(t *testing.T) {
+	network, counter := newRuleStateNetwork(t)
+	if err := network.SetRuleState("big", RuleStateDisabled); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+
+	for _, fact := range []*Fact{order("o1", 150), order("o2", 200), order("o3", 10)} {
+		if err := network.SubmitFact(fact); err != nil {
+			t.Fatalf("SubmitFact() error = %v", err)
+		}
+	}
+	if counter["big"] != 0 || counter["any"] != 3 {
+		t.Fatalf("activations = %v, want big muted and any fired 3 times", counter)
+	}
+	status, err := network.RuleStatus("big")
+	if err != nil {
+		t.Fatalf("RuleStatus() error = %v", err)
+	}
+	if status.State != RuleStateDisabled || status.Pending != 2 {
+		t.Errorf("status = %+v, want disabled with 2 pending matches", status)
+	}
+
+	// Une correspondance rétractée pendant la désactivation n'est pas rejouée
+	if err := network.RetractFact("Order~o1"); err != nil {
+		t.Fatalf("RetractFact() error = %v", err)
+	}
+	if err := network.SetRuleState("big", RuleStateActive); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if counter["big"] != 1 {
+		t.Errorf("big fired %d times after re-enabling, want 1", counter["big"])
+	}
+	if status, _ := network.RuleStatus("big"); status.State != RuleStateActive || status.Pending != 0 {
+		t.Errorf("status = %+v, want active without pending matches", status)
+	}
+
+	if err := network.SubmitFact(order("o4", 500)); err != nil {
+		t.Fatalf("SubmitFact() error = %v", err)
+	}
+	if counter["big"] != 2 {
+		t.Errorf("big fired %d times, want 2", counter["big"])
+	}
+}
+
+func TestRuleState_DryRun(t *testing.T) {
+	network, counter := newRuleStateNetwork(t)
+	if err := network.SetRuleState("big", RuleStateDryRun); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if err := network.SubmitFact(order("o1", 150)); err != nil {
+		t.Fatalf("SubmitFact() error = %v", err)
+	}
+
+	if counter["big"] != 0 {
+		t.Errorf("big executed %d actions in dry-run", counter["big"])
+	}
+	records := network.DryRunActivations("big")
+	if len(records) != 1 {
+		t.Fatalf("DryRunActivations() = %v, want 1 record", records)
+	}
+	if !reflect.DeepEqual(records[0].Actions, []string{"notify"}) || records[0].Bindings["o"] != "Order~o1" {
+		t.Errorf("record = %+v", records[0])
+	}
+	if len(network.DryRunActivations("any")) != 0 || len(network.DryRunActivations("")) != 1 {
+		t.Errorf("dry-run records should only concern big")
+	}
+	if status, _ := network.RuleStatus("big"); status.DryRuns != 1 {
+		t.Errorf("status = %+v, want 1 dry run", status)
+	}
+}
+
+func TestRuleState_DisabledReplayedInDryRun(t *testing.T) {
+	network, counter := newRuleStateNetwork(t)
+	_ = network.SetRuleState("big", RuleStateDisabled)
+	_ = network.SubmitFact(order("o1", 150))
+
+	if err := network.SetRuleState("big", RuleStateDryRun); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if counter["big"] != 0 || len(network.DryRunActivations("big")) != 1 {
+		t.Errorf("held match should be recorded in dry-run, activations = %v", counter)
+	}
+}
+
+func TestRuleState_Errors(t *testing.T) {
+	network, _ := newRuleStateNetwork(t)
+	if err := network.SetRuleState("unknown", RuleStateDisabled); err == nil {
+		t.Errorf("SetRuleState(unknown) should fail")
+	}
+	if err := network.SetRuleState(QueryRuleIDPrefix+"orders", RuleStateDisabled); err == nil {
+		t.Errorf("SetRuleState(query) should fail")
+	}
+	if err := network.SetRuleState("big", RuleState("paused")); err == nil {
+		t.Errorf("SetRuleState(paused) should fail")
+	}
+	if _, err := ParseRuleState("Dry-Run"); err != nil {
+		t.Errorf("ParseRuleState(Dry-Run) error = %v", err)
+	}
+}
+
+func TestRuleStatuses_Metadata(t *testing.T) {
+	network, _ := newRuleStateNetwork(t)
+	statuses := network.RuleStatuses()
+	if len(statuses) != 2 || statuses[0].Rule != "any" || statuses[1].Rule != "big" {
+		t.Fatalf("RuleStatuses() = %+v, want any and big", statuses)
+	}
+	want := RuleMetadata{Version: "3", Owner: "pricing", Tags: []string{"promo", "prix"}}
+	if !reflect.DeepEqual(statuses[1].Metadata, want) {
+		t.Errorf("big metadata = %+v, want %+v", statuses[1].Metadata, want)
+	}
+	if !reflect.DeepEqual(statuses[0].Metadata, RuleMetadata{}) || statuses[0].State != RuleStateActive {
+		t.Errorf("any status = %+v", statuses[0])
+	}
+}
+
+func TestRuleState_SurvivesRecompilation(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, ruleStateProgram)
+	_ = network.SetRuleState("big", RuleStateDisabled)
+	if err := network.RemoveRule("big"); err != nil {
+		t.Fatalf("RemoveRule() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, `@version("4") rule big : {o: Order} / o.total > 50 ==> notify(o.id)
+`)
+	status, err := network.RuleStatus("big")
+	if err != nil {
+		t.Fatalf("RuleStatus() error = %v", err)
+	}
+	if status.State != RuleStateDisabled || status.Metadata.Version != "4" {
+		t.Errorf("status = %+v, want disabled version 4", status)
+	}
+}
+
+func TestRuleSpan_DoesNotPreventSharing(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := ingestQueryProgram(t, NewReteNetwork(storage), storage, `type Order(#id: string, total: number)
+action notify(id: string)
+rule first : {o: Order} / o.total > 100 ==> notify(o.id)
+
+rule second : {o: Order} /   o.total > 100 ==> notify(o.id)
+`)
+	first, second := network.RuleSpan("first"), network.RuleSpan("second")
+	if first == nil || first.Line != 3 || second == nil || second.Line != 5 {
+		t.Fatalf("RuleSpan() = %v, %v, want lines 3 and 5", first, second)
+	}
+	if !strings.HasSuffix(first.File, "query.tsd") {
+		t.Errorf("RuleSpan(first).File = %q", first.File)
+	}
+
+	// La même condition à deux positions différentes partage son nœud alpha
+	filters := 0
+	for _, node := range network.AlphaNodes {
+		if simple, _ := node.Condition.(map[string]interface{}); simple["type"] != ConditionTypeSimple {
+			filters++
+		}
+	}
+	if filters != 1 {
+		t.Errorf("alpha filters = %d, want 1 shared node", filters)
+	}
+}
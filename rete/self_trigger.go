@@ -0,0 +1,288 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// SelfTriggerWarning signale une règle dont l'action modifie des faits
+// qu'elle filtre elle-même, et qui risque donc de se redéclencher en boucle
+type SelfTriggerWarning struct {
+	Rule     string   `json:"rule"`
+	Action   string   `json:"action"`           // Update ou Insert
+	Variable string   `json:"variable"`         // Variable du motif concernée
+	Type     string   `json:"type"`             // Type des faits concernés
+	Fields   []string `json:"fields,omitempty"` // Champs modifiés lus par les conditions (Update)
+
+	Span *constraint.Span `json:"span,omitempty"` // Position de la règle dans le source
+}
+
+func (w SelfTriggerWarning) String() string {
+	if w.Span != nil {
+		return w.Span.String() + ": " + w.message()
+	}
+	return w.message()
+}
+
+// Diagnostic convertit l'avertissement en diagnostic
+func (w SelfTriggerWarning) Diagnostic() constraint.Diagnostic {
+	return constraint.Diagnostic{
+		Severity:     tsdio.SeverityWarning,
+		Code:         constraint.DiagnosticCodeSelfTrigger,
+		Message:      w.message(),
+		Span:         w.Span,
+		SuggestedFix: "ajouter une condition que l'action rend fausse, ou borner les cascades (SetCascadeLimits)",
+	}
+}
+
+func (w SelfTriggerWarning) message() string {
+	if w.Action == "Update" {
+		return fmt.Sprintf("règle %s: %s(%s) modifie %s, lu(s) par ses propres conditions",
+			w.Rule, w.Action, w.Variable, strings.Join(w.Fields, ", "))
+	}
+	return fmt.Sprintf("règle %s: %s(%s) crée un fait filtré par la variable %s de la règle",
+		w.Rule, w.Action, w.Type, w.Variable)
+}
+
+// SelfTriggeringRules analyse le réseau compilé et retourne les règles dont
+// l'action peut les redéclencher : Update d'un champ lu par leurs conditions,
+// Insert d'un fait d'un type qu'elles filtrent.
+//
+// L'analyse est statique : une condition qui cesse d'être vraie après la
+// modification (p.stock < 10 avec stock décrémenté jusqu'à 0, par exemple)
+// n'est pas évaluée. Le garde-fou de cascade (SetCascadeLimits) borne
+// l'exécution de ces règles.
+func (rn *ReteNetwork) SelfTriggeringRules() []SelfTriggerWarning {
+	terminals := make([]*TerminalNode, 0, len(rn.TerminalNodes))
+	for _, terminal := range rn.TerminalNodes {
+		terminals = append(terminals, terminal)
+	}
+	return rn.selfTriggeringRules(terminals)
+}
+
+// selfTriggeringRules analyse les règles des terminaux donnés
+func (rn *ReteNetwork) selfTriggeringRules(terminals []*TerminalNode) []SelfTriggerWarning {
+	var warnings []SelfTriggerWarning
+	for _, terminal := range terminals {
+		if terminal.query != nil || terminal.Action == nil {
+			continue
+		}
+		warnings = append(warnings, rn.ruleSelfTriggers(terminal)...)
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Rule != warnings[j].Rule {
+			return warnings[i].Rule < warnings[j].Rule
+		}
+		return warnings[i].Variable < warnings[j].Variable
+	})
+	return warnings
+}
+
+// ExpressionSelfTriggers analyse une règle avant compilation, sur son AST :
+// mêmes avertissements que SelfTriggeringRules pour les variables de ses
+// motifs et de ses EXISTS
+func ExpressionSelfTriggers(expression constraint.Expression) []SelfTriggerWarning {
+	if expression.Action == nil {
+		return nil
+	}
+
+	var variables []ruleVariable
+	sets := expression.Patterns
+	if len(sets) == 0 {
+		sets = []constraint.Set{expression.Set}
+	}
+	for _, set := range sets {
+		for _, variable := range set.Variables {
+			if variable.Type == "" || variable.Type == "typedVariable" {
+				variables = append(variables, ruleVariable{name: variable.Name, typeName: variable.DataType})
+			}
+		}
+	}
+	walkConstraintNodes(expression.Constraints, func(node map[string]interface{}) {
+		if node["type"] != "existsConstraint" {
+			return
+		}
+		if variable, ok := node["variable"].(map[string]interface{}); ok {
+			name, _ := variable["name"].(string)
+			typeName, _ := variable["dataType"].(string)
+			variables = append(variables, ruleVariable{name: name, typeName: typeName})
+		}
+	})
+
+	reads := make(map[string]map[string]bool)
+	addConditionReads(reads, expression.Constraints)
+
+	writes := actionWrites(convertJobCalls(expression.Action.GetJobs()))
+	return selfTriggers(expression.RuleId, expression.Pos, variables, reads, writes)
+}
+
+// ruleSelfTriggers compare les champs lus par les conditions d'une règle aux
+// faits modifiés par son action
+func (rn *ReteNetwork) ruleSelfTriggers(terminal *TerminalNode) []SelfTriggerWarning {
+	chain, alphaTypes := rn.ruleNodeChain(terminal)
+	rule := terminal.getRuleName()
+	return selfTriggers(rule, rn.RuleSpan(rule), ruleVariables(chain, alphaTypes), conditionReads(chain), actionWrites(terminal.Action.GetJobs()))
+}
+
+// selfTriggers retourne les modifications de l'action qui concernent les
+// faits filtrés par la règle
+func selfTriggers(rule string, span *constraint.Span, variables []ruleVariable, reads map[string]map[string]bool, writes []factWrite) []SelfTriggerWarning {
+	var warnings []SelfTriggerWarning
+	for _, write := range writes {
+		for _, variable := range variables {
+			switch {
+			case write.action == "Update" && write.variable == variable.name:
+				var fields []string
+				for _, field := range write.fields {
+					if reads[variable.name][field] {
+						fields = append(fields, field)
+					}
+				}
+				if len(fields) > 0 {
+					warnings = append(warnings, SelfTriggerWarning{
+						Rule: rule, Action: write.action, Variable: variable.name, Type: variable.typeName, Fields: fields,
+						Span: span,
+					})
+				}
+			case write.action == "Insert" && write.typeName == variable.typeName:
+				warnings = append(warnings, SelfTriggerWarning{
+					Rule: rule, Action: write.action, Variable: variable.name, Type: variable.typeName,
+					Span: span,
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// conditionReads retourne les champs lus par les conditions de la chaîne, par variable
+func conditionReads(chain []Node) map[string]map[string]bool {
+	reads := make(map[string]map[string]bool)
+	walk := func(condition interface{}) {
+		addConditionReads(reads, condition)
+	}
+
+	for _, node := range chain {
+		switch n := node.(type) {
+		case *AlphaNode:
+			walk(n.Condition)
+		case *JoinNode:
+			walk(n.Condition)
+			for _, condition := range n.JoinConditions {
+				addRead(reads, condition.LeftVar, condition.LeftField)
+				addRead(reads, condition.RightVar, condition.RightField)
+			}
+		case *ExistsNode:
+			walk(n.Condition)
+		case *AccumulatorNode:
+			walk(n.Condition)
+		case *CollectNode:
+			walk(n.Condition)
+			walk(n.Filter)
+		}
+	}
+	return reads
+}
+
+// addConditionReads enregistre les champs lus par une condition
+func addConditionReads(reads map[string]map[string]bool, condition interface{}) {
+	walkConstraintNodes(condition, func(node map[string]interface{}) {
+		if node["type"] == "fieldAccess" {
+			object, _ := node["object"].(string)
+			field, _ := node["field"].(string)
+			addRead(reads, object, field)
+		}
+	})
+}
+
+// addRead enregistre la lecture d'un champ d'une variable
+func addRead(reads map[string]map[string]bool, variable, field string) {
+	if variable == "" || field == "" {
+		return
+	}
+	if reads[variable] == nil {
+		reads[variable] = make(map[string]bool)
+	}
+	reads[variable][field] = true
+}
+
+// walkConstraintNodes appelle visit sur chaque nœud d'une condition, en profondeur
+func walkConstraintNodes(value interface{}, visit func(node map[string]interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		visit(v)
+		for _, child := range v {
+			walkConstraintNodes(child, visit)
+		}
+	case []interface{}:
+		for _, child := range v {
+			walkConstraintNodes(child, visit)
+		}
+	case []map[string]interface{}:
+		for _, child := range v {
+			walkConstraintNodes(child, visit)
+		}
+	}
+}
+
+// factWrite est une modification de faits effectuée par une action
+type factWrite struct {
+	action   string   // Update ou Insert
+	variable string   // Variable mise à jour (Update)
+	fields   []string // Champs modifiés (Update)
+	typeName string   // Type du fait créé (Insert)
+}
+
+// actionWrites collecte les Update et Insert d'une action, blocs if compris
+func actionWrites(jobs []JobCall) []factWrite {
+	var writes []factWrite
+	for _, job := range jobs {
+		switch job.Type {
+		case JobTypeLet:
+			continue
+		case JobTypeIf:
+			writes = append(writes, actionWrites(job.Then)...)
+			writes = append(writes, actionWrites(job.Else)...)
+			continue
+		}
+		if len(job.Args) == 0 {
+			continue
+		}
+		arg, ok := job.Args[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch job.Name {
+		case "Update":
+			if arg["type"] != "updateWithModifications" {
+				continue
+			}
+			variable, _ := arg["variable"].(map[string]interface{})
+			name, _ := variable["name"].(string)
+			modifications, _ := arg["modifications"].(map[string]interface{})
+			fields := make([]string, 0, len(modifications))
+			for field := range modifications {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			writes = append(writes, factWrite{action: job.Name, variable: name, fields: fields})
+		case "Insert":
+			if arg["type"] != "inlineFact" {
+				continue
+			}
+			typeName, _ := arg["typeName"].(string)
+			writes = append(writes, factWrite{action: job.Name, typeName: typeName})
+		}
+	}
+	return writes
+}
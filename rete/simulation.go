@@ -0,0 +1,267 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Mode simulation : rejouer des faits et des règles sans effet de bord.
+//
+// Pendant une simulation, les actions passent par un exécuteur qui les
+// enregistre : Insert, Update et Retract modifient la mémoire de travail pour
+// que la cascade se poursuive, Xuple, Print, Log et les actions externes ne
+// sont pas exécutées. La première modification d'un fait conserve sa version
+// d'origine ; EndSimulation compare chaque fait modifié à sa version
+// d'origine.
+//
+// La simulation ne défait rien : elle s'exécute sur un réseau jetable,
+// reconstruit à partir des Definitions du réseau réel et de sa mémoire de
+// travail (SeedFacts). Le réseau réel, ses requêtes et ses requêtes suivies ne
+// voient ainsi jamais les faits simulés.
+
+// Types de modification d'un fait pendant une simulation
+const (
+	FactChangeInserted  = "inserted"
+	FactChangeUpdated   = "updated"
+	FactChangeRetracted = "retracted"
+)
+
+// SimulationReport décrit ce qu'une simulation aurait produit
+type SimulationReport struct {
+	Activations []SimulatedActivation `json:"activations"` // Activations de règles, dans l'ordre
+	Facts       []FactChange          `json:"facts"`       // Faits insérés, modifiés ou rétractés
+	Xuples      []SimulatedXuple      `json:"xuples"`      // Xuples qui auraient été créés
+}
+
+// SimulatedActivation décrit une activation de règle pendant une simulation
+type SimulatedActivation struct {
+	Sequence uint64            `json:"sequence"` // Numéro d'ordre de l'activation
+	Rule     string            `json:"rule"`     // Règle activée
+	Bindings map[string]string `json:"bindings"` // Variable → identifiant interne du fait lié
+	Actions  []SimulatedAction `json:"actions"`  // Actions appelées, arguments évalués
+	Error    string            `json:"error,omitempty"`
+}
+
+// SimulatedAction décrit un appel d'action pendant une simulation
+type SimulatedAction struct {
+	Name    string        `json:"name"`
+	Args    []interface{} `json:"args"`    // Les faits sont remplacés par leur identifiant interne
+	Applied bool          `json:"applied"` // Insert, Update, Retract : appliquée à la mémoire simulée
+}
+
+// FactChange décrit la différence entre un fait avant et après la simulation
+type FactChange struct {
+	FactID string                 `json:"fact_id"`
+	Type   string                 `json:"type"`
+	Change string                 `json:"change"` // inserted, updated ou retracted
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+}
+
+// SimulatedXuple décrit un xuple qui aurait été créé pendant une simulation
+type SimulatedXuple struct {
+	Space    string                 `json:"space"`
+	Type     string                 `json:"type"`
+	Fields   map[string]interface{} `json:"fields"`
+	Triggers []string               `json:"triggers"` // Faits déclencheurs (identifiants internes)
+}
+
+// simulation enregistre les effets d'une simulation en cours
+type simulation struct {
+	mutex       sync.Mutex
+	originals   map[string]*Fact       // Version d'origine des faits modifiés (nil : absent)
+	touched     []string               // Faits modifiés, dans l'ordre de la première modification
+	activations []*SimulatedActivation // Activations enregistrées
+	running     []*SimulatedActivation // Activations en cours (cascade synchrone)
+	xuples      []SimulatedXuple
+}
+
+// simulatedActions sont appliquées à la mémoire simulée, les autres sont remplacées par un enregistrement
+var simulatedActions = map[string]bool{"Insert": true, "Update": true, "Retract": true}
+
+// BeginSimulation démarre une simulation : jusqu'à EndSimulation, les actions
+// sont enregistrées au lieu d'être exécutées
+func (rn *ReteNetwork) BeginSimulation() error {
+	rn.simMutex.Lock()
+	defer rn.simMutex.Unlock()
+	if rn.simulation != nil {
+		return fmt.Errorf("une simulation est déjà en cours")
+	}
+	rn.simulation = &simulation{originals: make(map[string]*Fact)}
+	rn.logger.Info("🧪 Simulation démarrée")
+	return nil
+}
+
+// Simulating indique si une simulation est en cours
+func (rn *ReteNetwork) Simulating() bool {
+	return rn.currentSimulation() != nil
+}
+
+// EndSimulation termine la simulation et retourne son rapport. La mémoire de
+// travail garde les effets simulés : le réseau est destiné à être abandonné.
+func (rn *ReteNetwork) EndSimulation() (*SimulationReport, error) {
+	sim := rn.currentSimulation()
+	if sim == nil {
+		return nil, fmt.Errorf("aucune simulation en cours")
+	}
+
+	report := sim.report(rn.Storage)
+
+	rn.simMutex.Lock()
+	rn.simulation = nil
+	rn.simMutex.Unlock()
+
+	rn.logger.Info("🧪 Simulation terminée: %d activation(s), %d fait(s) modifié(s), %d xuple(s)",
+		len(report.Activations), len(report.Facts), len(report.Xuples))
+	return report, nil
+}
+
+// currentSimulation retourne la simulation en cours (nil : aucune)
+func (rn *ReteNetwork) currentSimulation() *simulation {
+	rn.simMutex.RLock()
+	defer rn.simMutex.RUnlock()
+	return rn.simulation
+}
+
+// beforeWrite conserve la version d'origine d'un fait lors de sa première
+// modification (copie sur écriture)
+func (s *simulation) beforeWrite(factID string, current *Fact) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, seen := s.originals[factID]; seen {
+		return
+	}
+	var original *Fact
+	if current != nil {
+		original = current.Clone()
+	}
+	s.originals[factID] = original
+	s.touched = append(s.touched, factID)
+}
+
+// begin ouvre l'enregistrement d'une activation
+func (s *simulation) begin(rule string, token *Token) *SimulatedActivation {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	bindings := tokenBindings(token)
+	if bindings == nil {
+		bindings = make(map[string]string)
+	}
+	activation := &SimulatedActivation{
+		Sequence: uint64(len(s.activations) + 1),
+		Rule:     rule,
+		Bindings: bindings,
+		Actions:  []SimulatedAction{},
+	}
+	s.activations = append(s.activations, activation)
+	s.running = append(s.running, activation)
+	return activation
+}
+
+// end ferme l'enregistrement d'une activation
+func (s *simulation) end(activation *SimulatedActivation, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err != nil {
+		activation.Error = err.Error()
+	}
+	for i := len(s.running) - 1; i >= 0; i-- {
+		if s.running[i] == activation {
+			s.running = s.running[:i]
+			break
+		}
+	}
+}
+
+// intercept enregistre un appel d'action et indique s'il doit être exécuté
+// (Insert, Update, Retract sur la mémoire simulée) ou seulement enregistré
+func (s *simulation) intercept(name string, args []interface{}, ctx *ExecutionContext) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	applied := simulatedActions[name]
+	recorded := make([]interface{}, len(args))
+	for i, arg := range args {
+		if fact, ok := arg.(*Fact); ok && fact != nil {
+			recorded[i] = fact.GetInternalID()
+		} else {
+			recorded[i] = arg
+		}
+	}
+	if len(s.running) > 0 {
+		activation := s.running[len(s.running)-1]
+		activation.Actions = append(activation.Actions, SimulatedAction{Name: name, Args: recorded, Applied: applied})
+	}
+
+	if name == "Xuple" && len(args) == 2 {
+		space, _ := args[0].(string)
+		if fact, ok := args[1].(*Fact); ok && fact != nil {
+			xuple := SimulatedXuple{Space: space, Type: fact.Type, Fields: simulatedFields(fact), Triggers: []string{}}
+			if ctx != nil {
+				for _, factID := range tokenBindings(ctx.token) {
+					xuple.Triggers = append(xuple.Triggers, factID)
+				}
+				sort.Strings(xuple.Triggers)
+			}
+			s.xuples = append(s.xuples, xuple)
+		}
+	}
+	return applied
+}
+
+// report construit le rapport : activations, différences de faits et xuples
+func (s *simulation) report(storage Storage) *SimulationReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report := &SimulationReport{
+		Activations: make([]SimulatedActivation, 0, len(s.activations)),
+		Facts:       []FactChange{},
+		Xuples:      append([]SimulatedXuple{}, s.xuples...),
+	}
+	for _, activation := range s.activations {
+		report.Activations = append(report.Activations, *activation)
+	}
+
+	for _, factID := range s.touched {
+		before := s.originals[factID]
+		after := storage.GetFact(factID)
+		change := FactChange{FactID: factID}
+		switch {
+		case before == nil && after == nil:
+			continue
+		case before == nil:
+			change.Change, change.Type, change.After = FactChangeInserted, after.Type, simulatedFields(after)
+		case after == nil:
+			change.Change, change.Type, change.Before = FactChangeRetracted, before.Type, simulatedFields(before)
+		case reflect.DeepEqual(before.Fields, after.Fields):
+			continue
+		default:
+			change.Change, change.Type = FactChangeUpdated, after.Type
+			change.Before, change.After = simulatedFields(before), simulatedFields(after)
+		}
+		report.Facts = append(report.Facts, change)
+	}
+	return report
+}
+
+// simulatedFields copie les champs d'un fait pour le rapport, sans le champ
+// interne d'identifiant
+func simulatedFields(fact *Fact) map[string]interface{} {
+	fields := make(map[string]interface{}, len(fact.Fields))
+	for name, value := range fact.Fields {
+		if name != FieldNameID {
+			fields[name] = value
+		}
+	}
+	return fields
+}
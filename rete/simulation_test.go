@@ -0,0 +1,158 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// notifyHandler compte les appels réels de l'action notify
+type notifyHandler struct{ calls *int }
+
+func (notifyHandler) GetName() string                   { return "notify" }
+func (notifyHandler) Validate(args []interface{}) error { return nil }
+func (h notifyHandler) Execute(args []interface{}, ctx *ExecutionContext) error {
+	*h.calls++
+	return nil
+}
+
+const simulationProgram = `type Order(#id: string, total: number)
+action notify(id: string)
+
+rule big : {o: Order} / o.total > 100 ==> notify(o.id)
+
+query orders() : {o: Order}
+`
+
+// copyNetwork reconstruit un réseau à partir des Definitions et de la mémoire
+// de travail de source ; calls compte les appels de notify sur la copie
+func copyNetwork(t *testing.T, source *ReteNetwork, calls *int) (*ReteNetwork, Storage) {
+	t.Helper()
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+	if err := network.ActionExecutor.RegisterAction(notifyHandler{calls: calls}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network, _, err := NewConstraintPipeline().IngestProgramContext(context.Background(), "copie", source.Definitions(), network, storage)
+	if err != nil {
+		t.Fatalf("IngestProgramContext(Definitions()) error = %v", err)
+	}
+	if err := network.SeedFacts(source); err != nil {
+		t.Fatalf("SeedFacts() error = %v", err)
+	}
+	return network, storage
+}
+
+func TestSimulation_Records(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+	calls := 0
+	if err := network.ActionExecutor.RegisterAction(notifyHandler{calls: &calls}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, simulationProgram)
+	if err := network.SubmitFact(order("o1", 150)); err != nil {
+		t.Fatalf("SubmitFact() error = %v", err)
+	}
+
+	// La copie reçoit les faits sans rejouer leurs activations
+	copyCalls := 0
+	simulated, simulatedStorage := copyNetwork(t, network, &copyCalls)
+	if copyCalls != 0 || simulatedStorage.GetFact("Order~o1") == nil {
+		t.Fatalf("copy: notify executed %d times, facts = %v", copyCalls, simulatedStorage.GetAllFacts())
+	}
+
+	if err := simulated.BeginSimulation(); err != nil {
+		t.Fatalf("BeginSimulation() error = %v", err)
+	}
+	if err := simulated.BeginSimulation(); err == nil {
+		t.Errorf("nested BeginSimulation() should fail")
+	}
+	_ = simulated.SubmitFact(order("o2", 200))
+	_ = simulated.RetractFact("Order~o1")
+	_ = simulated.UpdateFact(order("o2", 300))
+
+	report, err := simulated.EndSimulation()
+	if err != nil {
+		t.Fatalf("EndSimulation() error = %v", err)
+	}
+	if calls != 1 || copyCalls != 0 {
+		t.Errorf("notify executed %d/%d times, want only the call before the simulation", calls, copyCalls)
+	}
+
+	if len(report.Activations) != 2 {
+		t.Fatalf("Activations = %+v, want big for o2 before and after the update", report.Activations)
+	}
+	want := SimulatedAction{Name: "notify", Args: []interface{}{"o2"}}
+	if got := report.Activations[0]; got.Rule != "big" || got.Bindings["o"] != "Order~o2" ||
+		!reflect.DeepEqual(got.Actions, []SimulatedAction{want}) {
+		t.Errorf("Activations[0] = %+v", got)
+	}
+
+	wantFacts := []FactChange{
+		{FactID: "Order~o2", Type: "Order", Change: FactChangeInserted, After: map[string]interface{}{"id": "o2", "total": 300.0}},
+		{FactID: "Order~o1", Type: "Order", Change: FactChangeRetracted, Before: map[string]interface{}{"id": "o1", "total": 150.0}},
+	}
+	if !reflect.DeepEqual(report.Facts, wantFacts) {
+		t.Errorf("Facts =\n%+v\nwant\n%+v", report.Facts, wantFacts)
+	}
+
+	// Le réseau d'origine n'a jamais vu les faits simulés
+	if storage.GetFact("Order~o1") == nil || storage.GetFact("Order~o2") != nil {
+		t.Errorf("working memory changed: %v", storage.GetAllFacts())
+	}
+	query, _ := network.GetQuery("orders")
+	if rows, _ := query.Execute(); len(rows) != 1 {
+		t.Errorf("orders returned %d rows after the simulation, want 1", len(rows))
+	}
+	if simulated.Simulating() {
+		t.Errorf("simulating after EndSimulation")
+	}
+
+	// Hors simulation, les actions sont de nouveau exécutées
+	_ = simulated.SubmitFact(order("o3", 500))
+	if copyCalls != 1 {
+		t.Errorf("notify executed %d times on the copy, want 1", copyCalls)
+	}
+}
+
+func TestSeedFacts_KeepsRuleStates(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+	calls := 0
+	if err := network.ActionExecutor.RegisterAction(notifyHandler{calls: &calls}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, simulationProgram+"\nrule gone : {o: Order} / o.total > 0 ==> notify(o.id)\n")
+	if err := network.SetRuleState("big", RuleStateDisabled); err != nil {
+		t.Fatalf("SetRuleState() error = %v", err)
+	}
+	if err := network.RemoveRule("gone"); err != nil {
+		t.Fatalf("RemoveRule() error = %v", err)
+	}
+	_ = network.SubmitFact(order("o1", 150))
+
+	copyCalls := 0
+	copied, _ := copyNetwork(t, network, &copyCalls)
+	statuses := copied.RuleStatuses()
+	if len(statuses) != 1 || statuses[0].Rule != "big" || statuses[0].State != RuleStateDisabled || statuses[0].Pending != 1 {
+		t.Errorf("RuleStatuses() = %+v, want big disabled with its held match", statuses)
+	}
+	if _, exists := copied.GetQuery("orders"); !exists {
+		t.Errorf("query orders not copied")
+	}
+	if copyCalls != 0 {
+		t.Errorf("notify executed %d times while seeding", copyCalls)
+	}
+}
+
+func TestSimulation_EndWithoutBegin(t *testing.T) {
+	network := NewReteNetwork(NewMemoryStorage())
+	if _, err := network.EndSimulation(); err == nil {
+		t.Errorf("EndSimulation() without simulation should fail")
+	}
+}
@@ -29,10 +29,21 @@ type TypeDefinition struct {
 	Fields []Field `json:"fields"`
 }
 
+// Types d'instructions d'une action
+const (
+	JobTypeCall = "jobCall"    // Appel d'action
+	JobTypeLet  = "letBinding" // let nom = expression
+	JobTypeIf   = "ifBlock"    // if condition then ... else ...
+)
+
 type JobCall struct {
-	Type string        `json:"type"`
-	Name string        `json:"name"`
-	Args []interface{} `json:"args"`
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Args      []interface{} `json:"args"`
+	Value     interface{}   `json:"value,omitempty"`     // Expression liée (let)
+	Condition interface{}   `json:"condition,omitempty"` // Condition (if)
+	Then      []JobCall     `json:"then,omitempty"`      // Instructions si la condition est vraie (if)
+	Else      []JobCall     `json:"else,omitempty"`      // Instructions sinon (if)
 }
 
 type Action struct {
@@ -53,6 +64,32 @@ func (a *Action) GetJobs() []JobCall {
 	return []JobCall{}
 }
 
+// FirstCall retourne le premier appel d'action, en parcourant les blocs if.
+// Retourne nil si l'action ne contient aucun appel.
+func (a *Action) FirstCall() *JobCall {
+	return firstCall(a.GetJobs())
+}
+
+// firstCall retourne le premier appel d'une liste d'instructions
+func firstCall(jobs []JobCall) *JobCall {
+	for i := range jobs {
+		switch jobs[i].Type {
+		case JobTypeLet:
+			continue
+		case JobTypeIf:
+			if call := firstCall(jobs[i].Then); call != nil {
+				return call
+			}
+			if call := firstCall(jobs[i].Else); call != nil {
+				return call
+			}
+		default:
+			return &jobs[i]
+		}
+	}
+	return nil
+}
+
 type TypedVariable struct {
 	Type     string `json:"type"`
 	Name     string `json:"name"`
@@ -0,0 +1,347 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTraceBatchSize est le nombre de spans terminés au-delà duquel le
+// traceur exporte automatiquement, sans attendre Flush
+const DefaultTraceBatchSize = 512
+
+// Noms des spans de propagation
+const (
+	SpanFactSubmit  = "rete.fact.submit"
+	SpanFactUpdate  = "rete.fact.update"
+	SpanFactRetract = "rete.fact.retract"
+	spanNodePrefix  = "rete."
+)
+
+// SpanContext identifie un span dans une trace (format W3C Trace Context)
+type SpanContext struct {
+	TraceID string // 32 caractères hexadécimaux
+	SpanID  string // 16 caractères hexadécimaux
+}
+
+// IsValid indique si le contexte porte des identifiants non nuls
+func (sc SpanContext) IsValid() bool {
+	return len(sc.TraceID) == 32 && len(sc.SpanID) == 16 &&
+		strings.Trim(sc.TraceID, "0") != "" && strings.Trim(sc.SpanID, "0") != ""
+}
+
+// Traceparent formate le contexte en en-tête W3C traceparent
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+// ParseTraceparent analyse un en-tête W3C traceparent (version 00)
+func ParseTraceparent(header string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[3]) != 2 {
+		return SpanContext{}, fmt.Errorf("traceparent invalide: %q", header)
+	}
+	if parts[0] == "ff" {
+		return SpanContext{}, fmt.Errorf("version traceparent invalide: %q", parts[0])
+	}
+	for _, part := range parts {
+		if _, err := hex.DecodeString(part); err != nil || strings.ToLower(part) != part {
+			return SpanContext{}, fmt.Errorf("traceparent invalide: %q", header)
+		}
+	}
+
+	sc := SpanContext{TraceID: parts[1], SpanID: parts[2]}
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("identifiants traceparent invalides: %q", header)
+	}
+	return sc, nil
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext attache un contexte de span parent à un context.Context
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext retourne le contexte de span parent porté par ctx
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// Span est une opération tracée : soumission d'un fait, activation d'un nœud, action
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Start        time.Time
+	End          time.Time
+	Attributes   map[string]interface{}
+	Error        string // vide si l'opération a réussi
+}
+
+// Context retourne l'identité du span, utilisable comme parent
+func (s *Span) Context() SpanContext {
+	return SpanContext{TraceID: s.TraceID, SpanID: s.SpanID}
+}
+
+// SetAttribute ajoute un attribut au span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]interface{})
+	}
+	s.Attributes[key] = value
+}
+
+// SpanExporter publie les spans terminés (OTLP/HTTP, fichier JSON, tests).
+// Les implémentations doivent être thread-safe : un exporteur est partagé
+// par les traceurs de plusieurs réseaux.
+type SpanExporter interface {
+	ExportSpans(spans []*Span) error
+}
+
+// Tracer produit les spans de propagation d'un réseau RETE.
+//
+// La propagation étant synchrone, le span parent d'une activation est celui
+// qui est ouvert en dernier : le traceur maintient une pile de spans actifs.
+// Un traceur ne doit donc pas être partagé entre des soumissions concurrentes ;
+// utiliser un traceur par réseau (et par requête côté serveur).
+type Tracer struct {
+	exporter  SpanExporter
+	remote    SpanContext // Parent distant (requête HTTP entrante), optionnel
+	batchSize int
+
+	mutex    sync.Mutex
+	stack    []*Span
+	finished []*Span
+}
+
+// NewTracer crée un traceur qui publie ses spans vers l'exporteur donné
+func NewTracer(exporter SpanExporter) *Tracer {
+	return &Tracer{exporter: exporter, batchSize: DefaultTraceBatchSize}
+}
+
+// SetRemoteParent rattache les spans racines à un span distant (trace propagée)
+func (t *Tracer) SetRemoteParent(sc SpanContext) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.remote = sc
+}
+
+// StartSpan ouvre un span, enfant du span actif ou du parent distant.
+// Sans parent, une nouvelle trace est créée.
+func (t *Tracer) StartSpan(name string, attributes map[string]interface{}) *Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	span := &Span{
+		SpanID:     newTraceID(8),
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: attributes,
+	}
+	switch {
+	case len(t.stack) > 0:
+		parent := t.stack[len(t.stack)-1]
+		span.TraceID, span.ParentSpanID = parent.TraceID, parent.SpanID
+	case t.remote.IsValid():
+		span.TraceID, span.ParentSpanID = t.remote.TraceID, t.remote.SpanID
+	default:
+		span.TraceID = newTraceID(16)
+	}
+
+	t.stack = append(t.stack, span)
+	return span
+}
+
+// EndSpan ferme un span et l'ajoute au lot à exporter.
+// Les spans ouverts après lui et non fermés (erreur, panique) sont fermés avec lui.
+func (t *Tracer) EndSpan(span *Span, err error) {
+	if span == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	span.End = time.Now()
+	if err != nil {
+		span.Error = err.Error()
+	}
+	for i := len(t.stack) - 1; i >= 0; i-- {
+		if t.stack[i] == span {
+			for _, orphan := range t.stack[i+1:] {
+				orphan.End = span.End
+				t.finished = append(t.finished, orphan)
+			}
+			t.stack = t.stack[:i]
+			break
+		}
+	}
+	t.finished = append(t.finished, span)
+	flush := len(t.stack) == 0 && len(t.finished) >= t.batchSize
+	t.mutex.Unlock()
+
+	if flush {
+		_ = t.Flush()
+	}
+}
+
+// Flush exporte les spans terminés
+func (t *Tracer) Flush() error {
+	t.mutex.Lock()
+	spans := t.finished
+	t.finished = nil
+	t.mutex.Unlock()
+
+	if len(spans) == 0 || t.exporter == nil {
+		return nil
+	}
+	return t.exporter.ExportSpans(spans)
+}
+
+// newTraceID génère un identifiant aléatoire de n octets, encodé en hexadécimal
+func newTraceID(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand ne devrait pas échouer ; garantir un identifiant non nul
+		buf[n-1] = 1
+	}
+	return hex.EncodeToString(buf)
+}
+
+// SetTracer active le traçage de la propagation (nil pour désactiver).
+// À appeler avant la soumission des faits.
+func (rn *ReteNetwork) SetTracer(tracer *Tracer) {
+	rn.tracer = tracer
+	rn.tracedNodeCount = -1
+	if tracer != nil {
+		rn.attachNodes()
+	}
+}
+
+// GetTracer retourne le traceur configuré (nil si le traçage est désactivé)
+func (rn *ReteNetwork) GetTracer() *Tracer {
+	return rn.tracer
+}
+
+// startFactSpan ouvre le span d'une opération sur un fait (nil sans traceur)
+func (rn *ReteNetwork) startFactSpan(name string, factType, factID string) *Span {
+	if rn.tracer == nil {
+		return nil
+	}
+	rn.attachNodes()
+	return rn.tracer.StartSpan(name, map[string]interface{}{
+		"rete.fact.type": factType,
+		"rete.fact.id":   factID,
+	})
+}
+
+// endSpan ferme un span ouvert par startFactSpan
+func (rn *ReteNetwork) endSpan(span *Span, err error) {
+	if span != nil {
+		rn.tracer.EndSpan(span, err)
+	}
+}
+
+// attachNodes rattache au réseau les nœuds construits depuis le dernier appel,
+// afin que leurs activations retrouvent le traceur
+func (rn *ReteNetwork) attachNodes() {
+	count := len(rn.TypeNodes) + len(rn.AlphaNodes) + len(rn.BetaNodes) + len(rn.TerminalNodes)
+	if count == rn.tracedNodeCount || rn.RootNode == nil {
+		return
+	}
+	rn.tracedNodeCount = count
+
+	visited := make(map[Node]bool)
+	var visit func(node Node)
+	visit = func(node Node) {
+		if node == nil || visited[node] {
+			return
+		}
+		visited[node] = true
+		if attachable, ok := node.(interface{ SetNetwork(*ReteNetwork) }); ok {
+			attachable.SetNetwork(rn)
+		}
+		for _, child := range node.GetChildren() {
+			visit(child)
+		}
+	}
+	visit(rn.RootNode)
+	for _, terminal := range rn.TerminalNodes {
+		visit(terminal)
+	}
+}
+
+// tracerOf retourne le traceur du réseau auquel appartient le nœud
+func tracerOf(node Node) *Tracer {
+	owned, ok := node.(interface{ GetNetwork() *ReteNetwork })
+	if !ok {
+		return nil
+	}
+	if network := owned.GetNetwork(); network != nil {
+		return network.tracer
+	}
+	return nil
+}
+
+// startNodeSpan ouvre le span d'activation d'un nœud
+func startNodeSpan(tracer *Tracer, node Node, side string) *Span {
+	attributes := map[string]interface{}{
+		"rete.node.id":    node.GetID(),
+		"rete.activation": side,
+	}
+	if terminal, ok := node.(*TerminalNode); ok {
+		attributes["rete.rule"] = terminal.getRuleName()
+		attributes["rete.action"] = terminal.getActionName()
+	}
+	return tracer.StartSpan(spanNodePrefix+node.GetType(), attributes)
+}
+
+// activateRight propage un fait vers un nœud enfant, dans un span si le traçage est actif
+func activateRight(child Node, fact *Fact) error {
+	tracer := tracerOf(child)
+	if tracer == nil {
+		return child.ActivateRight(fact)
+	}
+	span := startNodeSpan(tracer, child, "right")
+	err := child.ActivateRight(fact)
+	tracer.EndSpan(span, err)
+	return err
+}
+
+// activateLeft propage un token vers un nœud enfant, dans un span si le traçage est actif
+func activateLeft(child Node, token *Token) error {
+	tracer := tracerOf(child)
+	if tracer == nil {
+		return child.ActivateLeft(token)
+	}
+	span := startNodeSpan(tracer, child, "left")
+	err := child.ActivateLeft(token)
+	tracer.EndSpan(span, err)
+	return err
+}
+
+// activateAlphaWithContext active un nœud d'une chaîne alpha décomposée, dans un
+// span si le traçage est actif
+func activateAlphaWithContext(alpha *AlphaNode, fact *Fact, ctx *EvaluationContext) error {
+	tracer := tracerOf(alpha)
+	if tracer == nil {
+		return alpha.ActivateWithContext(fact, ctx)
+	}
+	span := startNodeSpan(tracer, alpha, "right")
+	err := alpha.ActivateWithContext(fact, ctx)
+	tracer.EndSpan(span, err)
+	return err
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTraceServiceName est le service.name des ressources exportées
+	DefaultTraceServiceName = "tsd"
+
+	// OTLPTracesPath est le chemin standard du récepteur OTLP/HTTP
+	OTLPTracesPath = "/v1/traces"
+
+	// DefaultOTLPTimeout borne la durée d'un export OTLP/HTTP
+	DefaultOTLPTimeout = 10 * time.Second
+
+	// otlpScopeName identifie la bibliothèque instrumentée
+	otlpScopeName = "github.com/treivax/tsd/rete"
+
+	// Codes de statut et de type de span OTLP
+	otlpStatusOK       = 1
+	otlpStatusError    = 2
+	otlpSpanKindInside = 1
+)
+
+// Structures de l'encodage JSON OTLP (ExportTraceServiceRequest)
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// EncodeOTLPJSON encode des spans en requête d'export OTLP (encodage JSON)
+func EncodeOTLPJSON(serviceName string, spans []*Span) ([]byte, error) {
+	if serviceName == "" {
+		serviceName = DefaultTraceServiceName
+	}
+
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		status := otlpStatus{Code: otlpStatusOK}
+		if span.Error != "" {
+			status = otlpStatus{Code: otlpStatusError, Message: span.Error}
+		}
+		encoded = append(encoded, otlpSpan{
+			TraceID:           span.TraceID,
+			SpanID:            span.SpanID,
+			ParentSpanID:      span.ParentSpanID,
+			Name:              span.Name,
+			Kind:              otlpSpanKindInside,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(span.Attributes),
+			Status:            status,
+		})
+	}
+
+	return json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: otlpAttributes(map[string]interface{}{
+			"service.name": serviceName,
+		})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: otlpScopeName}, Spans: encoded}},
+	}}})
+}
+
+// otlpAttributes convertit des attributs en KeyValue OTLP, triés par clé
+func otlpAttributes(attributes map[string]interface{}) []otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]otlpKeyValue, 0, len(keys))
+	for _, key := range keys {
+		var value map[string]interface{}
+		switch v := attributes[key].(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		values = append(values, otlpKeyValue{Key: key, Value: value})
+	}
+	return values
+}
+
+// OTLPHTTPExporter publie les spans vers un collecteur OTLP/HTTP (encodage JSON)
+type OTLPHTTPExporter struct {
+	endpoint    string
+	serviceName string
+	client      *http.Client
+}
+
+// NewOTLPHTTPExporter crée un exporteur OTLP/HTTP.
+// Si l'endpoint n'a pas de chemin, le chemin standard /v1/traces est utilisé.
+func NewOTLPHTTPExporter(endpoint string) *OTLPHTTPExporter {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if scheme := strings.Index(endpoint, "://"); scheme >= 0 && !strings.Contains(endpoint[scheme+3:], "/") {
+		endpoint += OTLPTracesPath
+	}
+	return &OTLPHTTPExporter{
+		endpoint:    endpoint,
+		serviceName: DefaultTraceServiceName,
+		client:      &http.Client{Timeout: DefaultOTLPTimeout},
+	}
+}
+
+// Endpoint retourne l'URL de destination des spans
+func (e *OTLPHTTPExporter) Endpoint() string {
+	return e.endpoint
+}
+
+// ExportSpans envoie les spans en une requête POST
+func (e *OTLPHTTPExporter) ExportSpans(spans []*Span) error {
+	body, err := EncodeOTLPJSON(e.serviceName, spans)
+	if err != nil {
+		return fmt.Errorf("encodage OTLP: %w", err)
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("export OTLP vers %s: %w", e.endpoint, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("export OTLP vers %s: statut %d", e.endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// JSONFileExporter ajoute les spans à un fichier, une requête OTLP JSON par ligne
+// (format du « file exporter » OpenTelemetry, relisible par un collecteur)
+type JSONFileExporter struct {
+	path        string
+	serviceName string
+	mutex       sync.Mutex
+}
+
+// NewJSONFileExporter crée un exporteur vers un fichier JSON
+func NewJSONFileExporter(path string) *JSONFileExporter {
+	return &JSONFileExporter{path: path, serviceName: DefaultTraceServiceName}
+}
+
+// ExportSpans ajoute une ligne au fichier
+func (e *JSONFileExporter) ExportSpans(spans []*Span) error {
+	body, err := EncodeOTLPJSON(e.serviceName, spans)
+	if err != nil {
+		return fmt.Errorf("encodage OTLP: %w", err)
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	file, err := os.OpenFile(e.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("ouverture du fichier de traces: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(body, '\n')); err != nil {
+		return fmt.Errorf("écriture du fichier de traces: %w", err)
+	}
+	return nil
+}
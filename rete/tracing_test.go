@@ -0,0 +1,257 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// memorySpanExporter conserve les spans exportés pour les assertions
+type memorySpanExporter struct {
+	mutex sync.Mutex
+	spans []*Span
+}
+
+func (e *memorySpanExporter) ExportSpans(spans []*Span) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+// alertActionHandler insère un fait Alert, pour tracer une insertion en cascade
+type alertActionHandler struct{}
+
+func (alertActionHandler) GetName() string                   { return "raise" }
+func (alertActionHandler) Validate(args []interface{}) error { return nil }
+func (alertActionHandler) Execute(args []interface{}, ctx *ExecutionContext) error {
+	room, _ := args[0].(string)
+	return ctx.network.SubmitFact(&Fact{ID: "Alert~" + room, Type: "Alert", Fields: map[string]interface{}{"room": room}})
+}
+
+const tracingProgram = `type Sensor(#id: string, room: string)
+type Reading(#id: string, sensor: string, value: number)
+type Alert(#room: string)
+action raise(room: string)
+
+rule hot_room : {s: Sensor, r: Reading} / r.sensor == s.id AND r.value > 30 ==> raise(s.room)
+`
+
+func TestTracer_PropagationSpans(t *testing.T) {
+	storage := NewMemoryStorage()
+	network := NewReteNetwork(storage)
+	if err := network.ActionExecutor.RegisterAction(alertActionHandler{}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	network = ingestQueryProgram(t, network, storage, tracingProgram)
+
+	exporter := &memorySpanExporter{}
+	tracer := NewTracer(exporter)
+	remote := SpanContext{TraceID: strings.Repeat("ab", 16), SpanID: strings.Repeat("cd", 8)}
+	tracer.SetRemoteParent(remote)
+	network.SetTracer(tracer)
+
+	if err := network.SubmitFact(&Fact{ID: "Sensor~s1", Type: "Sensor", Fields: map[string]interface{}{"id": "s1", "room": "lab"}}); err != nil {
+		t.Fatalf("SubmitFact(sensor) error = %v", err)
+	}
+	if err := network.SubmitFact(&Fact{ID: "Reading~r1", Type: "Reading", Fields: map[string]interface{}{"id": "r1", "sensor": "s1", "value": 35.0}}); err != nil {
+		t.Fatalf("SubmitFact(reading) error = %v", err)
+	}
+	if err := tracer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	byID := make(map[string]*Span)
+	for _, span := range exporter.spans {
+		byID[span.SpanID] = span
+		if span.TraceID != remote.TraceID {
+			t.Errorf("span %s trace = %s, want remote trace %s", span.Name, span.TraceID, remote.TraceID)
+		}
+		if span.End.Before(span.Start) {
+			t.Errorf("span %s ends before it starts", span.Name)
+		}
+	}
+
+	// Chaque span est rattaché à un span exporté, ou au parent distant pour les faits soumis
+	var roots, terminals, cascaded []*Span
+	for _, span := range exporter.spans {
+		if span.ParentSpanID == remote.SpanID {
+			roots = append(roots, span)
+		} else if byID[span.ParentSpanID] == nil {
+			t.Errorf("span %s has unknown parent %s", span.Name, span.ParentSpanID)
+		}
+		switch span.Name {
+		case "rete.terminal":
+			terminals = append(terminals, span)
+		case SpanFactSubmit:
+			if span.Attributes["rete.fact.type"] == "Alert" {
+				cascaded = append(cascaded, span)
+			}
+		}
+	}
+
+	if len(roots) != 2 {
+		t.Fatalf("root spans = %d, want 2 (one per submitted fact)", len(roots))
+	}
+	for _, root := range roots {
+		if root.Name != SpanFactSubmit {
+			t.Errorf("root span name = %s, want %s", root.Name, SpanFactSubmit)
+		}
+	}
+	if len(terminals) != 1 || terminals[0].Attributes["rete.rule"] != "hot_room" || terminals[0].Attributes["rete.action"] != "raise" {
+		t.Fatalf("terminal spans = %+v, want one span for hot_room/raise", terminals)
+	}
+	if len(cascaded) != 1 || cascaded[0].ParentSpanID != terminals[0].SpanID {
+		t.Fatalf("cascaded insert span should be a child of the terminal span, got %+v", cascaded)
+	}
+
+	// Le chemin de la lecture passe par les nœuds type et join avant le terminal
+	names := map[string]bool{}
+	for parent := byID[terminals[0].ParentSpanID]; parent != nil; parent = byID[parent.ParentSpanID] {
+		names[parent.Name] = true
+	}
+	for _, want := range []string{"rete.type", "rete.join", SpanFactSubmit} {
+		if !names[want] {
+			t.Errorf("terminal span ancestors = %v, missing %s", names, want)
+		}
+	}
+}
+
+func TestTracer_ErrorClosesOrphans(t *testing.T) {
+	exporter := &memorySpanExporter{}
+	tracer := NewTracer(exporter)
+
+	root := tracer.StartSpan("root", nil)
+	child := tracer.StartSpan("child", nil)
+	tracer.EndSpan(root, io.ErrUnexpectedEOF)
+	next := tracer.StartSpan("next", nil)
+	tracer.EndSpan(next, nil)
+	_ = tracer.Flush()
+
+	if len(exporter.spans) != 3 {
+		t.Fatalf("exported spans = %d, want 3", len(exporter.spans))
+	}
+	if child.End.IsZero() || root.Error == "" {
+		t.Errorf("orphan child not closed or root error missing: %+v %+v", child, root)
+	}
+	if next.ParentSpanID != "" || next.TraceID == root.TraceID {
+		t.Errorf("span after a closed root should start a new trace, got %+v", next)
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	valid := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(valid)
+	if err != nil {
+		t.Fatalf("ParseTraceparent() error = %v", err)
+	}
+	if sc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("ParseTraceparent() = %+v", sc)
+	}
+	if sc.Traceparent() != valid {
+		t.Errorf("Traceparent() = %s, want %s", sc.Traceparent(), valid)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-zzf067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceparent(invalid); err == nil {
+			t.Errorf("ParseTraceparent(%q) expected error", invalid)
+		}
+	}
+}
+
+func tracingSampleSpans() []*Span {
+	tracer := NewTracer(nil)
+	root := tracer.StartSpan(SpanFactSubmit, map[string]interface{}{"rete.fact.type": "Reading", "count": 2})
+	child := tracer.StartSpan("rete.join", nil)
+	tracer.EndSpan(child, io.EOF)
+	tracer.EndSpan(root, nil)
+	return []*Span{root, child}
+}
+
+func TestOTLPHTTPExporter_CollectorStub(t *testing.T) {
+	var mutex sync.Mutex
+	var received []map[string]interface{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != OTLPTracesPath || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mutex.Lock()
+		received = append(received, body)
+		mutex.Unlock()
+	}))
+	defer collector.Close()
+
+	exporter := NewOTLPHTTPExporter(collector.URL)
+	if exporter.Endpoint() != collector.URL+OTLPTracesPath {
+		t.Errorf("Endpoint() = %s, want default traces path", exporter.Endpoint())
+	}
+	if err := exporter.ExportSpans(tracingSampleSpans()); err != nil {
+		t.Fatalf("ExportSpans() error = %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("collector received %d requests, want 1", len(received))
+	}
+	encoded, _ := json.Marshal(received[0])
+	for _, want := range []string{
+		`"service.name"`, `"stringValue":"tsd"`, `"name":"rete.fact.submit"`, `"parentSpanId":"`,
+		`"intValue":"2"`, `"status":{"code":2,"message":"EOF"}`,
+	} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("OTLP payload missing %s: %s", want, encoded)
+		}
+	}
+
+	if err := NewOTLPHTTPExporter(collector.URL + "/other").ExportSpans(tracingSampleSpans()); err == nil {
+		t.Error("ExportSpans() to a rejecting endpoint expected error")
+	}
+}
+
+func TestJSONFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	exporter := NewJSONFileExporter(path)
+	for i := 0; i < 2; i++ {
+		if err := exporter.ExportSpans(tracingSampleSpans()); err != nil {
+			t.Fatalf("ExportSpans() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(lines))
+	}
+	var request otlpRequest
+	if err := json.Unmarshal([]byte(lines[0]), &request); err != nil {
+		t.Fatalf("invalid OTLP JSON line: %v", err)
+	}
+	if spans := request.ResourceSpans[0].ScopeSpans[0].Spans; len(spans) != 2 || spans[1].ParentSpanID != spans[0].SpanID {
+		t.Errorf("decoded spans = %+v", spans)
+	}
+}
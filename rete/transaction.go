@@ -7,11 +7,23 @@ package rete
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Compteurs globaux des transactions terminées (exportés par /metrics)
+var (
+	transactionCommits   atomic.Uint64
+	transactionRollbacks atomic.Uint64
+)
+
+// TransactionCounts retourne le nombre de transactions validées et annulées depuis le démarrage
+func TransactionCounts() (commits, rollbacks uint64) {
+	return transactionCommits.Load(), transactionRollbacks.Load()
+}
+
 // Transaction gère le cycle de vie d'une transaction sur le réseau RETE
 // Utilise le Command Pattern pour enregistrer et annuler les opérations
 // Cette implémentation remplace l'ancienne approche par snapshot qui doublait la mémoire
@@ -24,6 +36,7 @@ type Transaction struct {
 	IsCommitted  bool
 	IsRolledBack bool
 	StartTime    time.Time
+	journal      *transactionJournal // Versions d'origine des faits (transaction annulable, nil sinon)
 	mutex        sync.RWMutex
 }
 
@@ -97,6 +110,7 @@ func (tx *Transaction) Commit() error {
 
 	// Libérer le log des commandes (plus besoin pour rollback)
 	tx.Commands = nil
+	transactionCommits.Add(1)
 
 	return nil
 }
@@ -134,6 +148,12 @@ func (tx *Transaction) Rollback() error {
 	tx.IsActive = false
 	tx.IsRolledBack = true
 	tx.Commands = nil
+	transactionRollbacks.Add(1)
+
+	// Les faits restaurés ou annulés modifient l'occupation suivie par les limites
+	if tx.Network != nil {
+		tx.Network.factLimiter.resync(tx.Network.Storage)
+	}
 
 	return nil
 }
@@ -454,3 +454,26 @@ func TestTransaction_GetCommands(t *testing.T) {
 	}
 	t.Log("✅ GetCommands works correctly")
 }
+
+// TestTransactionCounts vérifie les compteurs globaux de commits et rollbacks
+func TestTransactionCounts(t *testing.T) {
+	network := buildTestNetworkWithFacts(1)
+	commitsBefore, rollbacksBefore := TransactionCounts()
+
+	tx := network.BeginTransaction()
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	tx = network.BeginTransaction()
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	// Un second commit échoue et ne doit pas être compté
+	_ = tx.Commit()
+
+	commits, rollbacks := TransactionCounts()
+	if commits-commitsBefore < 1 || rollbacks-rollbacksBefore < 1 {
+		t.Errorf("TransactionCounts() delta = (%d, %d), want at least (1, 1)",
+			commits-commitsBefore, rollbacks-rollbacksBefore)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrNoUndoableTransaction est retourné par RollbackTransaction lorsqu'aucune
+// transaction annulable n'est ouverte
+var ErrNoUndoableTransaction = errors.New("aucune transaction annulable en cours")
+
+// transactionJournal conserve ce qu'il faut pour annuler une transaction dans
+// le réseau, et pas seulement dans le storage : la version d'origine des faits
+// modifiés (copie sur écriture) et les règles existant à son ouverture
+type transactionJournal struct {
+	originals   map[string]*Fact // nil : le fait n'existait pas
+	touched     []string         // Faits modifiés, dans l'ordre
+	rules       map[string]bool  // Terminaux existant à l'ouverture
+	activations uint64           // Dernière activation journalisée à l'ouverture
+}
+
+// BeginUndoableTransaction démarre une transaction qui journalise la version
+// d'origine des faits qu'elle modifie, pour que RollbackTransaction remette
+// aussi les mémoires du réseau dans leur état initial
+func (rn *ReteNetwork) BeginUndoableTransaction() *Transaction {
+	tx := rn.BeginTransaction()
+	tx.journal = &transactionJournal{
+		originals:   make(map[string]*Fact),
+		rules:       make(map[string]bool, len(rn.TerminalNodes)),
+		activations: rn.activations.mark(),
+	}
+	for id := range rn.TerminalNodes {
+		tx.journal.rules[id] = true
+	}
+	return tx
+}
+
+// beforeWrite conserve la version d'origine d'un fait lors de sa première
+// modification dans une transaction annulable
+func (tx *Transaction) beforeWrite(factID string, current *Fact) {
+	if tx == nil {
+		return
+	}
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+	if tx.journal == nil || !tx.IsActive {
+		return
+	}
+	if _, seen := tx.journal.originals[factID]; seen {
+		return
+	}
+	var original *Fact
+	if current != nil {
+		original = current.Clone()
+	}
+	tx.journal.originals[factID] = original
+	tx.journal.touched = append(tx.journal.touched, factID)
+}
+
+// RollbackTransaction annule la transaction annulable courante
+// (BeginUndoableTransaction) : les faits qu'elle a modifiés sont retirés des
+// mémoires du réseau, Transaction.Rollback restaure le storage, puis les
+// versions d'origine y sont repropagées ; les règles ajoutées sont supprimées.
+// Les activations journalisées depuis son ouverture sont oubliées (Explain).
+// Aucune action n'est exécutée pendant l'annulation. Les types ajoutés et
+// les xuples créés par les actions sont conservés.
+func (rn *ReteNetwork) RollbackTransaction() error {
+	tx := rn.GetTransaction()
+	if tx == nil || !tx.IsActive || tx.journal == nil {
+		return ErrNoUndoableTransaction
+	}
+	journal := tx.journal
+
+	rn.undoing.Store(true)
+	defer rn.undoing.Store(false)
+
+	var errs []error
+	for i := len(journal.touched) - 1; i >= 0; i-- {
+		factID := journal.touched[i]
+		if rn.Storage.GetFact(factID) != nil {
+			errs = append(errs, rn.RootNode.ActivateRetract(factID))
+		}
+	}
+
+	if err := tx.Rollback(); err != nil {
+		return err
+	}
+	rn.SetTransaction(nil)
+
+	// Les modifications hors journal de commandes (propagation delta des
+	// Update) sont corrigées à partir des versions d'origine
+	for _, factID := range journal.touched {
+		original := journal.originals[factID]
+		current := rn.Storage.GetFact(factID)
+		if current != nil && (original == nil || !reflect.DeepEqual(current.Fields, original.Fields)) {
+			errs = append(errs, rn.Storage.RemoveFact(factID))
+			current = nil
+		}
+		if original == nil {
+			continue
+		}
+		if current == nil {
+			errs = append(errs, rn.Storage.AddFact(original))
+		}
+		errs = append(errs, rn.RootNode.ActivateRight(original))
+	}
+	rn.factLimiter.resync(rn.Storage)
+	rn.activations.truncate(journal.activations)
+
+	var added []string
+	for id, terminal := range rn.TerminalNodes {
+		if !journal.rules[id] {
+			added = append(added, terminal.getRuleName())
+		}
+	}
+	sort.Strings(added)
+	for _, rule := range added {
+		errs = append(errs, rn.RemoveRule(rule))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("annulation de la transaction %s incomplète: %w", tx.ID, err)
+	}
+	return nil
+}
+
+// PushUndoPoint conserve une transaction annulable remplacée par une nouvelle
+// transaction courante, pour que UndoTransaction puisse l'annuler ensuite.
+// Au-delà de depth transactions conservées, les plus anciennes sont validées.
+func (rn *ReteNetwork) PushUndoPoint(tx *Transaction, depth int) error {
+	rn.txMutex.Lock()
+	defer rn.txMutex.Unlock()
+
+	if tx != nil && tx.IsActive {
+		rn.undoPoints = append(rn.undoPoints, tx)
+	}
+	var errs []error
+	for len(rn.undoPoints) > 0 && len(rn.undoPoints) > depth {
+		errs = append(errs, rn.undoPoints[0].Commit())
+		rn.undoPoints = rn.undoPoints[1:]
+	}
+	return errors.Join(errs...)
+}
+
+// UndoTransaction annule la transaction annulable courante
+// (RollbackTransaction), puis remet en place la transaction conservée par
+// PushUndoPoint qui la précédait : des appels successifs remontent les
+// transactions une à une.
+func (rn *ReteNetwork) UndoTransaction() error {
+	if err := rn.RollbackTransaction(); err != nil {
+		return err
+	}
+
+	rn.txMutex.Lock()
+	defer rn.txMutex.Unlock()
+	if last := len(rn.undoPoints) - 1; last >= 0 {
+		rn.currentTx = rn.undoPoints[last]
+		rn.undoPoints = rn.undoPoints[:last]
+	}
+	return nil
+}
+
+// Undoing indique si une transaction est en cours d'annulation
+func (rn *ReteNetwork) Undoing() bool {
+	return rn.undoing.Load()
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package rete
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const undoProgram = `type Person(#id: string, age: number)
+type Adult(#id: string)
+action adult(id: string)
+query people() : {p: Person}
+rule adults : {p: Person} / p.age >= 18 ==> adult(p.id)
+Person(id: "alice", age: 30)
+`
+
+// adultHandler insère un fait Adult et compte ses exécutions
+type adultHandler struct{ calls *int }
+
+func (adultHandler) GetName() string                   { return "adult" }
+func (adultHandler) Validate(args []interface{}) error { return nil }
+func (h adultHandler) Execute(args []interface{}, ctx *ExecutionContext) error {
+	*h.calls++
+	id, _ := args[0].(string)
+	return ctx.network.SubmitFact(&Fact{ID: "Adult~" + id, Type: "Adult", Fields: map[string]interface{}{"id": id}})
+}
+
+// undoSession ingère des programmes successifs avec des transactions annulables
+type undoSession struct {
+	t        *testing.T
+	pipeline *ConstraintPipeline
+	storage  Storage
+	network  *ReteNetwork
+	calls    int // Exécutions de l'action adult
+}
+
+func newUndoSession(t *testing.T) *undoSession {
+	pipeline := NewConstraintPipeline()
+	pipeline.SetLogger(NewLogger(LogLevelSilent, io.Discard))
+	pipeline.SetUndoable(true)
+	s := &undoSession{t: t, pipeline: pipeline, storage: NewMemoryStorage()}
+	s.network = NewReteNetwork(s.storage)
+	if err := s.network.ActionExecutor.RegisterAction(adultHandler{calls: &s.calls}); err != nil {
+		t.Fatalf("RegisterAction() error = %v", err)
+	}
+	if err := s.ingest(undoProgram); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	return s
+}
+
+func (s *undoSession) ingest(program string) error {
+	s.t.Helper()
+	tsdFile := filepath.Join(s.t.TempDir(), "undo.tsd")
+	if err := os.WriteFile(tsdFile, []byte(program), 0644); err != nil {
+		s.t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	network, _, err := s.pipeline.IngestFile(tsdFile, s.network, s.storage)
+	if network != nil {
+		s.network = network
+	}
+	return err
+}
+
+// facts retourne les identifiants des faits du storage
+func (s *undoSession) facts() []string {
+	var ids []string
+	for _, fact := range s.storage.GetAllFacts() {
+		ids = append(ids, fact.GetInternalID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// people retourne les personnes vues par la requête (mémoires du réseau)
+func (s *undoSession) people() []string {
+	s.t.Helper()
+	rows, err := s.network.Queries["people"].Execute()
+	if err != nil {
+		s.t.Fatalf("Execute() error = %v", err)
+	}
+	var ids []string
+	for _, row := range rows {
+		ids = append(ids, strings.TrimPrefix(row.Get("p").ID, "Person~"))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *undoSession) expect(facts, people []string) {
+	s.t.Helper()
+	if got := s.facts(); !equalStrings(got, facts) {
+		s.t.Errorf("faits = %v, want %v", got, facts)
+	}
+	if got := s.people(); !equalStrings(got, people) {
+		s.t.Errorf("personnes = %v, want %v", got, people)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRollbackTransaction_Facts(t *testing.T) {
+	s := newUndoSession(t)
+	initial := []string{"Adult~alice", "Person~alice"}
+	s.expect(initial, []string{"alice"})
+
+	if err := s.ingest(`Person(id: "bob", age: 40)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	s.expect([]string{"Adult~alice", "Adult~bob", "Person~alice", "Person~bob"}, []string{"alice", "bob"})
+
+	if err := s.network.RollbackTransaction(); err != nil {
+		t.Fatalf("RollbackTransaction() error = %v", err)
+	}
+	s.expect(initial, []string{"alice"})
+	if !errors.Is(s.network.RollbackTransaction(), ErrNoUndoableTransaction) {
+		t.Error("une transaction déjà annulée ne doit pas l'être deux fois")
+	}
+
+	// Un fait retiré revient dans le storage et les mémoires, sans relancer l'action
+	if err := s.ingest(`remove fact Person alice`); err != nil {
+		t.Fatalf("remove error = %v", err)
+	}
+	s.expect([]string{"Adult~alice"}, nil)
+	if err := s.network.RollbackTransaction(); err != nil {
+		t.Fatalf("RollbackTransaction() error = %v", err)
+	}
+	s.expect(initial, []string{"alice"})
+	if s.calls != 2 {
+		t.Errorf("action exécutée %d fois, want 2 (pas pendant l'annulation)", s.calls)
+	}
+
+	// Le réseau reste utilisable après l'annulation
+	if err := s.ingest(`Person(id: "carol", age: 50)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	s.expect([]string{"Adult~alice", "Adult~carol", "Person~alice", "Person~carol"}, []string{"alice", "carol"})
+}
+
+func TestRollbackTransaction_Rules(t *testing.T) {
+	s := newUndoSession(t)
+	if err := s.ingest("type Senior(#id: string)\nrule seniors : {p: Person} / p.age >= 25 ==> Insert(Senior(id: p.id))\n"); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	if _, ok := s.network.TerminalNodes["seniors_terminal"]; !ok {
+		t.Fatal("règle seniors absente")
+	}
+	if err := s.network.RollbackTransaction(); err != nil {
+		t.Fatalf("RollbackTransaction() error = %v", err)
+	}
+	if _, ok := s.network.TerminalNodes["seniors_terminal"]; ok {
+		t.Error("la règle ajoutée doit être supprimée")
+	}
+	if _, ok := s.network.TerminalNodes["adults_terminal"]; !ok {
+		t.Error("la règle existante doit être conservée")
+	}
+
+	// Le fait produit par la règle annulée disparaît, pas les nouveaux faits
+	if err := s.ingest(`Person(id: "dave", age: 60)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	s.expect([]string{"Adult~alice", "Adult~dave", "Person~alice", "Person~dave"}, []string{"alice", "dave"})
+}
+
+func TestRollbackTransaction_FailedIngestion(t *testing.T) {
+	s := newUndoSession(t)
+	if err := s.ingest(`Person(id: "bob", age: 40)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	// Une ingestion en échec est annulée ; la précédente reste annulable
+	if err := s.ingest(`Person(id: "eve", age: "old")`); err == nil {
+		t.Fatal("ingestion invalide acceptée")
+	}
+	s.expect([]string{"Adult~alice", "Adult~bob", "Person~alice", "Person~bob"}, []string{"alice", "bob"})
+	if err := s.network.RollbackTransaction(); err != nil {
+		t.Fatalf("RollbackTransaction() error = %v", err)
+	}
+	s.expect([]string{"Adult~alice", "Person~alice"}, []string{"alice"})
+
+	// Une ingestion réussie valide la précédente
+	if err := s.ingest(`Person(id: "bob", age: 40)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	previous := s.network.GetTransaction()
+	if err := s.ingest(`Person(id: "carol", age: 50)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	if !previous.IsCommitted {
+		t.Error("la transaction précédente doit être validée")
+	}
+}
+
+func TestUndoTransaction_Stack(t *testing.T) {
+	s := newUndoSession(t)
+	s.pipeline.SetUndoDepth(2)
+	for _, program := range []string{`Person(id: "bob", age: 40)`, `Person(id: "carol", age: 50)`} {
+		if err := s.ingest(program); err != nil {
+			t.Fatalf("ingestion error = %v", err)
+		}
+	}
+	if got := len(s.network.Activations("adults")); got != 3 {
+		t.Fatalf("activations = %d, want 3", got)
+	}
+
+	if err := s.network.UndoTransaction(); err != nil {
+		t.Fatalf("UndoTransaction() error = %v", err)
+	}
+	s.expect([]string{"Adult~alice", "Adult~bob", "Person~alice", "Person~bob"}, []string{"alice", "bob"})
+	if err := s.network.UndoTransaction(); err != nil {
+		t.Fatalf("second UndoTransaction() error = %v", err)
+	}
+	s.expect([]string{"Adult~alice", "Person~alice"}, []string{"alice"})
+
+	// Les activations annulées disparaissent du journal et de la provenance
+	if records := s.network.Activations("adults"); len(records) != 1 || records[0].Bindings["p"] != "Person~alice" {
+		t.Errorf("activations after undo = %v, want alice only", records)
+	}
+	if _, found := s.network.activations.producerOf("Adult~bob"); found {
+		t.Error("Adult~bob still has a producer after undo")
+	}
+	if _, found := s.network.activations.producerOf("Adult~alice"); !found {
+		t.Error("Adult~alice lost its producer")
+	}
+
+	// L'ingestion initiale, au-delà de la profondeur, a été validée
+	if !errors.Is(s.network.UndoTransaction(), ErrNoUndoableTransaction) {
+		t.Error("UndoTransaction() should fail once the undo points are exhausted")
+	}
+	if err := s.ingest(`Person(id: "dave", age: 60)`); err != nil {
+		t.Fatalf("ingestion error = %v", err)
+	}
+	if records := s.network.Activations("adults"); len(records) != 2 || records[1].Sequence != 2 {
+		t.Errorf("activations = %v, want alice then dave with sequence 2", records)
+	}
+}
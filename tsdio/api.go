@@ -32,6 +32,9 @@ type ExecuteResponse struct {
 	// ErrorType précise le type d'erreur (parsing, validation, execution)
 	ErrorType string `json:"error_type,omitempty"`
 
+	// Diagnostics localise les erreurs de syntaxe et de validation dans le source
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+
 	// Results contient les résultats d'exécution si Success == true
 	Results *ExecutionResults `json:"results,omitempty"`
 
@@ -124,12 +127,167 @@ type VersionResponse struct {
 	GoVersion string `json:"go_version"`
 }
 
+// SessionResponse représente la réponse des endpoints de session
+// (création et ingestion incrémentale dans une session existante)
+type SessionResponse struct {
+	// SessionID est l'identifiant de la session
+	SessionID string `json:"session_id"`
+
+	// Success indique si l'opération a réussi
+	Success bool `json:"success"`
+
+	// Error contient le message d'erreur si Success == false
+	Error string `json:"error,omitempty"`
+
+	// ErrorType précise le type d'erreur (parsing, validation, execution)
+	ErrorType string `json:"error_type,omitempty"`
+
+	// Diagnostics localise les erreurs de syntaxe et de validation dans le source
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+
+	// Queries liste les requêtes nommées disponibles dans la session
+	Queries []string `json:"queries"`
+
+	// ExecutionTime est la durée d'exécution en millisecondes
+	ExecutionTimeMs int64 `json:"execution_time_ms"`
+}
+
+// QueryRequest représente l'exécution d'une requête nommée dans une session
+type QueryRequest struct {
+	// Query est le nom de la requête déclarée (query name(...) : ...)
+	Query string `json:"query"`
+
+	// Args contient les arguments positionnels de la requête
+	Args []interface{} `json:"args,omitempty"`
+}
+
+// QueryResponse représente le résultat d'une requête nommée
+type QueryResponse struct {
+	// Success indique si la requête a réussi
+	Success bool `json:"success"`
+
+	// Error contient le message d'erreur si Success == false
+	Error string `json:"error,omitempty"`
+
+	// ErrorType précise le type d'erreur
+	ErrorType string `json:"error_type,omitempty"`
+
+	// Query est le nom de la requête exécutée
+	Query string `json:"query,omitempty"`
+
+	// Variables liste les variables liées dans chaque ligne
+	Variables []string `json:"variables,omitempty"`
+
+	// Rows contient une ligne par combinaison de faits : variable → fait
+	Rows []map[string]Fact `json:"rows"`
+
+	// Count est le nombre de lignes
+	Count int `json:"count"`
+
+	// ExecutionTime est la durée d'exécution en millisecondes
+	ExecutionTimeMs int64 `json:"execution_time_ms"`
+}
+
+// RuleReload décrit un rechargement de la base de règles du serveur (tsd server --rules)
+type RuleReload struct {
+	// ID est le numéro du rechargement, croissant depuis le démarrage
+	ID int `json:"id"`
+
+	// Trigger est l'origine du rechargement : startup, watch ou admin
+	Trigger string `json:"trigger"`
+
+	// StartedAt est l'heure de début du rechargement
+	StartedAt time.Time `json:"started_at"`
+
+	// DurationMs est la durée du rechargement en millisecondes
+	DurationMs int64 `json:"duration_ms"`
+
+	// Success indique si la nouvelle base de règles est en place
+	Success bool `json:"success"`
+
+	// Error contient le message d'erreur si Success == false (l'ancienne base est conservée)
+	Error string `json:"error,omitempty"`
+
+	// ErrorType précise le type d'erreur
+	ErrorType string `json:"error_type,omitempty"`
+
+	// Diagnostics localise les erreurs de syntaxe et de validation dans le source
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+
+	// Added, Removed et Changed listent les règles ajoutées, supprimées et recompilées
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+
+	// Files liste les fichiers de règles chargés
+	Files []string `json:"files,omitempty"`
+
+	// Rules est le nombre de règles chargées depuis les fichiers après le rechargement
+	Rules int `json:"rules"`
+}
+
+// RuleReloadsResponse représente l'historique des rechargements de règles
+type RuleReloadsResponse struct {
+	// RulesDir est le répertoire de règles surveillé
+	RulesDir string `json:"rules_dir"`
+
+	// Reloads liste les derniers rechargements, du plus récent au plus ancien
+	Reloads []RuleReload `json:"reloads"`
+}
+
+// RuleStatus décrit l'état d'une règle d'une session
+type RuleStatus struct {
+	// Rule est l'identifiant de la règle
+	Rule string `json:"rule"`
+
+	// State est l'état de la règle : active, disabled ou dry-run
+	State string `json:"state"`
+
+	// Version, Owner et Tags reprennent les annotations @version, @owner et @tags
+	Version string   `json:"version,omitempty"`
+	Owner   string   `json:"owner,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+
+	// Pending est le nombre de correspondances conservées pendant la désactivation
+	Pending int `json:"pending"`
+
+	// DryRuns est le nombre d'activations à blanc conservées
+	DryRuns int `json:"dry_runs"`
+}
+
+// RuleStatusesResponse liste l'état des règles d'une session
+type RuleStatusesResponse struct {
+	Rules []RuleStatus `json:"rules"`
+}
+
+// RuleStateRequest change l'état d'une règle
+type RuleStateRequest struct {
+	// State est le nouvel état : active, disabled ou dry-run
+	State string `json:"state"`
+}
+
+// DryRunActivation décrit une activation d'une règle en dry-run
+type DryRunActivation struct {
+	Sequence uint64            `json:"sequence"`
+	Rule     string            `json:"rule"`
+	Actions  []string          `json:"actions"`
+	Bindings map[string]string `json:"bindings"`
+	Time     time.Time         `json:"time"`
+}
+
+// DryRunsResponse liste les activations à blanc d'une règle
+type DryRunsResponse struct {
+	Rule        string             `json:"rule"`
+	Activations []DryRunActivation `json:"activations"`
+}
+
 // ErrorTypes constants pour les types d'erreurs
 const (
 	ErrorTypeParsingError    = "parsing_error"
 	ErrorTypeValidationError = "validation_error"
 	ErrorTypeExecutionError  = "execution_error"
 	ErrorTypeServerError     = "server_error"
+	ErrorTypeTimeoutError    = "timeout_error"
 )
 
 // GetInternalID retourne l'ID interne du fait
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdio
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity est la gravité d'un diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Span désigne une portion d'un fichier source. Les lignes et colonnes
+// commencent à 1 ; la fin (EndLine, EndColumn) est exclusive.
+type Span struct {
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+}
+
+// String retourne la position de début au format fichier:ligne:colonne
+func (s *Span) String() string {
+	if s == nil {
+		return ""
+	}
+	file := s.File
+	if file == "" {
+		file = "<input>"
+	}
+	if s.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", file, s.Line, s.Column)
+	}
+	return fmt.Sprintf("%s:%d", file, s.Line)
+}
+
+// Diagnostic est une erreur ou un avertissement localisé dans le source.
+// C'est la structure commune à la ligne de commande, aux réponses d'erreur du
+// serveur et aux erreurs du package api.
+type Diagnostic struct {
+	Severity     Severity `json:"severity"`
+	Code         string   `json:"code"`                   // Identifiant stable de la catégorie (syntax, unknown-field, ...)
+	Message      string   `json:"message"`                // Message sans la position
+	Span         *Span    `json:"span,omitempty"`         // Position dans le source, si connue
+	SuggestedFix string   `json:"suggestedFix,omitempty"` // Correction proposée, si une correction évidente existe
+
+	cause error
+}
+
+// NewDiagnostic crée un diagnostic de gravité error
+func NewDiagnostic(code, message string, span *Span) *Diagnostic {
+	return &Diagnostic{Severity: SeverityError, Code: code, Message: message, Span: span}
+}
+
+// WithCause rattache l'erreur d'origine au diagnostic (accessible par errors.Unwrap)
+func (d *Diagnostic) WithCause(err error) *Diagnostic {
+	d.cause = err
+	return d
+}
+
+// Error retourne le message précédé de la position : fichier:ligne:colonne: message
+func (d *Diagnostic) Error() string {
+	if d.Span == nil {
+		return d.Message
+	}
+	return d.Span.String() + ": " + d.Message
+}
+
+// Unwrap retourne l'erreur d'origine
+func (d *Diagnostic) Unwrap() error {
+	return d.cause
+}
+
+// String retourne le diagnostic complet sur une ligne, gravité, code et
+// correction proposée compris
+func (d *Diagnostic) String() string {
+	var sb strings.Builder
+	if d.Span != nil {
+		sb.WriteString(d.Span.String())
+		sb.WriteString(": ")
+	}
+	sb.WriteString(string(d.Severity))
+	if d.Code != "" {
+		sb.WriteString("[" + d.Code + "]")
+	}
+	sb.WriteString(": ")
+	sb.WriteString(d.Message)
+	if d.SuggestedFix != "" {
+		sb.WriteString(" (" + d.SuggestedFix + ")")
+	}
+	return sb.String()
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdio
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiagnostic_Format(t *testing.T) {
+	cause := errors.New("cause")
+	d := NewDiagnostic("unknown-field", "field agee not found", &Span{File: "rules.tsd", Line: 3, Column: 24}).WithCause(cause)
+	d.SuggestedFix = "remplacer 'agee' par 'age'"
+
+	if got, want := d.Error(), "rules.tsd:3:24: field agee not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := d.String(), "rules.tsd:3:24: error[unknown-field]: field agee not found (remplacer 'agee' par 'age')"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if !errors.Is(d, cause) {
+		t.Errorf("errors.Is(d, cause) = false")
+	}
+
+	anonymous := &Span{Line: 2}
+	if got := anonymous.String(); got != "<input>:2" {
+		t.Errorf("Span.String() = %q, want <input>:2", got)
+	}
+	if got := NewDiagnostic("error", "boom", nil).Error(); got != "boom" {
+		t.Errorf("Error() without span = %q", got)
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdlint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/rete"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// numericAggregates sont les fonctions d'agrégation dont le résultat est un nombre
+var numericAggregates = map[string]bool{
+	rete.AggregateCount: true, rete.AggregateSum: true, rete.AggregateAvg: true,
+	rete.AggregateCountDistinct: true, rete.AggregateMedian: true,
+	rete.AggregatePercentile: true, rete.AggregateStddev: true,
+}
+
+// programAnalysis exécute les vérifications portant sur les règles d'un programme
+type programAnalysis struct {
+	program   *constraint.Program
+	config    *Config
+	fields    map[string]map[string]string // Type des champs, par type
+	functions *constraint.FunctionRegistry
+}
+
+func newProgramAnalysis(program *constraint.Program, config *Config) *programAnalysis {
+	fields := make(map[string]map[string]string)
+	for _, typeDef := range program.Types {
+		fields[typeDef.Name] = make(map[string]string)
+		for _, field := range typeDef.Fields {
+			fields[typeDef.Name][field.Name] = primitiveType(field.Type)
+		}
+	}
+	return &programAnalysis{
+		program:   program,
+		config:    config,
+		fields:    fields,
+		functions: constraint.NewFunctionRegistry(),
+	}
+}
+
+// ruleFindings retourne les constats des vérifications activées sur les règles
+func (a *programAnalysis) ruleFindings() []finding {
+	var findings []finding
+	rules := make([]*analyzedRule, 0, len(a.program.Expressions))
+	for i := range a.program.Expressions {
+		rules = append(rules, newAnalyzedRule(&a.program.Expressions[i]))
+	}
+
+	for _, rule := range rules {
+		if a.config.enabled(CheckUnsatisfiable) {
+			findings = append(findings, rule.unsatisfiable()...)
+		}
+		if a.config.enabled(CheckTypeCoercion) {
+			findings = append(findings, a.typeCoercions(rule)...)
+		}
+		if a.config.enabled(CheckCartesianJoin) {
+			findings = append(findings, rule.cartesianJoins()...)
+		}
+		if a.config.enabled(CheckSelfTrigger) {
+			for _, warning := range rete.ExpressionSelfTriggers(*rule.expression) {
+				findings = append(findings, finding{Diagnostic: warning.Diagnostic(), scope: rule.span()})
+			}
+		}
+	}
+	if a.config.enabled(CheckDuplicateRule) || a.config.enabled(CheckSubsumedRule) {
+		findings = append(findings, a.redundantRules(rules)...)
+	}
+	return findings
+}
+
+// analyzedRule est une règle et sa forme canonique : variables renommées
+// dans leur ordre de déclaration, conditions en forme disjonctive
+type analyzedRule struct {
+	expression *constraint.Expression
+	variables  []constraint.TypedVariable // Variables des motifs
+	patterns   string                     // Signature des motifs
+	action     string                     // Signature de l'action
+	terms      [][]interface{}            // Conditions en forme disjonctive, nil si non analysables
+}
+
+func newAnalyzedRule(expression *constraint.Expression) *analyzedRule {
+	rule := &analyzedRule{expression: expression}
+	sets := expression.Patterns
+	if len(sets) == 0 {
+		sets = []constraint.Set{expression.Set}
+	}
+
+	names := make(map[string]string)
+	for _, set := range sets {
+		for _, variable := range set.Variables {
+			names[variable.Name] = fmt.Sprintf("$%d", len(names)+1)
+			rule.variables = append(rule.variables, variable)
+		}
+	}
+
+	rule.patterns = signature(canonicalize(sets, names))
+	if expression.Action != nil {
+		rule.action = signature(canonicalize(expression.Action.GetJobs(), names))
+	}
+	if terms, ok := disjunctiveForm(canonicalize(expression.Constraints, names)); ok {
+		rule.terms = terms
+	}
+	return rule
+}
+
+func (r *analyzedRule) name() string {
+	return r.expression.RuleId
+}
+
+func (r *analyzedRule) span() *constraint.Span {
+	return r.expression.Pos
+}
+
+// unsatisfiable signale une règle dont aucune conjonction ne peut être vraie
+func (r *analyzedRule) unsatisfiable() []finding {
+	if r.terms == nil {
+		return nil
+	}
+	field := ""
+	for _, term := range r.terms {
+		conflict := conflictingField(term)
+		if conflict == "" {
+			return nil
+		}
+		if field == "" {
+			field = conflict
+		}
+	}
+
+	message := fmt.Sprintf("règle %s: conditions contradictoires, elle ne peut jamais se déclencher", r.name())
+	if field != "" {
+		message += fmt.Sprintf(" (%s)", r.originalField(field))
+	}
+	return []finding{newFinding(CheckUnsatisfiable, r.span(), r.span(), message, "")}
+}
+
+// originalField retrouve le nom d'origine d'un champ canonique ($1.age → p.age)
+func (r *analyzedRule) originalField(field string) string {
+	for i, variable := range r.variables {
+		prefix := fmt.Sprintf("$%d.", i+1)
+		if strings.HasPrefix(field, prefix) {
+			return variable.Name + "." + strings.TrimPrefix(field, prefix)
+		}
+	}
+	return field
+}
+
+// cartesianJoins signale une règle dont une conjonction ne relie pas toutes
+// les variables des motifs par des égalités
+func (r *analyzedRule) cartesianJoins() []finding {
+	var variables []string
+	types := make(map[string]string)
+	for i, variable := range r.variables {
+		if variable.Type == "" || variable.Type == "typedVariable" {
+			name := fmt.Sprintf("$%d", i+1)
+			variables = append(variables, name)
+			types[name] = variable.DataType
+		}
+	}
+	if len(variables) < 2 || r.terms == nil {
+		return nil
+	}
+
+	for _, term := range r.terms {
+		if conflictingField(term) != "" {
+			continue
+		}
+		groups := newVariableGroups(variables)
+		for _, atom := range term {
+			node, ok := atom.(map[string]interface{})
+			if !ok || node["type"] != "comparison" || stringValue(node["operator"]) != "==" {
+				continue
+			}
+			for _, left := range referencedVariables(node["left"]) {
+				for _, right := range referencedVariables(node["right"]) {
+					groups.union(left, right)
+				}
+			}
+		}
+
+		if first, second, ok := groups.disconnected(); ok {
+			message := fmt.Sprintf("règle %s: aucune condition d'égalité ne relie %s et %s, produit cartésien des faits %s × %s",
+				r.name(), r.originalVariable(first), r.originalVariable(second), types[first], types[second])
+			return []finding{newFinding(CheckCartesianJoin, r.span(), r.span(), message,
+				fmt.Sprintf("ajouter une jointure %s.<champ> == %s.<champ>", r.originalVariable(first), r.originalVariable(second)))}
+		}
+	}
+	return nil
+}
+
+// originalVariable retrouve le nom d'origine d'une variable canonique
+func (r *analyzedRule) originalVariable(name string) string {
+	var index int
+	if _, err := fmt.Sscanf(name, "$%d", &index); err == nil && index >= 1 && index <= len(r.variables) {
+		return r.variables[index-1].Name
+	}
+	return name
+}
+
+// referencedVariables retourne les variables dont une expression lit un champ
+func referencedVariables(expression interface{}) []string {
+	var variables []string
+	seen := make(map[string]bool)
+	walkNodes(expression, func(node map[string]interface{}) {
+		if node["type"] == "fieldAccess" {
+			object := stringValue(node["object"])
+			if !seen[object] {
+				seen[object] = true
+				variables = append(variables, object)
+			}
+		}
+	})
+	return variables
+}
+
+// variableGroups regroupe les variables reliées par des égalités
+type variableGroups struct {
+	order  []string
+	parent map[string]string
+}
+
+func newVariableGroups(variables []string) *variableGroups {
+	groups := &variableGroups{order: variables, parent: make(map[string]string)}
+	for _, variable := range variables {
+		groups.parent[variable] = variable
+	}
+	return groups
+}
+
+func (g *variableGroups) find(variable string) string {
+	for g.parent[variable] != variable {
+		variable = g.parent[variable]
+	}
+	return variable
+}
+
+func (g *variableGroups) union(a, b string) {
+	if _, ok := g.parent[a]; !ok {
+		return
+	}
+	if _, ok := g.parent[b]; !ok {
+		return
+	}
+	g.parent[g.find(b)] = g.find(a)
+}
+
+// disconnected retourne deux variables de groupes différents, la première
+// étant la première variable déclarée
+func (g *variableGroups) disconnected() (string, string, bool) {
+	root := g.find(g.order[0])
+	for _, variable := range g.order[1:] {
+		if g.find(variable) != root {
+			return g.order[0], variable, true
+		}
+	}
+	return "", "", false
+}
+
+// redundantRules signale les règles identiques ou couvertes par une autre
+// règle de mêmes motifs et même action
+func (a *programAnalysis) redundantRules(rules []*analyzedRule) []finding {
+	var findings []finding
+	reported := make(map[*analyzedRule]bool)
+	for j, later := range rules {
+		for _, earlier := range rules[:j] {
+			if reported[later] && reported[earlier] {
+				break
+			}
+			if earlier.terms == nil || later.terms == nil || earlier.patterns != later.patterns ||
+				earlier.action != later.action || earlier.expression.Action == nil {
+				continue
+			}
+			earlierCoversLater := covers(earlier.terms, later.terms)
+			laterCoversEarlier := covers(later.terms, earlier.terms)
+
+			switch {
+			case earlierCoversLater && laterCoversEarlier:
+				if !reported[later] && a.config.enabled(CheckDuplicateRule) {
+					reported[later] = true
+					findings = append(findings, newFinding(CheckDuplicateRule, later.span(), later.span(),
+						fmt.Sprintf("règle %s: identique à la règle %s (mêmes motifs, conditions équivalentes, même action)", later.name(), earlier.name()),
+						"supprimer l'une des deux règles"))
+				}
+			case earlierCoversLater:
+				findings = append(findings, a.subsumed(later, earlier, reported)...)
+			case laterCoversEarlier:
+				findings = append(findings, a.subsumed(earlier, later, reported)...)
+			}
+		}
+	}
+	return findings
+}
+
+// subsumed signale une règle qui ne se déclenche que lorsque general se déclenche
+func (a *programAnalysis) subsumed(specific, general *analyzedRule, reported map[*analyzedRule]bool) []finding {
+	if reported[specific] || !a.config.enabled(CheckSubsumedRule) {
+		return nil
+	}
+	if specific.unsatisfiable() != nil {
+		return nil
+	}
+	reported[specific] = true
+	return []finding{newFinding(CheckSubsumedRule, specific.span(), specific.span(),
+		fmt.Sprintf("règle %s: couverte par la règle %s, qui se déclenche dans tous ses cas avec la même action", specific.name(), general.name()),
+		"supprimer la règle ou différencier son action")}
+}
+
+// typeCoercions signale les comparaisons d'opérandes de types primitifs
+// différents, NOT compris
+func (a *programAnalysis) typeCoercions(rule *analyzedRule) []finding {
+	variables := make(map[string]string)
+	for _, variable := range rule.variables {
+		switch variable.Type {
+		case "", "typedVariable":
+			variables[variable.Name] = variable.DataType
+		case "aggregationVariable":
+			if numericAggregates[strings.ToUpper(variable.Function)] {
+				variables[variable.Name] = "number"
+			}
+		}
+	}
+	walkNodes(rule.expression.Constraints, func(node map[string]interface{}) {
+		if variable, ok := node["variable"].(map[string]interface{}); ok && node["type"] != "updateWithModifications" {
+			if name := stringValue(variable["name"]); name != "" {
+				variables[name] = stringValue(variable["dataType"])
+			}
+		}
+	})
+
+	var findings []finding
+	walkNodes(rule.expression.Constraints, func(node map[string]interface{}) {
+		if node["type"] != "comparison" {
+			return
+		}
+		operator := strings.ToUpper(stringValue(node["operator"]))
+		left := a.operandType(node["left"], variables)
+		right := a.operandType(node["right"], variables)
+
+		var message string
+		switch operator {
+		case "==", "!=":
+			if left != "" && right != "" && left != right {
+				result := "fausse"
+				if operator == "!=" {
+					result = "vraie"
+				}
+				message = fmt.Sprintf("comparaison %s %s %s: types différents, toujours %s à l'exécution", left, operator, right, result)
+			}
+		case "<", "<=", ">", ">=":
+			if left != "" && right != "" && left != right {
+				message = fmt.Sprintf("comparaison %s %s %s: types différents, erreur d'évaluation à l'exécution", left, operator, right)
+			}
+		case "LIKE", "MATCHES":
+			if left != "" && left != "string" {
+				message = fmt.Sprintf("%s appliqué à un opérande %s: le motif porte sur une chaîne", operator, left)
+			}
+		}
+		if message == "" {
+			return
+		}
+		span := nodeSpan(node["pos"])
+		if span == nil {
+			span = rule.span()
+		}
+		findings = append(findings, newFinding(CheckTypeCoercion, span, rule.span(),
+			fmt.Sprintf("règle %s: %s", rule.name(), message),
+			"convertir explicitement un opérande : (number) expr, (string) expr ou (bool) expr"))
+	})
+	return findings
+}
+
+// operandType retourne le type primitif d'un opérande, ou "" s'il n'est pas
+// connu statiquement
+func (a *programAnalysis) operandType(operand interface{}, variables map[string]string) string {
+	node, ok := operand.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	switch node["type"] {
+	case "fieldAccess":
+		return a.fields[variables[stringValue(node["object"])]][stringValue(node["field"])]
+	case "variable":
+		return primitiveType(variables[stringValue(node["name"])])
+	case "number":
+		return "number"
+	case "string":
+		return "string"
+	case "boolean", "booleanLiteral":
+		return "bool"
+	case "cast":
+		return primitiveType(stringValue(node["castType"]))
+	case "functionCall":
+		return primitiveType(a.functions.GetReturnType(stringValue(node["name"]), ""))
+	case "binaryOp", "binaryOperation":
+		left := a.operandType(node["left"], variables)
+		right := a.operandType(node["right"], variables)
+		if stringValue(node["operator"]) == "+" && (left == "string" || right == "string") {
+			if left == "string" && right == "string" {
+				return "string"
+			}
+			return ""
+		}
+		return "number"
+	}
+	return ""
+}
+
+// primitiveType normalise un type primitif, "" pour un type défini par l'utilisateur
+func primitiveType(typeName string) string {
+	switch typeName {
+	case "string", "number":
+		return typeName
+	case "bool", "boolean":
+		return "bool"
+	}
+	return ""
+}
+
+// nodeSpan lit la position d'un nœud générique
+func nodeSpan(value interface{}) *constraint.Span {
+	pos, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	number := func(key string) int {
+		n, _ := pos[key].(float64)
+		return int(n)
+	}
+	file, _ := pos["file"].(string)
+	return &constraint.Span{File: file, Line: number("line"), Column: number("column"), EndLine: number("endLine"), EndColumn: number("endColumn")}
+}
+
+// walkNodes appelle visit sur chaque nœud d'une valeur de l'AST, en profondeur
+func walkNodes(value interface{}, visit func(node map[string]interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		visit(v)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walkNodes(v[key], visit)
+		}
+	case []interface{}:
+		for _, child := range v {
+			walkNodes(child, visit)
+		}
+	case []map[string]interface{}:
+		for _, child := range v {
+			walkNodes(child, visit)
+		}
+	}
+}
+
+// unusedDeclarations signale les actions jamais appelées et les types sur
+// lesquels ne porte aucune règle, dans l'ensemble des programmes
+func unusedDeclarations(programs []*constraint.Program, config *Config) []finding {
+	usedActions := make(map[string]bool)
+	usedTypes := make(map[string]bool)
+	for _, program := range programs {
+		for _, expression := range program.Expressions {
+			markPatternTypes(usedTypes, expression.Set, expression.Patterns, expression.Constraints)
+			if expression.Action != nil {
+				markJobs(usedActions, usedTypes, expression.Action.GetJobs())
+			}
+		}
+		for _, query := range program.Queries {
+			markPatternTypes(usedTypes, query.Set, query.Patterns, query.Constraints)
+		}
+		for _, typeDef := range program.Types {
+			for _, field := range typeDef.Fields {
+				usedTypes[field.Type] = true
+			}
+		}
+		for _, action := range program.Actions {
+			for _, parameter := range action.Parameters {
+				usedTypes[parameter.Type] = true
+			}
+		}
+	}
+
+	var findings []finding
+	seen := make(map[string]bool)
+	for _, program := range programs {
+		if config.enabled(CheckUnusedAction) {
+			for _, action := range program.Actions {
+				key := "action:" + action.Name + action.Pos.String()
+				if action.IsDefault || usedActions[action.Name] || seen[key] {
+					continue
+				}
+				seen[key] = true
+				f := newFinding(CheckUnusedAction, action.Pos, action.Pos,
+					fmt.Sprintf("action %s déclarée mais appelée par aucune règle", action.Name),
+					"supprimer la déclaration ou l'appeler dans une règle")
+				f.Severity = tsdio.SeverityInfo
+				findings = append(findings, f)
+			}
+		}
+		if config.enabled(CheckUnusedType) {
+			for _, typeDef := range program.Types {
+				key := "type:" + typeDef.Name + typeDef.Pos.String()
+				if usedTypes[typeDef.Name] || seen[key] {
+					continue
+				}
+				seen[key] = true
+				f := newFinding(CheckUnusedType, typeDef.Pos, typeDef.Pos,
+					fmt.Sprintf("type %s: aucune règle ni requête ne porte sur ses faits", typeDef.Name),
+					"supprimer le type ou écrire les règles qui le traitent")
+				f.Severity = tsdio.SeverityInfo
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// markPatternTypes enregistre les types des variables des motifs et des
+// conditions (EXISTS, FORALL, collectes)
+func markPatternTypes(used map[string]bool, set constraint.Set, patterns []constraint.Set, constraints interface{}) {
+	for _, s := range append([]constraint.Set{set}, patterns...) {
+		for _, variable := range s.Variables {
+			used[variable.DataType] = true
+			if variable.Variable != nil {
+				used[variable.Variable.DataType] = true
+			}
+		}
+	}
+	walkNodes(constraints, func(node map[string]interface{}) {
+		if dataType := stringValue(node["dataType"]); dataType != "" {
+			used[dataType] = true
+		}
+	})
+}
+
+// markJobs enregistre les actions appelées et les types des faits créés
+func markJobs(usedActions, usedTypes map[string]bool, jobs []constraint.JobCall) {
+	for _, job := range jobs {
+		switch job.Type {
+		case "letBinding":
+		case "ifBlock":
+			markJobs(usedActions, usedTypes, job.Then)
+			markJobs(usedActions, usedTypes, job.Else)
+		default:
+			usedActions[job.Name] = true
+		}
+		walkNodes([]interface{}{job.Args, job.Value}, func(node map[string]interface{}) {
+			if node["type"] == "inlineFact" {
+				usedTypes[stringValue(node["typeName"])] = true
+			}
+		})
+	}
+}
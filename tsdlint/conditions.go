@@ -0,0 +1,494 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/rete"
+)
+
+// maxDNFTerms borne la forme normale disjonctive d'une condition : au-delà,
+// la condition n'est pas analysée
+const maxDNFTerms = 64
+
+// disjunctiveForm développe une condition en disjonction de conjonctions
+// d'atomes (comparaisons, EXISTS...). AND est prioritaire sur OR, comme dans
+// le réseau (voir rete.TransformToDNF). Les NOT de comparaisons sont remplacés
+// par la comparaison inverse ; ceux de chaînes AND/OR par la loi de De Morgan ;
+// les autres restent des atomes. Une condition absente donne une conjonction
+// vide. ok est faux si la forme dépasse maxDNFTerms.
+func disjunctiveForm(condition interface{}) (terms [][]interface{}, ok bool) {
+	node, isMap := condition.(map[string]interface{})
+	if !isMap {
+		if condition == nil {
+			return [][]interface{}{{}}, true
+		}
+		return [][]interface{}{{condition}}, true
+	}
+
+	switch node["type"] {
+	case "constraint":
+		return disjunctiveForm(node["constraint"])
+	case "logicalExpr", "logicalExpression":
+		return logicalDisjunctiveForm(node)
+	case "notConstraint":
+		if negated, ok := negateComparison(node["expression"]); ok {
+			return [][]interface{}{{negated}}, true
+		}
+		if transformed, ok := rete.ApplyDeMorganTransformation(node); ok {
+			return disjunctiveForm(transformed)
+		}
+	case "boolean", "booleanLiteral":
+		if value, _ := node["value"].(bool); value {
+			return [][]interface{}{{}}, true
+		}
+		return [][]interface{}{}, true
+	}
+	return [][]interface{}{{node}}, true
+}
+
+// logicalDisjunctiveForm développe une chaîne AND/OR : les opérandes reliés
+// par AND forment des groupes, séparés par OR
+func logicalDisjunctiveForm(node map[string]interface{}) ([][]interface{}, bool) {
+	groups := [][]interface{}{{node["left"]}}
+	for _, operation := range asList(node["operations"]) {
+		op, _ := operation.(map[string]interface{})
+		if strings.EqualFold(stringValue(op["op"]), "OR") || op["op"] == "||" {
+			groups = append(groups, []interface{}{op["right"]})
+		} else {
+			groups[len(groups)-1] = append(groups[len(groups)-1], op["right"])
+		}
+	}
+
+	var terms [][]interface{}
+	for _, group := range groups {
+		groupTerms := [][]interface{}{{}}
+		for _, operand := range group {
+			operandTerms, ok := disjunctiveForm(operand)
+			if !ok {
+				return nil, false
+			}
+			var product [][]interface{}
+			for _, left := range groupTerms {
+				for _, right := range operandTerms {
+					term := append(append([]interface{}{}, left...), right...)
+					product = append(product, term)
+				}
+			}
+			if len(product) > maxDNFTerms {
+				return nil, false
+			}
+			groupTerms = product
+		}
+		terms = append(terms, groupTerms...)
+		if len(terms) > maxDNFTerms {
+			return nil, false
+		}
+	}
+	return terms, true
+}
+
+// negatedOperators associe chaque comparaison à son inverse
+var negatedOperators = map[string]string{
+	"==": "!=", "!=": "==", "<>": "==",
+	"<": ">=", ">=": "<", ">": "<=", "<=": ">",
+	"IN": "NOT IN", "NOT IN": "IN",
+}
+
+// negateComparison retourne la comparaison inverse d'une comparaison
+func negateComparison(expression interface{}) (interface{}, bool) {
+	node, ok := expression.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if node["type"] == "constraint" {
+		return negateComparison(node["constraint"])
+	}
+	if node["type"] != "comparison" {
+		return nil, false
+	}
+	negated, ok := negatedOperators[strings.ToUpper(stringValue(node["operator"]))]
+	if !ok {
+		return nil, false
+	}
+	copied := make(map[string]interface{}, len(node))
+	for key, value := range node {
+		copied[key] = value
+	}
+	copied["operator"] = negated
+	return copied, true
+}
+
+// literalComparison est une comparaison entre un champ et une valeur littérale
+type literalComparison struct {
+	field    string      // variable.champ
+	operator string      // ==, !=, <, <=, >, >=, IN, NOT IN
+	value    interface{} // float64, string, bool ou []interface{} (IN)
+}
+
+// flippedOperators associe une comparaison à celle obtenue en échangeant ses opérandes
+var flippedOperators = map[string]string{
+	"==": "==", "!=": "!=", "<": ">", ">": "<", "<=": ">=", ">=": "<=",
+}
+
+// asLiteralComparison reconnaît un atome « champ opérateur littéral » ou
+// « littéral opérateur champ »
+func asLiteralComparison(atom interface{}) (literalComparison, bool) {
+	node, ok := atom.(map[string]interface{})
+	if !ok || node["type"] != "comparison" {
+		return literalComparison{}, false
+	}
+	operator := strings.ToUpper(stringValue(node["operator"]))
+	if operator == "<>" {
+		operator = "!="
+	}
+
+	if field, ok := fieldName(node["left"]); ok {
+		if value, ok := literalValue(node["right"]); ok {
+			if _, isList := value.([]interface{}); isList != (operator == "IN" || operator == "NOT IN") {
+				return literalComparison{}, false
+			}
+			if _, known := negatedOperators[operator]; known {
+				return literalComparison{field: field, operator: operator, value: value}, true
+			}
+		}
+	}
+	if field, ok := fieldName(node["right"]); ok {
+		if value, ok := literalValue(node["left"]); ok {
+			if flipped, known := flippedOperators[operator]; known {
+				if _, isList := value.([]interface{}); !isList {
+					return literalComparison{field: field, operator: flipped, value: value}, true
+				}
+			}
+		}
+	}
+	return literalComparison{}, false
+}
+
+// fieldName retourne « variable.champ » pour un accès à un champ
+func fieldName(value interface{}) (string, bool) {
+	node, ok := value.(map[string]interface{})
+	if !ok || node["type"] != "fieldAccess" {
+		return "", false
+	}
+	return stringValue(node["object"]) + "." + stringValue(node["field"]), true
+}
+
+// literalValue retourne la valeur d'un littéral, liste comprise
+func literalValue(value interface{}) (interface{}, bool) {
+	node, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	switch node["type"] {
+	case "number", "string", "boolean", "booleanLiteral":
+		switch v := node["value"].(type) {
+		case float64, string, bool:
+			return v, true
+		case int:
+			return float64(v), true
+		}
+	case "arrayLiteral":
+		var values []interface{}
+		for _, element := range asList(node["elements"]) {
+			v, ok := literalValue(element)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, v)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// bound est une borne numérique
+type bound struct {
+	value     float64
+	inclusive bool
+}
+
+// fieldDomain regroupe les contraintes qu'une conjonction impose à un champ
+type fieldDomain struct {
+	lower, upper *bound
+	equal        []interface{}   // Valeurs imposées par ==
+	excluded     []interface{}   // Valeurs exclues par != et NOT IN
+	allowed      [][]interface{} // Listes IN
+}
+
+// add ajoute une contrainte au domaine. Les comparaisons d'ordre non
+// numériques ne sont pas modélisées : add retourne faux.
+func (d *fieldDomain) add(operator string, value interface{}) bool {
+	switch operator {
+	case "==":
+		d.equal = append(d.equal, value)
+	case "!=":
+		d.excluded = append(d.excluded, value)
+	case "IN":
+		d.allowed = append(d.allowed, value.([]interface{}))
+	case "NOT IN":
+		d.excluded = append(d.excluded, value.([]interface{})...)
+	case "<", "<=", ">", ">=":
+		number, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		b := &bound{value: number, inclusive: strings.HasSuffix(operator, "=")}
+		if operator[0] == '>' {
+			if d.lower == nil || b.value > d.lower.value || (b.value == d.lower.value && !b.inclusive) {
+				d.lower = b
+			}
+		} else if d.upper == nil || b.value < d.upper.value || (b.value == d.upper.value && !b.inclusive) {
+			d.upper = b
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// satisfiable indique si une valeur au moins respecte toutes les contraintes
+func (d *fieldDomain) satisfiable() bool {
+	if d.lower != nil && d.upper != nil {
+		if d.lower.value > d.upper.value || (d.lower.value == d.upper.value && !(d.lower.inclusive && d.upper.inclusive)) {
+			return false
+		}
+	}
+
+	var candidates []interface{}
+	finite := false
+	if len(d.equal) > 0 {
+		for _, value := range d.equal[1:] {
+			if !sameValue(value, d.equal[0]) {
+				return false
+			}
+		}
+		candidates, finite = d.equal[:1], true
+	}
+	for _, list := range d.allowed {
+		if !finite {
+			candidates, finite = list, true
+			continue
+		}
+		var kept []interface{}
+		for _, candidate := range candidates {
+			if containsValue(list, candidate) {
+				kept = append(kept, candidate)
+			}
+		}
+		candidates = kept
+	}
+	if !finite {
+		return true
+	}
+
+	for _, candidate := range candidates {
+		if d.inBounds(candidate) && !containsValue(d.excluded, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// inBounds indique si une valeur respecte les bornes ; une valeur non
+// numérique ne respecte aucune borne (la comparaison échoue à l'exécution)
+func (d *fieldDomain) inBounds(value interface{}) bool {
+	if d.lower == nil && d.upper == nil {
+		return true
+	}
+	number, ok := value.(float64)
+	if !ok {
+		return false
+	}
+	if d.lower != nil && (number < d.lower.value || (number == d.lower.value && !d.lower.inclusive)) {
+		return false
+	}
+	if d.upper != nil && (number > d.upper.value || (number == d.upper.value && !d.upper.inclusive)) {
+		return false
+	}
+	return true
+}
+
+func sameValue(a, b interface{}) bool {
+	return a == b
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if sameValue(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// termDomains calcule le domaine de chaque champ comparé à un littéral dans
+// une conjonction
+func termDomains(term []interface{}) map[string]*fieldDomain {
+	domains := make(map[string]*fieldDomain)
+	for _, atom := range term {
+		comparison, ok := asLiteralComparison(atom)
+		if !ok {
+			continue
+		}
+		domain := domains[comparison.field]
+		if domain == nil {
+			domain = &fieldDomain{}
+			domains[comparison.field] = domain
+		}
+		domain.add(comparison.operator, comparison.value)
+	}
+	return domains
+}
+
+// conflictingField retourne le premier champ (par ordre alphabétique) dont le
+// domaine est vide dans la conjonction, ou "" si elle est satisfiable
+func conflictingField(term []interface{}) string {
+	domains := termDomains(term)
+	fields := make([]string, 0, len(domains))
+	for field := range domains {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if !domains[field].satisfiable() {
+			return field
+		}
+	}
+	return ""
+}
+
+// implies indique si la conjonction rend l'atome vrai : l'atome y figure, ou
+// la conjonction est incompatible avec sa négation
+func implies(term []interface{}, atom interface{}) bool {
+	for _, candidate := range term {
+		if reflect.DeepEqual(candidate, atom) {
+			return true
+		}
+	}
+	comparison, ok := asLiteralComparison(atom)
+	if !ok {
+		return false
+	}
+	domain := termDomains(term)[comparison.field]
+	if domain == nil {
+		domain = &fieldDomain{}
+	}
+	if !domain.add(negatedOperators[comparison.operator], comparison.value) {
+		return false
+	}
+	return !domain.satisfiable()
+}
+
+// covers indique si la forme disjonctive inner n'est vraie que lorsque outer
+// l'est : chaque conjonction de inner implique une conjonction de outer
+func covers(outer, inner [][]interface{}) bool {
+	for _, innerTerm := range inner {
+		covered := false
+		for _, outerTerm := range outer {
+			if termImplies(innerTerm, outerTerm) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// termImplies indique si la conjonction term implique chaque atome de target
+func termImplies(term, target []interface{}) bool {
+	for _, atom := range target {
+		if !implies(term, atom) {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalize copie une valeur de l'AST sous forme générique (comme après
+// ConvertResultToProgram), sans positions, les variables renommées selon names
+func canonicalize(value interface{}, names map[string]string) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return value
+	}
+	return renameVariables(constraint.StripPositions(generic), names)
+}
+
+// renameVariables renomme les variables des accès aux champs et des
+// références, en place
+func renameVariables(value interface{}, names map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = renameVariables(child, names)
+		}
+		rename := func(key string) {
+			if name, ok := names[stringValue(v[key])]; ok {
+				v[key] = name
+			}
+		}
+		switch v["type"] {
+		case "fieldAccess":
+			rename("object")
+		case "variable", "typedVariable", "aggregationVariable", "collectVariable":
+			rename("name")
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = renameVariables(child, names)
+		}
+	}
+	return value
+}
+
+// signature sérialise une valeur canonique pour la comparer
+func signature(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// asList retourne les éléments d'une liste de l'AST
+func asList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case []map[string]interface{}:
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			list[i] = element
+		}
+		return list
+	}
+	return nil
+}
+
+// stringValue retourne une chaîne de l'AST ; les opérateurs encodés en
+// base64 par la conversion JSON sont décodés
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return rete.DecodeOperator(v)
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
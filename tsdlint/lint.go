@@ -0,0 +1,286 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+// Package tsdlint analyse statiquement les programmes TSD pour signaler les
+// erreurs probables avant déploiement : règles qui ne peuvent jamais se
+// déclencher, règles dupliquées ou couvertes par une autre, déclarations
+// inutilisées, comparaisons de types différents, jointures sans égalité et
+// règles qui se redéclenchent elles-mêmes.
+//
+// Un commentaire « // tsd:ignore » supprime les constats de sa ligne et de la
+// ligne suivante ; placé avant une règle, il couvre toute la règle. Il peut
+// être limité à certaines vérifications : « // tsd:ignore cartesian-join ».
+package tsdlint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Identifiants des vérifications, utilisés comme codes des constats
+const (
+	CheckUnsatisfiable = "unsatisfiable-rule"
+	CheckDuplicateRule = "duplicate-rule"
+	CheckSubsumedRule  = "subsumed-rule"
+	CheckUnusedAction  = "unused-action"
+	CheckUnusedType    = "unused-type"
+	CheckTypeCoercion  = "type-coercion"
+	CheckCartesianJoin = "cartesian-join"
+	CheckSelfTrigger   = constraint.DiagnosticCodeSelfTrigger
+)
+
+// IgnoreDirective est le commentaire qui supprime des constats
+const IgnoreDirective = "tsd:ignore"
+
+// Check décrit une vérification du linter
+type Check struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// Checks liste les vérifications disponibles, toutes actives par défaut
+var Checks = []Check{
+	{CheckUnsatisfiable, "règle dont les conditions ne peuvent jamais être vraies (x > 5 AND x < 3)"},
+	{CheckDuplicateRule, "règle identique à une autre : mêmes motifs, conditions équivalentes et même action"},
+	{CheckSubsumedRule, "règle qui ne se déclenche que lorsqu'une autre, de même action, se déclenche"},
+	{CheckUnusedAction, "action déclarée mais appelée par aucune règle"},
+	{CheckUnusedType, "type sur lequel ne porte aucune règle ni requête"},
+	{CheckTypeCoercion, "comparaison de types différents, non vérifiée à la compilation"},
+	{CheckCartesianJoin, "jointure sans condition d'égalité entre deux variables (produit cartésien)"},
+	{CheckSelfTrigger, "règle dont l'action modifie les faits qu'elle filtre (Update d'un champ lu, Insert)"},
+}
+
+// ErrUnknownCheck est retourné pour un identifiant de vérification inconnu
+var ErrUnknownCheck = errors.New("vérification inconnue")
+
+// Config sélectionne les vérifications exécutées
+type Config struct {
+	Enable  []string // Vérifications à exécuter (vide : toutes)
+	Disable []string // Vérifications à ne pas exécuter
+}
+
+// Validate vérifie que les vérifications citées existent
+func (c *Config) Validate() error {
+	for _, id := range append(append([]string{}, c.Enable...), c.Disable...) {
+		if !knownCheck(id) {
+			return fmt.Errorf("%w: %s", ErrUnknownCheck, id)
+		}
+	}
+	return nil
+}
+
+// enabled indique si une vérification doit être exécutée
+func (c *Config) enabled(id string) bool {
+	if c == nil {
+		return true
+	}
+	for _, disabled := range c.Disable {
+		if disabled == id {
+			return false
+		}
+	}
+	if len(c.Enable) == 0 {
+		return true
+	}
+	for _, enabled := range c.Enable {
+		if enabled == id {
+			return true
+		}
+	}
+	return false
+}
+
+// knownCheck indique si id désigne une vérification
+func knownCheck(id string) bool {
+	for _, check := range Checks {
+		if check.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Report est le résultat de l'analyse de fichiers
+type Report struct {
+	Files      []string                `json:"files"`
+	Findings   []constraint.Diagnostic `json:"findings"`
+	Suppressed int                     `json:"suppressed"` // Constats supprimés par tsd:ignore
+}
+
+// finding est un constat et la déclaration qui le contient, pour la suppression
+type finding struct {
+	constraint.Diagnostic
+	scope *constraint.Span
+}
+
+// Lint analyse des fichiers .tsd, imports compris. Un fichier qui ne se parse
+// pas ou ne se valide pas interrompt l'analyse : l'erreur porte ses
+// diagnostics (voir constraint.Diagnostics). Les déclarations inutilisées le
+// sont dans tous les fichiers analysés.
+func Lint(files []string, config *Config) (*Report, error) {
+	if config != nil {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	programs := make([]*constraint.Program, 0, len(files))
+	for _, file := range files {
+		result, err := constraint.NewModuleLoader().Load(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := constraint.ValidateConstraintProgram(result); err != nil {
+			return nil, err
+		}
+		program, err := constraint.ConvertResultToProgram(result)
+		if err != nil {
+			return nil, err
+		}
+		programs = append(programs, program)
+	}
+
+	var findings []finding
+	for _, program := range programs {
+		findings = append(findings, newProgramAnalysis(program, config).ruleFindings()...)
+	}
+	findings = append(findings, unusedDeclarations(programs, config)...)
+
+	report := &Report{Files: files, Findings: []constraint.Diagnostic{}}
+	suppressions := newSuppressions()
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		key := fmt.Sprintf("%s|%s|%s", f.Span, f.Code, f.Message)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if suppressions.suppressed(f) {
+			report.Suppressed++
+			continue
+		}
+		report.Findings = append(report.Findings, f.Diagnostic)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i].Span, report.Findings[j].Span
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return report.Findings[i].Code < report.Findings[j].Code
+	})
+	return report, nil
+}
+
+// newFinding crée un constat de gravité warning
+func newFinding(code string, span, scope *constraint.Span, message, fix string) finding {
+	return finding{
+		Diagnostic: constraint.Diagnostic{
+			Severity:     tsdio.SeverityWarning,
+			Code:         code,
+			Message:      message,
+			Span:         span,
+			SuggestedFix: fix,
+		},
+		scope: scope,
+	}
+}
+
+// ignoreDirective est un commentaire tsd:ignore
+type ignoreDirective struct {
+	line   int
+	checks []string // vide : toutes les vérifications
+}
+
+// suppressions lit les directives tsd:ignore des fichiers signalés
+type suppressions struct {
+	directives map[string][]ignoreDirective
+}
+
+func newSuppressions() *suppressions {
+	return &suppressions{directives: make(map[string][]ignoreDirective)}
+}
+
+// suppressed indique si un constat est couvert par une directive : sur sa
+// ligne ou la précédente, ou sur la ligne de la déclaration qui le contient
+// ou la précédente
+func (s *suppressions) suppressed(f finding) bool {
+	if f.Span == nil {
+		return false
+	}
+	for _, directive := range s.fileDirectives(f.Span.File) {
+		if !directive.covers(f.Code) {
+			continue
+		}
+		for _, span := range []*constraint.Span{f.Span, f.scope} {
+			if span != nil && (directive.line == span.Line || directive.line == span.Line-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// covers indique si la directive s'applique à la vérification
+func (d ignoreDirective) covers(code string) bool {
+	if len(d.checks) == 0 {
+		return true
+	}
+	for _, check := range d.checks {
+		if check == code {
+			return true
+		}
+	}
+	return false
+}
+
+// fileDirectives retourne les directives d'un fichier, lues une seule fois
+func (s *suppressions) fileDirectives(file string) []ignoreDirective {
+	if directives, ok := s.directives[file]; ok {
+		return directives
+	}
+	var directives []ignoreDirective
+	if src, err := os.ReadFile(file); err == nil {
+		comments, _ := constraint.Comments(file, src)
+		for _, comment := range comments {
+			if directive, ok := parseIgnoreDirective(comment); ok {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	s.directives[file] = directives
+	return directives
+}
+
+// parseIgnoreDirective reconnaît « // tsd:ignore [vérification, ...] »
+func parseIgnoreDirective(comment constraint.Comment) (ignoreDirective, bool) {
+	text := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(comment.Text, "//"), "/*"), "*/"))
+	if !strings.HasPrefix(text, IgnoreDirective) {
+		return ignoreDirective{}, false
+	}
+	rest := strings.TrimPrefix(text, IgnoreDirective)
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return ignoreDirective{}, false
+	}
+	directive := ignoreDirective{line: comment.Span.Line}
+	for _, check := range strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		directive.checks = append(directive.checks, check)
+	}
+	return directive, true
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdlint
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Constantes du format SARIF 2.1.0
+const (
+	SARIFVersion  = "2.1.0"
+	SARIFSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	SARIFToolName = "tsd-lint"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// sarifLevels associe les gravités des diagnostics aux niveaux SARIF
+var sarifLevels = map[tsdio.Severity]string{
+	tsdio.SeverityError:   "error",
+	tsdio.SeverityWarning: "warning",
+	tsdio.SeverityInfo:    "note",
+}
+
+// WriteSARIF écrit le rapport au format SARIF 2.1.0, lu par les outils
+// d'analyse de code (GitHub code scanning, éditeurs...)
+func WriteSARIF(w io.Writer, report *Report) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: SARIFToolName}},
+		Results: []sarifResult{},
+	}
+	for _, check := range Checks {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               check.ID,
+			ShortDescription: sarifMessage{Text: check.Description},
+		})
+	}
+
+	for _, finding := range report.Findings {
+		result := sarifResult{
+			RuleID:  finding.Code,
+			Level:   sarifLevels[finding.Severity],
+			Message: sarifMessage{Text: finding.Message},
+		}
+		if result.Level == "" {
+			result.Level = "warning"
+		}
+		if span := finding.Span; span != nil {
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(span.File)},
+				Region: sarifRegion{
+					StartLine:   span.Line,
+					StartColumn: span.Column,
+					EndLine:     span.EndLine,
+					EndColumn:   span.EndColumn,
+				},
+			}}}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(sarifLog{Version: SARIFVersion, Schema: SARIFSchema, Runs: []sarifRun{run}})
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+const lintTypes = `type Person(#id: string, age: number, name: string, status: string)
+type Order(#id: string, customerId: string, amount: number)
+action notify(id: string)
+`
+
+// writeSource crée un fichier .tsd dans un répertoire temporaire
+func writeSource(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+// lintSource analyse un source et retourne ses constats
+func lintSource(t *testing.T, content string, config *Config) *Report {
+	t.Helper()
+	tsdio.Mute()
+	defer tsdio.Unmute()
+	report, err := Lint([]string{writeSource(t, "rules.tsd", content)}, config)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	return report
+}
+
+// findingLines retourne « ligne:code » pour chaque constat
+func findingLines(report *Report) []string {
+	var lines []string
+	for _, finding := range report.Findings {
+		lines = append(lines, strconv.Itoa(finding.Span.Line)+":"+finding.Code)
+	}
+	return lines
+}
+
+func TestLint_Checks(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		want  []string
+	}{
+		{
+			name:  "conditions contradictoires",
+			rules: "rule r : {p: Person} / p.age > 5 AND p.age < 3 ==> notify(p.id)\n",
+			want:  []string{"4:unsatisfiable-rule"},
+		},
+		{
+			name:  "contradiction dans chaque branche OR",
+			rules: "rule r : {p: Person} / p.status == \"a\" AND p.status == \"b\" OR p.age IN [1, 2] AND p.age >= 3 ==> notify(p.id)\n",
+			want:  []string{"4:unsatisfiable-rule"},
+		},
+		{
+			name:  "une branche OR satisfiable",
+			rules: "rule r : {p: Person} / p.age > 5 AND p.age < 3 OR p.age == 4 ==> notify(p.id)\n",
+		},
+		{
+			name:  "NOT d'une comparaison",
+			rules: "rule r : {p: Person} / p.age > 5 AND NOT (p.age >= 3) ==> notify(p.id)\n",
+			want:  []string{"4:unsatisfiable-rule"},
+		},
+		{
+			name: "règle couverte par une autre",
+			rules: "rule adults : {p: Person} / p.age >= 18 ==> notify(p.id)\n" +
+				"rule seniors : {x: Person} / x.age >= 65 AND x.status == \"a\" ==> notify(x.id)\n",
+			want: []string{"5:subsumed-rule"},
+		},
+		{
+			name: "règles équivalentes",
+			rules: "rule adults : {p: Person} / p.age >= 18 ==> notify(p.id)\n" +
+				"rule adults2 : {x: Person} / NOT (x.age < 18) ==> notify(x.id)\n",
+			want: []string{"5:duplicate-rule"},
+		},
+		{
+			name: "actions différentes",
+			rules: "rule adults : {p: Person} / p.age >= 18 ==> notify(p.id)\n" +
+				"rule seniors : {p: Person} / p.age >= 65 ==> notify(p.name)\n",
+		},
+		{
+			name:  "comparaisons de types différents",
+			rules: "rule r : {p: Person} / LENGTH(p.name) == \"5\" OR NOT (p.name == 5) OR p.age + 1 > UPPER(p.name) ==> notify(p.id)\n",
+			want:  []string{"4:type-coercion", "4:type-coercion", "4:type-coercion"},
+		},
+		{
+			name:  "jointure sans égalité",
+			rules: "rule r : {p: Person, o: Order} / o.amount > 100 ==> notify(p.id)\n",
+			want:  []string{"4:cartesian-join"},
+		},
+		{
+			name:  "jointure dans une seule branche OR",
+			rules: "rule r : {p: Person, o: Order} / o.customerId == p.id OR o.amount > 100 ==> notify(p.id)\n",
+			want:  []string{"4:cartesian-join"},
+		},
+		{
+			name:  "jointure par égalité",
+			rules: "rule r : {p: Person, o: Order} / o.customerId == p.id AND o.amount > 100 ==> notify(p.id)\n",
+		},
+		{
+			name:  "Update d'un champ lu",
+			rules: "rule r : {p: Person} / p.status == \"new\" ==> Update(p, {status: \"seen\"}), notify(p.id)\n",
+			want:  []string{"4:self-trigger"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := lintSource(t, lintTypes+tt.rules, &Config{Disable: []string{CheckUnusedType, CheckUnusedAction}})
+			got := findingLines(report)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("constats = %v, want %v\n%v", got, tt.want, report.Findings)
+			}
+		})
+	}
+}
+
+func TestLint_UnusedDeclarations(t *testing.T) {
+	report := lintSource(t, lintTypes+
+		"type Orphan(#id: string)\n"+
+		"type Line(#id: string, order: Order)\n"+
+		"action unused(id: string)\n"+
+		"rule r : {l: Line} / l.id == \"x\" ==> notify(l.id)\n", nil)
+
+	got := findingLines(report)
+	want := []string{"1:unused-type", "4:unused-type", "6:unused-action"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("constats = %v, want %v", got, want)
+	}
+	if report.Findings[0].Severity != tsdio.SeverityInfo {
+		t.Errorf("gravité = %s, want info", report.Findings[0].Severity)
+	}
+}
+
+func TestLint_Suppression(t *testing.T) {
+	report := lintSource(t, lintTypes+
+		"// tsd:ignore cartesian-join\n"+
+		"rule a : {p: Person, o: Order} /\n"+
+		"    o.amount > 100\n"+
+		"    ==> notify(p.id)\n"+
+		"// tsd:ignore unsatisfiable-rule\n"+
+		"rule b : {p: Person, o: Order} / o.amount > 1 ==> notify(o.id)\n"+
+		"rule c : {p: Person} / p.age > 5 AND p.age < 3 ==> notify(p.id) // tsd:ignore\n",
+		&Config{Enable: []string{CheckCartesianJoin, CheckUnsatisfiable}})
+
+	if got := findingLines(report); strings.Join(got, " ") != "9:cartesian-join" {
+		t.Errorf("constats = %v", got)
+	}
+	if report.Suppressed != 2 {
+		t.Errorf("Suppressed = %d, want 2", report.Suppressed)
+	}
+}
+
+func TestLint_Errors(t *testing.T) {
+	if _, err := Lint(nil, &Config{Enable: []string{"unknown"}}); !errors.Is(err, ErrUnknownCheck) {
+		t.Errorf("vérification inconnue: err = %v", err)
+	}
+
+	tsdio.Mute()
+	defer tsdio.Unmute()
+	_, err := Lint([]string{writeSource(t, "broken.tsd", "rule r  {p: P} / p.id == 1 ==> Print(p.id)\n")}, nil)
+	if diagnostics := constraint.Diagnostics(err); len(diagnostics) == 0 {
+		t.Errorf("erreur de syntaxe sans diagnostic: %v", err)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	report := lintSource(t, lintTypes+"rule r : {p: Person} / p.age > 5 AND p.age < 3 ==> notify(p.id)\n",
+		&Config{Enable: []string{CheckUnsatisfiable}})
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, report); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("SARIF invalide: %v", err)
+	}
+	if log.Version != SARIFVersion || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != len(Checks) {
+		t.Fatalf("SARIF = %+v", log)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != CheckUnsatisfiable || results[0].Level != "warning" {
+		t.Fatalf("results = %+v", results)
+	}
+	region := results[0].Locations[0].PhysicalLocation.Region
+	if region.StartLine != 4 || region.StartColumn != 1 {
+		t.Errorf("region = %+v", region)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtable
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxCombinations borne le nombre de combinaisons de valeurs examinées par
+// l'analyse de couverture
+const MaxCombinations = 100000
+
+// otherValue représente, pour un sujet comparé à des chaînes, une valeur
+// qu'aucune cellule ne cite
+type otherValue struct{}
+
+// domain est l'ensemble des valeurs représentatives d'un sujet : entre deux
+// seuils consécutifs, toutes les valeurs donnent le même résultat pour
+// toutes les cellules
+type domain struct {
+	subject string
+	values  []interface{}
+}
+
+// coverage est le résultat de l'analyse : pour chaque combinaison de valeurs
+// représentatives, les lignes qui s'appliquent
+type coverage struct {
+	matched  []bool            // La ligne s'applique à au moins une combinaison
+	reached  []bool            // La ligne est la première (dans l'ordre d'évaluation) pour au moins une combinaison
+	overlaps map[[2]int]string // Paires de lignes (i < j) applicables ensemble, avec un exemple
+	gaps     []string          // Exemples de combinaisons sans ligne
+	gapCount int
+}
+
+// overlap indique si deux lignes peuvent s'appliquer ensemble
+func (c *coverage) overlap(i, j int) (string, bool) {
+	if i > j {
+		i, j = j, i
+	}
+	example, found := c.overlaps[[2]int{i, j}]
+	return example, found
+}
+
+// analyze examine toutes les combinaisons de valeurs représentatives ; order
+// donne l'ordre d'évaluation des lignes (first, priority). Retourne la raison
+// pour laquelle l'analyse est impossible le cas échéant.
+func analyze(rows [][]cellCondition, order []int) (*coverage, string) {
+	domains, reason := buildDomains(rows)
+	if reason != "" {
+		return nil, reason
+	}
+	total := 1
+	for _, d := range domains {
+		total *= len(d.values)
+		if total > MaxCombinations {
+			return nil, fmt.Sprintf("plus de %d combinaisons de valeurs", MaxCombinations)
+		}
+	}
+
+	result := &coverage{
+		matched:  make([]bool, len(rows)),
+		reached:  make([]bool, len(rows)),
+		overlaps: make(map[[2]int]string),
+	}
+	index := make(map[string]int, len(domains))
+	for i, d := range domains {
+		index[d.subject] = i
+	}
+
+	point := make([]int, len(domains))
+	for n := 0; n < total; n++ {
+		rest := n
+		for i := range domains {
+			point[i] = rest % len(domains[i].values)
+			rest /= len(domains[i].values)
+		}
+
+		var matching []int
+		for _, row := range order {
+			if matches(rows[row], domains, index, point) {
+				matching = append(matching, row)
+			}
+		}
+		if len(matching) == 0 {
+			if len(result.gaps) < 3 {
+				result.gaps = append(result.gaps, describe(domains, point))
+			}
+			result.gapCount++
+			continue
+		}
+		result.reached[matching[0]] = true
+		for k, i := range matching {
+			result.matched[i] = true
+			for _, j := range matching[k+1:] {
+				pair := [2]int{min(i, j), max(i, j)}
+				if _, found := result.overlaps[pair]; !found {
+					result.overlaps[pair] = describe(domains, point)
+				}
+			}
+		}
+	}
+	return result, ""
+}
+
+// buildDomains calcule les valeurs représentatives de chaque sujet
+func buildDomains(rows [][]cellCondition) ([]domain, string) {
+	var subjects []string
+	cells := make(map[string][]cellCondition)
+	for _, row := range rows {
+		for _, condition := range row {
+			if _, found := cells[condition.subject]; !found {
+				subjects = append(subjects, condition.subject)
+			}
+			cells[condition.subject] = append(cells[condition.subject], condition)
+		}
+	}
+
+	domains := make([]domain, 0, len(subjects))
+	for _, subject := range subjects {
+		var numbers []float64
+		var texts []string
+		hasBool := false
+		for _, condition := range cells[subject] {
+			switch literal := condition.value.literal.(type) {
+			case float64:
+				numbers = append(numbers, literal)
+			case string:
+				if condition.operator != "==" && condition.operator != "!=" {
+					return nil, fmt.Sprintf("%s: comparaison %s d'une chaîne", subject, condition.operator)
+				}
+				texts = append(texts, literal)
+			case bool:
+				if condition.operator != "==" && condition.operator != "!=" {
+					return nil, fmt.Sprintf("%s: comparaison %s d'un booléen", subject, condition.operator)
+				}
+				hasBool = true
+			default:
+				return nil, fmt.Sprintf("%s: expression %s", subject, condition.value.source)
+			}
+		}
+
+		kinds := 0
+		for _, present := range []bool{len(numbers) > 0, len(texts) > 0, hasBool} {
+			if present {
+				kinds++
+			}
+		}
+		if kinds > 1 {
+			return nil, fmt.Sprintf("%s: valeurs de types différents", subject)
+		}
+
+		d := domain{subject: subject}
+		switch {
+		case len(numbers) > 0:
+			d.values = numberValues(numbers)
+		case len(texts) > 0:
+			seen := make(map[string]bool)
+			for _, s := range texts {
+				if !seen[s] {
+					seen[s] = true
+					d.values = append(d.values, s)
+				}
+			}
+			d.values = append(d.values, otherValue{})
+		default:
+			d.values = []interface{}{true, false}
+		}
+		domains = append(domains, d)
+	}
+	return domains, ""
+}
+
+// numberValues retourne chaque seuil, une valeur entre deux seuils
+// consécutifs et une valeur de part et d'autre
+func numberValues(numbers []float64) []interface{} {
+	sort.Float64s(numbers)
+	var thresholds []float64
+	for i, n := range numbers {
+		if i == 0 || n != numbers[i-1] {
+			thresholds = append(thresholds, n)
+		}
+	}
+
+	values := []interface{}{thresholds[0] - 1}
+	for i, threshold := range thresholds {
+		if i > 0 {
+			values = append(values, (thresholds[i-1]+threshold)/2)
+		}
+		values = append(values, threshold)
+	}
+	return append(values, thresholds[len(thresholds)-1]+1)
+}
+
+// matches indique si toutes les cellules d'une ligne sont vraies pour une
+// combinaison
+func matches(row []cellCondition, domains []domain, index map[string]int, point []int) bool {
+	for _, condition := range row {
+		i := index[condition.subject]
+		if !compare(domains[i].values[point[i]], condition.operator, condition.value.literal) {
+			return false
+		}
+	}
+	return true
+}
+
+// compare évalue « valeur opérateur littéral » ; les types sont ceux du domaine
+func compare(value interface{}, operator string, literal interface{}) bool {
+	if number, ok := value.(float64); ok {
+		threshold := literal.(float64)
+		switch operator {
+		case "==":
+			return number == threshold
+		case "!=":
+			return number != threshold
+		case "<":
+			return number < threshold
+		case "<=":
+			return number <= threshold
+		case ">":
+			return number > threshold
+		case ">=":
+			return number >= threshold
+		}
+		return false
+	}
+	equal := value == literal
+	if operator == "!=" {
+		return !equal
+	}
+	return equal
+}
+
+// describe écrit une combinaison de valeurs : o.total = 50, c.tier = "gold"
+func describe(domains []domain, point []int) string {
+	parts := make([]string, len(domains))
+	for i, d := range domains {
+		var text string
+		switch v := d.values[point[i]].(type) {
+		case float64:
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			text = strconv.Quote(v)
+		case bool:
+			text = strconv.FormatBool(v)
+		case otherValue:
+			text = "(autre valeur)"
+		}
+		parts[i] = d.subject + " = " + text
+	}
+	return strings.Join(parts, ", ")
+}
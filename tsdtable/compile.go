@@ -0,0 +1,358 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtable
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// Codes des diagnostics de validation
+const (
+	CodeOverlap       = "table-overlap"           // Lignes applicables ensemble (unique, priorités égales)
+	CodeGap           = "table-gap"               // Combinaisons de valeurs sans ligne
+	CodeUnreachable   = "table-unreachable-row"   // Ligne toujours masquée par les précédentes (first, priority)
+	CodeContradiction = "table-contradictory-row" // Ligne dont les cellules ne peuvent être vraies ensemble
+	CodeNoAction      = "table-no-action"         // Ligne sans action
+	CodeNotAnalyzed   = "table-not-analyzed"      // Couverture non vérifiée
+)
+
+// CompiledRule est la règle générée pour une ligne
+type CompiledRule struct {
+	ID          string
+	Line        int    // Ligne de la table
+	Description string // Colonne @description
+	Source      string
+}
+
+// Compilation est le résultat de la compilation d'une table
+type Compilation struct {
+	Table       *Table
+	Rules       []CompiledRule
+	Diagnostics []tsdio.Diagnostic
+}
+
+// compiledRow est une ligne analysée
+type compiledRow struct {
+	conditions  []cellCondition
+	actions     []string
+	priority    float64
+	description string
+}
+
+// CompileFile lit puis compile une table de décision
+func CompileFile(path string) (*Compilation, error) {
+	table, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(table)
+}
+
+// Compile génère une règle par ligne et vérifie la table : chevauchements,
+// combinaisons non couvertes, lignes inatteignables ou contradictoires. Les
+// lignes first et priority sont exclusives : chaque règle porte la négation
+// des lignes précédentes (dans l'ordre des priorités) qui peuvent s'appliquer
+// en même temps qu'elle. La compilation est retournée même en cas d'erreur,
+// avec ses diagnostics ; l'erreur réunit ceux de gravité error.
+func Compile(table *Table) (*Compilation, error) {
+	c := &Compilation{Table: table}
+
+	patterns, condition, err := splitWhen(table.When)
+	if err != nil {
+		c.report(tsdio.SeverityError, constraint.DiagnosticCodeSyntax, table.Line, "directive when: %v", err)
+		return c, c.Err()
+	}
+
+	rows := make([]compiledRow, len(table.Rows))
+	conditions := make([][]cellCondition, len(table.Rows))
+	for i, row := range table.Rows {
+		rows[i] = c.compileRow(row)
+		conditions[i] = rows[i].conditions
+	}
+	if c.Err() != nil {
+		return c, c.Err()
+	}
+
+	// Ordre d'évaluation ; sans politique exclusive, une ligne sans action
+	// n'a aucun effet et n'est pas analysée
+	exclusive := table.Hit == HitFirst || table.Hit == HitPriority
+	var order []int
+	for i, row := range rows {
+		if len(row.actions) > 0 || exclusive {
+			order = append(order, i)
+		} else {
+			c.report(tsdio.SeverityWarning, CodeNoAction, table.Rows[i].Line, "ligne sans action, ignorée")
+		}
+	}
+	if table.Hit == HitPriority {
+		sort.SliceStable(order, func(a, b int) bool { return rows[order[a]].priority > rows[order[b]].priority })
+	}
+
+	result, reason := analyze(conditions, order)
+	if result == nil {
+		c.report(tsdio.SeverityInfo, CodeNotAnalyzed, table.Line, "couverture non vérifiée: %s", reason)
+	} else {
+		c.checkCoverage(rows, order, result)
+	}
+
+	for position, i := range order {
+		row, line := rows[i], table.Rows[i].Line
+		if len(row.actions) == 0 {
+			// Ligne first ou priority sans action : elle masque les suivantes
+			continue
+		}
+
+		parts := append([]string(nil), condition...)
+		for _, cell := range row.conditions {
+			parts = append(parts, cell.String())
+		}
+		reachable := true
+		if exclusive {
+			var guards [][]cellCondition
+			for _, j := range order[:position] {
+				if result != nil {
+					if _, found := result.overlap(i, j); !found {
+						continue
+					}
+				}
+				if len(rows[j].conditions) == 0 {
+					reachable = false
+					break
+				}
+				guards = append(guards, rows[j].conditions)
+			}
+			for _, guard := range reduceGuards(guards) {
+				parts = append(parts, negate(guard))
+			}
+		}
+		if result != nil {
+			reachable = result.matched[i] && (!exclusive || result.reached[i])
+		}
+		if !reachable {
+			if result == nil {
+				c.report(tsdio.SeverityWarning, CodeUnreachable, line, "ligne masquée par une ligne sans condition, ignorée")
+			}
+			continue
+		}
+
+		id := fmt.Sprintf("%s_%d", table.Name, i+1)
+		source := fmt.Sprintf("rule %s : %s / %s==> %s", id, patterns, joinConditions(parts), strings.Join(row.actions, ", "))
+		if _, err := constraint.ParseConstraint(table.File, []byte(source)); err != nil {
+			c.report(tsdio.SeverityError, constraint.DiagnosticCodeSyntax, line, "règle générée invalide: %s: %v", source, err)
+			continue
+		}
+		c.Rules = append(c.Rules, CompiledRule{ID: id, Line: line, Description: row.description, Source: source})
+	}
+
+	sort.SliceStable(c.Rules, func(a, b int) bool { return c.Rules[a].Line < c.Rules[b].Line })
+	sort.SliceStable(c.Diagnostics, func(a, b int) bool { return c.Diagnostics[a].Span.Line < c.Diagnostics[b].Span.Line })
+	return c, c.Err()
+}
+
+// compileRow lit les conditions, actions, priorité et description d'une ligne
+func (c *Compilation) compileRow(row Row) compiledRow {
+	result := compiledRow{conditions: c.Table.conditions(row)}
+	for i, column := range c.Table.Columns {
+		cell := row.Cells[i]
+		switch column.Kind {
+		case ColumnAction:
+			if cell != "" && cell != "-" {
+				result.actions = append(result.actions, fillTemplate(column.Template, parseValue(cell).source))
+			}
+		case ColumnPriorityKind:
+			priority, err := strconv.ParseFloat(cell, 64)
+			if err != nil && c.Table.Hit == HitPriority {
+				c.report(tsdio.SeverityError, constraint.DiagnosticCodeSyntax, row.Line, "priorité invalide: '%s'", cell)
+			}
+			result.priority = priority
+		case ColumnDescriptionKind:
+			result.description = cell
+		}
+	}
+	return result
+}
+
+// checkCoverage signale les chevauchements, combinaisons non couvertes et
+// lignes jamais appliquées
+func (c *Compilation) checkCoverage(rows []compiledRow, order []int, result *coverage) {
+	table := c.Table
+	for _, i := range order {
+		line := table.Rows[i].Line
+		if !result.matched[i] {
+			c.report(tsdio.SeverityError, CodeContradiction, line, "conditions contradictoires : la ligne ne s'applique jamais")
+			continue
+		}
+
+		switch table.Hit {
+		case HitFirst, HitPriority:
+			if !result.reached[i] && len(rows[i].actions) > 0 {
+				c.report(tsdio.SeverityWarning, CodeUnreachable, line, "ligne toujours masquée par une ligne précédente, ignorée")
+			}
+		}
+
+		for j := 0; j < i; j++ {
+			example, found := result.overlap(i, j)
+			if !found {
+				continue
+			}
+			switch {
+			case table.Hit == HitUnique:
+				c.report(tsdio.SeverityError, CodeOverlap, line, "chevauche la ligne %d (%s) ; politique unique", table.Rows[j].Line, example)
+			case table.Hit == HitPriority && rows[i].priority == rows[j].priority:
+				c.report(tsdio.SeverityError, CodeOverlap, line, "chevauche la ligne %d de même priorité (%s)", table.Rows[j].Line, example)
+			}
+		}
+	}
+
+	if result.gapCount > 0 {
+		message := fmt.Sprintf("%d combinaison(s) sans ligne applicable, par exemple %s", result.gapCount, strings.Join(result.gaps, " ; "))
+		c.report(tsdio.SeverityWarning, CodeGap, table.Line, "%s", message)
+	}
+}
+
+// report ajoute un diagnostic situé à une ligne de la table
+func (c *Compilation) report(severity tsdio.Severity, code string, line int, format string, args ...interface{}) {
+	c.Diagnostics = append(c.Diagnostics, tsdio.Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Span:     &tsdio.Span{File: c.Table.File, Line: line},
+	})
+}
+
+// Err réunit les diagnostics de gravité error ; nil s'il n'y en a pas
+func (c *Compilation) Err() error {
+	var errs []error
+	for i := range c.Diagnostics {
+		if c.Diagnostics[i].Severity == tsdio.SeverityError {
+			errs = append(errs, &c.Diagnostics[i])
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Source retourne le programme TSD généré : une règle par ligne, précédée
+// de la ligne de la table et de sa description
+func (c *Compilation) Source() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// Table de décision %s (%s), politique %s\n", c.Table.Name, c.Table.File, c.Table.Hit)
+	sb.WriteString("// Généré par tsd table compile : modifier la table plutôt que ce fichier\n")
+	for _, rule := range c.Rules {
+		sb.WriteString("\n")
+		if rule.Description != "" {
+			fmt.Fprintf(&sb, "// Ligne %d : %s\n", rule.Line, rule.Description)
+		} else {
+			fmt.Fprintf(&sb, "// Ligne %d\n", rule.Line)
+		}
+		sb.WriteString(rule.Source)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// splitWhen sépare les motifs de la condition commune de la directive when
+func splitWhen(when string) (string, []string, error) {
+	end := strings.LastIndex(when, "}")
+	if !strings.HasPrefix(when, "{") || end < 0 {
+		return "", nil, fmt.Errorf("motifs attendus : {v: Type} / condition")
+	}
+	patterns, rest := when[:end+1], strings.TrimSpace(when[end+1:])
+	if rest == "" {
+		return patterns, nil, nil
+	}
+	condition, found := strings.CutPrefix(rest, "/")
+	if !found {
+		return "", nil, fmt.Errorf("« / » attendu après les motifs : %s", rest)
+	}
+	if condition = strings.TrimSpace(condition); condition == "" {
+		return patterns, nil, nil
+	}
+	if strings.Contains(strings.ToUpper(condition), " OR ") {
+		condition = "(" + condition + ")"
+	}
+	return patterns, []string{condition}, nil
+}
+
+// reduceGuards retire les lignes dont les cellules comprennent toutes celles
+// d'une autre : la négation de la plus générale implique la sienne
+func reduceGuards(guards [][]cellCondition) [][]cellCondition {
+	var reduced [][]cellCondition
+	for i, guard := range guards {
+		implied := false
+		for j, other := range guards {
+			if i != j && includes(guard, other) && (len(guard) > len(other) || j < i) {
+				implied = true
+				break
+			}
+		}
+		if !implied {
+			reduced = append(reduced, guard)
+		}
+	}
+	return reduced
+}
+
+// includes indique si toutes les cellules de b figurent dans a
+func includes(a, b []cellCondition) bool {
+	for _, cb := range b {
+		found := false
+		for _, ca := range a {
+			if ca.String() == cb.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// negate écrit la négation des cellules d'une ligne
+func negate(conditions []cellCondition) string {
+	parts := make([]string, len(conditions))
+	for i, condition := range conditions {
+		parts[i] = condition.String()
+	}
+	return "NOT (" + strings.Join(parts, " AND ") + ")"
+}
+
+// joinConditions relie les conditions par AND, suivies d'une espace
+func joinConditions(parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, " AND ") + " "
+}
+
+// fillTemplate remplace les ? d'un appel, hors chaînes, par la valeur
+func fillTemplate(template, value string) string {
+	if !strings.Contains(template, "?") {
+		return template
+	}
+	var sb strings.Builder
+	inString := false
+	for i := 0; i < len(template); i++ {
+		ch := template[i]
+		switch {
+		case ch == '"' && (i == 0 || template[i-1] != '\\'):
+			inString = !inString
+			sb.WriteByte(ch)
+		case ch == '?' && !inString:
+			sb.WriteString(value)
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,395 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+// Package tsdtable compile des tables de décision CSV en règles TSD
+// ordinaires. Une table commence par des directives, puis une ligne d'en-tête
+// et une ligne par règle :
+//
+//	# table: pricing
+//	# hit: first
+//	# when: {o: Order, c: Customer} / o.customer == c
+//	@description,o.total >=,c.tier,"discount(o.id, ?)"
+//	Gros clients gold,1000,gold,20
+//	Grosses commandes,1000,,10
+//	Par défaut,,,0
+//
+// Les colonnes de condition portent un sujet suivi d'un opérateur (==, !=,
+// <, <=, >, >=) ; sans opérateur dans l'en-tête, chaque cellule commence par
+// le sien (== par défaut). Les colonnes d'action sont des appels dont les ?
+// reçoivent la valeur de la cellule ; sans ?, une cellule non vide déclenche
+// l'appel. Une cellule vide ou « - » ne pose pas de condition (ou n'appelle
+// pas l'action). Une valeur est un nombre, true, false, une expression TSD
+// précédée de = (=o.total * 0.1) ou, sinon, une chaîne.
+//
+// Colonnes réservées : @priority (politique priority) et @description,
+// reprise en commentaire de la règle générée.
+package tsdtable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/treivax/tsd/constraint"
+	"github.com/treivax/tsd/tsdio"
+)
+
+// HitPolicy détermine les lignes appliquées lorsque plusieurs correspondent
+type HitPolicy string
+
+const (
+	HitUnique   HitPolicy = "unique"   // Au plus une ligne correspond : les chevauchements sont des erreurs
+	HitFirst    HitPolicy = "first"    // Seule la première ligne qui correspond s'applique
+	HitCollect  HitPolicy = "collect"  // Toutes les lignes qui correspondent s'appliquent
+	HitPriority HitPolicy = "priority" // Seule la ligne de plus haute @priority s'applique
+)
+
+// Directives et colonnes réservées
+const (
+	DirectiveTable = "table"
+	DirectiveHit   = "hit"
+	DirectiveWhen  = "when"
+
+	ColumnPriority    = "@priority"
+	ColumnDescription = "@description"
+)
+
+// ColumnKind est le rôle d'une colonne
+type ColumnKind int
+
+const (
+	ColumnCondition ColumnKind = iota
+	ColumnAction
+	ColumnPriorityKind
+	ColumnDescriptionKind
+)
+
+// Column est une colonne de la table
+type Column struct {
+	Kind     ColumnKind
+	Header   string
+	Subject  string // Condition : expression comparée (o.total)
+	Operator string // Condition : opérateur de l'en-tête, vide s'il est donné par chaque cellule
+	Template string // Action : appel dont les ? reçoivent la cellule
+}
+
+// Row est une ligne de la table ; Cells suit l'ordre des colonnes
+type Row struct {
+	Line  int
+	Cells []string
+}
+
+// Table est une table de décision lue depuis un fichier CSV
+type Table struct {
+	Name    string
+	File    string
+	Hit     HitPolicy
+	When    string // Motifs et condition communs : {o: Order, c: Customer} / o.customer == c
+	Line    int    // Ligne de l'en-tête
+	Columns []Column
+	Rows    []Row
+}
+
+// Opérateurs de comparaison reconnus, les plus longs d'abord
+var operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+var identifierPattern = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_]*$`)
+
+// ParseFile lit une table de décision ; son nom par défaut est celui du
+// fichier sans extension
+func ParseFile(path string) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(data), path)
+}
+
+// Parse lit une table de décision. Les erreurs sont des *tsdio.Diagnostic
+// situés dans le fichier.
+func Parse(r io.Reader, file string) (*Table, error) {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	table := &Table{Name: sanitizeName(name), File: file, Hit: HitUnique}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Directives et commentaires précédant l'en-tête
+	rest, line := data, 0
+	for len(rest) > 0 {
+		text, next, _ := bytes.Cut(rest, []byte("\n"))
+		if !isDirectiveLine(string(text)) && strings.TrimSpace(string(text)) != "" {
+			break
+		}
+		line++
+		if isDirectiveLine(string(text)) {
+			if err := table.parseDirective(string(text), line); err != nil {
+				return nil, err
+			}
+		}
+		rest = next
+	}
+	return parseBody(table, bytes.NewReader(rest), line)
+}
+
+// isDirectiveLine indique si une ligne est une directive ou un commentaire,
+// éventuellement exportée entre guillemets par un tableur
+func isDirectiveLine(text string) bool {
+	text = strings.TrimSpace(text)
+	return strings.HasPrefix(text, "#") || strings.HasPrefix(text, `"#`)
+}
+
+// parseDirective lit « # clé: valeur » ; les autres lignes # sont des commentaires
+func (t *Table) parseDirective(text string, line int) error {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, `"`) {
+		record, err := csv.NewReader(strings.NewReader(text)).Read()
+		if err != nil || len(record) == 0 {
+			return t.diagnostic(line, "directive illisible: %s", text)
+		}
+		text = record[0]
+	} else {
+		// Directive écrite à la main ou cellules vides ajoutées par un tableur
+		text = strings.TrimRight(text, ", \t")
+	}
+
+	key, value, found := strings.Cut(strings.TrimSpace(strings.TrimPrefix(text, "#")), ":")
+	if !found {
+		return nil
+	}
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case DirectiveTable:
+		if !identifierPattern.MatchString(value) {
+			return t.diagnostic(line, "nom de table invalide: '%s'", value)
+		}
+		t.Name = value
+	case DirectiveHit:
+		switch policy := HitPolicy(strings.ToLower(value)); policy {
+		case HitUnique, HitFirst, HitCollect, HitPriority:
+			t.Hit = policy
+		default:
+			return t.diagnostic(line, "politique '%s' inconnue (unique, first, collect, priority)", value)
+		}
+	case DirectiveWhen:
+		t.When = value
+	}
+	return nil
+}
+
+// parseBody lit l'en-tête et les lignes ; offset est le nombre de lignes
+// déjà lues
+func parseBody(table *Table, r io.Reader, offset int) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, table.diagnostic(offset+1, "en-tête absent")
+	}
+	if err != nil {
+		return nil, table.csvError(err, offset)
+	}
+	headerLine, _ := reader.FieldPos(0)
+	table.Line = offset + headerLine
+	if err := table.parseHeader(header, offset+headerLine); err != nil {
+		return nil, err
+	}
+	if table.When == "" {
+		return nil, table.diagnostic(offset+headerLine, "directive « # when: {v: Type} / condition » absente")
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, table.csvError(err, offset)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlank(record) || (len(record) > 0 && isDirectiveLine(record[0])) {
+			continue
+		}
+		if len(record) > len(table.Columns) {
+			if !isBlank(record[len(table.Columns):]) {
+				return nil, table.diagnostic(offset+line, "%d cellules pour %d colonnes", len(record), len(table.Columns))
+			}
+			record = record[:len(table.Columns)]
+		}
+		cells := make([]string, len(table.Columns))
+		for i, cell := range record {
+			cells[i] = strings.TrimSpace(cell)
+		}
+		table.Rows = append(table.Rows, Row{Line: offset + line, Cells: cells})
+	}
+
+	if len(table.Rows) == 0 {
+		return nil, table.diagnostic(offset+headerLine, "table sans ligne")
+	}
+	return table, nil
+}
+
+// parseHeader détermine le rôle de chaque colonne
+func (t *Table) parseHeader(header []string, line int) error {
+	for len(header) > 0 && strings.TrimSpace(header[len(header)-1]) == "" {
+		header = header[:len(header)-1]
+	}
+	hasAction := false
+	for _, text := range header {
+		text = strings.TrimSpace(text)
+		column := Column{Header: text}
+		switch {
+		case text == "":
+			return t.diagnostic(line, "colonne sans en-tête")
+		case strings.EqualFold(text, ColumnPriority):
+			column.Kind = ColumnPriorityKind
+		case strings.EqualFold(text, ColumnDescription):
+			column.Kind = ColumnDescriptionKind
+		case strings.HasPrefix(text, "@"):
+			return t.diagnostic(line, "colonne réservée inconnue: %s (%s, %s)", text, ColumnPriority, ColumnDescription)
+		case strings.HasSuffix(text, ")") && strings.Contains(text, "("):
+			column.Kind = ColumnAction
+			column.Template = text
+			hasAction = true
+		default:
+			column.Kind = ColumnCondition
+			column.Subject = text
+			for _, operator := range operators {
+				if subject, found := strings.CutSuffix(text, operator); found {
+					column.Subject, column.Operator = strings.TrimSpace(subject), operator
+					break
+				}
+			}
+			if column.Subject == "" {
+				return t.diagnostic(line, "colonne '%s' sans expression à comparer", text)
+			}
+		}
+		t.Columns = append(t.Columns, column)
+	}
+
+	if !hasAction {
+		return t.diagnostic(line, "aucune colonne d'action (appel tel que notify(o.id, ?))")
+	}
+	if t.Hit == HitPriority && t.columnIndex(ColumnPriorityKind) < 0 {
+		return t.diagnostic(line, "la politique priority exige une colonne %s", ColumnPriority)
+	}
+	return nil
+}
+
+// columnIndex retourne l'indice de la première colonne de ce rôle, ou -1
+func (t *Table) columnIndex(kind ColumnKind) int {
+	for i, column := range t.Columns {
+		if column.Kind == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// csvError convertit une erreur du lecteur CSV en diagnostic
+func (t *Table) csvError(err error, offset int) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return t.diagnostic(offset+parseErr.Line, "CSV invalide: %v", parseErr.Err)
+	}
+	return err
+}
+
+// diagnostic crée une erreur située à une ligne de la table
+func (t *Table) diagnostic(line int, format string, args ...interface{}) *tsdio.Diagnostic {
+	return tsdio.NewDiagnostic(constraint.DiagnosticCodeSyntax, fmt.Sprintf(format, args...), &tsdio.Span{File: t.File, Line: line})
+}
+
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeName dérive un identifiant TSD d'un nom de fichier
+func sanitizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	result := sb.String()
+	if result == "" || ('0' <= result[0] && result[0] <= '9') {
+		result = "table_" + result
+	}
+	return result
+}
+
+// value est une valeur de cellule
+type value struct {
+	source  string      // Texte TSD : littéral ou expression
+	literal interface{} // float64, string ou bool ; nil pour une expression
+}
+
+// parseValue convertit une cellule en valeur TSD
+func parseValue(cell string) value {
+	if expression, found := strings.CutPrefix(cell, "="); found {
+		return value{source: strings.TrimSpace(expression)}
+	}
+	if number, err := strconv.ParseFloat(cell, 64); err == nil {
+		return value{source: cell, literal: number}
+	}
+	if cell == "true" || cell == "false" {
+		return value{source: cell, literal: cell == "true"}
+	}
+	return value{source: constraint.FormatLiteral(cell), literal: cell}
+}
+
+// cellCondition est la condition posée par une cellule
+type cellCondition struct {
+	column   int
+	subject  string
+	operator string
+	value    value
+}
+
+// String retourne la condition en TSD
+func (c cellCondition) String() string {
+	return c.subject + " " + c.operator + " " + c.value.source
+}
+
+// conditions retourne les conditions d'une ligne, dans l'ordre des colonnes
+func (t *Table) conditions(row Row) []cellCondition {
+	var conditions []cellCondition
+	for i, column := range t.Columns {
+		cell := row.Cells[i]
+		if column.Kind != ColumnCondition || cell == "" || cell == "-" {
+			continue
+		}
+		operator := column.Operator
+		if operator == "" {
+			operator = "=="
+			for _, candidate := range operators {
+				if rest, found := strings.CutPrefix(cell, candidate); found {
+					operator, cell = candidate, strings.TrimSpace(rest)
+					break
+				}
+			}
+		}
+		conditions = append(conditions, cellCondition{column: i, subject: column.Subject, operator: operator, value: parseValue(cell)})
+	}
+	return conditions
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/treivax/tsd/tsdio"
+)
+
+func compileString(t *testing.T, content string) *Compilation {
+	t.Helper()
+	table, err := Parse(strings.NewReader(content), "rules/pricing.csv")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	compilation, _ := Compile(table)
+	return compilation
+}
+
+func rulesByID(c *Compilation) map[string]string {
+	rules := make(map[string]string, len(c.Rules))
+	for _, rule := range c.Rules {
+		rules[rule.ID] = rule.Source
+	}
+	return rules
+}
+
+func diagnosticCodes(c *Compilation) []string {
+	var codes []string
+	for _, d := range c.Diagnostics {
+		codes = append(codes, string(d.Severity)+":"+d.Code)
+	}
+	return codes
+}
+
+func TestParse(t *testing.T) {
+	table, err := Parse(strings.NewReader(`# Remises accordées par le service commercial
+# table: remises
+# hit: First
+# when: {o: Order} / o.total > 0
+
+@description,o.total >=,o.channel,"discount(o.id, ?)",audit(o.id)
+Grosses commandes,1000,web,10,x
+`), "pricing.csv")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if table.Name != "remises" || table.Hit != HitFirst || table.When != "{o: Order} / o.total > 0" || table.Line != 6 {
+		t.Errorf("Parse() = %+v", table)
+	}
+
+	kinds := []ColumnKind{ColumnDescriptionKind, ColumnCondition, ColumnCondition, ColumnAction, ColumnAction}
+	for i, kind := range kinds {
+		if table.Columns[i].Kind != kind {
+			t.Errorf("colonne %d: Kind = %v, want %v", i, table.Columns[i].Kind, kind)
+		}
+	}
+	if column := table.Columns[1]; column.Subject != "o.total" || column.Operator != ">=" {
+		t.Errorf("colonne o.total = %+v", column)
+	}
+	if column := table.Columns[2]; column.Subject != "o.channel" || column.Operator != "" {
+		t.Errorf("colonne o.channel = %+v", column)
+	}
+	if len(table.Rows) != 1 || table.Rows[0].Line != 7 || table.Rows[0].Cells[3] != "10" {
+		t.Errorf("Rows = %+v", table.Rows)
+	}
+}
+
+func TestParse_SpreadsheetExport(t *testing.T) {
+	// Un tableur exporte les directives entre guillemets, complétées de cellules vides
+	table, err := Parse(strings.NewReader(`"# hit: collect",,
+"# when: {o: Order}",,
+o.status,"tag(o.id, ?)",
+new,fresh,
+`), "export.csv")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if table.Name != "export" || table.Hit != HitCollect || table.When != "{o: Order}" {
+		t.Errorf("Parse() = %+v", table)
+	}
+	if len(table.Columns) != 2 || table.Rows[0].Line != 4 {
+		t.Errorf("Columns = %+v, Rows = %+v", table.Columns, table.Rows)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		message string
+	}{
+		{"when absent", "o.total >,notify(o.id)\n1,x\n", 1, "when"},
+		{"politique inconnue", "# hit: all\n", 1, "politique 'all' inconnue"},
+		{"sans action", "# when: {o: Order}\no.total >,o.status\n1,new\n", 2, "aucune colonne d'action"},
+		{"priority sans colonne", "# hit: priority\n# when: {o: Order}\no.total >,notify(o.id)\n1,x\n", 3, "@priority"},
+		{"colonne réservée", "# when: {o: Order}\n@weight,notify(o.id)\n1,x\n", 2, "colonne réservée inconnue"},
+		{"table vide", "# when: {o: Order}\no.total >,notify(o.id)\n", 2, "table sans ligne"},
+		{"cellules en trop", "# when: {o: Order}\no.total >,notify(o.id)\n1,x,y\n", 3, "3 cellules pour 2 colonnes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.content), "t.csv")
+			var diagnostic *tsdio.Diagnostic
+			if !errors.As(err, &diagnostic) {
+				t.Fatalf("Parse() error = %v, attendu un diagnostic", err)
+			}
+			if diagnostic.Span.Line != tt.line || !strings.Contains(diagnostic.Message, tt.message) {
+				t.Errorf("diagnostic = %s, attendu ligne %d et %q", diagnostic.String(), tt.line, tt.message)
+			}
+		})
+	}
+}
+
+func TestCompile_First(t *testing.T) {
+	c := compileString(t, `# hit: first
+# when: {o: Order, c: Customer} / o.customer == c
+@description,o.total >=,c.tier,"discount(o.id, ?)"
+Gros clients gold,1000,gold,20
+Grosses commandes,1000,,10
+Moyennes,100,,5
+Par défaut,,,0
+`)
+	if err := c.Err(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(c.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %v", diagnosticCodes(c))
+	}
+
+	want := map[string]string{
+		"pricing_1": `rule pricing_1 : {o: Order, c: Customer} / o.customer == c AND o.total >= 1000 AND c.tier == "gold" ==> discount(o.id, 20)`,
+		"pricing_2": `rule pricing_2 : {o: Order, c: Customer} / o.customer == c AND o.total >= 1000 AND NOT (o.total >= 1000 AND c.tier == "gold") ==> discount(o.id, 10)`,
+		// La garde de la ligne 1 est impliquée par celle de la ligne 2
+		"pricing_3": `rule pricing_3 : {o: Order, c: Customer} / o.customer == c AND o.total >= 100 AND NOT (o.total >= 1000) ==> discount(o.id, 5)`,
+		"pricing_4": `rule pricing_4 : {o: Order, c: Customer} / o.customer == c AND NOT (o.total >= 1000) AND NOT (o.total >= 100) ==> discount(o.id, 0)`,
+	}
+	rules := rulesByID(c)
+	for id, source := range want {
+		if rules[id] != source {
+			t.Errorf("%s =\n  %s\nwant\n  %s", id, rules[id], source)
+		}
+	}
+
+	source := c.Source()
+	for _, expected := range []string{"politique first", "// Ligne 4 : Gros clients gold\nrule pricing_1"} {
+		if !strings.Contains(source, expected) {
+			t.Errorf("Source() ne contient pas %q:\n%s", expected, source)
+		}
+	}
+}
+
+func TestCompile_FirstUnreachable(t *testing.T) {
+	c := compileString(t, `# hit: first
+# when: {o: Order}
+o.total >,notify(o.id)
+100,x
+500,x
+`)
+	if err := c.Err(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if codes := diagnosticCodes(c); len(codes) != 2 || codes[0] != "warning:"+CodeGap || codes[1] != "warning:"+CodeUnreachable {
+		t.Errorf("Diagnostics = %v", codes)
+	}
+	if len(c.Rules) != 1 || c.Rules[0].ID != "pricing_1" {
+		t.Errorf("Rules = %+v", c.Rules)
+	}
+}
+
+func TestCompile_UniqueOverlap(t *testing.T) {
+	c := compileString(t, `# when: {p: Person}
+p.age >=,p.age <,p.country,"eligible(p.id, ?)"
+18,65,FR,true
+60,,FR,true
+`)
+	err := c.Err()
+	if err == nil || !strings.Contains(err.Error(), "chevauche la ligne 3 (p.age = 60, p.country = \"FR\")") {
+		t.Fatalf("Err() = %v", err)
+	}
+	// La couverture incomplète est un avertissement
+	if codes := diagnosticCodes(c); codes[0] != "warning:"+CodeGap || codes[1] != "error:"+CodeOverlap {
+		t.Errorf("Diagnostics = %v", codes)
+	}
+}
+
+func TestCompile_Contradiction(t *testing.T) {
+	c := compileString(t, `# hit: collect
+# when: {p: Person}
+p.age >=,p.age <,"tag(p.id, ?)",note(p.id)
+30,20,adult,
+10,,,-
+`)
+	var diagnostic *tsdio.Diagnostic
+	if !errors.As(c.Err(), &diagnostic) || diagnostic.Code != CodeContradiction || diagnostic.Span.Line != 4 {
+		t.Fatalf("Err() = %v", c.Err())
+	}
+	// Une ligne sans action est ignorée hors politiques exclusives
+	found := false
+	for _, d := range c.Diagnostics {
+		found = found || (d.Code == CodeNoAction && d.Span.Line == 5)
+	}
+	if !found {
+		t.Errorf("Diagnostics = %v", diagnosticCodes(c))
+	}
+}
+
+func TestCompile_Priority(t *testing.T) {
+	c := compileString(t, `"# hit: priority",,,
+"# when: {o: Order} / o.total > 0",,,
+@priority,o.status,o.total >,"tag(o.id, ?)"
+5,,1000,=o.total * 0.1
+10,blocked,,stop
+1,,,default
+`)
+	if err := c.Err(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	want := map[string]string{
+		"pricing_1": `rule pricing_1 : {o: Order} / o.total > 0 AND o.total > 1000 AND NOT (o.status == "blocked") ==> tag(o.id, o.total * 0.1)`,
+		"pricing_2": `rule pricing_2 : {o: Order} / o.total > 0 AND o.status == "blocked" ==> tag(o.id, "stop")`,
+		"pricing_3": `rule pricing_3 : {o: Order} / o.total > 0 AND NOT (o.status == "blocked") AND NOT (o.total > 1000) ==> tag(o.id, "default")`,
+	}
+	rules := rulesByID(c)
+	for id, source := range want {
+		if rules[id] != source {
+			t.Errorf("%s =\n  %s\nwant\n  %s", id, rules[id], source)
+		}
+	}
+	// Les règles restent dans l'ordre de la table
+	if c.Rules[0].ID != "pricing_1" || c.Rules[0].Line != 4 {
+		t.Errorf("Rules = %+v", c.Rules)
+	}
+}
+
+func TestCompile_PriorityTie(t *testing.T) {
+	c := compileString(t, `# hit: priority
+# when: {o: Order}
+@priority,o.status,o.total >,"tag(o.id, ?)"
+5,,1000,big
+5,new,,new
+`)
+	err := c.Err()
+	if err == nil || !strings.Contains(err.Error(), "chevauche la ligne 4 de même priorité") {
+		t.Errorf("Err() = %v", err)
+	}
+
+	c = compileString(t, `# hit: priority
+# when: {o: Order}
+@priority,o.status,"tag(o.id, ?)"
+haute,new,x
+`)
+	if err := c.Err(); err == nil || !strings.Contains(err.Error(), "priorité invalide: 'haute'") {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestCompile_Values(t *testing.T) {
+	c := compileString(t, `# hit: collect
+# when: {o: Order, c: Customer} / o.customer == c OR c.tier == "vip"
+o.channel,o.total,c.vip,"note(o.id, ?)",flag(o.id)
+web,>= =c.limit,true,"L'équipe ""web""",x
+!= store,,-,42,
+`)
+	if err := c.Err(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	want := map[string]string{
+		"pricing_1": `rule pricing_1 : {o: Order, c: Customer} / (o.customer == c OR c.tier == "vip") AND o.channel == "web" AND o.total >= c.limit AND c.vip == true ==> note(o.id, "L'équipe \"web\""), flag(o.id)`,
+		"pricing_2": `rule pricing_2 : {o: Order, c: Customer} / (o.customer == c OR c.tier == "vip") AND o.channel != "store" ==> note(o.id, 42)`,
+	}
+	rules := rulesByID(c)
+	for id, source := range want {
+		if rules[id] != source {
+			t.Errorf("%s =\n  %s\nwant\n  %s", id, rules[id], source)
+		}
+	}
+	// Une cellule expression rend l'analyse de couverture impossible
+	if codes := diagnosticCodes(c); len(codes) != 1 || codes[0] != "info:"+CodeNotAnalyzed {
+		t.Errorf("Diagnostics = %v", codes)
+	}
+}
+
+func TestCompile_InvalidGeneratedRule(t *testing.T) {
+	c := compileString(t, `# when: {o: Order}
+o.total >,"notify(o.id, ?)"
+1,=o.total +
+`)
+	var diagnostic *tsdio.Diagnostic
+	if !errors.As(c.Err(), &diagnostic) || diagnostic.Span.Line != 3 || !strings.Contains(diagnostic.Message, "règle générée invalide") {
+		t.Errorf("Err() = %v", c.Err())
+	}
+}
+
+func TestFillTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		value    string
+		want     string
+	}{
+		{"discount(o.id, ?)", "10", "discount(o.id, 10)"},
+		{`log("why?", ?)`, `"x"`, `log("why?", "x")`},
+		{"flag(o.id)", "true", "flag(o.id)"},
+	}
+	for _, tt := range tests {
+		if got := fillTemplate(tt.template, tt.value); got != tt.want {
+			t.Errorf("fillTemplate(%q, %q) = %q, want %q", tt.template, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Rapport JUnit XML, lu par les serveurs d'intégration continue
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit écrit les résultats au format JUnit XML : une testsuite par
+// fichier .tsdtest, un testcase par cas de test
+func WriteJUnit(w io.Writer, results []*SuiteResult) error {
+	report := junitTestSuites{}
+	var total float64
+	for _, suite := range results {
+		junitSuite := junitTestSuite{Name: suite.File, Tests: len(suite.Results), Time: seconds(suite.Duration.Seconds())}
+		for _, result := range suite.Results {
+			testCase := junitTestCase{Name: result.Name, ClassName: suite.File, Time: seconds(result.Duration.Seconds())}
+			switch {
+			case result.Error != "":
+				testCase.Error = &junitMessage{Message: result.Error, Body: result.Error}
+				junitSuite.Errors++
+			case len(result.Failures) > 0:
+				testCase.Failure = &junitMessage{
+					Message: fmt.Sprintf("%d attente(s) non satisfaite(s)", len(result.Failures)),
+					Body:    strings.Join(result.Failures, "\n"),
+				}
+				junitSuite.Failures++
+			}
+			junitSuite.Cases = append(junitSuite.Cases, testCase)
+		}
+		report.Tests += junitSuite.Tests
+		report.Failures += junitSuite.Failures
+		report.Errors += junitSuite.Errors
+		total += suite.Duration.Seconds()
+		report.Suites = append(report.Suites, junitSuite)
+	}
+	report.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(value float64) string {
+	return fmt.Sprintf("%.3f", value)
+}
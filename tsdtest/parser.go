@@ -0,0 +1,475 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Types d'attentes
+const (
+	ExpectActivation = "activation"
+	ExpectFact       = "fact"
+	ExpectXuple      = "xuple"
+)
+
+// Suite est le contenu d'un fichier .tsdtest : les fichiers de règles testés
+// et les cas de test
+type Suite struct {
+	File  string   // Fichier .tsdtest
+	Rules []string // Fichiers de règles, relatifs au répertoire de la suite
+	Tests []*Case
+}
+
+// Case est un cas de test : des faits donnés et les attentes sur le résultat
+type Case struct {
+	Name         string
+	Line         int
+	Given        string // Programme TSD (faits) ingéré en simulation
+	Expectations []*Expectation
+}
+
+// Expectation est une ligne « expect » d'un cas de test
+type Expectation struct {
+	Line    int
+	Text    string // Ligne source, pour les messages d'échec
+	Kind    string // activation, fact ou xuple
+	Negated bool   // expect no ...
+	Times   int    // Nombre exact d'occurrences attendues (-1 : au moins une)
+
+	Rule     string            // activation : règle
+	Bindings map[string]string // activation : variable → fait (Type~id)
+	Action   string            // activation : action appelée (optionnelle)
+	Args     []interface{}     // activation : arguments de l'action
+	HasArgs  bool              // activation : arguments donnés entre parenthèses
+
+	FactID string                 // fact : identifiant interne
+	Space  string                 // xuple : xuple-space
+	Type   string                 // xuple : type du fait (optionnel)
+	Fields map[string]interface{} // fact, xuple : champs attendus (sous-ensemble)
+}
+
+// ParseFile lit un fichier .tsdtest
+func ParseFile(filename string) (*Suite, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filename, string(content))
+}
+
+// Parse analyse le contenu d'un fichier .tsdtest.
+//
+//	rules "orders.tsd"
+//
+//	test "grosse commande signalée" {
+//	    given {
+//	        Order(id: "o1", total: 500)
+//	    }
+//	    expect activation flag(o: Order~o1) calls notify("o1")
+//	    expect fact Order~o1 {status: "review"}
+//	    expect xuple alerts Alert {order: "o1"}
+//	    expect no activation publish
+//	}
+func Parse(filename, content string) (*Suite, error) {
+	suite := &Suite{File: filename}
+	lines := strings.Split(content, "\n")
+	fail := func(line int, format string, args ...interface{}) error {
+		return fmt.Errorf("%s:%d: %s", filename, line, fmt.Sprintf(format, args...))
+	}
+
+	var current *Case
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := stripComment(lines[i])
+		if line == "" {
+			continue
+		}
+		keyword, rest := splitKeyword(line)
+
+		if current == nil {
+			switch keyword {
+			case "rules":
+				path, err := strconv.Unquote(rest)
+				if err != nil {
+					return nil, fail(lineNumber, "rules attend un chemin entre guillemets")
+				}
+				suite.Rules = append(suite.Rules, path)
+			case "test":
+				if !strings.HasSuffix(rest, "{") {
+					return nil, fail(lineNumber, "test attend un nom suivi de {")
+				}
+				name, err := strconv.Unquote(strings.TrimSpace(strings.TrimSuffix(rest, "{")))
+				if err != nil {
+					return nil, fail(lineNumber, "nom de test invalide")
+				}
+				current = &Case{Name: name, Line: lineNumber}
+			default:
+				return nil, fail(lineNumber, "instruction inattendue %q (rules ou test)", keyword)
+			}
+			continue
+		}
+
+		switch keyword {
+		case "}":
+			suite.Tests = append(suite.Tests, current)
+			current = nil
+		case "given":
+			if rest != "{" {
+				return nil, fail(lineNumber, "given attend {")
+			}
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "}" {
+				end++
+			}
+			if end == len(lines) {
+				return nil, fail(lineNumber, "bloc given non fermé")
+			}
+			current.Given += strings.Join(lines[i+1:end], "\n") + "\n"
+			i = end
+		case "expect":
+			expectation, err := parseExpectation(rest)
+			if err != nil {
+				return nil, fail(lineNumber, "%v", err)
+			}
+			expectation.Line, expectation.Text = lineNumber, line
+			current.Expectations = append(current.Expectations, expectation)
+		default:
+			return nil, fail(lineNumber, "instruction inattendue %q (given, expect ou })", keyword)
+		}
+	}
+
+	if current != nil {
+		return nil, fail(current.Line, "test %q non fermé", current.Name)
+	}
+	if len(suite.Rules) == 0 {
+		return nil, fmt.Errorf("%s: aucun fichier de règles (rules \"fichier.tsd\")", filename)
+	}
+	return suite, nil
+}
+
+// RulePaths retourne les chemins des fichiers de règles, résolus par rapport
+// au répertoire de la suite
+func (s *Suite) RulePaths() []string {
+	paths := make([]string, len(s.Rules))
+	for i, path := range s.Rules {
+		if filepath.IsAbs(path) {
+			paths[i] = path
+		} else {
+			paths[i] = filepath.Join(filepath.Dir(s.File), path)
+		}
+	}
+	return paths
+}
+
+// stripComment retire un commentaire // hors chaîne et les espaces
+func stripComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inString:
+			i++
+		case line[i] == '"':
+			inString = !inString
+		case !inString && strings.HasPrefix(line[i:], "//"):
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// splitKeyword sépare le premier mot du reste de la ligne
+func splitKeyword(line string) (string, string) {
+	if index := strings.IndexFunc(line, unicode.IsSpace); index >= 0 {
+		return line[:index], strings.TrimSpace(line[index:])
+	}
+	return line, ""
+}
+
+// parseExpectation analyse ce qui suit « expect »
+func parseExpectation(text string) (*Expectation, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &expectParser{tokens: tokens}
+	expectation := &Expectation{Times: -1}
+
+	if p.peekWord("no") {
+		p.next()
+		expectation.Negated = true
+	}
+	kind := p.next()
+	switch kind.text {
+	case ExpectActivation:
+		err = p.activation(expectation)
+	case ExpectFact:
+		err = p.fact(expectation)
+	case ExpectXuple:
+		err = p.xuple(expectation)
+	default:
+		return nil, fmt.Errorf("attente inconnue %q (activation, fact ou xuple)", kind.text)
+	}
+	if err != nil {
+		return nil, err
+	}
+	expectation.Kind = kind.text
+
+	if p.peekWord("times") {
+		p.next()
+		count := p.next()
+		times, convErr := strconv.Atoi(count.text)
+		if convErr != nil || count.kind != tokenWord || times < 0 {
+			return nil, fmt.Errorf("times attend un entier positif")
+		}
+		if expectation.Negated {
+			return nil, fmt.Errorf("times est incompatible avec no")
+		}
+		expectation.Times = times
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("texte inattendu %q", p.peek().text)
+	}
+	return expectation, nil
+}
+
+// activation : <règle>[(<variable>: <fait>, ...)] [calls <action>[(<arguments>)]]
+func (p *expectParser) activation(expectation *Expectation) error {
+	rule := p.next()
+	if rule.kind != tokenWord {
+		return fmt.Errorf("activation attend un nom de règle")
+	}
+	expectation.Rule = rule.text
+
+	if p.peekPunct("(") {
+		p.next()
+		expectation.Bindings = make(map[string]string)
+		for !p.peekPunct(")") {
+			variable, fact := p.next(), token{}
+			if variable.kind != tokenWord || !p.expectPunct(":") {
+				return fmt.Errorf("liaison attendue (variable: Type~id)")
+			}
+			if fact = p.next(); fact.kind != tokenWord {
+				return fmt.Errorf("liaison attendue (variable: Type~id)")
+			}
+			expectation.Bindings[variable.text] = fact.text
+			if !p.peekPunct(")") && !p.expectPunct(",") {
+				return fmt.Errorf("liaisons séparées par des virgules attendues")
+			}
+		}
+		p.next()
+	}
+
+	if p.peekWord("calls") {
+		p.next()
+		action := p.next()
+		if action.kind != tokenWord {
+			return fmt.Errorf("calls attend un nom d'action")
+		}
+		expectation.Action = action.text
+		if p.peekPunct("(") {
+			p.next()
+			expectation.HasArgs = true
+			expectation.Args = []interface{}{}
+			for !p.peekPunct(")") {
+				value, err := p.value()
+				if err != nil {
+					return err
+				}
+				expectation.Args = append(expectation.Args, value)
+				if !p.peekPunct(")") && !p.expectPunct(",") {
+					return fmt.Errorf("arguments séparés par des virgules attendus")
+				}
+			}
+			p.next()
+		}
+	}
+	return nil
+}
+
+// fact : <Type~id> [{champ: valeur, ...}]
+func (p *expectParser) fact(expectation *Expectation) error {
+	factID := p.next()
+	if factID.kind != tokenWord || !strings.Contains(factID.text, "~") {
+		return fmt.Errorf("fact attend un identifiant interne (Type~id)")
+	}
+	expectation.FactID = factID.text
+	if p.peekPunct("{") {
+		if expectation.Negated {
+			return fmt.Errorf("expect no fact n'accepte pas de champs")
+		}
+		fields, err := p.fields()
+		if err != nil {
+			return err
+		}
+		expectation.Fields = fields
+	}
+	return nil
+}
+
+// xuple : <xuple-space> [<Type>] [{champ: valeur, ...}]
+func (p *expectParser) xuple(expectation *Expectation) error {
+	space := p.next()
+	if space.kind != tokenWord {
+		return fmt.Errorf("xuple attend un nom de xuple-space")
+	}
+	expectation.Space = space.text
+	if next := p.peek(); next.kind == tokenWord && next.text != "times" {
+		expectation.Type = p.next().text
+	}
+	if p.peekPunct("{") {
+		fields, err := p.fields()
+		if err != nil {
+			return err
+		}
+		expectation.Fields = fields
+	}
+	return nil
+}
+
+// fields : {champ: valeur, ...}
+func (p *expectParser) fields() (map[string]interface{}, error) {
+	p.next()
+	fields := make(map[string]interface{})
+	for !p.peekPunct("}") {
+		name := p.next()
+		if name.kind != tokenWord || !p.expectPunct(":") {
+			return nil, fmt.Errorf("champ attendu (nom: valeur)")
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		fields[name.text] = value
+		if !p.peekPunct("}") && !p.expectPunct(",") {
+			return nil, fmt.Errorf("champs séparés par des virgules attendus")
+		}
+	}
+	p.next()
+	return fields, nil
+}
+
+// value : chaîne, nombre, booléen ou identifiant de fait (comparé comme une chaîne)
+func (p *expectParser) value() (interface{}, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return tok.text, nil
+	case tokenWord:
+		switch tok.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		if number, err := strconv.ParseFloat(tok.text, 64); err == nil {
+			return number, nil
+		}
+		return tok.text, nil
+	}
+	return nil, fmt.Errorf("valeur attendue")
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize découpe une attente en mots, chaînes et ponctuation
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.IndexByte("(){},:", c) >= 0:
+			tokens = append(tokens, token{tokenPunct, string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(text) {
+				return nil, fmt.Errorf("chaîne non fermée")
+			}
+			value, err := strconv.Unquote(text[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("chaîne invalide %s", text[i:end+1])
+			}
+			tokens = append(tokens, token{tokenString, value})
+			i = end + 1
+		default:
+			end := i
+			for end < len(text) && strings.IndexByte(" \t(){},:\"", text[end]) < 0 {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, text[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// expectParser parcourt les jetons d'une attente
+type expectParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *expectParser) peek() token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return token{kind: tokenEnd}
+}
+
+func (p *expectParser) next() token {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *expectParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *expectParser) peekWord(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && tok.text == word
+}
+
+func (p *expectParser) peekPunct(punct string) bool {
+	tok := p.peek()
+	return tok.kind == tokenPunct && tok.text == punct
+}
+
+func (p *expectParser) expectPunct(punct string) bool {
+	if p.peekPunct(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/treivax/tsd/api"
+	"github.com/treivax/tsd/rete"
+)
+
+// Result est le résultat d'un cas de test
+type Result struct {
+	Name     string        `json:"name"`
+	Line     int           `json:"line"`
+	Duration time.Duration `json:"duration"`
+	Failures []string      `json:"failures,omitempty"` // Attentes non satisfaites, avec le résultat obtenu
+	Error    string        `json:"error,omitempty"`    // Erreur d'exécution (règles, faits donnés)
+}
+
+// Passed indique si le cas de test a réussi
+func (r *Result) Passed() bool {
+	return r.Error == "" && len(r.Failures) == 0
+}
+
+// SuiteResult est le résultat d'un fichier .tsdtest
+type SuiteResult struct {
+	File     string              `json:"file"`
+	Duration time.Duration       `json:"duration"`
+	Results  []*Result           `json:"results"`
+	Coverage []rete.RuleCoverage `json:"coverage"` // Couverture cumulée des règles testées
+}
+
+// Failed retourne le nombre de cas en échec
+func (s *SuiteResult) Failed() int {
+	failed := 0
+	for _, result := range s.Results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// outcome est ce qu'a produit un cas de test : le rapport de simulation et
+// l'état final des faits
+type outcome struct {
+	report *rete.SimulationReport
+	facts  map[string]*rete.Fact
+}
+
+// RunSuite exécute chaque cas de test dans un pipeline isolé : les fichiers de
+// règles sont ingérés, puis les faits donnés le sont en simulation (les
+// actions personnalisées, Print et Xuple sont enregistrées, pas exécutées).
+func RunSuite(ctx context.Context, suite *Suite) *SuiteResult {
+	start := time.Now()
+	result := &SuiteResult{File: suite.File, Results: make([]*Result, 0, len(suite.Tests))}
+	for _, test := range suite.Tests {
+		testResult, coverage := runCase(ctx, suite, test)
+		result.Results = append(result.Results, testResult)
+		result.Coverage = MergeCoverage(result.Coverage, coverage)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// runCase exécute un cas de test et retourne la couverture de ses règles
+func runCase(ctx context.Context, suite *Suite, test *Case) (*Result, []rete.RuleCoverage) {
+	start := time.Now()
+	result := &Result{Name: test.Name, Line: test.Line}
+	defer func() { result.Duration = time.Since(start) }()
+
+	config := api.DefaultConfig()
+	config.LogLevel = api.LogLevelSilent
+	pipeline := api.NewPipelineWithConfig(config)
+
+	if len(suite.Rules) == 0 {
+		result.Error = "aucun fichier de règles"
+		return result, nil
+	}
+	var network *rete.ReteNetwork
+	for _, path := range suite.RulePaths() {
+		ingested, err := pipeline.IngestFileContext(ctx, path)
+		if err != nil {
+			result.Error = err.Error()
+			return result, nil
+		}
+		network = ingested.Network()
+	}
+
+	recorder := rete.NewCoverageRecorder()
+	network.SetTracer(rete.NewTracer(recorder))
+	report, err := pipeline.Simulate(ctx, test.Given)
+	coverage := network.RuleCoverage(recorder)
+	network.SetTracer(nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result, coverage
+	}
+
+	out := outcome{report: report, facts: finalFacts(network.Storage, report)}
+	for _, expectation := range test.Expectations {
+		if failure := out.check(expectation); failure != "" {
+			result.Failures = append(result.Failures, fmt.Sprintf("ligne %d: %s\n%s", expectation.Line, expectation.Text, failure))
+		}
+	}
+	return result, coverage
+}
+
+// finalFacts reconstitue l'état des faits à la fin de la simulation : la
+// mémoire de travail du pipeline (inchangée) corrigée des différences du rapport
+func finalFacts(storage rete.Storage, report *rete.SimulationReport) map[string]*rete.Fact {
+	facts := make(map[string]*rete.Fact)
+	for _, fact := range storage.GetAllFacts() {
+		facts[fact.GetInternalID()] = fact
+	}
+	for _, change := range report.Facts {
+		if change.Change == rete.FactChangeRetracted {
+			delete(facts, change.FactID)
+			continue
+		}
+		facts[change.FactID] = &rete.Fact{ID: change.FactID, Type: change.Type, Fields: change.After}
+	}
+	return facts
+}
+
+// check vérifie une attente ; retourne la description de l'échec (vide : satisfaite)
+func (o outcome) check(expectation *Expectation) string {
+	switch expectation.Kind {
+	case ExpectActivation:
+		return o.checkActivation(expectation)
+	case ExpectFact:
+		return o.checkFact(expectation)
+	default:
+		return o.checkXuple(expectation)
+	}
+}
+
+func (o outcome) checkActivation(expectation *Expectation) string {
+	count := 0
+	var actual []string
+	for _, activation := range o.report.Activations {
+		if activation.Rule != expectation.Rule {
+			continue
+		}
+		actual = append(actual, formatActivation(activation))
+		if activationMatches(activation, expectation) {
+			count++
+		}
+	}
+	if failure := countFailure(expectation, count); failure != "" {
+		if len(actual) == 0 {
+			return failure + fmt.Sprintf("\n  obtenu : aucune activation de %s", expectation.Rule)
+		}
+		return failure + "\n  obtenu :\n    " + strings.Join(actual, "\n    ")
+	}
+	return ""
+}
+
+func activationMatches(activation rete.SimulatedActivation, expectation *Expectation) bool {
+	for variable, factID := range expectation.Bindings {
+		if activation.Bindings[variable] != factID {
+			return false
+		}
+	}
+	if expectation.Action == "" {
+		return true
+	}
+	for _, action := range activation.Actions {
+		if action.Name == expectation.Action && (!expectation.HasArgs || valuesEqual(action.Args, expectation.Args)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o outcome) checkFact(expectation *Expectation) string {
+	fact := o.facts[expectation.FactID]
+	switch {
+	case expectation.Negated && fact != nil:
+		return fmt.Sprintf("  attendu : aucun fait %s\n  obtenu : %s", expectation.FactID, formatFields(fact.Fields))
+	case expectation.Negated:
+		return ""
+	case fact == nil:
+		return fmt.Sprintf("  attendu : fait %s\n  obtenu : aucun fait %s", expectation.FactID, expectation.FactID)
+	}
+
+	var diffs []string
+	for _, name := range sortedNames(expectation.Fields) {
+		want := expectation.Fields[name]
+		got, ok := fact.Fields[name]
+		if !ok || !valueEqual(got, want) {
+			diffs = append(diffs, fmt.Sprintf("  %s : attendu %s, obtenu %s", name, formatValue(want), formatOptional(got, ok)))
+		}
+	}
+	return strings.Join(diffs, "\n")
+}
+
+func (o outcome) checkXuple(expectation *Expectation) string {
+	count := 0
+	var actual []string
+	for _, xuple := range o.report.Xuples {
+		if xuple.Space != expectation.Space {
+			continue
+		}
+		actual = append(actual, xuple.Type+" "+formatFields(xuple.Fields))
+		if expectation.Type != "" && xuple.Type != expectation.Type {
+			continue
+		}
+		if fieldsMatch(xuple.Fields, expectation.Fields) {
+			count++
+		}
+	}
+	if failure := countFailure(expectation, count); failure != "" {
+		if len(actual) == 0 {
+			return failure + fmt.Sprintf("\n  obtenu : aucun xuple dans %s", expectation.Space)
+		}
+		return failure + "\n  obtenu :\n    " + strings.Join(actual, "\n    ")
+	}
+	return ""
+}
+
+// countFailure compare le nombre d'occurrences à l'attente (vide : satisfaite)
+func countFailure(expectation *Expectation, count int) string {
+	switch {
+	case expectation.Negated && count > 0:
+		return fmt.Sprintf("  attendu : aucune occurrence, obtenu : %d", count)
+	case expectation.Negated:
+		return ""
+	case expectation.Times >= 0 && count != expectation.Times:
+		return fmt.Sprintf("  attendu : %d occurrence(s), obtenu : %d", expectation.Times, count)
+	case expectation.Times < 0 && count == 0:
+		return "  attendu : au moins une occurrence, obtenu : 0"
+	}
+	return ""
+}
+
+func fieldsMatch(fields, want map[string]interface{}) bool {
+	for name, value := range want {
+		got, ok := fields[name]
+		if !ok || !valueEqual(got, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func valuesEqual(got, want []interface{}) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !valueEqual(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqual compare deux valeurs ; les nombres sont comparés quel que soit leur type Go
+func valueEqual(got, want interface{}) bool {
+	if wantNumber, ok := toFloat(want); ok {
+		gotNumber, isNumber := toFloat(got)
+		return isNumber && gotNumber == wantNumber
+	}
+	return fmt.Sprint(got) == fmt.Sprint(want)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func formatActivation(activation rete.SimulatedActivation) string {
+	var bindings []string
+	for _, variable := range sortedStringKeys(activation.Bindings) {
+		bindings = append(bindings, variable+": "+activation.Bindings[variable])
+	}
+	text := activation.Rule + "(" + strings.Join(bindings, ", ") + ")"
+	for _, action := range activation.Actions {
+		args := make([]string, len(action.Args))
+		for i, arg := range action.Args {
+			args[i] = formatValue(arg)
+		}
+		text += " calls " + action.Name + "(" + strings.Join(args, ", ") + ")"
+	}
+	return text
+}
+
+func formatFields(fields map[string]interface{}) string {
+	parts := make([]string, 0, len(fields))
+	for _, name := range sortedNames(fields) {
+		if name == rete.FieldNameID {
+			continue
+		}
+		parts = append(parts, name+": "+formatValue(fields[name]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func formatValue(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return fmt.Sprintf("%q", text)
+	}
+	return fmt.Sprint(value)
+}
+
+func formatOptional(value interface{}, ok bool) string {
+	if !ok {
+		return "(absent)"
+	}
+	return formatValue(value)
+}
+
+func sortedNames(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedStringKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MergeCoverage cumule deux couvertures : les compteurs des mêmes règles et
+// des mêmes nœuds sont additionnés
+func MergeCoverage(total, coverage []rete.RuleCoverage) []rete.RuleCoverage {
+	rules := make(map[string]int, len(total))
+	for i, rule := range total {
+		rules[rule.Rule] = i
+	}
+	for _, rule := range coverage {
+		index, exists := rules[rule.Rule]
+		if !exists {
+			rule.Conditions = append([]rete.ConditionCoverage(nil), rule.Conditions...)
+			rules[rule.Rule] = len(total)
+			total = append(total, rule)
+			continue
+		}
+		merged := &total[index]
+		merged.Fired += rule.Fired
+		for _, condition := range rule.Conditions {
+			found := false
+			for i := range merged.Conditions {
+				if merged.Conditions[i].NodeID == condition.NodeID {
+					merged.Conditions[i].Evaluated += condition.Evaluated
+					merged.Conditions[i].Passed += condition.Passed
+					found = true
+					break
+				}
+			}
+			if !found {
+				merged.Conditions = append(merged.Conditions, condition)
+			}
+		}
+	}
+	sort.Slice(total, func(i, j int) bool { return total[i].Rule < total[j].Rule })
+	return total
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 TSD Contributors
+// Licensed under the MIT License
+// See LICENSE file in the project root for full license text
+
+package tsdtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testRules = `xuple-space alerts {
+    selection: fifo
+    consumption: once
+}
+
+type Order(#id: string, total: number, status: string)
+type Alert(#order: string, level: string)
+action notify(id: string)
+
+rule flag : {o: Order} / o.total > 100 AND o.status == "new" ==>
+    Update(o, {status: "review"}),
+    Insert(Alert(order: o.id, level: "high")),
+    notify(o.id)
+
+rule publish : {a: Alert} / ==> Xuple("alerts", a)
+
+rule cancelled : {o: Order} / o.status == "cancelled" ==> notify(o.id)
+`
+
+const testSuite = `// Commandes à vérifier
+rules "orders.tsd"
+
+test "grosse commande signalée" {
+    given {
+        Order(id: "o1", total: 500, status: "new")
+    }
+    expect activation flag(o: Order~o1) calls notify("o1")
+    expect activation flag calls Update(Order~o1) times 1
+    expect fact Order~o1 {status: "review", total: 500}
+    expect fact Alert~o1 {level: "high"}
+    expect xuple alerts Alert {order: "o1"}
+    expect no activation cancelled
+}
+
+test "attentes non satisfaites" {
+    given {
+        Order(id: "o2", total: 50, status: "new")
+    }
+    expect activation flag
+    expect fact Order~o2 {status: "review"}
+    expect no fact Order~o2
+    expect xuple alerts times 2
+}
+`
+
+func writeSuite(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.tsd"), []byte(testRules), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	path := filepath.Join(dir, "orders.tsdtest")
+	if err := os.WriteFile(path, []byte(testSuite), 0644); err != nil {
+		t.Fatalf("❌ Impossible d'écrire le fichier: %v", err)
+	}
+	return path
+}
+
+func TestParse(t *testing.T) {
+	suite, err := Parse("orders.tsdtest", testSuite)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(suite.Rules) != 1 || suite.Rules[0] != "orders.tsd" || len(suite.Tests) != 2 {
+		t.Fatalf("suite = %+v", suite)
+	}
+	test := suite.Tests[0]
+	if test.Name != "grosse commande signalée" || test.Line != 4 || !strings.Contains(test.Given, `Order(id: "o1"`) {
+		t.Errorf("test = %+v", test)
+	}
+	if len(test.Expectations) != 6 {
+		t.Fatalf("expectations = %d, want 6", len(test.Expectations))
+	}
+
+	activation := test.Expectations[0]
+	if activation.Kind != ExpectActivation || activation.Rule != "flag" || activation.Bindings["o"] != "Order~o1" ||
+		activation.Action != "notify" || len(activation.Args) != 1 || activation.Args[0] != "o1" || activation.Times != -1 {
+		t.Errorf("activation = %+v", activation)
+	}
+	if times := test.Expectations[1]; times.Times != 1 || times.Args[0] != "Order~o1" {
+		t.Errorf("times = %+v", times)
+	}
+	if fact := test.Expectations[2]; fact.FactID != "Order~o1" || fact.Fields["total"] != 500.0 || fact.Line != 10 {
+		t.Errorf("fact = %+v", fact)
+	}
+	if xuple := test.Expectations[4]; xuple.Space != "alerts" || xuple.Type != "Alert" || xuple.Fields["order"] != "o1" {
+		t.Errorf("xuple = %+v", xuple)
+	}
+	if negated := test.Expectations[5]; !negated.Negated || negated.Rule != "cancelled" {
+		t.Errorf("negated = %+v", negated)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no rules", "test \"t\" {\n}\n", "aucun fichier de règles"},
+		{"unclosed test", "rules \"a.tsd\"\ntest \"t\" {\n", "t.tsdtest:2: test \"t\" non fermé"},
+		{"unclosed given", "rules \"a.tsd\"\ntest \"t\" {\ngiven {\n", "t.tsdtest:3: bloc given non fermé"},
+		{"unknown expectation", "rules \"a.tsd\"\ntest \"t\" {\nexpect rule r\n}\n", "t.tsdtest:3: attente inconnue"},
+		{"bad fact id", "rules \"a.tsd\"\ntest \"t\" {\nexpect fact o1\n}\n", "Type~id"},
+		{"no with times", "rules \"a.tsd\"\ntest \"t\" {\nexpect no activation r times 2\n}\n", "incompatible"},
+		{"trailing text", "rules \"a.tsd\"\ntest \"t\" {\nexpect activation r extra\n}\n", "texte inattendu"},
+		{"unexpected", "rules \"a.tsd\"\ngiven {\n", "t.tsdtest:2: instruction inattendue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("t.tsdtest", tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSuite(t *testing.T) {
+	suite, err := ParseFile(writeSuite(t))
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	result := RunSuite(context.Background(), suite)
+	if len(result.Results) != 2 || result.Failed() != 1 {
+		t.Fatalf("results = %+v, want 1 passed and 1 failed", result.Results)
+	}
+
+	passed := result.Results[0]
+	if !passed.Passed() {
+		t.Errorf("first test failed: %v %s", passed.Failures, passed.Error)
+	}
+
+	failed := result.Results[1]
+	if len(failed.Failures) != 4 {
+		t.Fatalf("failures = %d, want 4:\n%s", len(failed.Failures), strings.Join(failed.Failures, "\n"))
+	}
+	for i, want := range []string{
+		"aucune activation de flag",
+		`status : attendu "review", obtenu "new"`,
+		"attendu : aucun fait Order~o2",
+		"attendu : 2 occurrence(s), obtenu : 0",
+	} {
+		if !strings.Contains(failed.Failures[i], want) {
+			t.Errorf("failure %d = %q, want mention of %q", i, failed.Failures[i], want)
+		}
+	}
+
+	coverage := make(map[string]bool)
+	for _, rule := range result.Coverage {
+		coverage[rule.Rule] = rule.Fired > 0
+	}
+	if len(coverage) != 3 || !coverage["flag"] || !coverage["publish"] || coverage["cancelled"] {
+		t.Errorf("coverage = %+v, want flag and publish fired, cancelled not", result.Coverage)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	suite, err := ParseFile(writeSuite(t))
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, []*SuiteResult{RunSuite(context.Background(), suite)}); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if report.Tests != 2 || report.Failures != 1 || len(report.Suites) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	cases := report.Suites[0].Cases
+	if cases[0].Failure != nil || cases[1].Failure == nil || !strings.Contains(cases[1].Failure.Body, "obtenu") {
+		t.Errorf("cases = %+v", cases)
+	}
+}
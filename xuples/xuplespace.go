@@ -6,12 +6,21 @@ package xuples
 
 import "sync"
 
+// XupleSpaceStats contient les compteurs d'activité d'un xuple-space.
+type XupleSpaceStats struct {
+	Available int    // Xuples disponibles (profondeur)
+	Inserted  uint64 // Xuples insérés depuis la création
+	Consumed  uint64 // Consommations (une par agent et par xuple)
+}
+
 // DefaultXupleSpace implémente XupleSpace.
 type DefaultXupleSpace struct {
-	name   string
-	config XupleSpaceConfig
-	xuples map[string]*Xuple // xupleID -> Xuple
-	mu     sync.RWMutex
+	name     string
+	config   XupleSpaceConfig
+	xuples   map[string]*Xuple // xupleID -> Xuple
+	inserted uint64
+	consumed uint64
+	mu       sync.RWMutex
 }
 
 // NewXupleSpace crée un nouveau xuple-space.
@@ -63,6 +72,7 @@ func (xs *DefaultXupleSpace) Insert(xuple *Xuple) error {
 	xuple.Metadata.ExpiresAt = xs.config.RetentionPolicy.ComputeExpiration(xuple.CreatedAt)
 
 	xs.xuples[xuple.ID] = xuple
+	xs.inserted++
 	return nil
 }
 
@@ -121,6 +131,7 @@ func (xs *DefaultXupleSpace) Retrieve(agentID string) (*Xuple, error) {
 	// Cela évite que l'appelant oublie d'appeler MarkConsumed() et garantit
 	// que la politique de consommation 'once' fonctionne correctement
 	selected.markConsumedBy(agentID)
+	xs.consumed++
 
 	// Vérifier si le xuple doit être marqué comme complètement consommé
 	if xs.config.ConsumptionPolicy.OnConsumed(selected, agentID) {
@@ -216,6 +227,7 @@ func (xs *DefaultXupleSpace) RetrieveMultiple(agentID string, n int) ([]*Xuple,
 
 		// Marquer comme consommé
 		xuple.markConsumedBy(agentID)
+		xs.consumed++
 
 		// Vérifier si le xuple doit être marqué comme complètement consommé
 		if xs.config.ConsumptionPolicy.OnConsumed(xuple, agentID) {
@@ -257,6 +269,7 @@ func (xs *DefaultXupleSpace) MarkConsumed(xupleID string, agentID string) error
 
 	// Marquer comme consommé (thread-safe car nous avons le lock)
 	xuple.markConsumedBy(agentID)
+	xs.consumed++
 
 	// Vérifier si le xuple doit être marqué comme complètement consommé
 	if xs.config.ConsumptionPolicy.OnConsumed(xuple, agentID) {
@@ -281,6 +294,20 @@ func (xs *DefaultXupleSpace) Count() int {
 	return count
 }
 
+// Stats retourne la profondeur et les compteurs d'insertion et de consommation.
+func (xs *DefaultXupleSpace) Stats() XupleSpaceStats {
+	available := xs.Count()
+
+	xs.mu.RLock()
+	defer xs.mu.RUnlock()
+
+	return XupleSpaceStats{
+		Available: available,
+		Inserted:  xs.inserted,
+		Consumed:  xs.consumed,
+	}
+}
+
 // Cleanup nettoie les xuples expirés.
 func (xs *DefaultXupleSpace) Cleanup() int {
 	xs.mu.Lock()
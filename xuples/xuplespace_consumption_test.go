@@ -472,3 +472,40 @@ func TestMultipleXuplesWithOncePolicy(t *testing.T) {
 	t.Log("✅ TEST PLUSIEURS XUPLES RÉUSSI!")
 	t.Log("═══════════════════════════════════════════════════════════════")
 }
+
+// TestXupleSpaceStats vérifie les compteurs d'insertion et de consommation
+func TestXupleSpaceStats(t *testing.T) {
+	space := NewXupleSpace(XupleSpaceConfig{
+		Name:              "stats",
+		SelectionPolicy:   NewFIFOSelectionPolicy(),
+		ConsumptionPolicy: NewOnceConsumptionPolicy(),
+		RetentionPolicy:   NewDurationRetentionPolicy(time.Hour),
+	}).(*DefaultXupleSpace)
+
+	for i := 0; i < 3; i++ {
+		xuple := &Xuple{
+			ID:        fmt.Sprintf("xuple-stats-%d", i),
+			Fact:      &rete.Fact{Type: "TestFact", Fields: map[string]interface{}{"id": i}},
+			CreatedAt: time.Now(),
+			Metadata: XupleMetadata{
+				State:      XupleStateAvailable,
+				ConsumedBy: make(map[string]time.Time),
+			},
+		}
+		if err := space.Insert(xuple); err != nil {
+			t.Fatalf("❌ Erreur Insert: %v", err)
+		}
+	}
+
+	if _, err := space.Retrieve("agent1"); err != nil {
+		t.Fatalf("❌ Erreur Retrieve: %v", err)
+	}
+	if _, err := space.RetrieveMultiple("agent1", 1); err != nil {
+		t.Fatalf("❌ Erreur RetrieveMultiple: %v", err)
+	}
+
+	stats := space.Stats()
+	if stats.Inserted != 3 || stats.Consumed != 2 || stats.Available != 1 {
+		t.Errorf("❌ Stats = %+v, attendu {Available:1 Inserted:3 Consumed:2}", stats)
+	}
+}